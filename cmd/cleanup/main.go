@@ -0,0 +1,109 @@
+// Command cleanup deletes the admin API endpoint of an APIScheme and the cloud
+// resources behind it, the way the operator's finalizer does, then removes the
+// finalizer. It's for when the operator can't, eg it's been uninstalled or its
+// credentials are broken. Run it with a kubeconfig for the cluster:
+//
+//	go run ./cmd/cleanup --name rh-api
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	operatorconfig "github.com/openshift/cloud-ingress-operator/config"
+	"github.com/openshift/cloud-ingress-operator/pkg/apis"
+	cloudingressv1alpha1 "github.com/openshift/cloud-ingress-operator/pkg/apis/cloudingress/v1alpha1"
+	"github.com/openshift/cloud-ingress-operator/pkg/cloudclient"
+	"github.com/openshift/cloud-ingress-operator/pkg/controller/apischeme"
+	cioerrors "github.com/openshift/cloud-ingress-operator/pkg/errors"
+	baseutils "github.com/openshift/cloud-ingress-operator/pkg/utils"
+
+	configv1 "github.com/openshift/api/config/v1"
+	machineapi "github.com/openshift/machine-api-operator/pkg/apis/machine/v1beta1"
+
+	"github.com/operator-framework/operator-sdk/pkg/log/zap"
+	"github.com/spf13/pflag"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/config"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// apiSchemeFinalizer is the finalizer the APIScheme controller adds
+const apiSchemeFinalizer = "dns.cloudingress.managed.openshift.io"
+
+func main() {
+	pflag.CommandLine.AddFlagSet(zap.FlagSet())
+	name := pflag.String("name", operatorconfig.AdminAPIName,
+		"Name of the APIScheme to clean up")
+	namespace := pflag.String("namespace", operatorconfig.OperatorNamespace,
+		"Namespace of the APIScheme to clean up")
+	timeout := pflag.Duration("timeout", 10*time.Minute,
+		"How long to wait for AWS to delete the load balancer and release the security group")
+	pflag.Parse()
+	logf.SetLogger(zap.Logger())
+
+	if err := run(*name, *namespace, *timeout); err != nil {
+		fmt.Fprintf(os.Stderr, "Couldn't clean up the APIScheme %s/%s: %v\n", *namespace, *name, err)
+		os.Exit(1)
+	}
+	fmt.Printf("Cleaned up the APIScheme %s/%s\n", *namespace, *name)
+}
+
+// run destroys the admin API endpoint of the APIScheme namespace/name, trying
+// again until AWS has caught up or timeout passes, then removes its finalizer
+func run(name, namespace string, timeout time.Duration) error {
+	cfg, err := config.GetConfig()
+	if err != nil {
+		return err
+	}
+	s := scheme.Scheme
+	for _, addToScheme := range []func(*runtime.Scheme) error{apis.AddToScheme, configv1.AddToScheme, machineapi.AddToScheme} {
+		if err := addToScheme(s); err != nil {
+			return err
+		}
+	}
+	kclient, err := client.New(cfg, client.Options{Scheme: s})
+	if err != nil {
+		return err
+	}
+
+	ctx := context.TODO()
+	instance := &cloudingressv1alpha1.APIScheme{}
+	err = kclient.Get(ctx, types.NamespacedName{Name: name, Namespace: namespace}, instance)
+	if err != nil {
+		return err
+	}
+	cloudPlatform, err := baseutils.GetPlatformType(kclient)
+	if err != nil {
+		return err
+	}
+	cli := cloudclient.GetClientFor(kclient, *cloudPlatform)
+
+	err = wait.PollImmediate(10*time.Second, timeout, func() (bool, error) {
+		err := apischeme.Destroy(ctx, kclient, cli, instance)
+		switch err.(type) {
+		case nil:
+			return true, nil
+		case *cioerrors.LoadBalancerNotReadyError, *cioerrors.ElasticIPInUseError, *cioerrors.SecurityGroupInUseError:
+			return false, nil
+		default:
+			return false, err
+		}
+	})
+	if err != nil {
+		return err
+	}
+
+	if controllerutil.ContainsFinalizer(instance, apiSchemeFinalizer) {
+		controllerutil.RemoveFinalizer(instance, apiSchemeFinalizer)
+		return kclient.Update(ctx, instance)
+	}
+	return nil
+}
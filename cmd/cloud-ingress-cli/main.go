@@ -0,0 +1,224 @@
+// Command cloud-ingress-cli runs one of the operator's cloud operations
+// against a cluster, for break-glass scenarios when the operator is down. It
+// uses the same cloud clients and credentials as the operator, and a
+// kubeconfig for the cluster:
+//
+//	go run ./cmd/cloud-ingress-cli list-registered-instances
+//
+// The operations are:
+//
+//	ensure-elb                 repair the load balancer of the admin API
+//	toggle-private             make the default API private, or public with --private=false
+//	list-registered-instances  list the instances behind the admin API load balancer
+//	verify-dns                 check the DNS records of the admin API, without changing them
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	operatorconfig "github.com/openshift/cloud-ingress-operator/config"
+	"github.com/openshift/cloud-ingress-operator/pkg/apis"
+	cloudingressv1alpha1 "github.com/openshift/cloud-ingress-operator/pkg/apis/cloudingress/v1alpha1"
+	"github.com/openshift/cloud-ingress-operator/pkg/cloudclient"
+	baseutils "github.com/openshift/cloud-ingress-operator/pkg/utils"
+
+	configv1 "github.com/openshift/api/config/v1"
+	machineapi "github.com/openshift/machine-api-operator/pkg/apis/machine/v1beta1"
+
+	"github.com/operator-framework/operator-sdk/pkg/log/zap"
+	"github.com/spf13/pflag"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/config"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// cli holds what every operation needs
+type cli struct {
+	kclient   client.Client
+	cloud     cloudclient.CloudClient
+	namespace string
+}
+
+func main() {
+	pflag.CommandLine.AddFlagSet(zap.FlagSet())
+	namespace := pflag.String("namespace", operatorconfig.OperatorNamespace,
+		"Namespace of the operator's custom resources")
+	apiSchemeName := pflag.String("apischeme", operatorconfig.AdminAPIName,
+		"Name of the APIScheme, for the admin API operations")
+	publishingStrategyName := pflag.String("publishingstrategy", "publishingstrategy",
+		"Name of the PublishingStrategy, for toggle-private")
+	private := pflag.Bool("private", true,
+		"Whether toggle-private makes the default API private or public")
+	pflag.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: %s [flags] ensure-elb|toggle-private|list-registered-instances|verify-dns\n", os.Args[0])
+		pflag.PrintDefaults()
+	}
+	pflag.Parse()
+	logf.SetLogger(zap.Logger())
+
+	if pflag.NArg() != 1 {
+		pflag.Usage()
+		os.Exit(2)
+	}
+	c, err := newCLI(*namespace)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Couldn't connect to the cluster: %v\n", err)
+		os.Exit(1)
+	}
+
+	ctx := context.TODO()
+	operation := pflag.Arg(0)
+	switch operation {
+	case "ensure-elb":
+		err = c.ensureELB(ctx, *apiSchemeName)
+	case "toggle-private":
+		err = c.togglePrivate(ctx, *publishingStrategyName, *private)
+	case "list-registered-instances":
+		err = c.listRegisteredInstances(ctx, *apiSchemeName)
+	case "verify-dns":
+		err = c.verifyDNS(ctx, *apiSchemeName)
+	default:
+		pflag.Usage()
+		os.Exit(2)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s failed: %v\n", operation, err)
+		os.Exit(1)
+	}
+}
+
+// newCLI connects to the cluster of the current kubeconfig, and to its cloud
+// with the operator's credentials
+func newCLI(namespace string) (*cli, error) {
+	cfg, err := config.GetConfig()
+	if err != nil {
+		return nil, err
+	}
+	s := scheme.Scheme
+	for _, addToScheme := range []func(*runtime.Scheme) error{apis.AddToScheme, configv1.AddToScheme, machineapi.AddToScheme} {
+		if err := addToScheme(s); err != nil {
+			return nil, err
+		}
+	}
+	kclient, err := client.New(cfg, client.Options{Scheme: s})
+	if err != nil {
+		return nil, err
+	}
+	cloudPlatform, err := baseutils.GetPlatformType(kclient)
+	if err != nil {
+		return nil, err
+	}
+	return &cli{
+		kclient:   kclient,
+		cloud:     cloudclient.GetClientFor(kclient, *cloudPlatform),
+		namespace: namespace,
+	}, nil
+}
+
+// getAdminAPI returns the APIScheme called name and its admin API Service
+func (c *cli) getAdminAPI(ctx context.Context, name string) (*cloudingressv1alpha1.APIScheme, *corev1.Service, error) {
+	instance := &cloudingressv1alpha1.APIScheme{}
+	err := c.kclient.Get(ctx, types.NamespacedName{Name: name, Namespace: c.namespace}, instance)
+	if err != nil {
+		return nil, nil, err
+	}
+	svc := &corev1.Service{}
+	err = c.kclient.Get(ctx, types.NamespacedName{Name: instance.Spec.ManagementAPIServerIngress.DNSName, Namespace: "openshift-kube-apiserver"}, svc)
+	if err != nil {
+		return nil, nil, err
+	}
+	return instance, svc, nil
+}
+
+// ensureELB repairs the listeners, health check, instances, security groups,
+// subnets and DNS records of the admin API load balancer, as the operator does
+// when they drift
+func (c *cli) ensureELB(ctx context.Context, name string) error {
+	instance, svc, err := c.getAdminAPI(ctx, name)
+	if err != nil {
+		return err
+	}
+	repaired, err := c.cloud.RepairAdminAPIDrift(ctx, c.kclient, instance, svc)
+	if err != nil {
+		return err
+	}
+	if len(repaired) == 0 {
+		fmt.Println("Nothing had drifted")
+		return nil
+	}
+	fmt.Printf("Repaired: %s\n", strings.Join(repaired, ", "))
+	return nil
+}
+
+// togglePrivate makes the default API private or public, whatever the
+// PublishingStrategy says. The operator puts it back as the PublishingStrategy
+// says once it runs again
+func (c *cli) togglePrivate(ctx context.Context, name string, private bool) error {
+	instance := &cloudingressv1alpha1.PublishingStrategy{}
+	err := c.kclient.Get(ctx, types.NamespacedName{Name: name, Namespace: c.namespace}, instance)
+	if err != nil {
+		return err
+	}
+	if private {
+		err = c.cloud.SetDefaultAPIPrivate(ctx, c.kclient, instance)
+	} else {
+		err = c.cloud.SetDefaultAPIPublic(ctx, c.kclient, instance)
+	}
+	if err != nil {
+		return err
+	}
+	fmt.Printf("The default API is now private=%t\n", private)
+	return nil
+}
+
+// listRegisteredInstances prints the instances registered with the admin API
+// load balancer, and whether they pass its health checks
+func (c *cli) listRegisteredInstances(ctx context.Context, name string) error {
+	instance, svc, err := c.getAdminAPI(ctx, name)
+	if err != nil {
+		return err
+	}
+	registered, err := c.cloud.GetAdminAPIRegisteredInstances(ctx, c.kclient, instance, svc)
+	if err != nil {
+		return err
+	}
+	healthy, err := c.cloud.GetAdminAPIHealthyInstances(ctx, c.kclient, instance, svc)
+	if err != nil {
+		return err
+	}
+	isHealthy := map[string]bool{}
+	for _, id := range healthy {
+		isHealthy[id] = true
+	}
+	for _, id := range registered {
+		fmt.Printf("%s\thealthy=%t\n", id, isHealthy[id])
+	}
+	return nil
+}
+
+// verifyDNS checks the DNS records of the admin API point at its load
+// balancer, without changing anything. Fails if they don't
+func (c *cli) verifyDNS(ctx context.Context, name string) error {
+	instance, svc, err := c.getAdminAPI(ctx, name)
+	if err != nil {
+		return err
+	}
+	drifted, err := c.cloud.CheckAdminAPIDrift(ctx, c.kclient, instance, svc)
+	if err != nil {
+		return err
+	}
+	for _, component := range drifted {
+		if component == "dns" {
+			return fmt.Errorf("the DNS records of %s don't point at its load balancer", instance.Spec.ManagementAPIServerIngress.DNSName)
+		}
+	}
+	fmt.Printf("The DNS records of %s point at its load balancer\n", instance.Spec.ManagementAPIServerIngress.DNSName)
+	return nil
+}
@@ -8,10 +8,20 @@ import (
 	"os"
 	"runtime"
 	"strings"
+	// The time zones of public access schedules don't depend on the image
+	_ "time/tzdata"
 
 	operatorconfig "github.com/openshift/cloud-ingress-operator/config"
 	"github.com/openshift/cloud-ingress-operator/pkg/apis"
+	cloudingressv1alpha1 "github.com/openshift/cloud-ingress-operator/pkg/apis/cloudingress/v1alpha1"
+	"github.com/openshift/cloud-ingress-operator/pkg/cloudclient"
 	"github.com/openshift/cloud-ingress-operator/pkg/controller"
+	"github.com/openshift/cloud-ingress-operator/pkg/controller/apischeme"
+	"github.com/openshift/cloud-ingress-operator/pkg/controller/publishingstrategy"
+	"github.com/openshift/cloud-ingress-operator/pkg/controller/routerservice"
+	"github.com/openshift/cloud-ingress-operator/pkg/controller/sshd"
+	"github.com/openshift/cloud-ingress-operator/pkg/migrations"
+	baseutils "github.com/openshift/cloud-ingress-operator/pkg/utils"
 	"github.com/openshift/cloud-ingress-operator/version"
 
 	configv1 "github.com/openshift/api/config/v1"
@@ -20,13 +30,15 @@ import (
 
 	monitoringv1 "github.com/coreos/prometheus-operator/pkg/apis/monitoring/v1"
 	"github.com/operator-framework/operator-sdk/pkg/k8sutil"
-	"github.com/operator-framework/operator-sdk/pkg/leader"
 	"github.com/operator-framework/operator-sdk/pkg/log/zap"
 	sdkVersion "github.com/operator-framework/operator-sdk/version"
 	"github.com/spf13/pflag"
+	"k8s.io/client-go/tools/leaderelection/resourcelock"
 	awsproviderapi "sigs.k8s.io/cluster-api-provider-aws/pkg/apis/awsproviderconfig/v1beta1"
 	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/config"
+	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 	"sigs.k8s.io/controller-runtime/pkg/manager/signals"
@@ -59,6 +71,17 @@ func main() {
 	// controller-runtime)
 	pflag.CommandLine.AddGoFlagSet(flag.CommandLine)
 
+	resyncPeriod := pflag.Duration("resync-period", operatorconfig.DefaultResyncPeriod,
+		"How often to reconcile everything again and repair drift in the cloud resources")
+	leaderElect := pflag.Bool("leader-elect", true,
+		"Only let the replica holding the leader election lease reconcile, so several can run")
+	maxConcurrentReconciles := pflag.Int("max-concurrent-reconciles", 1,
+		"How many objects each controller reconciles at once")
+	healthProbeBindAddress := pflag.String("health-probe-bind-address", ":8081",
+		"The address /healthz and /readyz, which also check the cloud credentials, are served on")
+	pprofBindAddress := pflag.String("pprof-bind-address", "",
+		"The address Go profiles and execution traces are served on under /debug/pprof, disabled if empty")
+
 	pflag.Parse()
 
 	// Use a zap logr.Logger implementation. If none of the zap
@@ -71,6 +94,11 @@ func main() {
 	// uniform and structured logs.
 	logf.SetLogger(zap.Logger())
 
+	// verify reports drift once and exits, instead of running the operator
+	if pflag.Arg(0) == "verify" {
+		os.Exit(verify(context.TODO()))
+	}
+
 	printVersion()
 
 	namespace, err := k8sutil.GetWatchNamespace()
@@ -78,6 +106,11 @@ func main() {
 		log.Error(err, "Failed to get watch namespace")
 		os.Exit(1)
 	}
+	// The credentials and the trusted CA bundle are in the namespace the
+	// operator is deployed in
+	if operatorNs, err := k8sutil.GetOperatorNamespace(); err == nil {
+		operatorconfig.OperatorNamespace = operatorNs
+	}
 
 	// Get a config to talk to the apiserver
 	cfg, err := config.GetConfig()
@@ -86,16 +119,51 @@ func main() {
 		os.Exit(1)
 	}
 	ctx := context.TODO()
-	// Become the leader before proceeding
-	err = leader.Become(ctx, "cloud-ingress-operator-lock")
+
+	// The manager's client isn't running yet, so read the CloudIngressConfig
+	// directly. Its reconcile interval takes precedence over the flag
+	directClient, err := client.New(cfg, client.Options{})
 	if err != nil {
 		log.Error(err, "")
 		os.Exit(1)
 	}
+	// The custom resources are migrated before anything reads them
+	if err := migrations.Run(ctx, directClient, migrations.Migrations); err != nil {
+		log.Error(err, "Failed to migrate the custom resources")
+		os.Exit(1)
+	}
+	ingressConfig, err := baseutils.GetCloudIngressConfig(directClient)
+	if err != nil {
+		log.Error(err, "Failed to get the CloudIngressConfig")
+		os.Exit(1)
+	}
+	if ingressConfig.Spec.ReconcileInterval != nil {
+		*resyncPeriod = ingressConfig.Spec.ReconcileInterval.Duration
+	}
+	namespace = watchNamespaces(namespace, ingressConfig.Spec.WatchNamespaces)
+	log.Info("Watching namespaces", "namespaces", namespace)
 
 	options := manager.Options{
-		Namespace: namespace,
+		Namespace:              namespace,
+		SyncPeriod:             resyncPeriod,
+		HealthProbeBindAddress: *healthProbeBindAddress,
+	}
+	if *leaderElect {
+		// The lease lets a standby replica take over as soon as the leader
+		// stops, and the leader gives it up when it's told to stop, so an
+		// upgrade doesn't pause reconciliation for long
+		options.LeaderElection = true
+		options.LeaderElectionID = operatorconfig.LeaderElectionID
+		options.LeaderElectionResourceLock = resourcelock.LeasesResourceLock
+		options.LeaderElectionReleaseOnCancel = true
+		if _, err := k8sutil.GetOperatorNamespace(); errors.Is(err, k8sutil.ErrRunLocal) {
+			log.Info("Skipping leader election; not running in a cluster.")
+			options.LeaderElection = false
+		}
 	}
+	apischeme.RequeueIntervals.DriftScan = *resyncPeriod
+	setMaxConcurrentReconciles(*maxConcurrentReconciles, ingressConfig.Spec.MaxConcurrentReconciles)
+	setRequeueIntervals(ingressConfig.Spec.RequeueIntervals)
 
 	// Add support for MultiNamespace set in WATCH_NAMESPACE (e.g ns1,ns2)
 	// Note that this is not intended to be used for excluding namespaces, this is better done via a Predicate
@@ -148,6 +216,30 @@ func main() {
 		os.Exit(1)
 	}
 
+	// The operator is only ready while its cloud credentials work, and is
+	// restarted once they have failed for long
+	healthChecker := cloudclient.NewHealthChecker(mgr.GetClient())
+	if err := mgr.AddHealthzCheck("ping", healthz.Ping); err != nil {
+		log.Error(err, "")
+		os.Exit(1)
+	}
+	if err := mgr.AddHealthzCheck("cloud-credentials", healthChecker.Live); err != nil {
+		log.Error(err, "")
+		os.Exit(1)
+	}
+	if err := mgr.AddReadyzCheck("cloud-credentials", healthChecker.Ready); err != nil {
+		log.Error(err, "")
+		os.Exit(1)
+	}
+
+	if *pprofBindAddress != "" {
+		if err := mgr.Add(pprofServer{addr: *pprofBindAddress}); err != nil {
+			log.Error(err, "")
+			os.Exit(1)
+		}
+		log.Info("Serving profiles", "address", *pprofBindAddress)
+	}
+
 	addMetrics(ctx)
 
 	log.Info("Starting the Cmd.")
@@ -182,3 +274,66 @@ func addMetrics(ctx context.Context) {
 		log.Error(err, "Failed to configure OSD metrics")
 	}
 }
+
+// watchNamespaces returns the namespaces the manager watches: those of
+// watchNamespace, the WATCH_NAMESPACE list, plus the operator's own and extra,
+// from the CloudIngressConfig. An empty watchNamespace already covers every
+// namespace
+func watchNamespaces(watchNamespace string, extra []string) string {
+	if watchNamespace == "" {
+		return ""
+	}
+	namespaces := strings.Split(watchNamespace, ",")
+	for _, namespace := range append([]string{operatorconfig.OperatorNamespace}, extra...) {
+		watched := false
+		for _, existing := range namespaces {
+			if existing == namespace {
+				watched = true
+				break
+			}
+		}
+		if !watched && namespace != "" {
+			namespaces = append(namespaces, namespace)
+		}
+	}
+	return strings.Join(namespaces, ",")
+}
+
+// setMaxConcurrentReconciles sets how many objects each controller reconciles
+// at once: defaultValue, unless overrides, from the CloudIngressConfig, say
+// otherwise
+func setMaxConcurrentReconciles(defaultValue int, overrides *cloudingressv1alpha1.MaxConcurrentReconciles) {
+	if overrides == nil {
+		overrides = &cloudingressv1alpha1.MaxConcurrentReconciles{}
+	}
+	valueOrDefault := func(value int32) int {
+		if value > 0 {
+			return int(value)
+		}
+		return defaultValue
+	}
+	apischeme.MaxConcurrentReconciles = valueOrDefault(overrides.APIScheme)
+	publishingstrategy.MaxConcurrentReconciles = valueOrDefault(overrides.PublishingStrategy)
+	routerservice.MaxConcurrentReconciles = valueOrDefault(overrides.RouterService)
+	sshd.MaxConcurrentReconciles = valueOrDefault(overrides.SSHD)
+	log.Info("Concurrent reconciles", "apischeme", apischeme.MaxConcurrentReconciles,
+		"publishingstrategy", publishingstrategy.MaxConcurrentReconciles,
+		"routerservice", routerservice.MaxConcurrentReconciles,
+		"sshd", sshd.MaxConcurrentReconciles)
+}
+
+// setRequeueIntervals overrides the requeue intervals of each controller with
+// those set in the CloudIngressConfig
+func setRequeueIntervals(overrides *cloudingressv1alpha1.RequeueIntervals) {
+	if overrides == nil {
+		return
+	}
+	apischeme.RequeueIntervals = apischeme.RequeueIntervals.Override(overrides.APIScheme)
+	publishingstrategy.RequeueIntervals = publishingstrategy.RequeueIntervals.Override(overrides.PublishingStrategy)
+	routerservice.RequeueIntervals = routerservice.RequeueIntervals.Override(overrides.RouterService)
+	sshd.RequeueIntervals = sshd.RequeueIntervals.Override(overrides.SSHD)
+	log.Info("Requeue intervals", "apischeme", apischeme.RequeueIntervals,
+		"publishingstrategy", publishingstrategy.RequeueIntervals,
+		"routerservice", routerservice.RequeueIntervals,
+		"sshd", sshd.RequeueIntervals)
+}
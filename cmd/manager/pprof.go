@@ -0,0 +1,114 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"runtime/pprof"
+	"runtime/trace"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// defaultProfileSeconds is how long CPU profiles and execution traces run
+// unless the request's seconds parameter says otherwise
+const defaultProfileSeconds = 30
+
+// pprofServer serves the Go profiles of the operator on addr, as
+// net/http/pprof does: /debug/pprof/heap, goroutine etc, a CPU profile on
+// /debug/pprof/profile and an execution trace on /debug/pprof/trace.
+// net/http/pprof isn't imported since it would also serve them on the default
+// mux, which the metrics server uses
+type pprofServer struct {
+	addr string
+}
+
+// Start implements manager.Runnable
+func (s pprofServer) Start(ctx context.Context) error {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/debug/pprof/", serveProfile)
+	mux.HandleFunc("/debug/pprof/profile", serveCPUProfile)
+	mux.HandleFunc("/debug/pprof/trace", serveTrace)
+	server := &http.Server{Addr: s.addr, Handler: mux}
+
+	errs := make(chan error, 1)
+	go func() {
+		errs <- server.ListenAndServe()
+	}()
+	select {
+	case err := <-errs:
+		return err
+	case <-ctx.Done():
+		return server.Shutdown(context.Background())
+	}
+}
+
+// NeedLeaderElection implements manager.LeaderElectionRunnable. Standby
+// replicas can be profiled too
+func (s pprofServer) NeedLeaderElection() bool {
+	return false
+}
+
+// serveProfile writes the profile named by the request's path, or the list of
+// profiles for /debug/pprof/. debug=1 writes it as text
+func serveProfile(w http.ResponseWriter, r *http.Request) {
+	name := strings.TrimPrefix(r.URL.Path, "/debug/pprof/")
+	if name == "" {
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		for _, profile := range pprof.Profiles() {
+			fmt.Fprintf(w, "%s %d\n", profile.Name(), profile.Count())
+		}
+		return
+	}
+	profile := pprof.Lookup(name)
+	if profile == nil {
+		http.Error(w, fmt.Sprintf("Unknown profile %s", name), http.StatusNotFound)
+		return
+	}
+	debug, _ := strconv.Atoi(r.FormValue("debug"))
+	if debug != 0 {
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	} else {
+		w.Header().Set("Content-Type", "application/octet-stream")
+	}
+	if err := profile.WriteTo(w, debug); err != nil {
+		log.Error(err, "Couldn't write the profile", "profile", name)
+	}
+}
+
+// serveCPUProfile profiles the CPU for the request's seconds and writes the
+// profile
+func serveCPUProfile(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/octet-stream")
+	if err := pprof.StartCPUProfile(w); err != nil {
+		http.Error(w, fmt.Sprintf("Couldn't start the CPU profile: %v", err), http.StatusInternalServerError)
+		return
+	}
+	sleepFor(r)
+	pprof.StopCPUProfile()
+}
+
+// serveTrace traces the execution for the request's seconds and writes the
+// trace
+func serveTrace(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/octet-stream")
+	if err := trace.Start(w); err != nil {
+		http.Error(w, fmt.Sprintf("Couldn't start the trace: %v", err), http.StatusInternalServerError)
+		return
+	}
+	sleepFor(r)
+	trace.Stop()
+}
+
+// sleepFor sleeps for the request's seconds, or until the request is cancelled
+func sleepFor(r *http.Request) {
+	seconds, err := strconv.Atoi(r.FormValue("seconds"))
+	if err != nil || seconds <= 0 {
+		seconds = defaultProfileSeconds
+	}
+	select {
+	case <-time.After(time.Duration(seconds) * time.Second):
+	case <-r.Context().Done():
+	}
+}
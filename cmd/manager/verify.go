@@ -0,0 +1,114 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/openshift/cloud-ingress-operator/pkg/apis"
+	cloudingressv1alpha1 "github.com/openshift/cloud-ingress-operator/pkg/apis/cloudingress/v1alpha1"
+	"github.com/openshift/cloud-ingress-operator/pkg/cloudclient"
+	"github.com/openshift/cloud-ingress-operator/pkg/controller/apischeme"
+	"github.com/openshift/cloud-ingress-operator/pkg/controller/publishingstrategy"
+	baseutils "github.com/openshift/cloud-ingress-operator/pkg/utils"
+
+	configv1 "github.com/openshift/api/config/v1"
+	operatorv1 "github.com/openshift/api/operator/v1"
+	machineapi "github.com/openshift/machine-api-operator/pkg/apis/machine/v1beta1"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/config"
+)
+
+// Exit codes of the verify command
+const (
+	verifyInSync  = 0
+	verifyDrifted = 1
+	verifyFailed  = 2
+)
+
+// verify runs the diff logic of the APIScheme and PublishingStrategy
+// controllers once against the cluster of the current kubeconfig, without
+// changing anything, and prints what reconciling each of them would change.
+// It returns verifyDrifted if anything would, and verifyFailed if something
+// couldn't be verified
+func verify(ctx context.Context) int {
+	kclient, cloud, err := newVerifyClients()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Couldn't connect to the cluster: %v\n", err)
+		return verifyFailed
+	}
+
+	code := verifyInSync
+	report := func(kind, name string, changes []string, err error) {
+		switch {
+		case err != nil && len(changes) == 0:
+			fmt.Printf("%s %s: couldn't verify: %v\n", kind, name, err)
+		case len(changes) == 0:
+			fmt.Printf("%s %s: in sync\n", kind, name)
+		default:
+			fmt.Printf("%s %s: %d changes\n", kind, name, len(changes))
+			for _, change := range changes {
+				fmt.Printf("  - %s\n", change)
+			}
+			if err != nil {
+				fmt.Printf("  and couldn't verify the rest: %v\n", err)
+			}
+		}
+		if len(changes) != 0 && code == verifyInSync {
+			code = verifyDrifted
+		}
+		if err != nil {
+			code = verifyFailed
+		}
+	}
+
+	apiSchemes := &cloudingressv1alpha1.APISchemeList{}
+	if err := kclient.List(ctx, apiSchemes); err != nil {
+		fmt.Fprintf(os.Stderr, "Couldn't list the APISchemes: %v\n", err)
+		return verifyFailed
+	}
+	for i := range apiSchemes.Items {
+		instance := &apiSchemes.Items[i]
+		changes, err := apischeme.Verify(ctx, kclient, cloud, instance)
+		report("APIScheme", instance.Namespace+"/"+instance.Name, changes, err)
+	}
+
+	publishingStrategies := &cloudingressv1alpha1.PublishingStrategyList{}
+	if err := kclient.List(ctx, publishingStrategies); err != nil {
+		fmt.Fprintf(os.Stderr, "Couldn't list the PublishingStrategies: %v\n", err)
+		return verifyFailed
+	}
+	for i := range publishingStrategies.Items {
+		instance := &publishingStrategies.Items[i]
+		changes, err := publishingstrategy.Verify(kclient, instance)
+		report("PublishingStrategy", instance.Namespace+"/"+instance.Name, changes, err)
+	}
+	return code
+}
+
+// newVerifyClients connects to the cluster of the current kubeconfig, and to
+// its cloud with the operator's credentials
+func newVerifyClients() (client.Client, cloudclient.CloudClient, error) {
+	cfg, err := config.GetConfig()
+	if err != nil {
+		return nil, nil, err
+	}
+	s := scheme.Scheme
+	for _, addToScheme := range []func(*runtime.Scheme) error{apis.AddToScheme, configv1.AddToScheme, machineapi.AddToScheme, operatorv1.AddToScheme} {
+		if err := addToScheme(s); err != nil {
+			return nil, nil, err
+		}
+	}
+	kclient, err := client.New(cfg, client.Options{Scheme: s})
+	if err != nil {
+		return nil, nil, err
+	}
+	cloudPlatform, err := baseutils.GetPlatformType(kclient)
+	if err != nil {
+		return nil, nil, err
+	}
+	return kclient, cloudclient.GetClientFor(kclient, *cloudPlatform), nil
+}
@@ -1,5 +1,7 @@
 package config
 
+import "time"
+
 const (
 	// AdminAPIName is the name of the API endpoint for non-customer use (eg Hive)
 	AdminAPIName string = "rh-api"
@@ -40,7 +42,8 @@ const (
 	// availability zone, networking information, base domain, cluster name and more
 	KubeConfigConfigMapName string = "cluster-config-v1"
 
-	// AdminAPIListenerPort
+	// AdminAPIListenerPort is the port the API server listens on, unless the
+	// cluster's KubeAPIServer config says otherwise
 	AdminAPIListenerPort int64 = 6443
 
 	// MaxAPIRetries
@@ -52,6 +55,36 @@ const (
 	// GCPSecretName
 	GCPSecretName string = "cloud-ingress-operator-credentials-gcp"
 
-	// OperatorNamespace
-	OperatorNamespace string = "openshift-cloud-ingress-operator"
+	// TrustedCABundleConfigMapName is the ConfigMap in OperatorNamespace the
+	// Cluster Network Operator injects the cluster's trusted CA bundle in
+	TrustedCABundleConfigMapName string = "cloud-ingress-operator-trusted-ca"
+
+	// LeaderElectionID is the name of the Lease the operator replicas compete
+	// for. Only the holder reconciles
+	LeaderElectionID string = "cloud-ingress-operator-lock"
+
+	// CloudIngressConfigName is the name of the cluster-scoped
+	// CloudIngressConfig holding the operator-wide settings
+	CloudIngressConfigName string = "cluster"
+
+	// MigrationsConfigMapName is the ConfigMap in OperatorNamespace recording
+	// the version of the last migration of the custom resources applied
+	MigrationsConfigMapName string = "cloud-ingress-operator-migrations"
+
+	// ResourceManifestConfigMapName is the ConfigMap in OperatorNamespace
+	// recording the cloud resources created for each APIScheme. Unlike the
+	// APISchemes' status, it survives them being recreated
+	ResourceManifestConfigMapName string = "cloud-ingress-operator-resources"
+
+	// DefaultResyncPeriod is how often every watched object is reconciled again,
+	// and how often the cloud resources behind them are re-verified for drift
+	DefaultResyncPeriod time.Duration = 10 * time.Minute
+
+	// DefaultCloudAPICallTimeout is how long each cloud API call may take
+	// before it's abandoned
+	DefaultCloudAPICallTimeout time.Duration = time.Minute
 )
+
+// OperatorNamespace is where the operator runs and finds its credentials. The
+// operator sets it to the namespace it's deployed in when it starts
+var OperatorNamespace = "openshift-cloud-ingress-operator"
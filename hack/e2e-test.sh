@@ -0,0 +1,18 @@
+#!/usr/bin/env bash
+#
+# Runs the end-to-end tests in test/e2e against the cluster of KUBECONFIG,
+# which must have the operator deployed and its cloud credentials in place.
+# E2E_DISRUPTIVE=true also runs the scenarios that can cut the tests off from
+# the cluster, like making the default API internal.
+
+set -euo pipefail
+
+REPO_ROOT=$(git rev-parse --show-toplevel)
+
+if [[ -z "${KUBECONFIG:-}" && ! -f "${HOME}/.kube/config" ]]; then
+    echo "Set KUBECONFIG to the cluster to test" >&2
+    exit 1
+fi
+
+cd "${REPO_ROOT}"
+go test -tags e2e -count=1 -timeout 2h -v ./test/e2e/...
@@ -0,0 +1,34 @@
+#!/usr/bin/env bash
+#
+# Runs the integration tests in test/integration against envtest and a
+# localstack container. LOCALSTACK_ENDPOINT points them at a localstack that's
+# already running instead, and KUBEBUILDER_ASSETS at envtest binaries that are
+# already installed.
+
+set -euo pipefail
+
+REPO_ROOT=$(git rev-parse --show-toplevel)
+ENVTEST_K8S_VERSION=${ENVTEST_K8S_VERSION:-1.19.2}
+CONTAINER_ENGINE=${CONTAINER_ENGINE:-$(command -v podman || command -v docker)}
+
+if [[ -z "${KUBEBUILDER_ASSETS:-}" ]]; then
+    ASSETS_DIR="${REPO_ROOT}/.envtest/${ENVTEST_K8S_VERSION}"
+    if [[ ! -x "${ASSETS_DIR}/bin/kube-apiserver" ]]; then
+        mkdir -p "${ASSETS_DIR}"
+        curl -sSLf "https://storage.googleapis.com/kubebuilder-tools/kubebuilder-tools-${ENVTEST_K8S_VERSION}-$(go env GOOS)-$(go env GOARCH).tar.gz" |
+            tar -xz -C "${ASSETS_DIR}" --strip-components=1
+    fi
+    export KUBEBUILDER_ASSETS="${ASSETS_DIR}/bin"
+fi
+
+if [[ -z "${LOCALSTACK_ENDPOINT:-}" ]]; then
+    CONTAINER=$(${CONTAINER_ENGINE} run -d --rm -p 4566:4566 -e SERVICES=ec2,elb,elbv2,route53,cloudwatch localstack/localstack:0.12.11)
+    trap '${CONTAINER_ENGINE} stop ${CONTAINER} >/dev/null' EXIT
+    export LOCALSTACK_ENDPOINT=http://localhost:4566
+    until curl -sf "${LOCALSTACK_ENDPOINT}/health" | grep -Eq '"ec2": ?"(running|available)"'; do
+        sleep 2
+    done
+fi
+
+cd "${REPO_ROOT}"
+go test -tags integration -count=1 -v ./test/integration/...
@@ -0,0 +1,26 @@
+#!/usr/bin/env bash
+#
+# Regenerates the typed clientset, listers and informers in pkg/client from the
+# +genclient types in pkg/apis. The generators are built from a checkout of
+# k8s.io/code-generator outside the module, so go.mod isn't touched.
+
+set -euo pipefail
+
+REPO_ROOT=$(git rev-parse --show-toplevel)
+CODEGEN_VERSION=${CODEGEN_VERSION:-v0.19.2}
+MODULE=github.com/openshift/cloud-ingress-operator
+
+WORK_DIR=$(mktemp -d)
+trap 'rm -rf "${WORK_DIR}"' EXIT
+
+git clone -q --depth 1 --branch "${CODEGEN_VERSION}" https://github.com/kubernetes/code-generator.git "${WORK_DIR}/code-generator"
+
+cd "${REPO_ROOT}"
+GOBIN="${WORK_DIR}/bin" bash "${WORK_DIR}/code-generator/generate-groups.sh" client,lister,informer \
+    "${MODULE}/pkg/client" "${MODULE}/pkg/apis" \
+    cloudingress:v1alpha1 \
+    --output-base "${WORK_DIR}/out" \
+    --go-header-file /dev/null
+
+rm -rf "${REPO_ROOT}/pkg/client"
+cp -R "${WORK_DIR}/out/${MODULE}/pkg/client" "${REPO_ROOT}/pkg/client"
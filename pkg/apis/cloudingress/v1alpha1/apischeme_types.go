@@ -14,6 +14,149 @@ type APISchemeConditionType string
 const (
 	ConditionError APISchemeConditionType = "Error"
 	ConditionReady APISchemeConditionType = "Ready"
+	// ConditionCleanupPending lists the cloud resources left behind by a
+	// rolled back management API endpoint
+	ConditionCleanupPending APISchemeConditionType = "CleanupPending"
+	// ConditionPaused is set while the operator makes no cloud changes for
+	// the APIScheme, see PausedAnnotation
+	ConditionPaused APISchemeConditionType = "Paused"
+	// ConditionPlanned is set while the APIScheme is in the Plan management
+	// state, with the changes the operator would make in Status.Plan
+	ConditionPlanned APISchemeConditionType = "Planned"
+	// ConditionDegraded is set while the APIScheme's reconciles keep failing,
+	// see ReconcileFailures
+	ConditionDegraded APISchemeConditionType = "Degraded"
+	// ConditionDNSVerified is set once the admin API records are resolved
+	// after a change, if the CloudIngressConfig asks for DNSVerification
+	ConditionDNSVerified APISchemeConditionType = "DNSVerified"
+	// ConditionPreconditionFailed is set while a cloud resource the
+	// APIScheme depends on, like its access log bucket, wouldn't let the
+	// operator's changes work. It's fixed outside the operator
+	ConditionPreconditionFailed APISchemeConditionType = "PreconditionFailed"
+	// ConditionRejected is set on every APIScheme but the one driving the
+	// admin API load balancer, as they would fight over it. The oldest
+	// enabled APIScheme of the cluster drives it
+	ConditionRejected APISchemeConditionType = "Rejected"
+	// ConditionUnsupported is set on every APIScheme of a cluster whose
+	// control plane is hosted outside of it, eg by HyperShift. Its admin API
+	// is managed with the hosted control plane, so the operator leaves it be.
+	// Otherwise it's set while the spec asks for features the cluster's cloud
+	// doesn't have, which are ignored
+	ConditionUnsupported APISchemeConditionType = "Unsupported"
+	// ConditionPreflightFailed is set while the operator's cloud credentials
+	// lack permissions it needs, so no change is attempted until they're
+	// granted. Its message lists the missing permissions
+	ConditionPreflightFailed APISchemeConditionType = "PreflightFailed"
+	// ConditionThrottled is set while the cloud API throttles the operator.
+	// Its message estimates when the operator retries. Meanwhile only spec
+	// changes and deletions are reconciled, and drift scans are put off
+	ConditionThrottled APISchemeConditionType = "Throttled"
+	// ConditionWaitingForDNSZone is set while a freshly installed cluster
+	// doesn't have the DNS zone of the admin API record yet. The APIScheme
+	// only fails once the zone is still missing after DNSZoneGracePeriod
+	ConditionWaitingForDNSZone APISchemeConditionType = "WaitingForDNSZone"
+	// ConditionActionRequired is set while the APIScheme fails in a way
+	// retrying won't fix. Its reason is one of the ActionRequired* codes,
+	// saying who has to act, and its message how to fix it
+	ConditionActionRequired APISchemeConditionType = "ActionRequired"
+	// ConditionSecurityGroupRulesReverted is set while the allowed CIDR
+	// blocks can't all be applied to the admin API security group. The
+	// changes that were applied are reverted, so the group keeps its previous
+	// rules, unless the reason is PartiallyApplied
+	ConditionSecurityGroupRulesReverted APISchemeConditionType = "SecurityGroupRulesReverted"
+	// ConditionQuotaNearExhaustion is set while the cloud's load balancer
+	// quota is nearly used up, as of the last load balancer the operator
+	// created. Its message says how many are left
+	ConditionQuotaNearExhaustion APISchemeConditionType = "QuotaNearExhaustion"
+)
+
+// The reasons of the ActionRequired condition. They're part of the API, alerts
+// are routed on them
+const (
+	// ActionRequiredSecurityGroupRuleLimit is a security group of the admin
+	// API that can't take the rules of its allowed CIDR blocks. The AWS quota
+	// of rules per security group has to be raised, or the blocks merged
+	ActionRequiredSecurityGroupRuleLimit = "SecurityGroupRuleLimitReached"
+	// ActionRequiredDNSZoneMissing is a DNS zone of the admin API record still
+	// missing after DNSZoneGracePeriod. The zone has to be created, or the
+	// base domain of the cluster fixed
+	ActionRequiredDNSZoneMissing = "DNSZoneMissing"
+	// ActionRequiredSubnetsUntagged is a VPC without a subnet tagged for the
+	// cluster to put the admin API load balancer in. The subnets have to be
+	// tagged, or listed in the APIScheme
+	ActionRequiredSubnetsUntagged = "SubnetsUntagged"
+	// ActionRequiredLoadBalancerQuota is a load balancer of the admin API
+	// that can't be created as the cloud's quota of load balancers is used up.
+	// The quota has to be raised, or unused load balancers deleted
+	ActionRequiredLoadBalancerQuota = "LoadBalancerQuotaExhausted"
+	// ActionRequiredSubnetSchemeMismatch is a subnet listed for the admin API
+	// load balancer that doesn't suit its scheme: a private one for an
+	// internet-facing load balancer, or a public one for an internal one. The
+	// subnetIDs have to be fixed, or the subnets' routes
+	ActionRequiredSubnetSchemeMismatch = "SubnetSchemeMismatch"
+	// ActionRequiredEdgeZoneSubnet is a subnet listed for the admin API load
+	// balancer in an AWS Local Zone or Wavelength Zone, where the load
+	// balancer can't be created. The subnetIDs have to be fixed, or the
+	// CloudIngressConfig's edgeZoneSubnets feature gate turned on
+	ActionRequiredEdgeZoneSubnet = "EdgeZoneSubnet"
+)
+
+// ManagementState - whether the operator applies the changes an APIScheme needs
+// +kubebuilder:validation:Enum=Managed;Plan
+type ManagementState string
+
+const (
+	// ManagementStateManaged applies the changes
+	ManagementStateManaged ManagementState = "Managed"
+	// ManagementStatePlan only records the changes in Status.Plan, for them
+	// to be reviewed before switching to Managed
+	ManagementStatePlan ManagementState = "Plan"
+)
+
+// PausedAnnotation set to "true" on a custom resource stops the operator from
+// making any cloud change for it, eg during manual cloud surgery. Drift is
+// still reported in the status of an APIScheme
+const PausedAnnotation = "cloudingress.managed.openshift.io/paused"
+
+// AdoptAnnotation set to "true" on an APIScheme makes the operator rewrite its
+// spec to match the admin API Service already in the cluster, before making
+// any change, then remove the annotation. It brings existing clusters under
+// the operator without their admin API being recreated
+const AdoptAnnotation = "cloudingress.managed.openshift.io/adopt"
+
+// AllowedCIDRBlocksMetadataAnnotation on an APIScheme describes some of its
+// AllowedCIDRBlocks, as a JSON object keyed by CIDR block whose values may have
+// a ticket, a requester and an expires timestamp. They're written in the
+// descriptions of the security group rules, and a CIDR block is no longer
+// allowed once it expires
+const AllowedCIDRBlocksMetadataAnnotation = "cloudingress.managed.openshift.io/allowed-cidr-blocks-metadata"
+
+// AllowedCIDRBlocksConfigMapKey is the key of the ConfigMap named by
+// AllowedCIDRBlocksConfigMap listing CIDR blocks, one per line or separated by
+// commas
+const AllowedCIDRBlocksConfigMapKey = "allowedCIDRBlocks"
+
+// APIEndpointVisibility - where the management API endpoint can be reached from
+type APIEndpointVisibility string
+
+const (
+	// APIEndpointPublic is reachable from the internet, subject to AllowedCIDRBlocks
+	APIEndpointPublic APIEndpointVisibility = "Public"
+	// APIEndpointPrivate is only reachable from inside the cluster's network
+	APIEndpointPrivate APIEndpointVisibility = "Private"
+	// APIEndpointTransitioning is being created or changed
+	APIEndpointTransitioning APIEndpointVisibility = "Transitioning"
+)
+
+// IPAddressType - the IP address family of the management API load balancer
+// +kubebuilder:validation:Enum=ipv4;dualstack
+type IPAddressType string
+
+const (
+	// IPAddressTypeIPv4 serves the management API over IPv4 only
+	IPAddressTypeIPv4 IPAddressType = "ipv4"
+	// IPAddressTypeDualStack serves the management API over both IPv4 and IPv6
+	IPAddressTypeDualStack IPAddressType = "dualstack"
 )
 
 // APISchemeSpec defines the desired state of APIScheme
@@ -23,16 +166,267 @@ type APISchemeSpec struct {
 	// Important: Run "operator-sdk generate k8s" to regenerate code after modifying this file
 	// Add custom validation using kubebuilder tags: https://book-v1.book.kubebuilder.io/beyond_basics/generating_crd.html
 	ManagementAPIServerIngress ManagementAPIServerIngress `json:"managementAPIServerIngress"`
+	// ManagementState is Managed for the operator to apply the changes the
+	// management API needs, or Plan to only list them in the status. Defaults
+	// to Managed
+	ManagementState ManagementState `json:"managementState,omitempty"`
 }
 
+// LoadBalancerScheme - who can reach the management API load balancer
+// +kubebuilder:validation:Enum=internet-facing;internal
+type LoadBalancerScheme string
+
+const (
+	// LoadBalancerSchemeInternetFacing is reachable from the internet, in the
+	// public subnets
+	LoadBalancerSchemeInternetFacing LoadBalancerScheme = "internet-facing"
+	// LoadBalancerSchemeInternal is only reachable from inside the cluster's
+	// network and the networks peered with it, in the private subnets
+	LoadBalancerSchemeInternal LoadBalancerScheme = "internal"
+)
+
 // ManagementAPIServerIngress defines the Management API ingress
 type ManagementAPIServerIngress struct {
 	// Enabled to create the Management API endpoint or not.
 	Enabled bool `json:"enabled"`
 	// DNSName is the name that should be used for DNS of the management API, eg rh-api
 	DNSName string `json:"dnsName"`
+	// BaseDomain is the domain the management API is published under, as
+	// <dnsName>.<baseDomain>, for clusters with a vanity domain. Defaults to
+	// the cluster's base domain
+	BaseDomain string `json:"baseDomain,omitempty"`
+	// HostedZoneID is the DNS zone the management API record is created in:
+	// a Route53 hosted zone ID (AWS) or a Cloud DNS managed zone name (GCP).
+	// It must exist and hold <dnsName>.<baseDomain>. If unset, the record goes
+	// in the public zone named baseDomain when that's set, or else in the
+	// cluster's private and public zones
+	HostedZoneID string `json:"hostedZoneID,omitempty"`
 	// AllowedCIDRBlocks is the list of CIDR blocks that should be allowed to access the management API
+	// +kubebuilder:validation:items:Pattern=`^((25[0-5]|2[0-4][0-9]|1[0-9]{2}|[1-9]?[0-9])\.){3}(25[0-5]|2[0-4][0-9]|1[0-9]{2}|[1-9]?[0-9])/([0-9]|[12][0-9]|3[0-2])$`
 	AllowedCIDRBlocks []string `json:"allowedCIDRBlocks"`
+	// AllowedCIDRBlocksConfigMap names a ConfigMap in the APIScheme's
+	// namespace, eg maintained by another controller, whose allowedCIDRBlocks
+	// are allowed to access the management API too. A missing ConfigMap
+	// allows none
+	AllowedCIDRBlocksConfigMap string `json:"allowedCIDRBlocksConfigMap,omitempty"`
+	// StaticIP, when set, fronts the management API with a network load
+	// balancer that has a static IP address in each of its subnets
+	StaticIP *StaticIP `json:"staticIP,omitempty"`
+	// IPAddressType is the IP address family of the management API load
+	// balancer. dualstack requires a dual-stack VPC and also publishes AAAA
+	// records. Defaults to ipv4.
+	IPAddressType IPAddressType `json:"ipAddressType,omitempty"`
+	// AccessLogs, when set, makes the management API load balancer write
+	// access logs to an S3 bucket (AWS)
+	AccessLogs *AccessLogs `json:"accessLogs,omitempty"`
+	// Alarms, when set, makes the operator create CloudWatch alarms for the
+	// management API load balancer (AWS, classic ELB only)
+	Alarms *Alarms `json:"alarms,omitempty"`
+	// SubnetIDs are the subnets to put the management API load balancer in,
+	// one per availability zone (AWS): public ones, or private ones for an
+	// internal load balancer. If empty, the subnets tagged for the cluster are
+	// used
+	SubnetIDs []string `json:"subnetIDs,omitempty"`
+	// BackendNodeSelector selects, by their labels, the nodes the management
+	// API load balancer forwards to (AWS), eg a dedicated pool of infra nodes
+	// fronting the API, in place of the masters. The master Machines then
+	// don't get the load balancer. If empty, the masters are behind it
+	BackendNodeSelector map[string]string `json:"backendNodeSelector,omitempty"`
+	// Scheme is internet-facing for the management API load balancer to be
+	// reachable from the internet, or internal for it to only be reachable
+	// from the cluster's network, eg on fully private clusters. Changing it
+	// replaces the load balancer. StaticIP needs internet-facing. Defaults to
+	// internet-facing
+	Scheme LoadBalancerScheme `json:"scheme,omitempty"`
+	// TemporaryPublicAccess, when set on an internal management API, makes
+	// its load balancer internet-facing for a while, then internal again
+	TemporaryPublicAccess *TemporaryPublicAccess `json:"temporaryPublicAccess,omitempty"`
+	// PublicAccessSchedule, when set on an internal management API, makes its
+	// load balancer internet-facing during recurring maintenance windows, and
+	// internal again outside them
+	PublicAccessSchedule *PublicAccessSchedule `json:"publicAccessSchedule,omitempty"`
+	// TLSSecurityPolicy is the predefined security policy the SSL and TLS
+	// listeners of the management API load balancer negotiate with, eg
+	// ELBSecurityPolicy-TLS-1-2-2017-01 (AWS). If unset, their policies are
+	// left alone
+	TLSSecurityPolicy string `json:"tlsSecurityPolicy,omitempty"`
+	// CertificateARN is the ACM certificate the SSL and TLS listeners of the
+	// management API load balancer serve (AWS). Changing it, eg to a renewed
+	// certificate, swaps it on the listeners in place. If unset, their
+	// certificates are left alone
+	CertificateARN string `json:"certificateARN,omitempty"`
+	// Stickiness, when set, configures how the target groups of the
+	// management API network load balancer keep clients on a backend (AWS),
+	// for tooling that holds a session with one API server. Classic ELBs pass
+	// TCP through, so they can't keep clients sticky. Removing it leaves the
+	// target groups as they are, so set sourceIP to false to turn stickiness
+	// off
+	Stickiness *Stickiness `json:"stickiness,omitempty"`
+	// NLBMigration moves the management API from a classic ELB to a network
+	// load balancer gradually (AWS): a second Service gets a network load
+	// balancer, and the DNS records are weighted between the two. Removing it
+	// points the records back at the management API Service alone, and
+	// deletes the second Service
+	NLBMigration *NLBMigration `json:"nlbMigration,omitempty"`
+	// Listeners are the ports the management API load balancer listens on,
+	// each forwarding to a port of the API server, eg 443 forwarding to 6443
+	// for networks that block 6443. Defaults to a single listener on the port
+	// of the cluster's API URL, forwarding to the port the API server serves
+	// on
+	Listeners []APIListener `json:"listeners,omitempty"`
+	// DNSManagement is Operator for the operator to publish the management
+	// API DNS records itself, in Route53 or Cloud DNS, or ExternalDNS for it
+	// to annotate the management API Service with its hostname, for
+	// external-dns to publish them. NLBMigration needs Operator. Defaults to
+	// Operator
+	DNSManagement DNSManagement `json:"dnsManagement,omitempty"`
+	// DNSTTLSeconds is the TTL of the management API DNS records (GCP), eg
+	// low around a planned load balancer migration and higher afterwards to
+	// spare the resolvers. Records whose TTL differs are updated. Defaults
+	// to 30
+	// +kubebuilder:validation:Minimum=1
+	DNSTTLSeconds int64 `json:"dnsTTLSeconds,omitempty"`
+	// Backend is what the management API load balancer forwards to (GCP):
+	// TargetPool for a legacy network load balancer, or BackendService for a
+	// passthrough load balancer with a regional backend service, which newer
+	// regions and features need. Internal load balancers always have a
+	// backend service. Changing it replaces the load balancer. Defaults to
+	// TargetPool
+	Backend LoadBalancerBackend `json:"backend,omitempty"`
+}
+
+// LoadBalancerBackend - what a GCP passthrough load balancer forwards to
+// +kubebuilder:validation:Enum=TargetPool;BackendService
+type LoadBalancerBackend string
+
+const (
+	// LoadBalancerBackendTargetPool forwards to a target pool of instances
+	LoadBalancerBackendTargetPool LoadBalancerBackend = "TargetPool"
+	// LoadBalancerBackendBackendService forwards to a regional backend
+	// service of instance groups
+	LoadBalancerBackendBackendService LoadBalancerBackend = "BackendService"
+)
+
+// DNSManagement - who publishes the management API DNS records
+// +kubebuilder:validation:Enum=Operator;ExternalDNS
+type DNSManagement string
+
+const (
+	// DNSManagementOperator publishes the records with the cloud's DNS API
+	DNSManagementOperator DNSManagement = "Operator"
+	// DNSManagementExternalDNS leaves the records to external-dns, reading
+	// the hostname annotation of the management API Service
+	DNSManagementExternalDNS DNSManagement = "ExternalDNS"
+)
+
+// APIListener is a port the management API load balancer listens on
+type APIListener struct {
+	// Port is the port the load balancer listens on
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	Port int32 `json:"port"`
+	// TargetPort is the port of the API server the listener forwards to.
+	// Defaults to the port the API server serves on
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=65535
+	TargetPort int32 `json:"targetPort,omitempty"`
+}
+
+// NLBMigration defines how the management API traffic is split between its
+// load balancer and the network load balancer it's migrated to
+type NLBMigration struct {
+	// Weight is the percentage of DNS queries answered with the network load
+	// balancer, once its targets are healthy. The rest are answered with the
+	// management API load balancer
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	Weight int32 `json:"weight"`
+	// Routing is how the DNS queries are answered once the network load
+	// balancer's targets are healthy. Weighted, the default, splits them by
+	// Weight. Failover answers with the management API load balancer while
+	// it's healthy, and with the network load balancer otherwise, ignoring
+	// Weight
+	// +optional
+	Routing NLBRouting `json:"routing,omitempty"`
+}
+
+// NLBRouting is how the management API DNS name is shared between its load
+// balancer and the network load balancer it's migrated to
+// +kubebuilder:validation:Enum=Weighted;Failover
+type NLBRouting string
+
+const (
+	// NLBRoutingWeighted splits the DNS queries by weight
+	NLBRoutingWeighted NLBRouting = "Weighted"
+	// NLBRoutingFailover answers with the network load balancer only while
+	// the management API load balancer fails its health check
+	NLBRoutingFailover NLBRouting = "Failover"
+)
+
+// TemporaryPublicAccess defines how long an internal management API is public
+// for. The period starts when the operator first sees it, and changing
+// Duration starts a new one
+type TemporaryPublicAccess struct {
+	// Duration is how long the management API is public for, eg 2h
+	Duration metav1.Duration `json:"duration"`
+}
+
+// PublicAccessSchedule defines the recurring maintenance windows an internal
+// management API is public during
+type PublicAccessSchedule struct {
+	// Start is the cron expression of when a window opens, with five fields:
+	// minute, hour, day of month, month and day of week, eg "0 22 * * 6" for
+	// Saturdays at 22:00
+	Start string `json:"start"`
+	// Duration is how long each window stays open for, eg 4h
+	Duration metav1.Duration `json:"duration"`
+	// TimeZone is the IANA time zone Start is in, eg Europe/Berlin. Defaults
+	// to UTC
+	TimeZone string `json:"timeZone,omitempty"`
+}
+
+// Alarms defines the CloudWatch alarms of the management API load balancer
+type Alarms struct {
+	// ActionARNs are notified when an alarm fires or recovers, eg SNS topics
+	ActionARNs []string `json:"actionARNs,omitempty"`
+	// LatencyThresholdMilliseconds is the average latency above which the
+	// latency alarm fires. Defaults to 1000
+	// +kubebuilder:validation:Minimum=0
+	LatencyThresholdMilliseconds int64 `json:"latencyThresholdMilliseconds,omitempty"`
+}
+
+// AccessLogs defines where the management API load balancer writes its access logs
+type AccessLogs struct {
+	// S3BucketName is the S3 bucket the access logs are written to. Its bucket
+	// policy must let the load balancer write to it
+	S3BucketName string `json:"s3BucketName"`
+	// S3BucketPrefix is the path in the bucket the access logs are written under
+	S3BucketPrefix string `json:"s3BucketPrefix,omitempty"`
+	// EmitInterval is how often, in minutes, access logs are written: 5 or 60.
+	// Only classic ELBs support it; network load balancers always write every
+	// 5 minutes. Defaults to 60.
+	// +kubebuilder:validation:Enum=5;60
+	EmitInterval int64 `json:"emitInterval,omitempty"`
+}
+
+// Stickiness defines how the management API load balancer keeps clients on a
+// backend
+type Stickiness struct {
+	// SourceIP sends the connections of a client IP address to the same
+	// backend for as long as it stays healthy
+	SourceIP bool `json:"sourceIP"`
+	// PreserveClientIP is whether the backends see the IP addresses of the
+	// clients rather than those of the load balancer. If unset, it's left as
+	// the target groups have it
+	PreserveClientIP *bool `json:"preserveClientIP,omitempty"`
+}
+
+// StaticIP defines the static IP addresses for the management API load balancer
+type StaticIP struct {
+	// AllocationIDs is an optional list of pre-allocated Elastic IP allocation
+	// IDs (AWS), one per load balancer subnet. If empty, the operator allocates
+	// and tags the addresses itself.
+	AllocationIDs []string `json:"allocationIDs,omitempty"`
 }
 
 // APISchemeStatus defines the observed state of APIScheme
@@ -44,14 +438,99 @@ type APISchemeStatus struct {
 	CloudLoadBalancerDNSName string                 `json:"cloudLoadBalancerDNSName,omitempty"`
 	Conditions               []APISchemeCondition   `json:"conditions,omitempty"`
 	State                    APISchemeConditionType `json:"state,omitempty"`
+	// LoadBalancerIPs are the static IP addresses attached to the management
+	// API load balancer, if ManagementAPIServerIngress.StaticIP is set
+	LoadBalancerIPs []string `json:"loadBalancerIPs,omitempty"`
+	// CreatedResources are the cloud resources the operator created for the
+	// management API, as <kind>/<id>. They're rolled back if the management
+	// API endpoint never becomes ready
+	CreatedResources []string `json:"createdResources,omitempty"`
+	// RolledBackGeneration is the generation of the APIScheme whose cloud
+	// resources were rolled back. It isn't retried until its spec changes
+	RolledBackGeneration int64 `json:"rolledBackGeneration,omitempty"`
+	// APIEndpointVisibility is where the management API endpoint can be
+	// reached from: Public, Private, or Transitioning until it's ready
+	APIEndpointVisibility APIEndpointVisibility `json:"apiEndpointVisibility,omitempty"`
+	// RegisteredInstanceCount is the number of instances registered with the
+	// management API load balancer
+	RegisteredInstanceCount int32 `json:"registeredInstanceCount,omitempty"`
+	// RegisteredInstances are the instances registered with the management
+	// API load balancer, and their health as it sees it, refreshed on every
+	// reconcile
+	RegisteredInstances []RegisteredInstance `json:"registeredInstances,omitempty"`
+	// Drifted are the components of the management API cloud resources found
+	// changed outside the operator while it's paused or planning, when they
+	// aren't repaired, eg listeners or dns
+	Drifted []string `json:"drifted,omitempty"`
+	// Plan are the changes the operator would make to the management API
+	// resources, while ManagementState is Plan
+	Plan []string `json:"plan,omitempty"`
+	// Failures is the streak of consecutive failed reconciles, if the last one
+	// failed
+	Failures *ReconcileFailures `json:"failures,omitempty"`
+	// TemporaryPublicAccess is the period the management API is public for,
+	// while ManagementAPIServerIngress.TemporaryPublicAccess is set
+	TemporaryPublicAccess *TemporaryPublicAccessStatus `json:"temporaryPublicAccess,omitempty"`
+	// NLBMigration is the progress of ManagementAPIServerIngress.NLBMigration
+	NLBMigration *NLBMigrationStatus `json:"nlbMigration,omitempty"`
+	// EffectiveAllowedCIDRBlocks are the CIDR blocks allowed to access the
+	// management API, merged from ManagementAPIServerIngress.AllowedCIDRBlocks,
+	// its AllowedCIDRBlocksConfigMap and the CloudIngressConfig's
+	// BaselineAllowedCIDRBlocks
+	EffectiveAllowedCIDRBlocks []string `json:"effectiveAllowedCIDRBlocks,omitempty"`
+}
+
+// RegisteredInstance is an instance registered with the management API load
+// balancer
+type RegisteredInstance struct {
+	// InstanceID is the ID of the instance on AWS, or its name on GCP
+	InstanceID string `json:"instanceID"`
+	// AvailabilityZone is the zone the instance is in
+	AvailabilityZone string `json:"availabilityZone,omitempty"`
+	// State is the health of the instance as the load balancer reports it:
+	// InService, OutOfService or Unknown on a classic ELB, the target health
+	// state on a network load balancer, eg healthy or unhealthy, and HEALTHY
+	// or UNHEALTHY on GCP
+	State string `json:"state"`
+	// Description is why the load balancer reports that state, if it says
+	Description string `json:"description,omitempty"`
+}
+
+// TemporaryPublicAccessStatus is a period of temporary public access to the
+// management API
+type TemporaryPublicAccessStatus struct {
+	// Duration is the TemporaryPublicAccess duration the period was started for
+	Duration metav1.Duration `json:"duration"`
+	// ExpiresAt is when the management API is made internal again
+	ExpiresAt metav1.Time `json:"expiresAt"`
 }
 
+// NLBMigrationStatus is how the management API traffic is split during a
+// migration to a network load balancer
+type NLBMigrationStatus struct {
+	// ServiceName is the Service whose network load balancer the management
+	// API is migrated to
+	ServiceName string `json:"serviceName"`
+	// Ready is whether the targets of the network load balancer are healthy.
+	// Until they are, it gets no traffic
+	Ready bool `json:"ready"`
+	// Weight is the percentage of DNS queries answered with the network load
+	// balancer. It's 0 with Failover routing
+	Weight int32 `json:"weight"`
+}
+
+// +genclient
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 
 // APIScheme is the Schema for the APISchemes API
 // +k8s:openapi-gen=true
 // +kubebuilder:subresource:status
 // +kubebuilder:resource:path=apischemes,scope=Namespaced
+// +kubebuilder:printcolumn:name="Visibility",type="string",JSONPath=".status.apiEndpointVisibility"
+// +kubebuilder:printcolumn:name="State",type="string",JSONPath=".status.state"
+// +kubebuilder:printcolumn:name="Load Balancer",type="string",JSONPath=".status.cloudLoadBalancerDNSName",priority=1
+// +kubebuilder:printcolumn:name="Instances",type="integer",JSONPath=".status.registeredInstanceCount",priority=1
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
 type APIScheme struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
@@ -83,6 +562,19 @@ type APISchemeCondition struct {
 	Status corev1.ConditionStatus `json:"status"`
 }
 
+// ReconcileFailures is a streak of consecutive failed reconciles of a resource,
+// which ends with the first one that succeeds
+type ReconcileFailures struct {
+	// Count is the number of consecutive failed reconciles
+	Count int32 `json:"count"`
+	// Since is when the first of them failed
+	Since metav1.Time `json:"since"`
+	// Errors are the distinct errors of the streak, the most recent last
+	Errors []string `json:"errors,omitempty"`
+	// Degraded is set once the streak is long enough to need attention
+	Degraded bool `json:"degraded,omitempty"`
+}
+
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 
 // APISchemeList contains a list of APIScheme
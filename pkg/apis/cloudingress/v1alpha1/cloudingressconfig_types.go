@@ -0,0 +1,278 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// CloudIngressConfigSpec defines the operator-wide settings
+type CloudIngressConfigSpec struct {
+	// Important: Run "operator-sdk generate k8s" to regenerate code after modifying this file
+	// Add custom validation using kubebuilder tags: https://book-v1.book.kubebuilder.io/beyond_basics/generating_crd.html
+
+	// AWSRegion overrides the region read from the cluster's Infrastructure
+	// object when talking to AWS
+	AWSRegion string `json:"awsRegion,omitempty"`
+
+	// AWSServiceEndpoints override the endpoints of AWS services, eg to use
+	// the FIPS endpoints in GovCloud, including those the cluster was
+	// installed with. The other services use the endpoints of the region's
+	// partition
+	AWSServiceEndpoints []AWSServiceEndpoint `json:"awsServiceEndpoints,omitempty"`
+
+	// AWSPartition is the ID of the AWS partition of the region, eg aws-iso
+	// in C2S, used in the ARNs the operator builds. Worked out from the
+	// region if unset, which only knows the partitions of the AWS SDK
+	AWSPartition string `json:"awsPartition,omitempty"`
+
+	// BaselineAllowedCIDRBlocks are allowed to access the management API of
+	// every APIScheme, on top of its own AllowedCIDRBlocks, eg the SRE
+	// bastions
+	// +kubebuilder:validation:items:Pattern=`^((25[0-5]|2[0-4][0-9]|1[0-9]{2}|[1-9]?[0-9])\.){3}(25[0-5]|2[0-4][0-9]|1[0-9]{2}|[1-9]?[0-9])/([0-9]|[12][0-9]|3[0-2])$`
+	BaselineAllowedCIDRBlocks []string `json:"baselineAllowedCIDRBlocks,omitempty"`
+
+	// CloudAPICABundleConfigMapName names a ConfigMap in the operator's
+	// namespace whose ca-bundle.crt holds PEM certificates trusted for the
+	// cloud API calls, eg the CA of the endpoints of an air-gapped region.
+	// They're trusted on top of the system ones, those of the egress proxy
+	// and the CA bundle the cluster was installed with
+	CloudAPICABundleConfigMapName string `json:"cloudAPICABundleConfigMapName,omitempty"`
+
+	// Disconnected is for air-gapped clusters, without a route to public
+	// endpoints. The admin API records are then only verified against the
+	// resolvers of DNSVerification, not the public nameservers of their
+	// zones, and AWS STS and S3 are called on their regional endpoints
+	Disconnected bool `json:"disconnected,omitempty"`
+
+	// ReconcileInterval is how often every watched object is reconciled again,
+	// and how often the cloud resources behind them are re-verified for drift.
+	// Overrides the operator's --resync-period flag
+	ReconcileInterval *metav1.Duration `json:"reconcileInterval,omitempty"`
+
+	// CloudAPICallTimeout bounds how long each cloud API call may take,
+	// including its retries, before it's abandoned and the object requeued,
+	// so a stuck call doesn't block a controller's work queue. A minute if
+	// unset, unbounded if zero
+	CloudAPICallTimeout *metav1.Duration `json:"cloudAPICallTimeout,omitempty"`
+
+	// RateLimit bounds the rate of the operator's cloud API calls
+	RateLimit *RateLimit `json:"rateLimit,omitempty"`
+
+	// MaxConcurrentReconciles is how many objects each controller reconciles
+	// at once. Overrides the operator's --max-concurrent-reconciles flag. Read
+	// when the operator starts
+	MaxConcurrentReconciles *MaxConcurrentReconciles `json:"maxConcurrentReconciles,omitempty"`
+
+	// RequeueIntervals override how soon each controller reconciles an object
+	// again, to trade convergence speed for cloud API calls. Read when the
+	// operator starts
+	RequeueIntervals *RequeueIntervals `json:"requeueIntervals,omitempty"`
+
+	// DryRun makes the operator log the cloud API calls that would change
+	// something instead of making them
+	DryRun bool `json:"dryRun,omitempty"`
+
+	// Paused stops the operator from making any cloud change, as if every
+	// custom resource had the PausedAnnotation
+	Paused bool `json:"paused,omitempty"`
+
+	// Observer makes the operator only report how the cloud resources it
+	// would manage differ from what it would make them, in the status of each
+	// custom resource and in metrics, without ever changing them. For clusters
+	// where they're still owned by something else
+	Observer bool `json:"observer,omitempty"`
+
+	// HealthCheck overrides the cloud provider's defaults for the admin API
+	// load balancer health check
+	HealthCheck *HealthCheck `json:"healthCheck,omitempty"`
+
+	// LoadBalancerPolicy is what the attributes of the admin API load
+	// balancers should be. They're audited along the drift checks and their
+	// compliance exported in metrics, but never changed
+	LoadBalancerPolicy *LoadBalancerAttributes `json:"loadBalancerPolicy,omitempty"`
+
+	// Tags are added to the cloud resources the operator creates
+	Tags map[string]string `json:"tags,omitempty"`
+
+	// FeatureGates turns optional behaviour on
+	FeatureGates FeatureGates `json:"featureGates,omitempty"`
+
+	// DNSVerification checks the admin API records resolve once they're
+	// changed. Off if unset
+	DNSVerification *DNSVerification `json:"dnsVerification,omitempty"`
+
+	// WatchNamespaces are namespaces holding APISchemes and
+	// PublishingStrategies, watched in addition to those of the operator's
+	// WATCH_NAMESPACE. Ignored when WATCH_NAMESPACE is empty, ie every
+	// namespace is watched. Read when the operator starts
+	WatchNamespaces []string `json:"watchNamespaces,omitempty"`
+}
+
+// AWSServiceEndpoint is a custom endpoint for an AWS service
+type AWSServiceEndpoint struct {
+	// Name is the ID of the AWS service, eg ec2, elasticloadbalancing,
+	// monitoring or route53
+	Name string `json:"name"`
+	// URL is the endpoint's URL, eg https://ec2.us-gov-west-1.amazonaws.com
+	URL string `json:"url"`
+	// SigningRegion is the region the calls to the endpoint are signed for.
+	// The one the partition of the region gives the service if unset, or the
+	// region itself for a service the partition doesn't know
+	SigningRegion string `json:"signingRegion,omitempty"`
+}
+
+// RateLimit is a token bucket for cloud API calls
+type RateLimit struct {
+	// QPS is the sustained number of calls per second
+	// +kubebuilder:validation:Minimum=1
+	QPS int32 `json:"qps"`
+	// Burst is how many calls may be made at once above QPS
+	// +kubebuilder:validation:Minimum=1
+	Burst int32 `json:"burst"`
+}
+
+// MaxConcurrentReconciles is how many objects of each kind are reconciled at
+// once. Unset fields keep the operator's --max-concurrent-reconciles flag
+type MaxConcurrentReconciles struct {
+	// +kubebuilder:validation:Minimum=1
+	APIScheme int32 `json:"apiScheme,omitempty"`
+	// +kubebuilder:validation:Minimum=1
+	PublishingStrategy int32 `json:"publishingStrategy,omitempty"`
+	// +kubebuilder:validation:Minimum=1
+	RouterService int32 `json:"routerService,omitempty"`
+	// +kubebuilder:validation:Minimum=1
+	SSHD int32 `json:"sshd,omitempty"`
+}
+
+// RequeueIntervals are the requeue intervals of each controller. Unset
+// controllers keep their defaults
+type RequeueIntervals struct {
+	APIScheme          *ControllerRequeueIntervals `json:"apiScheme,omitempty"`
+	PublishingStrategy *ControllerRequeueIntervals `json:"publishingStrategy,omitempty"`
+	RouterService      *ControllerRequeueIntervals `json:"routerService,omitempty"`
+	SSHD               *ControllerRequeueIntervals `json:"sshd,omitempty"`
+}
+
+// ControllerRequeueIntervals are how soon a controller reconciles an object
+// again. Unset fields keep the controller's defaults
+type ControllerRequeueIntervals struct {
+	// Success is how long after a reconcile that succeeded the object is
+	// reconciled again. Only the APIScheme controller requeues by default,
+	// every minute, to revoke expired CIDR blocks on time
+	Success *metav1.Duration `json:"success,omitempty"`
+	// Error is how long after a reconcile left waiting on the cloud provider,
+	// eg for a load balancer to be ready, the object is reconciled again
+	Error *metav1.Duration `json:"error,omitempty"`
+	// DriftScan is how often the cloud resources behind the object are
+	// re-verified for drift, ReconcileInterval by default. The controllers
+	// other than APIScheme re-verify them on every reconcile, so it requeues
+	// their objects
+	DriftScan *metav1.Duration `json:"driftScan,omitempty"`
+}
+
+// HealthCheck defines the load balancer health check parameters. Unset fields
+// keep the cloud provider's defaults. The bounds are those of AWS
+type HealthCheck struct {
+	// IntervalSeconds is the time between health checks
+	// +kubebuilder:validation:Minimum=5
+	// +kubebuilder:validation:Maximum=300
+	IntervalSeconds int32 `json:"intervalSeconds,omitempty"`
+	// TimeoutSeconds is how long to wait for a health check response
+	// +kubebuilder:validation:Minimum=2
+	// +kubebuilder:validation:Maximum=60
+	TimeoutSeconds int32 `json:"timeoutSeconds,omitempty"`
+	// HealthyThreshold is the number of successes for an instance to be healthy
+	// +kubebuilder:validation:Minimum=2
+	// +kubebuilder:validation:Maximum=10
+	HealthyThreshold int32 `json:"healthyThreshold,omitempty"`
+	// UnhealthyThreshold is the number of failures for an instance to be unhealthy
+	// +kubebuilder:validation:Minimum=2
+	// +kubebuilder:validation:Maximum=10
+	UnhealthyThreshold int32 `json:"unhealthyThreshold,omitempty"`
+	// Protocol is the protocol of the health check. If unset, the operator
+	// probes the API server and picks the first of HTTPS, SSL and HTTP it
+	// answers, or else TCP
+	Protocol HealthCheckProtocol `json:"protocol,omitempty"`
+	// Path is what HTTP and HTTPS health checks request, /readyz by default
+	// +kubebuilder:validation:Pattern=`^/`
+	Path string `json:"path,omitempty"`
+}
+
+// HealthCheckProtocol is the protocol of a load balancer health check
+// +kubebuilder:validation:Enum=TCP;HTTP;HTTPS;SSL
+type HealthCheckProtocol string
+
+const (
+	// HealthCheckProtocolTCP only checks a connection can be opened
+	HealthCheckProtocolTCP HealthCheckProtocol = "TCP"
+	// HealthCheckProtocolHTTP expects a 200 response to a plain text request
+	HealthCheckProtocolHTTP HealthCheckProtocol = "HTTP"
+	// HealthCheckProtocolHTTPS expects a 200 response to a request over TLS
+	HealthCheckProtocolHTTPS HealthCheckProtocol = "HTTPS"
+	// HealthCheckProtocolSSL only checks a TLS handshake completes
+	HealthCheckProtocolSSL HealthCheckProtocol = "SSL"
+)
+
+// LoadBalancerAttributes are attributes of a load balancer. Unset fields
+// aren't audited in a policy, and don't apply to the load balancer when read
+// from it, eg the idle timeout of a network load balancer
+type LoadBalancerAttributes struct {
+	// CrossZone is whether the load balancer spreads traffic across the
+	// instances of every availability zone
+	CrossZone *bool `json:"crossZone,omitempty"`
+	// ConnectionDraining is whether the load balancer lets the requests in
+	// flight to an instance complete when it's deregistered
+	ConnectionDraining *bool `json:"connectionDraining,omitempty"`
+	// IdleTimeoutSeconds is how long the load balancer keeps an idle
+	// connection open
+	IdleTimeoutSeconds *int64 `json:"idleTimeoutSeconds,omitempty"`
+	// AccessLogs is whether the load balancer writes access logs
+	AccessLogs *bool `json:"accessLogs,omitempty"`
+}
+
+// DNSVerification defines where the admin API records are resolved to check
+// they've propagated. They're always resolved against the authoritative
+// nameservers of the zones they're published in
+type DNSVerification struct {
+	// Resolvers are other DNS servers to resolve the records against, eg
+	// public resolvers such as 8.8.8.8, as host or host:port
+	Resolvers []string `json:"resolvers,omitempty"`
+}
+
+// FeatureGates are the optional behaviours of the operator
+type FeatureGates struct {
+	// NetworkLoadBalancer fronts the admin API with a network load balancer
+	// instead of a classic ELB (AWS)
+	NetworkLoadBalancer bool `json:"networkLoadBalancer,omitempty"`
+	// EdgeZoneSubnets lets the admin API load balancers use subnets in Local
+	// Zones and Wavelength Zones, which are otherwise left out as not every
+	// load balancer is supported there (AWS)
+	EdgeZoneSubnets bool `json:"edgeZoneSubnets,omitempty"`
+}
+
+// +genclient
+// +genclient:nonNamespaced
+// +genclient:noStatus
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// CloudIngressConfig is the Schema for the cloudingressconfigs API. The
+// operator reads the one named "cluster"
+// +kubebuilder:resource:path=cloudingressconfigs,scope=Cluster
+type CloudIngressConfig struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec CloudIngressConfigSpec `json:"spec,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// CloudIngressConfigList contains a list of CloudIngressConfig
+type CloudIngressConfigList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []CloudIngressConfig `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&CloudIngressConfig{}, &CloudIngressConfigList{})
+}
@@ -24,6 +24,31 @@ type PublishingStrategySpec struct {
 type DefaultAPIServerIngress struct {
 	// Listening defines internal or external ingress
 	Listening Listening `json:"listening,omitempty"`
+	// ConnectionDraining, when set, makes the operator wait for the connections
+	// to the external API load balancer to end before removing it, when the
+	// API becomes internal (AWS)
+	ConnectionDraining *ConnectionDraining `json:"connectionDraining,omitempty"`
+	// KeepPublicPorts are the ports whose listeners on the external API load
+	// balancer stay when the API becomes internal. Every other listener is
+	// removed, and the load balancer with them if none is kept (AWS)
+	KeepPublicPorts []int32 `json:"keepPublicPorts,omitempty"`
+	// LoadBalancerName is the name of the external API load balancer the
+	// operator creates. Defaults to <infrastructureName>-ext, shortened with a
+	// hash of the infrastructure name past the 32 characters AWS allows (AWS)
+	// +kubebuilder:validation:MaxLength=32
+	// +kubebuilder:validation:Pattern=`^[a-zA-Z0-9]([a-zA-Z0-9-]*[a-zA-Z0-9])?$`
+	LoadBalancerName string `json:"loadBalancerName,omitempty"`
+}
+
+// ConnectionDraining defines how long to wait for connections to end before
+// removing a load balancer
+type ConnectionDraining struct {
+	// ActiveConnectionThreshold is the number of active connections at or
+	// below which the load balancer is removed
+	ActiveConnectionThreshold int64 `json:"activeConnectionThreshold,omitempty"`
+	// TimeoutSeconds is the longest to wait before removing the load balancer
+	// anyway. Defaults to 300
+	TimeoutSeconds int64 `json:"timeoutSeconds,omitempty"`
 }
 
 // ApplicationIngress defines application ingress
@@ -35,6 +60,10 @@ type ApplicationIngress struct {
 	DNSName       string                 `json:"dnsName"`
 	Certificate   corev1.SecretReference `json:"certificate"`
 	RouteSelector metav1.LabelSelector   `json:"routeSelector,omitempty"`
+	// DNSTTLSeconds is the TTL of the wildcard DNS records of the application
+	// ingress (GCP). Records whose TTL differs are updated. Defaults to 30
+	// +kubebuilder:validation:Minimum=1
+	DNSTTLSeconds int64 `json:"dnsTTLSeconds,omitempty"`
 }
 
 // Listening defines internal or external api and ingress
@@ -52,8 +81,44 @@ type PublishingStrategyStatus struct {
 	// INSERT ADDITIONAL STATUS FIELD - define observed state of cluster
 	// Important: Run "operator-sdk generate k8s" to regenerate code after modifying this file
 	// Add custom validation using kubebuilder tags: https://book-v1.book.kubebuilder.io/beyond_basics/generating_crd.html
+
+	// ApplicationIngress reports how each ApplicationIngress is published
+	ApplicationIngress []ApplicationIngressStatus `json:"applicationIngress,omitempty"`
+	// DefaultAPIServerIngress reports the connection draining of the external
+	// API load balancer, while the API is becoming internal
+	DefaultAPIServerIngress *DefaultAPIServerIngressStatus `json:"defaultAPIServerIngress,omitempty"`
+	// Drifted are the IngressControllers found different from what the
+	// ApplicationIngresses make them, in observer mode, when they aren't
+	// changed
+	Drifted []string `json:"drifted,omitempty"`
+	// Failures is the streak of consecutive failed reconciles, if the last one
+	// failed
+	Failures *ReconcileFailures `json:"failures,omitempty"`
+}
+
+// DefaultAPIServerIngressStatus defines the observed state of the default API
+// becoming internal
+type DefaultAPIServerIngressStatus struct {
+	// DrainingStartTime is when the operator started waiting for the
+	// connections to the external API load balancer to end
+	DrainingStartTime metav1.Time `json:"drainingStartTime"`
+	// ActiveConnections is the number of active connections to the external
+	// API load balancer at the last check
+	ActiveConnections int64 `json:"activeConnections"`
+}
+
+// ApplicationIngressStatus defines the observed state of an ApplicationIngress
+type ApplicationIngressStatus struct {
+	// DNSName is the DNSName of the ApplicationIngress
+	DNSName string `json:"dnsName"`
+	// IngressControllerName is the IngressController publishing the ApplicationIngress
+	IngressControllerName string `json:"ingressControllerName"`
+	// LoadBalancer is the hostname or IP address of the cloud load balancer in
+	// front of the IngressController, once it has been provisioned
+	LoadBalancer string `json:"loadBalancer,omitempty"`
 }
 
+// +genclient
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 
 // PublishingStrategy is the Schema for the publishingstrategies API
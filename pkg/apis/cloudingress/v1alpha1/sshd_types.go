@@ -47,6 +47,7 @@ type SSHDStatus struct {
 	Message string `json:"message,omitempty"`
 }
 
+// +genclient
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 
 // SSHD is the Schema for the sshds API
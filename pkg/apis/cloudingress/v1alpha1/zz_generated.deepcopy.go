@@ -5,9 +5,26 @@
 package v1alpha1
 
 import (
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *APIListener) DeepCopyInto(out *APIListener) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APIListener.
+func (in *APIListener) DeepCopy() *APIListener {
+	if in == nil {
+		return nil
+	}
+	out := new(APIListener)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *APIScheme) DeepCopyInto(out *APIScheme) {
 	*out = *in
@@ -119,6 +136,51 @@ func (in *APISchemeStatus) DeepCopyInto(out *APISchemeStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.LoadBalancerIPs != nil {
+		in, out := &in.LoadBalancerIPs, &out.LoadBalancerIPs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.CreatedResources != nil {
+		in, out := &in.CreatedResources, &out.CreatedResources
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.RegisteredInstances != nil {
+		in, out := &in.RegisteredInstances, &out.RegisteredInstances
+		*out = make([]RegisteredInstance, len(*in))
+		copy(*out, *in)
+	}
+	if in.Drifted != nil {
+		in, out := &in.Drifted, &out.Drifted
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Plan != nil {
+		in, out := &in.Plan, &out.Plan
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Failures != nil {
+		in, out := &in.Failures, &out.Failures
+		*out = new(ReconcileFailures)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TemporaryPublicAccess != nil {
+		in, out := &in.TemporaryPublicAccess, &out.TemporaryPublicAccess
+		*out = new(TemporaryPublicAccessStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NLBMigration != nil {
+		in, out := &in.NLBMigration, &out.NLBMigration
+		*out = new(NLBMigrationStatus)
+		**out = **in
+	}
+	if in.EffectiveAllowedCIDRBlocks != nil {
+		in, out := &in.EffectiveAllowedCIDRBlocks, &out.EffectiveAllowedCIDRBlocks
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -132,6 +194,59 @@ func (in *APISchemeStatus) DeepCopy() *APISchemeStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AWSServiceEndpoint) DeepCopyInto(out *AWSServiceEndpoint) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AWSServiceEndpoint.
+func (in *AWSServiceEndpoint) DeepCopy() *AWSServiceEndpoint {
+	if in == nil {
+		return nil
+	}
+	out := new(AWSServiceEndpoint)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AccessLogs) DeepCopyInto(out *AccessLogs) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AccessLogs.
+func (in *AccessLogs) DeepCopy() *AccessLogs {
+	if in == nil {
+		return nil
+	}
+	out := new(AccessLogs)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Alarms) DeepCopyInto(out *Alarms) {
+	*out = *in
+	if in.ActionARNs != nil {
+		in, out := &in.ActionARNs, &out.ActionARNs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Alarms.
+func (in *Alarms) DeepCopy() *Alarms {
+	if in == nil {
+		return nil
+	}
+	out := new(Alarms)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ApplicationIngress) DeepCopyInto(out *ApplicationIngress) {
 	*out = *in
@@ -150,9 +265,242 @@ func (in *ApplicationIngress) DeepCopy() *ApplicationIngress {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ApplicationIngressStatus) DeepCopyInto(out *ApplicationIngressStatus) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApplicationIngressStatus.
+func (in *ApplicationIngressStatus) DeepCopy() *ApplicationIngressStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ApplicationIngressStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CloudIngressConfig) DeepCopyInto(out *CloudIngressConfig) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloudIngressConfig.
+func (in *CloudIngressConfig) DeepCopy() *CloudIngressConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(CloudIngressConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CloudIngressConfig) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CloudIngressConfigList) DeepCopyInto(out *CloudIngressConfigList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]CloudIngressConfig, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloudIngressConfigList.
+func (in *CloudIngressConfigList) DeepCopy() *CloudIngressConfigList {
+	if in == nil {
+		return nil
+	}
+	out := new(CloudIngressConfigList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CloudIngressConfigList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CloudIngressConfigSpec) DeepCopyInto(out *CloudIngressConfigSpec) {
+	*out = *in
+	if in.AWSServiceEndpoints != nil {
+		in, out := &in.AWSServiceEndpoints, &out.AWSServiceEndpoints
+		*out = make([]AWSServiceEndpoint, len(*in))
+		copy(*out, *in)
+	}
+	if in.BaselineAllowedCIDRBlocks != nil {
+		in, out := &in.BaselineAllowedCIDRBlocks, &out.BaselineAllowedCIDRBlocks
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ReconcileInterval != nil {
+		in, out := &in.ReconcileInterval, &out.ReconcileInterval
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.CloudAPICallTimeout != nil {
+		in, out := &in.CloudAPICallTimeout, &out.CloudAPICallTimeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.RateLimit != nil {
+		in, out := &in.RateLimit, &out.RateLimit
+		*out = new(RateLimit)
+		**out = **in
+	}
+	if in.MaxConcurrentReconciles != nil {
+		in, out := &in.MaxConcurrentReconciles, &out.MaxConcurrentReconciles
+		*out = new(MaxConcurrentReconciles)
+		**out = **in
+	}
+	if in.RequeueIntervals != nil {
+		in, out := &in.RequeueIntervals, &out.RequeueIntervals
+		*out = new(RequeueIntervals)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.HealthCheck != nil {
+		in, out := &in.HealthCheck, &out.HealthCheck
+		*out = new(HealthCheck)
+		**out = **in
+	}
+	if in.LoadBalancerPolicy != nil {
+		in, out := &in.LoadBalancerPolicy, &out.LoadBalancerPolicy
+		*out = new(LoadBalancerAttributes)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	out.FeatureGates = in.FeatureGates
+	if in.DNSVerification != nil {
+		in, out := &in.DNSVerification, &out.DNSVerification
+		*out = new(DNSVerification)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.WatchNamespaces != nil {
+		in, out := &in.WatchNamespaces, &out.WatchNamespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloudIngressConfigSpec.
+func (in *CloudIngressConfigSpec) DeepCopy() *CloudIngressConfigSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CloudIngressConfigSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConnectionDraining) DeepCopyInto(out *ConnectionDraining) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConnectionDraining.
+func (in *ConnectionDraining) DeepCopy() *ConnectionDraining {
+	if in == nil {
+		return nil
+	}
+	out := new(ConnectionDraining)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ControllerRequeueIntervals) DeepCopyInto(out *ControllerRequeueIntervals) {
+	*out = *in
+	if in.Success != nil {
+		in, out := &in.Success, &out.Success
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.Error != nil {
+		in, out := &in.Error, &out.Error
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.DriftScan != nil {
+		in, out := &in.DriftScan, &out.DriftScan
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ControllerRequeueIntervals.
+func (in *ControllerRequeueIntervals) DeepCopy() *ControllerRequeueIntervals {
+	if in == nil {
+		return nil
+	}
+	out := new(ControllerRequeueIntervals)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DNSVerification) DeepCopyInto(out *DNSVerification) {
+	*out = *in
+	if in.Resolvers != nil {
+		in, out := &in.Resolvers, &out.Resolvers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSVerification.
+func (in *DNSVerification) DeepCopy() *DNSVerification {
+	if in == nil {
+		return nil
+	}
+	out := new(DNSVerification)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DefaultAPIServerIngress) DeepCopyInto(out *DefaultAPIServerIngress) {
 	*out = *in
+	if in.ConnectionDraining != nil {
+		in, out := &in.ConnectionDraining, &out.ConnectionDraining
+		*out = new(ConnectionDraining)
+		**out = **in
+	}
+	if in.KeepPublicPorts != nil {
+		in, out := &in.KeepPublicPorts, &out.KeepPublicPorts
+		*out = make([]int32, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -166,6 +514,91 @@ func (in *DefaultAPIServerIngress) DeepCopy() *DefaultAPIServerIngress {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DefaultAPIServerIngressStatus) DeepCopyInto(out *DefaultAPIServerIngressStatus) {
+	*out = *in
+	in.DrainingStartTime.DeepCopyInto(&out.DrainingStartTime)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DefaultAPIServerIngressStatus.
+func (in *DefaultAPIServerIngressStatus) DeepCopy() *DefaultAPIServerIngressStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DefaultAPIServerIngressStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FeatureGates) DeepCopyInto(out *FeatureGates) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FeatureGates.
+func (in *FeatureGates) DeepCopy() *FeatureGates {
+	if in == nil {
+		return nil
+	}
+	out := new(FeatureGates)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HealthCheck) DeepCopyInto(out *HealthCheck) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HealthCheck.
+func (in *HealthCheck) DeepCopy() *HealthCheck {
+	if in == nil {
+		return nil
+	}
+	out := new(HealthCheck)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LoadBalancerAttributes) DeepCopyInto(out *LoadBalancerAttributes) {
+	*out = *in
+	if in.CrossZone != nil {
+		in, out := &in.CrossZone, &out.CrossZone
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ConnectionDraining != nil {
+		in, out := &in.ConnectionDraining, &out.ConnectionDraining
+		*out = new(bool)
+		**out = **in
+	}
+	if in.IdleTimeoutSeconds != nil {
+		in, out := &in.IdleTimeoutSeconds, &out.IdleTimeoutSeconds
+		*out = new(int64)
+		**out = **in
+	}
+	if in.AccessLogs != nil {
+		in, out := &in.AccessLogs, &out.AccessLogs
+		*out = new(bool)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LoadBalancerAttributes.
+func (in *LoadBalancerAttributes) DeepCopy() *LoadBalancerAttributes {
+	if in == nil {
+		return nil
+	}
+	out := new(LoadBalancerAttributes)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ManagementAPIServerIngress) DeepCopyInto(out *ManagementAPIServerIngress) {
 	*out = *in
@@ -174,6 +607,58 @@ func (in *ManagementAPIServerIngress) DeepCopyInto(out *ManagementAPIServerIngre
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.StaticIP != nil {
+		in, out := &in.StaticIP, &out.StaticIP
+		*out = new(StaticIP)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AccessLogs != nil {
+		in, out := &in.AccessLogs, &out.AccessLogs
+		*out = new(AccessLogs)
+		**out = **in
+	}
+	if in.Alarms != nil {
+		in, out := &in.Alarms, &out.Alarms
+		*out = new(Alarms)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SubnetIDs != nil {
+		in, out := &in.SubnetIDs, &out.SubnetIDs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.BackendNodeSelector != nil {
+		in, out := &in.BackendNodeSelector, &out.BackendNodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.TemporaryPublicAccess != nil {
+		in, out := &in.TemporaryPublicAccess, &out.TemporaryPublicAccess
+		*out = new(TemporaryPublicAccess)
+		**out = **in
+	}
+	if in.PublicAccessSchedule != nil {
+		in, out := &in.PublicAccessSchedule, &out.PublicAccessSchedule
+		*out = new(PublicAccessSchedule)
+		**out = **in
+	}
+	if in.Stickiness != nil {
+		in, out := &in.Stickiness, &out.Stickiness
+		*out = new(Stickiness)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NLBMigration != nil {
+		in, out := &in.NLBMigration, &out.NLBMigration
+		*out = new(NLBMigration)
+		**out = **in
+	}
+	if in.Listeners != nil {
+		in, out := &in.Listeners, &out.Listeners
+		*out = make([]APIListener, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -187,13 +672,78 @@ func (in *ManagementAPIServerIngress) DeepCopy() *ManagementAPIServerIngress {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MaxConcurrentReconciles) DeepCopyInto(out *MaxConcurrentReconciles) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MaxConcurrentReconciles.
+func (in *MaxConcurrentReconciles) DeepCopy() *MaxConcurrentReconciles {
+	if in == nil {
+		return nil
+	}
+	out := new(MaxConcurrentReconciles)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NLBMigration) DeepCopyInto(out *NLBMigration) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NLBMigration.
+func (in *NLBMigration) DeepCopy() *NLBMigration {
+	if in == nil {
+		return nil
+	}
+	out := new(NLBMigration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NLBMigrationStatus) DeepCopyInto(out *NLBMigrationStatus) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NLBMigrationStatus.
+func (in *NLBMigrationStatus) DeepCopy() *NLBMigrationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(NLBMigrationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PublicAccessSchedule) DeepCopyInto(out *PublicAccessSchedule) {
+	*out = *in
+	out.Duration = in.Duration
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PublicAccessSchedule.
+func (in *PublicAccessSchedule) DeepCopy() *PublicAccessSchedule {
+	if in == nil {
+		return nil
+	}
+	out := new(PublicAccessSchedule)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PublishingStrategy) DeepCopyInto(out *PublishingStrategy) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 	return
 }
 
@@ -251,7 +801,7 @@ func (in *PublishingStrategyList) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PublishingStrategySpec) DeepCopyInto(out *PublishingStrategySpec) {
 	*out = *in
-	out.DefaultAPIServerIngress = in.DefaultAPIServerIngress
+	in.DefaultAPIServerIngress.DeepCopyInto(&out.DefaultAPIServerIngress)
 	if in.ApplicationIngress != nil {
 		in, out := &in.ApplicationIngress, &out.ApplicationIngress
 		*out = make([]ApplicationIngress, len(*in))
@@ -275,6 +825,26 @@ func (in *PublishingStrategySpec) DeepCopy() *PublishingStrategySpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PublishingStrategyStatus) DeepCopyInto(out *PublishingStrategyStatus) {
 	*out = *in
+	if in.ApplicationIngress != nil {
+		in, out := &in.ApplicationIngress, &out.ApplicationIngress
+		*out = make([]ApplicationIngressStatus, len(*in))
+		copy(*out, *in)
+	}
+	if in.DefaultAPIServerIngress != nil {
+		in, out := &in.DefaultAPIServerIngress, &out.DefaultAPIServerIngress
+		*out = new(DefaultAPIServerIngressStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Drifted != nil {
+		in, out := &in.Drifted, &out.Drifted
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Failures != nil {
+		in, out := &in.Failures, &out.Failures
+		*out = new(ReconcileFailures)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -288,6 +858,96 @@ func (in *PublishingStrategyStatus) DeepCopy() *PublishingStrategyStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RateLimit) DeepCopyInto(out *RateLimit) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RateLimit.
+func (in *RateLimit) DeepCopy() *RateLimit {
+	if in == nil {
+		return nil
+	}
+	out := new(RateLimit)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReconcileFailures) DeepCopyInto(out *ReconcileFailures) {
+	*out = *in
+	in.Since.DeepCopyInto(&out.Since)
+	if in.Errors != nil {
+		in, out := &in.Errors, &out.Errors
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReconcileFailures.
+func (in *ReconcileFailures) DeepCopy() *ReconcileFailures {
+	if in == nil {
+		return nil
+	}
+	out := new(ReconcileFailures)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RegisteredInstance) DeepCopyInto(out *RegisteredInstance) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RegisteredInstance.
+func (in *RegisteredInstance) DeepCopy() *RegisteredInstance {
+	if in == nil {
+		return nil
+	}
+	out := new(RegisteredInstance)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RequeueIntervals) DeepCopyInto(out *RequeueIntervals) {
+	*out = *in
+	if in.APIScheme != nil {
+		in, out := &in.APIScheme, &out.APIScheme
+		*out = new(ControllerRequeueIntervals)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PublishingStrategy != nil {
+		in, out := &in.PublishingStrategy, &out.PublishingStrategy
+		*out = new(ControllerRequeueIntervals)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RouterService != nil {
+		in, out := &in.RouterService, &out.RouterService
+		*out = new(ControllerRequeueIntervals)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SSHD != nil {
+		in, out := &in.SSHD, &out.SSHD
+		*out = new(ControllerRequeueIntervals)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RequeueIntervals.
+func (in *RequeueIntervals) DeepCopy() *RequeueIntervals {
+	if in == nil {
+		return nil
+	}
+	out := new(RequeueIntervals)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SSHD) DeepCopyInto(out *SSHD) {
 	*out = *in
@@ -386,3 +1046,80 @@ func (in *SSHDStatus) DeepCopy() *SSHDStatus {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StaticIP) DeepCopyInto(out *StaticIP) {
+	*out = *in
+	if in.AllocationIDs != nil {
+		in, out := &in.AllocationIDs, &out.AllocationIDs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StaticIP.
+func (in *StaticIP) DeepCopy() *StaticIP {
+	if in == nil {
+		return nil
+	}
+	out := new(StaticIP)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Stickiness) DeepCopyInto(out *Stickiness) {
+	*out = *in
+	if in.PreserveClientIP != nil {
+		in, out := &in.PreserveClientIP, &out.PreserveClientIP
+		*out = new(bool)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Stickiness.
+func (in *Stickiness) DeepCopy() *Stickiness {
+	if in == nil {
+		return nil
+	}
+	out := new(Stickiness)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TemporaryPublicAccess) DeepCopyInto(out *TemporaryPublicAccess) {
+	*out = *in
+	out.Duration = in.Duration
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TemporaryPublicAccess.
+func (in *TemporaryPublicAccess) DeepCopy() *TemporaryPublicAccess {
+	if in == nil {
+		return nil
+	}
+	out := new(TemporaryPublicAccess)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TemporaryPublicAccessStatus) DeepCopyInto(out *TemporaryPublicAccessStatus) {
+	*out = *in
+	out.Duration = in.Duration
+	in.ExpiresAt.DeepCopyInto(&out.ExpiresAt)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TemporaryPublicAccessStatus.
+func (in *TemporaryPublicAccessStatus) DeepCopy() *TemporaryPublicAccessStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(TemporaryPublicAccessStatus)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -79,6 +79,13 @@ func schema_pkg_apis_cloudingress_v1alpha1_APISchemeSpec(ref common.ReferenceCal
 							Ref:         ref("github.com/openshift/cloud-ingress-operator/pkg/apis/cloudingress/v1alpha1.ManagementAPIServerIngress"),
 						},
 					},
+					"managementState": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ManagementState is Managed for the operator to apply the changes the management API needs, or Plan to only list them in the status. Defaults to Managed",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
 				},
 				Required: []string{"managementAPIServerIngress"},
 			},
@@ -120,11 +127,133 @@ func schema_pkg_apis_cloudingress_v1alpha1_APISchemeStatus(ref common.ReferenceC
 							Format: "",
 						},
 					},
+					"loadBalancerIPs": {
+						SchemaProps: spec.SchemaProps{
+							Description: "LoadBalancerIPs are the static IP addresses attached to the management API load balancer, if ManagementAPIServerIngress.StaticIP is set",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Type:   []string{"string"},
+										Format: "",
+									},
+								},
+							},
+						},
+					},
+					"createdResources": {
+						SchemaProps: spec.SchemaProps{
+							Description: "CreatedResources are the cloud resources the operator created for the management API, as <kind>/<id>. They're rolled back if the management API endpoint never becomes ready",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Type:   []string{"string"},
+										Format: "",
+									},
+								},
+							},
+						},
+					},
+					"rolledBackGeneration": {
+						SchemaProps: spec.SchemaProps{
+							Description: "RolledBackGeneration is the generation of the APIScheme whose cloud resources were rolled back. It isn't retried until its spec changes",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"apiEndpointVisibility": {
+						SchemaProps: spec.SchemaProps{
+							Description: "APIEndpointVisibility is where the management API endpoint can be reached from: Public, Private, or Transitioning until it's ready",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"registeredInstanceCount": {
+						SchemaProps: spec.SchemaProps{
+							Description: "RegisteredInstanceCount is the number of instances registered with the management API load balancer",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"registeredInstances": {
+						SchemaProps: spec.SchemaProps{
+							Description: "RegisteredInstances are the instances registered with the management API load balancer, and their health as it sees it, refreshed on every reconcile",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("github.com/openshift/cloud-ingress-operator/pkg/apis/cloudingress/v1alpha1.RegisteredInstance"),
+									},
+								},
+							},
+						},
+					},
+					"drifted": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Drifted are the components of the management API cloud resources found changed outside the operator while it's paused or planning, when they aren't repaired, eg listeners or dns",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Type:   []string{"string"},
+										Format: "",
+									},
+								},
+							},
+						},
+					},
+					"plan": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Plan are the changes the operator would make to the management API resources, while ManagementState is Plan",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Type:   []string{"string"},
+										Format: "",
+									},
+								},
+							},
+						},
+					},
+					"failures": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Failures is the streak of consecutive failed reconciles, if the last one failed",
+							Ref:         ref("github.com/openshift/cloud-ingress-operator/pkg/apis/cloudingress/v1alpha1.ReconcileFailures"),
+						},
+					},
+					"temporaryPublicAccess": {
+						SchemaProps: spec.SchemaProps{
+							Description: "TemporaryPublicAccess is the period the management API is public for, while ManagementAPIServerIngress.TemporaryPublicAccess is set",
+							Ref:         ref("github.com/openshift/cloud-ingress-operator/pkg/apis/cloudingress/v1alpha1.TemporaryPublicAccessStatus"),
+						},
+					},
+					"nlbMigration": {
+						SchemaProps: spec.SchemaProps{
+							Description: "NLBMigration is the progress of ManagementAPIServerIngress.NLBMigration",
+							Ref:         ref("github.com/openshift/cloud-ingress-operator/pkg/apis/cloudingress/v1alpha1.NLBMigrationStatus"),
+						},
+					},
+					"effectiveAllowedCIDRBlocks": {
+						SchemaProps: spec.SchemaProps{
+							Description: "EffectiveAllowedCIDRBlocks are the CIDR blocks allowed to access the management API, merged from ManagementAPIServerIngress.AllowedCIDRBlocks, its AllowedCIDRBlocksConfigMap and the CloudIngressConfig's BaselineAllowedCIDRBlocks",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Type:   []string{"string"},
+										Format: "",
+									},
+								},
+							},
+						},
+					},
 				},
 			},
 		},
 		Dependencies: []string{
-			"github.com/openshift/cloud-ingress-operator/pkg/apis/cloudingress/v1alpha1.APISchemeCondition"},
+			"github.com/openshift/cloud-ingress-operator/pkg/apis/cloudingress/v1alpha1.APISchemeCondition", "github.com/openshift/cloud-ingress-operator/pkg/apis/cloudingress/v1alpha1.NLBMigrationStatus", "github.com/openshift/cloud-ingress-operator/pkg/apis/cloudingress/v1alpha1.ReconcileFailures", "github.com/openshift/cloud-ingress-operator/pkg/apis/cloudingress/v1alpha1.RegisteredInstance", "github.com/openshift/cloud-ingress-operator/pkg/apis/cloudingress/v1alpha1.TemporaryPublicAccessStatus"},
 	}
 }
 
@@ -6,21 +6,16 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/credentials"
 	"github.com/aws/aws-sdk-go/aws/session"
 
-	"github.com/aws/aws-sdk-go/service/ec2"
-	"github.com/aws/aws-sdk-go/service/elb"
-	"github.com/aws/aws-sdk-go/service/elbv2"
-	"github.com/aws/aws-sdk-go/service/route53"
-
-	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
-	"github.com/aws/aws-sdk-go/service/elb/elbiface"
-	"github.com/aws/aws-sdk-go/service/elbv2/elbv2iface"
-	"github.com/aws/aws-sdk-go/service/route53/route53iface"
-
+	"github.com/openshift/cloud-ingress-operator/config"
+	"github.com/openshift/cloud-ingress-operator/pkg/awsclient/dns"
+	"github.com/openshift/cloud-ingress-operator/pkg/awsclient/loadbalancer"
+	"github.com/openshift/cloud-ingress-operator/pkg/awsclient/securitygroup"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/types"
 	kubeclientpkg "sigs.k8s.io/controller-runtime/pkg/client"
@@ -39,76 +34,40 @@ type NewAwsClientInput struct {
 	AwsRegion               string
 	SecretName              string
 	NameSpace               string
+	// CallTimeout bounds each AWS API call of the client, retries included.
+	// config.DefaultCloudAPICallTimeout if zero, unbounded if negative
+	CallTimeout time.Duration
 }
 
-// Client wraps for AWS SDK (for easier testing)
+// Client wraps for AWS SDK (for easier testing). It's made of the small
+// interfaces of the loadbalancer and dns packages, so code that only needs one
+// of them can take it instead
 type Client interface {
-	/*
-	 * ELB-related Functions
-	 */
-
-	/*
-	 * ELBv2-related Functions
-	 */
-
-	// list all or 1 NLB to get external or internal
-	DescribeLoadBalancersV2(*elbv2.DescribeLoadBalancersInput) (*elbv2.DescribeLoadBalancersOutput, error)
-	// delete external NLB so we can make cluster private
-	DeleteLoadBalancerV2(*elbv2.DeleteLoadBalancerInput) (*elbv2.DeleteLoadBalancerOutput, error)
-	// create nlb to make server api public
-	CreateLoadBalancerV2(*elbv2.CreateLoadBalancerInput) (*elbv2.CreateLoadBalancerOutput, error)
-	// create targetGroup for new external NLB
-	CreateTargetGroupV2(*elbv2.CreateTargetGroupInput) (*elbv2.CreateTargetGroupOutput, error)
-	// register master instances with target group
-	RegisterTargetsV2(*elbv2.RegisterTargetsInput) (*elbv2.RegisterTargetsOutput, error)
-	// create listener for an NLB
-	CreateListenerV2(*elbv2.CreateListenerInput) (*elbv2.CreateListenerOutput, error)
-	// describes the targetGroup for NLB
-	DescribeTargetGroupsV2(*elbv2.DescribeTargetGroupsInput) (*elbv2.DescribeTargetGroupsOutput, error)
-	// add tags for an NLB
-	AddTagsV2(*elbv2.AddTagsInput) (*elbv2.AddTagsOutput, error)
-
-	/*
-	 * Route 53-related Functions
-	 */
-
-	// Route 53 - to update DNS for internal/external swap and to add rh-api
-	// for actually upserting the record
-	ChangeResourceRecordSets(*route53.ChangeResourceRecordSetsInput) (*route53.ChangeResourceRecordSetsOutput, error)
-	// to turn baseDomain into a Route53 zone ID
-	ListHostedZonesByName(*route53.ListHostedZonesByNameInput) (*route53.ListHostedZonesByNameOutput, error)
-
-	/*
-	 * EC2-related Functions
-	 */
-	// DescribeSubnets to find subnet for master nodes for incoming elb
-	DescribeSubnets(*ec2.DescribeSubnetsInput) (*ec2.DescribeSubnetsOutput, error)
-
-	// Helper extensions
-	// ec2
-	SubnetNameToSubnetIDLookup([]string) ([]string, error)
+	loadbalancer.API
+	dns.API
+}
 
-	// elb/elbv2
-	DoesELBExist(string) (bool, *AWSLoadBalancer, error)
-	ListAllNLBs() ([]LoadBalancerV2, error)
-	DeleteExternalLoadBalancer(string) error
-	CreateNetworkLoadBalancer(string, string, string) ([]LoadBalancerV2, error)
-	CreateListenerForNLB(string, string) error
-	GetTargetGroupArn(string) (string, error)
+// AWSLoadBalancer a handy way to return information about an ELB
+type AWSLoadBalancer = loadbalancer.AWSLoadBalancer
 
-	// route53
-	UpsertARecord(string, string, string, string, string, bool) error
-	DeleteARecord(string, string, string, string, bool) error
-}
+// LoadBalancerV2 is a list of all non-classic ELBs
+type LoadBalancerV2 = loadbalancer.LoadBalancerV2
 
+// AwsClient implements Client, and the calls of the securitygroup package, by
+// putting together the clients of the sub-packages
 type AwsClient struct {
-	ec2Client     ec2iface.EC2API
-	route53Client route53iface.Route53API
-	elbClient     elbiface.ELBAPI
-	elbv2Client   elbv2iface.ELBV2API
+	*loadbalancer.LoadBalancers
+	*dns.Records
+	*securitygroup.SecurityGroups
 }
 
+// NewClient returns an AwsClient whose calls time out after
+// config.DefaultCloudAPICallTimeout
 func NewClient(accessID, accessSecret, token, region string) (*AwsClient, error) {
+	return newClient(accessID, accessSecret, token, region, config.DefaultCloudAPICallTimeout)
+}
+
+func newClient(accessID, accessSecret, token, region string, callTimeout time.Duration) (*AwsClient, error) {
 	awsConfig := &aws.Config{Region: aws.String(region)}
 	if token == "" {
 		os.Setenv("AWS_ACCESS_KEY_ID", accessID)
@@ -121,10 +80,9 @@ func NewClient(accessID, accessSecret, token, region string) (*AwsClient, error)
 		return nil, err
 	}
 	return &AwsClient{
-		ec2Client:     ec2.New(s),
-		elbClient:     elb.New(s),
-		elbv2Client:   elbv2.New(s),
-		route53Client: route53.New(s),
+		LoadBalancers:  loadbalancer.New(s, callTimeout),
+		Records:        dns.New(s, callTimeout),
+		SecurityGroups: securitygroup.New(s, callTimeout),
 	}, nil
 }
 
@@ -139,6 +97,10 @@ func GetAWSClient(kubeClient kubeclientpkg.Client, input NewAwsClientInput) (*Aw
 	if input.AwsRegion == "" {
 		return nil, fmt.Errorf("getAWSClient:NoRegion: %v", input.AwsRegion)
 	}
+	callTimeout := config.DefaultCloudAPICallTimeout
+	if input.CallTimeout != 0 {
+		callTimeout = input.CallTimeout
+	}
 
 	if input.SecretName != "" && input.NameSpace != "" {
 		secret := &corev1.Secret{}
@@ -162,7 +124,7 @@ func GetAWSClient(kubeClient kubeclientpkg.Client, input NewAwsClientInput) (*Aw
 				input.SecretName, awsCredsSecretAccessKey)
 		}
 
-		AwsClient, err := NewClient(string(accessKeyID), string(secretAccessKey), input.AwsToken, input.AwsRegion)
+		AwsClient, err := newClient(string(accessKeyID), string(secretAccessKey), input.AwsToken, input.AwsRegion, callTimeout)
 		if err != nil {
 			return nil, err
 		}
@@ -173,104 +135,9 @@ func GetAWSClient(kubeClient kubeclientpkg.Client, input NewAwsClientInput) (*Aw
 		return nil, fmt.Errorf("getAWSClient: NoAwsCredentials or Secret %v", input)
 	}
 
-	AwsClient, err := NewClient(input.AwsCredsSecretIDKey, input.AwsCredsSecretAccessKey, input.AwsToken, input.AwsRegion)
+	AwsClient, err := newClient(input.AwsCredsSecretIDKey, input.AwsCredsSecretAccessKey, input.AwsToken, input.AwsRegion, callTimeout)
 	if err != nil {
 		return nil, err
 	}
 	return AwsClient, nil
 }
-
-func (c *AwsClient) ApplySecurityGroupsToLoadBalancer(i *elb.ApplySecurityGroupsToLoadBalancerInput) (*elb.ApplySecurityGroupsToLoadBalancerOutput, error) {
-	return c.elbClient.ApplySecurityGroupsToLoadBalancer(i)
-}
-
-func (c *AwsClient) ConfigureHealthCheck(i *elb.ConfigureHealthCheckInput) (*elb.ConfigureHealthCheckOutput, error) {
-	return c.elbClient.ConfigureHealthCheck(i)
-}
-
-func (c *AwsClient) CreateLoadBalancer(i *elb.CreateLoadBalancerInput) (*elb.CreateLoadBalancerOutput, error) {
-	return c.elbClient.CreateLoadBalancer(i)
-}
-
-func (c *AwsClient) CreateLoadBalancerListeners(i *elb.CreateLoadBalancerListenersInput) (*elb.CreateLoadBalancerListenersOutput, error) {
-	return c.elbClient.CreateLoadBalancerListeners(i)
-}
-
-func (c *AwsClient) DeleteLoadBalancerListeners(i *elb.DeleteLoadBalancerListenersInput) (*elb.DeleteLoadBalancerListenersOutput, error) {
-	return c.elbClient.DeleteLoadBalancerListeners(i)
-}
-
-func (c *AwsClient) DeregisterInstancesFromLoadBalancer(i *elb.DeregisterInstancesFromLoadBalancerInput) (*elb.DeregisterInstancesFromLoadBalancerOutput, error) {
-	return c.elbClient.DeregisterInstancesFromLoadBalancer(i)
-}
-func (c *AwsClient) DescribeLoadBalancers(i *elb.DescribeLoadBalancersInput) (*elb.DescribeLoadBalancersOutput, error) {
-	return c.elbClient.DescribeLoadBalancers(i)
-}
-
-func (c *AwsClient) DescribeLoadBalancersV2(i *elbv2.DescribeLoadBalancersInput) (*elbv2.DescribeLoadBalancersOutput, error) {
-	return c.elbv2Client.DescribeLoadBalancers(i)
-}
-
-func (c *AwsClient) DeleteLoadBalancerV2(i *elbv2.DeleteLoadBalancerInput) (*elbv2.DeleteLoadBalancerOutput, error) {
-	return c.elbv2Client.DeleteLoadBalancer(i)
-}
-
-func (c *AwsClient) CreateLoadBalancerV2(i *elbv2.CreateLoadBalancerInput) (*elbv2.CreateLoadBalancerOutput, error) {
-	return c.elbv2Client.CreateLoadBalancer(i)
-}
-
-func (c *AwsClient) CreateTargetGroupV2(i *elbv2.CreateTargetGroupInput) (*elbv2.CreateTargetGroupOutput, error) {
-	return c.elbv2Client.CreateTargetGroup(i)
-}
-
-func (c *AwsClient) RegisterTargetsV2(i *elbv2.RegisterTargetsInput) (*elbv2.RegisterTargetsOutput, error) {
-	return c.elbv2Client.RegisterTargets(i)
-}
-
-func (c *AwsClient) CreateListenerV2(i *elbv2.CreateListenerInput) (*elbv2.CreateListenerOutput, error) {
-	return c.elbv2Client.CreateListener(i)
-}
-
-func (c *AwsClient) DescribeTags(i *elb.DescribeTagsInput) (*elb.DescribeTagsOutput, error) {
-	return c.elbClient.DescribeTags(i)
-}
-func (c *AwsClient) RegisterInstancesWithLoadBalancer(i *elb.RegisterInstancesWithLoadBalancerInput) (*elb.RegisterInstancesWithLoadBalancerOutput, error) {
-	return c.elbClient.RegisterInstancesWithLoadBalancer(i)
-}
-
-func (c *AwsClient) DescribeTargetGroupsV2(i *elbv2.DescribeTargetGroupsInput) (*elbv2.DescribeTargetGroupsOutput, error) {
-	return c.elbv2Client.DescribeTargetGroups(i)
-}
-
-func (c *AwsClient) AddTagsV2(i *elbv2.AddTagsInput) (*elbv2.AddTagsOutput, error) {
-	return c.elbv2Client.AddTags(i)
-}
-
-func (c *AwsClient) ChangeResourceRecordSets(i *route53.ChangeResourceRecordSetsInput) (*route53.ChangeResourceRecordSetsOutput, error) {
-	return c.route53Client.ChangeResourceRecordSets(i)
-}
-func (c *AwsClient) ListHostedZonesByName(i *route53.ListHostedZonesByNameInput) (*route53.ListHostedZonesByNameOutput, error) {
-	return c.route53Client.ListHostedZonesByName(i)
-}
-
-func (c *AwsClient) AuthorizeSecurityGroupIngress(i *ec2.AuthorizeSecurityGroupIngressInput) (*ec2.AuthorizeSecurityGroupIngressOutput, error) {
-	return c.ec2Client.AuthorizeSecurityGroupIngress(i)
-}
-func (c *AwsClient) CreateSecurityGroup(i *ec2.CreateSecurityGroupInput) (*ec2.CreateSecurityGroupOutput, error) {
-	return c.ec2Client.CreateSecurityGroup(i)
-}
-func (c *AwsClient) DeleteSecurityGroup(i *ec2.DeleteSecurityGroupInput) (*ec2.DeleteSecurityGroupOutput, error) {
-	return c.ec2Client.DeleteSecurityGroup(i)
-}
-func (c *AwsClient) DescribeSecurityGroups(i *ec2.DescribeSecurityGroupsInput) (*ec2.DescribeSecurityGroupsOutput, error) {
-	return c.ec2Client.DescribeSecurityGroups(i)
-}
-func (c *AwsClient) RevokeSecurityGroupIngress(i *ec2.RevokeSecurityGroupIngressInput) (*ec2.RevokeSecurityGroupIngressOutput, error) {
-	return c.ec2Client.RevokeSecurityGroupIngress(i)
-}
-func (c *AwsClient) DescribeSubnets(i *ec2.DescribeSubnetsInput) (*ec2.DescribeSubnetsOutput, error) {
-	return c.ec2Client.DescribeSubnets(i)
-}
-func (c *AwsClient) CreateTags(i *ec2.CreateTagsInput) (*ec2.CreateTagsOutput, error) {
-	return c.ec2Client.CreateTags(i)
-}
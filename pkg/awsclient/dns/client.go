@@ -0,0 +1,54 @@
+// Package dns wraps the AWS calls managing the Route53 records of the
+// cluster
+package dns
+
+import (
+	"context"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/route53"
+	"github.com/aws/aws-sdk-go/service/route53/route53iface"
+
+	"github.com/openshift/cloud-ingress-operator/pkg/awsclient/internal/calltimeout"
+)
+
+// API is the DNS part of awsclient.Client
+type API interface {
+	// Route 53 - to update DNS for internal/external swap and to add rh-api
+	// for actually upserting the record
+	ChangeResourceRecordSets(context.Context, *route53.ChangeResourceRecordSetsInput) (*route53.ChangeResourceRecordSetsOutput, error)
+	// to turn baseDomain into a Route53 zone ID
+	ListHostedZonesByName(context.Context, *route53.ListHostedZonesByNameInput) (*route53.ListHostedZonesByNameOutput, error)
+
+	// Helper extensions
+	UpsertARecord(context.Context, string, string, string, string, string, bool) error
+	DeleteARecord(context.Context, string, string, string, string, bool) error
+}
+
+// Records implements API with the AWS SDK
+type Records struct {
+	route53Client route53iface.Route53API
+	// callTimeout bounds each API call. Unbounded if negative
+	callTimeout time.Duration
+}
+
+// New returns the Records calling AWS with s
+func New(s *session.Session, callTimeout time.Duration) *Records {
+	return &Records{
+		route53Client: route53.New(s),
+		callTimeout:   callTimeout,
+	}
+}
+
+func (c *Records) ChangeResourceRecordSets(ctx context.Context, i *route53.ChangeResourceRecordSetsInput) (*route53.ChangeResourceRecordSetsOutput, error) {
+	ctx, cancel := calltimeout.Context(ctx, c.callTimeout)
+	defer cancel()
+	return c.route53Client.ChangeResourceRecordSetsWithContext(ctx, i)
+}
+
+func (c *Records) ListHostedZonesByName(ctx context.Context, i *route53.ListHostedZonesByNameInput) (*route53.ListHostedZonesByNameOutput, error) {
+	ctx, cancel := calltimeout.Context(ctx, c.callTimeout)
+	defer cancel()
+	return c.route53Client.ListHostedZonesByNameWithContext(ctx, i)
+}
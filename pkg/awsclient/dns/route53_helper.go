@@ -1,6 +1,7 @@
-package awsclient
+package dns
 
 import (
+	"context"
 	"path"
 
 	"github.com/aws/aws-sdk-go/aws"
@@ -9,11 +10,11 @@ import (
 )
 
 // GetPublicHostedZoneID looks up the ID of the public hosted zone for clusterDomain.
-func (c *AwsClient) GetPublicHostedZoneID(clusterDomain string) (string, error) {
+func (c *Records) GetPublicHostedZoneID(ctx context.Context, clusterDomain string) (string, error) {
 	input := &route53.ListHostedZonesByNameInput{
 		DNSName: aws.String(clusterDomain),
 	}
-	output, err := c.ListHostedZonesByName(input)
+	output, err := c.ListHostedZonesByName(ctx, input)
 	if err != nil {
 		return "", err
 	}
@@ -33,8 +34,8 @@ func (c *AwsClient) GetPublicHostedZoneID(clusterDomain string) (string, error)
 }
 
 // UpsertARecord adds an A record alias named DNSName in the target zone aliasDNSZoneID, inside the clusterDomain's zone.
-func (c *AwsClient) UpsertARecord(clusterDomain, DNSName, aliasDNSZoneID, resourceRecordSetName, comment string, targetHealth bool) error {
-	publicHostedZoneID, err := c.GetPublicHostedZoneID(clusterDomain)
+func (c *Records) UpsertARecord(ctx context.Context, clusterDomain, DNSName, aliasDNSZoneID, resourceRecordSetName, comment string, targetHealth bool) error {
+	publicHostedZoneID, err := c.GetPublicHostedZoneID(ctx, clusterDomain)
 	if err != nil {
 		return err
 	}
@@ -60,7 +61,7 @@ func (c *AwsClient) UpsertARecord(clusterDomain, DNSName, aliasDNSZoneID, resour
 		HostedZoneId: aws.String(publicHostedZoneID),
 	}
 
-	_, err = c.ChangeResourceRecordSets(change)
+	_, err = c.ChangeResourceRecordSets(ctx, change)
 	if err != nil {
 		return err
 	}
@@ -70,8 +71,8 @@ func (c *AwsClient) UpsertARecord(clusterDomain, DNSName, aliasDNSZoneID, resour
 // DeleteARecord removes an A record alias named DNSName in the target zone
 // aliasDNSZoneID, inside the clusterDomain's zone.  Effectively, it undoes
 // the UpsertARecord function.
-func (c *AwsClient) DeleteARecord(clusterDomain, DNSName, aliasDNSZoneID, resourceRecordSetName string, targetHealth bool) error {
-	publicHostedZoneID, err := c.GetPublicHostedZoneID(clusterDomain)
+func (c *Records) DeleteARecord(ctx context.Context, clusterDomain, DNSName, aliasDNSZoneID, resourceRecordSetName string, targetHealth bool) error {
+	publicHostedZoneID, err := c.GetPublicHostedZoneID(ctx, clusterDomain)
 	if err != nil {
 		return err
 	}
@@ -96,7 +97,7 @@ func (c *AwsClient) DeleteARecord(clusterDomain, DNSName, aliasDNSZoneID, resour
 		HostedZoneId: aws.String(publicHostedZoneID),
 	}
 
-	_, err = c.ChangeResourceRecordSets(change)
+	_, err = c.ChangeResourceRecordSets(ctx, change)
 	if err != nil {
 		// If the DNS entry was not found, disregard the error.
 		//
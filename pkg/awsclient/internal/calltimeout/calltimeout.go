@@ -0,0 +1,16 @@
+// Package calltimeout bounds the AWS API calls of the awsclient packages
+package calltimeout
+
+import (
+	"context"
+	"time"
+)
+
+// Context returns ctx bounded by timeout, and the function releasing it once
+// the call returns. A negative timeout leaves the call bounded by ctx only
+func Context(ctx context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	if timeout < 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, timeout)
+}
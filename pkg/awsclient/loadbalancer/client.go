@@ -0,0 +1,191 @@
+// Package loadbalancer wraps the AWS calls managing the classic ELBs and the
+// network load balancers of the cluster, and the subnets they're placed in
+package loadbalancer
+
+import (
+	"context"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
+	"github.com/aws/aws-sdk-go/service/elb"
+	"github.com/aws/aws-sdk-go/service/elb/elbiface"
+	"github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/aws/aws-sdk-go/service/elbv2/elbv2iface"
+
+	"github.com/openshift/cloud-ingress-operator/pkg/awsclient/internal/calltimeout"
+)
+
+// API is the load balancer part of awsclient.Client
+type API interface {
+	/*
+	 * ELB-related Functions
+	 */
+
+	/*
+	 * ELBv2-related Functions
+	 */
+
+	// list all or 1 NLB to get external or internal
+	DescribeLoadBalancersV2(context.Context, *elbv2.DescribeLoadBalancersInput) (*elbv2.DescribeLoadBalancersOutput, error)
+	// delete external NLB so we can make cluster private
+	DeleteLoadBalancerV2(context.Context, *elbv2.DeleteLoadBalancerInput) (*elbv2.DeleteLoadBalancerOutput, error)
+	// create nlb to make server api public
+	CreateLoadBalancerV2(context.Context, *elbv2.CreateLoadBalancerInput) (*elbv2.CreateLoadBalancerOutput, error)
+	// create targetGroup for new external NLB
+	CreateTargetGroupV2(context.Context, *elbv2.CreateTargetGroupInput) (*elbv2.CreateTargetGroupOutput, error)
+	// register master instances with target group
+	RegisterTargetsV2(context.Context, *elbv2.RegisterTargetsInput) (*elbv2.RegisterTargetsOutput, error)
+	// create listener for an NLB
+	CreateListenerV2(context.Context, *elbv2.CreateListenerInput) (*elbv2.CreateListenerOutput, error)
+	// describes the targetGroup for NLB
+	DescribeTargetGroupsV2(context.Context, *elbv2.DescribeTargetGroupsInput) (*elbv2.DescribeTargetGroupsOutput, error)
+	// add tags for an NLB
+	AddTagsV2(context.Context, *elbv2.AddTagsInput) (*elbv2.AddTagsOutput, error)
+
+	/*
+	 * EC2-related Functions
+	 */
+	// DescribeSubnets to find subnet for master nodes for incoming elb
+	DescribeSubnets(context.Context, *ec2.DescribeSubnetsInput) (*ec2.DescribeSubnetsOutput, error)
+
+	// Helper extensions
+	// ec2
+	SubnetNameToSubnetIDLookup(context.Context, []string) ([]string, error)
+
+	// elb/elbv2
+	DoesELBExist(context.Context, string) (bool, *AWSLoadBalancer, error)
+	ListAllNLBs(context.Context) ([]LoadBalancerV2, error)
+	DeleteExternalLoadBalancer(context.Context, string) error
+	CreateNetworkLoadBalancer(context.Context, string, string, string) ([]LoadBalancerV2, error)
+	CreateListenerForNLB(context.Context, string, string) error
+	GetTargetGroupArn(context.Context, string) (string, error)
+}
+
+// LoadBalancers implements API with the AWS SDK
+type LoadBalancers struct {
+	ec2Client   ec2iface.EC2API
+	elbClient   elbiface.ELBAPI
+	elbv2Client elbv2iface.ELBV2API
+	// callTimeout bounds each API call. Unbounded if negative
+	callTimeout time.Duration
+}
+
+// New returns the LoadBalancers calling AWS with s
+func New(s *session.Session, callTimeout time.Duration) *LoadBalancers {
+	return &LoadBalancers{
+		ec2Client:   ec2.New(s),
+		elbClient:   elb.New(s),
+		elbv2Client: elbv2.New(s),
+		callTimeout: callTimeout,
+	}
+}
+
+func (c *LoadBalancers) ApplySecurityGroupsToLoadBalancer(ctx context.Context, i *elb.ApplySecurityGroupsToLoadBalancerInput) (*elb.ApplySecurityGroupsToLoadBalancerOutput, error) {
+	ctx, cancel := calltimeout.Context(ctx, c.callTimeout)
+	defer cancel()
+	return c.elbClient.ApplySecurityGroupsToLoadBalancerWithContext(ctx, i)
+}
+
+func (c *LoadBalancers) ConfigureHealthCheck(ctx context.Context, i *elb.ConfigureHealthCheckInput) (*elb.ConfigureHealthCheckOutput, error) {
+	ctx, cancel := calltimeout.Context(ctx, c.callTimeout)
+	defer cancel()
+	return c.elbClient.ConfigureHealthCheckWithContext(ctx, i)
+}
+
+func (c *LoadBalancers) CreateLoadBalancer(ctx context.Context, i *elb.CreateLoadBalancerInput) (*elb.CreateLoadBalancerOutput, error) {
+	ctx, cancel := calltimeout.Context(ctx, c.callTimeout)
+	defer cancel()
+	return c.elbClient.CreateLoadBalancerWithContext(ctx, i)
+}
+
+func (c *LoadBalancers) CreateLoadBalancerListeners(ctx context.Context, i *elb.CreateLoadBalancerListenersInput) (*elb.CreateLoadBalancerListenersOutput, error) {
+	ctx, cancel := calltimeout.Context(ctx, c.callTimeout)
+	defer cancel()
+	return c.elbClient.CreateLoadBalancerListenersWithContext(ctx, i)
+}
+
+func (c *LoadBalancers) DeleteLoadBalancerListeners(ctx context.Context, i *elb.DeleteLoadBalancerListenersInput) (*elb.DeleteLoadBalancerListenersOutput, error) {
+	ctx, cancel := calltimeout.Context(ctx, c.callTimeout)
+	defer cancel()
+	return c.elbClient.DeleteLoadBalancerListenersWithContext(ctx, i)
+}
+
+func (c *LoadBalancers) DeregisterInstancesFromLoadBalancer(ctx context.Context, i *elb.DeregisterInstancesFromLoadBalancerInput) (*elb.DeregisterInstancesFromLoadBalancerOutput, error) {
+	ctx, cancel := calltimeout.Context(ctx, c.callTimeout)
+	defer cancel()
+	return c.elbClient.DeregisterInstancesFromLoadBalancerWithContext(ctx, i)
+}
+
+func (c *LoadBalancers) DescribeLoadBalancers(ctx context.Context, i *elb.DescribeLoadBalancersInput) (*elb.DescribeLoadBalancersOutput, error) {
+	ctx, cancel := calltimeout.Context(ctx, c.callTimeout)
+	defer cancel()
+	return c.elbClient.DescribeLoadBalancersWithContext(ctx, i)
+}
+
+func (c *LoadBalancers) DescribeTags(ctx context.Context, i *elb.DescribeTagsInput) (*elb.DescribeTagsOutput, error) {
+	ctx, cancel := calltimeout.Context(ctx, c.callTimeout)
+	defer cancel()
+	return c.elbClient.DescribeTagsWithContext(ctx, i)
+}
+
+func (c *LoadBalancers) RegisterInstancesWithLoadBalancer(ctx context.Context, i *elb.RegisterInstancesWithLoadBalancerInput) (*elb.RegisterInstancesWithLoadBalancerOutput, error) {
+	ctx, cancel := calltimeout.Context(ctx, c.callTimeout)
+	defer cancel()
+	return c.elbClient.RegisterInstancesWithLoadBalancerWithContext(ctx, i)
+}
+
+func (c *LoadBalancers) DescribeLoadBalancersV2(ctx context.Context, i *elbv2.DescribeLoadBalancersInput) (*elbv2.DescribeLoadBalancersOutput, error) {
+	ctx, cancel := calltimeout.Context(ctx, c.callTimeout)
+	defer cancel()
+	return c.elbv2Client.DescribeLoadBalancersWithContext(ctx, i)
+}
+
+func (c *LoadBalancers) DeleteLoadBalancerV2(ctx context.Context, i *elbv2.DeleteLoadBalancerInput) (*elbv2.DeleteLoadBalancerOutput, error) {
+	ctx, cancel := calltimeout.Context(ctx, c.callTimeout)
+	defer cancel()
+	return c.elbv2Client.DeleteLoadBalancerWithContext(ctx, i)
+}
+
+func (c *LoadBalancers) CreateLoadBalancerV2(ctx context.Context, i *elbv2.CreateLoadBalancerInput) (*elbv2.CreateLoadBalancerOutput, error) {
+	ctx, cancel := calltimeout.Context(ctx, c.callTimeout)
+	defer cancel()
+	return c.elbv2Client.CreateLoadBalancerWithContext(ctx, i)
+}
+
+func (c *LoadBalancers) CreateTargetGroupV2(ctx context.Context, i *elbv2.CreateTargetGroupInput) (*elbv2.CreateTargetGroupOutput, error) {
+	ctx, cancel := calltimeout.Context(ctx, c.callTimeout)
+	defer cancel()
+	return c.elbv2Client.CreateTargetGroupWithContext(ctx, i)
+}
+
+func (c *LoadBalancers) RegisterTargetsV2(ctx context.Context, i *elbv2.RegisterTargetsInput) (*elbv2.RegisterTargetsOutput, error) {
+	ctx, cancel := calltimeout.Context(ctx, c.callTimeout)
+	defer cancel()
+	return c.elbv2Client.RegisterTargetsWithContext(ctx, i)
+}
+
+func (c *LoadBalancers) CreateListenerV2(ctx context.Context, i *elbv2.CreateListenerInput) (*elbv2.CreateListenerOutput, error) {
+	ctx, cancel := calltimeout.Context(ctx, c.callTimeout)
+	defer cancel()
+	return c.elbv2Client.CreateListenerWithContext(ctx, i)
+}
+
+func (c *LoadBalancers) DescribeTargetGroupsV2(ctx context.Context, i *elbv2.DescribeTargetGroupsInput) (*elbv2.DescribeTargetGroupsOutput, error) {
+	ctx, cancel := calltimeout.Context(ctx, c.callTimeout)
+	defer cancel()
+	return c.elbv2Client.DescribeTargetGroupsWithContext(ctx, i)
+}
+
+func (c *LoadBalancers) AddTagsV2(ctx context.Context, i *elbv2.AddTagsInput) (*elbv2.AddTagsOutput, error) {
+	ctx, cancel := calltimeout.Context(ctx, c.callTimeout)
+	defer cancel()
+	return c.elbv2Client.AddTagsWithContext(ctx, i)
+}
+
+func (c *LoadBalancers) DescribeSubnets(ctx context.Context, i *ec2.DescribeSubnetsInput) (*ec2.DescribeSubnetsOutput, error) {
+	ctx, cancel := calltimeout.Context(ctx, c.callTimeout)
+	defer cancel()
+	return c.ec2Client.DescribeSubnetsWithContext(ctx, i)
+}
@@ -1,6 +1,8 @@
-package awsclient
+package loadbalancer
 
 import (
+	"context"
+
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/service/elb"
@@ -16,12 +18,12 @@ type AWSLoadBalancer struct {
 
 // DoesELBExist checks for the existence of an ELB by name. If there's an AWS
 // error it is returned.
-func (c *AwsClient) DoesELBExist(elbName string) (bool, *AWSLoadBalancer, error) {
+func (c *LoadBalancers) DoesELBExist(ctx context.Context, elbName string) (bool, *AWSLoadBalancer, error) {
 
 	i := &elb.DescribeLoadBalancersInput{
 		LoadBalancerNames: []*string{aws.String(elbName)},
 	}
-	res, err := c.DescribeLoadBalancers(i)
+	res, err := c.DescribeLoadBalancers(ctx, i)
 	if err != nil {
 		if aerr, ok := err.(awserr.Error); ok {
 			switch aerr.Code() {
@@ -46,10 +48,10 @@ type LoadBalancerV2 struct {
 }
 
 // ListAllNLBs uses the DescribeLoadBalancersV2 to get back a list of all Network Load Balancers
-func (c *AwsClient) ListAllNLBs() ([]LoadBalancerV2, error) {
+func (c *LoadBalancers) ListAllNLBs(ctx context.Context) ([]LoadBalancerV2, error) {
 
 	i := &elbv2.DescribeLoadBalancersInput{}
-	output, err := c.DescribeLoadBalancersV2(i)
+	output, err := c.DescribeLoadBalancersV2(ctx, i)
 	if err != nil {
 		return []LoadBalancerV2{}, err
 	}
@@ -68,16 +70,16 @@ func (c *AwsClient) ListAllNLBs() ([]LoadBalancerV2, error) {
 }
 
 // DeleteExternalLoadBalancer takes in the external LB arn and deletes the entire LB
-func (c *AwsClient) DeleteExternalLoadBalancer(extLoadBalancerArn string) error {
+func (c *LoadBalancers) DeleteExternalLoadBalancer(ctx context.Context, extLoadBalancerArn string) error {
 	i := elbv2.DeleteLoadBalancerInput{
 		LoadBalancerArn: aws.String(extLoadBalancerArn),
 	}
-	_, err := c.DeleteLoadBalancerV2(&i)
+	_, err := c.DeleteLoadBalancerV2(ctx, &i)
 	return err
 }
 
 // CreateNetworkLoadBalancer should only return one new NLB at a time
-func (c *AwsClient) CreateNetworkLoadBalancer(lbName, scheme, subnet string) ([]LoadBalancerV2, error) {
+func (c *LoadBalancers) CreateNetworkLoadBalancer(ctx context.Context, lbName, scheme, subnet string) ([]LoadBalancerV2, error) {
 	i := &elbv2.CreateLoadBalancerInput{
 		Name:   aws.String(lbName),
 		Scheme: aws.String(scheme),
@@ -87,7 +89,7 @@ func (c *AwsClient) CreateNetworkLoadBalancer(lbName, scheme, subnet string) ([]
 		Type: aws.String("network"),
 	}
 
-	result, err := c.CreateLoadBalancerV2(i)
+	result, err := c.CreateLoadBalancerV2(ctx, i)
 	if err != nil {
 		return []LoadBalancerV2{}, err
 	}
@@ -109,7 +111,7 @@ func (c *AwsClient) CreateNetworkLoadBalancer(lbName, scheme, subnet string) ([]
 }
 
 // CreateListenerForNLB creates a listener between target group and nlb given their arn
-func (c *AwsClient) CreateListenerForNLB(targetGroupArn, loadBalancerArn string) error {
+func (c *LoadBalancers) CreateListenerForNLB(ctx context.Context, targetGroupArn, loadBalancerArn string) error {
 	i := &elbv2.CreateListenerInput{
 		DefaultActions: []*elbv2.Action{
 			{
@@ -122,7 +124,7 @@ func (c *AwsClient) CreateListenerForNLB(targetGroupArn, loadBalancerArn string)
 		Protocol:        aws.String("TCP"),
 	}
 
-	_, err := c.CreateListenerV2(i)
+	_, err := c.CreateListenerV2(ctx, i)
 	if err != nil {
 		return err
 	}
@@ -130,7 +132,7 @@ func (c *AwsClient) CreateListenerForNLB(targetGroupArn, loadBalancerArn string)
 }
 
 // AddTagsForNLB creates needed tags for an NLB
-func (c *AwsClient) AddTagsForNLB(resourceARN string, clusterName string) error {
+func (c *LoadBalancers) AddTagsForNLB(ctx context.Context, resourceARN string, clusterName string) error {
 	i := &elbv2.AddTagsInput{
 		ResourceArns: []*string{
 			aws.String(resourceARN), // ext nlb resources arn
@@ -147,7 +149,7 @@ func (c *AwsClient) AddTagsForNLB(resourceARN string, clusterName string) error
 		},
 	}
 
-	_, err := c.AddTagsV2(i)
+	_, err := c.AddTagsV2(ctx, i)
 	if err != nil {
 		return err
 	}
@@ -155,14 +157,14 @@ func (c *AwsClient) AddTagsForNLB(resourceARN string, clusterName string) error
 }
 
 // GetTargetGroupArn by passing in targetGroup Name
-func (c *AwsClient) GetTargetGroupArn(targetGroupName string) (string, error) {
+func (c *LoadBalancers) GetTargetGroupArn(ctx context.Context, targetGroupName string) (string, error) {
 	i := &elbv2.DescribeTargetGroupsInput{
 		Names: []*string{
 			aws.String(targetGroupName),
 		},
 	}
 
-	result, err := c.DescribeTargetGroupsV2(i)
+	result, err := c.DescribeTargetGroupsV2(ctx, i)
 	if err != nil {
 		return "", err
 	}
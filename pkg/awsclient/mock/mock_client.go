@@ -5,6 +5,7 @@
 package mock_awsclient
 
 import (
+	context "context"
 	ec2 "github.com/aws/aws-sdk-go/service/ec2"
 	elbv2 "github.com/aws/aws-sdk-go/service/elbv2"
 	route53 "github.com/aws/aws-sdk-go/service/route53"
@@ -37,189 +38,189 @@ func (m *MockClient) EXPECT() *MockClientMockRecorder {
 }
 
 // DescribeLoadBalancersV2 mocks base method
-func (m *MockClient) DescribeLoadBalancersV2(arg0 *elbv2.DescribeLoadBalancersInput) (*elbv2.DescribeLoadBalancersOutput, error) {
+func (m *MockClient) DescribeLoadBalancersV2(arg0 context.Context, arg1 *elbv2.DescribeLoadBalancersInput) (*elbv2.DescribeLoadBalancersOutput, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DescribeLoadBalancersV2", arg0)
+	ret := m.ctrl.Call(m, "DescribeLoadBalancersV2", arg0, arg1)
 	ret0, _ := ret[0].(*elbv2.DescribeLoadBalancersOutput)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // DescribeLoadBalancersV2 indicates an expected call of DescribeLoadBalancersV2
-func (mr *MockClientMockRecorder) DescribeLoadBalancersV2(arg0 interface{}) *gomock.Call {
+func (mr *MockClientMockRecorder) DescribeLoadBalancersV2(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeLoadBalancersV2", reflect.TypeOf((*MockClient)(nil).DescribeLoadBalancersV2), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeLoadBalancersV2", reflect.TypeOf((*MockClient)(nil).DescribeLoadBalancersV2), arg0, arg1)
 }
 
 // DeleteLoadBalancerV2 mocks base method
-func (m *MockClient) DeleteLoadBalancerV2(arg0 *elbv2.DeleteLoadBalancerInput) (*elbv2.DeleteLoadBalancerOutput, error) {
+func (m *MockClient) DeleteLoadBalancerV2(arg0 context.Context, arg1 *elbv2.DeleteLoadBalancerInput) (*elbv2.DeleteLoadBalancerOutput, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteLoadBalancerV2", arg0)
+	ret := m.ctrl.Call(m, "DeleteLoadBalancerV2", arg0, arg1)
 	ret0, _ := ret[0].(*elbv2.DeleteLoadBalancerOutput)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // DeleteLoadBalancerV2 indicates an expected call of DeleteLoadBalancerV2
-func (mr *MockClientMockRecorder) DeleteLoadBalancerV2(arg0 interface{}) *gomock.Call {
+func (mr *MockClientMockRecorder) DeleteLoadBalancerV2(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteLoadBalancerV2", reflect.TypeOf((*MockClient)(nil).DeleteLoadBalancerV2), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteLoadBalancerV2", reflect.TypeOf((*MockClient)(nil).DeleteLoadBalancerV2), arg0, arg1)
 }
 
 // CreateLoadBalancerV2 mocks base method
-func (m *MockClient) CreateLoadBalancerV2(arg0 *elbv2.CreateLoadBalancerInput) (*elbv2.CreateLoadBalancerOutput, error) {
+func (m *MockClient) CreateLoadBalancerV2(arg0 context.Context, arg1 *elbv2.CreateLoadBalancerInput) (*elbv2.CreateLoadBalancerOutput, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateLoadBalancerV2", arg0)
+	ret := m.ctrl.Call(m, "CreateLoadBalancerV2", arg0, arg1)
 	ret0, _ := ret[0].(*elbv2.CreateLoadBalancerOutput)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // CreateLoadBalancerV2 indicates an expected call of CreateLoadBalancerV2
-func (mr *MockClientMockRecorder) CreateLoadBalancerV2(arg0 interface{}) *gomock.Call {
+func (mr *MockClientMockRecorder) CreateLoadBalancerV2(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateLoadBalancerV2", reflect.TypeOf((*MockClient)(nil).CreateLoadBalancerV2), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateLoadBalancerV2", reflect.TypeOf((*MockClient)(nil).CreateLoadBalancerV2), arg0, arg1)
 }
 
 // CreateTargetGroupV2 mocks base method
-func (m *MockClient) CreateTargetGroupV2(arg0 *elbv2.CreateTargetGroupInput) (*elbv2.CreateTargetGroupOutput, error) {
+func (m *MockClient) CreateTargetGroupV2(arg0 context.Context, arg1 *elbv2.CreateTargetGroupInput) (*elbv2.CreateTargetGroupOutput, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateTargetGroupV2", arg0)
+	ret := m.ctrl.Call(m, "CreateTargetGroupV2", arg0, arg1)
 	ret0, _ := ret[0].(*elbv2.CreateTargetGroupOutput)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // CreateTargetGroupV2 indicates an expected call of CreateTargetGroupV2
-func (mr *MockClientMockRecorder) CreateTargetGroupV2(arg0 interface{}) *gomock.Call {
+func (mr *MockClientMockRecorder) CreateTargetGroupV2(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTargetGroupV2", reflect.TypeOf((*MockClient)(nil).CreateTargetGroupV2), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTargetGroupV2", reflect.TypeOf((*MockClient)(nil).CreateTargetGroupV2), arg0, arg1)
 }
 
 // RegisterTargetsV2 mocks base method
-func (m *MockClient) RegisterTargetsV2(arg0 *elbv2.RegisterTargetsInput) (*elbv2.RegisterTargetsOutput, error) {
+func (m *MockClient) RegisterTargetsV2(arg0 context.Context, arg1 *elbv2.RegisterTargetsInput) (*elbv2.RegisterTargetsOutput, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "RegisterTargetsV2", arg0)
+	ret := m.ctrl.Call(m, "RegisterTargetsV2", arg0, arg1)
 	ret0, _ := ret[0].(*elbv2.RegisterTargetsOutput)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // RegisterTargetsV2 indicates an expected call of RegisterTargetsV2
-func (mr *MockClientMockRecorder) RegisterTargetsV2(arg0 interface{}) *gomock.Call {
+func (mr *MockClientMockRecorder) RegisterTargetsV2(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterTargetsV2", reflect.TypeOf((*MockClient)(nil).RegisterTargetsV2), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterTargetsV2", reflect.TypeOf((*MockClient)(nil).RegisterTargetsV2), arg0, arg1)
 }
 
 // CreateListenerV2 mocks base method
-func (m *MockClient) CreateListenerV2(arg0 *elbv2.CreateListenerInput) (*elbv2.CreateListenerOutput, error) {
+func (m *MockClient) CreateListenerV2(arg0 context.Context, arg1 *elbv2.CreateListenerInput) (*elbv2.CreateListenerOutput, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateListenerV2", arg0)
+	ret := m.ctrl.Call(m, "CreateListenerV2", arg0, arg1)
 	ret0, _ := ret[0].(*elbv2.CreateListenerOutput)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // CreateListenerV2 indicates an expected call of CreateListenerV2
-func (mr *MockClientMockRecorder) CreateListenerV2(arg0 interface{}) *gomock.Call {
+func (mr *MockClientMockRecorder) CreateListenerV2(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateListenerV2", reflect.TypeOf((*MockClient)(nil).CreateListenerV2), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateListenerV2", reflect.TypeOf((*MockClient)(nil).CreateListenerV2), arg0, arg1)
 }
 
 // DescribeTargetGroupsV2 mocks base method
-func (m *MockClient) DescribeTargetGroupsV2(arg0 *elbv2.DescribeTargetGroupsInput) (*elbv2.DescribeTargetGroupsOutput, error) {
+func (m *MockClient) DescribeTargetGroupsV2(arg0 context.Context, arg1 *elbv2.DescribeTargetGroupsInput) (*elbv2.DescribeTargetGroupsOutput, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DescribeTargetGroupsV2", arg0)
+	ret := m.ctrl.Call(m, "DescribeTargetGroupsV2", arg0, arg1)
 	ret0, _ := ret[0].(*elbv2.DescribeTargetGroupsOutput)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // DescribeTargetGroupsV2 indicates an expected call of DescribeTargetGroupsV2
-func (mr *MockClientMockRecorder) DescribeTargetGroupsV2(arg0 interface{}) *gomock.Call {
+func (mr *MockClientMockRecorder) DescribeTargetGroupsV2(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeTargetGroupsV2", reflect.TypeOf((*MockClient)(nil).DescribeTargetGroupsV2), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeTargetGroupsV2", reflect.TypeOf((*MockClient)(nil).DescribeTargetGroupsV2), arg0, arg1)
 }
 
 // AddTagsV2 mocks base method
-func (m *MockClient) AddTagsV2(arg0 *elbv2.AddTagsInput) (*elbv2.AddTagsOutput, error) {
+func (m *MockClient) AddTagsV2(arg0 context.Context, arg1 *elbv2.AddTagsInput) (*elbv2.AddTagsOutput, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "AddTagsV2", arg0)
+	ret := m.ctrl.Call(m, "AddTagsV2", arg0, arg1)
 	ret0, _ := ret[0].(*elbv2.AddTagsOutput)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // AddTagsV2 indicates an expected call of AddTagsV2
-func (mr *MockClientMockRecorder) AddTagsV2(arg0 interface{}) *gomock.Call {
+func (mr *MockClientMockRecorder) AddTagsV2(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddTagsV2", reflect.TypeOf((*MockClient)(nil).AddTagsV2), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddTagsV2", reflect.TypeOf((*MockClient)(nil).AddTagsV2), arg0, arg1)
 }
 
 // ChangeResourceRecordSets mocks base method
-func (m *MockClient) ChangeResourceRecordSets(arg0 *route53.ChangeResourceRecordSetsInput) (*route53.ChangeResourceRecordSetsOutput, error) {
+func (m *MockClient) ChangeResourceRecordSets(arg0 context.Context, arg1 *route53.ChangeResourceRecordSetsInput) (*route53.ChangeResourceRecordSetsOutput, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ChangeResourceRecordSets", arg0)
+	ret := m.ctrl.Call(m, "ChangeResourceRecordSets", arg0, arg1)
 	ret0, _ := ret[0].(*route53.ChangeResourceRecordSetsOutput)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // ChangeResourceRecordSets indicates an expected call of ChangeResourceRecordSets
-func (mr *MockClientMockRecorder) ChangeResourceRecordSets(arg0 interface{}) *gomock.Call {
+func (mr *MockClientMockRecorder) ChangeResourceRecordSets(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangeResourceRecordSets", reflect.TypeOf((*MockClient)(nil).ChangeResourceRecordSets), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangeResourceRecordSets", reflect.TypeOf((*MockClient)(nil).ChangeResourceRecordSets), arg0, arg1)
 }
 
 // ListHostedZonesByName mocks base method
-func (m *MockClient) ListHostedZonesByName(arg0 *route53.ListHostedZonesByNameInput) (*route53.ListHostedZonesByNameOutput, error) {
+func (m *MockClient) ListHostedZonesByName(arg0 context.Context, arg1 *route53.ListHostedZonesByNameInput) (*route53.ListHostedZonesByNameOutput, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListHostedZonesByName", arg0)
+	ret := m.ctrl.Call(m, "ListHostedZonesByName", arg0, arg1)
 	ret0, _ := ret[0].(*route53.ListHostedZonesByNameOutput)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // ListHostedZonesByName indicates an expected call of ListHostedZonesByName
-func (mr *MockClientMockRecorder) ListHostedZonesByName(arg0 interface{}) *gomock.Call {
+func (mr *MockClientMockRecorder) ListHostedZonesByName(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListHostedZonesByName", reflect.TypeOf((*MockClient)(nil).ListHostedZonesByName), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListHostedZonesByName", reflect.TypeOf((*MockClient)(nil).ListHostedZonesByName), arg0, arg1)
 }
 
 // DescribeSubnets mocks base method
-func (m *MockClient) DescribeSubnets(arg0 *ec2.DescribeSubnetsInput) (*ec2.DescribeSubnetsOutput, error) {
+func (m *MockClient) DescribeSubnets(arg0 context.Context, arg1 *ec2.DescribeSubnetsInput) (*ec2.DescribeSubnetsOutput, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DescribeSubnets", arg0)
+	ret := m.ctrl.Call(m, "DescribeSubnets", arg0, arg1)
 	ret0, _ := ret[0].(*ec2.DescribeSubnetsOutput)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // DescribeSubnets indicates an expected call of DescribeSubnets
-func (mr *MockClientMockRecorder) DescribeSubnets(arg0 interface{}) *gomock.Call {
+func (mr *MockClientMockRecorder) DescribeSubnets(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeSubnets", reflect.TypeOf((*MockClient)(nil).DescribeSubnets), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeSubnets", reflect.TypeOf((*MockClient)(nil).DescribeSubnets), arg0, arg1)
 }
 
 // SubnetNameToSubnetIDLookup mocks base method
-func (m *MockClient) SubnetNameToSubnetIDLookup(arg0 []string) ([]string, error) {
+func (m *MockClient) SubnetNameToSubnetIDLookup(arg0 context.Context, arg1 []string) ([]string, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "SubnetNameToSubnetIDLookup", arg0)
+	ret := m.ctrl.Call(m, "SubnetNameToSubnetIDLookup", arg0, arg1)
 	ret0, _ := ret[0].([]string)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // SubnetNameToSubnetIDLookup indicates an expected call of SubnetNameToSubnetIDLookup
-func (mr *MockClientMockRecorder) SubnetNameToSubnetIDLookup(arg0 interface{}) *gomock.Call {
+func (mr *MockClientMockRecorder) SubnetNameToSubnetIDLookup(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubnetNameToSubnetIDLookup", reflect.TypeOf((*MockClient)(nil).SubnetNameToSubnetIDLookup), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubnetNameToSubnetIDLookup", reflect.TypeOf((*MockClient)(nil).SubnetNameToSubnetIDLookup), arg0, arg1)
 }
 
 // DoesELBExist mocks base method
-func (m *MockClient) DoesELBExist(arg0 string) (bool, *awsclient.AWSLoadBalancer, error) {
+func (m *MockClient) DoesELBExist(arg0 context.Context, arg1 string) (bool, *awsclient.AWSLoadBalancer, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DoesELBExist", arg0)
+	ret := m.ctrl.Call(m, "DoesELBExist", arg0, arg1)
 	ret0, _ := ret[0].(bool)
 	ret1, _ := ret[1].(*awsclient.AWSLoadBalancer)
 	ret2, _ := ret[2].(error)
@@ -227,108 +228,108 @@ func (m *MockClient) DoesELBExist(arg0 string) (bool, *awsclient.AWSLoadBalancer
 }
 
 // DoesELBExist indicates an expected call of DoesELBExist
-func (mr *MockClientMockRecorder) DoesELBExist(arg0 interface{}) *gomock.Call {
+func (mr *MockClientMockRecorder) DoesELBExist(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DoesELBExist", reflect.TypeOf((*MockClient)(nil).DoesELBExist), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DoesELBExist", reflect.TypeOf((*MockClient)(nil).DoesELBExist), arg0, arg1)
 }
 
 // ListAllNLBs mocks base method
-func (m *MockClient) ListAllNLBs() ([]awsclient.LoadBalancerV2, error) {
+func (m *MockClient) ListAllNLBs(arg0 context.Context) ([]awsclient.LoadBalancerV2, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListAllNLBs")
+	ret := m.ctrl.Call(m, "ListAllNLBs", arg0)
 	ret0, _ := ret[0].([]awsclient.LoadBalancerV2)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // ListAllNLBs indicates an expected call of ListAllNLBs
-func (mr *MockClientMockRecorder) ListAllNLBs() *gomock.Call {
+func (mr *MockClientMockRecorder) ListAllNLBs(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAllNLBs", reflect.TypeOf((*MockClient)(nil).ListAllNLBs))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAllNLBs", reflect.TypeOf((*MockClient)(nil).ListAllNLBs), arg0)
 }
 
 // DeleteExternalLoadBalancer mocks base method
-func (m *MockClient) DeleteExternalLoadBalancer(arg0 string) error {
+func (m *MockClient) DeleteExternalLoadBalancer(arg0 context.Context, arg1 string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteExternalLoadBalancer", arg0)
+	ret := m.ctrl.Call(m, "DeleteExternalLoadBalancer", arg0, arg1)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeleteExternalLoadBalancer indicates an expected call of DeleteExternalLoadBalancer
-func (mr *MockClientMockRecorder) DeleteExternalLoadBalancer(arg0 interface{}) *gomock.Call {
+func (mr *MockClientMockRecorder) DeleteExternalLoadBalancer(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteExternalLoadBalancer", reflect.TypeOf((*MockClient)(nil).DeleteExternalLoadBalancer), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteExternalLoadBalancer", reflect.TypeOf((*MockClient)(nil).DeleteExternalLoadBalancer), arg0, arg1)
 }
 
 // CreateNetworkLoadBalancer mocks base method
-func (m *MockClient) CreateNetworkLoadBalancer(arg0, arg1, arg2 string) ([]awsclient.LoadBalancerV2, error) {
+func (m *MockClient) CreateNetworkLoadBalancer(arg0 context.Context, arg1, arg2, arg3 string) ([]awsclient.LoadBalancerV2, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateNetworkLoadBalancer", arg0, arg1, arg2)
+	ret := m.ctrl.Call(m, "CreateNetworkLoadBalancer", arg0, arg1, arg2, arg3)
 	ret0, _ := ret[0].([]awsclient.LoadBalancerV2)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // CreateNetworkLoadBalancer indicates an expected call of CreateNetworkLoadBalancer
-func (mr *MockClientMockRecorder) CreateNetworkLoadBalancer(arg0, arg1, arg2 interface{}) *gomock.Call {
+func (mr *MockClientMockRecorder) CreateNetworkLoadBalancer(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateNetworkLoadBalancer", reflect.TypeOf((*MockClient)(nil).CreateNetworkLoadBalancer), arg0, arg1, arg2)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateNetworkLoadBalancer", reflect.TypeOf((*MockClient)(nil).CreateNetworkLoadBalancer), arg0, arg1, arg2, arg3)
 }
 
 // CreateListenerForNLB mocks base method
-func (m *MockClient) CreateListenerForNLB(arg0, arg1 string) error {
+func (m *MockClient) CreateListenerForNLB(arg0 context.Context, arg1, arg2 string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateListenerForNLB", arg0, arg1)
+	ret := m.ctrl.Call(m, "CreateListenerForNLB", arg0, arg1, arg2)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // CreateListenerForNLB indicates an expected call of CreateListenerForNLB
-func (mr *MockClientMockRecorder) CreateListenerForNLB(arg0, arg1 interface{}) *gomock.Call {
+func (mr *MockClientMockRecorder) CreateListenerForNLB(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateListenerForNLB", reflect.TypeOf((*MockClient)(nil).CreateListenerForNLB), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateListenerForNLB", reflect.TypeOf((*MockClient)(nil).CreateListenerForNLB), arg0, arg1, arg2)
 }
 
 // GetTargetGroupArn mocks base method
-func (m *MockClient) GetTargetGroupArn(arg0 string) (string, error) {
+func (m *MockClient) GetTargetGroupArn(arg0 context.Context, arg1 string) (string, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetTargetGroupArn", arg0)
+	ret := m.ctrl.Call(m, "GetTargetGroupArn", arg0, arg1)
 	ret0, _ := ret[0].(string)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetTargetGroupArn indicates an expected call of GetTargetGroupArn
-func (mr *MockClientMockRecorder) GetTargetGroupArn(arg0 interface{}) *gomock.Call {
+func (mr *MockClientMockRecorder) GetTargetGroupArn(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTargetGroupArn", reflect.TypeOf((*MockClient)(nil).GetTargetGroupArn), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTargetGroupArn", reflect.TypeOf((*MockClient)(nil).GetTargetGroupArn), arg0, arg1)
 }
 
 // UpsertARecord mocks base method
-func (m *MockClient) UpsertARecord(arg0, arg1, arg2, arg3, arg4 string, arg5 bool) error {
+func (m *MockClient) UpsertARecord(arg0 context.Context, arg1, arg2, arg3, arg4, arg5 string, arg6 bool) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpsertARecord", arg0, arg1, arg2, arg3, arg4, arg5)
+	ret := m.ctrl.Call(m, "UpsertARecord", arg0, arg1, arg2, arg3, arg4, arg5, arg6)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // UpsertARecord indicates an expected call of UpsertARecord
-func (mr *MockClientMockRecorder) UpsertARecord(arg0, arg1, arg2, arg3, arg4, arg5 interface{}) *gomock.Call {
+func (mr *MockClientMockRecorder) UpsertARecord(arg0, arg1, arg2, arg3, arg4, arg5, arg6 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertARecord", reflect.TypeOf((*MockClient)(nil).UpsertARecord), arg0, arg1, arg2, arg3, arg4, arg5)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertARecord", reflect.TypeOf((*MockClient)(nil).UpsertARecord), arg0, arg1, arg2, arg3, arg4, arg5, arg6)
 }
 
 // DeleteARecord mocks base method
-func (m *MockClient) DeleteARecord(arg0, arg1, arg2, arg3 string, arg4 bool) error {
+func (m *MockClient) DeleteARecord(arg0 context.Context, arg1, arg2, arg3, arg4 string, arg5 bool) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteARecord", arg0, arg1, arg2, arg3, arg4)
+	ret := m.ctrl.Call(m, "DeleteARecord", arg0, arg1, arg2, arg3, arg4, arg5)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeleteARecord indicates an expected call of DeleteARecord
-func (mr *MockClientMockRecorder) DeleteARecord(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+func (mr *MockClientMockRecorder) DeleteARecord(arg0, arg1, arg2, arg3, arg4, arg5 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteARecord", reflect.TypeOf((*MockClient)(nil).DeleteARecord), arg0, arg1, arg2, arg3, arg4)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteARecord", reflect.TypeOf((*MockClient)(nil).DeleteARecord), arg0, arg1, arg2, arg3, arg4, arg5)
 }
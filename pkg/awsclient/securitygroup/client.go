@@ -0,0 +1,76 @@
+// Package securitygroup wraps the AWS calls managing the security groups the
+// cluster's load balancers are placed in
+package securitygroup
+
+import (
+	"context"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
+
+	"github.com/openshift/cloud-ingress-operator/pkg/awsclient/internal/calltimeout"
+)
+
+// API is the security group part of awsclient.AwsClient
+type API interface {
+	DescribeSecurityGroups(context.Context, *ec2.DescribeSecurityGroupsInput) (*ec2.DescribeSecurityGroupsOutput, error)
+	CreateSecurityGroup(context.Context, *ec2.CreateSecurityGroupInput) (*ec2.CreateSecurityGroupOutput, error)
+	DeleteSecurityGroup(context.Context, *ec2.DeleteSecurityGroupInput) (*ec2.DeleteSecurityGroupOutput, error)
+	AuthorizeSecurityGroupIngress(context.Context, *ec2.AuthorizeSecurityGroupIngressInput) (*ec2.AuthorizeSecurityGroupIngressOutput, error)
+	RevokeSecurityGroupIngress(context.Context, *ec2.RevokeSecurityGroupIngressInput) (*ec2.RevokeSecurityGroupIngressOutput, error)
+	// tag the security groups the operator creates
+	CreateTags(context.Context, *ec2.CreateTagsInput) (*ec2.CreateTagsOutput, error)
+}
+
+// SecurityGroups implements API with the AWS SDK
+type SecurityGroups struct {
+	ec2Client ec2iface.EC2API
+	// callTimeout bounds each API call. Unbounded if negative
+	callTimeout time.Duration
+}
+
+// New returns the SecurityGroups calling AWS with s
+func New(s *session.Session, callTimeout time.Duration) *SecurityGroups {
+	return &SecurityGroups{
+		ec2Client:   ec2.New(s),
+		callTimeout: callTimeout,
+	}
+}
+
+func (c *SecurityGroups) AuthorizeSecurityGroupIngress(ctx context.Context, i *ec2.AuthorizeSecurityGroupIngressInput) (*ec2.AuthorizeSecurityGroupIngressOutput, error) {
+	ctx, cancel := calltimeout.Context(ctx, c.callTimeout)
+	defer cancel()
+	return c.ec2Client.AuthorizeSecurityGroupIngressWithContext(ctx, i)
+}
+
+func (c *SecurityGroups) CreateSecurityGroup(ctx context.Context, i *ec2.CreateSecurityGroupInput) (*ec2.CreateSecurityGroupOutput, error) {
+	ctx, cancel := calltimeout.Context(ctx, c.callTimeout)
+	defer cancel()
+	return c.ec2Client.CreateSecurityGroupWithContext(ctx, i)
+}
+
+func (c *SecurityGroups) DeleteSecurityGroup(ctx context.Context, i *ec2.DeleteSecurityGroupInput) (*ec2.DeleteSecurityGroupOutput, error) {
+	ctx, cancel := calltimeout.Context(ctx, c.callTimeout)
+	defer cancel()
+	return c.ec2Client.DeleteSecurityGroupWithContext(ctx, i)
+}
+
+func (c *SecurityGroups) DescribeSecurityGroups(ctx context.Context, i *ec2.DescribeSecurityGroupsInput) (*ec2.DescribeSecurityGroupsOutput, error) {
+	ctx, cancel := calltimeout.Context(ctx, c.callTimeout)
+	defer cancel()
+	return c.ec2Client.DescribeSecurityGroupsWithContext(ctx, i)
+}
+
+func (c *SecurityGroups) RevokeSecurityGroupIngress(ctx context.Context, i *ec2.RevokeSecurityGroupIngressInput) (*ec2.RevokeSecurityGroupIngressOutput, error) {
+	ctx, cancel := calltimeout.Context(ctx, c.callTimeout)
+	defer cancel()
+	return c.ec2Client.RevokeSecurityGroupIngressWithContext(ctx, i)
+}
+
+func (c *SecurityGroups) CreateTags(ctx context.Context, i *ec2.CreateTagsInput) (*ec2.CreateTagsOutput, error) {
+	ctx, cancel := calltimeout.Context(ctx, c.callTimeout)
+	defer cancel()
+	return c.ec2Client.CreateTagsWithContext(ctx, i)
+}
@@ -9,5 +9,16 @@ func init() {
 	Register(
 		aws.ClientIdentifier,
 		func(kclient client.Client) CloudClient { return aws.NewClient(kclient) },
+		Capabilities{
+			SupportsStaticIP:            true,
+			SupportsListenerToggle:      true,
+			SupportsSGManagement:        true,
+			SupportsAccessLogs:          true,
+			SupportsAlarms:              true,
+			SupportsTLSPolicy:           true,
+			SupportsCertificate:         true,
+			SupportsStickiness:          true,
+			SupportsBackendNodeSelector: true,
+		},
 	)
 }
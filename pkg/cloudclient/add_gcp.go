@@ -9,5 +9,13 @@ func init() {
 	Register(
 		gcp.ClientIdentifier,
 		func(kclient client.Client) CloudClient { return gcp.NewClient(kclient) },
+		// A forwarding rule is the load balancer's only listener, and the
+		// passthrough load balancers pass TCP through without logs or TLS
+		Capabilities{
+			SupportsStaticIP:         true,
+			SupportsSGManagement:     true,
+			SupportsBackendSelection: true,
+			SupportsDNSTTL:           true,
+		},
 	)
 }
@@ -2,27 +2,47 @@ package aws
 
 import (
 	"context"
+	goError "errors"
 	"fmt"
-	"os"
+	"net/http"
+	"strings"
+	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/endpoints"
+	"github.com/aws/aws-sdk-go/aws/request"
 	"github.com/aws/aws-sdk-go/aws/session"
 
+	"github.com/aws/aws-sdk-go/service/acm"
+	"github.com/aws/aws-sdk-go/service/acm/acmiface"
+	"github.com/aws/aws-sdk-go/service/cloudwatch"
+	"github.com/aws/aws-sdk-go/service/cloudwatch/cloudwatchiface"
 	"github.com/aws/aws-sdk-go/service/ec2"
 	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
 	"github.com/aws/aws-sdk-go/service/elb"
 	"github.com/aws/aws-sdk-go/service/elb/elbiface"
 	"github.com/aws/aws-sdk-go/service/elbv2"
 	"github.com/aws/aws-sdk-go/service/elbv2/elbv2iface"
+	"github.com/aws/aws-sdk-go/service/iam"
+	"github.com/aws/aws-sdk-go/service/iam/iamiface"
+	"github.com/aws/aws-sdk-go/service/kms"
+	"github.com/aws/aws-sdk-go/service/kms/kmsiface"
 	"github.com/aws/aws-sdk-go/service/route53"
 	"github.com/aws/aws-sdk-go/service/route53/route53iface"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3iface"
+	"github.com/aws/aws-sdk-go/service/sts"
+	"github.com/aws/aws-sdk-go/service/sts/stsiface"
 
 	configv1 "github.com/openshift/api/config/v1"
-	cloudingressv1alpha1 "github.com/openshift/cloud-ingress-operator/pkg/apis/cloudingress/v1alpha1"
 	"github.com/openshift/cloud-ingress-operator/config"
+	cloudingressv1alpha1 "github.com/openshift/cloud-ingress-operator/pkg/apis/cloudingress/v1alpha1"
+	"github.com/openshift/cloud-ingress-operator/pkg/errors"
+	baseutils "github.com/openshift/cloud-ingress-operator/pkg/utils"
 	corev1 "k8s.io/api/core/v1"
-	"k8s.io/apimachinery/pkg/types"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
@@ -31,6 +51,10 @@ import (
 // ClientIdentifier is what kind of cloud this implement supports
 const ClientIdentifier configv1.PlatformType = configv1.AWSPlatformType
 
+// dryRunErrorCode is returned by AWS API calls refused because of a dry run.
+// It's the code EC2 uses for its own dry runs
+const dryRunErrorCode = "DryRunOperation"
+
 var (
 	log = logf.Log.WithName("aws_cloudclient")
 )
@@ -41,89 +65,504 @@ type Client struct {
 	route53Client route53iface.Route53API
 	elbClient     elbiface.ELBAPI
 	elbv2Client   elbv2iface.ELBV2API
+	// cloudWatchClient reads load balancer metrics
+	cloudWatchClient cloudwatchiface.CloudWatchAPI
+	// s3Client and kmsClient check the access log bucket and its key
+	s3Client  s3iface.S3API
+	kmsClient kmsiface.KMSAPI
+	// acmClient tells which certificate the load balancer should serve
+	acmClient acmiface.ACMAPI
+	// iamClient and stsClient check the operator's own permissions
+	iamClient iamiface.IAMAPI
+	stsClient stsiface.STSAPI
+	// tags are added to the AWS resources the operator creates
+	tags map[string]string
+	// partition is the ID of the region's AWS partition, eg aws-us-gov
+	partition string
+	// session and cache make the service clients of the copies auditedAs
+	// and withContext return
+	session *session.Session
+	cache   *describeCache
+	// callTimeout bounds each API call of the session. Unbounded if zero
+	callTimeout time.Duration
+	// timings are those of the reconcile of the context of withContext. The
+	// time its wait loops sleep is added to them
+	timings *baseutils.ReconcileTimings
 }
 
+// auditHandlerName names the handler adding the session's changes to the
+// audit trail
+const auditHandlerName = "cloudingress.AuditChange"
+
+// callContextHandlerName names the handler setting the context of the
+// session's calls
+const callContextHandlerName = "cloudingress.CallContext"
+
 // EnsureAdminAPIDNS implements cloudclient.CloudClient
 func (c *Client) EnsureAdminAPIDNS(ctx context.Context, kclient client.Client, instance *cloudingressv1alpha1.APIScheme, svc *corev1.Service) error {
-	return c.ensureAdminAPIDNS(ctx, kclient, instance, svc)
+	return classifyError(c.withContext(ctx).auditedAs(instance).ensureAdminAPIDNS(ctx, kclient, instance, svc))
+}
+
+// EnsureAdminAPIWeightedDNS implements cloudclient.CloudClient
+func (c *Client) EnsureAdminAPIWeightedDNS(ctx context.Context, kclient client.Client, instance *cloudingressv1alpha1.APIScheme, svc, migrationSvc *corev1.Service, weight int32) error {
+	return classifyError(c.withContext(ctx).auditedAs(instance).ensureAdminAPIWeightedDNS(ctx, kclient, instance, svc, migrationSvc, weight))
+}
+
+// EnsureAdminAPIFailoverDNS implements cloudclient.CloudClient
+func (c *Client) EnsureAdminAPIFailoverDNS(ctx context.Context, kclient client.Client, instance *cloudingressv1alpha1.APIScheme, svc, secondarySvc *corev1.Service) error {
+	return classifyError(c.withContext(ctx).auditedAs(instance).ensureAdminAPIFailoverDNS(ctx, kclient, instance, svc, secondarySvc))
 }
 
 // DeleteAdminAPIDNS implements cloudclient.CloudClient
 func (c *Client) DeleteAdminAPIDNS(ctx context.Context, kclient client.Client, instance *cloudingressv1alpha1.APIScheme, svc *corev1.Service) error {
-	return c.deleteAdminAPIDNS(ctx, kclient, instance, svc)
+	return classifyError(c.withContext(ctx).auditedAs(instance).deleteAdminAPIDNS(ctx, kclient, instance, svc))
+}
+
+// EnsureAdminAPIStaticIPs implements cloudclient.CloudClient
+func (c *Client) EnsureAdminAPIStaticIPs(ctx context.Context, kclient client.Client, instance *cloudingressv1alpha1.APIScheme, svc *corev1.Service) ([]string, error) {
+	result, err := c.withContext(ctx).auditedAs(instance).ensureAdminAPIStaticIPs(ctx, kclient, instance, svc)
+	return result, classifyError(err)
+}
+
+// ReleaseAdminAPIStaticIPs implements cloudclient.CloudClient
+func (c *Client) ReleaseAdminAPIStaticIPs(ctx context.Context, kclient client.Client, instance *cloudingressv1alpha1.APIScheme, allocationIDs []string) ([]string, error) {
+	result, err := c.withContext(ctx).auditedAs(instance).releaseAdminAPIStaticIPs(ctx, kclient, instance, allocationIDs)
+	return result, classifyError(err)
+}
+
+// EnsureAdminAPISecurityGroup implements cloudclient.CloudClient
+func (c *Client) EnsureAdminAPISecurityGroup(ctx context.Context, kclient client.Client, instance *cloudingressv1alpha1.APIScheme, svc *corev1.Service) (string, error) {
+	result, err := c.withContext(ctx).auditedAs(instance).ensureAdminAPISecurityGroup(ctx, kclient, instance, svc)
+	return result, classifyError(err)
+}
+
+// DeleteAdminAPISecurityGroup implements cloudclient.CloudClient
+func (c *Client) DeleteAdminAPISecurityGroup(ctx context.Context, kclient client.Client, instance *cloudingressv1alpha1.APIScheme) error {
+	return classifyError(c.withContext(ctx).auditedAs(instance).deleteAdminAPISecurityGroup(ctx, kclient, instance))
+}
+
+// DeleteAdminAPILoadBalancer implements cloudclient.CloudClient
+func (c *Client) DeleteAdminAPILoadBalancer(ctx context.Context, kclient client.Client, instance *cloudingressv1alpha1.APIScheme, svc *corev1.Service) error {
+	return classifyError(c.withContext(ctx).auditedAs(instance).deleteAdminAPILoadBalancer(ctx, kclient, instance, svc))
+}
+
+// EnsureAdminAPIIPAddressType implements cloudclient.CloudClient
+func (c *Client) EnsureAdminAPIIPAddressType(ctx context.Context, kclient client.Client, instance *cloudingressv1alpha1.APIScheme, svc *corev1.Service) error {
+	return classifyError(c.withContext(ctx).auditedAs(instance).ensureAdminAPIIPAddressType(ctx, kclient, instance, svc))
+}
+
+// EnsureAdminAPIAccessLogs implements cloudclient.CloudClient
+func (c *Client) EnsureAdminAPIAccessLogs(ctx context.Context, kclient client.Client, instance *cloudingressv1alpha1.APIScheme, svc *corev1.Service) error {
+	return classifyError(c.withContext(ctx).auditedAs(instance).ensureAdminAPIAccessLogs(ctx, kclient, instance, svc))
+}
+
+// EnsureAdminAPIAlarms implements cloudclient.CloudClient
+func (c *Client) EnsureAdminAPIAlarms(ctx context.Context, kclient client.Client, instance *cloudingressv1alpha1.APIScheme, svc *corev1.Service) error {
+	return classifyError(c.withContext(ctx).auditedAs(instance).ensureAdminAPIAlarms(ctx, kclient, instance, svc))
+}
+
+// EnsureAdminAPITLSPolicy implements cloudclient.CloudClient
+func (c *Client) EnsureAdminAPITLSPolicy(ctx context.Context, kclient client.Client, instance *cloudingressv1alpha1.APIScheme, svc *corev1.Service) error {
+	return classifyError(c.withContext(ctx).auditedAs(instance).ensureAdminAPITLSPolicy(ctx, kclient, instance, svc))
+}
+
+// EnsureAdminAPICertificate implements cloudclient.CloudClient
+func (c *Client) EnsureAdminAPICertificate(ctx context.Context, kclient client.Client, instance *cloudingressv1alpha1.APIScheme, svc *corev1.Service) error {
+	return classifyError(c.withContext(ctx).auditedAs(instance).ensureAdminAPICertificate(ctx, kclient, instance, svc))
+}
+
+// EnsureAdminAPIStickiness implements cloudclient.CloudClient
+func (c *Client) EnsureAdminAPIStickiness(ctx context.Context, kclient client.Client, instance *cloudingressv1alpha1.APIScheme, svc *corev1.Service) error {
+	return classifyError(c.withContext(ctx).auditedAs(instance).ensureAdminAPIStickiness(ctx, kclient, instance, svc))
+}
+
+// EnsureAdminAPIMasterMachines implements cloudclient.CloudClient
+func (c *Client) EnsureAdminAPIMasterMachines(ctx context.Context, kclient client.Client, instance *cloudingressv1alpha1.APIScheme, svc *corev1.Service) error {
+	return classifyError(c.withContext(ctx).ensureAdminAPIMasterMachines(ctx, kclient, instance, svc))
+}
+
+// DeleteAdminAPIAlarms implements cloudclient.CloudClient
+func (c *Client) DeleteAdminAPIAlarms(ctx context.Context, kclient client.Client, instance *cloudingressv1alpha1.APIScheme) error {
+	return classifyError(c.withContext(ctx).auditedAs(instance).deleteAdminAPIAlarms(ctx, kclient, instance))
+}
+
+// RepairAdminAPIDrift implements cloudclient.CloudClient
+func (c *Client) RepairAdminAPIDrift(ctx context.Context, kclient client.Client, instance *cloudingressv1alpha1.APIScheme, svc *corev1.Service) ([]string, error) {
+	result, err := c.withContext(ctx).auditedAs(instance).repairAdminAPIDrift(ctx, kclient, instance, svc)
+	return result, classifyError(err)
+}
+
+// CheckAdminAPIDrift implements cloudclient.CloudClient
+func (c *Client) CheckAdminAPIDrift(ctx context.Context, kclient client.Client, instance *cloudingressv1alpha1.APIScheme, svc *corev1.Service) ([]string, error) {
+	result, err := c.withContext(ctx).checkAdminAPIDrift(ctx, kclient, instance, svc)
+	return result, classifyError(err)
+}
+
+// GetAdminAPIRegisteredInstances implements cloudclient.CloudClient
+func (c *Client) GetAdminAPIRegisteredInstances(ctx context.Context, kclient client.Client, instance *cloudingressv1alpha1.APIScheme, svc *corev1.Service) ([]string, error) {
+	result, err := c.withContext(ctx).getAdminAPIRegisteredInstances(ctx, kclient, instance, svc)
+	return result, classifyError(err)
+}
+
+// GetAdminAPIHealthyInstances implements cloudclient.CloudClient
+func (c *Client) GetAdminAPIHealthyInstances(ctx context.Context, kclient client.Client, instance *cloudingressv1alpha1.APIScheme, svc *corev1.Service) ([]string, error) {
+	result, err := c.withContext(ctx).getAdminAPIHealthyInstances(ctx, kclient, instance, svc)
+	return result, classifyError(err)
+}
+
+// GetAdminAPIInstanceHealth implements cloudclient.CloudClient
+func (c *Client) GetAdminAPIInstanceHealth(ctx context.Context, kclient client.Client, instance *cloudingressv1alpha1.APIScheme, svc *corev1.Service) ([]cloudingressv1alpha1.RegisteredInstance, error) {
+	result, err := c.withContext(ctx).getAdminAPIInstanceHealth(ctx, kclient, instance, svc)
+	return result, classifyError(err)
+}
+
+// AdminAPILoadBalancerExists implements cloudclient.CloudClient
+func (c *Client) AdminAPILoadBalancerExists(ctx context.Context, kclient client.Client, instance *cloudingressv1alpha1.APIScheme, svc *corev1.Service) (bool, error) {
+	result, err := c.withContext(ctx).adminAPILoadBalancerExists(ctx, kclient, instance, svc)
+	return result, classifyError(err)
+}
+
+// GetAdminAPILoadBalancerAttributes implements cloudclient.CloudClient
+func (c *Client) GetAdminAPILoadBalancerAttributes(ctx context.Context, kclient client.Client, instance *cloudingressv1alpha1.APIScheme, svc *corev1.Service) (*cloudingressv1alpha1.LoadBalancerAttributes, error) {
+	result, err := c.withContext(ctx).getAdminAPILoadBalancerAttributes(ctx, kclient, instance, svc)
+	return result, classifyError(err)
+}
+
+// GetAdminAPINameServers implements cloudclient.CloudClient
+func (c *Client) GetAdminAPINameServers(ctx context.Context, kclient client.Client, instance *cloudingressv1alpha1.APIScheme) ([]string, error) {
+	result, err := c.withContext(ctx).getAdminAPINameServers(ctx, kclient, instance)
+	return result, classifyError(err)
+}
+
+// CheckPermissions implements cloudclient.CloudClient
+func (c *Client) CheckPermissions(ctx context.Context, kclient client.Client) ([]string, error) {
+	result, err := c.withContext(ctx).checkPermissions(ctx, kclient)
+	return result, classifyError(err)
+}
+
+// CheckCredentials implements cloudclient.CloudClient
+func (c *Client) CheckCredentials(ctx context.Context, kclient client.Client) error {
+	return classifyError(c.withContext(ctx).checkCredentials(ctx, kclient))
+}
+
+// GetLoadBalancerQuota implements cloudclient.CloudClient
+func (c *Client) GetLoadBalancerQuota(ctx context.Context, kclient client.Client, network bool) (int, int, error) {
+	used, limit, err := c.withContext(ctx).getLoadBalancerQuota(ctx, kclient, network)
+	return used, limit, classifyError(err)
+}
+
+// ValidateAdminAPISubnets implements cloudclient.CloudClient
+func (c *Client) ValidateAdminAPISubnets(ctx context.Context, kclient client.Client, subnetIDs []string, internal bool) error {
+	return classifyError(c.withContext(ctx).validateAdminAPISubnets(ctx, kclient, subnetIDs, internal))
 }
 
 // EnsureSSHDNS implements cloudclient.CloudClient
 func (c *Client) EnsureSSHDNS(ctx context.Context, kclient client.Client, instance *cloudingressv1alpha1.SSHD, svc *corev1.Service) error {
-	return c.ensureSSHDNS(ctx, kclient, instance, svc)
+	return classifyError(c.withContext(ctx).auditedAs(instance).ensureSSHDNS(ctx, kclient, instance, svc))
 }
 
 // DeleteSSHDNS implements cloudclient.CloudClient
 func (c *Client) DeleteSSHDNS(ctx context.Context, kclient client.Client, instance *cloudingressv1alpha1.SSHD, svc *corev1.Service) error {
-	return c.deleteSSHDNS(ctx, kclient, instance, svc)
+	return classifyError(c.withContext(ctx).auditedAs(instance).deleteSSHDNS(ctx, kclient, instance, svc))
 }
 
 // SetDefaultAPIPrivate implements cloudclient.CloudClient
 func (c *Client) SetDefaultAPIPrivate(ctx context.Context, kclient client.Client, instance *cloudingressv1alpha1.PublishingStrategy) error {
-	return c.setDefaultAPIPrivate(ctx, kclient, instance)
+	return classifyError(c.withContext(ctx).auditedAs(instance).setDefaultAPIPrivate(ctx, kclient, instance))
 }
 
 // SetDefaultAPIPublic implements cloudclient.CloudClient
 func (c *Client) SetDefaultAPIPublic(ctx context.Context, kclient client.Client, instance *cloudingressv1alpha1.PublishingStrategy) error {
-	return c.setDefaultAPIPublic(ctx, kclient, instance)
+	return classifyError(c.withContext(ctx).auditedAs(instance).setDefaultAPIPublic(ctx, kclient, instance))
+}
+
+// EnsureApplicationIngressDNS implements cloudclient.CloudClient
+func (c *Client) EnsureApplicationIngressDNS(ctx context.Context, kclient client.Client, instance *cloudingressv1alpha1.PublishingStrategy) error {
+	return classifyError(c.withContext(ctx).auditedAs(instance).ensureApplicationIngressDNS(ctx, kclient, instance))
+}
+
+// EnsureRouterServiceDNS implements cloudclient.CloudClient
+func (c *Client) EnsureRouterServiceDNS(ctx context.Context, kclient client.Client, appIngress *cloudingressv1alpha1.ApplicationIngress, svc *corev1.Service) error {
+	return classifyError(c.withContext(ctx).auditedAs(svc).ensureRouterServiceDNS(ctx, kclient, appIngress, svc))
+}
+
+// EnsureApplicationIngressLoadBalancers implements cloudclient.CloudClient
+func (c *Client) EnsureApplicationIngressLoadBalancers(ctx context.Context, kclient client.Client, instance *cloudingressv1alpha1.PublishingStrategy) error {
+	return classifyError(c.withContext(ctx).auditedAs(instance).ensureApplicationIngressLoadBalancers(ctx, kclient, instance))
 }
 
-func newClient(accessID, accessSecret, token, region string) (*Client, error) {
-	awsConfig := &aws.Config{Region: aws.String(region)}
-	if token == "" {
-		os.Setenv("AWS_ACCESS_KEY_ID", accessID)
-		os.Setenv("AWS_SECRET_ACCESS_KEY", accessSecret)
-	} else {
-		awsConfig.Credentials = credentials.NewStaticCredentials(accessID, accessSecret, token)
+// newClient returns a Client whose session signs its calls with the keys of
+// provider, which are read again once they're rejected
+func newClient(provider *secretCredentialsProvider, region string, settings cloudingressv1alpha1.CloudIngressConfigSpec, transport http.RoundTripper) (*Client, error) {
+	partitionID := settings.AWSPartition
+	if partitionID == "" {
+		partition, ok := endpoints.PartitionForRegion(endpoints.DefaultPartitions(), region)
+		if !ok {
+			// A region newer than the SDK. It's most likely in the commercial partition
+			log.Info("Unknown AWS region, assuming it's in the aws partition", "region", region)
+			partition = endpoints.AwsPartition()
+		}
+		partitionID = partition.ID()
+	}
+	awsConfig := &aws.Config{
+		Region:           aws.String(region),
+		EndpointResolver: serviceEndpointResolver(settings.AWSServiceEndpoints),
+		HTTPClient:       &http.Client{Transport: transport},
+		Credentials:      credentials.NewCredentials(provider),
+	}
+	if settings.Disconnected {
+		// The global endpoints are public, the regional ones can be reached
+		// through VPC endpoints
+		awsConfig.STSRegionalEndpoint = endpoints.RegionalSTSEndpoint
+		awsConfig.S3UsEast1RegionalEndpoint = endpoints.RegionalS3UsEast1Endpoint
 	}
 	s, err := session.NewSession(awsConfig)
 	if err != nil {
 		return nil, err
 	}
-	return &Client{
-		ec2Client:     ec2.New(s),
-		elbClient:     elb.New(s),
-		elbv2Client:   elbv2.New(s),
-		route53Client: route53.New(s),
-	}, nil
+	if limiter := baseutils.GetCloudAPIRateLimiter(settings.RateLimit); limiter != nil {
+		s.Handlers.Send.PushFront(func(r *request.Request) {
+			limiter.Accept()
+		})
+	}
+	if settings.DryRun {
+		s.Handlers.Validate.PushBack(refuseMutatingRequest)
+	}
+	callTimeout := config.DefaultCloudAPICallTimeout
+	if settings.CloudAPICallTimeout != nil {
+		callTimeout = settings.CloudAPICallTimeout.Duration
+	}
+	s.Handlers.Build.PushBackNamed(request.NamedHandler{Name: callContextHandlerName, Fn: callContext(context.Background(), callTimeout)})
+	cache := newDescribeCache(describeCacheTTL)
+	s.Handlers.Complete.PushBack(cache.invalidateOnChange)
+	s.Handlers.Complete.PushBackNamed(request.NamedHandler{Name: auditHandlerName, Fn: auditChange("")})
+	s.Handlers.Complete.PushBack(provider.recheckOnAuthFailure)
+	c := &Client{
+		tags:        settings.Tags,
+		partition:   partitionID,
+		cache:       cache,
+		callTimeout: callTimeout,
+	}
+	c.useSession(s)
+	return c, nil
+}
+
+// useSession makes the service clients of c from s
+func (c *Client) useSession(s *session.Session) {
+	c.session = s
+	c.ec2Client = cachedEC2{EC2API: ec2.New(s), cache: c.cache}
+	c.elbClient = cachedELB{ELBAPI: elb.New(s), cache: c.cache}
+	c.elbv2Client = cachedELBV2{ELBV2API: elbv2.New(s), cache: c.cache}
+	c.route53Client = route53.New(s)
+	c.cloudWatchClient = cloudwatch.New(s)
+	c.s3Client = s3.New(s)
+	c.kmsClient = kms.New(s)
+	c.acmClient = acm.New(s)
+	c.iamClient = iam.New(s)
+	c.stsClient = sts.New(s)
+}
+
+// auditedAs returns a copy of c whose changes are added to the audit trail as
+// made for caller. The copy shares the rate limit, dry run and describe cache
+// of c. A Client without a session, like those of the tests, is returned as
+// it is
+func (c *Client) auditedAs(caller metav1.Object) *Client {
+	if c.session == nil {
+		return c
+	}
+	s := c.session.Copy()
+	s.Handlers.Complete.Swap(auditHandlerName, request.NamedHandler{Name: auditHandlerName, Fn: auditChange(baseutils.AuditCaller(caller))})
+	audited := *c
+	audited.useSession(s)
+	return &audited
+}
+
+// withContext returns a copy of c whose API calls are cancelled with ctx, or
+// once they take longer than the call timeout of c, and timed in the
+// reconcile of ctx. The copy shares everything else with c. A Client without
+// a session is returned as it is
+func (c *Client) withContext(ctx context.Context) *Client {
+	if c.session == nil {
+		return c
+	}
+	s := c.session.Copy()
+	s.Handlers.Build.Swap(callContextHandlerName, request.NamedHandler{Name: callContextHandlerName, Fn: callContext(ctx, c.callTimeout)})
+	bound := *c
+	bound.useSession(s)
+	bound.timings = baseutils.ReconcileTimingsFrom(ctx)
+	return &bound
+}
+
+// wait sleeps for d, as part of a wait loop of the reconcile of c
+func (c *Client) wait(d time.Duration) {
+	defer c.timings.Start(baseutils.PhaseWait)()
+	time.Sleep(d)
+}
+
+// callContext returns a handler giving each request a context derived from
+// parent, cancelled once the request completes or takes longer than timeout.
+// A zero timeout leaves the requests bounded by parent only. The time until
+// the request completes is added to the reconcile of parent
+func callContext(parent context.Context, timeout time.Duration) func(*request.Request) {
+	return func(r *request.Request) {
+		stop := baseutils.TimePhase(parent, baseutils.PhaseCloud)
+		r.Handlers.Complete.PushBack(func(*request.Request) { stop() })
+		if timeout <= 0 {
+			r.SetContext(parent)
+			return
+		}
+		ctx, cancel := context.WithTimeout(parent, timeout)
+		r.SetContext(ctx)
+		r.Handlers.Complete.PushBack(func(*request.Request) { cancel() })
+	}
+}
+
+// serviceEndpointResolver resolves the endpoints of the AWS services through
+// the partition of the region, which also gives the signing region of global
+// services like Route53. overrides replace the URL of a service's endpoint,
+// and its signing region if they have one
+func serviceEndpointResolver(overrides []cloudingressv1alpha1.AWSServiceEndpoint) endpoints.Resolver {
+	return endpoints.ResolverFunc(func(service, region string, opts ...func(*endpoints.Options)) (endpoints.ResolvedEndpoint, error) {
+		resolved, err := endpoints.DefaultResolver().EndpointFor(service, region, opts...)
+		for _, override := range overrides {
+			if override.Name != service {
+				continue
+			}
+			if err != nil {
+				// the partition doesn't know the service, so sign for the region
+				resolved = endpoints.ResolvedEndpoint{SigningRegion: region}
+			}
+			resolved.URL = override.URL
+			if override.SigningRegion != "" {
+				resolved.SigningRegion = override.SigningRegion
+			}
+			return resolved, nil
+		}
+		return resolved, err
+	})
+}
+
+// mergeServiceEndpoints returns the endpoints of defaults, replaced by those of
+// overrides for the same service
+func mergeServiceEndpoints(defaults, overrides []cloudingressv1alpha1.AWSServiceEndpoint) []cloudingressv1alpha1.AWSServiceEndpoint {
+	overridden := map[string]bool{}
+	for _, override := range overrides {
+		overridden[override.Name] = true
+	}
+	merged := []cloudingressv1alpha1.AWSServiceEndpoint{}
+	for _, endpoint := range defaults {
+		if !overridden[endpoint.Name] {
+			merged = append(merged, endpoint)
+		}
+	}
+	return append(merged, overrides...)
+}
+
+// refuseMutatingRequest fails the AWS API calls that would change something,
+// and logs them instead
+func refuseMutatingRequest(r *request.Request) {
+	if isReadOnlyOperation(r.Operation.Name) {
+		return
+	}
+	log.Info("Dry run, not calling AWS", "service", r.ClientInfo.ServiceName, "operation", r.Operation.Name, "params", r.Params)
+	r.Error = awserr.New(dryRunErrorCode, "Request would have succeeded, but the operator is in dry run mode", nil)
+}
+
+// auditChange returns a handler adding the AWS API calls that change
+// something to the audit trail, as made for caller. It runs once the call is
+// complete, so refusals of a dry run are in the audit trail too
+func auditChange(caller string) func(r *request.Request) {
+	return func(r *request.Request) {
+		if isReadOnlyOperation(r.Operation.Name) {
+			return
+		}
+		baseutils.Audit(baseutils.AuditRecord{
+			Cloud:     "aws",
+			Service:   r.ClientInfo.ServiceName,
+			Operation: r.Operation.Name,
+			Params:    r.Params,
+			Caller:    caller,
+			Err:       r.Error,
+		})
+	}
+}
+
+// isReadOnlyOperation checks if an AWS API operation only reads
+func isReadOnlyOperation(name string) bool {
+	return strings.HasPrefix(name, "Describe") || strings.HasPrefix(name, "List") || strings.HasPrefix(name, "Get") || strings.HasPrefix(name, "Simulate")
+}
+
+// classifyError turns an AWS API error into one of the cloud error kinds of
+// pkg/errors. Other errors, including the operator's own and the refusals of a
+// dry run, are returned as they are
+func classifyError(err error) error {
+	var awsErr awserr.Error
+	if !goError.As(err, &awsErr) {
+		return err
+	}
+	if kind := awsErrorKind(awsErr); kind != nil {
+		return errors.NewCloudError(kind, err)
+	}
+	return err
+}
+
+// awsErrorKind returns the cloud error kind of an AWS API error, or nil if it
+// has none
+func awsErrorKind(err awserr.Error) error {
+	code := err.Code()
+	switch {
+	case code == dryRunErrorCode:
+		return nil
+	case request.IsErrorThrottle(err):
+		return errors.ErrThrottled
+	case strings.Contains(code, "NotFound") || strings.HasPrefix(code, "NoSuch"):
+		return errors.ErrNotFound
+	case code == "AccessDenied" || code == "AccessDeniedException" || code == "UnauthorizedOperation" ||
+		code == "AuthFailure" || code == "InvalidClientTokenId" || code == "ExpiredToken" || code == "OptInRequired":
+		return errors.ErrPermissionDenied
+	case code == "DependencyViolation" || code == "ResourceInUse":
+		return errors.ErrDependencyViolation
+	case request.IsErrorRetryable(err) || code == "InternalError" || code == "InternalFailure" || code == "ServiceUnavailable":
+		return errors.ErrTransient
+	}
+	if requestFailure, ok := err.(awserr.RequestFailure); ok && requestFailure.StatusCode() >= 500 {
+		return errors.ErrTransient
+	}
+	return nil
 }
 
 // NewClient creates a new CloudClient for use with AWS.
 func NewClient(kclient client.Client) *Client {
-	region, err := getClusterRegion(kclient)
-	if err != nil {
-		panic(fmt.Sprintf("Couldn't get cluster region %s", err.Error()))
-	}
-	secret := &corev1.Secret{}
-	err = kclient.Get(
-		context.TODO(),
-		types.NamespacedName{
-			Name:      config.AWSSecretName,
-			Namespace: config.OperatorNamespace,
-		},
-		secret)
+	ingressConfig, err := baseutils.GetCloudIngressConfig(kclient)
 	if err != nil {
-		panic(fmt.Sprintf("Couldn't get Secret with credentials %s", err.Error()))
+		panic(fmt.Sprintf("Couldn't get the CloudIngressConfig %s", err.Error()))
 	}
-	accessKeyID, ok := secret.Data["aws_access_key_id"]
-	if !ok {
-		panic("Access credentials missing key")
+	region := ingressConfig.Spec.AWSRegion
+	if region == "" {
+		region, err = getClusterRegion(kclient)
+		if err != nil {
+			panic(fmt.Sprintf("Couldn't get cluster region %s", err.Error()))
+		}
 	}
-	secretAccessKey, ok := secret.Data["aws_secret_access_key"]
-	if !ok {
-		panic("Access credentials missing secret key")
+	// The keys are read again from the Secret once it's rotated
+	provider, err := newSecretCredentialsProvider(kclient)
+	if err != nil {
+		panic(err.Error())
+	}
+	// The endpoints the cluster was installed with, eg VPC endpoints, unless
+	// the CloudIngressConfig overrides them
+	installEndpoints, err := baseutils.GetAWSServiceEndpoints(kclient)
+	if err != nil {
+		panic(fmt.Sprintf("Couldn't get the cluster's AWS service endpoints %s", err.Error()))
 	}
+	settings := ingressConfig.Spec.DeepCopy()
+	settings.AWSServiceEndpoints = mergeServiceEndpoints(installEndpoints, settings.AWSServiceEndpoints)
 
 	c, err := newClient(
-		string(accessKeyID),
-		string(secretAccessKey),
-		"",
-		region)
+		provider,
+		region,
+		*settings,
+		baseutils.NewCloudAPITransport(kclient))
 
 	if err != nil {
 		panic(fmt.Sprintf("Couldn't create AWS client %s", err.Error()))
@@ -0,0 +1,190 @@
+package aws
+
+import (
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws/awsutil"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
+	"github.com/aws/aws-sdk-go/service/elb"
+	"github.com/aws/aws-sdk-go/service/elb/elbiface"
+	"github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/aws/aws-sdk-go/service/elbv2/elbv2iface"
+)
+
+// describeCacheTTL is how long the result of a Describe call is reused. It's
+// about as long as a reconcile, whose helpers describe the same resources
+// over and over, but short enough for the next reconcile to see the changes
+// made outside the operator
+const describeCacheTTL = 10 * time.Second
+
+// describeCache holds the results of the Describe calls the operator repeats,
+// by operation and input. Every change made through the session empties it
+type describeCache struct {
+	mu      sync.Mutex
+	ttl     time.Duration
+	entries map[string]describeCacheEntry
+}
+
+type describeCacheEntry struct {
+	output  interface{}
+	expires time.Time
+}
+
+func newDescribeCache(ttl time.Duration) *describeCache {
+	return &describeCache{
+		ttl:     ttl,
+		entries: make(map[string]describeCacheEntry),
+	}
+}
+
+// get returns a copy of the cached output of the operation with input, calling
+// describe to fill the cache if it isn't there yet. Errors aren't cached
+func (c *describeCache) get(operation string, input interface{}, describe func() (interface{}, error)) (interface{}, error) {
+	key := operation + awsutil.Prettify(input)
+	c.mu.Lock()
+	entry, ok := c.entries[key]
+	c.mu.Unlock()
+	if ok && time.Now().Before(entry.expires) {
+		return awsutil.CopyOf(entry.output), nil
+	}
+
+	output, err := describe()
+	if err != nil {
+		return nil, err
+	}
+	c.mu.Lock()
+	c.entries[key] = describeCacheEntry{
+		output:  awsutil.CopyOf(output),
+		expires: time.Now().Add(c.ttl),
+	}
+	c.mu.Unlock()
+	return output, nil
+}
+
+// invalidate empties the cache
+func (c *describeCache) invalidate() {
+	c.mu.Lock()
+	c.entries = make(map[string]describeCacheEntry)
+	c.mu.Unlock()
+}
+
+// invalidateOnChange is a request handler emptying the cache once an AWS API
+// call that changes something completes, whether it succeeded or not
+func (c *describeCache) invalidateOnChange(r *request.Request) {
+	if !isReadOnlyOperation(r.Operation.Name) {
+		c.invalidate()
+	}
+}
+
+// cachedEC2 serves the EC2 Describe calls the operator repeats from cache
+type cachedEC2 struct {
+	ec2iface.EC2API
+	cache *describeCache
+}
+
+func (c cachedEC2) DescribeInstances(input *ec2.DescribeInstancesInput) (*ec2.DescribeInstancesOutput, error) {
+	output, err := c.cache.get("ec2.DescribeInstances", input, func() (interface{}, error) {
+		return c.EC2API.DescribeInstances(input)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return output.(*ec2.DescribeInstancesOutput), nil
+}
+
+func (c cachedEC2) DescribeRouteTables(input *ec2.DescribeRouteTablesInput) (*ec2.DescribeRouteTablesOutput, error) {
+	output, err := c.cache.get("ec2.DescribeRouteTables", input, func() (interface{}, error) {
+		return c.EC2API.DescribeRouteTables(input)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return output.(*ec2.DescribeRouteTablesOutput), nil
+}
+
+func (c cachedEC2) DescribeSecurityGroups(input *ec2.DescribeSecurityGroupsInput) (*ec2.DescribeSecurityGroupsOutput, error) {
+	output, err := c.cache.get("ec2.DescribeSecurityGroups", input, func() (interface{}, error) {
+		return c.EC2API.DescribeSecurityGroups(input)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return output.(*ec2.DescribeSecurityGroupsOutput), nil
+}
+
+func (c cachedEC2) DescribeSubnets(input *ec2.DescribeSubnetsInput) (*ec2.DescribeSubnetsOutput, error) {
+	output, err := c.cache.get("ec2.DescribeSubnets", input, func() (interface{}, error) {
+		return c.EC2API.DescribeSubnets(input)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return output.(*ec2.DescribeSubnetsOutput), nil
+}
+
+// cachedELB serves the classic ELB Describe calls the operator repeats from
+// cache. Instance health isn't cached, as it's polled
+type cachedELB struct {
+	elbiface.ELBAPI
+	cache *describeCache
+}
+
+func (c cachedELB) DescribeLoadBalancers(input *elb.DescribeLoadBalancersInput) (*elb.DescribeLoadBalancersOutput, error) {
+	output, err := c.cache.get("elb.DescribeLoadBalancers", input, func() (interface{}, error) {
+		return c.ELBAPI.DescribeLoadBalancers(input)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return output.(*elb.DescribeLoadBalancersOutput), nil
+}
+
+func (c cachedELB) DescribeLoadBalancerAttributes(input *elb.DescribeLoadBalancerAttributesInput) (*elb.DescribeLoadBalancerAttributesOutput, error) {
+	output, err := c.cache.get("elb.DescribeLoadBalancerAttributes", input, func() (interface{}, error) {
+		return c.ELBAPI.DescribeLoadBalancerAttributes(input)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return output.(*elb.DescribeLoadBalancerAttributesOutput), nil
+}
+
+// cachedELBV2 serves the ELBv2 Describe calls the operator repeats from cache.
+// Target health isn't cached, as it's polled
+type cachedELBV2 struct {
+	elbv2iface.ELBV2API
+	cache *describeCache
+}
+
+func (c cachedELBV2) DescribeLoadBalancers(input *elbv2.DescribeLoadBalancersInput) (*elbv2.DescribeLoadBalancersOutput, error) {
+	output, err := c.cache.get("elbv2.DescribeLoadBalancers", input, func() (interface{}, error) {
+		return c.ELBV2API.DescribeLoadBalancers(input)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return output.(*elbv2.DescribeLoadBalancersOutput), nil
+}
+
+func (c cachedELBV2) DescribeTags(input *elbv2.DescribeTagsInput) (*elbv2.DescribeTagsOutput, error) {
+	output, err := c.cache.get("elbv2.DescribeTags", input, func() (interface{}, error) {
+		return c.ELBV2API.DescribeTags(input)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return output.(*elbv2.DescribeTagsOutput), nil
+}
+
+func (c cachedELBV2) DescribeTargetGroups(input *elbv2.DescribeTargetGroupsInput) (*elbv2.DescribeTargetGroupsOutput, error) {
+	output, err := c.cache.get("elbv2.DescribeTargetGroups", input, func() (interface{}, error) {
+		return c.ELBV2API.DescribeTargetGroups(input)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return output.(*elbv2.DescribeTargetGroupsOutput), nil
+}
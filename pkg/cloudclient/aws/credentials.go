@@ -0,0 +1,132 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/openshift/cloud-ingress-operator/config"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// credentialsRecheckInterval is how often the credentials Secret is read
+// again, to pick up keys rotated by the Cloud Credential Operator
+var credentialsRecheckInterval = time.Minute
+
+// secretCredentialsProviderName names the provider in the credentials it
+// returns
+const secretCredentialsProviderName = "CloudIngressOperatorSecret"
+
+// authFailureErrorCodes are the AWS error codes of calls made with keys that
+// aren't valid anymore, eg because they were rotated
+var authFailureErrorCodes = map[string]bool{
+	"AuthFailure":                 true,
+	"InvalidClientTokenId":        true,
+	"UnrecognizedClientException": true,
+	"SignatureDoesNotMatch":       true,
+	"ExpiredToken":                true,
+}
+
+// secretCredentialsProvider provides the AWS keys in the operator's
+// credentials Secret. The Secret is read again at most once every
+// credentialsRecheckInterval, or on the next call after one failed to
+// authenticate, and the keys expire once it changed, so rotated keys are used
+// without restarting the operator. If it can't be read, the last keys are
+// kept. It's safe for concurrent use by every copy of the session
+type secretCredentialsProvider struct {
+	kclient client.Client
+
+	mu        sync.Mutex
+	value     credentials.Value
+	lastCheck time.Time
+}
+
+// newSecretCredentialsProvider returns a secretCredentialsProvider with the
+// keys the credentials Secret has now
+func newSecretCredentialsProvider(kclient client.Client) (*secretCredentialsProvider, error) {
+	value, err := readCredentialsSecret(kclient)
+	if err != nil {
+		return nil, err
+	}
+	return &secretCredentialsProvider{
+		kclient:   kclient,
+		value:     value,
+		lastCheck: time.Now(),
+	}, nil
+}
+
+// Retrieve implements credentials.Provider
+func (p *secretCredentialsProvider) Retrieve() (credentials.Value, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.value, nil
+}
+
+// IsExpired implements credentials.Provider. The keys expire once the Secret
+// has different ones
+func (p *secretCredentialsProvider) IsExpired() bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if time.Since(p.lastCheck) < credentialsRecheckInterval {
+		return false
+	}
+	p.lastCheck = time.Now()
+	value, err := readCredentialsSecret(p.kclient)
+	if err != nil {
+		log.Error(err, "Couldn't read the credentials Secret again, keeping the current keys")
+		return false
+	}
+	if value == p.value {
+		return false
+	}
+	log.Info("The credentials Secret changed, using its new keys", "accessKeyID", value.AccessKeyID)
+	p.value = value
+	return true
+}
+
+// recheckOnAuthFailure is a Complete handler making p read the Secret again
+// on the next call, after r failed because its keys aren't valid anymore
+func (p *secretCredentialsProvider) recheckOnAuthFailure(r *request.Request) {
+	aerr, ok := r.Error.(awserr.Error)
+	if !ok || !authFailureErrorCodes[aerr.Code()] {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.lastCheck = time.Time{}
+}
+
+// readCredentialsSecret returns the AWS keys of the operator's credentials
+// Secret
+func readCredentialsSecret(kclient client.Client) (credentials.Value, error) {
+	secret := &corev1.Secret{}
+	err := kclient.Get(
+		context.TODO(),
+		types.NamespacedName{
+			Name:      config.AWSSecretName,
+			Namespace: config.OperatorNamespace,
+		},
+		secret)
+	if err != nil {
+		return credentials.Value{}, fmt.Errorf("Couldn't get Secret with credentials %s", err.Error())
+	}
+	accessKeyID, ok := secret.Data["aws_access_key_id"]
+	if !ok {
+		return credentials.Value{}, fmt.Errorf("Access credentials missing key")
+	}
+	secretAccessKey, ok := secret.Data["aws_secret_access_key"]
+	if !ok {
+		return credentials.Value{}, fmt.Errorf("Access credentials missing secret key")
+	}
+	return credentials.Value{
+		AccessKeyID:     string(accessKeyID),
+		SecretAccessKey: string(secretAccessKey),
+		ProviderName:    secretCredentialsProviderName,
+	}, nil
+}
@@ -4,10 +4,17 @@ package aws
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
 	goError "errors"
 	"fmt"
+	"hash/crc32"
+	"net"
 	"path"
 	"reflect"
+	"sort"
+	"strconv"
 	"strings"
 	"time"
 
@@ -16,31 +23,141 @@ import (
 	cloudingressv1alpha1 "github.com/openshift/cloud-ingress-operator/pkg/apis/cloudingress/v1alpha1"
 	machineapi "github.com/openshift/machine-api-operator/pkg/apis/machine/v1beta1"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	awsproviderapi "sigs.k8s.io/cluster-api-provider-aws/pkg/apis/awsproviderconfig/v1beta1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/openshift/cloud-ingress-operator/config"
+	"github.com/openshift/cloud-ingress-operator/pkg/dnsclient"
 	"github.com/openshift/cloud-ingress-operator/pkg/errors"
 	baseutils "github.com/openshift/cloud-ingress-operator/pkg/utils"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/arn"
 	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/endpoints"
+	"github.com/aws/aws-sdk-go/service/acm"
+	"github.com/aws/aws-sdk-go/service/cloudwatch"
 	"github.com/aws/aws-sdk-go/service/ec2"
 	"github.com/aws/aws-sdk-go/service/elb"
 	"github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/aws/aws-sdk-go/service/iam"
+	"github.com/aws/aws-sdk-go/service/kms"
 	"github.com/aws/aws-sdk-go/service/route53"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/sts"
+)
+
+const (
+	// nlbTypeAnnotationKey asks the cloud provider for a network load balancer
+	nlbTypeAnnotationKey = "service.beta.kubernetes.io/aws-load-balancer-type"
+	// eipAllocationsAnnotationKey lists the Elastic IP allocations to attach
+	// to a network load balancer, one per subnet
+	eipAllocationsAnnotationKey = "service.beta.kubernetes.io/aws-load-balancer-eip-allocations"
+	// ipAddressTypeAnnotationKey records the IP address type the network load
+	// balancer of a Service should have
+	ipAddressTypeAnnotationKey = "service.beta.kubernetes.io/aws-load-balancer-ip-address-type"
+	// securityGroupsAnnotationKey lists the security groups to attach to a
+	// classic ELB instead of the one the cloud provider would create and
+	// manage itself
+	securityGroupsAnnotationKey = "service.beta.kubernetes.io/aws-load-balancer-security-groups"
+	// The access log annotations make the cloud provider configure the access
+	// logs of a classic ELB, and keep it from reverting them
+	accessLogEnabledAnnotationKey      = "service.beta.kubernetes.io/aws-load-balancer-access-log-enabled"
+	accessLogEmitIntervalAnnotationKey = "service.beta.kubernetes.io/aws-load-balancer-access-log-emit-interval"
+	accessLogBucketNameAnnotationKey   = "service.beta.kubernetes.io/aws-load-balancer-access-log-s3-bucket-name"
+	accessLogBucketPrefixAnnotationKey = "service.beta.kubernetes.io/aws-load-balancer-access-log-s3-bucket-prefix"
+	// The health check annotations make the cloud provider check the
+	// instances over another protocol than TCP
+	healthCheckProtocolAnnotationKey = "service.beta.kubernetes.io/aws-load-balancer-healthcheck-protocol"
+	healthCheckPathAnnotationKey     = "service.beta.kubernetes.io/aws-load-balancer-healthcheck-path"
+	// targetNodeLabelsAnnotationKey limits the nodes the AWS cloud provider
+	// registers with the load balancer to those with its labels
+	targetNodeLabelsAnnotationKey = "service.beta.kubernetes.io/aws-load-balancer-target-node-labels"
+	// adminAPIMachineAnnotationKey records, on a master Machine, the rh-api
+	// load balancer the operator added to its providerSpec, so it can be
+	// swapped for the load balancer of a new Service, or removed
+	adminAPIMachineAnnotationKey = "cloudingress.managed.openshift.io/admin-api-load-balancer"
+	// defaultAccessLogEmitInterval is how often, in minutes, a classic ELB
+	// writes its access logs if the APIScheme doesn't say
+	defaultAccessLogEmitInterval int64 = 60
+	// defaultDrainingTimeout is the longest to wait for the connections to the
+	// external API load balancer to end, if the PublishingStrategy doesn't say
+	defaultDrainingTimeout = 5 * time.Minute
+	// maxLoadBalancerNameLength is the longest name AWS allows a load balancer
+	maxLoadBalancerNameLength = 32
+	// classicLoadBalancersLimit and networkLoadBalancersLimit name the
+	// account limits ELB reports for the load balancers of a region
+	classicLoadBalancersLimit = "classic-load-balancers"
+	networkLoadBalancersLimit = "network-load-balancers"
+	// defaultLatencyAlarmThreshold is the average latency, in milliseconds,
+	// above which the rh-api latency alarm fires if the APIScheme doesn't say
+	defaultLatencyAlarmThreshold int64 = 1000
+	// sslNegotiationPolicyType is the type of the classic ELB policies that
+	// set the protocols and ciphers of its SSL and HTTPS listeners
+	sslNegotiationPolicyType = "SSLNegotiationPolicyType"
+	// tlsPolicyNamePrefix prefixes the name of the negotiation policies the
+	// operator creates on a classic ELB, followed by the reference policy
+	tlsPolicyNamePrefix = "cloud-ingress-"
+	// The set identifiers of the weighted admin API records during a
+	// migration to a network load balancer: the admin API Service's load
+	// balancer, and the migration Service's
+	primaryRecordSetIdentifier   = "primary"
+	migrationRecordSetIdentifier = "nlb-migration"
+	// The set identifiers of the failover admin API records, which can't
+	// reuse those of the weighted ones
+	failoverPrimaryRecordSetIdentifier   = "failover-primary"
+	failoverSecondaryRecordSetIdentifier = "failover-secondary"
+	// failoverHealthCheckSuffix follows the admin API load balancer name in
+	// the name of the Route53 health check of its failover records, which
+	// prefixes the health check's caller reference
+	failoverHealthCheckSuffix = "-failover"
 )
 
 type awsLoadBalancer struct {
-	elbName   string
-	dnsName   string
-	dnsZoneID string
+	elbName         string
+	dnsName         string
+	dnsZoneID       string
+	loadBalancerArn string // only set for network load balancers
+	ipAddressType   string
+	scheme          string // internal or internet-facing
+}
+
+// recordTypes returns the DNS alias record types that should point at the
+// load balancer: A, plus AAAA if it is dualstack
+func (lb *awsLoadBalancer) recordTypes() []string {
+	if lb.ipAddressType == elbv2.IpAddressTypeDualstack {
+		return []string{"A", "AAAA"}
+	}
+	return []string{"A"}
+}
+
+// isInternal checks if the load balancer is only reachable from inside the
+// cluster's VPC
+func (lb *awsLoadBalancer) isInternal() bool {
+	return lb.scheme == elbv2.LoadBalancerSchemeEnumInternal
 }
 
 type loadBalancer struct {
 	endpointName string // from APIScheme
-	baseDomain   string // cluster base domain
+	baseDomain   string // cluster base domain, or the APIScheme's
+	// hostedZone is the hosted zone the records go in. If nil, they go in
+	// the public and private zones named zoneNames
+	hostedZone *hostedZone
+	// zoneNames are the names of the zones the records go in, eg the
+	// cluster's private and public zones
+	zoneNames []string
+	// vpcID is the cluster's VPC. Private zones that aren't associated with
+	// it are left alone
+	vpcID string
+}
+
+// hostedZone is a Route53 hosted zone records are published in
+type hostedZone struct {
+	id string
+	// private zones only answer queries from the VPCs associated with them
+	private bool
 }
 
 type loadBalancerV2 struct {
@@ -90,6 +207,99 @@ func removeAWSLBFromMasterMachines(kclient client.Client, elbName string, master
 	return nil
 }
 
+// ensureAdminAPIMasterMachines adds the rh-api load balancer of svc to the
+// spec.providerSpec.value.loadBalancers list of each master Machine. A master
+// replacing one is made from a copy of its Machine, and the machine API then
+// registers it with the load balancer when it boots, rather than waiting for
+// the cloud provider. The load balancer of a previous rh-api Service, eg one
+// recreated to change its scheme, is removed from the list, as is svc's if
+// the APIScheme puts other nodes behind it
+func (c *Client) ensureAdminAPIMasterMachines(ctx context.Context, kclient client.Client, instance *cloudingressv1alpha1.APIScheme, svc *corev1.Service) error {
+	// Other nodes are behind the load balancer, which the cloud provider
+	// deregisters the masters from
+	if len(instance.Spec.ManagementAPIServerIngress.BackendNodeSelector) > 0 {
+		return setAdminAPIOnMasterMachines(kclient, nil, loadBalancerNameForService(svc))
+	}
+	ref := &awsproviderapi.LoadBalancerReference{
+		Name: loadBalancerNameForService(svc),
+		Type: awsproviderapi.ClassicLoadBalancerType,
+	}
+	if svc.Annotations[nlbTypeAnnotationKey] == "nlb" {
+		ref.Type = awsproviderapi.NetworkLoadBalancerType
+	}
+	return setAdminAPIOnMasterMachines(kclient, ref, "")
+}
+
+// setAdminAPIOnMasterMachines makes ref the rh-api load balancer in the
+// providerSpec of each master Machine, in place of the one the operator added
+// before. A nil ref removes the load balancer the operator added instead, if
+// it's the one named only
+func setAdminAPIOnMasterMachines(kclient client.Client, ref *awsproviderapi.LoadBalancerReference, only string) error {
+	masterList, err := baseutils.GetMasterMachines(kclient)
+	if err != nil {
+		return err
+	}
+	for _, machine := range masterList.Items {
+		previous := machine.Annotations[adminAPIMachineAnnotationKey]
+		if ref == nil && (previous == "" || previous != only) {
+			continue
+		}
+		providerSpecDecoded, err := getAWSDecodedProviderSpec(machine)
+		if err != nil {
+			return err
+		}
+		lbList := withAdminAPILoadBalancer(providerSpecDecoded.LoadBalancers, previous, ref)
+		name := ""
+		if ref != nil {
+			name = ref.Name
+		}
+		if previous == name && reflect.DeepEqual(lbList, providerSpecDecoded.LoadBalancers) {
+			continue
+		}
+		baseToPatch := client.MergeFrom(machine.DeepCopy())
+		providerSpecDecoded.LoadBalancers = lbList
+		awsCodec, err := awsproviderapi.NewCodec()
+		if err != nil {
+			return err
+		}
+		providerSpecEncoded, err := awsCodec.EncodeProviderSpec(providerSpecDecoded)
+		if err != nil {
+			return err
+		}
+		machine.Spec.ProviderSpec = *providerSpecEncoded
+		if name == "" {
+			delete(machine.Annotations, adminAPIMachineAnnotationKey)
+		} else {
+			metav1.SetMetaDataAnnotation(&machine.ObjectMeta, adminAPIMachineAnnotationKey, name)
+		}
+		if err = kclient.Patch(context.TODO(), &machine, baseToPatch); err != nil {
+			return err
+		}
+		log.Info("Updated the rh-api load balancer of the master Machine", "machine", machine.Name, "previous", previous, "loadBalancer", name)
+	}
+	return nil
+}
+
+// withAdminAPILoadBalancer returns lbList with ref in place of the load
+// balancer named previous, or without it if ref is nil. The other load
+// balancers, eg the cluster's API ones, are kept in order
+func withAdminAPILoadBalancer(lbList []awsproviderapi.LoadBalancerReference, previous string, ref *awsproviderapi.LoadBalancerReference) []awsproviderapi.LoadBalancerReference {
+	newLBList := []awsproviderapi.LoadBalancerReference{}
+	for _, lb := range lbList {
+		if previous != "" && lb.Name == previous {
+			continue
+		}
+		if ref != nil && lb.Name == ref.Name {
+			continue
+		}
+		newLBList = append(newLBList, lb)
+	}
+	if ref != nil {
+		newLBList = append(newLBList, *ref)
+	}
+	return newLBList
+}
+
 // getAWSDecodedProviderSpec casts the spec.providerSpec of an OpenShift machine
 // object to an AWSMachineProviderConfig object, which is required to read and
 // interact with fields in a machine's providerSpec
@@ -145,580 +355,4364 @@ func updateAWSLBList(kclient client.Client, oldLBList []awsproviderapi.LoadBalan
 // APIScheme is present and mapped to the corresponding Service's AWS
 // LoadBalancer
 func (c *Client) ensureAdminAPIDNS(ctx context.Context, kclient client.Client, instance *cloudingressv1alpha1.APIScheme, svc *corev1.Service) error {
-	return c.ensureDNSForService(ctx, kclient, svc, instance.Spec.ManagementAPIServerIngress.DNSName, "RH API Endpoint")
-}
-
-// deleteAdminAPIDNS removes the DNS record for the rh-api "admin API" for
-// APIScheme
-func (c *Client) deleteAdminAPIDNS(ctx context.Context, kclient client.Client, instance *cloudingressv1alpha1.APIScheme, svc *corev1.Service) error {
-	return c.removeDNSForService(ctx, kclient, svc, instance.Spec.ManagementAPIServerIngress.DNSName, "RH API Endpoint")
-}
-
-// ensureSSHDNS ensures the DNS record for the SSH Service LoadBalancer is set
-func (c *Client) ensureSSHDNS(ctx context.Context, kclient client.Client, instance *cloudingressv1alpha1.SSHD, svc *corev1.Service) error {
-	return c.ensureDNSForService(ctx, kclient, svc, instance.Spec.DNSName, "RH SSH Endpoint")
-}
-
-// deleteSSHDNS ensures the DNS record for the SSH Service AWS LoadBalancer is unset
-func (c *Client) deleteSSHDNS(ctx context.Context, kclient client.Client, instance *cloudingressv1alpha1.SSHD, svc *corev1.Service) error {
-	return c.removeDNSForService(ctx, kclient, svc, instance.Spec.DNSName, "RH SSH Endpoint")
-}
-
-// setDefaultAPIPrivate sets the default api (api.<cluster-domain>) to private
-// scope
-func (c *Client) setDefaultAPIPrivate(ctx context.Context, kclient client.Client, _ *cloudingressv1alpha1.PublishingStrategy) error {
-	// Delete the NLB and remove the NLB from the master Machine objects in
-	// cluster. At the same time, get the name of the DNS zone and base domain for
-	// the internal load balancer
-	intDNSName, intHostedZoneID, err := c.removeLoadBalancerFromMasterNodes(ctx, kclient)
+	lb, err := c.getAdminAPILoadBalancer(kclient, instance)
 	if err != nil {
 		return err
 	}
+	return c.ensureDNSForService(ctx, svc, lb, "RH API Endpoint")
+}
 
-	baseDomain, err := baseutils.GetClusterBaseDomain(kclient)
+// ensureAdminAPIWeightedDNS splits the rh-api alias records between the load
+// balancers of svc and migrationSvc, weight percent of the DNS queries to the
+// latter. The simple records are replaced in the same change batch, so the
+// name keeps resolving throughout
+func (c *Client) ensureAdminAPIWeightedDNS(ctx context.Context, kclient client.Client, instance *cloudingressv1alpha1.APIScheme, svc, migrationSvc *corev1.Service, weight int32) error {
+	lb, err := c.getAdminAPILoadBalancer(kclient, instance)
 	if err != nil {
 		return err
 	}
-
-	pubDomainName := baseDomain[strings.Index(baseDomain, ".")+1:]
-	apiDNSName := fmt.Sprintf("api.%s.", baseDomain)
-	comment := "Update api.<clusterName> alias to internal NLB"
-	err = c.upsertARecord(pubDomainName+".", intDNSName, intHostedZoneID, apiDNSName, comment, false)
+	primary, err := c.getLoadBalancerForService(svc)
 	if err != nil {
 		return err
 	}
-	return nil
-}
-
-// setDefaultAPIPublic sets the default API (api.<cluster-domain>) to public
-// scope
-func (c *Client) setDefaultAPIPublic(ctx context.Context, kclient client.Client, instance *cloudingressv1alpha1.PublishingStrategy) error {
-	nlbs, err := c.listOwnedNLBs(kclient)
+	migration, err := c.getLoadBalancerForService(migrationSvc)
 	if err != nil {
 		return err
 	}
-	// TODO: Check for the expected name?
-	for _, networkLoadBalancer := range nlbs {
-		if networkLoadBalancer.scheme == "internet-facing" {
-			// nothing to do
-			return nil
-		}
-	}
-	// create new ext nlb
-	infrastructureName, err := baseutils.GetClusterName(kclient)
+	zones, err := c.getHostedZones(lb)
 	if err != nil {
 		return err
 	}
-	extNLBName := infrastructureName + "-ext"
+	published, _ := publishedHostedZones(zones, primary)
+	for _, zone := range published {
+		for _, recordType := range primary.recordTypes() {
+			targets := []weightedAliasTarget{
+				{setIdentifier: primaryRecordSetIdentifier, lb: primary, weight: 100 - int64(weight)},
+			}
+			// A classic ELB has no AAAA record to share with a dualstack
+			// network load balancer
+			for _, migrationRecordType := range migration.recordTypes() {
+				if migrationRecordType == recordType {
+					targets = append(targets, weightedAliasTarget{setIdentifier: migrationRecordSetIdentifier, lb: migration, weight: int64(weight)})
+				}
+			}
+			err = c.upsertWeightedAliasRecords(zone.id, lb.endpointName+"."+lb.baseDomain, recordType, targets, "RH API Endpoint")
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
 
-	subnetIDs, err := c.getPublicSubnets(kclient)
+// ensureAdminAPIFailoverDNS makes the rh-api alias records failover records,
+// answering with the load balancer of svc while it's healthy, and with the
+// one of secondarySvc otherwise. An internet-facing load balancer of svc gets
+// a Route53 TCP health check on its first port. Route53 can't reach an
+// internal one, so the records only evaluate the health of their targets
+func (c *Client) ensureAdminAPIFailoverDNS(ctx context.Context, kclient client.Client, instance *cloudingressv1alpha1.APIScheme, svc, secondarySvc *corev1.Service) error {
+	lb, err := c.getAdminAPILoadBalancer(kclient, instance)
 	if err != nil {
 		return err
 	}
-	if len(subnetIDs) == 0 {
-		err = goError.New("No public subnets, can't change API to public")
-		return err
-	}
-
-	newNLBs, err := c.createNetworkLoadBalancer(extNLBName, "internet-facing", subnetIDs[0])
+	primary, err := c.getLoadBalancerForService(svc)
 	if err != nil {
 		return err
 	}
-	if len(newNLBs) != 1 {
-		return fmt.Errorf("more than one NLB, or no new NLB detected (expected 1, got %d)", len(newNLBs))
-	}
-	err = c.addTagsForNLB(newNLBs[0].loadBalancerArn, infrastructureName)
+	secondary, err := c.getLoadBalancerForService(secondarySvc)
 	if err != nil {
 		return err
 	}
-	// attempt to use existing TargetGroup
-	targetGroupName := fmt.Sprintf("%s-aext", infrastructureName)
-	targetGroupARN, err := c.getTargetGroupArn(targetGroupName)
+	zones, err := c.getHostedZones(lb)
 	if err != nil {
 		return err
 	}
-	err = c.createListenerForNLB(targetGroupARN, newNLBs[0].loadBalancerArn)
-	if err != nil {
-		if aerr, ok := err.(awserr.Error); ok {
-			if aerr.Code() == "TargetGroupAssociationLimit" {
-				// not possible to modify lb, we'd have to create a new targetGroup
-				return nil
-			}
+	published, _ := publishedHostedZones(zones, primary)
+	recordName := lb.endpointName + "." + lb.baseDomain + "."
+
+	var healthCheckID *string
+	if instance.Spec.ManagementAPIServerIngress.Scheme != cloudingressv1alpha1.LoadBalancerSchemeInternal && len(svc.Spec.Ports) > 0 {
+		clusterName, err := baseutils.GetClusterName(kclient)
+		if err != nil {
 			return err
 		}
-		// TODO: log - cant create listener for new ext nlb
-		return err
+		id, err := c.ensureFailoverHealthCheck(published, recordName, clusterName+"-"+lb.endpointName+failoverHealthCheckSuffix, primary, int64(svc.Spec.Ports[0].Port))
+		if err != nil {
+			return err
+		}
+		healthCheckID = aws.String(id)
 	}
 
-	// can't create listener for new ext nlb
-	baseDomain, err := baseutils.GetClusterBaseDomain(kclient)
-	if err != nil {
-		return err
-	}
-	pubDomainName := baseDomain[strings.Index(baseDomain, ".")+1:]
-	apiDNSName := fmt.Sprintf("api.%s.", baseDomain)
-	// not tested yet
-	comment := "Update api.<clusterName> alias to external NLB"
-	err = c.upsertARecord(pubDomainName+".",
-		newNLBs[0].dnsName,
-		newNLBs[0].canonicalHostedZoneNameID,
-		apiDNSName,
-		comment,
-		false)
-	if err != nil {
-		return err
+	for _, zone := range published {
+		for _, recordType := range primary.recordTypes() {
+			records := []*route53.ResourceRecordSet{
+				failoverAliasRecord(recordName, recordType, failoverPrimaryRecordSetIdentifier, route53.ResourceRecordSetFailoverPrimary, primary, healthCheckID),
+			}
+			// A classic ELB has no AAAA record to share with a dualstack
+			// network load balancer
+			for _, secondaryRecordType := range secondary.recordTypes() {
+				if secondaryRecordType == recordType {
+					records = append(records, failoverAliasRecord(recordName, recordType, failoverSecondaryRecordSetIdentifier, route53.ResourceRecordSetFailoverSecondary, secondary, nil))
+				}
+			}
+			err = c.upsertRoutedAliasRecords(zone.id, recordName, recordType, records, "RH API Endpoint")
+			if err != nil {
+				return err
+			}
+		}
 	}
-	// success
 	return nil
 }
 
-// getMasterNodeSubnets returns all the subnets for Machines with 'master' label.
-// return structure:
-// {
-//   public => subnetname,
-//   private => subnetname,
-// }
-//
-func getMasterNodeSubnets(kclient client.Client) (map[string]string, error) {
-	subnets := make(map[string]string)
-	machineList, err := baseutils.GetMasterMachines(kclient)
-	if err != nil {
-		return subnets, err
+// failoverAliasRecord returns the failover alias record of the given type
+// named recordName pointing at awsObj, with the health check healthCheckID if
+// it isn't nil
+func failoverAliasRecord(recordName, recordType, setIdentifier, failover string, awsObj *awsLoadBalancer, healthCheckID *string) *route53.ResourceRecordSet {
+	dnsName := awsObj.dnsName
+	if !strings.HasSuffix(dnsName, ".") {
+		dnsName += "."
 	}
-	if len(machineList.Items) == 0 {
-		return subnets, fmt.Errorf("Did not find any master Machine objects")
+	return &route53.ResourceRecordSet{
+		AliasTarget: &route53.AliasTarget{
+			DNSName:              aws.String(dnsName),
+			EvaluateTargetHealth: aws.Bool(true),
+			HostedZoneId:         aws.String(awsObj.dnsZoneID),
+		},
+		Name:          aws.String(recordName),
+		Type:          aws.String(recordType),
+		SetIdentifier: aws.String(setIdentifier),
+		Failover:      aws.String(failover),
+		HealthCheckId: healthCheckID,
 	}
+}
 
-	// get the AZ from a Master object's providerSpec.
-	codec, err := awsproviderapi.NewCodec()
-
-	if err != nil {
-		return subnets, err
+// ensureFailoverHealthCheck ensures the Route53 health check named name, the
+// one of the primary failover records named recordName in zones, checks that
+// awsObj accepts TCP connections on port. Returns its ID
+func (c *Client) ensureFailoverHealthCheck(zones []hostedZone, recordName, name string, awsObj *awsLoadBalancer, port int64) (string, error) {
+	var healthCheck *route53.HealthCheck
+	for _, zone := range zones {
+		records, err := c.getRecordsNamed(zone.id, recordName)
+		if err != nil {
+			return "", err
+		}
+		for _, record := range records {
+			if aws.StringValue(record.SetIdentifier) != failoverPrimaryRecordSetIdentifier || record.HealthCheckId == nil {
+				continue
+			}
+			output, err := c.route53Client.GetHealthCheck(&route53.GetHealthCheckInput{HealthCheckId: record.HealthCheckId})
+			if aerr, ok := err.(awserr.Error); ok && aerr.Code() == route53.ErrCodeNoSuchHealthCheck {
+				continue
+			}
+			if err != nil {
+				return "", err
+			}
+			healthCheck = output.HealthCheck
+			break
+		}
+		if healthCheck != nil {
+			break
+		}
 	}
 
-	// Obtain the availability zone
-	awsconfig := &awsproviderapi.AWSMachineProviderConfig{}
-	err = codec.DecodeProviderSpec(&machineList.Items[0].Spec.ProviderSpec, awsconfig)
-	if err != nil {
-		return subnets, err
+	fqdn := strings.TrimSuffix(awsObj.dnsName, ".")
+	if healthCheck == nil {
+		output, err := c.route53Client.CreateHealthCheck(&route53.CreateHealthCheckInput{
+			CallerReference: aws.String(fmt.Sprintf("%s-%d", name, time.Now().UnixNano())),
+			HealthCheckConfig: &route53.HealthCheckConfig{
+				Type:                     aws.String(route53.HealthCheckTypeTcp),
+				FullyQualifiedDomainName: aws.String(fqdn),
+				Port:                     aws.Int64(port),
+				RequestInterval:          aws.Int64(30),
+				FailureThreshold:         aws.Int64(3),
+			},
+		})
+		if err != nil {
+			return "", err
+		}
+		healthCheckID := aws.StringValue(output.HealthCheck.Id)
+		tags := []*route53.Tag{{Key: aws.String("Name"), Value: aws.String(name)}}
+		for key, value := range c.tags {
+			tags = append(tags, &route53.Tag{Key: aws.String(key), Value: aws.String(value)})
+		}
+		_, err = c.route53Client.ChangeTagsForResource(&route53.ChangeTagsForResourceInput{
+			ResourceId:   aws.String(healthCheckID),
+			ResourceType: aws.String(route53.TagResourceTypeHealthcheck),
+			AddTags:      tags,
+		})
+		if err != nil {
+			return "", err
+		}
+		log.Info("Created the health check of the failover records", "name", name, "healthCheckID", healthCheckID)
+		return healthCheckID, nil
 	}
 
-	// Infra object gives us the Infrastructure name, which is the combination of
-	// cluster name and identifier.
-	infra, err := baseutils.GetInfrastructureObject(kclient)
-	if err != nil {
-		return subnets, err
+	healthCheckConfig := healthCheck.HealthCheckConfig
+	if aws.StringValue(healthCheckConfig.FullyQualifiedDomainName) != fqdn || aws.Int64Value(healthCheckConfig.Port) != port {
+		_, err := c.route53Client.UpdateHealthCheck(&route53.UpdateHealthCheckInput{
+			HealthCheckId:            healthCheck.Id,
+			HealthCheckVersion:       healthCheck.HealthCheckVersion,
+			FullyQualifiedDomainName: aws.String(fqdn),
+			Port:                     aws.Int64(port),
+		})
+		if err != nil {
+			return "", err
+		}
+		log.Info("Updated the health check of the failover records", "name", name, "healthCheckID", aws.StringValue(healthCheck.Id))
 	}
-	subnets["public"] = fmt.Sprintf("%s-public-%s", infra.Status.InfrastructureName, awsconfig.Placement.AvailabilityZone)
-	subnets["private"] = fmt.Sprintf("%s-private-%s", infra.Status.InfrastructureName, awsconfig.Placement.AvailabilityZone)
-
-	return subnets, nil
+	return aws.StringValue(healthCheck.Id), nil
 }
 
-func (c *Client) getPublicSubnets(kclient client.Client) ([]string, error) {
-
-	var publicSubnets []string
-
-	machineList, err := baseutils.GetMasterMachines(kclient)
+// deleteReleasedHealthChecks deletes the failover health checks the operator
+// created for the records changes deleted. One still used by the records of
+// another zone is left for their deletion to delete
+func (c *Client) deleteReleasedHealthChecks(changes []*route53.Change) error {
+	for _, change := range changes {
+		if aws.StringValue(change.Action) != "DELETE" || change.ResourceRecordSet.HealthCheckId == nil {
+			continue
+		}
+		healthCheckID := change.ResourceRecordSet.HealthCheckId
+		output, err := c.route53Client.GetHealthCheck(&route53.GetHealthCheckInput{HealthCheckId: healthCheckID})
+		if aerr, ok := err.(awserr.Error); ok && aerr.Code() == route53.ErrCodeNoSuchHealthCheck {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		// Only the health checks the operator created are its to delete
+		if !strings.Contains(aws.StringValue(output.HealthCheck.CallerReference), failoverHealthCheckSuffix+"-") {
+			continue
+		}
+		_, err = c.route53Client.DeleteHealthCheck(&route53.DeleteHealthCheckInput{HealthCheckId: healthCheckID})
+		if aerr, ok := err.(awserr.Error); ok && (aerr.Code() == route53.ErrCodeNoSuchHealthCheck || aerr.Code() == route53.ErrCodeHealthCheckInUse) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		log.Info("Deleted the health check of the failover records", "healthCheckID", aws.StringValue(healthCheckID))
+	}
+	return nil
+}
 
+// deleteAdminAPIDNS removes the DNS record for the rh-api "admin API" for
+// APIScheme
+func (c *Client) deleteAdminAPIDNS(ctx context.Context, kclient client.Client, instance *cloudingressv1alpha1.APIScheme, svc *corev1.Service) error {
+	lb, err := c.getAdminAPILoadBalancer(kclient, instance)
 	if err != nil {
-		log.Error(err, "No master machines found")
-		return nil, err
+		return err
 	}
+	return c.removeDNSForService(ctx, svc, lb)
+}
 
-	// Get the first master machine in the list
-	masterMachine := machineList.Items[0]
-
-	// Get the instance ID of the machine in the form of aws:///us-east-1a/i-<hash>
-	instanceIDLong := masterMachine.Spec.ProviderID
-
-	split := strings.Split(*instanceIDLong, "/")
-
-	// The instance ID should be the last element of the split
-	instanceID := split[len(split)-1]
-
-	// Ensure we acutally have an instnace ID by erroring if its missing
-	if instanceID == "" {
-		err = goError.New("Instance ID is blank")
-		return nil, err
+// ensureAdminAPIStaticIPs ensures there is an Elastic IP for each availability
+// zone the rh-api network load balancer will be placed in, and annotates svc
+// so the cloud provider creates the NLB with those Elastic IPs attached.
+// Pre-allocated Elastic IPs from the APIScheme are used as given.
+func (c *Client) ensureAdminAPIStaticIPs(ctx context.Context, kclient client.Client, instance *cloudingressv1alpha1.APIScheme, svc *corev1.Service) ([]string, error) {
+	allocationIDs := instance.Spec.ManagementAPIServerIngress.StaticIP.AllocationIDs
+	if len(allocationIDs) == 0 {
+		// One Elastic IP per subnet, which is one per availability zone
+		subnetIDs, err := c.getPublicSubnets(kclient, instance.Spec.ManagementAPIServerIngress.SubnetIDs)
+		if err != nil {
+			return []string{}, err
+		}
+		zoneCount := len(subnetIDs)
+		if zoneCount == 0 {
+			return []string{}, goError.New("No public subnets, can't allocate Elastic IPs for the admin API")
+		}
+		clusterName, err := baseutils.GetClusterName(kclient)
+		if err != nil {
+			return []string{}, err
+		}
+		allocationIDs, err = c.ensureElasticIPs(clusterName, clusterName+"-"+config.AdminAPIName, zoneCount)
+		if err != nil {
+			return []string{}, err
+		}
 	}
 
-	// Get VPC the instance is in
-	describeInstanceOutput, err := c.ec2Client.DescribeInstances(
-		&ec2.DescribeInstancesInput{
-			InstanceIds: []*string{aws.String(instanceID)},
-		},
-	)
+	ips, err := c.getElasticIPAddresses(allocationIDs)
 	if err != nil {
-		return nil, err
+		return []string{}, err
 	}
 
-	// Extract the VPC ID from the subnet metadata
-	targetVPC := describeInstanceOutput.Reservations[0].Instances[0].VpcId
+	metav1.SetMetaDataAnnotation(&svc.ObjectMeta, nlbTypeAnnotationKey, "nlb")
+	metav1.SetMetaDataAnnotation(&svc.ObjectMeta, eipAllocationsAnnotationKey, strings.Join(allocationIDs, ","))
+	return ips, nil
+}
 
-	// List all subnets in the VPC
-	allSubnets, err := c.getAllSubnetsInVPC(*targetVPC)
+// releaseAdminAPIStaticIPs releases the Elastic IPs of allocationIDs, and
+// returns those released or already gone. Addresses still associated, eg with
+// the network interfaces of a load balancer AWS is deleting, are left for a
+// later call. So are addresses not tagged as owned by the cluster, which the
+// operator didn't allocate and never releases
+func (c *Client) releaseAdminAPIStaticIPs(ctx context.Context, kclient client.Client, instance *cloudingressv1alpha1.APIScheme, allocationIDs []string) ([]string, error) {
+	clusterName, err := baseutils.GetClusterName(kclient)
 	if err != nil {
-		return nil, err
+		return []string{}, err
 	}
-
-	// List all route tables associated with the VPC
-	routeTables, err := c.getAllRouteTablesInVPC(*targetVPC)
+	output, err := c.ec2Client.DescribeAddresses(&ec2.DescribeAddressesInput{
+		Filters: []*ec2.Filter{
+			{
+				Name:   aws.String("tag:kubernetes.io/cluster/" + clusterName),
+				Values: []*string{aws.String("owned")},
+			},
+		},
+	})
 	if err != nil {
-		return nil, err
+		return []string{}, err
 	}
-
-	for _, subnet := range allSubnets {
-		isPublic, err := isSubnetPublic(routeTables, *subnet.SubnetId)
-
-		if err != nil {
-			log.Error(err, "Error while determining if subnet is public")
-			return nil, err
+	owned := make(map[string]*ec2.Address)
+	for _, address := range output.Addresses {
+		owned[aws.StringValue(address.AllocationId)] = address
+	}
+	released := []string{}
+	for _, allocationID := range allocationIDs {
+		address, ok := owned[allocationID]
+		if !ok {
+			released = append(released, allocationID)
+			continue
+		}
+		if aws.StringValue(address.AssociationId) != "" || aws.StringValue(address.NetworkInterfaceId) != "" {
+			log.Info("Elastic IP is still associated, not releasing it yet", "allocationID", allocationID, "networkInterfaceID", aws.StringValue(address.NetworkInterfaceId))
+			continue
 		}
-		if isPublic {
-			publicSubnets = append(publicSubnets, *subnet.SubnetId)
+		_, err = c.ec2Client.ReleaseAddress(&ec2.ReleaseAddressInput{
+			AllocationId: aws.String(allocationID),
+		})
+		if err != nil {
+			return released, err
 		}
+		log.Info("Released Elastic IP", "allocationID", allocationID, "publicIP", aws.StringValue(address.PublicIp))
+		released = append(released, allocationID)
 	}
+	return released, nil
+}
+
+// ensureAdminAPISecurityGroup ensures the cluster's dedicated rh-api security
+// group exists and only lets the APIScheme's allowed CIDR blocks in on the
+// admin API port, and annotates svc so the cloud provider attaches it to the
+// classic ELB. Network load balancers have no security groups, so Services
+// asking for one are left alone
+func (c *Client) ensureAdminAPISecurityGroup(ctx context.Context, kclient client.Client, instance *cloudingressv1alpha1.APIScheme, svc *corev1.Service) (string, error) {
+	if svc.Annotations[nlbTypeAnnotationKey] == "nlb" {
+		return "", nil
+	}
+	clusterName, err := baseutils.GetClusterName(kclient)
+	if err != nil {
+		return "", err
+	}
+	groupName := clusterName + "-" + config.AdminAPISecurityGroupName
+	vpcID, err := c.getClusterVPC(kclient)
+	if err != nil {
+		return "", err
+	}
+	sg, err := c.adoptSecurityGroup(clusterName, groupName, vpcID)
+	if err != nil {
+		return "", err
+	}
+	if sg == nil {
+		sg, err = c.createOwnedSecurityGroup(clusterName, groupName, vpcID)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	// Like the cloud provider, treat no allowed CIDR blocks as open to all.
+	// Expired ones are revoked
+	cidrs, _, err := baseutils.GetEffectiveAllowedCIDRBlocks(kclient, instance, time.Now())
+	if err != nil {
+		return "", err
+	}
+	if len(cidrs) == 0 {
+		cidrs = []string{"0.0.0.0/0"}
+	}
+	metadata, err := baseutils.GetCIDRBlocksMetadata(instance)
+	if err != nil {
+		return "", err
+	}
+	descriptions := make(map[string]string)
+	for cidr, m := range metadata {
+		descriptions[cidr] = m.Description()
+	}
+	// The rules follow the Service's ports, which the load balancer listens on
+	ports := []int64{config.AdminAPIListenerPort}
+	if len(svc.Spec.Ports) > 0 {
+		ports = []int64{}
+		for _, port := range svc.Spec.Ports {
+			ports = append(ports, int64(port.Port))
+		}
+	}
+	err = c.setSecurityGroupIngress(sg, cidrs, descriptions, ports)
+	if err != nil {
+		return "", err
+	}
+
+	groupID := aws.StringValue(sg.GroupId)
+	metav1.SetMetaDataAnnotation(&svc.ObjectMeta, securityGroupsAnnotationKey, groupID)
+	// The cloud provider creates the ELB with the annotated group, but an ELB
+	// created before it, or one the group was swapped on since, keeps fronting
+	// the admin API with the groups it has. A Service not created yet has no
+	// ELB
+	if svc.UID != "" {
+		err = c.applyClassicELBSecurityGroups(loadBalancerNameForService(svc), []string{groupID})
+		if err != nil {
+			return "", err
+		}
+	}
+	return groupID, nil
+}
+
+// applyClassicELBSecurityGroups makes groupIDs the only security groups of
+// the classic ELB elbName, replacing any the ELB was created with, eg the
+// VPC's default one. An ELB that doesn't exist yet is left to the cloud
+// provider
+func (c *Client) applyClassicELBSecurityGroups(elbName string, groupIDs []string) error {
+	output, err := c.elbClient.DescribeLoadBalancers(&elb.DescribeLoadBalancersInput{
+		LoadBalancerNames: []*string{aws.String(elbName)},
+	})
+	if err != nil {
+		if aerr, ok := err.(awserr.Error); ok && aerr.Code() == elb.ErrCodeAccessPointNotFoundException {
+			return nil
+		}
+		return err
+	}
+	if len(output.LoadBalancerDescriptions) == 0 {
+		return nil
+	}
+	current := aws.StringValueSlice(output.LoadBalancerDescriptions[0].SecurityGroups)
+	if baseutils.SameMembers(current, groupIDs) {
+		return nil
+	}
+	_, err = c.elbClient.ApplySecurityGroupsToLoadBalancer(&elb.ApplySecurityGroupsToLoadBalancerInput{
+		LoadBalancerName: aws.String(elbName),
+		SecurityGroups:   aws.StringSlice(groupIDs),
+	})
+	if err != nil {
+		return err
+	}
+	log.Info("Applied security groups to the admin API load balancer", "elbName", elbName, "securityGroups", groupIDs, "replaced", current)
+	return nil
+}
+
+// deleteAdminAPISecurityGroup deletes the cluster's dedicated rh-api security
+// group, if it exists. It can only be deleted once no load balancer uses it
+func (c *Client) deleteAdminAPISecurityGroup(ctx context.Context, kclient client.Client, instance *cloudingressv1alpha1.APIScheme) error {
+	clusterName, err := baseutils.GetClusterName(kclient)
+	if err != nil {
+		return err
+	}
+	groupName := clusterName + "-" + config.AdminAPISecurityGroupName
+	sg, err := c.getOwnedSecurityGroup(clusterName, groupName)
+	if err != nil || sg == nil {
+		return err
+	}
+	_, err = c.ec2Client.DeleteSecurityGroup(&ec2.DeleteSecurityGroupInput{
+		GroupId: sg.GroupId,
+	})
+	if err != nil {
+		if aerr, ok := err.(awserr.Error); ok && aerr.Code() == "DependencyViolation" {
+			return errors.NewSecurityGroupInUseError(aws.StringValue(sg.GroupId))
+		}
+		return err
+	}
+	log.Info("Deleted security group", "groupName", groupName, "groupID", aws.StringValue(sg.GroupId))
+	return nil
+}
+
+// deleteAdminAPILoadBalancer deletes the load balancer the cloud provider
+// created for svc instead of waiting for the cloud provider to, so the
+// security group can go right after it. A load balancer that's already gone
+// is ignored
+func (c *Client) deleteAdminAPILoadBalancer(ctx context.Context, kclient client.Client, instance *cloudingressv1alpha1.APIScheme, svc *corev1.Service) error {
+	elbName := loadBalancerNameForService(svc)
+	// A new master mustn't try to register with a deleted load balancer
+	if err := setAdminAPIOnMasterMachines(kclient, nil, elbName); err != nil {
+		return err
+	}
+	if svc.Annotations[nlbTypeAnnotationKey] == "nlb" {
+		return c.deleteNetworkLoadBalancer(elbName)
+	}
+	return c.deleteClassicELB(elbName)
+}
+
+// loadBalancerNameForService returns the name the cloud provider gives the
+// load balancer of svc: its UID, truncated to the 32 characters AWS allows
+func loadBalancerNameForService(svc *corev1.Service) string {
+	elbName := strings.ReplaceAll("a"+string(svc.ObjectMeta.UID), "-", "")
+	if len(elbName) > maxLoadBalancerNameLength {
+		elbName = elbName[0:maxLoadBalancerNameLength]
+	}
+	return elbName
+}
+
+// deleteClassicELB deregisters the instances of the classic ELB elbName and
+// deletes its listeners, so it stops taking traffic, then deletes it
+func (c *Client) deleteClassicELB(elbName string) error {
+	output, err := c.elbClient.DescribeLoadBalancers(&elb.DescribeLoadBalancersInput{
+		LoadBalancerNames: []*string{aws.String(elbName)},
+	})
+	if err != nil {
+		if aerr, ok := err.(awserr.Error); ok && aerr.Code() == elb.ErrCodeAccessPointNotFoundException {
+			return nil
+		}
+		return err
+	}
+	if len(output.LoadBalancerDescriptions) == 0 {
+		return nil
+	}
+	lb := output.LoadBalancerDescriptions[0]
+	if len(lb.Instances) > 0 {
+		_, err = c.elbClient.DeregisterInstancesFromLoadBalancer(&elb.DeregisterInstancesFromLoadBalancerInput{
+			Instances:        lb.Instances,
+			LoadBalancerName: aws.String(elbName),
+		})
+		if err != nil {
+			return err
+		}
+	}
+	ports := []*int64{}
+	for _, listener := range lb.ListenerDescriptions {
+		ports = append(ports, listener.Listener.LoadBalancerPort)
+	}
+	if len(ports) > 0 {
+		_, err = c.elbClient.DeleteLoadBalancerListeners(&elb.DeleteLoadBalancerListenersInput{
+			LoadBalancerName:  aws.String(elbName),
+			LoadBalancerPorts: ports,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	_, err = c.elbClient.DeleteLoadBalancer(&elb.DeleteLoadBalancerInput{
+		LoadBalancerName: aws.String(elbName),
+	})
+	if err != nil {
+		return err
+	}
+	log.Info("Deleted load balancer", "elbName", elbName)
+	return nil
+}
+
+// deleteNetworkLoadBalancer deletes the listeners of the network load balancer
+// nlbName, then the load balancer and finally the target groups the listeners
+// forwarded to, which deregisters their instances
+func (c *Client) deleteNetworkLoadBalancer(nlbName string) error {
+	nlb, err := c.doesNLBExist(nlbName)
+	if _, notFound := err.(*errors.LoadBalancerNotReadyError); notFound {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	listeners, err := c.elbv2Client.DescribeListeners(&elbv2.DescribeListenersInput{
+		LoadBalancerArn: aws.String(nlb.loadBalancerArn),
+	})
+	if err != nil {
+		return err
+	}
+	targetGroupArns := []string{}
+	for _, listener := range listeners.Listeners {
+		for _, action := range listener.DefaultActions {
+			if action.TargetGroupArn != nil {
+				targetGroupArns = append(targetGroupArns, aws.StringValue(action.TargetGroupArn))
+			}
+		}
+		_, err = c.elbv2Client.DeleteListener(&elbv2.DeleteListenerInput{
+			ListenerArn: listener.ListenerArn,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	err = c.deleteExternalLoadBalancer(nlb.loadBalancerArn)
+	if err != nil {
+		return err
+	}
+	for _, targetGroupArn := range targetGroupArns {
+		_, err = c.elbv2Client.DeleteTargetGroup(&elbv2.DeleteTargetGroupInput{
+			TargetGroupArn: aws.String(targetGroupArn),
+		})
+		if err != nil {
+			return err
+		}
+	}
+	log.Info("Deleted load balancer", "nlbName", nlbName, "targetGroups", targetGroupArns)
+	return nil
+}
+
+// ensureAdminAPIIPAddressType switches the network load balancer of svc
+// between ipv4 and dualstack when its IP address type annotation asks, which
+// the cloud provider only does when it creates it. Classic ELBs, and Services
+// without the annotation, are left alone
+func (c *Client) ensureAdminAPIIPAddressType(ctx context.Context, kclient client.Client, instance *cloudingressv1alpha1.APIScheme, svc *corev1.Service) error {
+	ipAddressType, ok := svc.Annotations[ipAddressTypeAnnotationKey]
+	if !ok || svc.Annotations[nlbTypeAnnotationKey] != "nlb" {
+		return nil
+	}
+	awsNLB, err := c.getLoadBalancerForService(svc)
+	if err != nil {
+		return err
+	}
+	if awsNLB.ipAddressType == ipAddressType {
+		return nil
+	}
+	return c.setLoadBalancerIPAddressType(awsNLB.loadBalancerArn, ipAddressType)
+}
+
+// ensureAdminAPIAccessLogs annotates svc with the access log settings of the
+// APIScheme, then sets them on the rh-api load balancer. The cloud provider
+// only handles the annotations for classic ELBs, so network load balancers
+// depend on the latter. Either is only changed to write to a bucket once
+// checkAccessLogBucket finds the load balancer can
+func (c *Client) ensureAdminAPIAccessLogs(ctx context.Context, kclient client.Client, instance *cloudingressv1alpha1.APIScheme, svc *corev1.Service) error {
+	accessLogs := instance.Spec.ManagementAPIServerIngress.AccessLogs
+	// Services that never had access logs are left alone
+	if accessLogs == nil && !metav1.HasAnnotation(svc.ObjectMeta, accessLogEnabledAnnotationKey) {
+		return nil
+	}
+	annotations := accessLogAnnotations(accessLogs)
+	changed := false
+	for _, key := range []string{accessLogEnabledAnnotationKey, accessLogEmitIntervalAnnotationKey, accessLogBucketNameAnnotationKey, accessLogBucketPrefixAnnotationKey} {
+		value, ok := annotations[key]
+		if !ok {
+			if metav1.HasAnnotation(svc.ObjectMeta, key) {
+				delete(svc.Annotations, key)
+				changed = true
+			}
+			continue
+		}
+		if svc.Annotations[key] != value {
+			metav1.SetMetaDataAnnotation(&svc.ObjectMeta, key, value)
+			changed = true
+		}
+	}
+	if changed {
+		if accessLogs != nil {
+			err := c.checkAccessLogBucket(accessLogs, svc.Annotations[nlbTypeAnnotationKey] == "nlb")
+			if err != nil {
+				return err
+			}
+		}
+		err := kclient.Update(ctx, svc)
+		if err != nil {
+			return err
+		}
+		log.Info("Updated the access log annotations of the Service", "service", svc.Name)
+	}
+
+	awsELB, err := c.getLoadBalancerForService(svc)
+	if err != nil {
+		return err
+	}
+	if awsELB.loadBalancerArn == "" {
+		return c.setClassicELBAccessLogs(awsELB.elbName, accessLogs)
+	}
+	return c.setNLBAccessLogs(awsELB.loadBalancerArn, accessLogs)
+}
+
+// ensureAdminAPITLSPolicy makes the SSL and TLS listeners of the rh-api load
+// balancer negotiate with the security policy of the APIScheme. Listeners
+// passing TCP through are left alone, as are all listeners if the APIScheme
+// doesn't name a policy
+func (c *Client) ensureAdminAPITLSPolicy(ctx context.Context, kclient client.Client, instance *cloudingressv1alpha1.APIScheme, svc *corev1.Service) error {
+	policy := instance.Spec.ManagementAPIServerIngress.TLSSecurityPolicy
+	if policy == "" {
+		return nil
+	}
+	awsELB, err := c.getLoadBalancerForService(svc)
+	if err != nil {
+		return err
+	}
+	if awsELB.loadBalancerArn == "" {
+		return c.setClassicELBTLSPolicy(awsELB.elbName, policy)
+	}
+	return c.setNLBTLSPolicy(awsELB.loadBalancerArn, policy)
+}
+
+// ensureAdminAPICertificate makes the SSL and TLS listeners of the rh-api load
+// balancer serve the ACM certificate of the APIScheme. When it changes, eg to a
+// renewed certificate, it's swapped on each listener in place, without
+// dropping the listener, and the load balancer is checked to serve it
+// afterwards. Listeners passing TCP through are left alone, as are all
+// listeners if the APIScheme doesn't name a certificate
+func (c *Client) ensureAdminAPICertificate(ctx context.Context, kclient client.Client, instance *cloudingressv1alpha1.APIScheme, svc *corev1.Service) error {
+	certificateARN := instance.Spec.ManagementAPIServerIngress.CertificateARN
+	if certificateARN == "" {
+		return nil
+	}
+	if err := checkARNPartitions([]string{certificateARN}, c.partition); err != nil {
+		return err
+	}
+	awsELB, err := c.getLoadBalancerForService(svc)
+	if err != nil {
+		return err
+	}
+	var swapped []int64
+	if awsELB.loadBalancerArn == "" {
+		swapped, err = c.setClassicELBCertificate(awsELB.elbName, certificateARN)
+	} else {
+		swapped, err = c.setNLBCertificate(awsELB.loadBalancerArn, certificateARN)
+	}
+	if err != nil || len(swapped) == 0 {
+		return err
+	}
+	return c.verifyServedCertificate(awsELB.dnsName, swapped, certificateARN)
+}
+
+// ensureAdminAPIStickiness sets the stickiness attributes of the APIScheme on
+// the target groups of the rh-api network load balancer. Classic ELBs only
+// keep HTTP sessions sticky, with cookies, and the rh-api listeners pass TCP
+// through, so they're left alone, as are the target groups if the APIScheme
+// doesn't ask for stickiness
+func (c *Client) ensureAdminAPIStickiness(ctx context.Context, kclient client.Client, instance *cloudingressv1alpha1.APIScheme, svc *corev1.Service) error {
+	stickiness := instance.Spec.ManagementAPIServerIngress.Stickiness
+	if stickiness == nil {
+		return nil
+	}
+	awsELB, err := c.getLoadBalancerForService(svc)
+	if err != nil {
+		return err
+	}
+	if awsELB.loadBalancerArn == "" {
+		return nil
+	}
+	return c.setNLBStickiness(awsELB.loadBalancerArn, stickiness)
+}
+
+// ensureAdminAPIAlarms ensures the CloudWatch alarms for the rh-api classic
+// ELB match the APIScheme. They are removed if the APIScheme doesn't ask for
+// them, or the admin API is served by a network load balancer
+func (c *Client) ensureAdminAPIAlarms(ctx context.Context, kclient client.Client, instance *cloudingressv1alpha1.APIScheme, svc *corev1.Service) error {
+	alarms := instance.Spec.ManagementAPIServerIngress.Alarms
+	if alarms == nil || svc.Annotations[nlbTypeAnnotationKey] == "nlb" {
+		return c.deleteAdminAPIAlarms(ctx, kclient, instance)
+	}
+	if err := checkARNPartitions(alarms.ActionARNs, c.partition); err != nil {
+		return err
+	}
+	clusterName, err := baseutils.GetClusterName(kclient)
+	if err != nil {
+		return err
+	}
+	awsELB, err := c.getLoadBalancerForService(svc)
+	if err != nil {
+		return err
+	}
+	existing, err := c.getMetricAlarms(adminAPIAlarmNames(clusterName))
+	if err != nil {
+		return err
+	}
+	for _, desired := range c.adminAPIAlarms(clusterName, awsELB.elbName, alarms) {
+		if alarm, ok := existing[aws.StringValue(desired.AlarmName)]; ok && alarmMatches(alarm, desired) {
+			continue
+		}
+		_, err = c.cloudWatchClient.PutMetricAlarm(desired)
+		if err != nil {
+			return err
+		}
+		log.Info("Updated CloudWatch alarm", "alarmName", aws.StringValue(desired.AlarmName))
+	}
+	return nil
+}
+
+// checkARNPartitions checks that every one of arns is in the partition of the
+// cluster, as AWS resources can't refer to other partitions. An unknown
+// partition isn't checked
+func checkARNPartitions(arns []string, partition string) error {
+	if partition == "" {
+		return nil
+	}
+	for _, resource := range arns {
+		parsed, err := arn.Parse(resource)
+		if err != nil {
+			return fmt.Errorf("invalid ARN %s: %v", resource, err)
+		}
+		if parsed.Partition != partition {
+			return fmt.Errorf("ARN %s isn't in the cluster's AWS partition %s", resource, partition)
+		}
+	}
+	return nil
+}
+
+// deleteAdminAPIAlarms deletes the CloudWatch alarms for the rh-api classic
+// ELB, if they exist
+func (c *Client) deleteAdminAPIAlarms(ctx context.Context, kclient client.Client, instance *cloudingressv1alpha1.APIScheme) error {
+	clusterName, err := baseutils.GetClusterName(kclient)
+	if err != nil {
+		return err
+	}
+	existing, err := c.getMetricAlarms(adminAPIAlarmNames(clusterName))
+	if err != nil || len(existing) == 0 {
+		return err
+	}
+	names := []*string{}
+	for name := range existing {
+		names = append(names, aws.String(name))
+	}
+	// Deleting an alarm that doesn't exist fails the whole call, so only the
+	// existing ones are asked for
+	_, err = c.cloudWatchClient.DeleteAlarms(&cloudwatch.DeleteAlarmsInput{
+		AlarmNames: names,
+	})
+	if err != nil {
+		return err
+	}
+	log.Info("Deleted CloudWatch alarms", "alarmNames", aws.StringValueSlice(names))
+	return nil
+}
+
+// checkAdminAPIDrift compares the rh-api load balancer against the Service
+// and its DNS records against the load balancer, without changing either.
+// Returns the drifted components
+func (c *Client) checkAdminAPIDrift(ctx context.Context, kclient client.Client, instance *cloudingressv1alpha1.APIScheme, svc *corev1.Service) ([]string, error) {
+	awsELB, err := c.getLoadBalancerForService(svc)
+	if err != nil {
+		return []string{}, err
+	}
+	drifted := []string{}
+	// Network load balancers are checked for their targets and DNS drift only
+	if awsELB.loadBalancerArn == "" {
+		drifted, err = c.getClassicELBDrift(kclient, awsELB.elbName, svc)
+		if err != nil {
+			return []string{}, err
+		}
+		current, desired, err := c.getClassicELBSubnets(kclient, instance, awsELB.elbName)
+		if err != nil {
+			return drifted, err
+		}
+		if !baseutils.SameMembers(current, desired) {
+			drifted = append(drifted, "subnets")
+		}
+	} else {
+		drifted, err = c.getNetworkLoadBalancerDrift(kclient, awsELB.loadBalancerArn, svc)
+		if err != nil {
+			return []string{}, err
+		}
+	}
+
+	// external-dns owns the records of its APISchemes
+	if instance.Spec.ManagementAPIServerIngress.DNSManagement == cloudingressv1alpha1.DNSManagementExternalDNS {
+		return drifted, nil
+	}
+	lb, err := c.getAdminAPILoadBalancer(kclient, instance)
+	if err != nil {
+		return drifted, err
+	}
+	exist, err := c.dnsRecordsExist(lb, awsELB)
+	if err != nil {
+		return drifted, err
+	}
+	if !exist {
+		drifted = append(drifted, "dns")
+	}
+	return drifted, nil
+}
+
+// repairAdminAPIDrift repairs the drift checkAdminAPIDrift finds. Load
+// balancer drift is repaired by the cloud provider, which is asked to resync
+// the Service; DNS drift is repaired directly
+func (c *Client) repairAdminAPIDrift(ctx context.Context, kclient client.Client, instance *cloudingressv1alpha1.APIScheme, svc *corev1.Service) ([]string, error) {
+	drifted, err := c.checkAdminAPIDrift(ctx, kclient, instance, svc)
+	if err != nil {
+		return drifted, err
+	}
+	lbDrifted := []string{}
+	dnsDrifted := false
+	subnetsDrifted := false
+	securityGroupsDrifted := false
+	instancesDrifted := false
+	for _, component := range drifted {
+		switch component {
+		case "dns":
+			dnsDrifted = true
+		case "instances", "targets":
+			instancesDrifted = true
+		case "subnets":
+			subnetsDrifted = true
+		case "securitygroups":
+			securityGroupsDrifted = true
+		default:
+			lbDrifted = append(lbDrifted, component)
+		}
+	}
+	if len(lbDrifted) > 0 {
+		log.Info("Load balancer drifted from its Service, asking the cloud provider to resync", "service", svc.Name, "drifted", lbDrifted)
+		err = baseutils.RequestLoadBalancerResync(kclient, svc)
+		if err != nil {
+			return drifted, err
+		}
+	}
+	// The cloud provider only syncs the subnets of a classic ELB when the
+	// Service or the nodes change, so they're moved directly
+	if subnetsDrifted {
+		awsELB, err := c.getLoadBalancerForService(svc)
+		if err != nil {
+			return drifted, err
+		}
+		err = c.syncClassicELBSubnets(kclient, instance, awsELB.elbName)
+		if err != nil {
+			return drifted, err
+		}
+	}
+	// Nor does it swap the groups of an existing ELB
+	if securityGroupsDrifted {
+		err = c.applyClassicELBSecurityGroups(loadBalancerNameForService(svc), strings.Split(svc.Annotations[securityGroupsAnnotationKey], ","))
+		if err != nil {
+			return drifted, err
+		}
+	}
+	// The instances of a classic ELB, and the targets of a network load
+	// balancer, are registered and deregistered directly, only those that
+	// changed
+	if instancesDrifted {
+		awsELB, err := c.getLoadBalancerForService(svc)
+		if err != nil {
+			return drifted, err
+		}
+		if awsELB.loadBalancerArn == "" {
+			err = c.syncClassicELBInstances(kclient, awsELB.elbName, svc)
+		} else {
+			err = c.syncNetworkLoadBalancerTargets(kclient, awsELB.loadBalancerArn, svc)
+		}
+		if err != nil {
+			return drifted, err
+		}
+	}
+	if dnsDrifted {
+		err = c.ensureAdminAPIDNS(ctx, kclient, instance, svc)
+		if err != nil {
+			return drifted, err
+		}
+	}
+	return drifted, nil
+}
+
+// getAdminAPIRegisteredInstances returns the IDs of the instances registered
+// with the rh-api load balancer: those added to a classic ELB, or the targets
+// of a network load balancer's target groups
+func (c *Client) getAdminAPIRegisteredInstances(ctx context.Context, kclient client.Client, instance *cloudingressv1alpha1.APIScheme, svc *corev1.Service) ([]string, error) {
+	awsELB, err := c.getLoadBalancerForService(svc)
+	if err != nil {
+		return []string{}, err
+	}
+	instanceIDs := []string{}
+	if awsELB.loadBalancerArn == "" {
+		output, err := c.elbClient.DescribeLoadBalancers(&elb.DescribeLoadBalancersInput{
+			LoadBalancerNames: []*string{aws.String(awsELB.elbName)},
+		})
+		if err != nil {
+			return []string{}, err
+		}
+		if len(output.LoadBalancerDescriptions) == 0 {
+			return []string{}, errors.NewLoadBalancerNotReadyError()
+		}
+		for _, registered := range output.LoadBalancerDescriptions[0].Instances {
+			instanceIDs = append(instanceIDs, aws.StringValue(registered.InstanceId))
+		}
+		return instanceIDs, nil
+	}
+
+	targetGroups, err := c.elbv2Client.DescribeTargetGroups(&elbv2.DescribeTargetGroupsInput{
+		LoadBalancerArn: aws.String(awsELB.loadBalancerArn),
+	})
+	if err != nil {
+		return []string{}, err
+	}
+	// An instance is registered once per listener port
+	seen := make(map[string]bool)
+	for _, targetGroup := range targetGroups.TargetGroups {
+		output, err := c.elbv2Client.DescribeTargetHealth(&elbv2.DescribeTargetHealthInput{
+			TargetGroupArn: targetGroup.TargetGroupArn,
+		})
+		if err != nil {
+			return []string{}, err
+		}
+		for _, description := range output.TargetHealthDescriptions {
+			instanceID := aws.StringValue(description.Target.Id)
+			if !seen[instanceID] {
+				seen[instanceID] = true
+				instanceIDs = append(instanceIDs, instanceID)
+			}
+		}
+	}
+	return instanceIDs, nil
+}
+
+// getAdminAPIHealthyInstances returns the IDs of the instances InService on
+// the rh-api classic ELB, or healthy in a target group of the network load
+// balancer
+func (c *Client) getAdminAPIHealthyInstances(ctx context.Context, kclient client.Client, instance *cloudingressv1alpha1.APIScheme, svc *corev1.Service) ([]string, error) {
+	awsELB, err := c.getLoadBalancerForService(svc)
+	if err != nil {
+		return []string{}, err
+	}
+	instanceIDs := []string{}
+	if awsELB.loadBalancerArn == "" {
+		output, err := c.elbClient.DescribeInstanceHealth(&elb.DescribeInstanceHealthInput{
+			LoadBalancerName: aws.String(awsELB.elbName),
+		})
+		if err != nil {
+			return []string{}, err
+		}
+		for _, state := range output.InstanceStates {
+			if aws.StringValue(state.State) == "InService" {
+				instanceIDs = append(instanceIDs, aws.StringValue(state.InstanceId))
+			}
+		}
+		return instanceIDs, nil
+	}
+
+	targetGroups, err := c.elbv2Client.DescribeTargetGroups(&elbv2.DescribeTargetGroupsInput{
+		LoadBalancerArn: aws.String(awsELB.loadBalancerArn),
+	})
+	if err != nil {
+		return []string{}, err
+	}
+	seen := make(map[string]bool)
+	for _, targetGroup := range targetGroups.TargetGroups {
+		output, err := c.elbv2Client.DescribeTargetHealth(&elbv2.DescribeTargetHealthInput{
+			TargetGroupArn: targetGroup.TargetGroupArn,
+		})
+		if err != nil {
+			return []string{}, err
+		}
+		for _, description := range output.TargetHealthDescriptions {
+			instanceID := aws.StringValue(description.Target.Id)
+			if description.TargetHealth == nil || aws.StringValue(description.TargetHealth.State) != elbv2.TargetHealthStateEnumHealthy {
+				continue
+			}
+			if !seen[instanceID] {
+				seen[instanceID] = true
+				instanceIDs = append(instanceIDs, instanceID)
+			}
+		}
+	}
+	return instanceIDs, nil
+}
+
+// getAdminAPIInstanceHealth returns the instances registered with the rh-api
+// load balancer, with their availability zone and their state: from
+// DescribeInstanceHealth on a classic ELB, or the worst of their target
+// health across the target groups of a network load balancer
+func (c *Client) getAdminAPIInstanceHealth(ctx context.Context, kclient client.Client, instance *cloudingressv1alpha1.APIScheme, svc *corev1.Service) ([]cloudingressv1alpha1.RegisteredInstance, error) {
+	awsELB, err := c.getLoadBalancerForService(svc)
+	if err != nil {
+		return []cloudingressv1alpha1.RegisteredInstance{}, err
+	}
+	registered := []cloudingressv1alpha1.RegisteredInstance{}
+	if awsELB.loadBalancerArn == "" {
+		output, err := c.elbClient.DescribeInstanceHealth(&elb.DescribeInstanceHealthInput{
+			LoadBalancerName: aws.String(awsELB.elbName),
+		})
+		if err != nil {
+			return []cloudingressv1alpha1.RegisteredInstance{}, err
+		}
+		for _, state := range output.InstanceStates {
+			registered = append(registered, cloudingressv1alpha1.RegisteredInstance{
+				InstanceID:  aws.StringValue(state.InstanceId),
+				State:       aws.StringValue(state.State),
+				Description: aws.StringValue(state.Description),
+			})
+		}
+	} else {
+		targetGroups, err := c.elbv2Client.DescribeTargetGroups(&elbv2.DescribeTargetGroupsInput{
+			LoadBalancerArn: aws.String(awsELB.loadBalancerArn),
+		})
+		if err != nil {
+			return []cloudingressv1alpha1.RegisteredInstance{}, err
+		}
+		// An instance is registered once per listener port
+		index := make(map[string]int)
+		for _, targetGroup := range targetGroups.TargetGroups {
+			output, err := c.elbv2Client.DescribeTargetHealth(&elbv2.DescribeTargetHealthInput{
+				TargetGroupArn: targetGroup.TargetGroupArn,
+			})
+			if err != nil {
+				return []cloudingressv1alpha1.RegisteredInstance{}, err
+			}
+			for _, description := range output.TargetHealthDescriptions {
+				target := cloudingressv1alpha1.RegisteredInstance{
+					InstanceID:       aws.StringValue(description.Target.Id),
+					AvailabilityZone: aws.StringValue(description.Target.AvailabilityZone),
+				}
+				if description.TargetHealth != nil {
+					target.State = aws.StringValue(description.TargetHealth.State)
+					target.Description = aws.StringValue(description.TargetHealth.Description)
+				}
+				i, seen := index[target.InstanceID]
+				if !seen {
+					index[target.InstanceID] = len(registered)
+					registered = append(registered, target)
+				} else if registered[i].State == elbv2.TargetHealthStateEnumHealthy {
+					// Report the listener it isn't healthy on, if any
+					registered[i] = target
+				}
+			}
+		}
+	}
+
+	// Targets only have a zone when they're IP addresses
+	instanceIDs := []*string{}
+	for _, r := range registered {
+		if r.AvailabilityZone == "" {
+			instanceIDs = append(instanceIDs, aws.String(r.InstanceID))
+		}
+	}
+	if len(instanceIDs) == 0 {
+		return registered, nil
+	}
+	output, err := c.ec2Client.DescribeInstances(&ec2.DescribeInstancesInput{InstanceIds: instanceIDs})
+	if err != nil {
+		return []cloudingressv1alpha1.RegisteredInstance{}, err
+	}
+	zones := make(map[string]string)
+	for _, reservation := range output.Reservations {
+		for _, ec2Instance := range reservation.Instances {
+			if ec2Instance.Placement != nil {
+				zones[aws.StringValue(ec2Instance.InstanceId)] = aws.StringValue(ec2Instance.Placement.AvailabilityZone)
+			}
+		}
+	}
+	for i := range registered {
+		if registered[i].AvailabilityZone == "" {
+			registered[i].AvailabilityZone = zones[registered[i].InstanceID]
+		}
+	}
+	return registered, nil
+}
+
+// adminAPILoadBalancerExists checks the classic ELB or network load balancer
+// of the rh-api Service exists. The cloud provider names it after the
+// Service, so one it creates again has the same name
+func (c *Client) adminAPILoadBalancerExists(ctx context.Context, kclient client.Client, instance *cloudingressv1alpha1.APIScheme, svc *corev1.Service) (bool, error) {
+	elbName := loadBalancerNameForService(svc)
+	var err error
+	if svc.Annotations[nlbTypeAnnotationKey] == "nlb" {
+		_, err = c.doesNLBExist(elbName)
+	} else {
+		_, err = c.doesELBExist(elbName)
+	}
+	if _, notFound := err.(*errors.LoadBalancerNotReadyError); notFound {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// getAdminAPILoadBalancerAttributes returns the attributes of the rh-api load
+// balancer. Network load balancers have no idle timeout to set, and drain the
+// connections of their targets per target group
+func (c *Client) getAdminAPILoadBalancerAttributes(ctx context.Context, kclient client.Client, instance *cloudingressv1alpha1.APIScheme, svc *corev1.Service) (*cloudingressv1alpha1.LoadBalancerAttributes, error) {
+	awsELB, err := c.getLoadBalancerForService(svc)
+	if err != nil {
+		return nil, err
+	}
+	attributes := &cloudingressv1alpha1.LoadBalancerAttributes{}
+	if awsELB.loadBalancerArn == "" {
+		output, err := c.elbClient.DescribeLoadBalancerAttributes(&elb.DescribeLoadBalancerAttributesInput{
+			LoadBalancerName: aws.String(awsELB.elbName),
+		})
+		if err != nil {
+			return nil, err
+		}
+		current := output.LoadBalancerAttributes
+		if current.CrossZoneLoadBalancing != nil {
+			attributes.CrossZone = aws.Bool(aws.BoolValue(current.CrossZoneLoadBalancing.Enabled))
+		}
+		if current.ConnectionDraining != nil {
+			attributes.ConnectionDraining = aws.Bool(aws.BoolValue(current.ConnectionDraining.Enabled))
+		}
+		if current.ConnectionSettings != nil {
+			attributes.IdleTimeoutSeconds = aws.Int64(aws.Int64Value(current.ConnectionSettings.IdleTimeout))
+		}
+		if current.AccessLog != nil {
+			attributes.AccessLogs = aws.Bool(aws.BoolValue(current.AccessLog.Enabled))
+		}
+		return attributes, nil
+	}
+	output, err := c.elbv2Client.DescribeLoadBalancerAttributes(&elbv2.DescribeLoadBalancerAttributesInput{
+		LoadBalancerArn: aws.String(awsELB.loadBalancerArn),
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, attribute := range output.Attributes {
+		enabled := aws.StringValue(attribute.Value) == "true"
+		switch aws.StringValue(attribute.Key) {
+		case "load_balancing.cross_zone.enabled":
+			attributes.CrossZone = aws.Bool(enabled)
+		case "access_logs.s3.enabled":
+			attributes.AccessLogs = aws.Bool(enabled)
+		}
+	}
+	return attributes, nil
+}
+
+// getAdminAPINameServers returns the nameservers of the delegation sets of the
+// hosted zones the rh-api records go in. Private zones have none
+func (c *Client) getAdminAPINameServers(ctx context.Context, kclient client.Client, instance *cloudingressv1alpha1.APIScheme) ([]string, error) {
+	lb, err := c.getAdminAPILoadBalancer(kclient, instance)
+	if err != nil {
+		return []string{}, err
+	}
+	zones, err := c.getHostedZones(lb)
+	if err != nil {
+		return []string{}, err
+	}
+	nameServers := []string{}
+	seen := map[string]bool{}
+	for _, zone := range zones {
+		if zone.private {
+			continue
+		}
+		output, err := c.route53Client.GetHostedZone(&route53.GetHostedZoneInput{
+			Id: aws.String(zone.id),
+		})
+		if err != nil {
+			return []string{}, err
+		}
+		if output.DelegationSet == nil {
+			continue
+		}
+		for _, nameServer := range aws.StringValueSlice(output.DelegationSet.NameServers) {
+			if !seen[nameServer] {
+				seen[nameServer] = true
+				nameServers = append(nameServers, nameServer)
+			}
+		}
+	}
+	return nameServers, nil
+}
+
+// ensureSSHDNS ensures the DNS record for the SSH Service LoadBalancer is set
+func (c *Client) ensureSSHDNS(ctx context.Context, kclient client.Client, instance *cloudingressv1alpha1.SSHD, svc *corev1.Service) error {
+	lb, err := c.newClusterLoadBalancer(kclient, instance.Spec.DNSName)
+	if err != nil {
+		return err
+	}
+	return c.ensureDNSForService(ctx, svc, lb, "RH SSH Endpoint")
+}
+
+// deleteSSHDNS ensures the DNS record for the SSH Service AWS LoadBalancer is unset
+func (c *Client) deleteSSHDNS(ctx context.Context, kclient client.Client, instance *cloudingressv1alpha1.SSHD, svc *corev1.Service) error {
+	lb, err := c.newClusterLoadBalancer(kclient, instance.Spec.DNSName)
+	if err != nil {
+		return err
+	}
+	return c.removeDNSForService(ctx, svc, lb)
+}
+
+// setDefaultAPIPrivate sets the default api (api.<cluster-domain>) to private
+// scope
+func (c *Client) setDefaultAPIPrivate(ctx context.Context, kclient client.Client, instance *cloudingressv1alpha1.PublishingStrategy) error {
+	// Only the external NLB is toggled. The internal one must keep serving
+	// the API, from inside the VPC and over peered networks like the
+	// management VPN, before the external one goes
+	err := c.ensureInternalAPIListener(kclient)
+	if err != nil {
+		return err
+	}
+
+	if instance.Spec.DefaultAPIServerIngress.ConnectionDraining != nil {
+		err := c.drainExternalLoadBalancer(ctx, kclient, instance)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Remove the public listeners of the NLB, and unless some are kept, the NLB
+	// itself from AWS and from the master Machine objects in cluster. At the
+	// same time, get the name of the DNS zone and base domain for the internal
+	// load balancer
+	intDNSName, intHostedZoneID, err := c.removeLoadBalancerFromMasterNodes(ctx, kclient, instance.Spec.DefaultAPIServerIngress.KeepPublicPorts)
+	if err != nil {
+		return err
+	}
+	instance.Status.DefaultAPIServerIngress = nil
+
+	baseDomain, err := baseutils.GetClusterBaseDomain(kclient)
+	if err != nil {
+		return err
+	}
+
+	pubDomainName := baseDomain[strings.Index(baseDomain, ".")+1:]
+	apiDNSName := fmt.Sprintf("api.%s.", baseDomain)
+	comment := "Update api.<clusterName> alias to internal NLB"
+	err = c.upsertARecord(pubDomainName+".", intDNSName, intHostedZoneID, apiDNSName, comment, false)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// ensureInternalAPIListener ensures the cluster's internal API NLB has a
+// listener on the internal API port forwarding to the internal API target
+// group, and that the targets
+// are healthy. Returns LoadBalancerNotReadyError while they aren't, and an
+// error if there is no internal NLB at all, as the API would then only be
+// reachable through the external one
+func (c *Client) ensureInternalAPIListener(kclient client.Client) error {
+	nlbs, err := c.listOwnedNLBs(kclient)
+	if err != nil {
+		return err
+	}
+	var intNLB *loadBalancerV2
+	for i := range nlbs {
+		if nlbs[i].scheme == "internal" {
+			intNLB = &nlbs[i]
+		}
+	}
+	if intNLB == nil {
+		return goError.New("No internal API load balancer, can't change API to private")
+	}
+	infrastructureName, err := baseutils.GetClusterName(kclient)
+	if err != nil {
+		return err
+	}
+	targetGroupARN, err := c.getTargetGroupArn(infrastructureName + "-" + config.InternalAPITargetGroupSuffix)
+	if err != nil {
+		return err
+	}
+	port, err := baseutils.GetClusterInternalAPIPort(kclient)
+	if err != nil {
+		return err
+	}
+	// Creating a listener that exists with the same settings returns it. One
+	// on the API port forwarding elsewhere is left alone, it's serving the API
+	err = c.createListenerForNLB(targetGroupARN, intNLB.loadBalancerArn, port)
+	if aerr, ok := err.(awserr.Error); ok && aerr.Code() == elbv2.ErrCodeDuplicateListenerException {
+		err = nil
+	}
+	if err != nil {
+		return err
+	}
+	healthy, err := c.targetGroup(targetGroupARN).healthy()
+	if err != nil {
+		return err
+	}
+	if !healthy {
+		return errors.NewLoadBalancerNotReadyError()
+	}
+	return nil
+}
+
+// drainExternalLoadBalancer points api.<cluster-domain> at the internal load
+// balancer, so no new connections reach the external one, then waits for the
+// connections still open on the external load balancer to end. Returns
+// LoadBalancerDrainingError until they are at or below the threshold, or the
+// timeout passed. The progress is recorded in the status of instance
+func (c *Client) drainExternalLoadBalancer(ctx context.Context, kclient client.Client, instance *cloudingressv1alpha1.PublishingStrategy) error {
+	nlbs, err := c.listOwnedNLBs(kclient)
+	if err != nil {
+		return err
+	}
+	var extNLB, intNLB *loadBalancerV2
+	for i := range nlbs {
+		switch nlbs[i].scheme {
+		case "internet-facing":
+			extNLB = &nlbs[i]
+		case "internal":
+			intNLB = &nlbs[i]
+		}
+	}
+	if extNLB == nil || intNLB == nil {
+		// Nothing to drain, or nowhere to send new connections to
+		return nil
+	}
+
+	baseDomain, err := baseutils.GetClusterBaseDomain(kclient)
+	if err != nil {
+		return err
+	}
+	pubDomainName := baseDomain[strings.Index(baseDomain, ".")+1:]
+	apiDNSName := fmt.Sprintf("api.%s.", baseDomain)
+	comment := "Update api.<clusterName> alias to internal NLB"
+	err = c.upsertARecord(pubDomainName+".", intNLB.dnsName, intNLB.canonicalHostedZoneNameID, apiDNSName, comment, false)
+	if err != nil {
+		return err
+	}
+
+	if instance.Status.DefaultAPIServerIngress == nil {
+		log.Info("Draining the external API load balancer", "loadBalancerName", extNLB.loadBalancerName)
+		instance.Status.DefaultAPIServerIngress = &cloudingressv1alpha1.DefaultAPIServerIngressStatus{
+			DrainingStartTime: metav1.Now(),
+		}
+	}
+	status := instance.Status.DefaultAPIServerIngress
+	activeConnections, err := c.getActiveFlowCount(extNLB.loadBalancerArn)
+	if err != nil {
+		return err
+	}
+	status.ActiveConnections = activeConnections
+
+	draining := instance.Spec.DefaultAPIServerIngress.ConnectionDraining
+	if activeConnections <= draining.ActiveConnectionThreshold {
+		return nil
+	}
+	timeout := defaultDrainingTimeout
+	if draining.TimeoutSeconds > 0 {
+		timeout = time.Duration(draining.TimeoutSeconds) * time.Second
+	}
+	if time.Since(status.DrainingStartTime.Time) < timeout {
+		return errors.NewLoadBalancerDrainingError(extNLB.loadBalancerName, activeConnections)
+	}
+	log.Info("Timed out draining the external API load balancer, removing it anyway", "loadBalancerName", extNLB.loadBalancerName, "activeConnections", activeConnections)
+	return nil
+}
+
+// getActiveFlowCount returns the most recent number of active connections to
+// the network load balancer reported to CloudWatch. No data means there was
+// no traffic
+func (c *Client) getActiveFlowCount(loadBalancerArn string) (int64, error) {
+	// The metrics dimension is the end of the ARN, eg net/name/id
+	parsed, err := arn.Parse(loadBalancerArn)
+	if err != nil {
+		return 0, err
+	}
+	dimension := strings.TrimPrefix(parsed.Resource, "loadbalancer/")
+	now := time.Now()
+	output, err := c.cloudWatchClient.GetMetricStatistics(&cloudwatch.GetMetricStatisticsInput{
+		Namespace:  aws.String("AWS/NetworkELB"),
+		MetricName: aws.String("ActiveFlowCount"),
+		Dimensions: []*cloudwatch.Dimension{
+			{
+				Name:  aws.String("LoadBalancer"),
+				Value: aws.String(dimension),
+			},
+		},
+		StartTime:  aws.Time(now.Add(-5 * time.Minute)),
+		EndTime:    aws.Time(now),
+		Period:     aws.Int64(60),
+		Statistics: []*string{aws.String(cloudwatch.StatisticMaximum)},
+	})
+	if err != nil {
+		return 0, err
+	}
+	var latest *cloudwatch.Datapoint
+	for _, datapoint := range output.Datapoints {
+		if latest == nil || aws.TimeValue(datapoint.Timestamp).After(aws.TimeValue(latest.Timestamp)) {
+			latest = datapoint
+		}
+	}
+	if latest == nil {
+		return 0, nil
+	}
+	return int64(aws.Float64Value(latest.Maximum)), nil
+}
+
+// setDefaultAPIPublic sets the default API (api.<cluster-domain>) to public
+// scope
+func (c *Client) setDefaultAPIPublic(ctx context.Context, kclient client.Client, instance *cloudingressv1alpha1.PublishingStrategy) error {
+	nlbs, err := c.listOwnedNLBs(kclient)
+	if err != nil {
+		return err
+	}
+	infrastructureName, err := baseutils.GetClusterName(kclient)
+	if err != nil {
+		return err
+	}
+	var extNLB *loadBalancerV2
+	for i := range nlbs {
+		if nlbs[i].scheme == "internet-facing" {
+			extNLB = &nlbs[i]
+		}
+	}
+	if extNLB == nil {
+		// create new ext nlb
+		extNLBName := externalLoadBalancerName(infrastructureName, instance.Spec.DefaultAPIServerIngress.LoadBalancerName)
+
+		subnetIDs, err := c.getPublicSubnets(kclient, nil)
+		if err != nil {
+			return err
+		}
+		if len(subnetIDs) == 0 {
+			err = goError.New("No public subnets, can't change API to public")
+			return err
+		}
+
+		// Creating a load balancer that already exists with the same settings
+		// returns it, so an untagged one left behind is picked up again. One
+		// with other settings, like a subnet that's since been replaced, is
+		// adopted instead, unless it belongs to another cluster
+		newNLBs, err := c.createNetworkLoadBalancer(extNLBName, "internet-facing", subnetIDs[0])
+		if aerr, ok := err.(awserr.Error); ok && aerr.Code() == elbv2.ErrCodeDuplicateLoadBalancerNameException {
+			newNLBs, err = c.adoptNetworkLoadBalancer(kclient, extNLBName, "internet-facing")
+		}
+		if err != nil {
+			return err
+		}
+		if len(newNLBs) != 1 {
+			return fmt.Errorf("more than one NLB, or no new NLB detected (expected 1, got %d)", len(newNLBs))
+		}
+		err = c.addTagsForNLB(newNLBs[0].loadBalancerArn, infrastructureName, extNLBName)
+		if err != nil {
+			return err
+		}
+		extNLB = &newNLBs[0]
+	}
+
+	// The listener and the DNS record are ensured even if the external NLB
+	// exists, in case the operator stopped before getting to them. Both calls
+	// are safe to repeat
+	// attempt to use existing TargetGroup
+	targetGroupName := fmt.Sprintf("%s-aext", infrastructureName)
+	targetGroupARN, err := c.getTargetGroupArn(targetGroupName)
+	if err != nil {
+		return err
+	}
+	port, err := baseutils.GetClusterAPIPort(kclient)
+	if err != nil {
+		return err
+	}
+	err = c.createListenerForNLB(targetGroupARN, extNLB.loadBalancerArn, port)
+	if err != nil {
+		if aerr, ok := err.(awserr.Error); ok {
+			if aerr.Code() == "TargetGroupAssociationLimit" {
+				// not possible to modify lb, we'd have to create a new targetGroup
+				return nil
+			}
+			return err
+		}
+		// TODO: log - cant create listener for new ext nlb
+		return err
+	}
+
+	// Traffic is only switched to the external NLB once it can serve it
+	healthy, err := c.targetGroup(targetGroupARN).healthy()
+	if err != nil {
+		return err
+	}
+	if !healthy {
+		return errors.NewLoadBalancerNotReadyError()
+	}
+
+	// can't create listener for new ext nlb
+	baseDomain, err := baseutils.GetClusterBaseDomain(kclient)
+	if err != nil {
+		return err
+	}
+	pubDomainName := baseDomain[strings.Index(baseDomain, ".")+1:]
+	apiDNSName := fmt.Sprintf("api.%s.", baseDomain)
+	// not tested yet
+	comment := "Update api.<clusterName> alias to external NLB"
+	err = c.upsertARecord(pubDomainName+".",
+		extNLB.dnsName,
+		extNLB.canonicalHostedZoneNameID,
+		apiDNSName,
+		comment,
+		false)
+	if err != nil {
+		return err
+	}
+	// success
+	return nil
+}
+
+// ensureApplicationIngressDNS is a no-op on AWS: the routerservice controller
+// keeps the Route53 aliases of the application ingresses pointed at their
+// router load balancers with ensureRouterServiceDNS, as each router Service's
+// load balancer changes
+func (c *Client) ensureApplicationIngressDNS(ctx context.Context, kclient client.Client, instance *cloudingressv1alpha1.PublishingStrategy) error {
+	return nil
+}
+
+// ensureRouterServiceDNS points the wildcard alias records of appIngress,
+// *.<DNSName>, at the current load balancer of its router Service svc. They're
+// upserted in the cluster's private zone, and in its public zone unless the
+// load balancer is internal, in which case any left there by the external one
+// it replaced are removed. ApplicationIngresses outside the cluster's base
+// domain aren't published by the cluster and are left alone
+func (c *Client) ensureRouterServiceDNS(ctx context.Context, kclient client.Client, appIngress *cloudingressv1alpha1.ApplicationIngress, svc *corev1.Service) error {
+	clusterBaseDomain, err := baseutils.GetClusterBaseDomain(kclient)
+	if err != nil {
+		return err
+	}
+	if !baseutils.IsInDNSZone(appIngress.DNSName, clusterBaseDomain) || strings.EqualFold(strings.TrimSuffix(appIngress.DNSName, "."), clusterBaseDomain) {
+		return nil
+	}
+	if len(svc.Status.LoadBalancer.Ingress) == 0 {
+		return errors.NewLoadBalancerNotReadyError()
+	}
+	endpointName := "*." + strings.TrimSuffix(strings.TrimSuffix(appIngress.DNSName, "."), "."+clusterBaseDomain)
+	lb, err := c.newClusterLoadBalancer(kclient, endpointName)
+	if err != nil {
+		return err
+	}
+	awsELB, err := c.getLoadBalancerForService(svc)
+	if err != nil {
+		return err
+	}
+	err = c.ensureDNSRecord(lb, awsELB, "RH Application Ingress")
+	if err != nil {
+		return err
+	}
+	if awsELB.ipAddressType == elbv2.IpAddressTypeDualstack {
+		return nil
+	}
+	// Nor do AAAA records of a dual-stack load balancer it replaced
+	zones, err := c.getHostedZones(lb)
+	if err != nil {
+		return err
+	}
+	for _, zone := range zones {
+		err = c.deleteAliasRecordsNamed(zone.id, lb.endpointName+"."+lb.baseDomain, []string{"AAAA"})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ensureApplicationIngressLoadBalancers is a no-op on AWS: the scheme of a
+// router load balancer is an annotation of its Service, which the
+// routerservice controller enforces, and the cloud provider manages its
+// security groups
+func (c *Client) ensureApplicationIngressLoadBalancers(ctx context.Context, kclient client.Client, instance *cloudingressv1alpha1.PublishingStrategy) error {
+	return nil
+}
+
+// getMasterNodeSubnets returns all the subnets for Machines with 'master' label.
+// return structure:
+// {
+//   public => subnetname,
+//   private => subnetname,
+// }
+//
+func getMasterNodeSubnets(kclient client.Client) (map[string]string, error) {
+	subnets := make(map[string]string)
+	machineList, err := baseutils.GetMasterMachines(kclient)
+	if err != nil {
+		return subnets, err
+	}
+	if len(machineList.Items) == 0 {
+		return subnets, fmt.Errorf("Did not find any master Machine objects")
+	}
+
+	// get the AZ from a Master object's providerSpec.
+	codec, err := awsproviderapi.NewCodec()
+
+	if err != nil {
+		return subnets, err
+	}
+
+	// Obtain the availability zone
+	awsconfig := &awsproviderapi.AWSMachineProviderConfig{}
+	err = codec.DecodeProviderSpec(&machineList.Items[0].Spec.ProviderSpec, awsconfig)
+	if err != nil {
+		return subnets, err
+	}
+
+	// Infra object gives us the Infrastructure name, which is the combination of
+	// cluster name and identifier.
+	infra, err := baseutils.GetInfrastructureObject(kclient)
+	if err != nil {
+		return subnets, err
+	}
+	subnets["public"] = fmt.Sprintf("%s-public-%s", infra.Status.InfrastructureName, awsconfig.Placement.AvailabilityZone)
+	subnets["private"] = fmt.Sprintf("%s-private-%s", infra.Status.InfrastructureName, awsconfig.Placement.AvailabilityZone)
+
+	return subnets, nil
+}
+
+// getPublicSubnets returns the public subnets for a load balancer in front of
+// the masters, one per availability zone. They are subnetIDs if given, or
+// otherwise the subnets of the cluster's VPC tagged for the cluster, as shared
+// VPCs hold the subnets of other clusters too. There must be one in each
+// availability zone that has masters
+func (c *Client) getPublicSubnets(kclient client.Client, subnetIDs []string) ([]string, error) {
+	targetVPC, err := c.getClusterVPC(kclient)
+	if err != nil {
+		return nil, err
+	}
+
+	var subnets []*ec2.Subnet
+	if len(subnetIDs) > 0 {
+		output, err := c.ec2Client.DescribeSubnets(&ec2.DescribeSubnetsInput{
+			SubnetIds: aws.StringSlice(subnetIDs),
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, subnet := range output.Subnets {
+			if aws.StringValue(subnet.VpcId) != targetVPC {
+				return nil, fmt.Errorf("subnet %s is in VPC %s, not in the cluster's VPC %s", aws.StringValue(subnet.SubnetId), aws.StringValue(subnet.VpcId), targetVPC)
+			}
+		}
+		subnets = output.Subnets
+	} else {
+		clusterName, err := baseutils.GetClusterName(kclient)
+		if err != nil {
+			return nil, err
+		}
+		// List all subnets in the VPC
+		allSubnets, err := c.getAllSubnetsInVPC(targetVPC)
+		if err != nil {
+			return nil, err
+		}
+		for _, subnet := range allSubnets {
+			if isSubnetTaggedForCluster(subnet, clusterName) {
+				subnets = append(subnets, subnet)
+			}
+		}
+		if len(subnets) == 0 {
+			return nil, errors.NewActionRequiredError(cloudingressv1alpha1.ActionRequiredSubnetsUntagged,
+				fmt.Sprintf("no subnet of VPC %s is tagged kubernetes.io/cluster/%s with owned or shared", targetVPC, clusterName),
+				"tag the cluster's subnets, or list the public ones in the APIScheme's subnetIDs")
+		}
+	}
+	subnets, err = c.withoutEdgeZoneSubnets(kclient, subnets, len(subnetIDs) > 0)
+	if err != nil {
+		return nil, err
+	}
+
+	// List all route tables associated with the VPC
+	routeTables, err := c.getAllRouteTablesInVPC(targetVPC)
+	if err != nil {
+		return nil, err
+	}
+	zones, err := c.getMasterAvailabilityZones(kclient)
+	if err != nil {
+		return nil, err
+	}
+	return selectPublicSubnets(subnets, routeTables, zones, len(subnetIDs) > 0)
+}
+
+// selectPublicSubnets returns the first public subnet, by ID, of each
+// availability zone, and checks every one of zones has one. If explicit, the
+// subnets were asked for by ID, so any that isn't public is an error
+func selectPublicSubnets(subnets []*ec2.Subnet, routeTables []*ec2.RouteTable, zones []string, explicit bool) ([]string, error) {
+	sorted := append([]*ec2.Subnet{}, subnets...)
+	sort.Slice(sorted, func(i, j int) bool {
+		return aws.StringValue(sorted[i].SubnetId) < aws.StringValue(sorted[j].SubnetId)
+	})
+	subnetByZone := map[string]string{}
+	for _, subnet := range sorted {
+		subnetID := aws.StringValue(subnet.SubnetId)
+		isPublic, err := isSubnetPublic(routeTables, subnetID)
+		if err != nil {
+			log.Error(err, "Error while determining if subnet is public")
+			return nil, err
+		}
+		if !isPublic {
+			if explicit {
+				return nil, fmt.Errorf("subnet %s isn't public, it has no route to an internet gateway", subnetID)
+			}
+			continue
+		}
+		zone := aws.StringValue(subnet.AvailabilityZone)
+		if _, ok := subnetByZone[zone]; !ok {
+			subnetByZone[zone] = subnetID
+		}
+	}
+	for _, zone := range zones {
+		if _, ok := subnetByZone[zone]; !ok {
+			return nil, fmt.Errorf("no public subnet in availability zone %s, where there are masters", zone)
+		}
+	}
+
+	publicZones := make([]string, 0, len(subnetByZone))
+	for zone := range subnetByZone {
+		publicZones = append(publicZones, zone)
+	}
+	sort.Strings(publicZones)
+	publicSubnets := make([]string, 0, len(publicZones))
+	for _, zone := range publicZones {
+		publicSubnets = append(publicSubnets, subnetByZone[zone])
+	}
+	return publicSubnets, nil
+}
+
+// edgeZoneTypes are the types of the AWS zones extending a region into a
+// metropolitan area or a 5G network. Classic ELBs can't be created there, and
+// network load balancers only in some
+var edgeZoneTypes = map[string]bool{
+	"local-zone":      true,
+	"wavelength-zone": true,
+}
+
+// withoutEdgeZoneSubnets returns subnets without those in Local Zones and
+// Wavelength Zones, unless the CloudIngressConfig's edgeZoneSubnets feature
+// gate is on. If explicit, the subnets were asked for by ID, so any in such a
+// zone is an error
+func (c *Client) withoutEdgeZoneSubnets(kclient client.Client, subnets []*ec2.Subnet, explicit bool) ([]*ec2.Subnet, error) {
+	ingressConfig, err := baseutils.GetCloudIngressConfig(kclient)
+	if err != nil {
+		return nil, err
+	}
+	if ingressConfig.Spec.FeatureGates.EdgeZoneSubnets {
+		return subnets, nil
+	}
+	edgeZones, err := c.getEdgeZones(subnets)
+	if err != nil {
+		return nil, err
+	}
+	kept, excluded := excludeEdgeZoneSubnets(subnets, edgeZones)
+	if len(excluded) == 0 {
+		return subnets, nil
+	}
+	if explicit {
+		return nil, errors.NewActionRequiredError(cloudingressv1alpha1.ActionRequiredEdgeZoneSubnet,
+			fmt.Sprintf("the admin API load balancer can't be created in %s", strings.Join(excluded, ", ")),
+			"list subnets of the region's availability zones in the APIScheme's subnetIDs, or turn on the CloudIngressConfig's edgeZoneSubnets feature gate")
+	}
+	log.Info("Leaving out the subnets in Local Zones and Wavelength Zones", "subnets", excluded)
+	return kept, nil
+}
+
+// getEdgeZones returns the type of each of the zones of subnets that's a
+// Local Zone or a Wavelength Zone
+func (c *Client) getEdgeZones(subnets []*ec2.Subnet) (map[string]string, error) {
+	zoneSet := map[string]bool{}
+	for _, subnet := range subnets {
+		zoneSet[aws.StringValue(subnet.AvailabilityZone)] = true
+	}
+	edgeZones := map[string]string{}
+	if len(zoneSet) == 0 {
+		return edgeZones, nil
+	}
+	zones := make([]string, 0, len(zoneSet))
+	for zone := range zoneSet {
+		zones = append(zones, zone)
+	}
+	sort.Strings(zones)
+	output, err := c.ec2Client.DescribeAvailabilityZones(&ec2.DescribeAvailabilityZonesInput{
+		// Local Zones and Wavelength Zones are only listed once opted in
+		// otherwise
+		AllAvailabilityZones: aws.Bool(true),
+		ZoneNames:            aws.StringSlice(zones),
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, zone := range output.AvailabilityZones {
+		if edgeZoneTypes[aws.StringValue(zone.ZoneType)] {
+			edgeZones[aws.StringValue(zone.ZoneName)] = aws.StringValue(zone.ZoneType)
+		}
+	}
+	return edgeZones, nil
+}
+
+// excludeEdgeZoneSubnets returns the subnets that aren't in any of edgeZones,
+// and those that are, described with their zone and its type
+func excludeEdgeZoneSubnets(subnets []*ec2.Subnet, edgeZones map[string]string) ([]*ec2.Subnet, []string) {
+	kept := []*ec2.Subnet{}
+	excluded := []string{}
+	for _, subnet := range subnets {
+		zone := aws.StringValue(subnet.AvailabilityZone)
+		if zoneType, ok := edgeZones[zone]; ok {
+			excluded = append(excluded, fmt.Sprintf("%s (%s %s)", aws.StringValue(subnet.SubnetId), zoneType, zone))
+			continue
+		}
+		kept = append(kept, subnet)
+	}
+	return kept, excluded
+}
+
+// isSubnetTaggedForCluster checks if the subnet has the
+// kubernetes.io/cluster/<name> tag the installer adds to the subnets a
+// cluster owns, or uses in a VPC it shares
+func isSubnetTaggedForCluster(subnet *ec2.Subnet, clusterName string) bool {
+	for _, tag := range subnet.Tags {
+		if aws.StringValue(tag.Key) == "kubernetes.io/cluster/"+clusterName {
+			value := aws.StringValue(tag.Value)
+			return value == "owned" || value == "shared"
+		}
+	}
+	return false
+}
+
+// getMasterAvailabilityZones returns the availability zones of the master
+// Machines, sorted. Without any, they're those of the master instances found
+// by their tags
+func (c *Client) getMasterAvailabilityZones(kclient client.Client) ([]string, error) {
+	zoneSet := map[string]bool{}
+	machineList, err := baseutils.GetMasterMachines(kclient)
+	if err == nil && len(machineList.Items) > 0 {
+		for _, machine := range machineList.Items {
+			providerSpec, err := getAWSDecodedProviderSpec(machine)
+			if err != nil {
+				return nil, err
+			}
+			zoneSet[providerSpec.Placement.AvailabilityZone] = true
+		}
+	} else {
+		log.Info("No master Machines, discovering the master instances by their tags")
+		instances, err := c.discoverMasterInstances(kclient)
+		if err != nil {
+			return nil, err
+		}
+		for _, instance := range instances {
+			zoneSet[aws.StringValue(instance.Placement.AvailabilityZone)] = true
+		}
+	}
+	zones := make([]string, 0, len(zoneSet))
+	for zone := range zoneSet {
+		zones = append(zones, zone)
+	}
+	sort.Strings(zones)
+	return zones, nil
+}
+
+// getClusterVPC returns the ID of the VPC the master instances are in. It's
+// that of the instance of the first master Machine, or, if the Machine API
+// has none or its instance is gone, of the master instances found by their
+// tags
+func (c *Client) getClusterVPC(kclient client.Client) (string, error) {
+	instanceID, err := getMasterMachineInstanceID(kclient)
+	if err == nil {
+		// Get VPC the instance is in
+		describeInstanceOutput, describeErr := c.ec2Client.DescribeInstances(
+			&ec2.DescribeInstancesInput{
+				InstanceIds: []*string{aws.String(instanceID)},
+			},
+		)
+		if aerr, ok := describeErr.(awserr.Error); ok && aerr.Code() == "InvalidInstanceID.NotFound" {
+			describeInstanceOutput, describeErr = &ec2.DescribeInstancesOutput{}, nil
+		}
+		if describeErr != nil {
+			return "", describeErr
+		}
+		if len(describeInstanceOutput.Reservations) > 0 && len(describeInstanceOutput.Reservations[0].Instances) > 0 {
+			// Extract the VPC ID from the subnet metadata
+			return aws.StringValue(describeInstanceOutput.Reservations[0].Instances[0].VpcId), nil
+		}
+		err = fmt.Errorf("the instance %s of the master Machine doesn't exist", instanceID)
+	}
+	log.Info("Couldn't find a master instance through the Machine API, discovering them by their tags", "reason", err.Error())
+	instances, err := c.discoverMasterInstances(kclient)
+	if err != nil {
+		return "", err
+	}
+	return aws.StringValue(instances[0].VpcId), nil
+}
+
+// getMasterMachineInstanceID returns the instance ID of the first master
+// Machine
+func getMasterMachineInstanceID(kclient client.Client) (string, error) {
+	machineList, err := baseutils.GetMasterMachines(kclient)
+	if err != nil {
+		return "", err
+	}
+	if len(machineList.Items) == 0 {
+		return "", goError.New("No master machines found")
+	}
+
+	// Get the first master machine in the list
+	masterMachine := machineList.Items[0]
+
+	// Get the instance ID of the machine in the form of aws:///us-east-1a/i-<hash>
+	if masterMachine.Spec.ProviderID == nil {
+		return "", goError.New("Instance ID is blank")
+	}
+	split := strings.Split(*masterMachine.Spec.ProviderID, "/")
+
+	// The instance ID should be the last element of the split
+	instanceID := split[len(split)-1]
+
+	// Ensure we acutally have an instnace ID by erroring if its missing
+	if instanceID == "" {
+		return "", goError.New("Instance ID is blank")
+	}
+	return instanceID, nil
+}
+
+// discoverMasterInstances returns the running master instances of the
+// cluster, found by the tags the installer and the Machine API give them
+// rather than through the Machine objects: kubernetes.io/cluster/<infra
+// name> owned, and a Name of <infra name>-master-<index>
+func (c *Client) discoverMasterInstances(kclient client.Client) ([]*ec2.Instance, error) {
+	infraName, err := baseutils.GetClusterName(kclient)
+	if err != nil {
+		return nil, err
+	}
+	instances := []*ec2.Instance{}
+	var token *string
+	for {
+		output, err := c.ec2Client.DescribeInstances(&ec2.DescribeInstancesInput{
+			Filters: []*ec2.Filter{
+				{
+					Name:   aws.String("tag:kubernetes.io/cluster/" + infraName),
+					Values: aws.StringSlice([]string{"owned"}),
+				},
+				{
+					Name:   aws.String("tag:Name"),
+					Values: aws.StringSlice([]string{infraName + "-master-*"}),
+				},
+				{
+					Name:   aws.String("instance-state-name"),
+					Values: aws.StringSlice([]string{ec2.InstanceStateNamePending, ec2.InstanceStateNameRunning}),
+				},
+			},
+			NextToken: token,
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, reservation := range output.Reservations {
+			instances = append(instances, reservation.Instances...)
+		}
+		token = output.NextToken
+		if token == nil {
+			break
+		}
+	}
+	if len(instances) == 0 {
+		return nil, fmt.Errorf("no running instance is tagged kubernetes.io/cluster/%s=owned and named %s-master-*", infraName, infraName)
+	}
+	return instances, nil
+}
+
+func (c *Client) getAllSubnetsInVPC(vpcID string) ([]*ec2.Subnet, error) {
+
+	var subnetIDs []*ec2.Subnet
+	var token *string
+
+	for {
+		describeSubnetOutput, err := c.ec2Client.DescribeSubnets(
+			&ec2.DescribeSubnetsInput{
+				Filters: []*ec2.Filter{
+					{
+						Name:   aws.String("vpc-id"),
+						Values: []*string{aws.String(vpcID)},
+					},
+				},
+				NextToken: token,
+			})
+		if err != nil {
+			log.Error(err, "Error while describing subnets")
+			return nil, err
+		}
+		subnetIDs = append(subnetIDs, describeSubnetOutput.Subnets...)
+
+		token = describeSubnetOutput.NextToken
+		if token == nil {
+			break
+		}
+	}
+
+	return subnetIDs, nil
+}
+
+func (c *Client) getAllRouteTablesInVPC(vpcID string) ([]*ec2.RouteTable, error) {
+
+	var routeTables []*ec2.RouteTable
+	var token *string
+
+	for {
+		describeRouteTablesOutput, err := c.ec2Client.DescribeRouteTables(&ec2.DescribeRouteTablesInput{Filters: []*ec2.Filter{{Name: aws.String("vpc-id"), Values: []*string{aws.String(vpcID)}}}, NextToken: token})
+		if err != nil {
+			log.Error(err, "Error while describing route tables")
+			return nil, err
+		}
+		routeTables = append(routeTables, describeRouteTablesOutput.RouteTables...)
+
+		token = describeRouteTablesOutput.NextToken
+		if token == nil {
+			break
+		}
+	}
+
+	return routeTables, nil
+}
+
+func isSubnetPublic(rt []*ec2.RouteTable, subnetID string) (bool, error) {
+	var subnetTable *ec2.RouteTable
+	for _, table := range rt {
+		for _, assoc := range table.Associations {
+			if aws.StringValue(assoc.SubnetId) == subnetID {
+				subnetTable = table
+				break
+			}
+		}
+	}
+
+	if subnetTable == nil {
+		// If there is no explicit association, the subnet will be implicitly
+		// associated with the VPC's main routing table.
+		for _, table := range rt {
+			for _, assoc := range table.Associations {
+				if aws.BoolValue(assoc.Main) {
+					log.Info(fmt.Sprintf(
+						"Assuming implicit use of main routing table %s for %s",
+						aws.StringValue(table.RouteTableId), subnetID))
+					subnetTable = table
+					break
+				}
+			}
+		}
+	}
+
+	if subnetTable == nil {
+		return false, fmt.Errorf("could not locate routing table for %s", subnetID)
+	}
+
+	for _, route := range subnetTable.Routes {
+		// There is no direct way in the AWS API to determine if a subnet is public or private.
+		// A public subnet is one which has an internet gateway route
+		// we look for the gatewayId and make sure it has the prefix of igw to differentiate
+		// from the default in-subnet route which is called "local"
+		// or other virtual gateway (starting with vgv)
+		// or vpc peering connections (starting with pcx).
+		if strings.HasPrefix(aws.StringValue(route.GatewayId), "igw") {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+//getClusterRegion returns the installed cluster's AWS region
+func getClusterRegion(kclient client.Client) (string, error) {
+	infra, err := baseutils.GetInfrastructureObject(kclient)
+	if err != nil {
+		return "", err
+	} else if infra.Status.PlatformStatus == nil {
+		// Try the deprecated configmap. See https://bugzilla.redhat.com/show_bug.cgi?id=1814332
+		return readClusterRegionFromConfigMap(kclient)
+	}
+	return infra.Status.PlatformStatus.AWS.Region, nil
+}
+
+func readClusterRegionFromConfigMap(kclient client.Client) (string, error) {
+	cm, err := getClusterConfigMap(kclient)
+	if err != nil {
+		return "", err
+	}
+	return parseClusterRegionFromConfigMap(cm)
+}
+
+func getClusterConfigMap(kclient client.Client) (*corev1.ConfigMap, error) {
+	cm := &corev1.ConfigMap{}
+	ns := types.NamespacedName{
+		Namespace: "kube-system",
+		Name:      "cluster-config-v1",
+	}
+	err := kclient.Get(context.TODO(), ns, cm)
+	return cm, err
+}
+
+func parseClusterRegionFromConfigMap(cm *corev1.ConfigMap) (string, error) {
+	if cm == nil || cm.Data == nil {
+		return "", fmt.Errorf("unexpected nil configmap or nil configmap Data")
+	}
+	data, ok := cm.Data["install-config"]
+	if !ok {
+		return "", fmt.Errorf("Missing install-config in configmap")
+	}
+	var ic installConfig
+	if err := yaml.Unmarshal([]byte(data), &ic); err != nil {
+		return "", fmt.Errorf("Invalid install-config: %v\njson:%s", err, data)
+	}
+	return ic.Platform.AWS.Region, nil
+}
+
+/* Helper functions below, sorted by AWS API type */
+
+// EC2
+
+// ensureElasticIPs makes sure there are at least count Elastic IPs tagged with
+// name and owned by the cluster, allocating any that are missing. It returns
+// the allocation IDs of exactly count addresses. DescribeAddresses lists them
+// in no particular order, so those attached already come first, then by
+// allocation ID, and the same ones are picked on every call. Any past count
+// are logged, a shrunk load balancer may still be letting go of them
+func (c *Client) ensureElasticIPs(clusterName, name string, count int) ([]string, error) {
+	output, err := c.ec2Client.DescribeAddresses(&ec2.DescribeAddressesInput{
+		Filters: []*ec2.Filter{
+			{
+				Name:   aws.String("tag:Name"),
+				Values: []*string{aws.String(name)},
+			},
+			{
+				Name:   aws.String("tag:kubernetes.io/cluster/" + clusterName),
+				Values: []*string{aws.String("owned")},
+			},
+		},
+	})
+	if err != nil {
+		return []string{}, err
+	}
+	addresses := output.Addresses
+	sort.SliceStable(addresses, func(i, j int) bool {
+		iAttached, jAttached := addresses[i].AssociationId != nil, addresses[j].AssociationId != nil
+		if iAttached != jAttached {
+			return iAttached
+		}
+		return aws.StringValue(addresses[i].AllocationId) < aws.StringValue(addresses[j].AllocationId)
+	})
+	allocationIDs := make([]string, 0, count)
+	for _, address := range addresses {
+		allocationIDs = append(allocationIDs, aws.StringValue(address.AllocationId))
+	}
+	if len(allocationIDs) > count {
+		log.Info("More Elastic IPs are tagged for the admin API than it needs, the surplus isn't used", "name", name, "surplus", allocationIDs[count:])
+	}
+	for len(allocationIDs) < count {
+		// Tag on allocation, so an Elastic IP can't be left untagged (and
+		// allocated again) if the operator stops in between
+		allocation, err := c.ec2Client.AllocateAddress(&ec2.AllocateAddressInput{
+			Domain: aws.String(ec2.DomainTypeVpc),
+			TagSpecifications: []*ec2.TagSpecification{
+				{
+					ResourceType: aws.String(ec2.ResourceTypeElasticIp),
+					Tags:         c.ownedTags(clusterName, name),
+				},
+			},
+		})
+		if err != nil {
+			return []string{}, err
+		}
+		allocationID := aws.StringValue(allocation.AllocationId)
+		log.Info("Allocated Elastic IP", "allocationID", allocationID, "publicIP", aws.StringValue(allocation.PublicIp))
+		allocationIDs = append(allocationIDs, allocationID)
+	}
+	return allocationIDs[:count], nil
+}
+
+// getElasticIPAddresses returns the public IP addresses for the given Elastic
+// IP allocation IDs, in the same order
+func (c *Client) getElasticIPAddresses(allocationIDs []string) ([]string, error) {
+	output, err := c.ec2Client.DescribeAddresses(&ec2.DescribeAddressesInput{
+		AllocationIds: aws.StringSlice(allocationIDs),
+	})
+	if err != nil {
+		return []string{}, err
+	}
+	ipsByAllocation := make(map[string]string)
+	for _, address := range output.Addresses {
+		ipsByAllocation[aws.StringValue(address.AllocationId)] = aws.StringValue(address.PublicIp)
+	}
+	ips := make([]string, 0, len(allocationIDs))
+	for _, allocationID := range allocationIDs {
+		ip, ok := ipsByAllocation[allocationID]
+		if !ok {
+			return []string{}, fmt.Errorf("Elastic IP allocation %s not found", allocationID)
+		}
+		ips = append(ips, ip)
+	}
+	return ips, nil
+}
+
+// ownedTags returns the tags for an EC2 resource the operator creates: owned
+// by the cluster and named name, plus the configured tags
+func (c *Client) ownedTags(clusterName, name string) []*ec2.Tag {
+	tags := []*ec2.Tag{
+		{
+			Key:   aws.String("kubernetes.io/cluster/" + clusterName),
+			Value: aws.String("owned"),
+		},
+		{
+			Key:   aws.String("Name"),
+			Value: aws.String(name),
+		},
+	}
+	return append(tags, c.configuredTags()...)
+}
+
+// configuredTags returns the CloudIngressConfig tags, sorted by key
+func (c *Client) configuredTags() []*ec2.Tag {
+	keys := make([]string, 0, len(c.tags))
+	for key := range c.tags {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	tags := make([]*ec2.Tag, 0, len(keys))
+	for _, key := range keys {
+		tags = append(tags, &ec2.Tag{Key: aws.String(key), Value: aws.String(c.tags[key])})
+	}
+	return tags
+}
+
+// getOwnedSecurityGroup returns the security group named groupName and owned
+// by the cluster, or nil if there is none
+func (c *Client) getOwnedSecurityGroup(clusterName, groupName string) (*ec2.SecurityGroup, error) {
+	output, err := c.ec2Client.DescribeSecurityGroups(&ec2.DescribeSecurityGroupsInput{
+		Filters: []*ec2.Filter{
+			{
+				Name:   aws.String("group-name"),
+				Values: []*string{aws.String(groupName)},
+			},
+			{
+				Name:   aws.String("tag:kubernetes.io/cluster/" + clusterName),
+				Values: []*string{aws.String("owned")},
+			},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(output.SecurityGroups) == 0 {
+		return nil, nil
+	}
+	return output.SecurityGroups[0], nil
+}
+
+// adoptSecurityGroup returns the security group named groupName in vpcID, or
+// nil if there is none. A group left behind by an earlier installation with
+// the same infrastructure name is tagged as owned by the cluster, so it's
+// taken over rather than colliding with the one the operator would create.
+// Groups of that name in other VPCs are ignored, and one owned by another
+// cluster is an error
+func (c *Client) adoptSecurityGroup(clusterName, groupName, vpcID string) (*ec2.SecurityGroup, error) {
+	output, err := c.ec2Client.DescribeSecurityGroups(&ec2.DescribeSecurityGroupsInput{
+		Filters: []*ec2.Filter{
+			{
+				Name:   aws.String("group-name"),
+				Values: []*string{aws.String(groupName)},
+			},
+			{
+				Name:   aws.String("vpc-id"),
+				Values: []*string{aws.String(vpcID)},
+			},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(output.SecurityGroups) == 0 {
+		return nil, nil
+	}
+	sg := output.SecurityGroups[0]
+	owners := clusterOwners(sg.Tags)
+	if owners[clusterName] {
+		return sg, nil
+	}
+	if len(owners) > 0 {
+		return nil, fmt.Errorf("security group %s (%s) is owned by another cluster", groupName, aws.StringValue(sg.GroupId))
+	}
+	_, err = c.ec2Client.CreateTags(&ec2.CreateTagsInput{
+		Resources: []*string{sg.GroupId},
+		Tags:      c.ownedTags(clusterName, groupName),
+	})
+	if err != nil {
+		return nil, err
+	}
+	log.Info("Adopted security group", "groupName", groupName, "groupID", aws.StringValue(sg.GroupId))
+	return sg, nil
+}
+
+// clusterOwners returns the names of the clusters tags mark a resource as
+// owned by
+func clusterOwners(tags []*ec2.Tag) map[string]bool {
+	owners := make(map[string]bool)
+	for _, tag := range tags {
+		key := aws.StringValue(tag.Key)
+		if strings.HasPrefix(key, "kubernetes.io/cluster/") && aws.StringValue(tag.Value) == "owned" {
+			owners[strings.TrimPrefix(key, "kubernetes.io/cluster/")] = true
+		}
+	}
+	return owners
+}
+
+// createOwnedSecurityGroup creates a security group in vpcID without any
+// ingress rules, tagged as owned by the cluster
+func (c *Client) createOwnedSecurityGroup(clusterName, groupName, vpcID string) (*ec2.SecurityGroup, error) {
+	// Tag on creation, so the group is always found again by its tags, even if
+	// the operator stops right after creating it
+	output, err := c.ec2Client.CreateSecurityGroup(&ec2.CreateSecurityGroupInput{
+		GroupName:   aws.String(groupName),
+		Description: aws.String("Admin API load balancer for " + clusterName),
+		VpcId:       aws.String(vpcID),
+		TagSpecifications: []*ec2.TagSpecification{
+			{
+				ResourceType: aws.String(ec2.ResourceTypeSecurityGroup),
+				Tags:         c.ownedTags(clusterName, groupName),
+			},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	log.Info("Created security group", "groupName", groupName, "groupID", aws.StringValue(output.GroupId))
+	return &ec2.SecurityGroup{
+		GroupId:   output.GroupId,
+		GroupName: aws.String(groupName),
+		VpcId:     aws.String(vpcID),
+	}, nil
+}
+
+// setSecurityGroupIngress makes cidrs on each of the TCP ports the only
+// ingress rules of sg, revoking everything else
+func (c *Client) setSecurityGroupIngress(sg *ec2.SecurityGroup, cidrs []string, descriptions map[string]string, ports []int64) error {
+	wanted := make(map[int64]map[string]bool)
+	for _, port := range ports {
+		wanted[port] = make(map[string]bool)
+		for _, cidr := range cidrs {
+			wanted[port][cidr] = true
+		}
+	}
+	revoke := []*ec2.IpPermission{}
+	redescribe := make(map[int64][]*ec2.IpRange)
+	for _, permission := range sg.IpPermissions {
+		port := aws.Int64Value(permission.FromPort)
+		if aws.StringValue(permission.IpProtocol) != "tcp" ||
+			wanted[port] == nil || aws.Int64Value(permission.ToPort) != port ||
+			len(permission.UserIdGroupPairs) > 0 || len(permission.Ipv6Ranges) > 0 || len(permission.PrefixListIds) > 0 {
+			revoke = append(revoke, permission)
+			continue
+		}
+		unwanted := []*ec2.IpRange{}
+		for _, ipRange := range permission.IpRanges {
+			cidr := aws.StringValue(ipRange.CidrIp)
+			if wanted[port][cidr] {
+				delete(wanted[port], cidr)
+				if aws.StringValue(ipRange.Description) != descriptions[cidr] {
+					redescribe[port] = append(redescribe[port], &ec2.IpRange{CidrIp: aws.String(cidr), Description: aws.String(descriptions[cidr])})
+				}
+			} else {
+				unwanted = append(unwanted, ipRange)
+			}
+		}
+		if len(unwanted) > 0 {
+			revoke = append(revoke, &ec2.IpPermission{
+				IpProtocol: aws.String("tcp"),
+				FromPort:   aws.Int64(port),
+				ToPort:     aws.Int64(port),
+				IpRanges:   unwanted,
+			})
+		}
+	}
+
+	authorize := []*ec2.IpPermission{}
+	for _, port := range ports {
+		if len(wanted[port]) == 0 {
+			continue
+		}
+		permission := &ec2.IpPermission{
+			IpProtocol: aws.String("tcp"),
+			FromPort:   aws.Int64(port),
+			ToPort:     aws.Int64(port),
+		}
+		// Keep the order of cidrs
+		for _, cidr := range cidrs {
+			if wanted[port][cidr] {
+				ipRange := &ec2.IpRange{CidrIp: aws.String(cidr)}
+				if descriptions[cidr] != "" {
+					ipRange.Description = aws.String(descriptions[cidr])
+				}
+				permission.IpRanges = append(permission.IpRanges, ipRange)
+				delete(wanted[port], cidr)
+			}
+		}
+		authorize = append(authorize, permission)
+	}
+
+	err := c.applySecurityGroupIngressChanges(sg.GroupId, revoke, authorize)
+	cause := err
+	if reverted, ok := err.(*errors.SecurityGroupRulesRevertedError); ok && reverted.Reverted {
+		cause = reverted.Cause
+	}
+	if aerr, ok := cause.(awserr.Error); ok && aerr.Code() == "RulesPerSecurityGroupLimitExceeded" {
+		return errors.NewActionRequiredError(cloudingressv1alpha1.ActionRequiredSecurityGroupRuleLimit,
+			fmt.Sprintf("security group %s can't take the rules of every allowed CIDR block", aws.StringValue(sg.GroupId)),
+			"raise the rules per security group quota of the AWS account, or merge the allowed CIDR blocks")
+	}
+	if err != nil {
+		return err
+	}
+	for _, port := range ports {
+		if len(redescribe[port]) == 0 {
+			continue
+		}
+		_, err := c.ec2Client.UpdateSecurityGroupRuleDescriptionsIngress(&ec2.UpdateSecurityGroupRuleDescriptionsIngressInput{
+			GroupId: sg.GroupId,
+			IpPermissions: []*ec2.IpPermission{
+				{
+					IpProtocol: aws.String("tcp"),
+					FromPort:   aws.Int64(port),
+					ToPort:     aws.Int64(port),
+					IpRanges:   redescribe[port],
+				},
+			},
+		})
+		if err != nil {
+			return err
+		}
+		log.Info("Updated security group ingress rule descriptions", "groupID", aws.StringValue(sg.GroupId), "port", port, "CIDRs", redescribe[port])
+	}
+	return nil
+}
+
+// securityGroupRuleBatchSize is the most security group ingress rules
+// authorized or revoked in one call
+var securityGroupRuleBatchSize = 50
+
+// applySecurityGroupIngressChanges revokes the revoke rules of the security
+// group groupID, then authorizes the authorize rules, in calls of at most
+// securityGroupRuleBatchSize rules each. Once a call fails, the calls made
+// before it are undone, so the group keeps the rules it had rather than part
+// of the change, and a SecurityGroupRulesRevertedError is returned
+func (c *Client) applySecurityGroupIngressChanges(groupID *string, revoke, authorize []*ec2.IpPermission) error {
+	revoked := []*ec2.IpPermission{}
+	authorized := []*ec2.IpPermission{}
+	var err error
+	for _, batch := range batchIPPermissions(revoke, securityGroupRuleBatchSize) {
+		_, err = c.ec2Client.RevokeSecurityGroupIngress(&ec2.RevokeSecurityGroupIngressInput{
+			GroupId:       groupID,
+			IpPermissions: batch,
+		})
+		if err != nil {
+			break
+		}
+		log.Info("Revoked security group ingress rules", "groupID", aws.StringValue(groupID), "rules", batch)
+		revoked = append(revoked, batch...)
+	}
+	if err == nil {
+		for _, batch := range batchIPPermissions(authorize, securityGroupRuleBatchSize) {
+			_, err = c.ec2Client.AuthorizeSecurityGroupIngress(&ec2.AuthorizeSecurityGroupIngressInput{
+				GroupId:       groupID,
+				IpPermissions: batch,
+			})
+			if err != nil {
+				break
+			}
+			log.Info("Authorized security group ingress rules", "groupID", aws.StringValue(groupID), "rules", batch)
+			authorized = append(authorized, batch...)
+		}
+	}
+	if err == nil || len(revoked)+len(authorized) == 0 {
+		return err
+	}
+
+	log.Info("Reverting the security group ingress rule changes", "groupID", aws.StringValue(groupID), "reason", err.Error())
+	var revertErr error
+	for _, batch := range batchIPPermissions(authorized, securityGroupRuleBatchSize) {
+		_, revertErr = c.ec2Client.RevokeSecurityGroupIngress(&ec2.RevokeSecurityGroupIngressInput{
+			GroupId:       groupID,
+			IpPermissions: batch,
+		})
+		if revertErr != nil {
+			break
+		}
+	}
+	if revertErr == nil {
+		for _, batch := range batchIPPermissions(revoked, securityGroupRuleBatchSize) {
+			_, revertErr = c.ec2Client.AuthorizeSecurityGroupIngress(&ec2.AuthorizeSecurityGroupIngressInput{
+				GroupId:       groupID,
+				IpPermissions: batch,
+			})
+			if revertErr != nil {
+				break
+			}
+		}
+	}
+	return errors.NewSecurityGroupRulesRevertedError(aws.StringValue(groupID), err, revertErr)
+}
+
+// batchIPPermissions splits permissions into batches of at most size rules,
+// one per CIDR block, security group or prefix list. The CIDR blocks of a
+// permission with nothing else are split between batches when they don't fit
+// in one
+func batchIPPermissions(permissions []*ec2.IpPermission, size int) [][]*ec2.IpPermission {
+	batches := [][]*ec2.IpPermission{}
+	batch := []*ec2.IpPermission{}
+	rules := 0
+	add := func(permission *ec2.IpPermission, count int) {
+		if rules > 0 && rules+count > size {
+			batches = append(batches, batch)
+			batch = []*ec2.IpPermission{}
+			rules = 0
+		}
+		batch = append(batch, permission)
+		rules += count
+	}
+	for _, permission := range permissions {
+		others := len(permission.Ipv6Ranges) + len(permission.UserIdGroupPairs) + len(permission.PrefixListIds)
+		if others > 0 || len(permission.IpRanges) <= size {
+			count := len(permission.IpRanges) + others
+			if count == 0 {
+				count = 1
+			}
+			add(permission, count)
+			continue
+		}
+		for start := 0; start < len(permission.IpRanges); start += size {
+			end := start + size
+			if end > len(permission.IpRanges) {
+				end = len(permission.IpRanges)
+			}
+			add(&ec2.IpPermission{
+				IpProtocol: permission.IpProtocol,
+				FromPort:   permission.FromPort,
+				ToPort:     permission.ToPort,
+				IpRanges:   permission.IpRanges[start:end],
+			}, end-start)
+		}
+	}
+	if len(batch) > 0 {
+		batches = append(batches, batch)
+	}
+	return batches
+}
+
+// ELB (v1)
+func (c *Client) doesELBExist(elbName string) (*awsLoadBalancer, error) {
+	input := &elb.DescribeLoadBalancersInput{
+		LoadBalancerNames: []*string{aws.String(elbName)},
+	}
+	output, err := c.elbClient.DescribeLoadBalancers(input)
+	if err != nil {
+		if aerr, ok := err.(awserr.Error); ok {
+			switch aerr.Code() {
+			case elb.ErrCodeAccessPointNotFoundException:
+				return &awsLoadBalancer{}, errors.NewLoadBalancerNotReadyError()
+			default:
+				return &awsLoadBalancer{}, err
+			}
+		}
+		return &awsLoadBalancer{}, err
+	}
+	if len(output.LoadBalancerDescriptions) == 0 {
+		return &awsLoadBalancer{}, errors.NewLoadBalancerNotReadyError()
+	}
+	return &awsLoadBalancer{
+			elbName:   elbName,
+			dnsName:   *output.LoadBalancerDescriptions[0].DNSName,
+			dnsZoneID: *output.LoadBalancerDescriptions[0].CanonicalHostedZoneNameID,
+			scheme:    aws.StringValue(output.LoadBalancerDescriptions[0].Scheme)},
+		nil
+}
+
+// getLoadBalancerForService returns the AWS load balancer the cloud provider
+// created for svc, which is a network load balancer if svc asks for one and a
+// classic ELB otherwise. It only reads, so it's safe in plans, verify and
+// drift checks
+func (c *Client) getLoadBalancerForService(svc *corev1.Service) (*awsLoadBalancer, error) {
+	elbName := loadBalancerNameForService(svc)
+	if svc.Annotations[nlbTypeAnnotationKey] != "nlb" {
+		return c.doesELBExist(elbName)
+	}
+	return c.doesNLBExist(elbName)
+}
+
+// setClassicELBAccessLogs makes the classic ELB write access logs as
+// accessLogs says, or not at all if it's nil
+func (c *Client) setClassicELBAccessLogs(elbName string, accessLogs *cloudingressv1alpha1.AccessLogs) error {
+	desired := &elb.AccessLog{Enabled: aws.Bool(false)}
+	if accessLogs != nil {
+		desired = &elb.AccessLog{
+			Enabled:        aws.Bool(true),
+			EmitInterval:   aws.Int64(accessLogEmitInterval(accessLogs)),
+			S3BucketName:   aws.String(accessLogs.S3BucketName),
+			S3BucketPrefix: aws.String(accessLogs.S3BucketPrefix),
+		}
+	}
+	output, err := c.elbClient.DescribeLoadBalancerAttributes(&elb.DescribeLoadBalancerAttributesInput{
+		LoadBalancerName: aws.String(elbName),
+	})
+	if err != nil {
+		return err
+	}
+	current := output.LoadBalancerAttributes.AccessLog
+	if current != nil && aws.BoolValue(current.Enabled) == aws.BoolValue(desired.Enabled) {
+		if !aws.BoolValue(desired.Enabled) ||
+			(aws.Int64Value(current.EmitInterval) == aws.Int64Value(desired.EmitInterval) &&
+				aws.StringValue(current.S3BucketName) == aws.StringValue(desired.S3BucketName) &&
+				aws.StringValue(current.S3BucketPrefix) == aws.StringValue(desired.S3BucketPrefix)) {
+			return nil
+		}
+	}
+	if accessLogs != nil {
+		err = c.checkAccessLogBucket(accessLogs, false)
+		if err != nil {
+			return err
+		}
+	}
+	_, err = c.elbClient.ModifyLoadBalancerAttributes(&elb.ModifyLoadBalancerAttributesInput{
+		LoadBalancerName: aws.String(elbName),
+		LoadBalancerAttributes: &elb.LoadBalancerAttributes{
+			AccessLog: desired,
+		},
+	})
+	if err != nil {
+		return err
+	}
+	log.Info("Updated the access logs of the load balancer", "elbName", elbName, "enabled", aws.BoolValue(desired.Enabled))
+	return nil
+}
+
+// setNLBAccessLogs makes the network load balancer write access logs as
+// accessLogs says, or not at all if it's nil. Network load balancers always
+// write them every 5 minutes
+func (c *Client) setNLBAccessLogs(loadBalancerArn string, accessLogs *cloudingressv1alpha1.AccessLogs) error {
+	desired := map[string]string{"access_logs.s3.enabled": "false"}
+	if accessLogs != nil {
+		desired = map[string]string{
+			"access_logs.s3.enabled": "true",
+			"access_logs.s3.bucket":  accessLogs.S3BucketName,
+			"access_logs.s3.prefix":  accessLogs.S3BucketPrefix,
+		}
+	}
+	output, err := c.elbv2Client.DescribeLoadBalancerAttributes(&elbv2.DescribeLoadBalancerAttributesInput{
+		LoadBalancerArn: aws.String(loadBalancerArn),
+	})
+	if err != nil {
+		return err
+	}
+	current := map[string]string{}
+	for _, attribute := range output.Attributes {
+		current[aws.StringValue(attribute.Key)] = aws.StringValue(attribute.Value)
+	}
+	attributes := []*elbv2.LoadBalancerAttribute{}
+	for _, key := range []string{"access_logs.s3.enabled", "access_logs.s3.bucket", "access_logs.s3.prefix"} {
+		value, ok := desired[key]
+		if ok && current[key] != value {
+			attributes = append(attributes, &elbv2.LoadBalancerAttribute{Key: aws.String(key), Value: aws.String(value)})
+		}
+	}
+	if len(attributes) == 0 {
+		return nil
+	}
+	if accessLogs != nil {
+		err = c.checkAccessLogBucket(accessLogs, true)
+		if err != nil {
+			return err
+		}
+	}
+	_, err = c.elbv2Client.ModifyLoadBalancerAttributes(&elbv2.ModifyLoadBalancerAttributesInput{
+		LoadBalancerArn: aws.String(loadBalancerArn),
+		Attributes:      attributes,
+	})
+	if err != nil {
+		return err
+	}
+	log.Info("Updated the access logs of the load balancer", "loadBalancerArn", loadBalancerArn, "enabled", desired["access_logs.s3.enabled"])
+	return nil
+}
+
+// stickinessAttributeKeys are the target group attributes setNLBStickiness
+// manages, in the order they're modified
+var stickinessAttributeKeys = []string{"stickiness.enabled", "stickiness.type", "preserve_client_ip.enabled"}
+
+// setNLBStickiness makes the target groups of the network load balancer keep
+// the connections of a client IP address on one target, or not, and preserve
+// the client IP addresses if stickiness says, modifying only the attributes
+// that differ
+func (c *Client) setNLBStickiness(loadBalancerArn string, stickiness *cloudingressv1alpha1.Stickiness) error {
+	desired := map[string]string{"stickiness.enabled": strconv.FormatBool(stickiness.SourceIP)}
+	if stickiness.SourceIP {
+		desired["stickiness.type"] = "source_ip"
+	}
+	if stickiness.PreserveClientIP != nil {
+		desired["preserve_client_ip.enabled"] = strconv.FormatBool(*stickiness.PreserveClientIP)
+	}
+	targetGroups, err := c.elbv2Client.DescribeTargetGroups(&elbv2.DescribeTargetGroupsInput{
+		LoadBalancerArn: aws.String(loadBalancerArn),
+	})
+	if err != nil {
+		return err
+	}
+	for _, targetGroup := range targetGroups.TargetGroups {
+		output, err := c.elbv2Client.DescribeTargetGroupAttributes(&elbv2.DescribeTargetGroupAttributesInput{
+			TargetGroupArn: targetGroup.TargetGroupArn,
+		})
+		if err != nil {
+			return err
+		}
+		current := map[string]string{}
+		for _, attribute := range output.Attributes {
+			current[aws.StringValue(attribute.Key)] = aws.StringValue(attribute.Value)
+		}
+		attributes := []*elbv2.TargetGroupAttribute{}
+		for _, key := range stickinessAttributeKeys {
+			value, ok := desired[key]
+			if ok && current[key] != value {
+				attributes = append(attributes, &elbv2.TargetGroupAttribute{Key: aws.String(key), Value: aws.String(value)})
+			}
+		}
+		if len(attributes) == 0 {
+			continue
+		}
+		_, err = c.elbv2Client.ModifyTargetGroupAttributes(&elbv2.ModifyTargetGroupAttributesInput{
+			TargetGroupArn: targetGroup.TargetGroupArn,
+			Attributes:     attributes,
+		})
+		if err != nil {
+			return err
+		}
+		log.Info("Updated the stickiness of the target group", "targetGroupArn", aws.StringValue(targetGroup.TargetGroupArn), "sourceIP", stickiness.SourceIP, "preserveClientIP", desired["preserve_client_ip.enabled"])
+	}
+	return nil
+}
+
+// logDeliveryServicePrincipals are the service principals that write the
+// access logs of each kind of load balancer, nlb or not. Classic ELBs also
+// write them as the ELB account of their region
+var logDeliveryServicePrincipals = map[bool]string{
+	false: "logdelivery.elasticloadbalancing.amazonaws.com",
+	true:  "delivery.logs.amazonaws.com",
+}
+
+// checkAccessLogBucket checks the bucket of accessLogs lets the load balancer,
+// a network load balancer if nlb, write its access logs before they're turned
+// on. AWS doesn't check KMS keys and only reports an opaque
+// InvalidConfigurationRequest for bucket policies, so a bucket it can't write
+// to is a PreconditionFailedError saying why
+func (c *Client) checkAccessLogBucket(accessLogs *cloudingressv1alpha1.AccessLogs, nlb bool) error {
+	bucket := accessLogs.S3BucketName
+	encryption, err := c.s3Client.GetBucketEncryption(&s3.GetBucketEncryptionInput{Bucket: aws.String(bucket)})
+	if err != nil {
+		// Buckets without default encryption are written as they are
+		if awsErr, ok := err.(awserr.Error); !ok || awsErr.Code() != "ServerSideEncryptionConfigurationNotFoundError" {
+			return err
+		}
+	} else if encryption.ServerSideEncryptionConfiguration != nil {
+		for _, rule := range encryption.ServerSideEncryptionConfiguration.Rules {
+			sse := rule.ApplyServerSideEncryptionByDefault
+			if sse == nil || !strings.HasPrefix(aws.StringValue(sse.SSEAlgorithm), s3.ServerSideEncryptionAwsKms) {
+				continue
+			}
+			if !nlb {
+				return errors.NewPreconditionFailedError(fmt.Sprintf("the access log bucket %s is encrypted with SSE-KMS, classic ELBs can only write to buckets encrypted with SSE-S3", bucket))
+			}
+			err = c.checkAccessLogKey(bucket, aws.StringValue(sse.KMSMasterKeyID))
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	policy, err := c.s3Client.GetBucketPolicy(&s3.GetBucketPolicyInput{Bucket: aws.String(bucket)})
+	if err != nil {
+		if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == "NoSuchBucketPolicy" {
+			return errors.NewPreconditionFailedError(fmt.Sprintf("the access log bucket %s has no bucket policy, it must let the load balancer write to it", bucket))
+		}
+		return err
+	}
+	partition := c.partition
+	if partition == "" {
+		partition = endpoints.AwsPartitionID
+	}
+	resource := fmt.Sprintf("arn:%s:s3:::%s/", partition, bucket)
+	if accessLogs.S3BucketPrefix != "" {
+		resource += strings.Trim(accessLogs.S3BucketPrefix, "/") + "/"
+	}
+	resource += "AWSLogs/"
+	allowed, err := policyAllows(aws.StringValue(policy.Policy), "s3:PutObject", resource, func(principal policyPrincipal) bool {
+		return principal.Service.contains(logDeliveryServicePrincipals[nlb]) || principal.AWS.contains("*") || (!nlb && len(principal.AWS) > 0)
+	})
+	if err != nil {
+		return errors.NewPreconditionFailedError(fmt.Sprintf("the policy of the access log bucket %s can't be read: %v", bucket, err))
+	}
+	if !allowed {
+		return errors.NewPreconditionFailedError(fmt.Sprintf("the policy of the access log bucket %s doesn't let %s put objects under %s*", bucket, logDeliveryServicePrincipals[nlb], resource))
+	}
+	return nil
+}
+
+// checkAccessLogKey checks the KMS key keyID, which encrypts the access log
+// bucket, is a customer managed key that its key policy or a grant lets the
+// log delivery service generate data keys with. Log delivery can't use the AWS
+// managed aws/s3 key, which buckets encrypted without a keyID use
+func (c *Client) checkAccessLogKey(bucket, keyID string) error {
+	if keyID == "" {
+		return errors.NewPreconditionFailedError(fmt.Sprintf("the access log bucket %s is encrypted with the AWS managed KMS key aws/s3, load balancers can only write with a customer managed key", bucket))
+	}
+	described, err := c.kmsClient.DescribeKey(&kms.DescribeKeyInput{KeyId: aws.String(keyID)})
+	if err != nil {
+		return err
+	}
+	metadata := described.KeyMetadata
+	if aws.StringValue(metadata.KeyManager) != kms.KeyManagerTypeCustomer {
+		return errors.NewPreconditionFailedError(fmt.Sprintf("the access log bucket %s is encrypted with the AWS managed KMS key %s, load balancers can only write with a customer managed key", bucket, keyID))
+	}
+	if aws.StringValue(metadata.KeyState) != kms.KeyStateEnabled {
+		return errors.NewPreconditionFailedError(fmt.Sprintf("the KMS key %s of the access log bucket %s is %s", aws.StringValue(metadata.Arn), bucket, aws.StringValue(metadata.KeyState)))
+	}
+
+	principal := logDeliveryServicePrincipals[true]
+	keyPolicy, err := c.kmsClient.GetKeyPolicy(&kms.GetKeyPolicyInput{
+		KeyId:      metadata.KeyId,
+		PolicyName: aws.String("default"),
+	})
+	if err != nil {
+		return err
+	}
+	allowed, err := policyAllows(aws.StringValue(keyPolicy.Policy), "kms:GenerateDataKey", "*", func(p policyPrincipal) bool {
+		return p.Service.contains(principal) || p.AWS.contains("*")
+	})
+	if err == nil && allowed {
+		return nil
+	}
+	granted := false
+	err = c.kmsClient.ListGrantsPages(&kms.ListGrantsInput{KeyId: metadata.KeyId}, func(page *kms.ListGrantsResponse, lastPage bool) bool {
+		for _, grant := range page.Grants {
+			if aws.StringValue(grant.GranteePrincipal) == principal && stringSlice(aws.StringValueSlice(grant.Operations)).contains(kms.GrantOperationGenerateDataKey) {
+				granted = true
+				return false
+			}
+		}
+		return true
+	})
+	if err != nil {
+		return err
+	}
+	if !granted {
+		return errors.NewPreconditionFailedError(fmt.Sprintf("neither the key policy nor a grant of the KMS key %s of the access log bucket %s lets %s generate data keys", aws.StringValue(metadata.Arn), bucket, principal))
+	}
+	return nil
+}
+
+// stringSlice is a JSON policy element that's either a string or a list of
+// strings
+type stringSlice []string
+
+func (s *stringSlice) UnmarshalJSON(data []byte) error {
+	var single string
+	if json.Unmarshal(data, &single) == nil {
+		*s = []string{single}
+		return nil
+	}
+	var list []string
+	err := json.Unmarshal(data, &list)
+	*s = list
+	return err
+}
+
+// contains checks if s has value
+func (s stringSlice) contains(value string) bool {
+	for _, v := range s {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// policyPrincipal is the Principal of a policy statement. "*" is every
+// principal, which is an AWS principal
+type policyPrincipal struct {
+	AWS     stringSlice `json:"AWS,omitempty"`
+	Service stringSlice `json:"Service,omitempty"`
+}
+
+func (p *policyPrincipal) UnmarshalJSON(data []byte) error {
+	var everyone string
+	if json.Unmarshal(data, &everyone) == nil {
+		p.AWS = []string{everyone}
+		return nil
+	}
+	type plain policyPrincipal
+	return json.Unmarshal(data, (*plain)(p))
+}
+
+// policyStatement is a statement of an IAM policy document, as far as
+// policyAllows needs it
+type policyStatement struct {
+	Effect    string          `json:"Effect"`
+	Principal policyPrincipal `json:"Principal"`
+	Action    stringSlice     `json:"Action"`
+	Resource  stringSlice     `json:"Resource"`
+	Condition json.RawMessage `json:"Condition,omitempty"`
+}
+
+// policyStatements are the Statement of a policy document, a statement or a
+// list of them
+type policyStatements []policyStatement
+
+func (s *policyStatements) UnmarshalJSON(data []byte) error {
+	var single policyStatement
+	if err := json.Unmarshal(data, &single); err == nil {
+		*s = []policyStatement{single}
+		return nil
+	}
+	var list []policyStatement
+	err := json.Unmarshal(data, &list)
+	*s = list
+	return err
+}
+
+// policyAllows checks if the JSON policy document lets a principal principals
+// accepts take action on resource, or on what's under it if it ends with a
+// "/". Conditions aren't evaluated: Allow statements are assumed to apply,
+// and Deny statements not to, as they're usually there to refuse insecure
+// transport or other accounts
+func policyAllows(document, action, resource string, principals func(policyPrincipal) bool) (bool, error) {
+	policy := struct {
+		Statement policyStatements `json:"Statement"`
+	}{}
+	err := json.Unmarshal([]byte(document), &policy)
+	if err != nil {
+		return false, err
+	}
+	allowed := false
+	for _, statement := range policy.Statement {
+		if !principals(statement.Principal) || !policyElementMatches(statement.Action, action) || !policyResourceCovers(statement.Resource, resource) {
+			continue
+		}
+		if statement.Effect == "Deny" && len(statement.Condition) == 0 {
+			return false, nil
+		}
+		if statement.Effect == "Allow" {
+			allowed = true
+		}
+	}
+	return allowed, nil
+}
+
+// policyElementMatches checks if one of the patterns, with "*" wildcards and
+// case-insensitive like actions, matches value
+func policyElementMatches(patterns []string, value string) bool {
+	for _, pattern := range patterns {
+		if wildcardMatch(strings.ToLower(pattern), strings.ToLower(value)) {
+			return true
+		}
+	}
+	return false
+}
+
+// policyResourceCovers checks if one of the resource patterns matches
+// resource, or some of what's under it if it ends with a "/"
+func policyResourceCovers(patterns []string, resource string) bool {
+	for _, pattern := range patterns {
+		if wildcardMatch(pattern, resource) {
+			return true
+		}
+		if strings.HasSuffix(resource, "/") && strings.Contains(pattern, "*") {
+			// The pattern only has to reach under resource, eg
+			// arn:aws:s3:::bucket/prefix/AWSLogs/123456789012/*
+			literal := strings.SplitN(pattern, "*", 2)[0]
+			if strings.HasPrefix(literal, resource) || strings.HasPrefix(resource, literal) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// wildcardMatch checks if pattern, where "*" matches any characters and "?"
+// any one, matches value
+func wildcardMatch(pattern, value string) bool {
+	if pattern == "" {
+		return value == ""
+	}
+	switch pattern[0] {
+	case '*':
+		for i := 0; i <= len(value); i++ {
+			if wildcardMatch(pattern[1:], value[i:]) {
+				return true
+			}
+		}
+		return false
+	case '?':
+		return value != "" && wildcardMatch(pattern[1:], value[1:])
+	}
+	return value != "" && pattern[0] == value[0] && wildcardMatch(pattern[1:], value[1:])
+}
+
+// setClassicELBTLSPolicy makes the SSL and HTTPS listeners of the classic ELB
+// negotiate with a policy referencing the predefined referencePolicy, creating
+// it if needed. The other policies of the listeners are kept
+func (c *Client) setClassicELBTLSPolicy(elbName, referencePolicy string) error {
+	output, err := c.elbClient.DescribeLoadBalancers(&elb.DescribeLoadBalancersInput{
+		LoadBalancerNames: []*string{aws.String(elbName)},
+	})
+	if err != nil {
+		return err
+	}
+	if len(output.LoadBalancerDescriptions) == 0 {
+		return errors.NewLoadBalancerNotReadyError()
+	}
+	listeners := []*elb.ListenerDescription{}
+	for _, listener := range output.LoadBalancerDescriptions[0].ListenerDescriptions {
+		switch strings.ToUpper(aws.StringValue(listener.Listener.Protocol)) {
+		case "SSL", "HTTPS":
+			listeners = append(listeners, listener)
+		}
+	}
+	if len(listeners) == 0 {
+		return nil
+	}
+
+	policies, err := c.elbClient.DescribeLoadBalancerPolicies(&elb.DescribeLoadBalancerPoliciesInput{
+		LoadBalancerName: aws.String(elbName),
+	})
+	if err != nil {
+		return err
+	}
+	policyName := tlsPolicyNamePrefix + referencePolicy
+	negotiationPolicies := map[string]bool{}
+	for _, policy := range policies.PolicyDescriptions {
+		if aws.StringValue(policy.PolicyTypeName) == sslNegotiationPolicyType {
+			negotiationPolicies[aws.StringValue(policy.PolicyName)] = true
+		}
+	}
+	if !negotiationPolicies[policyName] {
+		_, err = c.elbClient.CreateLoadBalancerPolicy(&elb.CreateLoadBalancerPolicyInput{
+			LoadBalancerName: aws.String(elbName),
+			PolicyName:       aws.String(policyName),
+			PolicyTypeName:   aws.String(sslNegotiationPolicyType),
+			PolicyAttributes: []*elb.PolicyAttribute{
+				{
+					AttributeName:  aws.String("Reference-Security-Policy"),
+					AttributeValue: aws.String(referencePolicy),
+				},
+			},
+		})
+		if err != nil {
+			return err
+		}
+		log.Info("Created the TLS negotiation policy of the load balancer", "elbName", elbName, "policyName", policyName)
+	}
+
+	for _, listener := range listeners {
+		policyNames := []*string{aws.String(policyName)}
+		current := false
+		for _, name := range listener.PolicyNames {
+			switch {
+			case aws.StringValue(name) == policyName:
+				current = true
+			case !negotiationPolicies[aws.StringValue(name)]:
+				policyNames = append(policyNames, name)
+			}
+		}
+		if current && len(policyNames) == len(listener.PolicyNames) {
+			continue
+		}
+		_, err = c.elbClient.SetLoadBalancerPoliciesOfListener(&elb.SetLoadBalancerPoliciesOfListenerInput{
+			LoadBalancerName: aws.String(elbName),
+			LoadBalancerPort: listener.Listener.LoadBalancerPort,
+			PolicyNames:      policyNames,
+		})
+		if err != nil {
+			return err
+		}
+		log.Info("Set the TLS negotiation policy of the listener", "elbName", elbName, "port", aws.Int64Value(listener.Listener.LoadBalancerPort), "policyName", policyName)
+	}
+	return nil
+}
+
+// setNLBTLSPolicy makes the TLS and HTTPS listeners of the network load
+// balancer use the predefined security policy
+func (c *Client) setNLBTLSPolicy(loadBalancerArn, policy string) error {
+	output, err := c.elbv2Client.DescribeListeners(&elbv2.DescribeListenersInput{
+		LoadBalancerArn: aws.String(loadBalancerArn),
+	})
+	if err != nil {
+		return err
+	}
+	for _, listener := range output.Listeners {
+		switch aws.StringValue(listener.Protocol) {
+		case elbv2.ProtocolEnumTls, elbv2.ProtocolEnumHttps:
+		default:
+			continue
+		}
+		if aws.StringValue(listener.SslPolicy) == policy {
+			continue
+		}
+		_, err = c.elbv2Client.ModifyListener(&elbv2.ModifyListenerInput{
+			ListenerArn: listener.ListenerArn,
+			SslPolicy:   aws.String(policy),
+		})
+		if err != nil {
+			return err
+		}
+		log.Info("Set the TLS security policy of the listener", "loadBalancerArn", loadBalancerArn, "port", aws.Int64Value(listener.Port), "policy", policy)
+	}
+	return nil
+}
+
+// setClassicELBCertificate makes the SSL and HTTPS listeners of the classic ELB
+// serve certificateARN, and returns the ports of those it was swapped on
+func (c *Client) setClassicELBCertificate(elbName, certificateARN string) ([]int64, error) {
+	output, err := c.elbClient.DescribeLoadBalancers(&elb.DescribeLoadBalancersInput{
+		LoadBalancerNames: []*string{aws.String(elbName)},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(output.LoadBalancerDescriptions) == 0 {
+		return nil, errors.NewLoadBalancerNotReadyError()
+	}
+	swapped := []int64{}
+	for _, listener := range output.LoadBalancerDescriptions[0].ListenerDescriptions {
+		switch strings.ToUpper(aws.StringValue(listener.Listener.Protocol)) {
+		case "SSL", "HTTPS":
+		default:
+			continue
+		}
+		if aws.StringValue(listener.Listener.SSLCertificateId) == certificateARN {
+			continue
+		}
+		_, err = c.elbClient.SetLoadBalancerListenerSSLCertificate(&elb.SetLoadBalancerListenerSSLCertificateInput{
+			LoadBalancerName: aws.String(elbName),
+			LoadBalancerPort: listener.Listener.LoadBalancerPort,
+			SSLCertificateId: aws.String(certificateARN),
+		})
+		if err != nil {
+			return nil, err
+		}
+		log.Info("Swapped the certificate of the listener", "elbName", elbName, "port", aws.Int64Value(listener.Listener.LoadBalancerPort), "certificateARN", certificateARN)
+		swapped = append(swapped, aws.Int64Value(listener.Listener.LoadBalancerPort))
+	}
+	return swapped, nil
+}
+
+// setNLBCertificate makes the TLS and HTTPS listeners of the network load
+// balancer serve certificateARN as their default certificate, and returns the
+// ports of those it was swapped on
+func (c *Client) setNLBCertificate(loadBalancerArn, certificateARN string) ([]int64, error) {
+	output, err := c.elbv2Client.DescribeListeners(&elbv2.DescribeListenersInput{
+		LoadBalancerArn: aws.String(loadBalancerArn),
+	})
+	if err != nil {
+		return nil, err
+	}
+	swapped := []int64{}
+	for _, listener := range output.Listeners {
+		switch aws.StringValue(listener.Protocol) {
+		case elbv2.ProtocolEnumTls, elbv2.ProtocolEnumHttps:
+		default:
+			continue
+		}
+		// Only the default certificate is described
+		if len(listener.Certificates) > 0 && aws.StringValue(listener.Certificates[0].CertificateArn) == certificateARN {
+			continue
+		}
+		_, err = c.elbv2Client.ModifyListener(&elbv2.ModifyListenerInput{
+			ListenerArn:  listener.ListenerArn,
+			Certificates: []*elbv2.Certificate{{CertificateArn: aws.String(certificateARN)}},
+		})
+		if err != nil {
+			return nil, err
+		}
+		log.Info("Swapped the certificate of the listener", "loadBalancerArn", loadBalancerArn, "port", aws.Int64Value(listener.Port), "certificateARN", certificateARN)
+		swapped = append(swapped, aws.Int64Value(listener.Port))
+	}
+	return swapped, nil
+}
+
+// certificateCheckTimeout is how long a load balancer whose certificate was
+// swapped has to start serving it, checked every certificateCheckInterval
+var (
+	certificateCheckTimeout  = 30 * time.Second
+	certificateCheckInterval = 5 * time.Second
+)
+
+// dialServedCertificate returns the certificate served on address. It isn't
+// verified: the load balancer's hostname isn't the certificate's, and only
+// which certificate it is matters
+var dialServedCertificate = func(address string) (*x509.Certificate, error) {
+	tlsConfig := &tls.Config{InsecureSkipVerify: true}
+	conn, err := tls.DialWithDialer(&net.Dialer{Timeout: certificateCheckInterval}, "tcp", address, tlsConfig)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+	served := conn.ConnectionState().PeerCertificates
+	if len(served) == 0 {
+		return nil, fmt.Errorf("%s served no certificate", address)
+	}
+	return served[0], nil
+}
 
-	return publicSubnets, nil
+// verifyServedCertificate checks that the load balancer at host serves the
+// ACM certificate certificateARN on ports, comparing serial numbers, and
+// gives it certificateCheckTimeout to do so. A load balancer the operator
+// can't reach, eg one only letting in other networks, is left unchecked
+func (c *Client) verifyServedCertificate(host string, ports []int64, certificateARN string) error {
+	output, err := c.acmClient.DescribeCertificate(&acm.DescribeCertificateInput{
+		CertificateArn: aws.String(certificateARN),
+	})
+	if err != nil {
+		return err
+	}
+	expected := normalizedSerial(aws.StringValue(output.Certificate.Serial))
+	for _, port := range ports {
+		address := net.JoinHostPort(host, strconv.FormatInt(port, 10))
+		served := ""
+		reached := false
+		for deadline := time.Now().Add(certificateCheckTimeout); ; c.wait(certificateCheckInterval) {
+			cert, err := dialServedCertificate(address)
+			if err == nil {
+				reached = true
+				served = normalizedSerial(cert.SerialNumber.Text(16))
+			}
+			if served == expected || !time.Now().Before(deadline) {
+				break
+			}
+		}
+		switch {
+		case !reached:
+			log.Info("Couldn't reach the load balancer to check the certificate it serves", "address", address)
+		case served != expected:
+			return fmt.Errorf("%s still serves the certificate with serial %s after %s, not %s", address, served, certificateCheckTimeout, certificateARN)
+		default:
+			log.Info("The load balancer serves the new certificate", "address", address, "certificateARN", certificateARN)
+		}
+	}
+	return nil
 }
 
-func (c *Client) getAllSubnetsInVPC(vpcID string) ([]*ec2.Subnet, error) {
+// normalizedSerial returns the hex serial number of a certificate without
+// separators or leading zeros, in lower case, so ACM's 0a:1b:... compares
+// equal to the serial read from the certificate
+func normalizedSerial(serial string) string {
+	serial = strings.ToLower(strings.ReplaceAll(serial, ":", ""))
+	return strings.TrimLeft(serial, "0")
+}
 
-	var subnetIDs []*ec2.Subnet
-	token := aws.String("initString")
+// adminAPIAlarmNames returns the names of the CloudWatch alarms for the rh-api
+// classic ELB of the cluster
+func adminAPIAlarmNames(clusterName string) []string {
+	prefix := clusterName + "-" + config.AdminAPIName + "-"
+	return []string{prefix + "unhealthy-hosts", prefix + "spillover", prefix + "latency"}
+}
 
-	for token != nil {
-		describeSubnetOutput, err := c.ec2Client.DescribeSubnets(
-			&ec2.DescribeSubnetsInput{
-				Filters: []*ec2.Filter{
-					{
-						Name:   aws.String("vpc-id"),
-						Values: []*string{aws.String(vpcID)},
-					},
-				},
-			})
+// adminAPIAlarms returns the CloudWatch alarms for the rh-api classic ELB:
+// any unhealthy instance, any request rejected because the surge queue is
+// full, and a sustained average latency above the threshold
+func (c *Client) adminAPIAlarms(clusterName, elbName string, alarms *cloudingressv1alpha1.Alarms) []*cloudwatch.PutMetricAlarmInput {
+	latencyThreshold := defaultLatencyAlarmThreshold
+	if alarms.LatencyThresholdMilliseconds > 0 {
+		latencyThreshold = alarms.LatencyThresholdMilliseconds
+	}
+	names := adminAPIAlarmNames(clusterName)
+	metrics := []struct {
+		name, statistic   string
+		threshold         float64
+		evaluationPeriods int64
+	}{
+		{"UnHealthyHostCount", cloudwatch.StatisticMaximum, 0, 2},
+		{"SpilloverCount", cloudwatch.StatisticSum, 0, 1},
+		{"Latency", cloudwatch.StatisticAverage, float64(latencyThreshold) / 1000, 5},
+	}
+	tags := []*cloudwatch.Tag{}
+	for _, tag := range c.ownedTags(clusterName, clusterName+"-"+config.AdminAPIName) {
+		tags = append(tags, &cloudwatch.Tag{Key: tag.Key, Value: tag.Value})
+	}
+	inputs := []*cloudwatch.PutMetricAlarmInput{}
+	for i, metric := range metrics {
+		inputs = append(inputs, &cloudwatch.PutMetricAlarmInput{
+			AlarmName:          aws.String(names[i]),
+			AlarmDescription:   aws.String(fmt.Sprintf("%s of the %s load balancer", metric.name, config.AdminAPIName)),
+			Namespace:          aws.String("AWS/ELB"),
+			MetricName:         aws.String(metric.name),
+			Dimensions:         []*cloudwatch.Dimension{{Name: aws.String("LoadBalancerName"), Value: aws.String(elbName)}},
+			Statistic:          aws.String(metric.statistic),
+			Period:             aws.Int64(60),
+			EvaluationPeriods:  aws.Int64(metric.evaluationPeriods),
+			Threshold:          aws.Float64(metric.threshold),
+			ComparisonOperator: aws.String(cloudwatch.ComparisonOperatorGreaterThanThreshold),
+			TreatMissingData:   aws.String("notBreaching"),
+			AlarmActions:       aws.StringSlice(alarms.ActionARNs),
+			OKActions:          aws.StringSlice(alarms.ActionARNs),
+			Tags:               tags,
+		})
+	}
+	return inputs
+}
+
+// getMetricAlarms returns the CloudWatch alarms among names that exist, by name
+func (c *Client) getMetricAlarms(names []string) (map[string]*cloudwatch.MetricAlarm, error) {
+	output, err := c.cloudWatchClient.DescribeAlarms(&cloudwatch.DescribeAlarmsInput{
+		AlarmNames: aws.StringSlice(names),
+	})
+	if err != nil {
+		return nil, err
+	}
+	alarms := map[string]*cloudwatch.MetricAlarm{}
+	for _, alarm := range output.MetricAlarms {
+		alarms[aws.StringValue(alarm.AlarmName)] = alarm
+	}
+	return alarms, nil
+}
+
+// alarmMatches checks if the existing CloudWatch alarm is configured as desired.
+// Tags are only set when an alarm is created, so they aren't compared
+func alarmMatches(alarm *cloudwatch.MetricAlarm, desired *cloudwatch.PutMetricAlarmInput) bool {
+	if len(alarm.Dimensions) != 1 ||
+		aws.StringValue(alarm.Dimensions[0].Value) != aws.StringValue(desired.Dimensions[0].Value) {
+		return false
+	}
+	return aws.StringValue(alarm.MetricName) == aws.StringValue(desired.MetricName) &&
+		aws.StringValue(alarm.Statistic) == aws.StringValue(desired.Statistic) &&
+		aws.Int64Value(alarm.Period) == aws.Int64Value(desired.Period) &&
+		aws.Int64Value(alarm.EvaluationPeriods) == aws.Int64Value(desired.EvaluationPeriods) &&
+		aws.Float64Value(alarm.Threshold) == aws.Float64Value(desired.Threshold) &&
+		aws.StringValue(alarm.ComparisonOperator) == aws.StringValue(desired.ComparisonOperator) &&
+		aws.StringValue(alarm.TreatMissingData) == aws.StringValue(desired.TreatMissingData) &&
+		reflect.DeepEqual(aws.StringValueSlice(alarm.AlarmActions), aws.StringValueSlice(desired.AlarmActions)) &&
+		reflect.DeepEqual(aws.StringValueSlice(alarm.OKActions), aws.StringValueSlice(desired.OKActions))
+}
+
+// accessLogAnnotations returns the Service annotations that configure the
+// access logs of a classic ELB as accessLogs says, or turn them off if it's nil
+func accessLogAnnotations(accessLogs *cloudingressv1alpha1.AccessLogs) map[string]string {
+	if accessLogs == nil {
+		return map[string]string{accessLogEnabledAnnotationKey: "false"}
+	}
+	annotations := map[string]string{
+		accessLogEnabledAnnotationKey:      "true",
+		accessLogEmitIntervalAnnotationKey: strconv.FormatInt(accessLogEmitInterval(accessLogs), 10),
+		accessLogBucketNameAnnotationKey:   accessLogs.S3BucketName,
+	}
+	if accessLogs.S3BucketPrefix != "" {
+		annotations[accessLogBucketPrefixAnnotationKey] = accessLogs.S3BucketPrefix
+	}
+	return annotations
+}
+
+// accessLogEmitInterval returns how often, in minutes, a classic ELB should
+// write its access logs
+func accessLogEmitInterval(accessLogs *cloudingressv1alpha1.AccessLogs) int64 {
+	if accessLogs.EmitInterval == 0 {
+		return defaultAccessLogEmitInterval
+	}
+	return accessLogs.EmitInterval
+}
+
+// getClassicELBSubnets returns the subnets the rh-api classic ELB elbName is
+// in, and the ones it should be in: the public subnets of the APIScheme, one
+// per availability zone. An internal ELB stays in the subnets the cloud
+// provider picked, so both are its current subnets
+func (c *Client) getClassicELBSubnets(kclient client.Client, instance *cloudingressv1alpha1.APIScheme, elbName string) ([]string, []string, error) {
+	output, err := c.elbClient.DescribeLoadBalancers(&elb.DescribeLoadBalancersInput{
+		LoadBalancerNames: []*string{aws.String(elbName)},
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(output.LoadBalancerDescriptions) == 0 {
+		return nil, nil, errors.NewLoadBalancerNotReadyError()
+	}
+	desc := output.LoadBalancerDescriptions[0]
+	current := aws.StringValueSlice(desc.Subnets)
+	if aws.StringValue(desc.Scheme) == "internal" {
+		return current, current, nil
+	}
+	desired, err := c.getPublicSubnets(kclient, instance.Spec.ManagementAPIServerIngress.SubnetIDs)
+	if err != nil {
+		return nil, nil, err
+	}
+	return current, desired, nil
+}
+
+// syncClassicELBSubnets attaches the rh-api classic ELB elbName to the
+// subnets it should be in, and detaches it from the others
+func (c *Client) syncClassicELBSubnets(kclient client.Client, instance *cloudingressv1alpha1.APIScheme, elbName string) error {
+	current, desired, err := c.getClassicELBSubnets(kclient, instance, elbName)
+	if err != nil {
+		return err
+	}
+	output, err := c.ec2Client.DescribeSubnets(&ec2.DescribeSubnetsInput{
+		SubnetIds: aws.StringSlice(append(append([]string{}, current...), desired...)),
+	})
+	if err != nil {
+		return err
+	}
+	zones := make(map[string]string, len(output.Subnets))
+	for _, subnet := range output.Subnets {
+		zones[aws.StringValue(subnet.SubnetId)] = aws.StringValue(subnet.AvailabilityZone)
+	}
+	attachFirst, detach, attachLast := subnetChanges(current, desired, zones)
+	if len(attachFirst) > 0 {
+		_, err = c.elbClient.AttachLoadBalancerToSubnets(&elb.AttachLoadBalancerToSubnetsInput{
+			LoadBalancerName: aws.String(elbName),
+			Subnets:          aws.StringSlice(attachFirst),
+		})
+		if err != nil {
+			return err
+		}
+	}
+	if len(detach) > 0 {
+		_, err = c.elbClient.DetachLoadBalancerFromSubnets(&elb.DetachLoadBalancerFromSubnetsInput{
+			LoadBalancerName: aws.String(elbName),
+			Subnets:          aws.StringSlice(detach),
+		})
+		if err != nil {
+			return err
+		}
+	}
+	if len(attachLast) > 0 {
+		_, err = c.elbClient.AttachLoadBalancerToSubnets(&elb.AttachLoadBalancerToSubnetsInput{
+			LoadBalancerName: aws.String(elbName),
+			Subnets:          aws.StringSlice(attachLast),
+		})
+		if err != nil {
+			return err
+		}
+	}
+	log.Info("Moved the admin API load balancer to the cluster's subnets", "elbName", elbName, "attached", append(attachFirst, attachLast...), "detached", detach)
+	return nil
+}
+
+// subnetChanges orders the changes moving a classic ELB from the current to
+// the desired subnets, given the availability zone of each. A classic ELB has
+// a single subnet per availability zone, so a subnet replacing another in its
+// zone can only be attached once that one is detached. Subnets in new zones
+// are attached first, so the ELB always keeps a subnet
+func subnetChanges(current, desired []string, zones map[string]string) (attachFirst, detach, attachLast []string) {
+	isCurrent := make(map[string]bool, len(current))
+	currentZones := make(map[string]bool, len(current))
+	for _, subnet := range current {
+		isCurrent[subnet] = true
+		currentZones[zones[subnet]] = true
+	}
+	isDesired := make(map[string]bool, len(desired))
+	for _, subnet := range desired {
+		isDesired[subnet] = true
+		if isCurrent[subnet] {
+			continue
+		}
+		if currentZones[zones[subnet]] {
+			attachLast = append(attachLast, subnet)
+		} else {
+			attachFirst = append(attachFirst, subnet)
+		}
+	}
+	for _, subnet := range current {
+		if !isDesired[subnet] {
+			detach = append(detach, subnet)
+		}
+	}
+	return attachFirst, detach, attachLast
+}
+
+// getClassicELBDrift returns which parts of a classic ELB no longer match
+// what the cloud provider configured for svc: listeners, health check,
+// registered instances, security groups and their rules
+func (c *Client) getClassicELBDrift(kclient client.Client, elbName string, svc *corev1.Service) ([]string, error) {
+	output, err := c.elbClient.DescribeLoadBalancers(&elb.DescribeLoadBalancersInput{
+		LoadBalancerNames: []*string{aws.String(elbName)},
+	})
+	if err != nil {
+		return []string{}, err
+	}
+	if len(output.LoadBalancerDescriptions) == 0 {
+		return []string{}, errors.NewLoadBalancerNotReadyError()
+	}
+	desc := output.LoadBalancerDescriptions[0]
+	drifted := []string{}
+
+	for _, port := range svc.Spec.Ports {
+		found := false
+		for _, listener := range desc.ListenerDescriptions {
+			if aws.Int64Value(listener.Listener.LoadBalancerPort) == int64(port.Port) &&
+				aws.Int64Value(listener.Listener.InstancePort) == int64(port.NodePort) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			drifted = append(drifted, "listeners")
+			break
+		}
+	}
+
+	if len(svc.Spec.Ports) > 0 {
+		if desc.HealthCheck == nil || aws.StringValue(desc.HealthCheck.Target) != healthCheckTarget(svc) {
+			drifted = append(drifted, "healthcheck")
+		}
+	}
+
+	desired, err := loadBalancerInstanceIDs(kclient, svc)
+	if err != nil {
+		return drifted, err
+	}
+	registered := make([]string, 0, len(desc.Instances))
+	for _, instance := range desc.Instances {
+		registered = append(registered, aws.StringValue(instance.InstanceId))
+	}
+	if !baseutils.SameMembers(registered, desired) {
+		drifted = append(drifted, "instances")
+	}
+
+	if groupID := svc.Annotations[securityGroupsAnnotationKey]; groupID != "" {
+		if !baseutils.SameMembers(aws.StringValueSlice(desc.SecurityGroups), strings.Split(groupID, ",")) {
+			drifted = append(drifted, "securitygroups")
+		}
+	}
+
+	if len(desc.SecurityGroups) > 0 && len(svc.Spec.Ports) > 0 {
+		sgOutput, err := c.ec2Client.DescribeSecurityGroups(&ec2.DescribeSecurityGroupsInput{
+			GroupIds: desc.SecurityGroups,
+		})
+		if err != nil {
+			return drifted, err
+		}
+		sourceRanges := svc.Spec.LoadBalancerSourceRanges
+		if len(sourceRanges) == 0 {
+			sourceRanges = []string{"0.0.0.0/0"}
+		}
+	sourceRanges:
+		for _, cidr := range sourceRanges {
+			for _, port := range svc.Spec.Ports {
+				if !securityGroupsAllow(sgOutput.SecurityGroups, cidr, int64(port.Port)) {
+					drifted = append(drifted, "securitygroup")
+					break sourceRanges
+				}
+			}
+		}
+	}
+	return drifted, nil
+}
+
+// loadBalancerInstanceIDs returns the IDs of the instances that should be
+// registered with the load balancer of svc. The cloud provider only registers
+// the nodes with the target labels
+func loadBalancerInstanceIDs(kclient client.Client, svc *corev1.Service) ([]string, error) {
+	nodes, err := baseutils.GetLoadBalancerNodes(kclient, baseutils.ParseNodeLabels(svc.Annotations[targetNodeLabelsAnnotationKey]))
+	if err != nil {
+		return []string{}, err
+	}
+	instanceIDs := make([]string, 0, len(nodes))
+	for _, node := range nodes {
+		// spec.providerID looks like aws:///us-east-1a/i-0123456789abcdef0
+		if node.Spec.ProviderID != "" {
+			instanceIDs = append(instanceIDs, path.Base(node.Spec.ProviderID))
+		}
+	}
+	return instanceIDs, nil
+}
+
+// getNetworkLoadBalancerDrift returns "targets" if the instances registered
+// with a target group of the network load balancer nlbArn aren't those that
+// should be behind svc. Draining instances no longer count
+func (c *Client) getNetworkLoadBalancerDrift(kclient client.Client, nlbArn string, svc *corev1.Service) ([]string, error) {
+	desired, err := loadBalancerInstanceIDs(kclient, svc)
+	if err != nil {
+		return []string{}, err
+	}
+	targetGroups, err := c.elbv2Client.DescribeTargetGroups(&elbv2.DescribeTargetGroupsInput{
+		LoadBalancerArn: aws.String(nlbArn),
+	})
+	if err != nil {
+		return []string{}, err
+	}
+	for _, group := range targetGroups.TargetGroups {
+		health, err := c.targetGroup(aws.StringValue(group.TargetGroupArn)).health()
+		if err != nil {
+			return []string{}, err
+		}
+		registered := make([]string, 0, len(health))
+		for _, target := range health {
+			if target.state != elbv2.TargetHealthStateEnumDraining {
+				registered = append(registered, target.instanceID)
+			}
+		}
+		if !baseutils.SameMembers(registered, desired) {
+			return []string{"targets"}, nil
+		}
+	}
+	return []string{}, nil
+}
+
+// syncNetworkLoadBalancerTargets registers the instances that should be behind
+// svc with every target group of the network load balancer nlbArn, and
+// deregisters the others. Only the changes are made
+func (c *Client) syncNetworkLoadBalancerTargets(kclient client.Client, nlbArn string, svc *corev1.Service) error {
+	desired, err := loadBalancerInstanceIDs(kclient, svc)
+	if err != nil {
+		return err
+	}
+	targetGroups, err := c.elbv2Client.DescribeTargetGroups(&elbv2.DescribeTargetGroupsInput{
+		LoadBalancerArn: aws.String(nlbArn),
+	})
+	if err != nil {
+		return err
+	}
+	for _, group := range targetGroups.TargetGroups {
+		if _, err = c.targetGroup(aws.StringValue(group.TargetGroupArn)).sync(desired); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// syncClassicELBInstances registers the instances that should be behind svc
+// with the classic ELB elbName, and deregisters the others. The members are
+// those DescribeInstanceHealth lists, so only the changes are made, and
+// nothing is registered or deregistered when they're the desired ones already
+func (c *Client) syncClassicELBInstances(kclient client.Client, elbName string, svc *corev1.Service) error {
+	desired, err := loadBalancerInstanceIDs(kclient, svc)
+	if err != nil {
+		return err
+	}
+	output, err := c.elbClient.DescribeInstanceHealth(&elb.DescribeInstanceHealthInput{
+		LoadBalancerName: aws.String(elbName),
+	})
+	if err != nil {
+		return err
+	}
+	registered := make([]string, 0, len(output.InstanceStates))
+	for _, state := range output.InstanceStates {
+		registered = append(registered, aws.StringValue(state.InstanceId))
+	}
+	register, deregister := baseutils.MemberChanges(registered, desired)
+	if len(register) > 0 {
+		_, err = c.elbClient.RegisterInstancesWithLoadBalancer(&elb.RegisterInstancesWithLoadBalancerInput{
+			Instances:        elbInstances(register),
+			LoadBalancerName: aws.String(elbName),
+		})
+		if err != nil {
+			return err
+		}
+	}
+	if len(deregister) > 0 {
+		_, err = c.elbClient.DeregisterInstancesFromLoadBalancer(&elb.DeregisterInstancesFromLoadBalancerInput{
+			Instances:        elbInstances(deregister),
+			LoadBalancerName: aws.String(elbName),
+		})
+		if err != nil {
+			return err
+		}
+	}
+	if len(register) > 0 || len(deregister) > 0 {
+		log.Info("Synced the instances of the load balancer", "elbName", elbName, "registered", register, "deregistered", deregister)
+	}
+	return nil
+}
+
+// elbInstances returns the classic ELB instances of instanceIDs
+func elbInstances(instanceIDs []string) []*elb.Instance {
+	instances := make([]*elb.Instance, 0, len(instanceIDs))
+	for _, instanceID := range instanceIDs {
+		instances = append(instances, &elb.Instance{InstanceId: aws.String(instanceID)})
+	}
+	return instances
+}
+
+// healthCheckTarget returns the target of the classic ELB health check the
+// cloud provider configures for svc, which has at least one port: the health
+// check node port of a Service that keeps traffic local, or else the node
+// port over the protocol of the health check annotations, eg
+// HTTPS:30123/readyz, or TCP by default
+func healthCheckTarget(svc *corev1.Service) string {
+	if svc.Spec.HealthCheckNodePort != 0 {
+		return fmt.Sprintf("HTTP:%d/healthz", svc.Spec.HealthCheckNodePort)
+	}
+	nodePort := svc.Spec.Ports[0].NodePort
+	switch protocol := strings.ToUpper(svc.Annotations[healthCheckProtocolAnnotationKey]); protocol {
+	case "HTTP", "HTTPS":
+		path := svc.Annotations[healthCheckPathAnnotationKey]
+		if path == "" {
+			path = "/"
+		}
+		return fmt.Sprintf("%s:%d%s", protocol, nodePort, path)
+	case "SSL":
+		return fmt.Sprintf("SSL:%d", nodePort)
+	}
+	return fmt.Sprintf("TCP:%d", nodePort)
+}
+
+// securityGroupsAllow checks if any of the security groups lets cidr in on
+// TCP port
+func securityGroupsAllow(securityGroups []*ec2.SecurityGroup, cidr string, port int64) bool {
+	for _, sg := range securityGroups {
+		for _, permission := range sg.IpPermissions {
+			protocol := aws.StringValue(permission.IpProtocol)
+			if protocol != "tcp" && protocol != "-1" {
+				continue
+			}
+			if protocol == "tcp" && (aws.Int64Value(permission.FromPort) > port || aws.Int64Value(permission.ToPort) < port) {
+				continue
+			}
+			for _, ipRange := range permission.IpRanges {
+				if aws.StringValue(ipRange.CidrIp) == cidr {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// route53
+
+func (c *Client) ensureDNSForService(ctx context.Context, svc *corev1.Service, lb *loadBalancer, dnsComment string) error {
+	awsELB, err := c.getLoadBalancerForService(svc)
+	// Primarily checking to see if this exists. It is an error if it does not,
+	// likely because AWS is still creating it and the Reconcile should be retried
+	if err != nil {
+		return err
+	}
+	// ELB exists, now let's set the DNS
+	err = c.ensureDNSRecord(lb, awsELB, dnsComment)
+	if err != nil {
+		return err
+	}
+	// A load balancer that went back from dualstack to ipv4 leaves behind AAAA
+	// records that no longer resolve. Only then are they removed, not on every
+	// reconcile of an ipv4 one
+	if svc.Annotations[ipAddressTypeAnnotationKey] == elbv2.IpAddressTypeIpv4 {
+		wasDualStack, err := c.hasAliasRecords(lb, "AAAA")
+		if err != nil || !wasDualStack {
+			return err
+		}
+		log.Info("Removing the AAAA records of a load balancer that's no longer dualstack", "endpointName", lb.endpointName+"."+lb.baseDomain)
+		return c.ensureDNSRecordsRemoved(lb, awsELB, []string{"AAAA"})
+	}
+	return nil
+}
+
+// hasAliasRecords checks if any zone of lb has an alias record of recordType
+// for it, whatever load balancer it points at
+func (c *Client) hasAliasRecords(lb *loadBalancer, recordType string) (bool, error) {
+	zones, err := c.getHostedZones(lb)
+	if err != nil {
+		return false, err
+	}
+	for _, zone := range zones {
+		records, err := c.getRecordsNamed(zone.id, lb.endpointName+"."+lb.baseDomain)
+		if err != nil {
+			return false, err
+		}
+		if len(aliasRecords(records, []string{recordType})) > 0 {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// removeDNSForService will remove a DNS entry for a particular Service
+func (c *Client) removeDNSForService(ctx context.Context, svc *corev1.Service, lb *loadBalancer) error {
+	awsELB, err := c.getLoadBalancerForService(svc)
+	// Primarily checking to see if this exists. It is an error if it does not,
+	// likely because AWS is still creating it and the Reconcile should be retried
+	if err != nil {
+		return err
+	}
+	// ELB exists, now let's remove the DNS
+	return c.ensureDNSRecordsRemoved(lb, awsELB, awsELB.recordTypes())
+}
+
+// newClusterLoadBalancer returns the DNS name of a Service published in the
+// cluster's zones, as <endpointName>.<cluster base domain>
+func (c *Client) newClusterLoadBalancer(kclient client.Client, endpointName string) (*loadBalancer, error) {
+	clusterBaseDomain, err := baseutils.GetClusterBaseDomain(kclient)
+	if err != nil {
+		return nil, err
+	}
+	vpcID, err := c.getClusterVPC(kclient)
+	if err != nil {
+		return nil, err
+	}
+	// The public zone omits the cluster name. So an example:
+	// A cluster's base domain of alice-cluster.l4s7.s1.domain.com will need an
+	// entry made in l4s7.s1.domain.com. zone.
+	return &loadBalancer{
+		endpointName: endpointName,
+		baseDomain:   clusterBaseDomain,
+		zoneNames: []string{
+			clusterBaseDomain + ".",
+			clusterBaseDomain[strings.Index(clusterBaseDomain, ".")+1:] + ".",
+		},
+		vpcID: vpcID,
+	}, nil
+}
+
+// getAdminAPILoadBalancer returns the DNS name of the rh-api Service. An
+// APIScheme with its own hosted zone is only published in that zone, and one
+// with its own base domain in the zones named after it, which must exist
+func (c *Client) getAdminAPILoadBalancer(kclient client.Client, instance *cloudingressv1alpha1.APIScheme) (*loadBalancer, error) {
+	ingress := instance.Spec.ManagementAPIServerIngress
+	if ingress.BaseDomain == "" && ingress.HostedZoneID == "" {
+		return c.newClusterLoadBalancer(kclient, ingress.DNSName)
+	}
+	baseDomain, err := baseutils.GetAdminAPIBaseDomain(kclient, instance)
+	if err != nil {
+		return nil, err
+	}
+	lb := &loadBalancer{
+		endpointName: ingress.DNSName,
+		baseDomain:   baseDomain,
+	}
+	if ingress.HostedZoneID == "" {
+		lb.zoneNames = []string{baseDomain + "."}
+		lb.vpcID, err = c.getClusterVPC(kclient)
 		if err != nil {
-			log.Error(err, "Error while describing subnets")
 			return nil, err
 		}
-		subnetIDs = append(subnetIDs, describeSubnetOutput.Subnets...)
-
-		token = describeSubnetOutput.NextToken
+		return lb, nil
 	}
-
-	return subnetIDs, nil
+	lb.hostedZone, err = c.getCustomHostedZone(ingress.HostedZoneID, lb.endpointName+"."+baseDomain)
+	if err != nil {
+		return nil, err
+	}
+	return lb, nil
 }
 
-func (c *Client) getAllRouteTablesInVPC(vpcID string) ([]*ec2.RouteTable, error) {
-
-	var routeTables []*ec2.RouteTable
-	token := aws.String("initString")
+// getCustomHostedZone returns the hosted zone with the ID hostedZoneID given
+// by an APIScheme for recordName, once checked to exist and to be able to
+// hold recordName
+func (c *Client) getCustomHostedZone(hostedZoneID, recordName string) (*hostedZone, error) {
+	output, err := c.route53Client.GetHostedZone(&route53.GetHostedZoneInput{
+		Id: aws.String(hostedZoneID),
+	})
+	if err != nil {
+		if aerr, ok := err.(awserr.Error); ok && aerr.Code() == route53.ErrCodeNoSuchHostedZone {
+			return nil, errors.NewDNSZoneNotFoundError("Route53 hosted zone " + hostedZoneID)
+		}
+		return nil, err
+	}
+	zoneName := aws.StringValue(output.HostedZone.Name)
+	if !baseutils.IsInDNSZone(recordName, zoneName) {
+		return nil, fmt.Errorf("%s can't be published in the Route53 hosted zone %s for %s", recordName, hostedZoneID, zoneName)
+	}
+	return &hostedZone{
+		id:      path.Base(aws.StringValue(output.HostedZone.Id)),
+		private: output.HostedZone.Config != nil && aws.BoolValue(output.HostedZone.Config.PrivateZone),
+	}, nil
+}
 
-	for token != nil {
-		describeRouteTablesOutput, err := c.ec2Client.DescribeRouteTables(&ec2.DescribeRouteTablesInput{Filters: []*ec2.Filter{{Name: aws.String("vpc-id"), Values: []*string{aws.String(vpcID)}}}})
+// getHostedZones returns the hosted zones the records of lb go in: its own
+// zone, or the public and private zones named after its zoneNames. Each name
+// must have at least one
+func (c *Client) getHostedZones(lb *loadBalancer) ([]hostedZone, error) {
+	if lb.hostedZone != nil {
+		return []hostedZone{*lb.hostedZone}, nil
+	}
+	zones := []hostedZone{}
+	for _, zoneName := range lb.zoneNames {
+		named, err := c.getHostedZonesNamed(zoneName, lb.vpcID)
 		if err != nil {
-			log.Error(err, "Error while describing route tables")
 			return nil, err
 		}
-		routeTables = append(routeTables, describeRouteTablesOutput.RouteTables...)
-
-		token = describeRouteTablesOutput.NextToken
+		if len(named) == 0 {
+			return nil, errors.NewDNSZoneNotFoundError("Route53 Zone for " + zoneName)
+		}
+		zones = append(zones, named...)
 	}
-
-	return routeTables, nil
+	return zones, nil
 }
 
-func isSubnetPublic(rt []*ec2.RouteTable, subnetID string) (bool, error) {
-	var subnetTable *ec2.RouteTable
-	for _, table := range rt {
-		for _, assoc := range table.Associations {
-			if aws.StringValue(assoc.SubnetId) == subnetID {
-				subnetTable = table
-				break
-			}
-		}
+// getHostedZonesNamed returns the public and private hosted zones named
+// zoneName. Split-horizon DNS has one of each. Private zones are only
+// returned if they're associated with the VPC vpcID
+func (c *Client) getHostedZonesNamed(zoneName, vpcID string) ([]hostedZone, error) {
+	named, err := c.dns().ZoneForDomain(zoneName)
+	if err != nil {
+		return nil, err
 	}
-
-	if subnetTable == nil {
-		// If there is no explicit association, the subnet will be implicitly
-		// associated with the VPC's main routing table.
-		for _, table := range rt {
-			for _, assoc := range table.Associations {
-				if aws.BoolValue(assoc.Main) {
-					log.Info(fmt.Sprintf(
-						"Assuming implicit use of main routing table %s for %s",
-						aws.StringValue(table.RouteTableId), subnetID))
-					subnetTable = table
-					break
-				}
+	zones := []hostedZone{}
+	for _, zone := range named {
+		if zone.Private {
+			associated, err := c.isHostedZoneAssociated(zone.ID, vpcID)
+			if err != nil {
+				return nil, err
+			}
+			if !associated {
+				continue
 			}
 		}
+		zones = append(zones, hostedZone{id: zone.ID, private: zone.Private})
 	}
+	return zones, nil
+}
 
-	if subnetTable == nil {
-		return false, fmt.Errorf("could not locate routing table for %s", subnetID)
+// isHostedZoneAssociated checks if the private hosted zone with the ID
+// hostedZoneID answers queries from the VPC vpcID
+func (c *Client) isHostedZoneAssociated(hostedZoneID, vpcID string) (bool, error) {
+	output, err := c.route53Client.GetHostedZone(&route53.GetHostedZoneInput{
+		Id: aws.String(hostedZoneID),
+	})
+	if err != nil {
+		return false, err
 	}
-
-	for _, route := range subnetTable.Routes {
-		// There is no direct way in the AWS API to determine if a subnet is public or private.
-		// A public subnet is one which has an internet gateway route
-		// we look for the gatewayId and make sure it has the prefix of igw to differentiate
-		// from the default in-subnet route which is called "local"
-		// or other virtual gateway (starting with vgv)
-		// or vpc peering connections (starting with pcx).
-		if strings.HasPrefix(aws.StringValue(route.GatewayId), "igw") {
+	for _, vpc := range output.VPCs {
+		if aws.StringValue(vpc.VPCId) == vpcID {
 			return true, nil
 		}
 	}
-
 	return false, nil
 }
 
-//getClusterRegion returns the installed cluster's AWS region
-func getClusterRegion(kclient client.Client) (string, error) {
-	infra, err := baseutils.GetInfrastructureObject(kclient)
-	if err != nil {
-		return "", err
-	} else if infra.Status.PlatformStatus == nil {
-		// Try the deprecated configmap. See https://bugzilla.redhat.com/show_bug.cgi?id=1814332
-		return readClusterRegionFromConfigMap(kclient)
+// publishedHostedZones splits zones into those the records of awsObj go in,
+// and those they must be removed from. An internal load balancer only
+// resolves from inside the cluster's VPC, so it's left out of the public
+// zones, unless there's no private zone to publish it in
+func publishedHostedZones(zones []hostedZone, awsObj *awsLoadBalancer) (published, unpublished []hostedZone) {
+	hasPrivate := false
+	for _, zone := range zones {
+		hasPrivate = hasPrivate || zone.private
 	}
-	return infra.Status.PlatformStatus.AWS.Region, nil
-}
-
-func readClusterRegionFromConfigMap(kclient client.Client) (string, error) {
-	cm, err := getClusterConfigMap(kclient)
-	if err != nil {
-		return "", err
+	for _, zone := range zones {
+		if awsObj.isInternal() && hasPrivate && !zone.private {
+			unpublished = append(unpublished, zone)
+		} else {
+			published = append(published, zone)
+		}
 	}
-	return parseClusterRegionFromConfigMap(cm)
+	return published, unpublished
 }
 
-func getClusterConfigMap(kclient client.Client) (*corev1.ConfigMap, error) {
-	cm := &corev1.ConfigMap{}
-	ns := types.NamespacedName{
-		Namespace: "kube-system",
-		Name:      "cluster-config-v1",
-	}
-	err := kclient.Get(context.TODO(), ns, cm)
-	return cm, err
+// deleteAliasRecord deletes the alias record of the given type (A or AAAA),
+// if it still points at the load balancer DNSName
+func (c *Client) deleteAliasRecord(publicHostedZoneID, DNSName, aliasDNSZoneID, resourceRecordSetName, recordType string, targetHealth bool) error {
+	return c.dns().DeleteRecord(publicHostedZoneID, dnsclient.Record{
+		Name: resourceRecordSetName,
+		Type: recordType,
+		Alias: &dnsclient.Alias{
+			DNSName:              DNSName,
+			HostedZoneID:         aliasDNSZoneID,
+			EvaluateTargetHealth: targetHealth,
+		},
+	})
 }
 
-func parseClusterRegionFromConfigMap(cm *corev1.ConfigMap) (string, error) {
-	if cm == nil || cm.Data == nil {
-		return "", fmt.Errorf("unexpected nil configmap or nil configmap Data")
+// recordExists checks if a specific RecordSet already exist in route53
+func (c *Client) recordExists(resourceRecordSet *route53.ResourceRecordSet, publicHostedZoneID string) (bool, error) {
+	if resourceRecordSet == nil {
+		return false, goError.New("resourceRecordSet can't be nil")
 	}
-	data, ok := cm.Data["install-config"]
-	if !ok {
-		return "", fmt.Errorf("Missing install-config in configmap")
+	if resourceRecordSet.Name == nil {
+		return false, goError.New("resourceRecordSet Name is required")
 	}
-	var ic installConfig
-	if err := yaml.Unmarshal([]byte(data), &ic); err != nil {
-		return "", fmt.Errorf("Invalid install-config: %v\njson:%s", err, data)
+	// Route53 assumes that the domain name that you specify is fully qualified.
+	// Therefore, it doesn't mind if the trailing "." is missing.
+	// However, adding the trailing "." make the future comparaison easier, since route53 listResourceRecordSets contains trailing dots
+	if !strings.HasSuffix(*resourceRecordSet.Name, ".") {
+		*resourceRecordSet.Name += "."
+	}
+	if resourceRecordSet.AliasTarget != nil && !strings.HasSuffix(*resourceRecordSet.AliasTarget.DNSName, ".") {
+		*resourceRecordSet.AliasTarget.DNSName += "."
 	}
-	return ic.Platform.AWS.Region, nil
-}
-
-/* Helper functions below, sorted by AWS API type */
 
-// ELB (v1)
-func (c *Client) doesELBExist(elbName string) (*awsLoadBalancer, error) {
-	input := &elb.DescribeLoadBalancersInput{
-		LoadBalancerNames: []*string{aws.String(elbName)},
+	var recordExists bool
+	input := &route53.ListResourceRecordSetsInput{
+		HostedZoneId: aws.String(publicHostedZoneID),
 	}
-	output, err := c.elbClient.DescribeLoadBalancers(input)
-	if err != nil {
-		if aerr, ok := err.(awserr.Error); ok {
-			switch aerr.Code() {
-			case elb.ErrCodeAccessPointNotFoundException:
-				return &awsLoadBalancer{}, errors.NewLoadBalancerNotReadyError()
-			default:
-				return &awsLoadBalancer{}, err
+
+	// Check if any property of the record was changed.
+	// aws-go-sdk may potentially give all results in multiple pages, so this will go
+	// through every page given in response to the API call
+	err := c.route53Client.ListResourceRecordSetsPages(input, func(p *route53.ListResourceRecordSetsOutput, lastPage bool) bool {
+		for _, record := range p.ResourceRecordSets {
+			if strings.Replace(*record.Name, `\052`, "*", 1) == *resourceRecordSet.Name && *record.Type == *resourceRecordSet.Type && record.SetIdentifier == nil && reflect.DeepEqual(record.AliasTarget, resourceRecordSet.AliasTarget) {
+				log.Info("Record already exists, skipping UPSERT.", "Record", aws.StringValue(record.Name))
+				recordExists = true
+				return false
 			}
 		}
-	}
-	return &awsLoadBalancer{
-			elbName:   elbName,
-			dnsName:   *output.LoadBalancerDescriptions[0].DNSName,
-			dnsZoneID: *output.LoadBalancerDescriptions[0].CanonicalHostedZoneNameID},
-		nil
+		// we didn't find the record in this page, keep looking
+		return true
+	})
+
+	return recordExists, err
 }
 
-// route53
+func (c *Client) upsertARecord(clusterDomain, DNSName, aliasDNSZoneID, resourceRecordSetName, comment string, targetHealth bool) error {
+	return c.upsertAliasRecord(clusterDomain, DNSName, aliasDNSZoneID, resourceRecordSetName, "A", comment, targetHealth)
+}
 
-func (c *Client) ensureDNSForService(ctx context.Context, kclient client.Client, svc *corev1.Service, dnsName, dnsComment string) error {
-	// Get the ELB name from the Service's UID. Truncate to 32 characters for AWS
-	elbName := strings.ReplaceAll("a"+string(svc.ObjectMeta.UID), "-", "")
-	if len(elbName) > 32 {
-		// Truncate to 32 characters
-		elbName = elbName[0:32]
-	}
-	awsELB, err := c.doesELBExist(elbName)
-	// Primarily checking to see if this exists. It is an error if it does not,
-	// likely because AWS is still creating it and the Reconcile should be retried
-	if err != nil {
-		return err
-	}
-	// ELB exists, now let's set the DNS
-	clusterBaseDomain, err := baseutils.GetClusterBaseDomain(kclient)
+// upsertAliasRecord creates or updates the alias record of the given type (A
+// or AAAA)
+func (c *Client) upsertAliasRecord(clusterDomain, DNSName, aliasDNSZoneID, resourceRecordSetName, recordType, comment string, targetHealth bool) error {
+	publicHostedZoneID, err := c.getPublicHostedZoneID(clusterDomain)
 	if err != nil {
 		return err
 	}
-	lb := &loadBalancer{
-		endpointName: dnsName,
-		baseDomain:   clusterBaseDomain,
-	}
-	return c.ensureDNSRecord(lb, awsELB, dnsComment)
+	return c.upsertAliasRecordInZone(publicHostedZoneID, DNSName, aliasDNSZoneID, resourceRecordSetName, recordType, comment, targetHealth)
 }
 
-// removeDNSForService will remove a DNS entry for a particular Service
-func (c *Client) removeDNSForService(ctx context.Context, kclient client.Client, svc *corev1.Service, dnsName, dnsComment string) error {
-	// Get the ELB name from the Service's UID. Truncate to 32 characters for AWS
-	elbName := strings.ReplaceAll("a"+string(svc.ObjectMeta.UID), "-", "")[0:32]
-	awsELB, err := c.doesELBExist(elbName)
-	// Primarily checking to see if this exists. It is an error if it does not,
-	// likely because AWS is still creating it and the Reconcile should be retried
-	if err != nil {
-		return err
+// upsertAliasRecordInZone creates or updates the alias record of the given
+// type in the hosted zone with the ID publicHostedZoneID
+func (c *Client) upsertAliasRecordInZone(publicHostedZoneID, DNSName, aliasDNSZoneID, resourceRecordSetName, recordType, comment string, targetHealth bool) error {
+	resourceRecordSet := &route53.ResourceRecordSet{
+		AliasTarget: &route53.AliasTarget{
+			DNSName:              aws.String(DNSName),
+			EvaluateTargetHealth: aws.Bool(targetHealth),
+			HostedZoneId:         aws.String(aliasDNSZoneID),
+		},
+		Name: aws.String(resourceRecordSetName),
+		Type: aws.String(recordType),
 	}
-	// ELB exists, now let's set the DNS
-	clusterBaseDomain, err := baseutils.GetClusterBaseDomain(kclient)
-	if err != nil {
+
+	recordExists, err := c.recordExists(resourceRecordSet, publicHostedZoneID)
+	if err != nil || recordExists {
 		return err
 	}
-	return c.ensureDNSRecordsRemoved(
-		clusterBaseDomain,
-		awsELB.dnsName,
-		awsELB.dnsZoneID,
-		dnsName+"."+clusterBaseDomain,
-		dnsComment,
-		false)
-}
 
-func (c *Client) deleteARecord(clusterDomain, DNSName, aliasDNSZoneID, resourceRecordSetName string, targetHealth bool) error {
-	publicHostedZoneID, err := c.getPublicHostedZoneID(clusterDomain)
+	changes := []*route53.Change{}
+	// A CNAME can't share its name with any other record, so one pointing at
+	// the load balancer, eg created by hand, is replaced by the alias in the
+	// same change batch. So are weighted or failover records of the same
+	// type, left by a migration to a network load balancer
+	records, err := c.getRecordsNamed(publicHostedZoneID, resourceRecordSetName)
 	if err != nil {
 		return err
 	}
-
+	for _, record := range records {
+		switch {
+		case aws.StringValue(record.Type) == route53.RRTypeCname:
+			log.Info("Replacing CNAME record with an alias record", "Record", aws.StringValue(record.Name), "recordType", recordType)
+		case aws.StringValue(record.Type) == recordType && record.SetIdentifier != nil:
+			log.Info("Replacing weighted or failover record with an alias record", "Record", aws.StringValue(record.Name), "recordType", recordType, "setIdentifier", aws.StringValue(record.SetIdentifier))
+		default:
+			continue
+		}
+		changes = append(changes, &route53.Change{
+			Action:            aws.String("DELETE"),
+			ResourceRecordSet: record,
+		})
+	}
+	changes = append(changes, &route53.Change{
+		Action:            aws.String("UPSERT"),
+		ResourceRecordSet: resourceRecordSet,
+	})
 	change := &route53.ChangeResourceRecordSetsInput{
 		ChangeBatch: &route53.ChangeBatch{
-			Changes: []*route53.Change{
-				{
-					Action: aws.String("DELETE"),
-					ResourceRecordSet: &route53.ResourceRecordSet{
-						AliasTarget: &route53.AliasTarget{
-							DNSName:              aws.String(DNSName),
-							EvaluateTargetHealth: aws.Bool(targetHealth),
-							HostedZoneId:         aws.String(aliasDNSZoneID),
-						},
-						Name: aws.String(resourceRecordSetName),
-						Type: aws.String("A"),
-					},
-				},
-			},
+			Changes: changes,
+			Comment: aws.String(comment),
 		},
 		HostedZoneId: aws.String(publicHostedZoneID),
 	}
 	_, err = c.route53Client.ChangeResourceRecordSets(change)
 	if err != nil {
-		// If the DNS entry was not found, disregard the error.
-		//
-		// XXX The error code in this case is InvalidChangeBatch
-		//     with no other errors in awserr.Error.OrigErr() or
-		//     in awserr.BatchedErrors.OrigErrs().
-		//
-		//     So there seems to be no way, short of parsing the
-		//     message string, to verify the error was caused by
-		//     a missing DNS entry and not something else.
-		//
-		if awsErr, ok := err.(awserr.Error); ok {
-			if awsErr.Code() == route53.ErrCodeInvalidChangeBatch {
-				return nil
-			}
+		return err
+	}
+	return c.deleteReleasedHealthChecks(changes)
+}
+
+// dns returns the client the DNS records are managed with, Route53
+func (c *Client) dns() dnsclient.DNSClient {
+	return dnsclient.NewRoute53(c.route53Client)
+}
+
+// getRecordsNamed returns the records named recordName in the hosted zone
+// with the ID hostedZoneID, of every type and set identifier
+func (c *Client) getRecordsNamed(hostedZoneID, recordName string) ([]*route53.ResourceRecordSet, error) {
+	if !strings.HasSuffix(recordName, ".") {
+		recordName += "."
+	}
+	output, err := c.route53Client.ListResourceRecordSets(&route53.ListResourceRecordSetsInput{
+		HostedZoneId:    aws.String(hostedZoneID),
+		StartRecordName: aws.String(recordName),
+		MaxItems:        aws.String("100"),
+	})
+	if err != nil {
+		return nil, err
+	}
+	records := []*route53.ResourceRecordSet{}
+	for _, record := range output.ResourceRecordSets {
+		// Route53 lists the * of wildcard records in octal
+		if strings.EqualFold(strings.Replace(aws.StringValue(record.Name), `\052`, "*", 1), recordName) {
+			records = append(records, record)
 		}
 	}
+	return records, nil
+}
 
-	return err
+// weightedAliasTarget is a load balancer a weighted alias record points at
+type weightedAliasTarget struct {
+	setIdentifier string
+	lb            *awsLoadBalancer
+	weight        int64
 }
 
-// recordExists checks if a specific RecordSet already exist in route53
-func (c *Client) recordExists(resourceRecordSet *route53.ResourceRecordSet, publicHostedZoneID string) (bool, error) {
-	if resourceRecordSet == nil {
-		return false, goError.New("resourceRecordSet can't be nil")
+// upsertWeightedAliasRecords makes the alias records of the given type named
+// recordName in the hosted zone with the ID hostedZoneID weighted between
+// targets. Any other record of that type and name, simple or weighted, and any
+// CNAME, is deleted in the same change batch
+func (c *Client) upsertWeightedAliasRecords(hostedZoneID, recordName, recordType string, targets []weightedAliasTarget, comment string) error {
+	if !strings.HasSuffix(recordName, ".") {
+		recordName += "."
 	}
-	if resourceRecordSet.Name == nil {
-		return false, goError.New("resourceRecordSet Name is required")
+	desired := []*route53.ResourceRecordSet{}
+	for _, target := range targets {
+		dnsName := target.lb.dnsName
+		if !strings.HasSuffix(dnsName, ".") {
+			dnsName += "."
+		}
+		desired = append(desired, &route53.ResourceRecordSet{
+			AliasTarget: &route53.AliasTarget{
+				DNSName:              aws.String(dnsName),
+				EvaluateTargetHealth: aws.Bool(false),
+				HostedZoneId:         aws.String(target.lb.dnsZoneID),
+			},
+			Name:          aws.String(recordName),
+			Type:          aws.String(recordType),
+			SetIdentifier: aws.String(target.setIdentifier),
+			Weight:        aws.Int64(target.weight),
+		})
+	}
+	return c.upsertRoutedAliasRecords(hostedZoneID, recordName, recordType, desired, comment)
+}
+
+// upsertRoutedAliasRecords makes desired, alias records of the given type
+// named recordName with set identifiers, the only records of that type and
+// name in the hosted zone with the ID hostedZoneID. Any other record of that
+// type and name, and any CNAME, is deleted in the same change batch
+func (c *Client) upsertRoutedAliasRecords(hostedZoneID, recordName, recordType string, desired []*route53.ResourceRecordSet, comment string) error {
+	wanted := map[string]*route53.ResourceRecordSet{}
+	for _, record := range desired {
+		wanted[aws.StringValue(record.SetIdentifier)] = record
+	}
+
+	records, err := c.getRecordsNamed(hostedZoneID, recordName)
+	if err != nil {
+		return err
+	}
+	changes := []*route53.Change{}
+	for _, record := range records {
+		switch aws.StringValue(record.Type) {
+		case route53.RRTypeCname:
+		case recordType:
+			want, ok := wanted[aws.StringValue(record.SetIdentifier)]
+			if ok && record.SetIdentifier != nil &&
+				aws.Int64Value(record.Weight) == aws.Int64Value(want.Weight) &&
+				aws.StringValue(record.Failover) == aws.StringValue(want.Failover) &&
+				aws.StringValue(record.HealthCheckId) == aws.StringValue(want.HealthCheckId) &&
+				reflect.DeepEqual(record.AliasTarget, want.AliasTarget) {
+				// already in place
+				delete(wanted, aws.StringValue(record.SetIdentifier))
+				continue
+			}
+			if ok && record.SetIdentifier != nil && (record.Weight == nil) == (want.Weight == nil) {
+				// replaced by the UPSERT below. A record can't change its
+				// routing policy, so one that does is deleted
+				continue
+			}
+		default:
+			continue
+		}
+		changes = append(changes, &route53.Change{
+			Action:            aws.String("DELETE"),
+			ResourceRecordSet: record,
+		})
+	}
+	for _, record := range desired {
+		if _, ok := wanted[aws.StringValue(record.SetIdentifier)]; ok {
+			changes = append(changes, &route53.Change{
+				Action:            aws.String("UPSERT"),
+				ResourceRecordSet: record,
+			})
+		}
+	}
+	if len(changes) == 0 {
+		return nil
+	}
+	_, err = c.route53Client.ChangeResourceRecordSets(&route53.ChangeResourceRecordSetsInput{
+		ChangeBatch: &route53.ChangeBatch{
+			Changes: changes,
+			Comment: aws.String(comment),
+		},
+		HostedZoneId: aws.String(hostedZoneID),
+	})
+	if err != nil {
+		return err
+	}
+	log.Info("Updated the routed alias records", "Record", recordName, "recordType", recordType, "changes", len(changes))
+	return c.deleteReleasedHealthChecks(changes)
+}
+
+// deleteWeightedAliasRecords deletes the weighted alias records of the given
+// type named recordName that point at awsObj
+func (c *Client) deleteWeightedAliasRecords(hostedZoneID, recordName, recordType string, awsObj *awsLoadBalancer) error {
+	records, err := c.getRecordsNamed(hostedZoneID, recordName)
+	if err != nil {
+		return err
 	}
-	// Route53 assumes that the domain name that you specify is fully qualified.
-	// Therefore, it doesn't mind if the trailing "." is missing.
-	// However, adding the trailing "." make the future comparaison easier, since route53 listResourceRecordSets contains trailing dots
-	if !strings.HasSuffix(*resourceRecordSet.Name, ".") {
-		*resourceRecordSet.Name += "."
+	changes := []*route53.Change{}
+	for _, record := range records {
+		if aws.StringValue(record.Type) != recordType || record.SetIdentifier == nil || record.AliasTarget == nil {
+			continue
+		}
+		if !strings.EqualFold(strings.TrimSuffix(aws.StringValue(record.AliasTarget.DNSName), "."), strings.TrimSuffix(awsObj.dnsName, ".")) {
+			continue
+		}
+		changes = append(changes, &route53.Change{
+			Action:            aws.String("DELETE"),
+			ResourceRecordSet: record,
+		})
 	}
-	if resourceRecordSet.AliasTarget != nil && !strings.HasSuffix(*resourceRecordSet.AliasTarget.DNSName, ".") {
-		*resourceRecordSet.AliasTarget.DNSName += "."
+	if len(changes) == 0 {
+		return nil
 	}
-
-	var recordExists bool
-	input := &route53.ListResourceRecordSetsInput{
-		HostedZoneId: aws.String(publicHostedZoneID),
+	_, err = c.route53Client.ChangeResourceRecordSets(&route53.ChangeResourceRecordSetsInput{
+		ChangeBatch:  &route53.ChangeBatch{Changes: changes},
+		HostedZoneId: aws.String(hostedZoneID),
+	})
+	if err != nil {
+		return err
 	}
+	return c.deleteReleasedHealthChecks(changes)
+}
 
-	// Check if any property of the record was changed.
-	// aws-go-sdk may potentially give all results in multiple pages, so this will go
-	// through every page given in response to the API call
-	err := c.route53Client.ListResourceRecordSetsPages(input, func(p *route53.ListResourceRecordSetsOutput, lastPage bool) bool {
-		for _, record := range p.ResourceRecordSets {
-			if *record.Name == *resourceRecordSet.Name && *record.Type == *resourceRecordSet.Type && reflect.DeepEqual(record.AliasTarget, resourceRecordSet.AliasTarget) {
-				log.Info("Record already exists, skipping UPSERT.", "Record", aws.StringValue(record.Name))
-				recordExists = true
-				return false
+// aliasRecords returns the alias records of records of one of recordTypes,
+// simple or weighted
+func aliasRecords(records []*route53.ResourceRecordSet, recordTypes []string) []*route53.ResourceRecordSet {
+	aliases := []*route53.ResourceRecordSet{}
+	for _, record := range records {
+		if record.AliasTarget == nil {
+			continue
+		}
+		for _, recordType := range recordTypes {
+			if aws.StringValue(record.Type) == recordType {
+				aliases = append(aliases, record)
 			}
 		}
-		// we didn't find the record in this page, keep looking
-		return true
-	})
-
-	return recordExists, err
+	}
+	return aliases
 }
 
-func (c *Client) upsertARecord(clusterDomain, DNSName, aliasDNSZoneID, resourceRecordSetName, comment string, targetHealth bool) error {
-	publicHostedZoneID, err := c.getPublicHostedZoneID(clusterDomain)
+// deleteAliasRecordsNamed deletes the alias records of one of recordTypes
+// named recordName in the hosted zone with the ID hostedZoneID, whatever load
+// balancer they point at
+func (c *Client) deleteAliasRecordsNamed(hostedZoneID, recordName string, recordTypes []string) error {
+	records, err := c.getRecordsNamed(hostedZoneID, recordName)
 	if err != nil {
 		return err
 	}
-
-	resourceRecordSet := &route53.ResourceRecordSet{
-		AliasTarget: &route53.AliasTarget{
-			DNSName:              aws.String(DNSName),
-			EvaluateTargetHealth: aws.Bool(targetHealth),
-			HostedZoneId:         aws.String(aliasDNSZoneID),
-		},
-		Name: aws.String(resourceRecordSetName),
-		Type: aws.String("A"),
+	changes := []*route53.Change{}
+	for _, record := range aliasRecords(records, recordTypes) {
+		changes = append(changes, &route53.Change{
+			Action:            aws.String("DELETE"),
+			ResourceRecordSet: record,
+		})
 	}
-
-	recordExists, err := c.recordExists(resourceRecordSet, publicHostedZoneID)
-	if err != nil || recordExists {
+	if len(changes) == 0 {
+		return nil
+	}
+	_, err = c.route53Client.ChangeResourceRecordSets(&route53.ChangeResourceRecordSetsInput{
+		ChangeBatch:  &route53.ChangeBatch{Changes: changes},
+		HostedZoneId: aws.String(hostedZoneID),
+	})
+	if err != nil {
 		return err
 	}
+	log.Info("Removed the alias records from the hosted zone", "Record", recordName, "hostedZoneID", hostedZoneID, "changes", len(changes))
+	return c.deleteReleasedHealthChecks(changes)
+}
 
-	change := &route53.ChangeResourceRecordSetsInput{
-		ChangeBatch: &route53.ChangeBatch{
-			Changes: []*route53.Change{
-				{
-					Action:            aws.String("UPSERT"),
-					ResourceRecordSet: resourceRecordSet,
+// dnsRecordsExist checks if all of the alias records ensureDNSRecord would
+// create exist, in each of the zones of lb they go in
+func (c *Client) dnsRecordsExist(lb *loadBalancer, awsObj *awsLoadBalancer) (bool, error) {
+	zones, err := c.getHostedZones(lb)
+	if err != nil {
+		return false, err
+	}
+	published, unpublished := publishedHostedZones(zones, awsObj)
+	for _, zone := range published {
+		for _, recordType := range awsObj.recordTypes() {
+			exists, err := c.recordExists(&route53.ResourceRecordSet{
+				AliasTarget: &route53.AliasTarget{
+					DNSName:              aws.String(awsObj.dnsName),
+					EvaluateTargetHealth: aws.Bool(false),
+					HostedZoneId:         aws.String(awsObj.dnsZoneID),
 				},
-			},
-			Comment: aws.String(comment),
-		},
-		HostedZoneId: aws.String(publicHostedZoneID),
+				Name: aws.String(lb.endpointName + "." + lb.baseDomain),
+				Type: aws.String(recordType),
+			}, zone.id)
+			if err != nil || !exists {
+				return false, err
+			}
+		}
 	}
-	_, err = c.route53Client.ChangeResourceRecordSets(change)
-	return err
+	for _, zone := range unpublished {
+		records, err := c.getRecordsNamed(zone.id, lb.endpointName+"."+lb.baseDomain)
+		if err != nil {
+			return false, err
+		}
+		if len(aliasRecords(records, awsObj.recordTypes())) > 0 {
+			return false, nil
+		}
+	}
+	return true, nil
 }
 
 func (c *Client) getPublicHostedZoneID(clusterDomain string) (string, error) {
@@ -735,131 +4729,110 @@ func (c *Client) getPublicHostedZoneID(clusterDomain string) (string, error) {
 		}
 	}
 
-	return "", fmt.Errorf("Route53 Zone not found for %s", clusterDomain)
+	return "", errors.NewDNSZoneNotFoundError("Route53 Zone for " + clusterDomain)
 
 }
 
+// ensureDNSRecord ensures the alias records of lb point at awsObj in the zones
+// they go in, and are removed from the others
 func (c *Client) ensureDNSRecord(lb *loadBalancer, awsObj *awsLoadBalancer, comment string) error {
-	// private zone
-
-	for i := 1; i <= config.MaxAPIRetries; i++ {
-		err := c.upsertARecord(
-			lb.baseDomain+".",
-			awsObj.dnsName,
-			awsObj.dnsZoneID,
-			lb.endpointName+"."+lb.baseDomain,
-			comment,
-			false)
-		if err != nil {
-			log.Error(err, "Couldn't upsert A record for private zone",
-				"retryAttempt", i,
-				"publicZone", lb.baseDomain+".",
-				"dnsName", awsObj.dnsName,
-				"dnsZoneID", awsObj.dnsZoneID,
-				"endpointName", lb.endpointName+".", lb.baseDomain)
-			if i == config.MaxAPIRetries {
-				log.Error(err, "Couldn't upsert A record for private zone: Retries Exhausted")
-				return err
-			}
-			// TODO: Logging - sleep
-			time.Sleep(time.Duration(i) * time.Second)
-		} else {
-			// success
-			break
+	zones, err := c.getHostedZones(lb)
+	if err != nil {
+		return err
+	}
+	published, unpublished := publishedHostedZones(zones, awsObj)
+	for _, zone := range unpublished {
+		err = c.deleteAliasRecordsNamed(zone.id, lb.endpointName+"."+lb.baseDomain, awsObj.recordTypes())
+		if err != nil {
+			return err
 		}
 	}
-
-	// Public zone
-	// The public zone omits the cluster name. So an example:
-	// A cluster's base domain of alice-cluster.l4s7.s1.domain.com will need an
-	// entry made in l4s7.s1.domain.com. zone.
-	// Public zone
-	// The public zone omits the cluster name. So an example:
-	// A cluster's base domain of alice-cluster.l4s7.s1.domain.com will need an
-	// entry made in l4s7.s1.domain.com. zone.
-	publicZone := lb.baseDomain[strings.Index(lb.baseDomain, ".")+1:]
-
-	for i := 1; i <= config.MaxAPIRetries; i++ {
-		// Append a . to get the zone name
-		err := c.upsertARecord(
-			publicZone+".",
-			awsObj.dnsName,
-			awsObj.dnsZoneID,
-			lb.endpointName+"."+lb.baseDomain,
-			"RH API Endpoint",
-			false)
-		if err != nil {
-			log.Error(err, "Couldn't upsert A record for public zone",
-				"retryAttempt", i,
-				"publicZone", publicZone+".",
-				"dnsName", awsObj.dnsName,
-				"dnsZoneID", awsObj.dnsZoneID,
-				"endpointName", lb.endpointName+".", lb.baseDomain)
-			if i == config.MaxAPIRetries {
-				log.Error(err, "Couldn't upsert A record for public zone: Retries Exhausted")
-				return err
+	for _, zone := range published {
+		zoneID := zone.id
+		for _, recordType := range awsObj.recordTypes() {
+			for i := 1; i <= config.MaxAPIRetries; i++ {
+				err := c.upsertAliasRecordInZone(
+					zoneID,
+					awsObj.dnsName,
+					awsObj.dnsZoneID,
+					lb.endpointName+"."+lb.baseDomain,
+					recordType,
+					comment,
+					false)
+				if err != nil {
+					log.Error(err, "Couldn't upsert alias record",
+						"retryAttempt", i,
+						"recordType", recordType,
+						"hostedZoneID", zoneID,
+						"dnsName", awsObj.dnsName,
+						"dnsZoneID", awsObj.dnsZoneID,
+						"endpointName", lb.endpointName+"."+lb.baseDomain)
+					if i == config.MaxAPIRetries {
+						log.Error(err, "Couldn't upsert alias record: Retries Exhausted", "recordType", recordType, "hostedZoneID", zoneID)
+						return err
+					}
+					c.wait(time.Duration(i) * time.Second)
+				} else {
+					// success
+					break
+				}
 			}
-			time.Sleep(time.Duration(i) * time.Second)
-		} else {
-			// success
-			break
 		}
 	}
 	return nil
 }
 
-// ensureDNSRecordsRemoved undoes ensureDNSRecord
-func (c *Client) ensureDNSRecordsRemoved(clusterDomain, DNSName, aliasDNSZoneID, resourceRecordSetName, comment string, targetHealth bool) error {
-	for i := 1; i <= config.MaxAPIRetries; i++ {
-		err := c.deleteARecord(
-			clusterDomain+".",
-			DNSName,
-			aliasDNSZoneID,
-			resourceRecordSetName,
-			targetHealth)
-		if err != nil {
-			// retry
-			// TODO: logging
-			if i == config.MaxAPIRetries {
-				// TODO: logging
-				return err
-			}
-			// TODO: logging
-			time.Sleep(time.Duration(i) * time.Second)
-		} else {
-			break
-		}
+// ensureDNSRecordsRemoved undoes ensureDNSRecord for the given record types,
+// in every zone of lb
+func (c *Client) ensureDNSRecordsRemoved(lb *loadBalancer, awsObj *awsLoadBalancer, recordTypes []string) error {
+	zones, err := c.getHostedZones(lb)
+	if err != nil {
+		return err
 	}
-	for i := 1; i <= config.MaxAPIRetries; i++ {
-		err := c.deleteARecord(
-			// The public zone name omits the cluster name.
-			// e.g. mycluster.abcd.s1.openshift.com -> abcd.s1.openshift.com
-			clusterDomain[strings.Index(clusterDomain, ".")+1:]+".",
-			DNSName,
-			aliasDNSZoneID,
-			resourceRecordSetName,
-			targetHealth)
-		if err != nil {
-			// retry
-			// TODO: logging
-			if i == config.MaxAPIRetries {
-				// TODO: logging
+	for _, zone := range zones {
+		zoneID := zone.id
+		for _, recordType := range recordTypes {
+			for i := 1; i <= config.MaxAPIRetries; i++ {
+				err := c.deleteAliasRecord(
+					zoneID,
+					awsObj.dnsName,
+					awsObj.dnsZoneID,
+					lb.endpointName+"."+lb.baseDomain,
+					recordType,
+					false)
+				if err != nil {
+					log.Error(err, "Couldn't delete alias record",
+						"retryAttempt", i,
+						"recordType", recordType,
+						"hostedZoneID", zoneID,
+						"dnsName", awsObj.dnsName,
+						"endpointName", lb.endpointName+"."+lb.baseDomain)
+					if i == config.MaxAPIRetries {
+						log.Error(err, "Couldn't delete alias record: Retries Exhausted", "recordType", recordType, "hostedZoneID", zoneID)
+						return err
+					}
+					c.wait(time.Duration(i) * time.Second)
+				} else {
+					break
+				}
+			}
+			// Those left by a migration to a network load balancer too
+			err = c.deleteWeightedAliasRecords(zoneID, lb.endpointName+"."+lb.baseDomain, recordType, awsObj)
+			if err != nil {
 				return err
 			}
-			// TODO: logging
-			time.Sleep(time.Duration(i) * time.Second)
-		} else {
-			break
 		}
 	}
-	// public
 	return nil
 }
 
 // ELBv2
 
-// removeLoadBalancerFromMasterNodes
-func (c *Client) removeLoadBalancerFromMasterNodes(ctx context.Context, kclient client.Client) (string, string, error) {
+// removeLoadBalancerFromMasterNodes removes every listener of the
+// internet-facing NLB but those on keepPorts. When none is kept, the NLB is
+// deleted and removed from the master Machines. Returns the DNS name and
+// hosted zone of the internal NLB
+func (c *Client) removeLoadBalancerFromMasterNodes(ctx context.Context, kclient client.Client, keepPorts []int32) (string, string, error) {
 	nlbs, err := c.listOwnedNLBs(kclient)
 	if err != nil {
 		return "", "", err
@@ -872,7 +4845,15 @@ func (c *Client) removeLoadBalancerFromMasterNodes(ctx context.Context, kclient
 	for _, networkLoadBalancer := range nlbs {
 		if networkLoadBalancer.scheme == "internet-facing" {
 			lbName = networkLoadBalancer.loadBalancerName
-			err := c.deleteExternalLoadBalancer(networkLoadBalancer.loadBalancerArn)
+			kept, err := c.removePublicListeners(networkLoadBalancer.loadBalancerArn, keepPorts)
+			if err != nil {
+				return "", "", err
+			}
+			if kept {
+				// The masters must stay registered to serve the kept listeners
+				continue
+			}
+			err = c.deleteExternalLoadBalancer(networkLoadBalancer.loadBalancerArn)
 			if err != nil {
 				return "", "", err
 			}
@@ -890,6 +4871,46 @@ func (c *Client) removeLoadBalancerFromMasterNodes(ctx context.Context, kclient
 	return intDNSName, intHostedZoneID, nil
 }
 
+// removePublicListeners deletes every listener of the load balancer
+// lbArn, eg the API and machine config server ones, but those on keepPorts.
+// Returns whether any listener was kept
+func (c *Client) removePublicListeners(lbArn string, keepPorts []int32) (bool, error) {
+	kept := false
+	var deleteErr error
+	err := c.elbv2Client.DescribeListenersPages(
+		&elbv2.DescribeListenersInput{LoadBalancerArn: aws.String(lbArn)},
+		func(page *elbv2.DescribeListenersOutput, lastPage bool) bool {
+			for _, listener := range page.Listeners {
+				if keepsPort(keepPorts, aws.Int64Value(listener.Port)) {
+					kept = true
+					continue
+				}
+				_, deleteErr = c.elbv2Client.DeleteListener(&elbv2.DeleteListenerInput{
+					ListenerArn: listener.ListenerArn,
+				})
+				if deleteErr != nil {
+					return false
+				}
+			}
+			return true
+		},
+	)
+	if err != nil {
+		return false, err
+	}
+	return kept, deleteErr
+}
+
+// keepsPort checks if port is one of keepPorts
+func keepsPort(keepPorts []int32, port int64) bool {
+	for _, keepPort := range keepPorts {
+		if int64(keepPort) == port {
+			return true
+		}
+	}
+	return false
+}
+
 // listOwnedNLBs uses the DescribeLoadBalancersV2 to get back a list of all
 // Network Load Balancers, then filters the list to those owned by the cluster
 func (c *Client) listOwnedNLBs(kclient client.Client) ([]loadBalancerV2, error) {
@@ -968,6 +4989,42 @@ func (c *Client) listOwnedNLBs(kclient client.Client) ([]loadBalancerV2, error)
 	return loadBalancers, nil
 }
 
+// doesNLBExist looks up a network load balancer by name
+func (c *Client) doesNLBExist(nlbName string) (*awsLoadBalancer, error) {
+	output, err := c.elbv2Client.DescribeLoadBalancers(&elbv2.DescribeLoadBalancersInput{
+		Names: []*string{aws.String(nlbName)},
+	})
+	if err != nil {
+		if aerr, ok := err.(awserr.Error); ok && aerr.Code() == elbv2.ErrCodeLoadBalancerNotFoundException {
+			return &awsLoadBalancer{}, errors.NewLoadBalancerNotReadyError()
+		}
+		return &awsLoadBalancer{}, err
+	}
+	if len(output.LoadBalancers) == 0 {
+		return &awsLoadBalancer{}, errors.NewLoadBalancerNotReadyError()
+	}
+	nlb := output.LoadBalancers[0]
+	return &awsLoadBalancer{
+		elbName:         nlbName,
+		dnsName:         aws.StringValue(nlb.DNSName),
+		dnsZoneID:       aws.StringValue(nlb.CanonicalHostedZoneId),
+		loadBalancerArn: aws.StringValue(nlb.LoadBalancerArn),
+		ipAddressType:   aws.StringValue(nlb.IpAddressType),
+		scheme:          aws.StringValue(nlb.Scheme),
+	}, nil
+}
+
+// setLoadBalancerIPAddressType switches a load balancer between ipv4 and
+// dualstack
+func (c *Client) setLoadBalancerIPAddressType(loadBalancerArn, ipAddressType string) error {
+	log.Info("Setting load balancer IP address type", "loadBalancerArn", loadBalancerArn, "ipAddressType", ipAddressType)
+	_, err := c.elbv2Client.SetIpAddressType(&elbv2.SetIpAddressTypeInput{
+		IpAddressType:   aws.String(ipAddressType),
+		LoadBalancerArn: aws.String(loadBalancerArn),
+	})
+	return err
+}
+
 // deleteExternalLoadBalancer takes in the external LB arn and deletes the entire LB
 func (c *Client) deleteExternalLoadBalancer(extLoadBalancerArn string) error {
 	i := elbv2.DeleteLoadBalancerInput{
@@ -977,6 +5034,24 @@ func (c *Client) deleteExternalLoadBalancer(extLoadBalancerArn string) error {
 	return err
 }
 
+// externalLoadBalancerName returns the name of the external API load
+// balancer: override if set, <infrastructureName>-ext otherwise. A name past
+// the 32 characters AWS allows keeps as much of infrastructureName as fits,
+// followed by a hash of all of it, so it stays the same across reconciles and
+// differs between clusters
+func externalLoadBalancerName(infrastructureName, override string) string {
+	if override != "" {
+		return override
+	}
+	name := infrastructureName + "-ext"
+	if len(name) <= maxLoadBalancerNameLength {
+		return name
+	}
+	hash := fmt.Sprintf("%08x", crc32.ChecksumIEEE([]byte(infrastructureName)))
+	prefix := infrastructureName[:maxLoadBalancerNameLength-len(hash)-len("--ext")]
+	return strings.TrimSuffix(prefix, "-") + "-" + hash + "-ext"
+}
+
 // createNetworkLoadBalancer should only return one new NLB at a time
 func (c *Client) createNetworkLoadBalancer(lbName, scheme, subnet string) ([]loadBalancerV2, error) {
 	i := &elbv2.CreateLoadBalancerInput{
@@ -1009,8 +5084,63 @@ func (c *Client) createNetworkLoadBalancer(lbName, scheme, subnet string) ([]loa
 	return loadBalancers, nil
 }
 
-// createListenerForNLB creates a listener between target group and nlb given their arn
-func (c *Client) createListenerForNLB(targetGroupArn, loadBalancerArn string) error {
+// adoptNetworkLoadBalancer returns the existing load balancer named lbName, so
+// it can be tagged as owned by the cluster. It has to be in the cluster's VPC,
+// have the expected scheme and not be tagged as another cluster's, eg one
+// installed before with the same name: anything else can't be brought into
+// compliance, and is left for an administrator to delete or to avoid with
+// another name
+func (c *Client) adoptNetworkLoadBalancer(kclient client.Client, lbName, scheme string) ([]loadBalancerV2, error) {
+	vpcID, err := c.getClusterVPC(kclient)
+	if err != nil {
+		return []loadBalancerV2{}, err
+	}
+	clusterName, err := baseutils.GetClusterName(kclient)
+	if err != nil {
+		return []loadBalancerV2{}, err
+	}
+	output, err := c.elbv2Client.DescribeLoadBalancers(&elbv2.DescribeLoadBalancersInput{
+		Names: []*string{aws.String(lbName)},
+	})
+	if err != nil {
+		return []loadBalancerV2{}, err
+	}
+	if len(output.LoadBalancers) != 1 {
+		return []loadBalancerV2{}, fmt.Errorf("expected 1 load balancer named %s, got %d", lbName, len(output.LoadBalancers))
+	}
+	loadBalancer := output.LoadBalancers[0]
+	if aws.StringValue(loadBalancer.VpcId) != vpcID {
+		return []loadBalancerV2{}, fmt.Errorf("load balancer %s is in VPC %s, not the cluster's VPC %s", lbName, aws.StringValue(loadBalancer.VpcId), vpcID)
+	}
+	if aws.StringValue(loadBalancer.Scheme) != scheme {
+		return []loadBalancerV2{}, fmt.Errorf("load balancer %s is %s, expected %s", lbName, aws.StringValue(loadBalancer.Scheme), scheme)
+	}
+	tagsOutput, err := c.elbv2Client.DescribeTags(&elbv2.DescribeTagsInput{
+		ResourceArns: []*string{loadBalancer.LoadBalancerArn},
+	})
+	if err != nil {
+		return []loadBalancerV2{}, err
+	}
+	for _, tagDescription := range tagsOutput.TagDescriptions {
+		if owner := otherClusterOwner(tagDescription.Tags, clusterName); owner != "" {
+			return []loadBalancerV2{}, fmt.Errorf("load balancer %s belongs to the cluster %s, set the PublishingStrategy's loadBalancerName to use another name", lbName, owner)
+		}
+	}
+	log.Info("Adopting load balancer", "loadBalancerName", lbName, "loadBalancerArn", aws.StringValue(loadBalancer.LoadBalancerArn))
+	return []loadBalancerV2{
+		{
+			canonicalHostedZoneNameID: aws.StringValue(loadBalancer.CanonicalHostedZoneId),
+			dnsName:                   aws.StringValue(loadBalancer.DNSName),
+			loadBalancerArn:           aws.StringValue(loadBalancer.LoadBalancerArn),
+			loadBalancerName:          aws.StringValue(loadBalancer.LoadBalancerName),
+			scheme:                    aws.StringValue(loadBalancer.Scheme),
+			vpcID:                     aws.StringValue(loadBalancer.VpcId),
+		},
+	}, nil
+}
+
+// createListenerForNLB creates a listener on port between target group and nlb given their arn
+func (c *Client) createListenerForNLB(targetGroupArn, loadBalancerArn string, port int64) error {
 	i := &elbv2.CreateListenerInput{
 		DefaultActions: []*elbv2.Action{
 			{
@@ -1019,7 +5149,7 @@ func (c *Client) createListenerForNLB(targetGroupArn, loadBalancerArn string) er
 			},
 		},
 		LoadBalancerArn: aws.String(loadBalancerArn),
-		Port:            aws.Int64(6443),
+		Port:            aws.Int64(port),
 		Protocol:        aws.String("TCP"),
 	}
 
@@ -1030,8 +5160,20 @@ func (c *Client) createListenerForNLB(targetGroupArn, loadBalancerArn string) er
 	return nil
 }
 
-// addTagsForNLB creates needed tags for an NLB
-func (c *Client) addTagsForNLB(resourceARN string, clusterName string) error {
+// otherClusterOwner returns the cluster other than clusterName that tags
+// claim a resource for, if any
+func otherClusterOwner(tags []*elbv2.Tag, clusterName string) string {
+	for _, tag := range tags {
+		owner := strings.TrimPrefix(aws.StringValue(tag.Key), "kubernetes.io/cluster/")
+		if owner != aws.StringValue(tag.Key) && owner != clusterName {
+			return owner
+		}
+	}
+	return ""
+}
+
+// addTagsForNLB creates needed tags for the NLB named lbName
+func (c *Client) addTagsForNLB(resourceARN, clusterName, lbName string) error {
 	i := &elbv2.AddTagsInput{
 		ResourceArns: []*string{
 			aws.String(resourceARN), // ext nlb resources arn
@@ -1043,10 +5185,13 @@ func (c *Client) addTagsForNLB(resourceARN string, clusterName string) error {
 			},
 			{
 				Key:   aws.String("Name"),
-				Value: aws.String(clusterName + "-ext"), //in form of samn-test-qb58m-ext
+				Value: aws.String(lbName), //in form of samn-test-qb58m-ext
 			},
 		},
 	}
+	for _, tag := range c.configuredTags() {
+		i.Tags = append(i.Tags, &elbv2.Tag{Key: tag.Key, Value: tag.Value})
+	}
 
 	_, err := c.elbv2Client.AddTags(i)
 	if err != nil {
@@ -1069,3 +5214,263 @@ func (c *Client) getTargetGroupArn(targetGroupName string) (string, error) {
 	}
 	return aws.StringValue(result.TargetGroups[0].TargetGroupArn), nil
 }
+
+// requiredActions are the IAM actions the operator calls. Its credentials
+// request grants them, but the account may have taken some away, eg with a
+// service control policy. TestRequiredActions fails for an AWS call of this
+// package missing from it
+var requiredActions = []string{
+	"acm:DescribeCertificate",
+	"cloudwatch:DeleteAlarms",
+	"cloudwatch:DescribeAlarms",
+	"cloudwatch:GetMetricStatistics",
+	"cloudwatch:PutMetricAlarm",
+	"ec2:AllocateAddress",
+	"ec2:AuthorizeSecurityGroupIngress",
+	"ec2:CreateSecurityGroup",
+	"ec2:CreateTags",
+	"ec2:DeleteSecurityGroup",
+	"ec2:DescribeAddresses",
+	"ec2:DescribeAvailabilityZones",
+	"ec2:DescribeInstances",
+	"ec2:DescribeRouteTables",
+	"ec2:DescribeSecurityGroups",
+	"ec2:DescribeSubnets",
+	"ec2:ReleaseAddress",
+	"ec2:RevokeSecurityGroupIngress",
+	"ec2:UpdateSecurityGroupRuleDescriptionsIngress",
+	"elasticloadbalancing:AddTags",
+	"elasticloadbalancing:ApplySecurityGroupsToLoadBalancer",
+	"elasticloadbalancing:AttachLoadBalancerToSubnets",
+	"elasticloadbalancing:CreateListener",
+	"elasticloadbalancing:CreateLoadBalancer",
+	"elasticloadbalancing:CreateLoadBalancerPolicy",
+	"elasticloadbalancing:DeleteListener",
+	"elasticloadbalancing:DeleteLoadBalancer",
+	"elasticloadbalancing:DeleteLoadBalancerListeners",
+	"elasticloadbalancing:DeleteTargetGroup",
+	"elasticloadbalancing:DeregisterInstancesFromLoadBalancer",
+	"elasticloadbalancing:DeregisterTargets",
+	"elasticloadbalancing:DescribeAccountLimits",
+	"elasticloadbalancing:DescribeInstanceHealth",
+	"elasticloadbalancing:DescribeListeners",
+	"elasticloadbalancing:DescribeLoadBalancerAttributes",
+	"elasticloadbalancing:DescribeLoadBalancerPolicies",
+	"elasticloadbalancing:DescribeLoadBalancers",
+	"elasticloadbalancing:DescribeTags",
+	"elasticloadbalancing:DescribeTargetGroupAttributes",
+	"elasticloadbalancing:DescribeTargetGroups",
+	"elasticloadbalancing:DescribeTargetHealth",
+	"elasticloadbalancing:DetachLoadBalancerFromSubnets",
+	"elasticloadbalancing:ModifyListener",
+	"elasticloadbalancing:ModifyLoadBalancerAttributes",
+	"elasticloadbalancing:ModifyTargetGroupAttributes",
+	"elasticloadbalancing:RegisterInstancesWithLoadBalancer",
+	"elasticloadbalancing:RegisterTargets",
+	"elasticloadbalancing:SetIpAddressType",
+	"elasticloadbalancing:SetLoadBalancerListenerSSLCertificate",
+	"elasticloadbalancing:SetLoadBalancerPoliciesOfListener",
+	"iam:SimulatePrincipalPolicy",
+	"kms:DescribeKey",
+	"kms:GetKeyPolicy",
+	"kms:ListGrants",
+	"route53:ChangeResourceRecordSets",
+	"route53:ChangeTagsForResource",
+	"route53:CreateHealthCheck",
+	"route53:DeleteHealthCheck",
+	"route53:GetHealthCheck",
+	"route53:GetHostedZone",
+	"route53:ListHostedZonesByName",
+	"route53:ListResourceRecordSets",
+	"route53:UpdateHealthCheck",
+	"s3:GetBucketPolicy",
+	"s3:GetEncryptionConfiguration",
+}
+
+// checkPermissions simulates the IAM policies of the operator's credentials
+// against requiredActions, and returns the actions they deny
+func (c *Client) checkPermissions(ctx context.Context, kclient client.Client) ([]string, error) {
+	identity, err := c.stsClient.GetCallerIdentity(&sts.GetCallerIdentityInput{})
+	if err != nil {
+		return nil, err
+	}
+	principal, err := principalArn(aws.StringValue(identity.Arn))
+	if err != nil {
+		return nil, err
+	}
+	denied := []string{}
+	err = c.iamClient.SimulatePrincipalPolicyPages(&iam.SimulatePrincipalPolicyInput{
+		PolicySourceArn: aws.String(principal),
+		ActionNames:     aws.StringSlice(requiredActions),
+	}, func(output *iam.SimulatePolicyResponse, lastPage bool) bool {
+		denied = append(denied, deniedActions(output.EvaluationResults)...)
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	return denied, nil
+}
+
+// checkCredentials asks STS who the operator's credentials belong to, which
+// any valid credentials may, whatever their policies
+func (c *Client) checkCredentials(ctx context.Context, kclient client.Client) error {
+	_, err := c.stsClient.GetCallerIdentity(&sts.GetCallerIdentityInput{})
+	return err
+}
+
+// getLoadBalancerQuota returns how many load balancers of the kind the
+// region's quota allows, network or classic, and how many there are. ELB
+// reports the quotas in force, raised ones included, as its account limits
+func (c *Client) getLoadBalancerQuota(ctx context.Context, kclient client.Client, network bool) (int, int, error) {
+	used := 0
+	var limits []*elb.Limit
+	if network {
+		output, err := c.elbv2Client.DescribeAccountLimits(&elbv2.DescribeAccountLimitsInput{})
+		if err != nil {
+			return 0, 0, err
+		}
+		for _, limit := range output.Limits {
+			limits = append(limits, &elb.Limit{Name: limit.Name, Max: limit.Max})
+		}
+		err = c.elbv2Client.DescribeLoadBalancersPages(&elbv2.DescribeLoadBalancersInput{},
+			func(page *elbv2.DescribeLoadBalancersOutput, lastPage bool) bool {
+				for _, loadBalancer := range page.LoadBalancers {
+					if aws.StringValue(loadBalancer.Type) == elbv2.LoadBalancerTypeEnumNetwork {
+						used++
+					}
+				}
+				return true
+			})
+		if err != nil {
+			return 0, 0, err
+		}
+	} else {
+		output, err := c.elbClient.DescribeAccountLimits(&elb.DescribeAccountLimitsInput{})
+		if err != nil {
+			return 0, 0, err
+		}
+		limits = output.Limits
+		err = c.elbClient.DescribeLoadBalancersPages(&elb.DescribeLoadBalancersInput{},
+			func(page *elb.DescribeLoadBalancersOutput, lastPage bool) bool {
+				used += len(page.LoadBalancerDescriptions)
+				return true
+			})
+		if err != nil {
+			return 0, 0, err
+		}
+	}
+	name := classicLoadBalancersLimit
+	if network {
+		name = networkLoadBalancersLimit
+	}
+	for _, limit := range limits {
+		if aws.StringValue(limit.Name) != name {
+			continue
+		}
+		max, err := strconv.Atoi(aws.StringValue(limit.Max))
+		if err != nil {
+			return 0, 0, fmt.Errorf("invalid %s account limit %q", name, aws.StringValue(limit.Max))
+		}
+		return used, max, nil
+	}
+	return 0, 0, fmt.Errorf("ELB doesn't report the %s account limit", name)
+}
+
+// validateAdminAPISubnets checks subnetIDs, the subnets listed for the admin
+// API load balancer, suit its scheme by their route tables: public subnets,
+// routed to an internet gateway, for an internet-facing one, and private ones
+// for an internal one. ELB only reports a mismatch halfway through creating
+// it, as a subnet without an internet gateway. Subnets left to the cloud
+// provider are picked by their tags, so aren't checked. Neither are they
+// checked to be in the region's availability zones, see
+// withoutEdgeZoneSubnets
+func (c *Client) validateAdminAPISubnets(ctx context.Context, kclient client.Client, subnetIDs []string, internal bool) error {
+	if len(subnetIDs) == 0 {
+		return nil
+	}
+	output, err := c.ec2Client.DescribeSubnets(&ec2.DescribeSubnetsInput{
+		SubnetIds: aws.StringSlice(subnetIDs),
+	})
+	if err != nil {
+		return err
+	}
+	if _, err := c.withoutEdgeZoneSubnets(kclient, output.Subnets, true); err != nil {
+		return err
+	}
+	targetVPC, err := c.getClusterVPC(kclient)
+	if err != nil {
+		return err
+	}
+	routeTables, err := c.getAllRouteTablesInVPC(targetVPC)
+	if err != nil {
+		return err
+	}
+	mismatched, err := subnetsMismatchingScheme(subnetIDs, routeTables, internal)
+	if err != nil {
+		return err
+	}
+	if len(mismatched) == 0 {
+		return nil
+	}
+	if internal {
+		return errors.NewActionRequiredError(cloudingressv1alpha1.ActionRequiredSubnetSchemeMismatch,
+			fmt.Sprintf("the internal admin API load balancer needs private subnets, but %s are routed to an internet gateway", strings.Join(mismatched, ", ")),
+			"list private subnets in the APIScheme's subnetIDs")
+	}
+	return errors.NewActionRequiredError(cloudingressv1alpha1.ActionRequiredSubnetSchemeMismatch,
+		fmt.Sprintf("the internet-facing admin API load balancer needs public subnets, but %s have no route to an internet gateway", strings.Join(mismatched, ", ")),
+		"list public subnets in the APIScheme's subnetIDs, or route them to the VPC's internet gateway")
+}
+
+// subnetsMismatchingScheme returns those of subnetIDs that are public for an
+// internal load balancer, or private for an internet-facing one
+func subnetsMismatchingScheme(subnetIDs []string, routeTables []*ec2.RouteTable, internal bool) ([]string, error) {
+	mismatched := []string{}
+	for _, subnetID := range subnetIDs {
+		isPublic, err := isSubnetPublic(routeTables, subnetID)
+		if err != nil {
+			return nil, err
+		}
+		if isPublic == internal {
+			mismatched = append(mismatched, subnetID)
+		}
+	}
+	return mismatched, nil
+}
+
+// principalArn returns the ARN of the IAM user or role whose policies apply
+// to the caller callerArn. The policies of an assumed role session are those
+// of its role
+func principalArn(callerArn string) (string, error) {
+	parsed, err := arn.Parse(callerArn)
+	if err != nil {
+		return "", err
+	}
+	if parsed.Service != "sts" {
+		return callerArn, nil
+	}
+	// arn:aws:sts::123456789012:assumed-role/role-name/session-name
+	parts := strings.Split(parsed.Resource, "/")
+	if len(parts) < 2 || parts[0] != "assumed-role" {
+		return "", fmt.Errorf("can't tell the IAM principal of %s", callerArn)
+	}
+	return arn.ARN{
+		Partition: parsed.Partition,
+		Service:   "iam",
+		AccountID: parsed.AccountID,
+		Resource:  "role/" + parts[1],
+	}.String(), nil
+}
+
+// deniedActions returns the actions of the results the simulation didn't
+// allow, explicitly or implicitly
+func deniedActions(results []*iam.EvaluationResult) []string {
+	denied := []string{}
+	for _, result := range results {
+		if aws.StringValue(result.EvalDecision) != iam.PolicyEvaluationDecisionTypeAllowed {
+			denied = append(denied, aws.StringValue(result.EvalActionName))
+		}
+	}
+	return denied
+}
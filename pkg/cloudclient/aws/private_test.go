@@ -2,21 +2,49 @@ package aws
 
 import (
 	"context"
+	"crypto/x509"
+	goerrors "errors"
 	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"hash/crc32"
+	"math/big"
+	"path/filepath"
 	"reflect"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/openshift/cloud-ingress-operator/config"
+	cloudingressv1alpha1 "github.com/openshift/cloud-ingress-operator/pkg/apis/cloudingress/v1alpha1"
+	cioerrors "github.com/openshift/cloud-ingress-operator/pkg/errors"
 	"github.com/openshift/cloud-ingress-operator/pkg/testutils"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	awsproviderapi "sigs.k8s.io/cluster-api-provider-aws/pkg/apis/awsproviderconfig/v1beta1"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/acm"
+	"github.com/aws/aws-sdk-go/service/acm/acmiface"
+	"github.com/aws/aws-sdk-go/service/cloudwatch"
+	"github.com/aws/aws-sdk-go/service/cloudwatch/cloudwatchiface"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
+	"github.com/aws/aws-sdk-go/service/elb"
+	"github.com/aws/aws-sdk-go/service/elb/elbiface"
 	"github.com/aws/aws-sdk-go/service/elbv2"
 	"github.com/aws/aws-sdk-go/service/elbv2/elbv2iface"
+	"github.com/aws/aws-sdk-go/service/kms"
+	"github.com/aws/aws-sdk-go/service/kms/kmsiface"
 	"github.com/aws/aws-sdk-go/service/route53"
 	"github.com/aws/aws-sdk-go/service/route53/route53iface"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3iface"
 )
 
 func TestUpdateAWSLBList(t *testing.T) {
@@ -473,6 +501,44 @@ func TestListOwnedNLBs(t *testing.T) {
 	}
 }
 
+func TestEnsureInternalAPIListenerNoInternalNLB(t *testing.T) {
+	clusterName := "no-internal-nlb-test"
+	infraObj := testutils.CreateInfraObject(clusterName, testutils.DefaultAPIEndpoint, testutils.DefaultAPIEndpoint, testutils.DefaultRegionName)
+	mocks := testutils.NewTestMock(t, []runtime.Object{infraObj})
+
+	client := &Client{
+		elbv2Client: mockDescribeELBv2LoadBalancers{
+			Resp: elbv2.DescribeLoadBalancersOutput{
+				LoadBalancers: []*elbv2.LoadBalancer{
+					{
+						LoadBalancerArn:  aws.String("arn:123456"),
+						LoadBalancerName: aws.String(clusterName + "-ext"),
+						Scheme:           aws.String("internet-facing"),
+					},
+				},
+			},
+			TagsResp: elbv2.DescribeTagsOutput{
+				TagDescriptions: []*elbv2.TagDescription{
+					{
+						ResourceArn: aws.String("arn:123456"),
+						Tags: []*elbv2.Tag{
+							{
+								Key:   aws.String("kubernetes.io/cluster/" + clusterName),
+								Value: aws.String("owned"),
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	// Without an internal NLB, the external one must not be removed
+	err := client.ensureInternalAPIListener(mocks.FakeKubeClient)
+	if err == nil {
+		t.Fatalf("Expected an error without an internal API load balancer")
+	}
+}
+
 type mockDeleteLoadBalancer struct {
 	elbv2iface.ELBV2API
 	Resp    elbv2.DeleteLoadBalancerOutput
@@ -518,6 +584,67 @@ func TestDeleteExternalV2LoadBalancer(t *testing.T) {
 	}
 }
 
+type mockPublicListeners struct {
+	elbv2iface.ELBV2API
+	Listeners []*elbv2.Listener
+	Deleted   []string
+}
+
+func (m *mockPublicListeners) DescribeListenersPages(_ *elbv2.DescribeListenersInput, fn func(*elbv2.DescribeListenersOutput, bool) bool) error {
+	fn(&elbv2.DescribeListenersOutput{Listeners: m.Listeners}, true)
+	return nil
+}
+
+func (m *mockPublicListeners) DeleteListener(input *elbv2.DeleteListenerInput) (*elbv2.DeleteListenerOutput, error) {
+	m.Deleted = append(m.Deleted, aws.StringValue(input.ListenerArn))
+	return &elbv2.DeleteListenerOutput{}, nil
+}
+
+func TestRemovePublicListeners(t *testing.T) {
+	listener := func(port int64) *elbv2.Listener {
+		return &elbv2.Listener{
+			ListenerArn: aws.String(fmt.Sprintf("listener-%d", port)),
+			Port:        aws.Int64(port),
+		}
+	}
+	tests := []struct {
+		Name            string
+		KeepPorts       []int32
+		ExpectedKept    bool
+		ExpectedDeleted []string
+	}{
+		{
+			Name:            "Remove every listener",
+			ExpectedDeleted: []string{"listener-6443", "listener-22623", "listener-8443"},
+		},
+		{
+			Name:            "Keep a custom port",
+			KeepPorts:       []int32{8443},
+			ExpectedKept:    true,
+			ExpectedDeleted: []string{"listener-6443", "listener-22623"},
+		},
+		{
+			Name:            "Keep a port without a listener",
+			KeepPorts:       []int32{9000},
+			ExpectedDeleted: []string{"listener-6443", "listener-22623", "listener-8443"},
+		},
+	}
+	for _, test := range tests {
+		mock := &mockPublicListeners{Listeners: []*elbv2.Listener{listener(6443), listener(22623), listener(8443)}}
+		client := &Client{elbv2Client: mock}
+		kept, err := client.removePublicListeners("test-external-nlb", test.KeepPorts)
+		if err != nil {
+			t.Fatalf("Test [%v] unexpected error %v", test.Name, err)
+		}
+		if kept != test.ExpectedKept {
+			t.Fatalf("Test [%v] FAILED. Expected kept %v. Got %v", test.Name, test.ExpectedKept, kept)
+		}
+		if !reflect.DeepEqual(mock.Deleted, test.ExpectedDeleted) {
+			t.Fatalf("Test [%v] FAILED. Expected %v. Got %v", test.Name, test.ExpectedDeleted, mock.Deleted)
+		}
+	}
+}
+
 type mockCreateLoadBalancer struct {
 	elbv2iface.ELBV2API
 	Resp    elbv2.CreateLoadBalancerOutput
@@ -577,280 +704,3083 @@ func TestCreateNetworkLoadBalancer(t *testing.T) {
 	}
 }
 
-// BZ https://bugzilla.redhat.com/show_bug.cgi?id=1814332
-func TestOldClusterNoInfrastructureBackfill(t *testing.T) {
-	clustername := "oldtest"
-	// legacy infra obj has this extra bits, but the configmap does not
-	extrabits := "cmld7"
-	oldInfraObj := testutils.CreatOldInfraObject("oldtest-cmld7", testutils.DefaultAPIEndpoint, testutils.DefaultAPIEndpoint, testutils.DefaultRegionName)
-	oldCM := testutils.CreateLegacyClusterConfig(fmt.Sprintf("%s.%s", clustername, testutils.DefaultClusterDomain),
-		fmt.Sprintf("%s-%s", clustername, extrabits), testutils.DefaultRegionName, 0, 0)
-	objs := []runtime.Object{oldInfraObj, oldCM}
-	mocks := testutils.NewTestMock(t, objs)
-	region, err := getClusterRegion(mocks.FakeKubeClient)
-	if err != nil {
-		t.Fatalf("Error: Couldn't get region. Expected to get %s: %v", testutils.DefaultRegionName, err)
+func TestExternalLoadBalancerName(t *testing.T) {
+	tests := []struct {
+		Name               string
+		InfrastructureName string
+		Override           string
+		Expected           string
+	}{
+		{
+			Name:               "Short infrastructure name",
+			InfrastructureName: "samn-test-qb58m",
+			Expected:           "samn-test-qb58m-ext",
+		},
+		{
+			Name:               "Override",
+			InfrastructureName: "samn-test-qb58m",
+			Override:           "rh-api-public",
+			Expected:           "rh-api-public",
+		},
+		{
+			Name:               "Long infrastructure name",
+			InfrastructureName: "a-rather-long-cluster-name-qb58m",
+			Expected:           "a-rather-long-clust-" + fmt.Sprintf("%08x", crc32.ChecksumIEEE([]byte("a-rather-long-cluster-name-qb58m"))) + "-ext",
+		},
 	}
-	if region != testutils.DefaultRegionName {
-		t.Fatalf("Expected region to be %s, but got %s", testutils.DefaultRegionName, region)
+	for _, test := range tests {
+		name := externalLoadBalancerName(test.InfrastructureName, test.Override)
+		if name != test.Expected {
+			t.Fatalf("Test [%v] FAILED. Expected %v. Got %v", test.Name, test.Expected, name)
+		}
+		if len(name) > maxLoadBalancerNameLength {
+			t.Fatalf("Test [%v] FAILED. %v is longer than %d characters", test.Name, name, maxLoadBalancerNameLength)
+		}
 	}
 }
 
-func TestGetMasterSubnetNames(t *testing.T) {
-	clustername := "subnet-test"
-	masterNames := make([]string, 3)
-	for i := 0; i < 3; i++ {
-		masterNames[i] = fmt.Sprintf("master-%d", i)
-	}
-
-	machineList, _ := testutils.CreateMachineObjectList(masterNames, "ids", "master", testutils.DefaultRegionName, testutils.DefaultAzName)
-	infraObj := testutils.CreateInfraObject(clustername, testutils.DefaultAPIEndpoint, testutils.DefaultAPIEndpoint, testutils.DefaultRegionName)
-	objs := []runtime.Object{infraObj, machineList}
-	mocks := testutils.NewTestMock(t, objs)
-	subnetmap, err := getMasterNodeSubnets(mocks.FakeKubeClient)
-
-	if err != nil {
-		t.Fatalf("Couldn't get subnet map: %v", err)
-	}
-	if len(subnetmap) != 2 {
-		t.Fatalf("Expected Subnet map to have exactly two keys, but got %d keys back", len(subnetmap))
-	}
-	if _, ok := subnetmap["public"]; !ok {
-		t.Fatalf("Expected Subnet map to have a 'public' key, and it did not")
-	}
-	if _, ok := subnetmap["private"]; !ok {
-		t.Fatalf("Expected Subnet map to have a 'private' key, and it did not")
-	}
-	expected := fmt.Sprintf("%s-public-%s", clustername, testutils.DefaultAzName)
-	if subnetmap["public"] != expected {
-		t.Fatalf("Expected public subnet to be %s, but got %s instead", expected, subnetmap["public"])
+func TestOtherClusterOwner(t *testing.T) {
+	tests := []struct {
+		Name     string
+		Tags     []*elbv2.Tag
+		Expected string
+	}{
+		{
+			Name: "Untagged",
+			Tags: []*elbv2.Tag{{Key: aws.String("Name"), Value: aws.String("samn-test-qb58m-ext")}},
+		},
+		{
+			Name: "Owned by the cluster",
+			Tags: []*elbv2.Tag{{Key: aws.String("kubernetes.io/cluster/samn-test-qb58m"), Value: aws.String("owned")}},
+		},
+		{
+			Name:     "Owned by a previous install",
+			Tags:     []*elbv2.Tag{{Key: aws.String("kubernetes.io/cluster/samn-test-x7k2p"), Value: aws.String("owned")}},
+			Expected: "samn-test-x7k2p",
+		},
 	}
-	expected = fmt.Sprintf("%s-private-%s", clustername, testutils.DefaultAzName)
-	if subnetmap["private"] != expected {
-		t.Fatalf("Expected private subnet to be %s, but got %s instead", expected, subnetmap["private"])
+	for _, test := range tests {
+		owner := otherClusterOwner(test.Tags, "samn-test-qb58m")
+		if owner != test.Expected {
+			t.Fatalf("Test [%v] FAILED. Expected %v. Got %v", test.Name, test.Expected, owner)
+		}
 	}
 }
 
-func TestGetMasterSubnetNamesNoMasters(t *testing.T) {
-	clustername := "subnet-test"
-	masterNames := make([]string, 0)
-	machineList, _ := testutils.CreateMachineObjectList(masterNames, "ids", "master", testutils.DefaultRegionName, testutils.DefaultAzName)
-	infraObj := testutils.CreateInfraObject(clustername, testutils.DefaultAPIEndpoint, testutils.DefaultAPIEndpoint, testutils.DefaultRegionName)
-	objs := []runtime.Object{infraObj, machineList}
-	mocks := testutils.NewTestMock(t, objs)
+type mockElasticIPs struct {
+	ec2iface.EC2API
+	Existing  []*ec2.Address
+	Allocated int
+	Untagged  int
+	Released  []string
+}
 
-	_, err := getMasterNodeSubnets(mocks.FakeKubeClient)
-	if err == nil {
-		t.Fatalf("Expected to see an exception when trying to get subnets for 0 master nodes ")
+func (m *mockElasticIPs) DescribeAddresses(_ *ec2.DescribeAddressesInput) (*ec2.DescribeAddressesOutput, error) {
+	return &ec2.DescribeAddressesOutput{Addresses: m.Existing}, nil
+}
+
+func (m *mockElasticIPs) AllocateAddress(i *ec2.AllocateAddressInput) (*ec2.AllocateAddressOutput, error) {
+	m.Allocated++
+	if len(i.TagSpecifications) == 0 {
+		m.Untagged++
 	}
+	return &ec2.AllocateAddressOutput{AllocationId: aws.String(fmt.Sprintf("eipalloc-new%d", m.Allocated))}, nil
 }
 
-func TestGetClusterRegion(t *testing.T) {
-	infraObj := testutils.CreateInfraObject("region-test", testutils.DefaultAPIEndpoint, testutils.DefaultAPIEndpoint, testutils.DefaultRegionName)
-	objs := []runtime.Object{infraObj}
-	mocks := testutils.NewTestMock(t, objs)
+func (m *mockElasticIPs) ReleaseAddress(i *ec2.ReleaseAddressInput) (*ec2.ReleaseAddressOutput, error) {
+	m.Released = append(m.Released, aws.StringValue(i.AllocationId))
+	return &ec2.ReleaseAddressOutput{}, nil
+}
 
-	region, err := getClusterRegion(mocks.FakeKubeClient)
-	if err != nil {
-		t.Fatalf("Could not get cluster region: %v", err)
+func TestEnsureElasticIPs(t *testing.T) {
+	tests := []struct {
+		Name              string
+		Existing          []*ec2.Address
+		Count             int
+		Expected          []string
+		ExpectedAllocated int
+	}{
+		{
+			Name:              "Allocates all addresses when none exist",
+			Count:             2,
+			Expected:          []string{"eipalloc-new1", "eipalloc-new2"},
+			ExpectedAllocated: 2,
+		},
+		{
+			Name:              "Reuses existing addresses and allocates the rest",
+			Existing:          []*ec2.Address{{AllocationId: aws.String("eipalloc-old")}},
+			Count:             3,
+			Expected:          []string{"eipalloc-old", "eipalloc-new1", "eipalloc-new2"},
+			ExpectedAllocated: 2,
+		},
+		{
+			Name:              "Returns only as many addresses as requested",
+			Existing:          []*ec2.Address{{AllocationId: aws.String("eipalloc-a")}, {AllocationId: aws.String("eipalloc-b")}},
+			Count:             1,
+			Expected:          []string{"eipalloc-a"},
+			ExpectedAllocated: 0,
+		},
+		{
+			Name:              "Picks the same addresses when they come back in reverse order",
+			Existing:          []*ec2.Address{{AllocationId: aws.String("eipalloc-c")}, {AllocationId: aws.String("eipalloc-b")}, {AllocationId: aws.String("eipalloc-a")}},
+			Count:             2,
+			Expected:          []string{"eipalloc-a", "eipalloc-b"},
+			ExpectedAllocated: 0,
+		},
+		{
+			Name: "Keeps the attached addresses",
+			Existing: []*ec2.Address{
+				{AllocationId: aws.String("eipalloc-a")},
+				{AllocationId: aws.String("eipalloc-c"), AssociationId: aws.String("eipassoc-c")},
+				{AllocationId: aws.String("eipalloc-b"), AssociationId: aws.String("eipassoc-b")},
+			},
+			Count:             2,
+			Expected:          []string{"eipalloc-b", "eipalloc-c"},
+			ExpectedAllocated: 0,
+		},
 	}
-	if region != testutils.DefaultRegionName {
-		t.Fatalf("Region mismatch. Expected %s, got %s", region, testutils.DefaultRegionName)
+	for _, test := range tests {
+		mock := &mockElasticIPs{Existing: test.Existing}
+		client := &Client{ec2Client: mock}
+		resp, err := client.ensureElasticIPs("cluster", "cluster-rh-api", test.Count)
+		if err != nil {
+			t.Fatalf("Test [%v] unexpected error %v", test.Name, err)
+		}
+		if !reflect.DeepEqual(resp, test.Expected) {
+			t.Fatalf("Test [%v] FAILED. Expected %v. Got %v", test.Name, test.Expected, resp)
+		}
+		if mock.Allocated != test.ExpectedAllocated {
+			t.Fatalf("Test [%v] FAILED. Expected %d allocations. Got %d", test.Name, test.ExpectedAllocated, mock.Allocated)
+		}
+		if mock.Untagged != 0 {
+			t.Fatalf("Test [%v] FAILED. %d Elastic IPs were allocated without tags", test.Name, mock.Untagged)
+		}
 	}
 }
 
-// None of these should ever occur, but if they did, it'd be nice to know they return an error
-func TestNoInfraObj(t *testing.T) {
-	masterNames := make([]string, 3)
-	for i := 0; i < 3; i++ {
-		masterNames[i] = fmt.Sprintf("master-%d", i)
+func TestReleaseAdminAPIStaticIPs(t *testing.T) {
+	infraObj := testutils.CreateInfraObject("release-eips", testutils.DefaultAPIEndpoint, testutils.DefaultAPIEndpoint, testutils.DefaultRegionName)
+	mocks := testutils.NewTestMock(t, []runtime.Object{infraObj})
+	// DescribeAddresses only returns the addresses owned by the cluster
+	mock := &mockElasticIPs{Existing: []*ec2.Address{
+		{AllocationId: aws.String("eipalloc-free")},
+		{AllocationId: aws.String("eipalloc-attached"), AssociationId: aws.String("eipassoc-nlb"), NetworkInterfaceId: aws.String("eni-nlb")},
+	}}
+	client := &Client{ec2Client: mock}
+	released, err := client.releaseAdminAPIStaticIPs(context.TODO(), mocks.FakeKubeClient, &cloudingressv1alpha1.APIScheme{}, []string{"eipalloc-free", "eipalloc-attached", "eipalloc-gone"})
+	if err != nil {
+		t.Fatalf("Test [Release] unexpected error %v", err)
 	}
-	machineList, _ := testutils.CreateMachineObjectList(masterNames, "ids", "master", testutils.DefaultRegionName, testutils.DefaultAzName)
-	objs := []runtime.Object{machineList}
-	mocks := testutils.NewTestMock(t, objs)
-
-	_, err := getClusterRegion(mocks.FakeKubeClient)
-	if err == nil {
-		t.Fatalf("Expected to get an error from not having an Infrastructure object")
+	if expected := []string{"eipalloc-free", "eipalloc-gone"}; !reflect.DeepEqual(released, expected) {
+		t.Fatalf("Test [Release] FAILED. Expected %v. Got %v", expected, released)
 	}
-	_, err = getMasterNodeSubnets(mocks.FakeKubeClient)
-	if err == nil {
-		t.Fatalf("Expected to get an error from not having an Infrastructure object")
+	if expected := []string{"eipalloc-free"}; !reflect.DeepEqual(mock.Released, expected) {
+		t.Fatalf("Test [Release] FAILED. Expected to release %v. Got %v", expected, mock.Released)
 	}
 }
 
-type mockRoute53Client struct {
-	route53iface.Route53API
+type mockDualStackNLB struct {
+	elbv2iface.ELBV2API
+	IPAddressType    string
+	SetIPAddressType string
 }
 
-func (m mockRoute53Client) ListResourceRecordSetsPages(input *route53.ListResourceRecordSetsInput, fn func(*route53.ListResourceRecordSetsOutput, bool) bool) error {
-	resps := []*route53.ListResourceRecordSetsOutput{
+func (m *mockDualStackNLB) DescribeLoadBalancers(_ *elbv2.DescribeLoadBalancersInput) (*elbv2.DescribeLoadBalancersOutput, error) {
+	return &elbv2.DescribeLoadBalancersOutput{
+		LoadBalancers: []*elbv2.LoadBalancer{
+			{
+				DNSName:               aws.String("a0123456789.elb.us-east-1.amazonaws.com"),
+				CanonicalHostedZoneId: aws.String("BBBBBBBBBB"),
+				LoadBalancerArn:       aws.String("arn:aws:elasticloadbalancing:us-east-1:123456789012:loadbalancer/net/a0123456789/abcdef"),
+				IpAddressType:         aws.String(m.IPAddressType),
+			},
+		},
+	}, nil
+}
+
+func (m *mockDualStackNLB) SetIpAddressType(i *elbv2.SetIpAddressTypeInput) (*elbv2.SetIpAddressTypeOutput, error) {
+	m.SetIPAddressType = aws.StringValue(i.IpAddressType)
+	return &elbv2.SetIpAddressTypeOutput{}, nil
+}
+
+func TestEnsureAdminAPIIPAddressType(t *testing.T) {
+	tests := []struct {
+		Name                string
+		Annotations         map[string]string
+		IPAddressType       string
+		ExpectedSet         string
+		ExpectedRecordTypes []string
+	}{
 		{
-			ResourceRecordSets: []*route53.ResourceRecordSet{
+			Name:                "ipv4 NLB is switched to dualstack",
+			Annotations:         map[string]string{nlbTypeAnnotationKey: "nlb", ipAddressTypeAnnotationKey: "dualstack"},
+			IPAddressType:       "ipv4",
+			ExpectedSet:         "dualstack",
+			ExpectedRecordTypes: []string{"A"},
+		},
+		{
+			Name:                "dualstack NLB is left alone",
+			Annotations:         map[string]string{nlbTypeAnnotationKey: "nlb", ipAddressTypeAnnotationKey: "dualstack"},
+			IPAddressType:       "dualstack",
+			ExpectedSet:         "",
+			ExpectedRecordTypes: []string{"A", "AAAA"},
+		},
+		{
+			Name:                "dualstack NLB is switched back to ipv4",
+			Annotations:         map[string]string{nlbTypeAnnotationKey: "nlb", ipAddressTypeAnnotationKey: "ipv4"},
+			IPAddressType:       "dualstack",
+			ExpectedSet:         "ipv4",
+			ExpectedRecordTypes: []string{"A", "AAAA"},
+		},
+		{
+			Name:                "NLB without an IP address type annotation is left alone",
+			Annotations:         map[string]string{nlbTypeAnnotationKey: "nlb"},
+			IPAddressType:       "ipv4",
+			ExpectedSet:         "",
+			ExpectedRecordTypes: []string{"A"},
+		},
+	}
+	for _, test := range tests {
+		mock := &mockDualStackNLB{IPAddressType: test.IPAddressType}
+		client := &Client{elbv2Client: mock}
+		svc := &corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{
+				Annotations: test.Annotations,
+				UID:         types.UID("01234567-89ab-cdef-0123-456789abcdef"),
+			},
+		}
+		// Looking the load balancer up changes nothing
+		lb, err := client.getLoadBalancerForService(svc)
+		if err != nil {
+			t.Fatalf("Test [%v] unexpected error %v", test.Name, err)
+		}
+		if mock.SetIPAddressType != "" {
+			t.Fatalf("Test [%v] FAILED. Expected the lookup to change nothing. Got IP address type set to %q", test.Name, mock.SetIPAddressType)
+		}
+		if !reflect.DeepEqual(lb.recordTypes(), test.ExpectedRecordTypes) {
+			t.Fatalf("Test [%v] FAILED. Expected record types %v. Got %v", test.Name, test.ExpectedRecordTypes, lb.recordTypes())
+		}
+		err = client.ensureAdminAPIIPAddressType(context.TODO(), nil, &cloudingressv1alpha1.APIScheme{}, svc)
+		if err != nil {
+			t.Fatalf("Test [%v] unexpected error %v", test.Name, err)
+		}
+		if mock.SetIPAddressType != test.ExpectedSet {
+			t.Fatalf("Test [%v] FAILED. Expected IP address type to be set to %q. Got %q", test.Name, test.ExpectedSet, mock.SetIPAddressType)
+		}
+	}
+}
+
+func TestSecurityGroupsAllow(t *testing.T) {
+	securityGroups := []*ec2.SecurityGroup{
+		{
+			IpPermissions: []*ec2.IpPermission{
 				{
-					Name: aws.String("rh-api.osd-cluster.org."),
-					Type: aws.String("A"),
-					AliasTarget: &route53.AliasTarget{
-						DNSName:              aws.String("abcdefgh.us-east-1.elb.amazon.com."),
-						EvaluateTargetHealth: aws.Bool(false),
-						HostedZoneId:         aws.String("AAAAAAAAAA"),
-					},
+					IpProtocol: aws.String("tcp"),
+					FromPort:   aws.Int64(6443),
+					ToPort:     aws.Int64(6443),
+					IpRanges:   []*ec2.IpRange{{CidrIp: aws.String("10.0.0.0/16")}},
 				},
 				{
-					Name: aws.String("api-osd-cluster.org."),
-					Type: aws.String("A"),
-					AliasTarget: &route53.AliasTarget{
-						DNSName:              aws.String("0123456.elb.us-east-1.amazonaws.com."),
-						EvaluateTargetHealth: aws.Bool(false),
-						HostedZoneId:         aws.String("BBBBBBBBBB"),
-					},
+					IpProtocol: aws.String("icmp"),
+					FromPort:   aws.Int64(3),
+					ToPort:     aws.Int64(4),
+					IpRanges:   []*ec2.IpRange{{CidrIp: aws.String("0.0.0.0/0")}},
 				},
 			},
-			IsTruncated: aws.Bool(false),
-			MaxItems:    aws.String("100"),
 		},
 	}
-	for _, resp := range resps {
-		if !fn(resp, true) {
-			break
+	tests := []struct {
+		Name     string
+		CIDR     string
+		Port     int64
+		Expected bool
+	}{
+		{Name: "Allowed CIDR and port", CIDR: "10.0.0.0/16", Port: 6443, Expected: true},
+		{Name: "Allowed CIDR on another port", CIDR: "10.0.0.0/16", Port: 22, Expected: false},
+		{Name: "CIDR only allowed for ICMP", CIDR: "0.0.0.0/0", Port: 6443, Expected: false},
+		{Name: "Unknown CIDR", CIDR: "192.168.0.0/24", Port: 6443, Expected: false},
+	}
+	for _, test := range tests {
+		if resp := securityGroupsAllow(securityGroups, test.CIDR, test.Port); resp != test.Expected {
+			t.Fatalf("Test [%v] FAILED. Expected %t. Got %t", test.Name, test.Expected, resp)
 		}
 	}
-	return nil
 }
 
-func TestRecordExists(t *testing.T) {
-	tests := []struct {
-		Name          string
-		Record        *route53.ResourceRecordSet // the record to check
-		Resp          bool
-		ErrResp       string
-		ErrorExpected bool
-	}{
-		{
-			Name: "Record should exist",
-			Record: &route53.ResourceRecordSet{
-				AliasTarget: &route53.AliasTarget{
-					DNSName:              aws.String("abcdefgh.us-east-1.elb.amazon.com."),
-					EvaluateTargetHealth: aws.Bool(false),
-					HostedZoneId:         aws.String("AAAAAAAAAA"),
-				},
-				Name: aws.String("rh-api.osd-cluster.org."),
-				Type: aws.String("A"),
-			},
-			Resp:          true,
-			ErrResp:       "",
-			ErrorExpected: false,
+type mockSecurityGroupIngress struct {
+	ec2iface.EC2API
+	Revoked      []*ec2.IpPermission
+	Authorized   []*ec2.IpPermission
+	Redescribed  []*ec2.IpPermission
+	AuthorizeErr error
+	// FailAuthorizeCall is the only call AuthorizeErr fails, counting from
+	// 1. Every call fails if it's 0
+	FailAuthorizeCall int
+	authorizeCalls    int
+}
+
+func (m *mockSecurityGroupIngress) RevokeSecurityGroupIngress(input *ec2.RevokeSecurityGroupIngressInput) (*ec2.RevokeSecurityGroupIngressOutput, error) {
+	m.Revoked = append(m.Revoked, input.IpPermissions...)
+	return &ec2.RevokeSecurityGroupIngressOutput{}, nil
+}
+
+func (m *mockSecurityGroupIngress) AuthorizeSecurityGroupIngress(input *ec2.AuthorizeSecurityGroupIngressInput) (*ec2.AuthorizeSecurityGroupIngressOutput, error) {
+	m.authorizeCalls++
+	if m.AuthorizeErr != nil && (m.FailAuthorizeCall == 0 || m.FailAuthorizeCall == m.authorizeCalls) {
+		return nil, m.AuthorizeErr
+	}
+	m.Authorized = append(m.Authorized, input.IpPermissions...)
+	return &ec2.AuthorizeSecurityGroupIngressOutput{}, nil
+}
+
+func (m *mockSecurityGroupIngress) UpdateSecurityGroupRuleDescriptionsIngress(input *ec2.UpdateSecurityGroupRuleDescriptionsIngressInput) (*ec2.UpdateSecurityGroupRuleDescriptionsIngressOutput, error) {
+	m.Redescribed = append(m.Redescribed, input.IpPermissions...)
+	return &ec2.UpdateSecurityGroupRuleDescriptionsIngressOutput{}, nil
+}
+
+func TestSetSecurityGroupIngress(t *testing.T) {
+	sg := &ec2.SecurityGroup{
+		GroupId: aws.String("sg-rhapi"),
+		IpPermissions: []*ec2.IpPermission{
+			{
+				IpProtocol: aws.String("tcp"),
+				FromPort:   aws.Int64(6443),
+				ToPort:     aws.Int64(6443),
+				IpRanges:   []*ec2.IpRange{{CidrIp: aws.String("10.0.0.0/16")}, {CidrIp: aws.String("0.0.0.0/0")}},
+			},
+			{
+				IpProtocol: aws.String("tcp"),
+				FromPort:   aws.Int64(22),
+				ToPort:     aws.Int64(22),
+				IpRanges:   []*ec2.IpRange{{CidrIp: aws.String("10.0.0.0/16")}},
+			},
 		},
+	}
+	mock := &mockSecurityGroupIngress{}
+	client := &Client{ec2Client: mock}
+	err := client.setSecurityGroupIngress(sg, []string{"10.0.0.0/16", "192.168.0.0/24"}, map[string]string{
+		"10.0.0.0/16":    "ticket=OHSS-1 requester=jdoe",
+		"192.168.0.0/24": "ticket=OHSS-2 requester=jdoe",
+	}, []int64{6443})
+	if err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+
+	expectedRevoked := []*ec2.IpPermission{
 		{
-			Name: "Record with non FDQN name should still exist",
-			Record: &route53.ResourceRecordSet{
-				AliasTarget: &route53.AliasTarget{
-					DNSName:              aws.String("abcdefgh.us-east-1.elb.amazon.com"),
-					EvaluateTargetHealth: aws.Bool(false),
-					HostedZoneId:         aws.String("AAAAAAAAAA"),
-				},
-				Name: aws.String("rh-api.osd-cluster.org"),
-				Type: aws.String("A"),
-			},
-			Resp:          true,
-			ErrResp:       "",
-			ErrorExpected: false,
+			IpProtocol: aws.String("tcp"),
+			FromPort:   aws.Int64(6443),
+			ToPort:     aws.Int64(6443),
+			IpRanges:   []*ec2.IpRange{{CidrIp: aws.String("0.0.0.0/0")}},
 		},
+		sg.IpPermissions[1],
+	}
+	if !reflect.DeepEqual(mock.Revoked, expectedRevoked) {
+		t.Fatalf("Expected to revoke %v. Got %v", expectedRevoked, mock.Revoked)
+	}
+	expectedAuthorized := []*ec2.IpPermission{
 		{
-			Name: "Record shouldn't exist",
-			Record: &route53.ResourceRecordSet{
-				AliasTarget: &route53.AliasTarget{
-					DNSName:              aws.String("abcdefgh.us-east-1.elb.amazon.com."),
-					EvaluateTargetHealth: aws.Bool(false),
-					HostedZoneId:         aws.String("AAAAAAAAAA"),
-				},
-				Name: aws.String("rh-ssh.osd-cluster.org."),
-				Type: aws.String("A"),
-			},
-			Resp:          false,
-			ErrResp:       "",
-			ErrorExpected: false,
+			IpProtocol: aws.String("tcp"),
+			FromPort:   aws.Int64(6443),
+			ToPort:     aws.Int64(6443),
+			IpRanges:   []*ec2.IpRange{{CidrIp: aws.String("192.168.0.0/24"), Description: aws.String("ticket=OHSS-2 requester=jdoe")}},
 		},
+	}
+	if !reflect.DeepEqual(mock.Authorized, expectedAuthorized) {
+		t.Fatalf("Expected to authorize %v. Got %v", expectedAuthorized, mock.Authorized)
+	}
+	expectedRedescribed := []*ec2.IpPermission{
 		{
-			Name: "Record with matching Name but missmatched Type shouldn't exist",
-			Record: &route53.ResourceRecordSet{
-				AliasTarget: &route53.AliasTarget{
-					DNSName:              aws.String("abcdefgh.us-east-1.elb.amazon.com."),
-					EvaluateTargetHealth: aws.Bool(false),
-					HostedZoneId:         aws.String("AAAAAAAAAA"),
-				},
-				Name: aws.String("rh-ssh.osd-cluster.org."),
-				Type: aws.String("AAAA"),
+			IpProtocol: aws.String("tcp"),
+			FromPort:   aws.Int64(6443),
+			ToPort:     aws.Int64(6443),
+			IpRanges:   []*ec2.IpRange{{CidrIp: aws.String("10.0.0.0/16"), Description: aws.String("ticket=OHSS-1 requester=jdoe")}},
+		},
+	}
+	if !reflect.DeepEqual(mock.Redescribed, expectedRedescribed) {
+		t.Fatalf("Expected to update the descriptions %v. Got %v", expectedRedescribed, mock.Redescribed)
+	}
+}
+
+func TestSetSecurityGroupIngressRuleLimit(t *testing.T) {
+	sg := &ec2.SecurityGroup{GroupId: aws.String("sg-rhapi")}
+	mock := &mockSecurityGroupIngress{
+		AuthorizeErr: awserr.New("RulesPerSecurityGroupLimitExceeded", "The maximum number of rules per security group has been reached.", nil),
+	}
+	client := &Client{ec2Client: mock}
+	err := client.setSecurityGroupIngress(sg, []string{"10.0.0.0/16"}, map[string]string{}, []int64{6443})
+	actionRequired, ok := err.(*cioerrors.ActionRequiredError)
+	if !ok {
+		t.Fatalf("Expected an ActionRequiredError. Got %v", err)
+	}
+	if actionRequired.Reason != cloudingressv1alpha1.ActionRequiredSecurityGroupRuleLimit {
+		t.Fatalf("Expected reason %v. Got %v", cloudingressv1alpha1.ActionRequiredSecurityGroupRuleLimit, actionRequired.Reason)
+	}
+}
+
+func TestSetSecurityGroupIngressReverted(t *testing.T) {
+	defer func(size int) { securityGroupRuleBatchSize = size }(securityGroupRuleBatchSize)
+	securityGroupRuleBatchSize = 2
+
+	open := &ec2.IpPermission{
+		IpProtocol: aws.String("tcp"),
+		FromPort:   aws.Int64(6443),
+		ToPort:     aws.Int64(6443),
+		IpRanges:   []*ec2.IpRange{{CidrIp: aws.String("0.0.0.0/0")}},
+	}
+	sg := &ec2.SecurityGroup{GroupId: aws.String("sg-rhapi"), IpPermissions: []*ec2.IpPermission{open}}
+	mock := &mockSecurityGroupIngress{
+		AuthorizeErr:      awserr.New("InvalidParameterValue", "Test", nil),
+		FailAuthorizeCall: 2,
+	}
+	client := &Client{ec2Client: mock}
+	err := client.setSecurityGroupIngress(sg, []string{"10.0.0.0/16", "192.168.0.0/24", "172.16.0.0/12"}, map[string]string{}, []int64{6443})
+	reverted, ok := err.(*cioerrors.SecurityGroupRulesRevertedError)
+	if !ok || !reverted.Reverted {
+		t.Fatalf("Expected a reverted SecurityGroupRulesRevertedError. Got %v", err)
+	}
+
+	firstBatch := &ec2.IpPermission{
+		IpProtocol: aws.String("tcp"),
+		FromPort:   aws.Int64(6443),
+		ToPort:     aws.Int64(6443),
+		IpRanges:   []*ec2.IpRange{{CidrIp: aws.String("10.0.0.0/16")}, {CidrIp: aws.String("192.168.0.0/24")}},
+	}
+	// The first batch is revoked again, and the rule revoked before it
+	// authorized again
+	expectedRevoked := []*ec2.IpPermission{open, firstBatch}
+	if !reflect.DeepEqual(mock.Revoked, expectedRevoked) {
+		t.Fatalf("Expected to revoke %v. Got %v", expectedRevoked, mock.Revoked)
+	}
+	expectedAuthorized := []*ec2.IpPermission{firstBatch, open}
+	if !reflect.DeepEqual(mock.Authorized, expectedAuthorized) {
+		t.Fatalf("Expected to authorize %v. Got %v", expectedAuthorized, mock.Authorized)
+	}
+}
+
+func TestBatchIPPermissions(t *testing.T) {
+	ipRanges := func(cidrs ...string) []*ec2.IpRange {
+		ranges := []*ec2.IpRange{}
+		for _, cidr := range cidrs {
+			ranges = append(ranges, &ec2.IpRange{CidrIp: aws.String(cidr)})
+		}
+		return ranges
+	}
+	permission := func(port int64, ranges []*ec2.IpRange) *ec2.IpPermission {
+		return &ec2.IpPermission{IpProtocol: aws.String("tcp"), FromPort: aws.Int64(port), ToPort: aws.Int64(port), IpRanges: ranges}
+	}
+	tests := []struct {
+		Name        string
+		Permissions []*ec2.IpPermission
+		Expected    [][]*ec2.IpPermission
+	}{
+		{
+			Name:        "Nothing",
+			Permissions: []*ec2.IpPermission{},
+			Expected:    [][]*ec2.IpPermission{},
+		},
+		{
+			Name:        "One batch",
+			Permissions: []*ec2.IpPermission{permission(6443, ipRanges("10.0.0.0/16")), permission(22, ipRanges("10.0.0.0/16"))},
+			Expected:    [][]*ec2.IpPermission{{permission(6443, ipRanges("10.0.0.0/16")), permission(22, ipRanges("10.0.0.0/16"))}},
+		},
+		{
+			Name:        "CIDR blocks split",
+			Permissions: []*ec2.IpPermission{permission(6443, ipRanges("10.0.0.0/16", "192.168.0.0/24", "172.16.0.0/12"))},
+			Expected: [][]*ec2.IpPermission{
+				{permission(6443, ipRanges("10.0.0.0/16", "192.168.0.0/24"))},
+				{permission(6443, ipRanges("172.16.0.0/12"))},
 			},
-			Resp:          false,
-			ErrResp:       "",
-			ErrorExpected: false,
 		},
 		{
-			Name: "Record with matching Name and Type, but no AliasTarget, shoudn't exist",
-			Record: &route53.ResourceRecordSet{
-				Name: aws.String("rh-ssh.osd-cluster.org."),
-				Type: aws.String("A"),
+			Name:        "Permissions split",
+			Permissions: []*ec2.IpPermission{permission(6443, ipRanges("10.0.0.0/16")), permission(22, ipRanges("10.0.0.0/16", "192.168.0.0/24"))},
+			Expected: [][]*ec2.IpPermission{
+				{permission(6443, ipRanges("10.0.0.0/16"))},
+				{permission(22, ipRanges("10.0.0.0/16", "192.168.0.0/24"))},
 			},
-			Resp:          false,
-			ErrResp:       "",
-			ErrorExpected: false,
 		},
+	}
+	for _, test := range tests {
+		batches := batchIPPermissions(test.Permissions, 2)
+		if !reflect.DeepEqual(batches, test.Expected) {
+			t.Fatalf("Test [%v] FAILED. Expected %v. Got %v", test.Name, test.Expected, batches)
+		}
+	}
+}
+
+type mockAdoptSecurityGroup struct {
+	ec2iface.EC2API
+	SecurityGroups []*ec2.SecurityGroup
+	Tagged         []*ec2.Tag
+}
+
+func (m *mockAdoptSecurityGroup) DescribeSecurityGroups(_ *ec2.DescribeSecurityGroupsInput) (*ec2.DescribeSecurityGroupsOutput, error) {
+	return &ec2.DescribeSecurityGroupsOutput{SecurityGroups: m.SecurityGroups}, nil
+}
+
+func (m *mockAdoptSecurityGroup) CreateTags(input *ec2.CreateTagsInput) (*ec2.CreateTagsOutput, error) {
+	m.Tagged = append(m.Tagged, input.Tags...)
+	return &ec2.CreateTagsOutput{}, nil
+}
+
+func TestAdoptSecurityGroup(t *testing.T) {
+	tests := []struct {
+		Name           string
+		SecurityGroups []*ec2.SecurityGroup
+		ErrorExpected  bool
+		Found          bool
+		Tagged         bool
+	}{
 		{
-			Name: "Record with matching Name and Type, but missmatched AliasTarget.EvaluateTargetHealth , shoudn't exist",
-			Record: &route53.ResourceRecordSet{
-				AliasTarget: &route53.AliasTarget{
-					DNSName:              aws.String("abcdefgh.us-east-1.elb.amazon.com."),
-					EvaluateTargetHealth: aws.Bool(true),
-					HostedZoneId:         aws.String("AAAAAAAAAA"),
+			Name: "No security group",
+		},
+		{
+			Name: "Owned by the cluster",
+			SecurityGroups: []*ec2.SecurityGroup{
+				{
+					GroupId: aws.String("sg-rhapi"),
+					Tags:    []*ec2.Tag{{Key: aws.String("kubernetes.io/cluster/test-cluster"), Value: aws.String("owned")}},
 				},
-				Name: aws.String("rh-ssh.osd-cluster.org."),
-				Type: aws.String("A"),
 			},
-			Resp:          false,
-			ErrResp:       "",
-			ErrorExpected: false,
+			Found: true,
 		},
 		{
-			Name:          "nil Record should error",
-			Record:        nil,
-			Resp:          false,
-			ErrResp:       "resourceRecordSet can't be nil",
-			ErrorExpected: true,
+			Name: "Left behind without tags",
+			SecurityGroups: []*ec2.SecurityGroup{
+				{GroupId: aws.String("sg-rhapi")},
+			},
+			Found:  true,
+			Tagged: true,
 		},
 		{
-			Name: "nil Record.Name should error",
-			Record: &route53.ResourceRecordSet{
-				AliasTarget: &route53.AliasTarget{
-					DNSName:              aws.String("abcdefgh.us-east-1.elb.amazon.com."),
-					EvaluateTargetHealth: aws.Bool(false),
-					HostedZoneId:         aws.String("AAAAAAAAAA"),
+			Name: "Owned by another cluster",
+			SecurityGroups: []*ec2.SecurityGroup{
+				{
+					GroupId: aws.String("sg-rhapi"),
+					Tags:    []*ec2.Tag{{Key: aws.String("kubernetes.io/cluster/other-cluster"), Value: aws.String("owned")}},
 				},
-				Name: nil,
-				Type: aws.String("A"),
 			},
-			Resp:          false,
-			ErrResp:       "resourceRecordSet Name is required",
 			ErrorExpected: true,
 		},
 	}
+	for _, test := range tests {
+		mock := &mockAdoptSecurityGroup{SecurityGroups: test.SecurityGroups}
+		client := &Client{ec2Client: mock}
+		sg, err := client.adoptSecurityGroup("test-cluster", "test-cluster-rh-api", "vpc-1")
+		if err == nil && test.ErrorExpected || err != nil && !test.ErrorExpected {
+			t.Fatalf("Test [%v] FAILED. Expect error? %t: Return %v", test.Name, test.ErrorExpected, err)
+		}
+		if (sg != nil) != test.Found {
+			t.Fatalf("Test [%v] FAILED. Expected a security group? %t. Got %v", test.Name, test.Found, sg)
+		}
+		if (len(mock.Tagged) > 0) != test.Tagged {
+			t.Fatalf("Test [%v] FAILED. Expected tags? %t. Got %v", test.Name, test.Tagged, mock.Tagged)
+		}
+		if test.Tagged && !clusterOwners(mock.Tagged)["test-cluster"] {
+			t.Fatalf("Test [%v] FAILED. Expected the owned tag. Got %v", test.Name, mock.Tagged)
+		}
+	}
+}
+
+type mockClassicELBSecurityGroups struct {
+	elbiface.ELBAPI
+	SecurityGroups []*string
+	Applied        []*string
+}
+
+func (m *mockClassicELBSecurityGroups) DescribeLoadBalancers(_ *elb.DescribeLoadBalancersInput) (*elb.DescribeLoadBalancersOutput, error) {
+	if m.SecurityGroups == nil {
+		return nil, awserr.New(elb.ErrCodeAccessPointNotFoundException, "not found", nil)
+	}
+	return &elb.DescribeLoadBalancersOutput{
+		LoadBalancerDescriptions: []*elb.LoadBalancerDescription{{SecurityGroups: m.SecurityGroups}},
+	}, nil
+}
+
+func (m *mockClassicELBSecurityGroups) ApplySecurityGroupsToLoadBalancer(i *elb.ApplySecurityGroupsToLoadBalancerInput) (*elb.ApplySecurityGroupsToLoadBalancerOutput, error) {
+	m.Applied = i.SecurityGroups
+	return &elb.ApplySecurityGroupsToLoadBalancerOutput{SecurityGroups: i.SecurityGroups}, nil
+}
 
+func TestApplyClassicELBSecurityGroups(t *testing.T) {
+	tests := []struct {
+		Name           string
+		SecurityGroups []*string
+		Expected       []*string
+	}{
+		{
+			Name:           "Default security group is replaced",
+			SecurityGroups: aws.StringSlice([]string{"sg-default"}),
+			Expected:       aws.StringSlice([]string{"sg-rhapi"}),
+		},
+		{
+			Name:           "Extra security group is removed",
+			SecurityGroups: aws.StringSlice([]string{"sg-rhapi", "sg-extra"}),
+			Expected:       aws.StringSlice([]string{"sg-rhapi"}),
+		},
+		{
+			Name:           "Matching security group is left alone",
+			SecurityGroups: aws.StringSlice([]string{"sg-rhapi"}),
+		},
+		{
+			Name: "ELB not created yet",
+		},
+	}
 	for _, test := range tests {
-		client := &Client{
-			route53Client: mockRoute53Client{},
+		mock := &mockClassicELBSecurityGroups{SecurityGroups: test.SecurityGroups}
+		client := &Client{elbClient: mock}
+		err := client.applyClassicELBSecurityGroups("a0123456789", []string{"sg-rhapi"})
+		if err != nil {
+			t.Fatalf("Test [%v] unexpected error %v", test.Name, err)
 		}
-		resp, err := client.recordExists(test.Record, "publicHostedZoneID")
+		if !reflect.DeepEqual(mock.Applied, test.Expected) {
+			t.Fatalf("Test [%v] FAILED. Expected to apply %v. Got %v", test.Name, aws.StringValueSlice(test.Expected), aws.StringValueSlice(mock.Applied))
+		}
+	}
+}
 
-		if err == nil && test.ErrorExpected || err != nil && !test.ErrorExpected {
-			t.Fatalf("Test [%v] return mismatch. Expect error? %t: Return %+v", test.Name, test.ErrorExpected, err)
+type mockDescribeInstancesCount struct {
+	ec2iface.EC2API
+	Calls int
+}
+
+func (m *mockDescribeInstancesCount) DescribeInstances(_ *ec2.DescribeInstancesInput) (*ec2.DescribeInstancesOutput, error) {
+	m.Calls++
+	return &ec2.DescribeInstancesOutput{
+		Reservations: []*ec2.Reservation{{Instances: []*ec2.Instance{{VpcId: aws.String("vpc-1")}}}},
+	}, nil
+}
+
+func TestDescribeCache(t *testing.T) {
+	mock := &mockDescribeInstancesCount{}
+	cache := newDescribeCache(time.Minute)
+	client := cachedEC2{EC2API: mock, cache: cache}
+	input := &ec2.DescribeInstancesInput{InstanceIds: []*string{aws.String("i-1")}}
+
+	for i := 0; i < 3; i++ {
+		output, err := client.DescribeInstances(input)
+		if err != nil {
+			t.Fatalf("Unexpected error %v", err)
 		}
-		if err != nil && test.ErrorExpected && err.Error() != test.ErrResp {
-			t.Fatalf("Test [%v] FAILED. Excepted Error %v. Got %v", test.Name, test.ErrResp, err.Error())
+		// Changing a result mustn't change the cached one
+		output.Reservations[0].Instances[0].VpcId = aws.String("vpc-changed")
+	}
+	if mock.Calls != 1 {
+		t.Fatalf("Expected 1 call for the same input. Got %d", mock.Calls)
+	}
+	output, _ := client.DescribeInstances(&ec2.DescribeInstancesInput{InstanceIds: []*string{aws.String("i-1")}})
+	if vpcID := aws.StringValue(output.Reservations[0].Instances[0].VpcId); vpcID != "vpc-1" {
+		t.Fatalf("Expected the cached result to be vpc-1. Got %s", vpcID)
+	}
+
+	_, _ = client.DescribeInstances(&ec2.DescribeInstancesInput{InstanceIds: []*string{aws.String("i-2")}})
+	if mock.Calls != 2 {
+		t.Fatalf("Expected another call for another input. Got %d calls", mock.Calls)
+	}
+
+	cache.invalidateOnChange(&request.Request{Operation: &request.Operation{Name: "DescribeSubnets"}})
+	_, _ = client.DescribeInstances(input)
+	if mock.Calls != 2 {
+		t.Fatalf("Expected a read-only call to keep the cache. Got %d calls", mock.Calls)
+	}
+	cache.invalidateOnChange(&request.Request{Operation: &request.Operation{Name: "CreateTags"}})
+	_, _ = client.DescribeInstances(input)
+	if mock.Calls != 3 {
+		t.Fatalf("Expected a change to empty the cache. Got %d calls", mock.Calls)
+	}
+}
+
+type mockNLBAttributes struct {
+	elbv2iface.ELBV2API
+	Attributes []*elbv2.LoadBalancerAttribute
+	Modified   []*elbv2.LoadBalancerAttribute
+}
+
+func (m *mockNLBAttributes) DescribeLoadBalancerAttributes(_ *elbv2.DescribeLoadBalancerAttributesInput) (*elbv2.DescribeLoadBalancerAttributesOutput, error) {
+	return &elbv2.DescribeLoadBalancerAttributesOutput{Attributes: m.Attributes}, nil
+}
+
+func (m *mockNLBAttributes) ModifyLoadBalancerAttributes(i *elbv2.ModifyLoadBalancerAttributesInput) (*elbv2.ModifyLoadBalancerAttributesOutput, error) {
+	m.Modified = append(m.Modified, i.Attributes...)
+	return &elbv2.ModifyLoadBalancerAttributesOutput{}, nil
+}
+
+func TestSetNLBAccessLogs(t *testing.T) {
+	attribute := func(key, value string) *elbv2.LoadBalancerAttribute {
+		return &elbv2.LoadBalancerAttribute{Key: aws.String(key), Value: aws.String(value)}
+	}
+	disabled := []*elbv2.LoadBalancerAttribute{
+		attribute("access_logs.s3.enabled", "false"),
+		attribute("access_logs.s3.bucket", ""),
+		attribute("access_logs.s3.prefix", ""),
+	}
+	enabled := []*elbv2.LoadBalancerAttribute{
+		attribute("access_logs.s3.enabled", "true"),
+		attribute("access_logs.s3.bucket", "audit"),
+		attribute("access_logs.s3.prefix", ""),
+	}
+	tests := []struct {
+		Name       string
+		AccessLogs *cloudingressv1alpha1.AccessLogs
+		Attributes []*elbv2.LoadBalancerAttribute
+		Expected   []*elbv2.LoadBalancerAttribute
+	}{
+		{
+			Name:       "Access logs are turned on",
+			AccessLogs: &cloudingressv1alpha1.AccessLogs{S3BucketName: "audit", S3BucketPrefix: "rh-api"},
+			Attributes: disabled,
+			Expected: []*elbv2.LoadBalancerAttribute{
+				attribute("access_logs.s3.enabled", "true"),
+				attribute("access_logs.s3.bucket", "audit"),
+				attribute("access_logs.s3.prefix", "rh-api"),
+			},
+		},
+		{
+			Name:       "Matching access logs are left alone",
+			AccessLogs: &cloudingressv1alpha1.AccessLogs{S3BucketName: "audit"},
+			Attributes: enabled,
+			Expected:   nil,
+		},
+		{
+			Name:       "Access logs are turned off",
+			Attributes: enabled,
+			Expected:   []*elbv2.LoadBalancerAttribute{attribute("access_logs.s3.enabled", "false")},
+		},
+	}
+	for _, test := range tests {
+		mock := &mockNLBAttributes{Attributes: test.Attributes}
+		client := &Client{elbv2Client: mock, s3Client: &mockAccessLogBucket{Policy: nlbBucketPolicy}}
+		err := client.setNLBAccessLogs("arn:aws:elasticloadbalancing:us-east-1:123456789012:loadbalancer/net/a0123456789/abcdef", test.AccessLogs)
+		if err != nil {
+			t.Fatalf("Test [%v] unexpected error %v", test.Name, err)
 		}
-		if resp != test.Resp {
-			t.Fatalf("Test [%v] FAILED. Excepted Response %v. Got %v", test.Name, test.Resp, resp)
+		if !reflect.DeepEqual(mock.Modified, test.Expected) {
+			t.Fatalf("Test [%v] FAILED. Expected to modify %v. Got %v", test.Name, test.Expected, mock.Modified)
 		}
+	}
+}
+
+const nlbBucketPolicy = `{
+  "Version": "2012-10-17",
+  "Statement": [
+    {
+      "Effect": "Deny",
+      "Principal": "*",
+      "Action": "s3:*",
+      "Resource": "arn:aws:s3:::audit/*",
+      "Condition": {"Bool": {"aws:SecureTransport": "false"}}
+    },
+    {
+      "Effect": "Allow",
+      "Principal": {"Service": "delivery.logs.amazonaws.com"},
+      "Action": "s3:PutObject",
+      "Resource": "arn:aws:s3:::audit/rh-api/AWSLogs/123456789012/*"
+    }
+  ]
+}`
+
+type mockAccessLogBucket struct {
+	s3iface.S3API
+	// SSEAlgorithm and KMSMasterKeyID are the default encryption of the
+	// bucket, which has none if SSEAlgorithm is empty
+	SSEAlgorithm   string
+	KMSMasterKeyID string
+	// Policy is the bucket policy, which has none if it's empty
+	Policy string
+}
+
+func (m *mockAccessLogBucket) GetBucketEncryption(_ *s3.GetBucketEncryptionInput) (*s3.GetBucketEncryptionOutput, error) {
+	if m.SSEAlgorithm == "" {
+		return nil, awserr.New("ServerSideEncryptionConfigurationNotFoundError", "The server side encryption configuration was not found", nil)
+	}
+	return &s3.GetBucketEncryptionOutput{
+		ServerSideEncryptionConfiguration: &s3.ServerSideEncryptionConfiguration{
+			Rules: []*s3.ServerSideEncryptionRule{
+				{
+					ApplyServerSideEncryptionByDefault: &s3.ServerSideEncryptionByDefault{
+						SSEAlgorithm:   aws.String(m.SSEAlgorithm),
+						KMSMasterKeyID: aws.String(m.KMSMasterKeyID),
+					},
+				},
+			},
+		},
+	}, nil
+}
+
+func (m *mockAccessLogBucket) GetBucketPolicy(_ *s3.GetBucketPolicyInput) (*s3.GetBucketPolicyOutput, error) {
+	if m.Policy == "" {
+		return nil, awserr.New("NoSuchBucketPolicy", "The bucket policy does not exist", nil)
+	}
+	return &s3.GetBucketPolicyOutput{Policy: aws.String(m.Policy)}, nil
+}
+
+type mockAccessLogKey struct {
+	kmsiface.KMSAPI
+	KeyManager string
+	Policy     string
+	Grantees   []string
+}
+
+func (m *mockAccessLogKey) DescribeKey(_ *kms.DescribeKeyInput) (*kms.DescribeKeyOutput, error) {
+	return &kms.DescribeKeyOutput{
+		KeyMetadata: &kms.KeyMetadata{
+			Arn:        aws.String("arn:aws:kms:us-east-1:123456789012:key/1234abcd"),
+			KeyId:      aws.String("1234abcd"),
+			KeyManager: aws.String(m.KeyManager),
+			KeyState:   aws.String(kms.KeyStateEnabled),
+		},
+	}, nil
+}
+
+func (m *mockAccessLogKey) GetKeyPolicy(_ *kms.GetKeyPolicyInput) (*kms.GetKeyPolicyOutput, error) {
+	return &kms.GetKeyPolicyOutput{Policy: aws.String(m.Policy)}, nil
+}
 
+func (m *mockAccessLogKey) ListGrantsPages(_ *kms.ListGrantsInput, fn func(*kms.ListGrantsResponse, bool) bool) error {
+	page := &kms.ListGrantsResponse{}
+	for _, grantee := range m.Grantees {
+		page.Grants = append(page.Grants, &kms.GrantListEntry{
+			GranteePrincipal: aws.String(grantee),
+			Operations:       aws.StringSlice([]string{kms.GrantOperationEncrypt, kms.GrantOperationGenerateDataKey}),
+		})
 	}
+	fn(page, true)
+	return nil
+}
 
+func TestCheckAccessLogBucket(t *testing.T) {
+	rootOnlyKeyPolicy := `{"Statement": [{"Effect": "Allow", "Principal": {"AWS": "arn:aws:iam::123456789012:root"}, "Action": "kms:*", "Resource": "*"}]}`
+	deliveryKeyPolicy := `{"Statement": [{"Effect": "Allow", "Principal": {"Service": "delivery.logs.amazonaws.com"}, "Action": ["kms:Encrypt", "kms:GenerateDataKey*"], "Resource": "*"}]}`
+	classicBucketPolicy := `{"Statement": {"Effect": "Allow", "Principal": {"AWS": "arn:aws:iam::127311923021:root"}, "Action": "s3:PutObject", "Resource": "arn:aws:s3:::audit/*"}}`
+	tests := []struct {
+		Name          string
+		NLB           bool
+		Bucket        *mockAccessLogBucket
+		Key           *mockAccessLogKey
+		ExpectFailure bool
+	}{
+		{
+			Name:   "Unencrypted bucket the ELB account can write to",
+			Bucket: &mockAccessLogBucket{Policy: classicBucketPolicy},
+		},
+		{
+			Name:          "No bucket policy",
+			Bucket:        &mockAccessLogBucket{SSEAlgorithm: s3.ServerSideEncryptionAes256},
+			ExpectFailure: true,
+		},
+		{
+			Name:          "Bucket policy for the classic ELB account on a network load balancer",
+			NLB:           true,
+			Bucket:        &mockAccessLogBucket{Policy: classicBucketPolicy},
+			ExpectFailure: true,
+		},
+		{
+			Name:          "SSE-KMS bucket of a classic ELB",
+			Bucket:        &mockAccessLogBucket{SSEAlgorithm: s3.ServerSideEncryptionAwsKms, KMSMasterKeyID: "1234abcd", Policy: classicBucketPolicy},
+			Key:           &mockAccessLogKey{KeyManager: kms.KeyManagerTypeCustomer, Policy: deliveryKeyPolicy},
+			ExpectFailure: true,
+		},
+		{
+			Name:          "SSE-KMS bucket with the AWS managed key",
+			NLB:           true,
+			Bucket:        &mockAccessLogBucket{SSEAlgorithm: s3.ServerSideEncryptionAwsKms, Policy: nlbBucketPolicy},
+			ExpectFailure: true,
+		},
+		{
+			Name:   "SSE-KMS bucket with a key policy for log delivery",
+			NLB:    true,
+			Bucket: &mockAccessLogBucket{SSEAlgorithm: s3.ServerSideEncryptionAwsKms, KMSMasterKeyID: "1234abcd", Policy: nlbBucketPolicy},
+			Key:    &mockAccessLogKey{KeyManager: kms.KeyManagerTypeCustomer, Policy: deliveryKeyPolicy},
+		},
+		{
+			Name:   "SSE-KMS bucket with a grant for log delivery",
+			NLB:    true,
+			Bucket: &mockAccessLogBucket{SSEAlgorithm: s3.ServerSideEncryptionAwsKms, KMSMasterKeyID: "1234abcd", Policy: nlbBucketPolicy},
+			Key:    &mockAccessLogKey{KeyManager: kms.KeyManagerTypeCustomer, Policy: rootOnlyKeyPolicy, Grantees: []string{"delivery.logs.amazonaws.com"}},
+		},
+		{
+			Name:          "SSE-KMS bucket whose key log delivery can't use",
+			NLB:           true,
+			Bucket:        &mockAccessLogBucket{SSEAlgorithm: s3.ServerSideEncryptionAwsKms, KMSMasterKeyID: "1234abcd", Policy: nlbBucketPolicy},
+			Key:           &mockAccessLogKey{KeyManager: kms.KeyManagerTypeCustomer, Policy: rootOnlyKeyPolicy},
+			ExpectFailure: true,
+		},
+	}
+	accessLogs := &cloudingressv1alpha1.AccessLogs{S3BucketName: "audit", S3BucketPrefix: "rh-api"}
+	for _, test := range tests {
+		client := &Client{s3Client: test.Bucket, kmsClient: test.Key, partition: "aws"}
+		err := client.checkAccessLogBucket(accessLogs, test.NLB)
+		_, failed := err.(*cioerrors.PreconditionFailedError)
+		if err != nil && !failed {
+			t.Fatalf("Test [%v] unexpected error %v", test.Name, err)
+		}
+		if failed != test.ExpectFailure {
+			t.Fatalf("Test [%v] FAILED. Expected a failed precondition %v. Got %v", test.Name, test.ExpectFailure, err)
+		}
+	}
+}
+
+type mockTargetGroupAttributes struct {
+	elbv2iface.ELBV2API
+	Attributes []*elbv2.TargetGroupAttribute
+	Modified   []*elbv2.TargetGroupAttribute
+}
+
+func (m *mockTargetGroupAttributes) DescribeTargetGroups(_ *elbv2.DescribeTargetGroupsInput) (*elbv2.DescribeTargetGroupsOutput, error) {
+	return &elbv2.DescribeTargetGroupsOutput{
+		TargetGroups: []*elbv2.TargetGroup{{TargetGroupArn: aws.String("arn:aws:elasticloadbalancing:us-east-1:123456789012:targetgroup/k8s-6443/abcdef")}},
+	}, nil
+}
+
+func (m *mockTargetGroupAttributes) DescribeTargetGroupAttributes(_ *elbv2.DescribeTargetGroupAttributesInput) (*elbv2.DescribeTargetGroupAttributesOutput, error) {
+	return &elbv2.DescribeTargetGroupAttributesOutput{Attributes: m.Attributes}, nil
+}
+
+func (m *mockTargetGroupAttributes) ModifyTargetGroupAttributes(i *elbv2.ModifyTargetGroupAttributesInput) (*elbv2.ModifyTargetGroupAttributesOutput, error) {
+	m.Modified = append(m.Modified, i.Attributes...)
+	return &elbv2.ModifyTargetGroupAttributesOutput{}, nil
+}
+
+func TestSetNLBStickiness(t *testing.T) {
+	attribute := func(key, value string) *elbv2.TargetGroupAttribute {
+		return &elbv2.TargetGroupAttribute{Key: aws.String(key), Value: aws.String(value)}
+	}
+	defaults := []*elbv2.TargetGroupAttribute{
+		attribute("stickiness.enabled", "false"),
+		attribute("stickiness.type", "source_ip"),
+		attribute("preserve_client_ip.enabled", "true"),
+	}
+	sticky := []*elbv2.TargetGroupAttribute{
+		attribute("stickiness.enabled", "true"),
+		attribute("stickiness.type", "source_ip"),
+		attribute("preserve_client_ip.enabled", "true"),
+	}
+	tests := []struct {
+		Name       string
+		Stickiness *cloudingressv1alpha1.Stickiness
+		Attributes []*elbv2.TargetGroupAttribute
+		Expected   []*elbv2.TargetGroupAttribute
+	}{
+		{
+			Name:       "Stickiness is turned on",
+			Stickiness: &cloudingressv1alpha1.Stickiness{SourceIP: true},
+			Attributes: defaults,
+			Expected:   []*elbv2.TargetGroupAttribute{attribute("stickiness.enabled", "true")},
+		},
+		{
+			Name:       "Matching stickiness is left alone",
+			Stickiness: &cloudingressv1alpha1.Stickiness{SourceIP: true, PreserveClientIP: aws.Bool(true)},
+			Attributes: sticky,
+			Expected:   nil,
+		},
+		{
+			Name:       "Stickiness is turned off and client IPs aren't preserved",
+			Stickiness: &cloudingressv1alpha1.Stickiness{PreserveClientIP: aws.Bool(false)},
+			Attributes: sticky,
+			Expected: []*elbv2.TargetGroupAttribute{
+				attribute("stickiness.enabled", "false"),
+				attribute("preserve_client_ip.enabled", "false"),
+			},
+		},
+	}
+	for _, test := range tests {
+		mock := &mockTargetGroupAttributes{Attributes: test.Attributes}
+		client := &Client{elbv2Client: mock}
+		err := client.setNLBStickiness("arn:aws:elasticloadbalancing:us-east-1:123456789012:loadbalancer/net/a0123456789/abcdef", test.Stickiness)
+		if err != nil {
+			t.Fatalf("Test [%v] unexpected error %v", test.Name, err)
+		}
+		if !reflect.DeepEqual(mock.Modified, test.Expected) {
+			t.Fatalf("Test [%v] FAILED. Expected to modify %v. Got %v", test.Name, test.Expected, mock.Modified)
+		}
+	}
+}
+
+type mockActiveFlowCount struct {
+	cloudwatchiface.CloudWatchAPI
+	Datapoints []*cloudwatch.Datapoint
+	Dimension  string
+}
+
+func (m *mockActiveFlowCount) GetMetricStatistics(i *cloudwatch.GetMetricStatisticsInput) (*cloudwatch.GetMetricStatisticsOutput, error) {
+	m.Dimension = aws.StringValue(i.Dimensions[0].Value)
+	return &cloudwatch.GetMetricStatisticsOutput{Datapoints: m.Datapoints}, nil
+}
+
+func TestGetActiveFlowCount(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		Name       string
+		Datapoints []*cloudwatch.Datapoint
+		Expected   int64
+	}{
+		{
+			Name:     "No data means no connections",
+			Expected: 0,
+		},
+		{
+			Name: "The most recent datapoint is used",
+			Datapoints: []*cloudwatch.Datapoint{
+				{Timestamp: aws.Time(now.Add(-2 * time.Minute)), Maximum: aws.Float64(12)},
+				{Timestamp: aws.Time(now.Add(-1 * time.Minute)), Maximum: aws.Float64(3)},
+				{Timestamp: aws.Time(now.Add(-3 * time.Minute)), Maximum: aws.Float64(40)},
+			},
+			Expected: 3,
+		},
+	}
+	for _, test := range tests {
+		mock := &mockActiveFlowCount{Datapoints: test.Datapoints}
+		client := &Client{cloudWatchClient: mock}
+		count, err := client.getActiveFlowCount("arn:aws:elasticloadbalancing:us-east-1:123456789012:loadbalancer/net/basename-ext/abcdef")
+		if err != nil {
+			t.Fatalf("Test [%v] unexpected error %v", test.Name, err)
+		}
+		if count != test.Expected {
+			t.Fatalf("Test [%v] FAILED. Expected %d active connections. Got %d", test.Name, test.Expected, count)
+		}
+		if mock.Dimension != "net/basename-ext/abcdef" {
+			t.Fatalf("Test [%v] FAILED. Expected the net/basename-ext/abcdef dimension. Got %s", test.Name, mock.Dimension)
+		}
+	}
+}
+
+func TestAlarmMatches(t *testing.T) {
+	client := &Client{}
+	desired := client.adminAPIAlarms("basename", "a0123456789", &cloudingressv1alpha1.Alarms{LatencyThresholdMilliseconds: 500})
+	if len(desired) != 3 {
+		t.Fatalf("Expected 3 alarms. Got %d", len(desired))
+	}
+	latency := desired[2]
+	if aws.StringValue(latency.AlarmName) != "basename-rh-api-latency" || aws.Float64Value(latency.Threshold) != 0.5 {
+		t.Fatalf("Expected the basename-rh-api-latency alarm at 0.5 seconds. Got %s at %v", aws.StringValue(latency.AlarmName), aws.Float64Value(latency.Threshold))
+	}
+	existing := func(threshold float64, elbName string) *cloudwatch.MetricAlarm {
+		return &cloudwatch.MetricAlarm{
+			AlarmName:          latency.AlarmName,
+			MetricName:         latency.MetricName,
+			Dimensions:         []*cloudwatch.Dimension{{Name: aws.String("LoadBalancerName"), Value: aws.String(elbName)}},
+			Statistic:          latency.Statistic,
+			Period:             latency.Period,
+			EvaluationPeriods:  latency.EvaluationPeriods,
+			Threshold:          aws.Float64(threshold),
+			ComparisonOperator: latency.ComparisonOperator,
+			TreatMissingData:   latency.TreatMissingData,
+		}
+	}
+	tests := []struct {
+		Name     string
+		Alarm    *cloudwatch.MetricAlarm
+		Expected bool
+	}{
+		{Name: "Matching alarm", Alarm: existing(0.5, "a0123456789"), Expected: true},
+		{Name: "Alarm with another threshold", Alarm: existing(1, "a0123456789"), Expected: false},
+		{Name: "Alarm for a replaced load balancer", Alarm: existing(0.5, "a9876543210"), Expected: false},
+	}
+	for _, test := range tests {
+		if resp := alarmMatches(test.Alarm, latency); resp != test.Expected {
+			t.Fatalf("Test [%v] FAILED. Expected %t. Got %t", test.Name, test.Expected, resp)
+		}
+	}
+}
+
+func TestSelectPublicSubnets(t *testing.T) {
+	subnet := func(id, zone string) *ec2.Subnet {
+		return &ec2.Subnet{SubnetId: aws.String(id), AvailabilityZone: aws.String(zone)}
+	}
+	routeTables := []*ec2.RouteTable{
+		{
+			RouteTableId: aws.String("rtb-public"),
+			Associations: []*ec2.RouteTableAssociation{
+				{SubnetId: aws.String("subnet-a2")},
+				{SubnetId: aws.String("subnet-a1")},
+				{SubnetId: aws.String("subnet-b1")},
+			},
+			Routes: []*ec2.Route{{GatewayId: aws.String("igw-0123")}},
+		},
+		{
+			RouteTableId: aws.String("rtb-private"),
+			Associations: []*ec2.RouteTableAssociation{{Main: aws.Bool(true)}},
+			Routes:       []*ec2.Route{{GatewayId: aws.String("local")}},
+		},
+	}
+	tests := []struct {
+		Name      string
+		Subnets   []*ec2.Subnet
+		Zones     []string
+		Explicit  bool
+		Expected  []string
+		ExpectErr bool
+	}{
+		{
+			Name:     "One public subnet per zone, private ones skipped",
+			Subnets:  []*ec2.Subnet{subnet("subnet-a2", "us-east-1a"), subnet("subnet-b1", "us-east-1b"), subnet("subnet-a1", "us-east-1a"), subnet("subnet-p1", "us-east-1a")},
+			Zones:    []string{"us-east-1a", "us-east-1b"},
+			Expected: []string{"subnet-a1", "subnet-b1"},
+		},
+		{
+			Name:      "A zone with masters has no public subnet",
+			Subnets:   []*ec2.Subnet{subnet("subnet-a1", "us-east-1a")},
+			Zones:     []string{"us-east-1a", "us-east-1b"},
+			ExpectErr: true,
+		},
+		{
+			Name:      "An explicit subnet is private",
+			Subnets:   []*ec2.Subnet{subnet("subnet-a1", "us-east-1a"), subnet("subnet-p1", "us-east-1b")},
+			Zones:     []string{"us-east-1a"},
+			Explicit:  true,
+			ExpectErr: true,
+		},
+	}
+	for _, test := range tests {
+		resp, err := selectPublicSubnets(test.Subnets, routeTables, test.Zones, test.Explicit)
+		if test.ExpectErr {
+			if err == nil {
+				t.Fatalf("Test [%v] FAILED. Expected an error. Got %v", test.Name, resp)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("Test [%v] unexpected error %v", test.Name, err)
+		}
+		if !reflect.DeepEqual(resp, test.Expected) {
+			t.Fatalf("Test [%v] FAILED. Expected %v. Got %v", test.Name, test.Expected, resp)
+		}
+	}
+}
+
+func TestSubnetsMismatchingScheme(t *testing.T) {
+	routeTables := []*ec2.RouteTable{
+		{
+			RouteTableId: aws.String("rtb-public"),
+			Associations: []*ec2.RouteTableAssociation{{SubnetId: aws.String("subnet-public")}},
+			Routes:       []*ec2.Route{{GatewayId: aws.String("local")}, {GatewayId: aws.String("igw-0123")}},
+		},
+		{
+			RouteTableId: aws.String("rtb-private"),
+			Associations: []*ec2.RouteTableAssociation{{SubnetId: aws.String("subnet-private")}},
+			Routes:       []*ec2.Route{{GatewayId: aws.String("local")}, {NatGatewayId: aws.String("nat-0123")}},
+		},
+		{
+			RouteTableId: aws.String("rtb-main"),
+			Associations: []*ec2.RouteTableAssociation{{Main: aws.Bool(true)}},
+			Routes:       []*ec2.Route{{GatewayId: aws.String("local")}},
+		},
+	}
+	tests := []struct {
+		Name      string
+		SubnetIDs []string
+		Internal  bool
+		Expected  []string
+	}{
+		{
+			Name:      "Public subnets for an internet-facing load balancer",
+			SubnetIDs: []string{"subnet-public"},
+			Expected:  []string{},
+		},
+		{
+			Name:      "Private subnets for an internet-facing load balancer",
+			SubnetIDs: []string{"subnet-public", "subnet-private", "subnet-implicit"},
+			Expected:  []string{"subnet-private", "subnet-implicit"},
+		},
+		{
+			Name:      "Private subnets for an internal load balancer",
+			SubnetIDs: []string{"subnet-private", "subnet-implicit"},
+			Internal:  true,
+			Expected:  []string{},
+		},
+		{
+			Name:      "Public subnets for an internal load balancer",
+			SubnetIDs: []string{"subnet-private", "subnet-public"},
+			Internal:  true,
+			Expected:  []string{"subnet-public"},
+		},
+	}
+	for _, test := range tests {
+		mismatched, err := subnetsMismatchingScheme(test.SubnetIDs, routeTables, test.Internal)
+		if err != nil {
+			t.Fatalf("Test [%v] unexpected error %v", test.Name, err)
+		}
+		if !reflect.DeepEqual(mismatched, test.Expected) {
+			t.Fatalf("Test [%v] FAILED. Expected %v. Got %v", test.Name, test.Expected, mismatched)
+		}
+	}
+}
+
+func TestExcludeEdgeZoneSubnets(t *testing.T) {
+	subnets := []*ec2.Subnet{
+		{SubnetId: aws.String("subnet-a"), AvailabilityZone: aws.String("us-west-2a")},
+		{SubnetId: aws.String("subnet-lax"), AvailabilityZone: aws.String("us-west-2-lax-1a")},
+		{SubnetId: aws.String("subnet-b"), AvailabilityZone: aws.String("us-west-2b")},
+		{SubnetId: aws.String("subnet-wl"), AvailabilityZone: aws.String("us-west-2-wl1-sfo-wlz-1")},
+	}
+	tests := []struct {
+		Name             string
+		EdgeZones        map[string]string
+		ExpectedKept     []string
+		ExpectedExcluded []string
+	}{
+		{
+			Name:             "No edge zones",
+			EdgeZones:        map[string]string{},
+			ExpectedKept:     []string{"subnet-a", "subnet-lax", "subnet-b", "subnet-wl"},
+			ExpectedExcluded: []string{},
+		},
+		{
+			Name: "Local Zone and Wavelength Zone",
+			EdgeZones: map[string]string{
+				"us-west-2-lax-1a":        "local-zone",
+				"us-west-2-wl1-sfo-wlz-1": "wavelength-zone",
+			},
+			ExpectedKept: []string{"subnet-a", "subnet-b"},
+			ExpectedExcluded: []string{
+				"subnet-lax (local-zone us-west-2-lax-1a)",
+				"subnet-wl (wavelength-zone us-west-2-wl1-sfo-wlz-1)",
+			},
+		},
+	}
+	for _, test := range tests {
+		kept, excluded := excludeEdgeZoneSubnets(subnets, test.EdgeZones)
+		keptIDs := []string{}
+		for _, subnet := range kept {
+			keptIDs = append(keptIDs, aws.StringValue(subnet.SubnetId))
+		}
+		if !reflect.DeepEqual(keptIDs, test.ExpectedKept) {
+			t.Fatalf("Test [%v] FAILED. Expected %v. Got %v", test.Name, test.ExpectedKept, keptIDs)
+		}
+		if !reflect.DeepEqual(excluded, test.ExpectedExcluded) {
+			t.Fatalf("Test [%v] FAILED. Expected %v. Got %v", test.Name, test.ExpectedExcluded, excluded)
+		}
+	}
+}
+
+// BZ https://bugzilla.redhat.com/show_bug.cgi?id=1814332
+func TestOldClusterNoInfrastructureBackfill(t *testing.T) {
+	clustername := "oldtest"
+	// legacy infra obj has this extra bits, but the configmap does not
+	extrabits := "cmld7"
+	oldInfraObj := testutils.CreatOldInfraObject("oldtest-cmld7", testutils.DefaultAPIEndpoint, testutils.DefaultAPIEndpoint, testutils.DefaultRegionName)
+	oldCM := testutils.CreateLegacyClusterConfig(fmt.Sprintf("%s.%s", clustername, testutils.DefaultClusterDomain),
+		fmt.Sprintf("%s-%s", clustername, extrabits), testutils.DefaultRegionName, 0, 0)
+	objs := []runtime.Object{oldInfraObj, oldCM}
+	mocks := testutils.NewTestMock(t, objs)
+	region, err := getClusterRegion(mocks.FakeKubeClient)
+	if err != nil {
+		t.Fatalf("Error: Couldn't get region. Expected to get %s: %v", testutils.DefaultRegionName, err)
+	}
+	if region != testutils.DefaultRegionName {
+		t.Fatalf("Expected region to be %s, but got %s", testutils.DefaultRegionName, region)
+	}
+}
+
+func TestGetMasterSubnetNames(t *testing.T) {
+	clustername := "subnet-test"
+	masterNames := make([]string, 3)
+	for i := 0; i < 3; i++ {
+		masterNames[i] = fmt.Sprintf("master-%d", i)
+	}
+
+	machineList, _ := testutils.CreateMachineObjectList(masterNames, "ids", "master", testutils.DefaultRegionName, testutils.DefaultAzName)
+	infraObj := testutils.CreateInfraObject(clustername, testutils.DefaultAPIEndpoint, testutils.DefaultAPIEndpoint, testutils.DefaultRegionName)
+	objs := []runtime.Object{infraObj, machineList}
+	mocks := testutils.NewTestMock(t, objs)
+	subnetmap, err := getMasterNodeSubnets(mocks.FakeKubeClient)
+
+	if err != nil {
+		t.Fatalf("Couldn't get subnet map: %v", err)
+	}
+	if len(subnetmap) != 2 {
+		t.Fatalf("Expected Subnet map to have exactly two keys, but got %d keys back", len(subnetmap))
+	}
+	if _, ok := subnetmap["public"]; !ok {
+		t.Fatalf("Expected Subnet map to have a 'public' key, and it did not")
+	}
+	if _, ok := subnetmap["private"]; !ok {
+		t.Fatalf("Expected Subnet map to have a 'private' key, and it did not")
+	}
+	expected := fmt.Sprintf("%s-public-%s", clustername, testutils.DefaultAzName)
+	if subnetmap["public"] != expected {
+		t.Fatalf("Expected public subnet to be %s, but got %s instead", expected, subnetmap["public"])
+	}
+	expected = fmt.Sprintf("%s-private-%s", clustername, testutils.DefaultAzName)
+	if subnetmap["private"] != expected {
+		t.Fatalf("Expected private subnet to be %s, but got %s instead", expected, subnetmap["private"])
+	}
+}
+
+func TestGetMasterSubnetNamesNoMasters(t *testing.T) {
+	clustername := "subnet-test"
+	masterNames := make([]string, 0)
+	machineList, _ := testutils.CreateMachineObjectList(masterNames, "ids", "master", testutils.DefaultRegionName, testutils.DefaultAzName)
+	infraObj := testutils.CreateInfraObject(clustername, testutils.DefaultAPIEndpoint, testutils.DefaultAPIEndpoint, testutils.DefaultRegionName)
+	objs := []runtime.Object{infraObj, machineList}
+	mocks := testutils.NewTestMock(t, objs)
+
+	_, err := getMasterNodeSubnets(mocks.FakeKubeClient)
+	if err == nil {
+		t.Fatalf("Expected to see an exception when trying to get subnets for 0 master nodes ")
+	}
+}
+
+func TestGetClusterRegion(t *testing.T) {
+	infraObj := testutils.CreateInfraObject("region-test", testutils.DefaultAPIEndpoint, testutils.DefaultAPIEndpoint, testutils.DefaultRegionName)
+	objs := []runtime.Object{infraObj}
+	mocks := testutils.NewTestMock(t, objs)
+
+	region, err := getClusterRegion(mocks.FakeKubeClient)
+	if err != nil {
+		t.Fatalf("Could not get cluster region: %v", err)
+	}
+	if region != testutils.DefaultRegionName {
+		t.Fatalf("Region mismatch. Expected %s, got %s", region, testutils.DefaultRegionName)
+	}
+}
+
+// None of these should ever occur, but if they did, it'd be nice to know they return an error
+func TestNoInfraObj(t *testing.T) {
+	masterNames := make([]string, 3)
+	for i := 0; i < 3; i++ {
+		masterNames[i] = fmt.Sprintf("master-%d", i)
+	}
+	machineList, _ := testutils.CreateMachineObjectList(masterNames, "ids", "master", testutils.DefaultRegionName, testutils.DefaultAzName)
+	objs := []runtime.Object{machineList}
+	mocks := testutils.NewTestMock(t, objs)
+
+	_, err := getClusterRegion(mocks.FakeKubeClient)
+	if err == nil {
+		t.Fatalf("Expected to get an error from not having an Infrastructure object")
+	}
+	_, err = getMasterNodeSubnets(mocks.FakeKubeClient)
+	if err == nil {
+		t.Fatalf("Expected to get an error from not having an Infrastructure object")
+	}
+}
+
+type mockRoute53Client struct {
+	route53iface.Route53API
+}
+
+func (m mockRoute53Client) ListResourceRecordSetsPages(input *route53.ListResourceRecordSetsInput, fn func(*route53.ListResourceRecordSetsOutput, bool) bool) error {
+	resps := []*route53.ListResourceRecordSetsOutput{
+		{
+			ResourceRecordSets: []*route53.ResourceRecordSet{
+				{
+					Name: aws.String("rh-api.osd-cluster.org."),
+					Type: aws.String("A"),
+					AliasTarget: &route53.AliasTarget{
+						DNSName:              aws.String("abcdefgh.us-east-1.elb.amazon.com."),
+						EvaluateTargetHealth: aws.Bool(false),
+						HostedZoneId:         aws.String("AAAAAAAAAA"),
+					},
+				},
+				{
+					Name: aws.String("api-osd-cluster.org."),
+					Type: aws.String("A"),
+					AliasTarget: &route53.AliasTarget{
+						DNSName:              aws.String("0123456.elb.us-east-1.amazonaws.com."),
+						EvaluateTargetHealth: aws.Bool(false),
+						HostedZoneId:         aws.String("BBBBBBBBBB"),
+					},
+				},
+			},
+			IsTruncated: aws.Bool(false),
+			MaxItems:    aws.String("100"),
+		},
+	}
+	for _, resp := range resps {
+		if !fn(resp, true) {
+			break
+		}
+	}
+	return nil
+}
+
+func (m mockRoute53Client) GetHostedZone(input *route53.GetHostedZoneInput) (*route53.GetHostedZoneOutput, error) {
+	if aws.StringValue(input.Id) != "Z0123456789" {
+		return nil, awserr.New(route53.ErrCodeNoSuchHostedZone, "No hosted zone found with ID", nil)
+	}
+	return &route53.GetHostedZoneOutput{
+		HostedZone: &route53.HostedZone{
+			Id:   aws.String("/hostedzone/Z0123456789"),
+			Name: aws.String("vanity.example.com."),
+		},
+	}, nil
+}
+
+type mockCNAMERoute53 struct {
+	mockRoute53Client
+	CNAMEs  []*route53.ResourceRecordSet
+	Changes []*route53.Change
+}
+
+func (m *mockCNAMERoute53) ListResourceRecordSets(_ *route53.ListResourceRecordSetsInput) (*route53.ListResourceRecordSetsOutput, error) {
+	return &route53.ListResourceRecordSetsOutput{ResourceRecordSets: m.CNAMEs}, nil
+}
+
+func (m *mockCNAMERoute53) ChangeResourceRecordSets(input *route53.ChangeResourceRecordSetsInput) (*route53.ChangeResourceRecordSetsOutput, error) {
+	m.Changes = append(m.Changes, input.ChangeBatch.Changes...)
+	return &route53.ChangeResourceRecordSetsOutput{}, nil
+}
+
+func TestUpsertAliasRecordReplacesCNAME(t *testing.T) {
+	cname := &route53.ResourceRecordSet{
+		Name:            aws.String("rh-api.vanity.example.com."),
+		Type:            aws.String(route53.RRTypeCname),
+		TTL:             aws.Int64(300),
+		ResourceRecords: []*route53.ResourceRecord{{Value: aws.String("old.us-east-1.elb.amazonaws.com")}},
+	}
+	tests := []struct {
+		Name            string
+		CNAMEs          []*route53.ResourceRecordSet
+		ExpectedActions []string
+	}{
+		{
+			Name:            "No CNAME",
+			ExpectedActions: []string{"UPSERT"},
+		},
+		{
+			Name: "CNAME of another name",
+			CNAMEs: []*route53.ResourceRecordSet{
+				{Name: aws.String("sh.vanity.example.com."), Type: aws.String(route53.RRTypeCname)},
+			},
+			ExpectedActions: []string{"UPSERT"},
+		},
+		{
+			Name:            "CNAME of the same name",
+			CNAMEs:          []*route53.ResourceRecordSet{cname},
+			ExpectedActions: []string{"DELETE", "UPSERT"},
+		},
+	}
+	for _, test := range tests {
+		mock := &mockCNAMERoute53{CNAMEs: test.CNAMEs}
+		client := &Client{route53Client: mock}
+		err := client.upsertAliasRecordInZone("Z0123456789", "new.us-east-1.elb.amazonaws.com", "AAAAAAAAAA", "rh-api.vanity.example.com", "A", "test", false)
+		if err != nil {
+			t.Fatalf("Test [%v] FAILED. Unexpected error %v", test.Name, err)
+		}
+		actions := []string{}
+		for _, change := range mock.Changes {
+			actions = append(actions, aws.StringValue(change.Action))
+		}
+		if !reflect.DeepEqual(actions, test.ExpectedActions) {
+			t.Fatalf("Test [%v] FAILED. Expected %v. Got %v", test.Name, test.ExpectedActions, actions)
+		}
+		if len(mock.Changes) == 2 && mock.Changes[0].ResourceRecordSet != cname {
+			t.Fatalf("Test [%v] FAILED. Expected to delete the CNAME as it is. Got %v", test.Name, mock.Changes[0].ResourceRecordSet)
+		}
+	}
+}
+
+func TestUpsertWeightedAliasRecords(t *testing.T) {
+	weighted := func(setIdentifier, dnsName string, weight int64) *route53.ResourceRecordSet {
+		return &route53.ResourceRecordSet{
+			AliasTarget: &route53.AliasTarget{
+				DNSName:              aws.String(dnsName),
+				EvaluateTargetHealth: aws.Bool(false),
+				HostedZoneId:         aws.String("AAAAAAAAAA"),
+			},
+			Name:          aws.String("rh-api.example.com."),
+			Type:          aws.String("A"),
+			SetIdentifier: aws.String(setIdentifier),
+			Weight:        aws.Int64(weight),
+		}
+	}
+	simple := &route53.ResourceRecordSet{
+		AliasTarget: &route53.AliasTarget{
+			DNSName:              aws.String("clb.us-east-1.elb.amazonaws.com."),
+			EvaluateTargetHealth: aws.Bool(false),
+			HostedZoneId:         aws.String("AAAAAAAAAA"),
+		},
+		Name: aws.String("rh-api.example.com."),
+		Type: aws.String("A"),
+	}
+	targets := []weightedAliasTarget{
+		{setIdentifier: primaryRecordSetIdentifier, lb: &awsLoadBalancer{dnsName: "clb.us-east-1.elb.amazonaws.com", dnsZoneID: "AAAAAAAAAA"}, weight: 75},
+		{setIdentifier: migrationRecordSetIdentifier, lb: &awsLoadBalancer{dnsName: "nlb.us-east-1.elb.amazonaws.com", dnsZoneID: "AAAAAAAAAA"}, weight: 25},
+	}
+	tests := []struct {
+		Name            string
+		Records         []*route53.ResourceRecordSet
+		ExpectedActions []string
+	}{
+		{
+			Name:            "Simple record replaced",
+			Records:         []*route53.ResourceRecordSet{simple},
+			ExpectedActions: []string{"DELETE", "UPSERT", "UPSERT"},
+		},
+		{
+			Name: "Weight changed",
+			Records: []*route53.ResourceRecordSet{
+				weighted(primaryRecordSetIdentifier, "clb.us-east-1.elb.amazonaws.com.", 100),
+				weighted(migrationRecordSetIdentifier, "nlb.us-east-1.elb.amazonaws.com.", 25),
+			},
+			ExpectedActions: []string{"UPSERT"},
+		},
+		{
+			Name: "Already in place",
+			Records: []*route53.ResourceRecordSet{
+				weighted(primaryRecordSetIdentifier, "clb.us-east-1.elb.amazonaws.com.", 75),
+				weighted(migrationRecordSetIdentifier, "nlb.us-east-1.elb.amazonaws.com.", 25),
+			},
+			ExpectedActions: []string{},
+		},
+		{
+			Name: "Stale set identifier",
+			Records: []*route53.ResourceRecordSet{
+				weighted("old", "old.us-east-1.elb.amazonaws.com.", 50),
+				weighted(primaryRecordSetIdentifier, "clb.us-east-1.elb.amazonaws.com.", 75),
+				weighted(migrationRecordSetIdentifier, "nlb.us-east-1.elb.amazonaws.com.", 25),
+			},
+			ExpectedActions: []string{"DELETE"},
+		},
+	}
+	for _, test := range tests {
+		mock := &mockCNAMERoute53{CNAMEs: test.Records}
+		client := &Client{route53Client: mock}
+		err := client.upsertWeightedAliasRecords("Z0123456789", "rh-api.example.com", "A", targets, "test")
+		if err != nil {
+			t.Fatalf("Test [%v] FAILED. Unexpected error %v", test.Name, err)
+		}
+		actions := []string{}
+		for _, change := range mock.Changes {
+			actions = append(actions, aws.StringValue(change.Action))
+		}
+		if !reflect.DeepEqual(actions, test.ExpectedActions) {
+			t.Fatalf("Test [%v] FAILED. Expected %v. Got %v", test.Name, test.ExpectedActions, actions)
+		}
+	}
+}
+
+type mockHealthCheckRoute53 struct {
+	mockCNAMERoute53
+	CallerReferences map[string]string
+	Deleted          []string
+}
+
+func (m *mockHealthCheckRoute53) GetHealthCheck(input *route53.GetHealthCheckInput) (*route53.GetHealthCheckOutput, error) {
+	callerReference, ok := m.CallerReferences[aws.StringValue(input.HealthCheckId)]
+	if !ok {
+		return nil, awserr.New(route53.ErrCodeNoSuchHealthCheck, "", nil)
+	}
+	return &route53.GetHealthCheckOutput{HealthCheck: &route53.HealthCheck{Id: input.HealthCheckId, CallerReference: aws.String(callerReference)}}, nil
+}
+
+func (m *mockHealthCheckRoute53) DeleteHealthCheck(input *route53.DeleteHealthCheckInput) (*route53.DeleteHealthCheckOutput, error) {
+	m.Deleted = append(m.Deleted, aws.StringValue(input.HealthCheckId))
+	return &route53.DeleteHealthCheckOutput{}, nil
+}
+
+func TestUpsertFailoverAliasRecords(t *testing.T) {
+	primary := &awsLoadBalancer{dnsName: "clb.us-east-1.elb.amazonaws.com", dnsZoneID: "AAAAAAAAAA"}
+	secondary := &awsLoadBalancer{dnsName: "nlb.us-east-1.elb.amazonaws.com", dnsZoneID: "AAAAAAAAAA"}
+	failover := []*route53.ResourceRecordSet{
+		failoverAliasRecord("rh-api.example.com.", "A", failoverPrimaryRecordSetIdentifier, route53.ResourceRecordSetFailoverPrimary, primary, aws.String("hc-1")),
+		failoverAliasRecord("rh-api.example.com.", "A", failoverSecondaryRecordSetIdentifier, route53.ResourceRecordSetFailoverSecondary, secondary, nil),
+	}
+	weighted := []*route53.ResourceRecordSet{
+		{
+			AliasTarget:   &route53.AliasTarget{DNSName: aws.String("clb.us-east-1.elb.amazonaws.com."), EvaluateTargetHealth: aws.Bool(false), HostedZoneId: aws.String("AAAAAAAAAA")},
+			Name:          aws.String("rh-api.example.com."),
+			Type:          aws.String("A"),
+			SetIdentifier: aws.String(primaryRecordSetIdentifier),
+			Weight:        aws.Int64(75),
+		},
+		{
+			AliasTarget:   &route53.AliasTarget{DNSName: aws.String("nlb.us-east-1.elb.amazonaws.com."), EvaluateTargetHealth: aws.Bool(false), HostedZoneId: aws.String("AAAAAAAAAA")},
+			Name:          aws.String("rh-api.example.com."),
+			Type:          aws.String("A"),
+			SetIdentifier: aws.String(migrationRecordSetIdentifier),
+			Weight:        aws.Int64(25),
+		},
+	}
+	tests := []struct {
+		Name                 string
+		Records              []*route53.ResourceRecordSet
+		Desired              []*route53.ResourceRecordSet
+		CallerReferences     map[string]string
+		ExpectedActions      []string
+		ExpectedHealthChecks []string
+	}{
+		{
+			Name:            "Weighted records replaced",
+			Records:         weighted,
+			Desired:         failover,
+			ExpectedActions: []string{"DELETE", "DELETE", "UPSERT", "UPSERT"},
+		},
+		{
+			Name:            "Already in place",
+			Records:         failover,
+			Desired:         failover,
+			ExpectedActions: []string{},
+		},
+		{
+			Name:                 "Failover records replaced",
+			Records:              failover,
+			Desired:              weighted,
+			CallerReferences:     map[string]string{"hc-1": "cluster-rh-api-failover-1"},
+			ExpectedActions:      []string{"DELETE", "DELETE", "UPSERT", "UPSERT"},
+			ExpectedHealthChecks: []string{"hc-1"},
+		},
+		{
+			Name:             "Health check not the operator's",
+			Records:          failover,
+			Desired:          weighted,
+			CallerReferences: map[string]string{"hc-1": "someone-else"},
+			ExpectedActions:  []string{"DELETE", "DELETE", "UPSERT", "UPSERT"},
+		},
+	}
+	for _, test := range tests {
+		mock := &mockHealthCheckRoute53{mockCNAMERoute53: mockCNAMERoute53{CNAMEs: test.Records}, CallerReferences: test.CallerReferences}
+		client := &Client{route53Client: mock}
+		err := client.upsertRoutedAliasRecords("Z0123456789", "rh-api.example.com.", "A", test.Desired, "test")
+		if err != nil {
+			t.Fatalf("Test [%v] FAILED. Unexpected error %v", test.Name, err)
+		}
+		actions := []string{}
+		for _, change := range mock.Changes {
+			actions = append(actions, aws.StringValue(change.Action))
+		}
+		if !reflect.DeepEqual(actions, test.ExpectedActions) {
+			t.Fatalf("Test [%v] FAILED. Expected %v. Got %v", test.Name, test.ExpectedActions, actions)
+		}
+		if len(mock.Deleted) != len(test.ExpectedHealthChecks) || (len(mock.Deleted) > 0 && !reflect.DeepEqual(mock.Deleted, test.ExpectedHealthChecks)) {
+			t.Fatalf("Test [%v] FAILED. Expected the health checks %v deleted. Got %v", test.Name, test.ExpectedHealthChecks, mock.Deleted)
+		}
+	}
+}
+
+func TestGetCustomHostedZone(t *testing.T) {
+	client := &Client{
+		route53Client: mockRoute53Client{},
+	}
+	zone, err := client.getCustomHostedZone("Z0123456789", "rh-api.vanity.example.com")
+	if err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	if zone.id != "Z0123456789" || zone.private {
+		t.Fatalf("Expected the public hosted zone Z0123456789, got %+v", zone)
+	}
+
+	_, err = client.getCustomHostedZone("Z0123456789", "rh-api.example.org")
+	if err == nil {
+		t.Fatalf("Expected a record outside the hosted zone to be refused")
+	}
+	_, err = client.getCustomHostedZone("ZMISSING", "rh-api.vanity.example.com")
+	if err == nil {
+		t.Fatalf("Expected a missing hosted zone to be refused")
+	}
+}
+
+type mockSplitHorizonRoute53 struct {
+	mockCNAMERoute53
+}
+
+func (m *mockSplitHorizonRoute53) ListHostedZonesByName(input *route53.ListHostedZonesByNameInput) (*route53.ListHostedZonesByNameOutput, error) {
+	zone := func(id, name string, private bool) *route53.HostedZone {
+		return &route53.HostedZone{
+			Id:     aws.String("/hostedzone/" + id),
+			Name:   aws.String(name),
+			Config: &route53.HostedZoneConfig{PrivateZone: aws.Bool(private)},
+		}
+	}
+	return &route53.ListHostedZonesByNameOutput{
+		HostedZones: []*route53.HostedZone{
+			zone("ZPUBLIC", "example.com.", false),
+			zone("ZPRIVATE", "example.com.", true),
+			zone("ZOTHERVPC", "example.com.", true),
+			zone("ZSUB", "sub.example.com.", false),
+		},
+	}, nil
+}
+
+func (m *mockSplitHorizonRoute53) GetHostedZone(input *route53.GetHostedZoneInput) (*route53.GetHostedZoneOutput, error) {
+	vpcID := "vpc-cluster"
+	if aws.StringValue(input.Id) == "ZOTHERVPC" {
+		vpcID = "vpc-other"
+	}
+	return &route53.GetHostedZoneOutput{
+		HostedZone: &route53.HostedZone{Id: input.Id},
+		VPCs:       []*route53.VPC{{VPCId: aws.String(vpcID)}},
+	}, nil
+}
+
+func TestGetHostedZonesNamed(t *testing.T) {
+	client := &Client{route53Client: &mockSplitHorizonRoute53{}}
+	zones, err := client.getHostedZonesNamed("example.com.", "vpc-cluster")
+	if err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	expected := []hostedZone{{id: "ZPUBLIC"}, {id: "ZPRIVATE", private: true}}
+	if !reflect.DeepEqual(zones, expected) {
+		t.Fatalf("Expected %+v, got %+v", expected, zones)
+	}
+}
+
+func TestPublishedHostedZones(t *testing.T) {
+	public := hostedZone{id: "ZPUBLIC"}
+	private := hostedZone{id: "ZPRIVATE", private: true}
+	tests := []struct {
+		Name                string
+		Zones               []hostedZone
+		Scheme              string
+		ExpectedPublished   []hostedZone
+		ExpectedUnpublished []hostedZone
+	}{
+		{
+			Name:              "Internet-facing",
+			Zones:             []hostedZone{public, private},
+			Scheme:            "internet-facing",
+			ExpectedPublished: []hostedZone{public, private},
+		},
+		{
+			Name:                "Internal",
+			Zones:               []hostedZone{public, private},
+			Scheme:              "internal",
+			ExpectedPublished:   []hostedZone{private},
+			ExpectedUnpublished: []hostedZone{public},
+		},
+		{
+			Name:              "Internal without a private zone",
+			Zones:             []hostedZone{public},
+			Scheme:            "internal",
+			ExpectedPublished: []hostedZone{public},
+		},
+	}
+	for _, test := range tests {
+		published, unpublished := publishedHostedZones(test.Zones, &awsLoadBalancer{scheme: test.Scheme})
+		if !reflect.DeepEqual(published, test.ExpectedPublished) || !reflect.DeepEqual(unpublished, test.ExpectedUnpublished) {
+			t.Fatalf("Test [%v] FAILED. Expected %+v and %+v. Got %+v and %+v", test.Name, test.ExpectedPublished, test.ExpectedUnpublished, published, unpublished)
+		}
+	}
+}
+
+func TestDeleteAliasRecordsNamed(t *testing.T) {
+	alias := func(recordType string, setIdentifier *string) *route53.ResourceRecordSet {
+		return &route53.ResourceRecordSet{
+			Name:          aws.String("rh-api.example.com."),
+			Type:          aws.String(recordType),
+			SetIdentifier: setIdentifier,
+			AliasTarget: &route53.AliasTarget{
+				DNSName:      aws.String("old.us-east-1.elb.amazonaws.com."),
+				HostedZoneId: aws.String("Z35SXDOTRQ7X7K"),
+			},
+		}
+	}
+	mock := &mockCNAMERoute53{CNAMEs: []*route53.ResourceRecordSet{
+		alias("A", nil),
+		alias("AAAA", nil),
+		alias("A", aws.String(migrationRecordSetIdentifier)),
+		{
+			Name: aws.String("rh-api.example.com."),
+			Type: aws.String("TXT"),
+		},
+	}}
+	client := &Client{route53Client: mock}
+	err := client.deleteAliasRecordsNamed("ZPUBLIC", "rh-api.example.com", []string{"A"})
+	if err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	if len(mock.Changes) != 2 {
+		t.Fatalf("Expected the simple and weighted A records to be deleted, got %v", mock.Changes)
+	}
+	for _, change := range mock.Changes {
+		if aws.StringValue(change.Action) != "DELETE" || aws.StringValue(change.ResourceRecordSet.Type) != "A" {
+			t.Fatalf("Expected only A records to be deleted, got %v", change)
+		}
+	}
+}
+
+func TestHasAliasRecords(t *testing.T) {
+	alias := func(recordType string) *route53.ResourceRecordSet {
+		return &route53.ResourceRecordSet{
+			Name: aws.String("rh-api.example.com."),
+			Type: aws.String(recordType),
+			AliasTarget: &route53.AliasTarget{
+				DNSName:      aws.String("old.us-east-1.elb.amazonaws.com."),
+				HostedZoneId: aws.String("Z35SXDOTRQ7X7K"),
+			},
+		}
+	}
+	tests := []struct {
+		Name     string
+		Records  []*route53.ResourceRecordSet
+		Expected bool
+	}{
+		{
+			Name:     "Always ipv4",
+			Records:  []*route53.ResourceRecordSet{alias("A")},
+			Expected: false,
+		},
+		{
+			Name:     "Previously dualstack",
+			Records:  []*route53.ResourceRecordSet{alias("A"), alias("AAAA")},
+			Expected: true,
+		},
+	}
+	for _, test := range tests {
+		mock := &mockCNAMERoute53{CNAMEs: test.Records}
+		client := &Client{route53Client: mock}
+		lb := &loadBalancer{endpointName: "rh-api", baseDomain: "example.com", hostedZone: &hostedZone{id: "ZPUBLIC"}}
+		actual, err := client.hasAliasRecords(lb, "AAAA")
+		if err != nil {
+			t.Fatalf("Test [%v] unexpected error %v", test.Name, err)
+		}
+		if actual != test.Expected {
+			t.Fatalf("Test [%v] FAILED. Expected %v. Got %v", test.Name, test.Expected, actual)
+		}
+		if len(mock.Changes) != 0 {
+			t.Fatalf("Test [%v] FAILED. Expected no record changes. Got %v", test.Name, mock.Changes)
+		}
+	}
+}
+
+func TestRecordExists(t *testing.T) {
+	tests := []struct {
+		Name          string
+		Record        *route53.ResourceRecordSet // the record to check
+		Resp          bool
+		ErrResp       string
+		ErrorExpected bool
+	}{
+		{
+			Name: "Record should exist",
+			Record: &route53.ResourceRecordSet{
+				AliasTarget: &route53.AliasTarget{
+					DNSName:              aws.String("abcdefgh.us-east-1.elb.amazon.com."),
+					EvaluateTargetHealth: aws.Bool(false),
+					HostedZoneId:         aws.String("AAAAAAAAAA"),
+				},
+				Name: aws.String("rh-api.osd-cluster.org."),
+				Type: aws.String("A"),
+			},
+			Resp:          true,
+			ErrResp:       "",
+			ErrorExpected: false,
+		},
+		{
+			Name: "Record with non FDQN name should still exist",
+			Record: &route53.ResourceRecordSet{
+				AliasTarget: &route53.AliasTarget{
+					DNSName:              aws.String("abcdefgh.us-east-1.elb.amazon.com"),
+					EvaluateTargetHealth: aws.Bool(false),
+					HostedZoneId:         aws.String("AAAAAAAAAA"),
+				},
+				Name: aws.String("rh-api.osd-cluster.org"),
+				Type: aws.String("A"),
+			},
+			Resp:          true,
+			ErrResp:       "",
+			ErrorExpected: false,
+		},
+		{
+			Name: "Record shouldn't exist",
+			Record: &route53.ResourceRecordSet{
+				AliasTarget: &route53.AliasTarget{
+					DNSName:              aws.String("abcdefgh.us-east-1.elb.amazon.com."),
+					EvaluateTargetHealth: aws.Bool(false),
+					HostedZoneId:         aws.String("AAAAAAAAAA"),
+				},
+				Name: aws.String("rh-ssh.osd-cluster.org."),
+				Type: aws.String("A"),
+			},
+			Resp:          false,
+			ErrResp:       "",
+			ErrorExpected: false,
+		},
+		{
+			Name: "Record with matching Name but missmatched Type shouldn't exist",
+			Record: &route53.ResourceRecordSet{
+				AliasTarget: &route53.AliasTarget{
+					DNSName:              aws.String("abcdefgh.us-east-1.elb.amazon.com."),
+					EvaluateTargetHealth: aws.Bool(false),
+					HostedZoneId:         aws.String("AAAAAAAAAA"),
+				},
+				Name: aws.String("rh-ssh.osd-cluster.org."),
+				Type: aws.String("AAAA"),
+			},
+			Resp:          false,
+			ErrResp:       "",
+			ErrorExpected: false,
+		},
+		{
+			Name: "Record with matching Name and Type, but no AliasTarget, shoudn't exist",
+			Record: &route53.ResourceRecordSet{
+				Name: aws.String("rh-ssh.osd-cluster.org."),
+				Type: aws.String("A"),
+			},
+			Resp:          false,
+			ErrResp:       "",
+			ErrorExpected: false,
+		},
+		{
+			Name: "Record with matching Name and Type, but missmatched AliasTarget.EvaluateTargetHealth , shoudn't exist",
+			Record: &route53.ResourceRecordSet{
+				AliasTarget: &route53.AliasTarget{
+					DNSName:              aws.String("abcdefgh.us-east-1.elb.amazon.com."),
+					EvaluateTargetHealth: aws.Bool(true),
+					HostedZoneId:         aws.String("AAAAAAAAAA"),
+				},
+				Name: aws.String("rh-ssh.osd-cluster.org."),
+				Type: aws.String("A"),
+			},
+			Resp:          false,
+			ErrResp:       "",
+			ErrorExpected: false,
+		},
+		{
+			Name:          "nil Record should error",
+			Record:        nil,
+			Resp:          false,
+			ErrResp:       "resourceRecordSet can't be nil",
+			ErrorExpected: true,
+		},
+		{
+			Name: "nil Record.Name should error",
+			Record: &route53.ResourceRecordSet{
+				AliasTarget: &route53.AliasTarget{
+					DNSName:              aws.String("abcdefgh.us-east-1.elb.amazon.com."),
+					EvaluateTargetHealth: aws.Bool(false),
+					HostedZoneId:         aws.String("AAAAAAAAAA"),
+				},
+				Name: nil,
+				Type: aws.String("A"),
+			},
+			Resp:          false,
+			ErrResp:       "resourceRecordSet Name is required",
+			ErrorExpected: true,
+		},
+	}
+
+	for _, test := range tests {
+		client := &Client{
+			route53Client: mockRoute53Client{},
+		}
+		resp, err := client.recordExists(test.Record, "publicHostedZoneID")
+
+		if err == nil && test.ErrorExpected || err != nil && !test.ErrorExpected {
+			t.Fatalf("Test [%v] return mismatch. Expect error? %t: Return %+v", test.Name, test.ErrorExpected, err)
+		}
+		if err != nil && test.ErrorExpected && err.Error() != test.ErrResp {
+			t.Fatalf("Test [%v] FAILED. Excepted Error %v. Got %v", test.Name, test.ErrResp, err.Error())
+		}
+		if resp != test.Resp {
+			t.Fatalf("Test [%v] FAILED. Excepted Response %v. Got %v", test.Name, test.Resp, resp)
+		}
+
+	}
+
+}
+
+func TestCheckARNPartitions(t *testing.T) {
+	tests := []struct {
+		Name      string
+		ARNs      []string
+		Partition string
+		Valid     bool
+	}{
+		{
+			Name:      "Same partition",
+			ARNs:      []string{"arn:aws-us-gov:sns:us-gov-west-1:123456789012:alerts"},
+			Partition: "aws-us-gov",
+			Valid:     true,
+		},
+		{
+			Name:      "Commercial ARN in China",
+			ARNs:      []string{"arn:aws:sns:us-east-1:123456789012:alerts"},
+			Partition: "aws-cn",
+			Valid:     false,
+		},
+		{
+			Name:      "Not an ARN",
+			ARNs:      []string{"alerts"},
+			Partition: "aws",
+			Valid:     false,
+		},
+		{
+			Name:      "Unknown partition",
+			ARNs:      []string{"arn:aws:sns:us-east-1:123456789012:alerts"},
+			Partition: "",
+			Valid:     true,
+		},
+	}
+	for _, test := range tests {
+		err := checkARNPartitions(test.ARNs, test.Partition)
+		if (err == nil) != test.Valid {
+			t.Fatalf("Test [%v] FAILED. Expected valid %v. Got error %v", test.Name, test.Valid, err)
+		}
+	}
+}
+
+func TestServiceEndpointResolver(t *testing.T) {
+	resolver := serviceEndpointResolver([]cloudingressv1alpha1.AWSServiceEndpoint{
+		{Name: "ec2", URL: "https://ec2-fips.us-gov-west-1.amazonaws.com"},
+	})
+
+	ec2Endpoint, err := resolver.EndpointFor("ec2", "us-gov-west-1")
+	if err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	if ec2Endpoint.URL != "https://ec2-fips.us-gov-west-1.amazonaws.com" || ec2Endpoint.SigningRegion != "us-gov-west-1" {
+		t.Fatalf("Expected the overridden ec2 endpoint. Got %v", ec2Endpoint)
+	}
+
+	// Route53 is global, with one endpoint per partition
+	route53Endpoint, err := resolver.EndpointFor("route53", "cn-northwest-1")
+	if err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	if route53Endpoint.URL != "https://route53.amazonaws.com.cn" {
+		t.Fatalf("Expected the aws-cn route53 endpoint. Got %v", route53Endpoint)
+	}
+
+	// An air-gapped region the SDK doesn't know, with custom endpoints
+	isolated := serviceEndpointResolver([]cloudingressv1alpha1.AWSServiceEndpoint{
+		{Name: "ec2", URL: "https://ec2.us-isox-east-1.example.gov"},
+		{Name: "route53", URL: "https://route53.us-isox-east-1.example.gov", SigningRegion: "us-isox-east-1"},
+	})
+	ec2Endpoint, err = isolated.EndpointFor("ec2", "us-isox-east-1")
+	if err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	if ec2Endpoint.URL != "https://ec2.us-isox-east-1.example.gov" || ec2Endpoint.SigningRegion != "us-isox-east-1" {
+		t.Fatalf("Expected the custom ec2 endpoint signed for its region. Got %v", ec2Endpoint)
+	}
+	route53Endpoint, err = isolated.EndpointFor("route53", "us-isox-east-1")
+	if err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	if route53Endpoint.URL != "https://route53.us-isox-east-1.example.gov" || route53Endpoint.SigningRegion != "us-isox-east-1" {
+		t.Fatalf("Expected the custom route53 endpoint signed for its signing region. Got %v", route53Endpoint)
+	}
+}
+
+func TestMergeServiceEndpoints(t *testing.T) {
+	installEndpoints := []cloudingressv1alpha1.AWSServiceEndpoint{
+		{Name: "ec2", URL: "https://vpce-0a1b2c3d.ec2.us-east-1.vpce.amazonaws.com"},
+		{Name: "elasticloadbalancing", URL: "https://vpce-0e1f2a3b.elasticloadbalancing.us-east-1.vpce.amazonaws.com"},
+	}
+	overrides := []cloudingressv1alpha1.AWSServiceEndpoint{
+		{Name: "ec2", URL: "https://ec2.internal.example.com"},
+		{Name: "route53", URL: "https://route53.internal.example.com"},
+	}
+	expected := []cloudingressv1alpha1.AWSServiceEndpoint{
+		{Name: "elasticloadbalancing", URL: "https://vpce-0e1f2a3b.elasticloadbalancing.us-east-1.vpce.amazonaws.com"},
+		{Name: "ec2", URL: "https://ec2.internal.example.com"},
+		{Name: "route53", URL: "https://route53.internal.example.com"},
+	}
+	actual := mergeServiceEndpoints(installEndpoints, overrides)
+	if !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("Expected %v, got %v", expected, actual)
+	}
+}
+
+type mockInstanceHealth struct {
+	elbiface.ELBAPI
+	States map[string]string
+}
+
+func (m *mockInstanceHealth) DescribeLoadBalancers(_ *elb.DescribeLoadBalancersInput) (*elb.DescribeLoadBalancersOutput, error) {
+	return &elb.DescribeLoadBalancersOutput{
+		LoadBalancerDescriptions: []*elb.LoadBalancerDescription{
+			{
+				DNSName:                   aws.String("a0123456789.us-east-1.elb.amazonaws.com"),
+				CanonicalHostedZoneNameID: aws.String("AAAAAAAAAA"),
+			},
+		},
+	}, nil
+}
+
+func (m *mockInstanceHealth) DescribeInstanceHealth(_ *elb.DescribeInstanceHealthInput) (*elb.DescribeInstanceHealthOutput, error) {
+	output := &elb.DescribeInstanceHealthOutput{}
+	for instanceID, state := range m.States {
+		output.InstanceStates = append(output.InstanceStates, &elb.InstanceState{
+			InstanceId: aws.String(instanceID),
+			State:      aws.String(state),
+		})
+	}
+	return output, nil
+}
+
+func TestGetAdminAPIHealthyInstances(t *testing.T) {
+	tests := []struct {
+		Name     string
+		States   map[string]string
+		Expected []string
+	}{
+		{
+			Name:     "No registered instance",
+			Expected: []string{},
+		},
+		{
+			Name:     "Instances still failing their health checks",
+			States:   map[string]string{"i-0": "OutOfService", "i-1": "Unknown"},
+			Expected: []string{},
+		},
+		{
+			Name:     "Only the instances InService",
+			States:   map[string]string{"i-0": "OutOfService", "i-1": "InService"},
+			Expected: []string{"i-1"},
+		},
+	}
+	svc := &corev1.Service{ObjectMeta: metav1.ObjectMeta{UID: types.UID("0123456789")}}
+	for _, test := range tests {
+		client := &Client{elbClient: &mockInstanceHealth{States: test.States}}
+		actual, err := client.getAdminAPIHealthyInstances(context.TODO(), nil, nil, svc)
+		if err != nil {
+			t.Fatalf("Test [%v] unexpected error %v", test.Name, err)
+		}
+		if !reflect.DeepEqual(actual, test.Expected) {
+			t.Fatalf("Test [%v] FAILED. Expected %v. Got %v", test.Name, test.Expected, actual)
+		}
+	}
+}
+
+type mockInstancePlacement struct {
+	ec2iface.EC2API
+	Zones map[string]string
+}
+
+func (m *mockInstancePlacement) DescribeInstances(input *ec2.DescribeInstancesInput) (*ec2.DescribeInstancesOutput, error) {
+	reservation := &ec2.Reservation{}
+	for _, instanceID := range input.InstanceIds {
+		reservation.Instances = append(reservation.Instances, &ec2.Instance{
+			InstanceId: instanceID,
+			Placement:  &ec2.Placement{AvailabilityZone: aws.String(m.Zones[aws.StringValue(instanceID)])},
+		})
+	}
+	return &ec2.DescribeInstancesOutput{Reservations: []*ec2.Reservation{reservation}}, nil
+}
+
+type mockTaggedInstances struct {
+	ec2iface.EC2API
+	Instances []*ec2.Instance
+	Filters   []*ec2.Filter
+}
+
+func (m *mockTaggedInstances) DescribeInstances(input *ec2.DescribeInstancesInput) (*ec2.DescribeInstancesOutput, error) {
+	if len(input.InstanceIds) > 0 {
+		return nil, awserr.New("InvalidInstanceID.NotFound", "The instance IDs do not exist", nil)
+	}
+	m.Filters = input.Filters
+	return &ec2.DescribeInstancesOutput{Reservations: []*ec2.Reservation{{Instances: m.Instances}}}, nil
+}
+
+func TestDiscoverMasterInstances(t *testing.T) {
+	infraObj := testutils.CreateInfraObject("discover-test", testutils.DefaultAPIEndpoint, testutils.DefaultAPIEndpoint, testutils.DefaultRegionName)
+	mocks := testutils.NewTestMock(t, []runtime.Object{infraObj})
+	tests := []struct {
+		Name          string
+		Instances     []*ec2.Instance
+		ExpectedVPC   string
+		ExpectedZones []string
+		ErrorExpected bool
+	}{
+		{
+			Name: "Masters found by their tags",
+			Instances: []*ec2.Instance{
+				{InstanceId: aws.String("i-1"), VpcId: aws.String("vpc-1"), Placement: &ec2.Placement{AvailabilityZone: aws.String("us-east-1b")}},
+				{InstanceId: aws.String("i-0"), VpcId: aws.String("vpc-1"), Placement: &ec2.Placement{AvailabilityZone: aws.String("us-east-1a")}},
+			},
+			ExpectedVPC:   "vpc-1",
+			ExpectedZones: []string{"us-east-1a", "us-east-1b"},
+		},
+		{
+			Name:          "No tagged instance",
+			ErrorExpected: true,
+		},
+	}
+	for _, test := range tests {
+		mock := &mockTaggedInstances{Instances: test.Instances}
+		client := &Client{ec2Client: mock}
+		vpcID, err := client.getClusterVPC(mocks.FakeKubeClient)
+		if (err != nil) != test.ErrorExpected || vpcID != test.ExpectedVPC {
+			t.Fatalf("Test [%v] FAILED. Expected VPC %v. Got %v, %v", test.Name, test.ExpectedVPC, vpcID, err)
+		}
+		zones, err := client.getMasterAvailabilityZones(mocks.FakeKubeClient)
+		if (err != nil) != test.ErrorExpected || (!test.ErrorExpected && !reflect.DeepEqual(zones, test.ExpectedZones)) {
+			t.Fatalf("Test [%v] FAILED. Expected zones %v. Got %v, %v", test.Name, test.ExpectedZones, zones, err)
+		}
+		if len(mock.Filters) != 3 || aws.StringValue(mock.Filters[0].Name) != "tag:kubernetes.io/cluster/discover-test" {
+			t.Fatalf("Test [%v] FAILED. Expected the instances filtered on the cluster tag. Got %v", test.Name, mock.Filters)
+		}
+	}
+}
+
+func TestGetAdminAPIInstanceHealth(t *testing.T) {
+	client := &Client{
+		elbClient: &mockInstanceHealth{States: map[string]string{"i-0": "InService"}},
+		ec2Client: &mockInstancePlacement{Zones: map[string]string{"i-0": "us-east-1a"}},
+	}
+	svc := &corev1.Service{ObjectMeta: metav1.ObjectMeta{UID: types.UID("0123456789")}}
+	actual, err := client.getAdminAPIInstanceHealth(context.TODO(), nil, nil, svc)
+	if err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	expected := []cloudingressv1alpha1.RegisteredInstance{
+		{InstanceID: "i-0", AvailabilityZone: "us-east-1a", State: "InService"},
+	}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("Expected %v, got %v", expected, actual)
+	}
+}
+
+type mockDeleteClassicELB struct {
+	elbiface.ELBAPI
+	Exists bool
+	Calls  []string
+}
+
+func (m *mockDeleteClassicELB) DescribeLoadBalancers(_ *elb.DescribeLoadBalancersInput) (*elb.DescribeLoadBalancersOutput, error) {
+	if !m.Exists {
+		return nil, awserr.New(elb.ErrCodeAccessPointNotFoundException, "There is no ACTIVE Load Balancer named 'a0123456789'", nil)
+	}
+	return &elb.DescribeLoadBalancersOutput{
+		LoadBalancerDescriptions: []*elb.LoadBalancerDescription{
+			{
+				Instances: []*elb.Instance{{InstanceId: aws.String("i-0")}},
+				ListenerDescriptions: []*elb.ListenerDescription{
+					{Listener: &elb.Listener{LoadBalancerPort: aws.Int64(6443)}},
+				},
+			},
+		},
+	}, nil
+}
+
+func (m *mockDeleteClassicELB) DeregisterInstancesFromLoadBalancer(_ *elb.DeregisterInstancesFromLoadBalancerInput) (*elb.DeregisterInstancesFromLoadBalancerOutput, error) {
+	m.Calls = append(m.Calls, "DeregisterInstancesFromLoadBalancer")
+	return &elb.DeregisterInstancesFromLoadBalancerOutput{}, nil
+}
+
+func (m *mockDeleteClassicELB) DeleteLoadBalancerListeners(_ *elb.DeleteLoadBalancerListenersInput) (*elb.DeleteLoadBalancerListenersOutput, error) {
+	m.Calls = append(m.Calls, "DeleteLoadBalancerListeners")
+	return &elb.DeleteLoadBalancerListenersOutput{}, nil
+}
+
+func (m *mockDeleteClassicELB) DeleteLoadBalancer(_ *elb.DeleteLoadBalancerInput) (*elb.DeleteLoadBalancerOutput, error) {
+	m.Calls = append(m.Calls, "DeleteLoadBalancer")
+	return &elb.DeleteLoadBalancerOutput{}, nil
+}
+
+func TestDeleteClassicELB(t *testing.T) {
+	tests := []struct {
+		Name     string
+		Exists   bool
+		Expected []string
+	}{
+		{
+			Name: "Load balancer already gone",
+		},
+		{
+			Name:     "Instances and listeners go first",
+			Exists:   true,
+			Expected: []string{"DeregisterInstancesFromLoadBalancer", "DeleteLoadBalancerListeners", "DeleteLoadBalancer"},
+		},
+	}
+	for _, test := range tests {
+		mock := &mockDeleteClassicELB{Exists: test.Exists}
+		client := &Client{elbClient: mock}
+		err := client.deleteClassicELB("a0123456789")
+		if err != nil {
+			t.Fatalf("Test [%v] unexpected error %v", test.Name, err)
+		}
+		if !reflect.DeepEqual(mock.Calls, test.Expected) {
+			t.Fatalf("Test [%v] FAILED. Expected %v. Got %v", test.Name, test.Expected, mock.Calls)
+		}
+	}
+}
+
+type mockClassicELBTLSPolicy struct {
+	elbiface.ELBAPI
+	Listeners []*elb.ListenerDescription
+	Policies  []*elb.PolicyDescription
+	Created   []string
+	Set       map[int64][]string
+}
+
+func (m *mockClassicELBTLSPolicy) DescribeLoadBalancers(_ *elb.DescribeLoadBalancersInput) (*elb.DescribeLoadBalancersOutput, error) {
+	return &elb.DescribeLoadBalancersOutput{
+		LoadBalancerDescriptions: []*elb.LoadBalancerDescription{{ListenerDescriptions: m.Listeners}},
+	}, nil
+}
+
+func (m *mockClassicELBTLSPolicy) DescribeLoadBalancerPolicies(_ *elb.DescribeLoadBalancerPoliciesInput) (*elb.DescribeLoadBalancerPoliciesOutput, error) {
+	return &elb.DescribeLoadBalancerPoliciesOutput{PolicyDescriptions: m.Policies}, nil
+}
+
+func (m *mockClassicELBTLSPolicy) CreateLoadBalancerPolicy(i *elb.CreateLoadBalancerPolicyInput) (*elb.CreateLoadBalancerPolicyOutput, error) {
+	m.Created = append(m.Created, aws.StringValue(i.PolicyName))
+	return &elb.CreateLoadBalancerPolicyOutput{}, nil
+}
+
+func (m *mockClassicELBTLSPolicy) SetLoadBalancerPoliciesOfListener(i *elb.SetLoadBalancerPoliciesOfListenerInput) (*elb.SetLoadBalancerPoliciesOfListenerOutput, error) {
+	if m.Set == nil {
+		m.Set = map[int64][]string{}
+	}
+	m.Set[aws.Int64Value(i.LoadBalancerPort)] = aws.StringValueSlice(i.PolicyNames)
+	return &elb.SetLoadBalancerPoliciesOfListenerOutput{}, nil
+}
+
+type mockELBMembers struct {
+	elbiface.ELBAPI
+	Members      []string
+	Registered   []string
+	Deregistered []string
+}
+
+func (m *mockELBMembers) DescribeInstanceHealth(_ *elb.DescribeInstanceHealthInput) (*elb.DescribeInstanceHealthOutput, error) {
+	output := &elb.DescribeInstanceHealthOutput{}
+	for _, instanceID := range m.Members {
+		output.InstanceStates = append(output.InstanceStates, &elb.InstanceState{
+			InstanceId: aws.String(instanceID),
+			State:      aws.String("InService"),
+		})
+	}
+	return output, nil
+}
+
+func (m *mockELBMembers) RegisterInstancesWithLoadBalancer(input *elb.RegisterInstancesWithLoadBalancerInput) (*elb.RegisterInstancesWithLoadBalancerOutput, error) {
+	for _, instance := range input.Instances {
+		m.Registered = append(m.Registered, aws.StringValue(instance.InstanceId))
+	}
+	return &elb.RegisterInstancesWithLoadBalancerOutput{}, nil
+}
+
+func (m *mockELBMembers) DeregisterInstancesFromLoadBalancer(input *elb.DeregisterInstancesFromLoadBalancerInput) (*elb.DeregisterInstancesFromLoadBalancerOutput, error) {
+	for _, instance := range input.Instances {
+		m.Deregistered = append(m.Deregistered, aws.StringValue(instance.InstanceId))
+	}
+	return &elb.DeregisterInstancesFromLoadBalancerOutput{}, nil
+}
+
+func TestSyncClassicELBInstances(t *testing.T) {
+	node := func(instanceID string) runtime.Object {
+		return &corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: "ip-" + instanceID},
+			Spec:       corev1.NodeSpec{ProviderID: "aws:///us-east-1a/" + instanceID},
+			Status: corev1.NodeStatus{
+				Conditions: []corev1.NodeCondition{{Type: corev1.NodeReady, Status: corev1.ConditionTrue}},
+			},
+		}
+	}
+	tests := []struct {
+		Name                 string
+		Members              []string
+		ExpectedRegistered   []string
+		ExpectedDeregistered []string
+	}{
+		{
+			Name:    "Membership unchanged",
+			Members: []string{"i-b", "i-a"},
+		},
+		{
+			Name:               "Node added",
+			Members:            []string{"i-a"},
+			ExpectedRegistered: []string{"i-b"},
+		},
+		{
+			Name:                 "Node replaced",
+			Members:              []string{"i-a", "i-c"},
+			ExpectedRegistered:   []string{"i-b"},
+			ExpectedDeregistered: []string{"i-c"},
+		},
+	}
+	for _, test := range tests {
+		mocks := testutils.NewTestMock(t, []runtime.Object{node("i-a"), node("i-b")})
+		mock := &mockELBMembers{Members: test.Members}
+		client := &Client{elbClient: mock}
+		err := client.syncClassicELBInstances(mocks.FakeKubeClient, "a0123456789", &corev1.Service{})
+		if err != nil {
+			t.Fatalf("Test [%v] unexpected error %v", test.Name, err)
+		}
+		if !reflect.DeepEqual(mock.Registered, test.ExpectedRegistered) {
+			t.Fatalf("Test [%v] FAILED. Expected to register %v. Got %v", test.Name, test.ExpectedRegistered, mock.Registered)
+		}
+		if !reflect.DeepEqual(mock.Deregistered, test.ExpectedDeregistered) {
+			t.Fatalf("Test [%v] FAILED. Expected to deregister %v. Got %v", test.Name, test.ExpectedDeregistered, mock.Deregistered)
+		}
+	}
+}
+
+func TestSetClassicELBTLSPolicy(t *testing.T) {
+	listener := func(protocol string, port int64, policyNames ...string) *elb.ListenerDescription {
+		return &elb.ListenerDescription{
+			Listener:    &elb.Listener{Protocol: aws.String(protocol), LoadBalancerPort: aws.Int64(port)},
+			PolicyNames: aws.StringSlice(policyNames),
+		}
+	}
+	negotiationPolicy := func(name string) *elb.PolicyDescription {
+		return &elb.PolicyDescription{PolicyName: aws.String(name), PolicyTypeName: aws.String(sslNegotiationPolicyType)}
+	}
+	policyName := tlsPolicyNamePrefix + "ELBSecurityPolicy-TLS-1-2-2017-01"
+	tests := []struct {
+		Name            string
+		Listeners       []*elb.ListenerDescription
+		Policies        []*elb.PolicyDescription
+		ExpectedCreated []string
+		ExpectedSet     map[int64][]string
+	}{
+		{
+			Name:      "TCP listeners are left alone",
+			Listeners: []*elb.ListenerDescription{listener("TCP", 6443)},
+		},
+		{
+			Name:            "The policy is created and replaces the old one",
+			Listeners:       []*elb.ListenerDescription{listener("TCP", 6443), listener("SSL", 443, "ELBSecurityPolicy-2016-08", "sticky")},
+			Policies:        []*elb.PolicyDescription{negotiationPolicy("ELBSecurityPolicy-2016-08"), {PolicyName: aws.String("sticky")}},
+			ExpectedCreated: []string{policyName},
+			ExpectedSet:     map[int64][]string{443: {policyName, "sticky"}},
+		},
+		{
+			Name:      "A listener with the policy is left alone",
+			Listeners: []*elb.ListenerDescription{listener("HTTPS", 443, policyName)},
+			Policies:  []*elb.PolicyDescription{negotiationPolicy(policyName)},
+		},
+	}
+	for _, test := range tests {
+		mock := &mockClassicELBTLSPolicy{Listeners: test.Listeners, Policies: test.Policies}
+		client := &Client{elbClient: mock}
+		err := client.setClassicELBTLSPolicy("a0123456789", "ELBSecurityPolicy-TLS-1-2-2017-01")
+		if err != nil {
+			t.Fatalf("Test [%v] unexpected error %v", test.Name, err)
+		}
+		if !reflect.DeepEqual(mock.Created, test.ExpectedCreated) {
+			t.Fatalf("Test [%v] FAILED. Expected to create %v. Got %v", test.Name, test.ExpectedCreated, mock.Created)
+		}
+		if !reflect.DeepEqual(mock.Set, test.ExpectedSet) {
+			t.Fatalf("Test [%v] FAILED. Expected to set %v. Got %v", test.Name, test.ExpectedSet, mock.Set)
+		}
+	}
+}
+
+type mockClassicELBCertificate struct {
+	elbiface.ELBAPI
+	Listeners []*elb.ListenerDescription
+	Swapped   map[int64]string
+}
+
+func (m *mockClassicELBCertificate) DescribeLoadBalancers(_ *elb.DescribeLoadBalancersInput) (*elb.DescribeLoadBalancersOutput, error) {
+	return &elb.DescribeLoadBalancersOutput{
+		LoadBalancerDescriptions: []*elb.LoadBalancerDescription{{ListenerDescriptions: m.Listeners}},
+	}, nil
+}
+
+func (m *mockClassicELBCertificate) SetLoadBalancerListenerSSLCertificate(i *elb.SetLoadBalancerListenerSSLCertificateInput) (*elb.SetLoadBalancerListenerSSLCertificateOutput, error) {
+	if m.Swapped == nil {
+		m.Swapped = map[int64]string{}
+	}
+	m.Swapped[aws.Int64Value(i.LoadBalancerPort)] = aws.StringValue(i.SSLCertificateId)
+	return &elb.SetLoadBalancerListenerSSLCertificateOutput{}, nil
+}
+
+func TestSetClassicELBCertificate(t *testing.T) {
+	listener := func(protocol string, port int64, certificateARN string) *elb.ListenerDescription {
+		return &elb.ListenerDescription{
+			Listener: &elb.Listener{Protocol: aws.String(protocol), LoadBalancerPort: aws.Int64(port), SSLCertificateId: aws.String(certificateARN)},
+		}
+	}
+	oldARN := "arn:aws:acm:us-east-1:123456789012:certificate/old"
+	newARN := "arn:aws:acm:us-east-1:123456789012:certificate/new"
+	tests := []struct {
+		Name            string
+		Listeners       []*elb.ListenerDescription
+		ExpectedSwapped map[int64]string
+	}{
+		{
+			Name:      "TCP listeners are left alone",
+			Listeners: []*elb.ListenerDescription{listener("TCP", 6443, "")},
+		},
+		{
+			Name:            "A renewed certificate is swapped in",
+			Listeners:       []*elb.ListenerDescription{listener("TCP", 6443, ""), listener("SSL", 443, oldARN)},
+			ExpectedSwapped: map[int64]string{443: newARN},
+		},
+		{
+			Name:      "A listener with the certificate is left alone",
+			Listeners: []*elb.ListenerDescription{listener("HTTPS", 443, newARN)},
+		},
+	}
+	for _, test := range tests {
+		mock := &mockClassicELBCertificate{Listeners: test.Listeners}
+		client := &Client{elbClient: mock}
+		swapped, err := client.setClassicELBCertificate("a0123456789", newARN)
+		if err != nil {
+			t.Fatalf("Test [%v] unexpected error %v", test.Name, err)
+		}
+		if !reflect.DeepEqual(mock.Swapped, test.ExpectedSwapped) {
+			t.Fatalf("Test [%v] FAILED. Expected to swap %v. Got %v", test.Name, test.ExpectedSwapped, mock.Swapped)
+		}
+		if len(swapped) != len(test.ExpectedSwapped) {
+			t.Fatalf("Test [%v] FAILED. Expected the ports %v. Got %v", test.Name, test.ExpectedSwapped, swapped)
+		}
+	}
+}
+
+type mockACMCertificate struct {
+	acmiface.ACMAPI
+	Serial string
+}
+
+func (m *mockACMCertificate) DescribeCertificate(_ *acm.DescribeCertificateInput) (*acm.DescribeCertificateOutput, error) {
+	return &acm.DescribeCertificateOutput{Certificate: &acm.CertificateDetail{Serial: aws.String(m.Serial)}}, nil
+}
+
+func TestVerifyServedCertificate(t *testing.T) {
+	defer func(timeout, interval time.Duration) {
+		certificateCheckTimeout, certificateCheckInterval = timeout, interval
+	}(certificateCheckTimeout, certificateCheckInterval)
+	certificateCheckTimeout, certificateCheckInterval = 10*time.Millisecond, time.Millisecond
+	defer func(dial func(string) (*x509.Certificate, error)) { dialServedCertificate = dial }(dialServedCertificate)
+
+	tests := []struct {
+		Name          string
+		Served        []int64
+		DialErr       error
+		ErrorExpected bool
+	}{
+		{
+			Name:   "The new certificate is served",
+			Served: []int64{0x0a1b},
+		},
+		{
+			Name:   "The new certificate is served once the swap propagates",
+			Served: []int64{0x0c, 0x0c, 0x0a1b},
+		},
+		{
+			Name:          "The old certificate is still served",
+			Served:        []int64{0x0c},
+			ErrorExpected: true,
+		},
+		{
+			Name:    "The load balancer can't be reached",
+			DialErr: fmt.Errorf("i/o timeout"),
+		},
+	}
+	for _, test := range tests {
+		dials := 0
+		dialServedCertificate = func(address string) (*x509.Certificate, error) {
+			if address != "rh-api.elb.amazonaws.com:443" {
+				t.Fatalf("Test [%v] FAILED. Unexpected address %v", test.Name, address)
+			}
+			if test.DialErr != nil {
+				return nil, test.DialErr
+			}
+			serial := test.Served[len(test.Served)-1]
+			if dials < len(test.Served) {
+				serial = test.Served[dials]
+			}
+			dials++
+			return &x509.Certificate{SerialNumber: big.NewInt(serial)}, nil
+		}
+		client := &Client{acmClient: &mockACMCertificate{Serial: "00:00:0a:1b"}}
+		err := client.verifyServedCertificate("rh-api.elb.amazonaws.com", []int64{443}, "arn:aws:acm:us-east-1:123456789012:certificate/new")
+		if test.ErrorExpected != (err != nil) {
+			t.Fatalf("Test [%v] FAILED. Expected an error: %v. Got %v", test.Name, test.ErrorExpected, err)
+		}
+	}
+}
+
+func TestSubnetChanges(t *testing.T) {
+	zones := map[string]string{
+		"subnet-a1": "us-east-1a",
+		"subnet-a2": "us-east-1a",
+		"subnet-b1": "us-east-1b",
+		"subnet-c1": "us-east-1c",
+	}
+	tests := []struct {
+		Name                string
+		Current             []string
+		Desired             []string
+		ExpectedAttachFirst []string
+		ExpectedDetach      []string
+		ExpectedAttachLast  []string
+	}{
+		{
+			Name:    "No change",
+			Current: []string{"subnet-a1", "subnet-b1"},
+			Desired: []string{"subnet-a1", "subnet-b1"},
+		},
+		{
+			Name:                "The cluster expanded into a new zone",
+			Current:             []string{"subnet-a1", "subnet-b1"},
+			Desired:             []string{"subnet-a1", "subnet-b1", "subnet-c1"},
+			ExpectedAttachFirst: []string{"subnet-c1"},
+		},
+		{
+			Name:               "A subnet is replaced in its zone",
+			Current:            []string{"subnet-a1", "subnet-b1"},
+			Desired:            []string{"subnet-a2", "subnet-b1"},
+			ExpectedDetach:     []string{"subnet-a1"},
+			ExpectedAttachLast: []string{"subnet-a2"},
+		},
+		{
+			Name:                "The only subnet moves to another zone",
+			Current:             []string{"subnet-a1"},
+			Desired:             []string{"subnet-c1"},
+			ExpectedAttachFirst: []string{"subnet-c1"},
+			ExpectedDetach:      []string{"subnet-a1"},
+		},
+	}
+	for _, test := range tests {
+		attachFirst, detach, attachLast := subnetChanges(test.Current, test.Desired, zones)
+		if !reflect.DeepEqual(attachFirst, test.ExpectedAttachFirst) || !reflect.DeepEqual(detach, test.ExpectedDetach) || !reflect.DeepEqual(attachLast, test.ExpectedAttachLast) {
+			t.Fatalf("Test [%v] FAILED. Expected %v, %v, %v. Got %v, %v, %v", test.Name,
+				test.ExpectedAttachFirst, test.ExpectedDetach, test.ExpectedAttachLast, attachFirst, detach, attachLast)
+		}
+	}
+}
+
+func TestPrincipalArn(t *testing.T) {
+	tests := []struct {
+		Name        string
+		CallerArn   string
+		Expected    string
+		ExpectedErr bool
+	}{
+		{
+			Name:      "IAM user",
+			CallerArn: "arn:aws:iam::123456789012:user/cloud-ingress-operator",
+			Expected:  "arn:aws:iam::123456789012:user/cloud-ingress-operator",
+		},
+		{
+			Name:      "Assumed role",
+			CallerArn: "arn:aws:sts::123456789012:assumed-role/cloud-ingress-operator/1634567890",
+			Expected:  "arn:aws:iam::123456789012:role/cloud-ingress-operator",
+		},
+		{
+			Name:      "Assumed role in GovCloud",
+			CallerArn: "arn:aws-us-gov:sts::123456789012:assumed-role/cloud-ingress-operator/1634567890",
+			Expected:  "arn:aws-us-gov:iam::123456789012:role/cloud-ingress-operator",
+		},
+		{
+			Name:        "Federated user",
+			CallerArn:   "arn:aws:sts::123456789012:federated-user/someone",
+			ExpectedErr: true,
+		},
+	}
+	for _, test := range tests {
+		actual, err := principalArn(test.CallerArn)
+		if (err != nil) != test.ExpectedErr || actual != test.Expected {
+			t.Fatalf("Test [%v] FAILED. Expected %v. Got %v, %v", test.Name, test.Expected, actual, err)
+		}
+	}
+}
+
+// TestRequiredActions checks that every AWS call made by the package is in
+// requiredActions, so checkPermissions doesn't miss a denied one
+func TestRequiredActions(t *testing.T) {
+	// the IAM action prefix of the services of each SDK client of Client
+	prefixes := map[string]string{
+		"acmClient":        "acm",
+		"cloudWatchClient": "cloudwatch",
+		"ec2Client":        "ec2",
+		"elbClient":        "elasticloadbalancing",
+		"elbv2Client":      "elasticloadbalancing",
+		"iamClient":        "iam",
+		"kmsClient":        "kms",
+		"route53Client":    "route53",
+		"s3Client":         "s3",
+		"stsClient":        "sts",
+	}
+	// the IAM actions not named after their call
+	renamed := map[string]string{
+		"s3:GetBucketEncryption": "s3:GetEncryptionConfiguration",
+	}
+	// sts:GetCallerIdentity needs no permission, and can't be denied
+	required := map[string]bool{"sts:GetCallerIdentity": true}
+	for _, action := range requiredActions {
+		required[action] = true
+	}
+
+	files, err := filepath.Glob("*.go")
+	if err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	fset := token.NewFileSet()
+	for _, file := range files {
+		if strings.HasSuffix(file, "_test.go") {
+			continue
+		}
+		f, err := parser.ParseFile(fset, file, nil, 0)
+		if err != nil {
+			t.Fatalf("Couldn't parse %s: %v", file, err)
+		}
+		ast.Inspect(f, func(node ast.Node) bool {
+			call, ok := node.(*ast.CallExpr)
+			if !ok {
+				return true
+			}
+			// eg c.elbClient.DescribeLoadBalancers(...)
+			method, ok := call.Fun.(*ast.SelectorExpr)
+			if !ok {
+				return true
+			}
+			field, ok := method.X.(*ast.SelectorExpr)
+			if !ok || !strings.HasSuffix(field.Sel.Name, "Client") {
+				return true
+			}
+			prefix, ok := prefixes[field.Sel.Name]
+			if !ok {
+				t.Fatalf("%s: no IAM action prefix for the calls of %s", fset.Position(call.Pos()), field.Sel.Name)
+			}
+			operation := strings.TrimSuffix(strings.TrimSuffix(method.Sel.Name, "WithContext"), "Pages")
+			action := prefix + ":" + operation
+			if name, ok := renamed[action]; ok {
+				action = name
+			}
+			if !required[action] {
+				t.Fatalf("%s: %s isn't in requiredActions", fset.Position(call.Pos()), action)
+			}
+			return true
+		})
+	}
+}
+
+func TestHealthCheckTarget(t *testing.T) {
+	tests := []struct {
+		Name                string
+		Annotations         map[string]string
+		HealthCheckNodePort int32
+		Expected            string
+	}{
+		{
+			Name:     "No annotations",
+			Expected: "TCP:30443",
+		},
+		{
+			Name: "HTTPS with a path",
+			Annotations: map[string]string{
+				healthCheckProtocolAnnotationKey: "HTTPS",
+				healthCheckPathAnnotationKey:     "/readyz",
+			},
+			Expected: "HTTPS:30443/readyz",
+		},
+		{
+			Name:        "HTTP without a path",
+			Annotations: map[string]string{healthCheckProtocolAnnotationKey: "http"},
+			Expected:    "HTTP:30443/",
+		},
+		{
+			Name: "SSL ignores the path",
+			Annotations: map[string]string{
+				healthCheckProtocolAnnotationKey: "SSL",
+				healthCheckPathAnnotationKey:     "/readyz",
+			},
+			Expected: "SSL:30443",
+		},
+		{
+			Name:                "The health check node port wins",
+			Annotations:         map[string]string{healthCheckProtocolAnnotationKey: "HTTPS"},
+			HealthCheckNodePort: 31000,
+			Expected:            "HTTP:31000/healthz",
+		},
+	}
+	for _, test := range tests {
+		svc := &corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Annotations: test.Annotations},
+			Spec: corev1.ServiceSpec{
+				Ports:               []corev1.ServicePort{{Port: 6443, NodePort: 30443}},
+				HealthCheckNodePort: test.HealthCheckNodePort,
+			},
+		}
+		if actual := healthCheckTarget(svc); actual != test.Expected {
+			t.Fatalf("Test [%v] FAILED. Expected %v. Got %v", test.Name, test.Expected, actual)
+		}
+	}
+}
+
+func TestClassifyError(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected error
+	}{
+		{
+			name:     "load balancer not found",
+			err:      awserr.New("LoadBalancerNotFound", "There is no ACTIVE Load Balancer named 'rh-api'", nil),
+			expected: cioerrors.ErrNotFound,
+		},
+		{
+			name:     "hosted zone not found",
+			err:      awserr.New("NoSuchHostedZone", "No hosted zone found with ID: Z1", nil),
+			expected: cioerrors.ErrNotFound,
+		},
+		{
+			name:     "throttled",
+			err:      awserr.New("Throttling", "Rate exceeded", nil),
+			expected: cioerrors.ErrThrottled,
+		},
+		{
+			name:     "EC2 rate limit",
+			err:      awserr.New("RequestLimitExceeded", "Request limit exceeded.", nil),
+			expected: cioerrors.ErrThrottled,
+		},
+		{
+			name:     "permission denied",
+			err:      awserr.New("UnauthorizedOperation", "You are not authorized to perform this operation.", nil),
+			expected: cioerrors.ErrPermissionDenied,
+		},
+		{
+			name:     "dependency violation",
+			err:      awserr.New("DependencyViolation", "resource sg-1 has a dependent object", nil),
+			expected: cioerrors.ErrDependencyViolation,
+		},
+		{
+			name:     "server error",
+			err:      awserr.NewRequestFailure(awserr.New("InternalError", "An internal error has occurred", nil), 500, "1"),
+			expected: cioerrors.ErrTransient,
+		},
+		{
+			name:     "dry run",
+			err:      awserr.New(dryRunErrorCode, "Request would have succeeded", nil),
+			expected: nil,
+		},
+		{
+			name:     "not an AWS error",
+			err:      cioerrors.NewLoadBalancerNotReadyError(),
+			expected: nil,
+		},
+	}
+	kinds := []error{cioerrors.ErrNotFound, cioerrors.ErrThrottled, cioerrors.ErrPermissionDenied, cioerrors.ErrDependencyViolation, cioerrors.ErrTransient}
+	for _, test := range tests {
+		actual := classifyError(test.err)
+		if actual.Error() != test.err.Error() {
+			t.Errorf("%s: expected the message %q to be kept, got %q", test.name, test.err.Error(), actual.Error())
+		}
+		for _, kind := range kinds {
+			if goerrors.Is(actual, kind) != (kind == test.expected) {
+				t.Errorf("%s: expected kind %v, got %v", test.name, test.expected, actual)
+			}
+		}
+	}
+	if classifyError(nil) != nil {
+		t.Errorf("Expected no error to stay nil")
+	}
+}
+
+func TestDescribeRequestFailure(t *testing.T) {
+	failure := awserr.NewRequestFailure(awserr.New("UnauthorizedOperation", "You are not authorized to perform this operation.", nil), 403, "a1b2c3d4")
+	tests := []struct {
+		name     string
+		err      error
+		expected string
+	}{
+		{
+			name:     "request failure",
+			err:      classifyError(failure),
+			expected: "UnauthorizedOperation: You are not authorized to perform this operation. (error code: UnauthorizedOperation, request ID: a1b2c3d4)",
+		},
+		{
+			name:     "wrapped request failure",
+			err:      classifyError(fmt.Errorf("couldn't create the security group: %w", failure)),
+			expected: "couldn't create the security group: UnauthorizedOperation: You are not authorized to perform this operation. (error code: UnauthorizedOperation, request ID: a1b2c3d4)",
+		},
+		{
+			name:     "no request ID",
+			err:      classifyError(awserr.New("Throttling", "Rate exceeded", nil)),
+			expected: "Throttling: Rate exceeded",
+		},
+	}
+	for _, test := range tests {
+		if actual := cioerrors.Describe(test.err); actual != test.expected {
+			t.Errorf("%s: expected %q, got %q", test.name, test.expected, actual)
+		}
+	}
+}
+
+func TestSecretCredentialsProviderRotation(t *testing.T) {
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: config.AWSSecretName, Namespace: config.OperatorNamespace},
+		Data: map[string][]byte{
+			"aws_access_key_id":     []byte("AKIAOLD"),
+			"aws_secret_access_key": []byte("old"),
+		},
+	}
+	mocks := testutils.NewTestMock(t, []runtime.Object{secret})
+	provider, err := newSecretCredentialsProvider(mocks.FakeKubeClient)
+	if err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+
+	secret.Data["aws_access_key_id"] = []byte("AKIANEW")
+	secret.Data["aws_secret_access_key"] = []byte("new")
+	if err := mocks.FakeKubeClient.Update(context.TODO(), secret); err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	// The Secret isn't read again before credentialsRecheckInterval
+	if provider.IsExpired() {
+		t.Fatalf("Expected the keys to be kept until the Secret is read again")
+	}
+	// unless a call failed to authenticate
+	provider.recheckOnAuthFailure(&request.Request{Error: awserr.New("AuthFailure", "AWS was not able to validate the provided access credentials", nil)})
+	if !provider.IsExpired() {
+		t.Fatalf("Expected the keys to expire once the Secret changed")
+	}
+	value, err := provider.Retrieve()
+	if err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	if value.AccessKeyID != "AKIANEW" || value.SecretAccessKey != "new" {
+		t.Fatalf("Expected the rotated keys. Got %v", value.AccessKeyID)
+	}
+	if provider.IsExpired() {
+		t.Fatalf("Expected the rotated keys not to expire again")
+	}
+}
+
+type mockLoadBalancerQuota struct {
+	elbv2iface.ELBV2API
+	Limits        []*elbv2.Limit
+	LoadBalancers []*elbv2.LoadBalancer
+}
+
+func (m *mockLoadBalancerQuota) DescribeAccountLimits(_ *elbv2.DescribeAccountLimitsInput) (*elbv2.DescribeAccountLimitsOutput, error) {
+	return &elbv2.DescribeAccountLimitsOutput{Limits: m.Limits}, nil
+}
+
+func (m *mockLoadBalancerQuota) DescribeLoadBalancersPages(_ *elbv2.DescribeLoadBalancersInput, fn func(*elbv2.DescribeLoadBalancersOutput, bool) bool) error {
+	fn(&elbv2.DescribeLoadBalancersOutput{LoadBalancers: m.LoadBalancers}, true)
+	return nil
+}
+
+func TestGetLoadBalancerQuota(t *testing.T) {
+	limits := []*elbv2.Limit{
+		{Name: aws.String("application-load-balancers"), Max: aws.String("50")},
+		{Name: aws.String("network-load-balancers"), Max: aws.String("3")},
+	}
+	tests := []struct {
+		Name          string
+		Limits        []*elbv2.Limit
+		LoadBalancers []*elbv2.LoadBalancer
+		ExpectedUsed  int
+		ExpectedLimit int
+		ErrorExpected bool
+	}{
+		{
+			Name:          "No load balancer",
+			Limits:        limits,
+			ExpectedLimit: 3,
+		},
+		{
+			Name:   "Only network load balancers count",
+			Limits: limits,
+			LoadBalancers: []*elbv2.LoadBalancer{
+				{Type: aws.String(elbv2.LoadBalancerTypeEnumNetwork)},
+				{Type: aws.String(elbv2.LoadBalancerTypeEnumApplication)},
+				{Type: aws.String(elbv2.LoadBalancerTypeEnumNetwork)},
+			},
+			ExpectedUsed:  2,
+			ExpectedLimit: 3,
+		},
+		{
+			Name:          "No network load balancers limit",
+			Limits:        limits[:1],
+			ErrorExpected: true,
+		},
+	}
+	for _, test := range tests {
+		client := &Client{elbv2Client: &mockLoadBalancerQuota{Limits: test.Limits, LoadBalancers: test.LoadBalancers}}
+		used, limit, err := client.getLoadBalancerQuota(context.TODO(), nil, true)
+		if (err != nil) != test.ErrorExpected {
+			t.Fatalf("Test [%v] FAILED. Expected an error: %v. Got %v", test.Name, test.ErrorExpected, err)
+		}
+		if used != test.ExpectedUsed || limit != test.ExpectedLimit {
+			t.Fatalf("Test [%v] FAILED. Expected %v of %v. Got %v of %v", test.Name, test.ExpectedUsed, test.ExpectedLimit, used, limit)
+		}
+	}
+}
+
+func TestWithAdminAPILoadBalancer(t *testing.T) {
+	ext := awsproviderapi.LoadBalancerReference{Name: "test-ext", Type: awsproviderapi.NetworkLoadBalancerType}
+	internal := awsproviderapi.LoadBalancerReference{Name: "test-int", Type: awsproviderapi.NetworkLoadBalancerType}
+	admin := awsproviderapi.LoadBalancerReference{Name: "admin", Type: awsproviderapi.ClassicLoadBalancerType}
+	recreated := awsproviderapi.LoadBalancerReference{Name: "recreated", Type: awsproviderapi.ClassicLoadBalancerType}
+	tests := []struct {
+		Name     string
+		LBList   []awsproviderapi.LoadBalancerReference
+		Previous string
+		Ref      *awsproviderapi.LoadBalancerReference
+		Expected []awsproviderapi.LoadBalancerReference
+	}{
+		{
+			Name:     "Added",
+			LBList:   []awsproviderapi.LoadBalancerReference{ext, internal},
+			Ref:      &admin,
+			Expected: []awsproviderapi.LoadBalancerReference{ext, internal, admin},
+		},
+		{
+			Name:     "Already there",
+			LBList:   []awsproviderapi.LoadBalancerReference{ext, admin, internal},
+			Previous: "admin",
+			Ref:      &admin,
+			Expected: []awsproviderapi.LoadBalancerReference{ext, internal, admin},
+		},
+		{
+			Name:     "Replaced after the Service was recreated",
+			LBList:   []awsproviderapi.LoadBalancerReference{ext, internal, admin},
+			Previous: "admin",
+			Ref:      &recreated,
+			Expected: []awsproviderapi.LoadBalancerReference{ext, internal, recreated},
+		},
+		{
+			Name:     "Removed",
+			LBList:   []awsproviderapi.LoadBalancerReference{ext, internal, admin},
+			Previous: "admin",
+			Expected: []awsproviderapi.LoadBalancerReference{ext, internal},
+		},
+	}
+	for _, test := range tests {
+		actual := withAdminAPILoadBalancer(test.LBList, test.Previous, test.Ref)
+		if !reflect.DeepEqual(actual, test.Expected) {
+			t.Fatalf("Test [%v] FAILED. Expected %v. Got %v", test.Name, test.Expected, actual)
+		}
+	}
+}
+
+func TestCallContext(t *testing.T) {
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+	tests := []struct {
+		Name             string
+		Parent           context.Context
+		Timeout          time.Duration
+		ExpectDeadline   bool
+		ExpectCancelled  bool
+		ExpectDoneOnExit bool
+	}{
+		{
+			Name:             "Bounded",
+			Parent:           context.Background(),
+			Timeout:          time.Minute,
+			ExpectDeadline:   true,
+			ExpectDoneOnExit: true,
+		},
+		{
+			Name:    "Unbounded",
+			Parent:  context.Background(),
+			Timeout: 0,
+		},
+		{
+			Name:             "Reconcile cancelled",
+			Parent:           cancelled,
+			Timeout:          time.Minute,
+			ExpectDeadline:   true,
+			ExpectCancelled:  true,
+			ExpectDoneOnExit: true,
+		},
+	}
+	for _, test := range tests {
+		r := &request.Request{}
+		callContext(test.Parent, test.Timeout)(r)
+		_, hasDeadline := r.Context().Deadline()
+		if hasDeadline != test.ExpectDeadline {
+			t.Fatalf("Test [%v] FAILED. Expected deadline %v. Got %v", test.Name, test.ExpectDeadline, hasDeadline)
+		}
+		if cancelledNow := r.Context().Err() != nil; cancelledNow != test.ExpectCancelled {
+			t.Fatalf("Test [%v] FAILED. Expected cancelled %v. Got %v", test.Name, test.ExpectCancelled, cancelledNow)
+		}
+		r.Handlers.Complete.Run(r)
+		if done := r.Context().Err() != nil; done != test.ExpectDoneOnExit {
+			t.Fatalf("Test [%v] FAILED. Expected done once completed %v. Got %v", test.Name, test.ExpectDoneOnExit, done)
+		}
+	}
 }
@@ -0,0 +1,137 @@
+package aws
+
+import (
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/aws/aws-sdk-go/service/elbv2/elbv2iface"
+
+	baseutils "github.com/openshift/cloud-ingress-operator/pkg/utils"
+)
+
+// targetGroup manages the instances behind a network load balancer. They're
+// registered as targets of its target groups, where a classic ELB would have
+// them registered with the load balancer itself
+type targetGroup struct {
+	elbv2Client elbv2iface.ELBV2API
+	arn         string
+}
+
+// targetHealth is the health of an instance registered with a target group
+type targetHealth struct {
+	instanceID string
+	// state is one of the elbv2.TargetHealthStateEnum values, eg healthy
+	state string
+	// reason explains a state other than healthy
+	reason string
+}
+
+// targetGroup returns the manager of the target group with the ARN arn
+func (c *Client) targetGroup(arn string) *targetGroup {
+	return &targetGroup{elbv2Client: c.elbv2Client, arn: arn}
+}
+
+// health returns the health of every instance registered with the target
+// group, draining ones included
+func (tg *targetGroup) health() ([]targetHealth, error) {
+	output, err := tg.elbv2Client.DescribeTargetHealth(&elbv2.DescribeTargetHealthInput{
+		TargetGroupArn: aws.String(tg.arn),
+	})
+	if err != nil {
+		return nil, err
+	}
+	health := make([]targetHealth, 0, len(output.TargetHealthDescriptions))
+	for _, description := range output.TargetHealthDescriptions {
+		target := targetHealth{instanceID: aws.StringValue(description.Target.Id)}
+		if description.TargetHealth != nil {
+			target.state = aws.StringValue(description.TargetHealth.State)
+			target.reason = aws.StringValue(description.TargetHealth.Reason)
+		}
+		health = append(health, target)
+	}
+	return health, nil
+}
+
+// healthy checks if the target group has registered targets, and every one of
+// them passes its health checks, which is when the load balancer can serve
+// traffic
+func (tg *targetGroup) healthy() (bool, error) {
+	health, err := tg.health()
+	if err != nil {
+		return false, err
+	}
+	if len(health) == 0 {
+		return false, nil
+	}
+	for _, target := range health {
+		if target.state != elbv2.TargetHealthStateEnumHealthy {
+			log.Info("Target isn't healthy yet", "targetGroupArn", tg.arn, "target", target.instanceID, "state", target.state, "reason", target.reason)
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// register registers instanceIDs with the target group, on its port.
+// Registering an instance twice is a no-op
+func (tg *targetGroup) register(instanceIDs []string) error {
+	if len(instanceIDs) == 0 {
+		return nil
+	}
+	_, err := tg.elbv2Client.RegisterTargets(&elbv2.RegisterTargetsInput{
+		TargetGroupArn: aws.String(tg.arn),
+		Targets:        targetDescriptions(instanceIDs),
+	})
+	return err
+}
+
+// deregister removes instanceIDs from the target group. They're drained for
+// the deregistration delay of the target group first
+func (tg *targetGroup) deregister(instanceIDs []string) error {
+	if len(instanceIDs) == 0 {
+		return nil
+	}
+	_, err := tg.elbv2Client.DeregisterTargets(&elbv2.DeregisterTargetsInput{
+		TargetGroupArn: aws.String(tg.arn),
+		Targets:        targetDescriptions(instanceIDs),
+	})
+	return err
+}
+
+// sync registers the desired instances missing from the target group and
+// deregisters the others, and returns whether it changed anything. Draining
+// instances are no longer registered. When the members are the desired ones
+// already, nothing is registered or deregistered
+func (tg *targetGroup) sync(desired []string) (bool, error) {
+	health, err := tg.health()
+	if err != nil {
+		return false, err
+	}
+	registered := make([]string, 0, len(health))
+	for _, target := range health {
+		if target.state != elbv2.TargetHealthStateEnumDraining {
+			registered = append(registered, target.instanceID)
+		}
+	}
+	register, deregister := baseutils.MemberChanges(registered, desired)
+	if err = tg.register(register); err != nil {
+		return false, err
+	}
+	if err = tg.deregister(deregister); err != nil {
+		return len(register) > 0, err
+	}
+	if len(register) > 0 || len(deregister) > 0 {
+		log.Info("Synced the targets of the target group", "targetGroupArn", tg.arn, "registered", register, "deregistered", deregister)
+		return true, nil
+	}
+	return false, nil
+}
+
+// targetDescriptions returns the targets for instanceIDs. Without a port, the
+// port of the target group is used
+func targetDescriptions(instanceIDs []string) []*elbv2.TargetDescription {
+	targets := make([]*elbv2.TargetDescription, 0, len(instanceIDs))
+	for _, instanceID := range instanceIDs {
+		targets = append(targets, &elbv2.TargetDescription{Id: aws.String(instanceID)})
+	}
+	return targets
+}
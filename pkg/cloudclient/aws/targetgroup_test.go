@@ -0,0 +1,146 @@
+package aws
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/aws/aws-sdk-go/service/elbv2/elbv2iface"
+)
+
+const testTargetGroupArn = "arn:aws:elasticloadbalancing:us-east-1:123456789012:targetgroup/basename-aext/abcdef"
+
+type mockTargetHealth struct {
+	elbv2iface.ELBV2API
+	States []string
+}
+
+func (m *mockTargetHealth) DescribeTargetHealth(input *elbv2.DescribeTargetHealthInput) (*elbv2.DescribeTargetHealthOutput, error) {
+	output := &elbv2.DescribeTargetHealthOutput{}
+	for i, state := range m.States {
+		output.TargetHealthDescriptions = append(output.TargetHealthDescriptions, &elbv2.TargetHealthDescription{
+			Target:       &elbv2.TargetDescription{Id: aws.String(fmt.Sprintf("i-%d", i))},
+			TargetHealth: &elbv2.TargetHealth{State: aws.String(state)},
+		})
+	}
+	return output, nil
+}
+
+func TestTargetGroupHealthy(t *testing.T) {
+	tests := []struct {
+		Name     string
+		States   []string
+		Expected bool
+	}{
+		{
+			Name:     "No targets",
+			Expected: false,
+		},
+		{
+			Name:     "Targets still in their initial health checks",
+			States:   []string{elbv2.TargetHealthStateEnumHealthy, elbv2.TargetHealthStateEnumInitial},
+			Expected: false,
+		},
+		{
+			Name:     "All targets healthy",
+			States:   []string{elbv2.TargetHealthStateEnumHealthy, elbv2.TargetHealthStateEnumHealthy},
+			Expected: true,
+		},
+	}
+	for _, test := range tests {
+		client := &Client{elbv2Client: &mockTargetHealth{States: test.States}}
+		healthy, err := client.targetGroup(testTargetGroupArn).healthy()
+		if err != nil {
+			t.Fatalf("Test [%v] unexpected error %v", test.Name, err)
+		}
+		if healthy != test.Expected {
+			t.Fatalf("Test [%v] FAILED. Expected healthy %v. Got %v", test.Name, test.Expected, healthy)
+		}
+	}
+}
+
+type mockTargetGroup struct {
+	elbv2iface.ELBV2API
+	// Targets are the states of the registered instances, by ID
+	Targets      map[string]string
+	Registered   []string
+	Deregistered []string
+}
+
+func (m *mockTargetGroup) DescribeTargetHealth(input *elbv2.DescribeTargetHealthInput) (*elbv2.DescribeTargetHealthOutput, error) {
+	output := &elbv2.DescribeTargetHealthOutput{}
+	for instanceID, state := range m.Targets {
+		output.TargetHealthDescriptions = append(output.TargetHealthDescriptions, &elbv2.TargetHealthDescription{
+			Target:       &elbv2.TargetDescription{Id: aws.String(instanceID)},
+			TargetHealth: &elbv2.TargetHealth{State: aws.String(state)},
+		})
+	}
+	return output, nil
+}
+
+func (m *mockTargetGroup) RegisterTargets(input *elbv2.RegisterTargetsInput) (*elbv2.RegisterTargetsOutput, error) {
+	for _, target := range input.Targets {
+		m.Registered = append(m.Registered, aws.StringValue(target.Id))
+	}
+	return &elbv2.RegisterTargetsOutput{}, nil
+}
+
+func (m *mockTargetGroup) DeregisterTargets(input *elbv2.DeregisterTargetsInput) (*elbv2.DeregisterTargetsOutput, error) {
+	for _, target := range input.Targets {
+		m.Deregistered = append(m.Deregistered, aws.StringValue(target.Id))
+	}
+	return &elbv2.DeregisterTargetsOutput{}, nil
+}
+
+func TestTargetGroupSync(t *testing.T) {
+	tests := []struct {
+		Name                 string
+		Targets              map[string]string
+		Desired              []string
+		ExpectedRegistered   []string
+		ExpectedDeregistered []string
+	}{
+		{
+			Name:    "Membership unchanged",
+			Targets: map[string]string{"i-a": elbv2.TargetHealthStateEnumHealthy, "i-b": elbv2.TargetHealthStateEnumUnhealthy},
+			Desired: []string{"i-b", "i-a"},
+		},
+		{
+			Name:                 "Instance replaced",
+			Targets:              map[string]string{"i-a": elbv2.TargetHealthStateEnumHealthy, "i-b": elbv2.TargetHealthStateEnumHealthy},
+			Desired:              []string{"i-a", "i-c"},
+			ExpectedRegistered:   []string{"i-c"},
+			ExpectedDeregistered: []string{"i-b"},
+		},
+		{
+			Name:               "Draining instance desired again",
+			Targets:            map[string]string{"i-a": elbv2.TargetHealthStateEnumHealthy, "i-b": elbv2.TargetHealthStateEnumDraining},
+			Desired:            []string{"i-a", "i-b"},
+			ExpectedRegistered: []string{"i-b"},
+		},
+		{
+			Name:    "Draining instance not desired",
+			Targets: map[string]string{"i-a": elbv2.TargetHealthStateEnumHealthy, "i-b": elbv2.TargetHealthStateEnumDraining},
+			Desired: []string{"i-a"},
+		},
+	}
+	for _, test := range tests {
+		mock := &mockTargetGroup{Targets: test.Targets}
+		client := &Client{elbv2Client: mock}
+		changed, err := client.targetGroup(testTargetGroupArn).sync(test.Desired)
+		if err != nil {
+			t.Fatalf("Test [%v] unexpected error %v", test.Name, err)
+		}
+		if !reflect.DeepEqual(mock.Registered, test.ExpectedRegistered) {
+			t.Fatalf("Test [%v] FAILED. Expected to register %v. Got %v", test.Name, test.ExpectedRegistered, mock.Registered)
+		}
+		if !reflect.DeepEqual(mock.Deregistered, test.ExpectedDeregistered) {
+			t.Fatalf("Test [%v] FAILED. Expected to deregister %v. Got %v", test.Name, test.ExpectedDeregistered, mock.Deregistered)
+		}
+		if expected := len(test.ExpectedRegistered) > 0 || len(test.ExpectedDeregistered) > 0; changed != expected {
+			t.Fatalf("Test [%v] FAILED. Expected changed %v. Got %v", test.Name, expected, changed)
+		}
+	}
+}
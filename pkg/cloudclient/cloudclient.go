@@ -3,6 +3,7 @@ package cloudclient
 import (
 	"context"
 	"fmt"
+	"sync"
 
 	configv1 "github.com/openshift/api/config/v1"
 
@@ -19,9 +20,129 @@ type CloudClient interface {
 	// May return loadBalancerNotFound or other specific errors
 	EnsureAdminAPIDNS(context.Context, client.Client, *cloudingressv1alpha1.APIScheme, *corev1.Service) error
 
+	// EnsureAdminAPIWeightedDNS ensures the rh-api alias is split between the
+	// load balancers of the two Services, the second receiving the given
+	// percentage of the DNS queries. EnsureAdminAPIDNS undoes it
+	EnsureAdminAPIWeightedDNS(context.Context, client.Client, *cloudingressv1alpha1.APIScheme, *corev1.Service, *corev1.Service, int32) error
+
+	// EnsureAdminAPIFailoverDNS ensures the rh-api alias answers with the load
+	// balancer of the first Service while a health check finds it healthy,
+	// and with the second Service's otherwise. EnsureAdminAPIDNS undoes it,
+	// along with the health check
+	EnsureAdminAPIFailoverDNS(context.Context, client.Client, *cloudingressv1alpha1.APIScheme, *corev1.Service, *corev1.Service) error
+
 	// DeleteAdminAPIDNS will ensure that the A record for the admin API (rh-api) is removed
 	DeleteAdminAPIDNS(context.Context, client.Client, *cloudingressv1alpha1.APIScheme, *corev1.Service) error
 
+	// EnsureAdminAPIStaticIPs ensures the static IP addresses for the admin API
+	// exist, and configures the Service so its load balancer uses them.
+	// Returns the IP addresses
+	EnsureAdminAPIStaticIPs(context.Context, client.Client, *cloudingressv1alpha1.APIScheme, *corev1.Service) ([]string, error)
+
+	// ReleaseAdminAPIStaticIPs releases the static IP addresses of the given
+	// allocation IDs the operator allocated for the admin API. Addresses still
+	// attached to a load balancer are left for a later call. Returns the
+	// allocation IDs released, or already gone
+	ReleaseAdminAPIStaticIPs(context.Context, client.Client, *cloudingressv1alpha1.APIScheme, []string) ([]string, error)
+
+	// EnsureAdminAPISecurityGroup ensures the dedicated security group for the
+	// admin API exists with only the allowed CIDR blocks let in, and configures
+	// the Service so its load balancer uses it. Returns the security group ID,
+	// or an empty string if the load balancer can't have one
+	EnsureAdminAPISecurityGroup(context.Context, client.Client, *cloudingressv1alpha1.APIScheme, *corev1.Service) (string, error)
+
+	// DeleteAdminAPISecurityGroup will ensure that the dedicated security group
+	// for the admin API is removed. May return SecurityGroupInUseError while the
+	// load balancer is being deleted
+	DeleteAdminAPISecurityGroup(context.Context, client.Client, *cloudingressv1alpha1.APIScheme) error
+
+	// DeleteAdminAPILoadBalancer will ensure that the cloud load balancer of
+	// the admin API Service is removed, deregistering its instances and
+	// deleting its listeners first. The cloud provider would only get to it
+	// once the Service is gone
+	DeleteAdminAPILoadBalancer(context.Context, client.Client, *cloudingressv1alpha1.APIScheme, *corev1.Service) error
+
+	// EnsureAdminAPIIPAddressType ensures the admin API load balancer has the
+	// IP address type its Service asks for, where the cloud can change it in
+	// place. May return LoadBalancerNotReadyError
+	EnsureAdminAPIIPAddressType(context.Context, client.Client, *cloudingressv1alpha1.APIScheme, *corev1.Service) error
+
+	// EnsureAdminAPIAccessLogs ensures the admin API load balancer writes
+	// access logs where the APIScheme asks for them, or doesn't write any if
+	// it doesn't ask. May return LoadBalancerNotReadyError
+	EnsureAdminAPIAccessLogs(context.Context, client.Client, *cloudingressv1alpha1.APIScheme, *corev1.Service) error
+
+	// EnsureAdminAPIAlarms ensures the monitoring alarms for the admin API
+	// load balancer exist if the APIScheme asks for them, and are removed
+	// otherwise. May return LoadBalancerNotReadyError
+	EnsureAdminAPIAlarms(context.Context, client.Client, *cloudingressv1alpha1.APIScheme, *corev1.Service) error
+
+	// EnsureAdminAPITLSPolicy ensures the SSL and TLS listeners of the admin
+	// API load balancer negotiate with the security policy the APIScheme asks
+	// for, if any. May return LoadBalancerNotReadyError
+	EnsureAdminAPITLSPolicy(context.Context, client.Client, *cloudingressv1alpha1.APIScheme, *corev1.Service) error
+
+	// EnsureAdminAPICertificate ensures the SSL and TLS listeners of the admin
+	// API load balancer serve the certificate the APIScheme asks for, if any,
+	// swapping it in place when it changes. May return
+	// LoadBalancerNotReadyError
+	EnsureAdminAPICertificate(context.Context, client.Client, *cloudingressv1alpha1.APIScheme, *corev1.Service) error
+
+	// EnsureAdminAPIStickiness ensures the admin API load balancer keeps
+	// clients on a backend as the APIScheme asks, if it asks. May return
+	// LoadBalancerNotReadyError
+	EnsureAdminAPIStickiness(context.Context, client.Client, *cloudingressv1alpha1.APIScheme, *corev1.Service) error
+
+	// EnsureAdminAPIMasterMachines ensures the admin API load balancer is in
+	// the providerSpec of the master Machines, so a replacement master
+	// registers with it when it boots
+	EnsureAdminAPIMasterMachines(context.Context, client.Client, *cloudingressv1alpha1.APIScheme, *corev1.Service) error
+
+	// DeleteAdminAPIAlarms will ensure that the monitoring alarms for the
+	// admin API load balancer are removed
+	DeleteAdminAPIAlarms(context.Context, client.Client, *cloudingressv1alpha1.APIScheme) error
+
+	// CheckAdminAPIDrift compares the cloud resources behind the admin API
+	// against what RepairAdminAPIDrift would repair them to, without changing
+	// anything. Returns the components that have drifted
+	CheckAdminAPIDrift(context.Context, client.Client, *cloudingressv1alpha1.APIScheme, *corev1.Service) ([]string, error)
+
+	// RepairAdminAPIDrift re-verifies the cloud resources behind the admin API
+	// (load balancer listeners, registered instances, health check, security
+	// groups, subnets, DNS) and repairs any that were changed outside the
+	// operator or no longer match the cluster. Returns the components that
+	// had drifted
+	RepairAdminAPIDrift(context.Context, client.Client, *cloudingressv1alpha1.APIScheme, *corev1.Service) ([]string, error)
+
+	// GetAdminAPIRegisteredInstances returns the IDs of the instances
+	// registered with the admin API load balancer. May return
+	// LoadBalancerNotReadyError
+	GetAdminAPIRegisteredInstances(context.Context, client.Client, *cloudingressv1alpha1.APIScheme, *corev1.Service) ([]string, error)
+
+	// GetAdminAPIHealthyInstances returns the IDs of the instances registered
+	// with the admin API load balancer that pass its health checks, which are
+	// those it sends traffic to. May return LoadBalancerNotReadyError
+	GetAdminAPIHealthyInstances(context.Context, client.Client, *cloudingressv1alpha1.APIScheme, *corev1.Service) ([]string, error)
+
+	// GetAdminAPIInstanceHealth returns the instances registered with the
+	// admin API load balancer, with their zone and the health state it
+	// reports for them. May return LoadBalancerNotReadyError
+	GetAdminAPIInstanceHealth(context.Context, client.Client, *cloudingressv1alpha1.APIScheme, *corev1.Service) ([]cloudingressv1alpha1.RegisteredInstance, error)
+
+	// AdminAPILoadBalancerExists checks the cloud still has the load balancer
+	// the cloud provider created for the admin API Service, eg that it
+	// wasn't deleted by hand
+	AdminAPILoadBalancerExists(context.Context, client.Client, *cloudingressv1alpha1.APIScheme, *corev1.Service) (bool, error)
+
+	// GetAdminAPILoadBalancerAttributes returns the attributes of the admin
+	// API load balancer, leaving unset those that don't apply to it. May
+	// return LoadBalancerNotReadyError
+	GetAdminAPILoadBalancerAttributes(context.Context, client.Client, *cloudingressv1alpha1.APIScheme, *corev1.Service) (*cloudingressv1alpha1.LoadBalancerAttributes, error)
+
+	// GetAdminAPINameServers returns the authoritative nameservers of the
+	// public zones the admin API records are published in
+	GetAdminAPINameServers(context.Context, client.Client, *cloudingressv1alpha1.APIScheme) ([]string, error)
+
 	/* SSH */
 	// EnsureSSHDNS ensures there's a rh-ssh (for example) alias to the Service for the SSH pod
 	EnsureSSHDNS(context.Context, client.Client, *cloudingressv1alpha1.SSHD, *corev1.Service) error
@@ -35,14 +156,104 @@ type CloudClient interface {
 
 	// SetDefaultAPIPublic ensures that the default API is public, per user configure
 	SetDefaultAPIPublic(context.Context, client.Client, *cloudingressv1alpha1.PublishingStrategy) error
+
+	// EnsureApplicationIngressDNS ensures the wildcard DNS record of each
+	// ApplicationIngress points at its router's load balancer, in the zones
+	// matching its listening scope
+	EnsureApplicationIngressDNS(context.Context, client.Client, *cloudingressv1alpha1.PublishingStrategy) error
+
+	// EnsureRouterServiceDNS ensures the wildcard DNS record of the
+	// ApplicationIngress points at the current load balancer of its router
+	// Service, and removes the records a load balancer of the other scope left
+	EnsureRouterServiceDNS(context.Context, client.Client, *cloudingressv1alpha1.ApplicationIngress, *corev1.Service) error
+
+	// EnsureApplicationIngressLoadBalancers ensures the cloud resources around
+	// the router load balancer of each ApplicationIngress, like its reserved
+	// IP address and firewall rules, match its listening scope
+	EnsureApplicationIngressLoadBalancers(context.Context, client.Client, *cloudingressv1alpha1.PublishingStrategy) error
+
+	/* Preflight */
+	// CheckPermissions checks the operator's credentials against the cloud
+	// permissions it needs, without using any of them. Returns the permissions
+	// that are missing
+	CheckPermissions(context.Context, client.Client) ([]string, error)
+
+	// CheckCredentials checks the operator's credentials are valid and the
+	// cloud API reachable, with the cheapest call that authenticates
+	CheckCredentials(context.Context, client.Client) error
+
+	// GetLoadBalancerQuota returns how many load balancers the cloud's quota
+	// allows, and how many there are, for the kind a Service would get:
+	// network load balancers if the bool is true
+	GetLoadBalancerQuota(context.Context, client.Client, bool) (int, int, error)
+
+	// ValidateAdminAPISubnets checks the subnets listed for the admin API
+	// load balancer suit its scheme, internal if the bool is true, before it's
+	// created in them. Returns an ActionRequiredError if they don't
+	ValidateAdminAPISubnets(context.Context, client.Client, []string, bool) error
 }
 
-var controllerMapping = map[configv1.PlatformType]Factory{}
+// Capabilities are the features of a cloud that the spec of the custom
+// resources may ask for. Controllers consult them, so a field the cloud can't
+// honor is reported Unsupported rather than silently ignored
+type Capabilities struct {
+	// SupportsStaticIP is whether the admin API load balancer can be given
+	// static IP addresses, the APIScheme's staticIP
+	SupportsStaticIP bool
+	// SupportsListenerToggle is whether single listeners of the external API
+	// load balancer can be kept public while the default API is internal, the
+	// PublishingStrategy's keepPublicPorts
+	SupportsListenerToggle bool
+	// SupportsSGManagement is whether the operator manages a security group,
+	// or firewall rules, letting only the allowed CIDR blocks reach the admin
+	// API load balancer
+	SupportsSGManagement bool
+	// SupportsAccessLogs is whether the admin API load balancer can write
+	// access logs, the APIScheme's accessLogs
+	SupportsAccessLogs bool
+	// SupportsAlarms is whether the operator can create monitoring alarms for
+	// the admin API load balancer, the APIScheme's alarms
+	SupportsAlarms bool
+	// SupportsTLSPolicy is whether the admin API load balancer negotiates
+	// TLS, with the APIScheme's tlsSecurityPolicy
+	SupportsTLSPolicy bool
+	// SupportsCertificate is whether the admin API load balancer can serve a
+	// certificate of the cloud, the APIScheme's certificateARN
+	SupportsCertificate bool
+	// SupportsStickiness is whether the admin API load balancer can keep the
+	// connections of a client on one backend, the APIScheme's stickiness
+	SupportsStickiness bool
+	// SupportsBackendSelection is whether the admin API load balancer can
+	// forward to a target pool or a backend service, the APIScheme's backend
+	SupportsBackendSelection bool
+	// SupportsBackendNodeSelector is whether the admin API load balancer can
+	// forward to the nodes of a selector rather than the masters, the
+	// APIScheme's backendNodeSelector
+	SupportsBackendNodeSelector bool
+	// SupportsDNSTTL is whether the operator's DNS records have a TTL of
+	// their own, the APIScheme's and ApplicationIngress' dnsTTLSeconds.
+	// Alias records take that of the load balancer they point at
+	SupportsDNSTTL bool
+}
+
+var (
+	controllerMapping   = map[configv1.PlatformType]Factory{}
+	capabilitiesMapping = map[configv1.PlatformType]Capabilities{}
+)
 
 type Factory func(client.Client) CloudClient
 
-func Register(name configv1.PlatformType, factoryFunc Factory) {
+// Register makes the CloudClient of the cloud provider name, with the given
+// capabilities, available to GetClientFor and GetCapabilitiesFor
+func Register(name configv1.PlatformType, factoryFunc Factory, capabilities Capabilities) {
 	controllerMapping[name] = factoryFunc
+	capabilitiesMapping[name] = capabilities
+}
+
+// GetCapabilitiesFor returns the capabilities of the given cloud provider. A
+// provider without a CloudClient supports nothing
+func GetCapabilitiesFor(cloudID configv1.PlatformType) Capabilities {
+	return capabilitiesMapping[cloudID]
 }
 
 // GetClientFor returns the CloudClient for the given cloud provider, identified
@@ -55,3 +266,33 @@ func GetClientFor(kclient client.Client, cloudID configv1.PlatformType) CloudCli
 	// TODO: Return a minimal interface?
 	panic(fmt.Sprintf("Couldn't find a client matching %s", cloudID))
 }
+
+// sharedClient is a CloudClient and the platform config version it was made for
+type sharedClient struct {
+	cloud         CloudClient
+	configVersion string
+}
+
+var (
+	sharedClientsMu sync.Mutex
+	sharedClients   = map[configv1.PlatformType]sharedClient{}
+)
+
+// GetSharedClientFor returns the CloudClient for the given cloud provider that
+// every controller shares, so their concurrent reconciles reuse one session,
+// describe cache and rate limiter. A new one is made once configVersion, the
+// cluster's platform config version, changes. CloudClients are safe for
+// concurrent use
+func GetSharedClientFor(kclient client.Client, cloudID configv1.PlatformType, configVersion string) CloudClient {
+	sharedClientsMu.Lock()
+	defer sharedClientsMu.Unlock()
+	shared, ok := sharedClients[cloudID]
+	if !ok || shared.configVersion != configVersion {
+		shared = sharedClient{
+			cloud:         GetClientFor(kclient, cloudID),
+			configVersion: configVersion,
+		}
+		sharedClients[cloudID] = shared
+	}
+	return shared.cloud
+}
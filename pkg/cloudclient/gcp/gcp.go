@@ -2,18 +2,30 @@ package gcp
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
 
+	"golang.org/x/oauth2"
 	"golang.org/x/oauth2/google"
+	cloudresourcemanager "google.golang.org/api/cloudresourcemanager/v1"
 	computev1 "google.golang.org/api/compute/v1"
 	dnsv1 "google.golang.org/api/dns/v1"
+	"google.golang.org/api/googleapi"
 	"google.golang.org/api/option"
 
 	configv1 "github.com/openshift/api/config/v1"
-	cloudingressv1alpha1 "github.com/openshift/cloud-ingress-operator/pkg/apis/cloudingress/v1alpha1"
 	"github.com/openshift/cloud-ingress-operator/config"
+	cloudingressv1alpha1 "github.com/openshift/cloud-ingress-operator/pkg/apis/cloudingress/v1alpha1"
+	cioerrors "github.com/openshift/cloud-ingress-operator/pkg/errors"
+	baseutils "github.com/openshift/cloud-ingress-operator/pkg/utils"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/flowcontrol"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
@@ -31,62 +43,404 @@ type Client struct {
 	projectID      string
 	dnsService     *dnsv1.Service
 	computeService *computev1.Service
+	// resourceManagerService checks the operator's own permissions
+	resourceManagerService *cloudresourcemanager.Service
+	// transport makes the services of the copies auditedAs returns
+	transport *settingsTransport
 }
 
 // EnsureAdminAPIDNS implements cloudclient.CloudClient
 func (c *Client) EnsureAdminAPIDNS(ctx context.Context, kclient client.Client, instance *cloudingressv1alpha1.APIScheme, svc *corev1.Service) error {
-	return c.ensureAdminAPIDNS(ctx, kclient, instance, svc)
+	return classifyError(c.withContext(ctx).auditedAs(instance).ensureAdminAPIDNS(ctx, kclient, instance, svc))
+}
+
+// EnsureAdminAPIWeightedDNS implements cloudclient.CloudClient
+func (c *Client) EnsureAdminAPIWeightedDNS(ctx context.Context, kclient client.Client, instance *cloudingressv1alpha1.APIScheme, svc, migrationSvc *corev1.Service, weight int32) error {
+	return classifyError(c.withContext(ctx).auditedAs(instance).ensureAdminAPIWeightedDNS(ctx, kclient, instance, svc, migrationSvc, weight))
+}
+
+// EnsureAdminAPIFailoverDNS implements cloudclient.CloudClient
+func (c *Client) EnsureAdminAPIFailoverDNS(ctx context.Context, kclient client.Client, instance *cloudingressv1alpha1.APIScheme, svc, secondarySvc *corev1.Service) error {
+	return classifyError(c.withContext(ctx).auditedAs(instance).ensureAdminAPIFailoverDNS(ctx, kclient, instance, svc, secondarySvc))
 }
 
 // DeleteAdminAPIDNS implements cloudclient.CloudClient
 func (c *Client) DeleteAdminAPIDNS(ctx context.Context, kclient client.Client, instance *cloudingressv1alpha1.APIScheme, svc *corev1.Service) error {
-	return c.deleteAdminAPIDNS(ctx, kclient, instance, svc)
+	return classifyError(c.withContext(ctx).auditedAs(instance).deleteAdminAPIDNS(ctx, kclient, instance, svc))
+}
+
+// EnsureAdminAPIStaticIPs implements cloudclient.CloudClient
+func (c *Client) EnsureAdminAPIStaticIPs(ctx context.Context, kclient client.Client, instance *cloudingressv1alpha1.APIScheme, svc *corev1.Service) ([]string, error) {
+	result, err := c.withContext(ctx).auditedAs(instance).ensureAdminAPIStaticIPs(ctx, kclient, instance, svc)
+	return result, classifyError(err)
+}
+
+// ReleaseAdminAPIStaticIPs implements cloudclient.CloudClient
+func (c *Client) ReleaseAdminAPIStaticIPs(ctx context.Context, kclient client.Client, instance *cloudingressv1alpha1.APIScheme, allocationIDs []string) ([]string, error) {
+	result, err := c.withContext(ctx).auditedAs(instance).releaseAdminAPIStaticIPs(ctx, kclient, instance, allocationIDs)
+	return result, classifyError(err)
+}
+
+// EnsureAdminAPISecurityGroup implements cloudclient.CloudClient
+func (c *Client) EnsureAdminAPISecurityGroup(ctx context.Context, kclient client.Client, instance *cloudingressv1alpha1.APIScheme, svc *corev1.Service) (string, error) {
+	result, err := c.withContext(ctx).auditedAs(instance).ensureAdminAPISecurityGroup(ctx, kclient, instance, svc)
+	return result, classifyError(err)
+}
+
+// DeleteAdminAPISecurityGroup implements cloudclient.CloudClient
+func (c *Client) DeleteAdminAPISecurityGroup(ctx context.Context, kclient client.Client, instance *cloudingressv1alpha1.APIScheme) error {
+	return classifyError(c.withContext(ctx).auditedAs(instance).deleteAdminAPISecurityGroup(ctx, kclient, instance))
+}
+
+// DeleteAdminAPILoadBalancer implements cloudclient.CloudClient
+func (c *Client) DeleteAdminAPILoadBalancer(ctx context.Context, kclient client.Client, instance *cloudingressv1alpha1.APIScheme, svc *corev1.Service) error {
+	return classifyError(c.withContext(ctx).auditedAs(instance).deleteAdminAPILoadBalancer(ctx, kclient, instance, svc))
+}
+
+// EnsureAdminAPIIPAddressType implements cloudclient.CloudClient
+func (c *Client) EnsureAdminAPIIPAddressType(ctx context.Context, kclient client.Client, instance *cloudingressv1alpha1.APIScheme, svc *corev1.Service) error {
+	return classifyError(c.withContext(ctx).auditedAs(instance).ensureAdminAPIIPAddressType(ctx, kclient, instance, svc))
+}
+
+// EnsureAdminAPIAccessLogs implements cloudclient.CloudClient
+func (c *Client) EnsureAdminAPIAccessLogs(ctx context.Context, kclient client.Client, instance *cloudingressv1alpha1.APIScheme, svc *corev1.Service) error {
+	return classifyError(c.withContext(ctx).auditedAs(instance).ensureAdminAPIAccessLogs(ctx, kclient, instance, svc))
+}
+
+// EnsureAdminAPIAlarms implements cloudclient.CloudClient
+func (c *Client) EnsureAdminAPIAlarms(ctx context.Context, kclient client.Client, instance *cloudingressv1alpha1.APIScheme, svc *corev1.Service) error {
+	return classifyError(c.withContext(ctx).auditedAs(instance).ensureAdminAPIAlarms(ctx, kclient, instance, svc))
+}
+
+// EnsureAdminAPITLSPolicy implements cloudclient.CloudClient
+func (c *Client) EnsureAdminAPITLSPolicy(ctx context.Context, kclient client.Client, instance *cloudingressv1alpha1.APIScheme, svc *corev1.Service) error {
+	return classifyError(c.withContext(ctx).auditedAs(instance).ensureAdminAPITLSPolicy(ctx, kclient, instance, svc))
+}
+
+// EnsureAdminAPICertificate implements cloudclient.CloudClient
+func (c *Client) EnsureAdminAPICertificate(ctx context.Context, kclient client.Client, instance *cloudingressv1alpha1.APIScheme, svc *corev1.Service) error {
+	return classifyError(c.withContext(ctx).auditedAs(instance).ensureAdminAPICertificate(ctx, kclient, instance, svc))
+}
+
+// EnsureAdminAPIStickiness implements cloudclient.CloudClient
+func (c *Client) EnsureAdminAPIStickiness(ctx context.Context, kclient client.Client, instance *cloudingressv1alpha1.APIScheme, svc *corev1.Service) error {
+	return classifyError(c.withContext(ctx).auditedAs(instance).ensureAdminAPIStickiness(ctx, kclient, instance, svc))
+}
+
+// EnsureAdminAPIMasterMachines implements cloudclient.CloudClient
+func (c *Client) EnsureAdminAPIMasterMachines(ctx context.Context, kclient client.Client, instance *cloudingressv1alpha1.APIScheme, svc *corev1.Service) error {
+	return classifyError(c.withContext(ctx).ensureAdminAPIMasterMachines(ctx, kclient, instance, svc))
+}
+
+// DeleteAdminAPIAlarms implements cloudclient.CloudClient
+func (c *Client) DeleteAdminAPIAlarms(ctx context.Context, kclient client.Client, instance *cloudingressv1alpha1.APIScheme) error {
+	return classifyError(c.withContext(ctx).auditedAs(instance).deleteAdminAPIAlarms(ctx, kclient, instance))
+}
+
+// CheckAdminAPIDrift implements cloudclient.CloudClient
+func (c *Client) CheckAdminAPIDrift(ctx context.Context, kclient client.Client, instance *cloudingressv1alpha1.APIScheme, svc *corev1.Service) ([]string, error) {
+	result, err := c.withContext(ctx).checkAdminAPIDrift(ctx, kclient, instance, svc)
+	return result, classifyError(err)
+}
+
+// RepairAdminAPIDrift implements cloudclient.CloudClient
+func (c *Client) RepairAdminAPIDrift(ctx context.Context, kclient client.Client, instance *cloudingressv1alpha1.APIScheme, svc *corev1.Service) ([]string, error) {
+	result, err := c.withContext(ctx).auditedAs(instance).repairAdminAPIDrift(ctx, kclient, instance, svc)
+	return result, classifyError(err)
+}
+
+// GetAdminAPIRegisteredInstances implements cloudclient.CloudClient
+func (c *Client) GetAdminAPIRegisteredInstances(ctx context.Context, kclient client.Client, instance *cloudingressv1alpha1.APIScheme, svc *corev1.Service) ([]string, error) {
+	result, err := c.withContext(ctx).getAdminAPIRegisteredInstances(ctx, kclient, instance, svc)
+	return result, classifyError(err)
+}
+
+// GetAdminAPIHealthyInstances implements cloudclient.CloudClient
+func (c *Client) GetAdminAPIHealthyInstances(ctx context.Context, kclient client.Client, instance *cloudingressv1alpha1.APIScheme, svc *corev1.Service) ([]string, error) {
+	result, err := c.withContext(ctx).getAdminAPIHealthyInstances(ctx, kclient, instance, svc)
+	return result, classifyError(err)
+}
+
+// GetAdminAPIInstanceHealth implements cloudclient.CloudClient
+func (c *Client) GetAdminAPIInstanceHealth(ctx context.Context, kclient client.Client, instance *cloudingressv1alpha1.APIScheme, svc *corev1.Service) ([]cloudingressv1alpha1.RegisteredInstance, error) {
+	result, err := c.withContext(ctx).getAdminAPIInstanceHealth(ctx, kclient, instance, svc)
+	return result, classifyError(err)
+}
+
+// AdminAPILoadBalancerExists implements cloudclient.CloudClient
+func (c *Client) AdminAPILoadBalancerExists(ctx context.Context, kclient client.Client, instance *cloudingressv1alpha1.APIScheme, svc *corev1.Service) (bool, error) {
+	result, err := c.withContext(ctx).adminAPILoadBalancerExists(ctx, kclient, instance, svc)
+	return result, classifyError(err)
+}
+
+// GetAdminAPILoadBalancerAttributes implements cloudclient.CloudClient
+func (c *Client) GetAdminAPILoadBalancerAttributes(ctx context.Context, kclient client.Client, instance *cloudingressv1alpha1.APIScheme, svc *corev1.Service) (*cloudingressv1alpha1.LoadBalancerAttributes, error) {
+	result, err := c.withContext(ctx).getAdminAPILoadBalancerAttributes(ctx, kclient, instance, svc)
+	return result, classifyError(err)
+}
+
+// GetAdminAPINameServers implements cloudclient.CloudClient
+func (c *Client) GetAdminAPINameServers(ctx context.Context, kclient client.Client, instance *cloudingressv1alpha1.APIScheme) ([]string, error) {
+	result, err := c.withContext(ctx).getAdminAPINameServers(ctx, kclient, instance)
+	return result, classifyError(err)
+}
+
+// CheckPermissions implements cloudclient.CloudClient
+func (c *Client) CheckPermissions(ctx context.Context, kclient client.Client) ([]string, error) {
+	result, err := c.withContext(ctx).checkPermissions(ctx, kclient)
+	return result, classifyError(err)
+}
+
+// CheckCredentials implements cloudclient.CloudClient
+func (c *Client) CheckCredentials(ctx context.Context, kclient client.Client) error {
+	return classifyError(c.withContext(ctx).checkCredentials(ctx, kclient))
+}
+
+// GetLoadBalancerQuota implements cloudclient.CloudClient
+func (c *Client) GetLoadBalancerQuota(ctx context.Context, kclient client.Client, network bool) (int, int, error) {
+	used, limit, err := c.withContext(ctx).getLoadBalancerQuota(ctx, kclient, network)
+	return used, limit, classifyError(err)
+}
+
+// ValidateAdminAPISubnets implements cloudclient.CloudClient. The admin API
+// load balancer isn't put in subnets on GCP
+func (c *Client) ValidateAdminAPISubnets(ctx context.Context, kclient client.Client, subnetIDs []string, internal bool) error {
+	return nil
 }
 
 // EnsureSSHDNS implements cloudclient.CloudClient
 func (c *Client) EnsureSSHDNS(ctx context.Context, kclient client.Client, instance *cloudingressv1alpha1.SSHD, svc *corev1.Service) error {
-	return c.ensureSSHDNS(ctx, kclient, instance, svc)
+	return classifyError(c.withContext(ctx).auditedAs(instance).ensureSSHDNS(ctx, kclient, instance, svc))
 }
 
 // DeleteSSHDNS implements cloudclient.CloudClient
 func (c *Client) DeleteSSHDNS(ctx context.Context, kclient client.Client, instance *cloudingressv1alpha1.SSHD, svc *corev1.Service) error {
-	return c.deleteSSHDNS(ctx, kclient, instance, svc)
+	return classifyError(c.withContext(ctx).auditedAs(instance).deleteSSHDNS(ctx, kclient, instance, svc))
 }
 
 // SetDefaultAPIPrivate implements cloudclient.CloudClient
 func (c *Client) SetDefaultAPIPrivate(ctx context.Context, kclient client.Client, instance *cloudingressv1alpha1.PublishingStrategy) error {
-	return c.setDefaultAPIPrivate(ctx, kclient, instance)
+	return classifyError(c.withContext(ctx).auditedAs(instance).setDefaultAPIPrivate(ctx, kclient, instance))
 }
 
 // SetDefaultAPIPublic implements cloudclient.CloudClient
 func (c *Client) SetDefaultAPIPublic(ctx context.Context, kclient client.Client, instance *cloudingressv1alpha1.PublishingStrategy) error {
-	return c.setDefaultAPIPublic(ctx, kclient, instance)
+	return classifyError(c.withContext(ctx).auditedAs(instance).setDefaultAPIPublic(ctx, kclient, instance))
+}
+
+// EnsureApplicationIngressDNS implements cloudclient.CloudClient
+func (c *Client) EnsureApplicationIngressDNS(ctx context.Context, kclient client.Client, instance *cloudingressv1alpha1.PublishingStrategy) error {
+	return classifyError(c.withContext(ctx).auditedAs(instance).ensureApplicationIngressDNS(ctx, kclient, instance))
+}
+
+// EnsureRouterServiceDNS implements cloudclient.CloudClient
+func (c *Client) EnsureRouterServiceDNS(ctx context.Context, kclient client.Client, appIngress *cloudingressv1alpha1.ApplicationIngress, svc *corev1.Service) error {
+	return classifyError(c.withContext(ctx).auditedAs(svc).ensureRouterServiceDNS(ctx, kclient, appIngress, svc))
+}
+
+// EnsureApplicationIngressLoadBalancers implements cloudclient.CloudClient
+func (c *Client) EnsureApplicationIngressLoadBalancers(ctx context.Context, kclient client.Client, instance *cloudingressv1alpha1.PublishingStrategy) error {
+	return classifyError(c.withContext(ctx).auditedAs(instance).ensureApplicationIngressLoadBalancers(ctx, kclient, instance))
+}
+
+// settingsTransport applies the CloudIngressConfig rate limit and dry run to
+// GCP API calls, and adds those that change something to the audit trail as
+// made for caller
+type settingsTransport struct {
+	base    http.RoundTripper
+	limiter flowcontrol.RateLimiter
+	dryRun  bool
+	caller  string
+	// timings are those of the reconcile the calls are made for, see
+	// withContext
+	timings *baseutils.ReconcileTimings
+}
+
+// auditParamsLimit is how much of a request body is kept in the audit trail
+const auditParamsLimit = 4096
+
+// RoundTrip implements http.RoundTripper
+func (t *settingsTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	defer t.timings.Start(baseutils.PhaseCloud)()
+	// Only GETs are read-only in the compute and DNS APIs. Testing the
+	// operator's permissions is a POST that changes nothing
+	if req.Method == http.MethodGet || strings.HasSuffix(req.URL.Path, ":testIamPermissions") {
+		if t.limiter != nil {
+			t.limiter.Accept()
+		}
+		return t.base.RoundTrip(req)
+	}
+	record := baseutils.AuditRecord{
+		Cloud:     "gcp",
+		Service:   req.URL.Host,
+		Operation: req.Method + " " + req.URL.Path,
+		Params:    requestBody(req),
+		Caller:    t.caller,
+	}
+	if t.dryRun {
+		log.Info("Dry run, not calling GCP", "method", req.Method, "URL", req.URL.String())
+		record.Err = fmt.Errorf("Request %s %s would have been made, but the operator is in dry run mode", req.Method, req.URL.Path)
+		baseutils.Audit(record)
+		return nil, record.Err
+	}
+	if t.limiter != nil {
+		t.limiter.Accept()
+	}
+	resp, err := t.base.RoundTrip(req)
+	switch {
+	case err != nil:
+		record.Err = err
+	case resp.StatusCode >= http.StatusBadRequest:
+		record.Err = errors.New(resp.Status)
+	}
+	baseutils.Audit(record)
+	return resp, err
+}
+
+// requestBody returns the start of the body of req, without consuming it
+func requestBody(req *http.Request) string {
+	if req.GetBody == nil {
+		return ""
+	}
+	body, err := req.GetBody()
+	if err != nil {
+		return ""
+	}
+	defer body.Close()
+	params, _ := ioutil.ReadAll(io.LimitReader(body, auditParamsLimit))
+	return string(params)
 }
 
-func newClient(ctx context.Context, serviceAccountJSON []byte) (*Client, error) {
+func newClient(ctx context.Context, serviceAccountJSON []byte, settings cloudingressv1alpha1.CloudIngressConfigSpec, transport http.RoundTripper) (*Client, error) {
+	// The OAuth2 tokens are fetched through the same transport
+	ctx = context.WithValue(ctx, oauth2.HTTPClient, &http.Client{Transport: transport})
 	credentials, err := google.CredentialsFromJSON(
 		ctx, serviceAccountJSON,
 		dnsv1.NdevClouddnsReadwriteScope,
-		computev1.ComputeScope)
+		computev1.ComputeScope,
+		cloudresourcemanager.CloudPlatformReadOnlyScope)
 	if err != nil {
 		return nil, err
 	}
-
-	dnsService, err := dnsv1.NewService(ctx, option.WithCredentials(credentials))
+	c := &Client{
+		projectID: credentials.ProjectID,
+		transport: &settingsTransport{
+			base: &oauth2.Transport{
+				Source: credentials.TokenSource,
+				Base:   transport,
+			},
+			limiter: baseutils.GetCloudAPIRateLimiter(settings.RateLimit),
+			dryRun:  settings.DryRun,
+		},
+	}
+	err = c.useTransport(ctx, c.transport)
 	if err != nil {
 		return nil, err
 	}
+	return c, nil
+}
+
+// useTransport makes the services of c call GCP through transport
+func (c *Client) useTransport(ctx context.Context, transport *settingsTransport) error {
+	httpClient := &http.Client{Transport: transport}
 
-	computeService, err := computev1.NewService(ctx, option.WithCredentials(credentials))
+	dnsService, err := dnsv1.NewService(ctx, option.WithHTTPClient(httpClient))
 	if err != nil {
-		return nil, err
+		return err
 	}
 
-	return &Client{
-		projectID:      credentials.ProjectID,
-		dnsService:     dnsService,
-		computeService: computeService,
-	}, nil
+	computeService, err := computev1.NewService(ctx, option.WithHTTPClient(httpClient))
+	if err != nil {
+		return err
+	}
+
+	resourceManagerService, err := cloudresourcemanager.NewService(ctx, option.WithHTTPClient(httpClient))
+	if err != nil {
+		return err
+	}
+
+	c.transport = transport
+	c.dnsService = dnsService
+	c.computeService = computeService
+	c.resourceManagerService = resourceManagerService
+	return nil
+}
+
+// auditedAs returns a copy of c whose changes are added to the audit trail as
+// made for caller. The copy shares the rate limit and dry run of c. A Client
+// without a transport, like those of the tests, is returned as it is, and so
+// is c if the copy's services can't be made
+func (c *Client) auditedAs(caller metav1.Object) *Client {
+	if c.transport == nil {
+		return c
+	}
+	transport := *c.transport
+	transport.caller = baseutils.AuditCaller(caller)
+	audited := *c
+	err := audited.useTransport(context.Background(), &transport)
+	if err != nil {
+		log.Error(err, "Couldn't make an audited GCP client, changes won't name their caller")
+		return c
+	}
+	return &audited
+}
+
+// withContext returns a copy of c whose API calls are timed in the reconcile
+// of ctx. A Client without a transport, like those of the tests, is returned
+// as it is
+func (c *Client) withContext(ctx context.Context) *Client {
+	timings := baseutils.ReconcileTimingsFrom(ctx)
+	if c.transport == nil || timings == nil {
+		return c
+	}
+	transport := *c.transport
+	transport.timings = timings
+	bound := *c
+	err := bound.useTransport(context.Background(), &transport)
+	if err != nil {
+		log.Error(err, "Couldn't make a timed GCP client, its calls won't be timed")
+		return c
+	}
+	return &bound
+}
+
+// classifyError turns a GCP API error into one of the cloud error kinds of
+// pkg/errors. Other errors, including the operator's own and the refusals of a
+// dry run, are returned as they are
+func classifyError(err error) error {
+	var gcpError *googleapi.Error
+	if !errors.As(err, &gcpError) {
+		return err
+	}
+	if kind := gcpErrorKind(gcpError); kind != nil {
+		return cioerrors.NewCloudError(kind, err)
+	}
+	return err
+}
+
+// gcpErrorKind returns the cloud error kind of a GCP API error, or nil if it
+// has none
+func gcpErrorKind(err *googleapi.Error) error {
+	for _, item := range err.Errors {
+		switch item.Reason {
+		case "rateLimitExceeded", "userRateLimitExceeded":
+			// GCP reports some rate limits as 403s
+			return cioerrors.ErrThrottled
+		case "resourceInUseByAnotherResource":
+			return cioerrors.ErrDependencyViolation
+		}
+	}
+	switch {
+	case err.Code == http.StatusTooManyRequests:
+		return cioerrors.ErrThrottled
+	case err.Code == http.StatusNotFound:
+		return cioerrors.ErrNotFound
+	case err.Code == http.StatusUnauthorized || err.Code == http.StatusForbidden:
+		return cioerrors.ErrPermissionDenied
+	case err.Code >= http.StatusInternalServerError:
+		return cioerrors.ErrTransient
+	}
+	return nil
 }
 
 // NewClient creates a new CloudClient for use with GCP.
@@ -108,7 +462,12 @@ func NewClient(kclient client.Client) *Client {
 		panic("Access credentials missing service account")
 	}
 
-	c, err := newClient(ctx, serviceAccountJSON)
+	ingressConfig, err := baseutils.GetCloudIngressConfig(kclient)
+	if err != nil {
+		panic(fmt.Sprintf("Couldn't get the CloudIngressConfig %s", err.Error()))
+	}
+
+	c, err := newClient(ctx, serviceAccountJSON, ingressConfig.Spec, baseutils.NewCloudAPITransport(kclient))
 
 	if err != nil {
 		panic(fmt.Sprintf("Couldn't create GCP client %s", err.Error()))
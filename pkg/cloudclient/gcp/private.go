@@ -7,10 +7,14 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"path"
 	"reflect"
+	"strconv"
+	"strings"
+	"time"
 
+	cloudresourcemanager "google.golang.org/api/cloudresourcemanager/v1"
 	"google.golang.org/api/compute/v1"
-	gdnsv1 "google.golang.org/api/dns/v1"
 	"google.golang.org/api/googleapi"
 
 	configv1 "github.com/openshift/api/config/v1"
@@ -18,6 +22,7 @@ import (
 	gcpproviderapi "github.com/openshift/cluster-api-provider-gcp/pkg/apis/gcpprovider/v1beta1"
 	machineapi "github.com/openshift/machine-api-operator/pkg/apis/machine/v1beta1"
 	corev1 "k8s.io/api/core/v1"
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -25,32 +30,640 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	"github.com/openshift/cloud-ingress-operator/config"
+	"github.com/openshift/cloud-ingress-operator/pkg/dnsclient"
 	cioerrors "github.com/openshift/cloud-ingress-operator/pkg/errors"
 	baseutils "github.com/openshift/cloud-ingress-operator/pkg/utils"
 )
 
+const (
+	// routerServiceNamespace and routerServicePrefix locate the router Service
+	// of an IngressController, <prefix><IngressController name>
+	routerServiceNamespace = "openshift-ingress"
+	routerServicePrefix    = "router-"
+
+	// defaultRecordTTL is the TTL of the A records, in seconds, unless the
+	// custom resource they're for sets one
+	defaultRecordTTL int64 = 30
+)
+
 // ensureAdminAPIDNS ensures the DNS record for the "admin API" Service
 // LoadBalancer is accurately set
 func (c *Client) ensureAdminAPIDNS(ctx context.Context, kclient client.Client, instance *cloudingressv1alpha1.APIScheme, svc *corev1.Service) error {
-	return c.ensureDNSForService(kclient, svc, instance.Spec.ManagementAPIServerIngress.DNSName)
+	FQDN, zones, err := c.getAdminAPIDNS(kclient, instance)
+	if err != nil {
+		return err
+	}
+	return c.ensureDNSForService(svc, FQDN, zones, recordTTL(instance.Spec.ManagementAPIServerIngress.DNSTTLSeconds))
+}
+
+// ensureAdminAPIWeightedDNS points the admin API record at svc alone. There
+// is no classic load balancer on GCP to migrate away from
+func (c *Client) ensureAdminAPIWeightedDNS(ctx context.Context, kclient client.Client, instance *cloudingressv1alpha1.APIScheme, svc, migrationSvc *corev1.Service, weight int32) error {
+	return c.ensureAdminAPIDNS(ctx, kclient, instance, svc)
+}
+
+// ensureAdminAPIFailoverDNS points the admin API record at svc alone, like
+// ensureAdminAPIWeightedDNS
+func (c *Client) ensureAdminAPIFailoverDNS(ctx context.Context, kclient client.Client, instance *cloudingressv1alpha1.APIScheme, svc, secondarySvc *corev1.Service) error {
+	return c.ensureAdminAPIDNS(ctx, kclient, instance, svc)
 }
 
 // deleteAdminAPIDNS ensures the DNS record for the "admin API" Service
 // LoadBalancer is deleted
 func (c *Client) deleteAdminAPIDNS(ctx context.Context, kclient client.Client, instance *cloudingressv1alpha1.APIScheme, svc *corev1.Service) error {
-	return c.removeDNSForService(kclient, svc, instance.Spec.ManagementAPIServerIngress.DNSName)
+	FQDN, zones, err := c.getAdminAPIDNS(kclient, instance)
+	if err != nil {
+		return err
+	}
+	return c.removeDNSForService(FQDN, zones)
+}
+
+// ensureAdminAPIStaticIPs reserves a static external IP for the "admin API"
+// Service LoadBalancer and pins the Service to it. A pre-reserved address may
+// be given by name as the first of the APIScheme's allocation IDs
+func (c *Client) ensureAdminAPIStaticIPs(ctx context.Context, kclient client.Client, instance *cloudingressv1alpha1.APIScheme, svc *corev1.Service) ([]string, error) {
+	region, err := getClusterRegion(kclient)
+	if err != nil {
+		return []string{}, err
+	}
+	infrastructureName, err := baseutils.GetClusterName(kclient)
+	if err != nil {
+		return []string{}, err
+	}
+	staticIPName := infrastructureName + "-" + config.AdminAPIName + "-ip"
+	if allocationIDs := instance.Spec.ManagementAPIServerIngress.StaticIP.AllocationIDs; len(allocationIDs) > 0 {
+		staticIPName = allocationIDs[0]
+	}
+	staticIPAddress, err := c.createExternalIP(staticIPName, "EXTERNAL", region)
+	if err != nil {
+		return []string{}, err
+	}
+	svc.Spec.LoadBalancerIP = staticIPAddress
+	return []string{staticIPAddress}, nil
+}
+
+// releaseAdminAPIStaticIPs is a no-op on GCP. The operator only records the
+// Elastic IPs it allocates on AWS, so there's nothing to release
+func (c *Client) releaseAdminAPIStaticIPs(ctx context.Context, kclient client.Client, instance *cloudingressv1alpha1.APIScheme, allocationIDs []string) ([]string, error) {
+	return []string{}, nil
+}
+
+// ensureAdminAPISecurityGroup ensures the cluster's rh-api firewall rule
+// exists and only lets the APIScheme's allowed CIDR blocks reach the master
+// instances on the admin API port, like the security group on AWS. The
+// cloud provider's own rule for the Service is left alone. Returns the name
+// of the rule
+func (c *Client) ensureAdminAPISecurityGroup(ctx context.Context, kclient client.Client, instance *cloudingressv1alpha1.APIScheme, svc *corev1.Service) (string, error) {
+	infrastructureName, err := baseutils.GetClusterName(kclient)
+	if err != nil {
+		return "", err
+	}
+	projectID, network, err := c.getMasterNetwork(kclient)
+	if err != nil {
+		return "", err
+	}
+	// Like the cloud provider, treat no allowed CIDR blocks as open to all.
+	// Expired ones are revoked
+	cidrs, _, err := baseutils.GetEffectiveAllowedCIDRBlocks(kclient, instance, time.Now())
+	if err != nil {
+		return "", err
+	}
+	if len(cidrs) == 0 {
+		cidrs = []string{"0.0.0.0/0"}
+	}
+	port := config.AdminAPIListenerPort
+	if len(svc.Spec.Ports) > 0 {
+		port = int64(svc.Spec.Ports[0].Port)
+	}
+	desired := adminAPIFirewall(infrastructureName, network, cidrs, port)
+
+	current, err := c.computeService.Firewalls.Get(projectID, desired.Name).Do()
+	if err != nil {
+		if gcpError, ok := err.(*googleapi.Error); !ok || gcpError.Code != http.StatusNotFound {
+			return "", err
+		}
+		_, err = c.computeService.Firewalls.Insert(projectID, desired).Do()
+		if err != nil {
+			return "", err
+		}
+		log.Info("Created firewall rule", "name", desired.Name, "sourceRanges", cidrs)
+		return desired.Name, nil
+	}
+	if firewallMatches(current, desired) {
+		return desired.Name, nil
+	}
+	_, err = c.computeService.Firewalls.Update(projectID, desired.Name, desired).Do()
+	if err != nil {
+		return "", err
+	}
+	log.Info("Updated firewall rule", "name", desired.Name, "sourceRanges", cidrs)
+	return desired.Name, nil
+}
+
+// deleteAdminAPISecurityGroup deletes the cluster's rh-api firewall rule, if
+// it exists
+func (c *Client) deleteAdminAPISecurityGroup(ctx context.Context, kclient client.Client, instance *cloudingressv1alpha1.APIScheme) error {
+	infrastructureName, err := baseutils.GetClusterName(kclient)
+	if err != nil {
+		return err
+	}
+	projectID, _, err := c.getMasterNetwork(kclient)
+	if err != nil {
+		return err
+	}
+	name := infrastructureName + "-" + config.AdminAPISecurityGroupName
+	_, err = c.computeService.Firewalls.Delete(projectID, name).Do()
+	if err != nil {
+		if gcpError, ok := err.(*googleapi.Error); ok && gcpError.Code == http.StatusNotFound {
+			return nil
+		}
+		return err
+	}
+	log.Info("Deleted firewall rule", "name", name)
+	return nil
+}
+
+// adminAPIFirewall returns the rh-api firewall rule letting cidrs reach the
+// master instances of the cluster infrastructureName, tagged
+// <infrastructure-name>-master by the installer, on TCP port
+func adminAPIFirewall(infrastructureName, network string, cidrs []string, port int64) *compute.Firewall {
+	return &compute.Firewall{
+		Name:         infrastructureName + "-" + config.AdminAPISecurityGroupName,
+		Description:  "Admin API access managed by cloud-ingress-operator",
+		Network:      network,
+		Direction:    "INGRESS",
+		SourceRanges: cidrs,
+		TargetTags:   []string{infrastructureName + "-master"},
+		Allowed: []*compute.FirewallAllowed{
+			{IPProtocol: "tcp", Ports: []string{strconv.FormatInt(port, 10)}},
+		},
+	}
+}
+
+// firewallMatches checks if the current firewall rule lets in what desired
+// does, and nothing else. The network of a rule can't be changed, and is
+// returned as a full URL, so it isn't compared
+func firewallMatches(current, desired *compute.Firewall) bool {
+	if current.Disabled || current.Direction != desired.Direction {
+		return false
+	}
+	if !sameIPs(current.SourceRanges, desired.SourceRanges) || !sameIPs(current.TargetTags, desired.TargetTags) {
+		return false
+	}
+	if len(current.SourceTags) > 0 || len(current.SourceServiceAccounts) > 0 || len(current.TargetServiceAccounts) > 0 {
+		return false
+	}
+	return reflect.DeepEqual(current.Allowed, desired.Allowed) && len(current.Denied) == 0
+}
+
+// getMasterNetwork returns the VPC network of the cluster's master
+// instances, as a partial URL, and the project it's in, where its firewall
+// rules go: the host project of a shared VPC, or else the cluster's
+func (c *Client) getMasterNetwork(kclient client.Client) (string, string, error) {
+	masterList, err := baseutils.GetMasterMachines(kclient)
+	if err != nil {
+		return "", "", err
+	}
+	for _, machine := range masterList.Items {
+		providerSpec, err := getGCPDecodedProviderSpec(machine)
+		if err != nil {
+			return "", "", err
+		}
+		for _, networkInterface := range providerSpec.NetworkInterfaces {
+			if networkInterface == nil || networkInterface.Network == "" {
+				continue
+			}
+			projectID := networkInterface.ProjectID
+			if projectID == "" {
+				projectID = c.projectID
+			}
+			return projectID, path.Join("projects", projectID, "global/networks", path.Base(networkInterface.Network)), nil
+		}
+	}
+	return "", "", fmt.Errorf("No master machine has a network interface")
+}
+
+// deleteAdminAPILoadBalancer is a no-op on GCP. The cloud provider deletes the
+// forwarding rule and target pool with the "admin API" Service, and nothing
+// else waits for them to go
+func (c *Client) deleteAdminAPILoadBalancer(ctx context.Context, kclient client.Client, instance *cloudingressv1alpha1.APIScheme, svc *corev1.Service) error {
+	return nil
+}
+
+// ensureAdminAPIIPAddressType is a no-op on GCP. The forwarding rule of the
+// "admin API" load balancer has a single IPv4 address
+func (c *Client) ensureAdminAPIIPAddressType(ctx context.Context, kclient client.Client, instance *cloudingressv1alpha1.APIScheme, svc *corev1.Service) error {
+	return nil
+}
+
+// ensureAdminAPIAccessLogs is a no-op on GCP. The target pool load balancer
+// the cloud provider creates for the "admin API" Service doesn't log requests
+func (c *Client) ensureAdminAPIAccessLogs(ctx context.Context, kclient client.Client, instance *cloudingressv1alpha1.APIScheme, svc *corev1.Service) error {
+	return nil
+}
+
+// ensureAdminAPIAlarms is a no-op on GCP. Alerting on the "admin API" load
+// balancer is left to the cluster's monitoring
+func (c *Client) ensureAdminAPIAlarms(ctx context.Context, kclient client.Client, instance *cloudingressv1alpha1.APIScheme, svc *corev1.Service) error {
+	return nil
+}
+
+// ensureAdminAPITLSPolicy is a no-op on GCP. The target pool load balancer
+// passes TCP through, so it has no TLS policy
+func (c *Client) ensureAdminAPITLSPolicy(ctx context.Context, kclient client.Client, instance *cloudingressv1alpha1.APIScheme, svc *corev1.Service) error {
+	return nil
+}
+
+// ensureAdminAPICertificate is a no-op on GCP. The target pool load balancer
+// passes TCP through, so it serves no certificate
+func (c *Client) ensureAdminAPICertificate(ctx context.Context, kclient client.Client, instance *cloudingressv1alpha1.APIScheme, svc *corev1.Service) error {
+	return nil
+}
+
+// ensureAdminAPIStickiness is a no-op on GCP. The cloud provider sets the
+// session affinity of the rh-api target pool or backend service from the
+// Service's sessionAffinity
+func (c *Client) ensureAdminAPIStickiness(ctx context.Context, kclient client.Client, instance *cloudingressv1alpha1.APIScheme, svc *corev1.Service) error {
+	return nil
+}
+
+// ensureAdminAPIMasterMachines is a no-op on GCP. The cloud provider keeps the
+// nodes in the rh-api target pool or instance groups itself
+func (c *Client) ensureAdminAPIMasterMachines(ctx context.Context, kclient client.Client, instance *cloudingressv1alpha1.APIScheme, svc *corev1.Service) error {
+	return nil
+}
+
+// deleteAdminAPIAlarms is a no-op on GCP, see ensureAdminAPIAlarms
+func (c *Client) deleteAdminAPIAlarms(ctx context.Context, kclient client.Client, instance *cloudingressv1alpha1.APIScheme) error {
+	return nil
+}
+
+// checkAdminAPIDrift checks the forwarding rule and target pool the cloud
+// provider created for the "admin API" Service, and the A records of the
+// Service, without changing any of them. Returns the drifted components
+func (c *Client) checkAdminAPIDrift(ctx context.Context, kclient client.Client, instance *cloudingressv1alpha1.APIScheme, svc *corev1.Service) ([]string, error) {
+	svcIPs, err := getIPAddressesFromService(svc)
+	if err != nil {
+		return []string{}, err
+	}
+	region, err := getClusterRegion(kclient)
+	if err != nil {
+		return []string{}, err
+	}
+	// The cloud provider names the forwarding rule after the Service
+	lbName := getServiceLoadBalancerName(svc)
+
+	drifted := []string{}
+	forwardingRule, err := c.computeService.ForwardingRules.Get(c.projectID, region, lbName).Do()
+	if err != nil {
+		gcpError, ok := err.(*googleapi.Error)
+		if !ok || gcpError.Code != http.StatusNotFound {
+			return []string{}, err
+		}
+		// Resyncing the Service recreates whatever it forwards to as well
+		drifted = append(drifted, "forwardingrule")
+	} else {
+		if forwardingRule.IPAddress != svcIPs[0] {
+			drifted = append(drifted, "forwardingrule")
+		}
+		instanceURLs, err := c.backendInstances(ctx, forwardingRuleBackend(region, forwardingRule))
+		if _, notReady := err.(*cioerrors.LoadBalancerNotReadyError); notReady {
+			drifted = append(drifted, "instances")
+		} else if err != nil {
+			return drifted, err
+		} else {
+			nodes, err := baseutils.GetLoadBalancerNodes(kclient, nil)
+			if err != nil {
+				return drifted, err
+			}
+			registered := make([]string, 0, len(instanceURLs))
+			for _, instanceURL := range instanceURLs {
+				registered = append(registered, path.Base(instanceURL))
+			}
+			desired := make([]string, 0, len(nodes))
+			for _, node := range nodes {
+				// GCP node names are their instance names
+				desired = append(desired, node.Name)
+			}
+			if !baseutils.SameMembers(registered, desired) {
+				drifted = append(drifted, "instances")
+			}
+		}
+	}
+
+	// external-dns owns the records of its APISchemes
+	if instance.Spec.ManagementAPIServerIngress.DNSManagement == cloudingressv1alpha1.DNSManagementExternalDNS {
+		return drifted, nil
+	}
+	FQDN, zones, err := c.getAdminAPIDNS(kclient, instance)
+	if err != nil {
+		return drifted, err
+	}
+	for _, zone := range zones {
+		record, err := c.getARecord(zone, FQDN)
+		if err != nil {
+			return drifted, err
+		}
+		if record == nil || !sameIPs(record.Values, svcIPs) || record.TTL != recordTTL(instance.Spec.ManagementAPIServerIngress.DNSTTLSeconds) {
+			drifted = append(drifted, "dns")
+			break
+		}
+	}
+	return drifted, nil
+}
+
+// repairAdminAPIDrift asks the cloud provider to resync the "admin API"
+// Service if checkAdminAPIDrift finds its load balancer drifted. DNS drift is
+// already repaired by ensureAdminAPIDNS, which replaces mismatched records
+func (c *Client) repairAdminAPIDrift(ctx context.Context, kclient client.Client, instance *cloudingressv1alpha1.APIScheme, svc *corev1.Service) ([]string, error) {
+	drifted, err := c.checkAdminAPIDrift(ctx, kclient, instance, svc)
+	if err != nil {
+		return drifted, err
+	}
+	lbDrifted := []string{}
+	for _, component := range drifted {
+		if component != "dns" {
+			lbDrifted = append(lbDrifted, component)
+		}
+	}
+	if len(lbDrifted) > 0 {
+		log.Info("Load balancer drifted from its Service, asking the cloud provider to resync", "service", svc.Name, "drifted", lbDrifted)
+		err = baseutils.RequestLoadBalancerResync(kclient, svc)
+		if err != nil {
+			return drifted, err
+		}
+	}
+	return drifted, nil
+}
+
+// getAdminAPIRegisteredInstances returns the names of the instances the load
+// balancer the cloud provider created for the "admin API" Service forwards to
+func (c *Client) getAdminAPIRegisteredInstances(ctx context.Context, kclient client.Client, instance *cloudingressv1alpha1.APIScheme, svc *corev1.Service) ([]string, error) {
+	backend, err := c.getLoadBalancerBackend(kclient, svc)
+	if err != nil {
+		return []string{}, err
+	}
+	instanceURLs, err := c.backendInstances(ctx, backend)
+	if err != nil {
+		return []string{}, err
+	}
+	instanceNames := []string{}
+	for _, instanceURL := range instanceURLs {
+		instanceNames = append(instanceNames, path.Base(instanceURL))
+	}
+	return instanceNames, nil
+}
+
+// getAdminAPIHealthyInstances returns the names of the instances behind the
+// rh-api load balancer its health checks find HEALTHY
+func (c *Client) getAdminAPIHealthyInstances(ctx context.Context, kclient client.Client, instance *cloudingressv1alpha1.APIScheme, svc *corev1.Service) ([]string, error) {
+	backend, err := c.getLoadBalancerBackend(kclient, svc)
+	if err != nil {
+		return []string{}, err
+	}
+	instanceURLs, err := c.backendInstances(ctx, backend)
+	if err != nil {
+		return []string{}, err
+	}
+	health, err := c.backendHealth(backend, instanceURLs)
+	if err != nil {
+		return []string{}, err
+	}
+	instanceNames := []string{}
+	for _, instanceURL := range instanceURLs {
+		for _, status := range health[instanceURL] {
+			if status.HealthState == "HEALTHY" {
+				instanceNames = append(instanceNames, path.Base(instanceURL))
+				break
+			}
+		}
+	}
+	return instanceNames, nil
+}
+
+// getAdminAPIInstanceHealth returns the instances behind the rh-api load
+// balancer, with their zone and the health state its health checks find. An
+// instance unhealthy on any of its addresses is UNHEALTHY, one not checked
+// yet UNKNOWN
+func (c *Client) getAdminAPIInstanceHealth(ctx context.Context, kclient client.Client, instance *cloudingressv1alpha1.APIScheme, svc *corev1.Service) ([]cloudingressv1alpha1.RegisteredInstance, error) {
+	backend, err := c.getLoadBalancerBackend(kclient, svc)
+	if err != nil {
+		return []cloudingressv1alpha1.RegisteredInstance{}, err
+	}
+	instanceURLs, err := c.backendInstances(ctx, backend)
+	if err != nil {
+		return []cloudingressv1alpha1.RegisteredInstance{}, err
+	}
+	health, err := c.backendHealth(backend, instanceURLs)
+	if err != nil {
+		return []cloudingressv1alpha1.RegisteredInstance{}, err
+	}
+	registered := []cloudingressv1alpha1.RegisteredInstance{}
+	for _, instanceURL := range instanceURLs {
+		registered = append(registered, cloudingressv1alpha1.RegisteredInstance{
+			InstanceID:       path.Base(instanceURL),
+			AvailabilityZone: instanceZone(instanceURL),
+			State:            worstHealthState(health[instanceURL]),
+		})
+	}
+	return registered, nil
+}
+
+// adminAPILoadBalancerExists checks the forwarding rule the cloud provider
+// created for the "admin API" Service exists
+func (c *Client) adminAPILoadBalancerExists(ctx context.Context, kclient client.Client, instance *cloudingressv1alpha1.APIScheme, svc *corev1.Service) (bool, error) {
+	_, err := c.getLoadBalancerBackend(kclient, svc)
+	if _, notFound := err.(*cioerrors.LoadBalancerNotReadyError); notFound {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// getAdminAPILoadBalancerAttributes returns no attribute on GCP. The target
+// pool load balancer passes TCP through without any of them
+func (c *Client) getAdminAPILoadBalancerAttributes(ctx context.Context, kclient client.Client, instance *cloudingressv1alpha1.APIScheme, svc *corev1.Service) (*cloudingressv1alpha1.LoadBalancerAttributes, error) {
+	return &cloudingressv1alpha1.LoadBalancerAttributes{}, nil
+}
+
+// loadBalancerBackend is what the forwarding rule of a load balancer forwards
+// to: a target pool, for a legacy network load balancer, or a regional
+// backend service of instance groups, for internal load balancers and
+// external ones asking for it
+type loadBalancerBackend struct {
+	region         string
+	targetPool     string
+	backendService string
+}
+
+// getLoadBalancerBackend returns the backend of the load balancer the cloud
+// provider created for svc, read from its forwarding rule, so either kind is
+// found whatever the APIScheme asks for now
+func (c *Client) getLoadBalancerBackend(kclient client.Client, svc *corev1.Service) (loadBalancerBackend, error) {
+	region, err := getClusterRegion(kclient)
+	if err != nil {
+		return loadBalancerBackend{}, err
+	}
+	forwardingRule, err := c.computeService.ForwardingRules.Get(c.projectID, region, getServiceLoadBalancerName(svc)).Do()
+	if err != nil {
+		if gcpError, ok := err.(*googleapi.Error); ok && gcpError.Code == http.StatusNotFound {
+			return loadBalancerBackend{}, cioerrors.NewLoadBalancerNotReadyError()
+		}
+		return loadBalancerBackend{}, err
+	}
+	return forwardingRuleBackend(region, forwardingRule), nil
+}
+
+// forwardingRuleBackend returns the backend forwardingRule, in region,
+// forwards to
+func forwardingRuleBackend(region string, forwardingRule *compute.ForwardingRule) loadBalancerBackend {
+	if forwardingRule.BackendService != "" {
+		return loadBalancerBackend{region: region, backendService: path.Base(forwardingRule.BackendService)}
+	}
+	return loadBalancerBackend{region: region, targetPool: path.Base(forwardingRule.Target)}
+}
+
+// backendInstances returns the URLs of the instances backend forwards to:
+// those of the target pool, or of the instance groups of the backend service
+func (c *Client) backendInstances(ctx context.Context, backend loadBalancerBackend) ([]string, error) {
+	if backend.backendService == "" {
+		targetPool, err := c.computeService.TargetPools.Get(c.projectID, backend.region, backend.targetPool).Do()
+		if err != nil {
+			if gcpError, ok := err.(*googleapi.Error); ok && gcpError.Code == http.StatusNotFound {
+				return []string{}, cioerrors.NewLoadBalancerNotReadyError()
+			}
+			return []string{}, err
+		}
+		return targetPool.Instances, nil
+	}
+	backendService, err := c.computeService.RegionBackendServices.Get(c.projectID, backend.region, backend.backendService).Do()
+	if err != nil {
+		if gcpError, ok := err.(*googleapi.Error); ok && gcpError.Code == http.StatusNotFound {
+			return []string{}, cioerrors.NewLoadBalancerNotReadyError()
+		}
+		return []string{}, err
+	}
+	instanceURLs := []string{}
+	for _, group := range backendService.Backends {
+		// Instance groups are zonal, .../zones/<zone>/instanceGroups/<name>
+		err = c.computeService.InstanceGroups.ListInstances(c.projectID, instanceZone(group.Group), path.Base(group.Group), &compute.InstanceGroupsListInstancesRequest{
+			InstanceState: "ALL",
+		}).Pages(ctx, func(page *compute.InstanceGroupsListInstances) error {
+			for _, item := range page.Items {
+				instanceURLs = append(instanceURLs, item.Instance)
+			}
+			return nil
+		})
+		if err != nil {
+			return []string{}, err
+		}
+	}
+	return instanceURLs, nil
+}
+
+// backendHealth returns the health statuses the health checks of backend
+// find for each of instanceURLs, by URL
+func (c *Client) backendHealth(backend loadBalancerBackend, instanceURLs []string) (map[string][]*compute.HealthStatus, error) {
+	health := map[string][]*compute.HealthStatus{}
+	if backend.backendService == "" {
+		for _, instanceURL := range instanceURLs {
+			output, err := c.computeService.TargetPools.GetHealth(c.projectID, backend.region, backend.targetPool, &compute.InstanceReference{
+				Instance: instanceURL,
+			}).Do()
+			if err != nil {
+				return nil, err
+			}
+			health[instanceURL] = output.HealthStatus
+		}
+		return health, nil
+	}
+	backendService, err := c.computeService.RegionBackendServices.Get(c.projectID, backend.region, backend.backendService).Do()
+	if err != nil {
+		return nil, err
+	}
+	for _, group := range backendService.Backends {
+		output, err := c.computeService.RegionBackendServices.GetHealth(c.projectID, backend.region, backend.backendService, &compute.ResourceGroupReference{
+			Group: group.Group,
+		}).Do()
+		if err != nil {
+			return nil, err
+		}
+		for _, status := range output.HealthStatus {
+			health[status.Instance] = append(health[status.Instance], status)
+		}
+	}
+	return health, nil
+}
+
+// instanceZone returns the zone of the instance at instanceURL,
+// .../zones/<zone>/instances/<name>, or of any other zonal resource
+func instanceZone(instanceURL string) string {
+	return path.Base(path.Dir(path.Dir(instanceURL)))
+}
+
+// worstHealthState returns HEALTHY if every one of statuses is, UNKNOWN if
+// there are none, or else the first state that isn't HEALTHY
+func worstHealthState(statuses []*compute.HealthStatus) string {
+	if len(statuses) == 0 {
+		return "UNKNOWN"
+	}
+	for _, status := range statuses {
+		if status.HealthState != "HEALTHY" {
+			return status.HealthState
+		}
+	}
+	return "HEALTHY"
+}
+
+// getAdminAPINameServers returns the nameservers of the public managed zones
+// the rh-api record goes in
+func (c *Client) getAdminAPINameServers(ctx context.Context, kclient client.Client, instance *cloudingressv1alpha1.APIScheme) ([]string, error) {
+	_, zones, err := c.getAdminAPIDNS(kclient, instance)
+	if err != nil {
+		return []string{}, err
+	}
+	nameServers := []string{}
+	seen := map[string]bool{}
+	for _, zone := range zones {
+		managedZone, err := c.dnsService.ManagedZones.Get(c.projectID, zone).Do()
+		if err != nil {
+			return []string{}, err
+		}
+		// Private zones are only served inside the VPC
+		if managedZone.Visibility == "private" {
+			continue
+		}
+		for _, nameServer := range managedZone.NameServers {
+			if !seen[nameServer] {
+				seen[nameServer] = true
+				nameServers = append(nameServers, nameServer)
+			}
+		}
+	}
+	return nameServers, nil
 }
 
 // ensureSSHDNS ensures the DNS record for the SSH Service LoadBalancer
 // is accurately set
 func (c *Client) ensureSSHDNS(ctx context.Context, kclient client.Client, instance *cloudingressv1alpha1.SSHD, svc *corev1.Service) error {
-	return c.ensureDNSForService(kclient, svc, instance.Spec.DNSName)
+	FQDN, zones, err := getServiceDNS(kclient, instance.Spec.DNSName)
+	if err != nil {
+		return err
+	}
+	return c.ensureDNSForService(svc, FQDN, zones, defaultRecordTTL)
 }
 
 // deleteSSHDNS ensures the DNS record for the SSH Service LoadBalancer
 // is deleted
 func (c *Client) deleteSSHDNS(ctx context.Context, kclient client.Client, instance *cloudingressv1alpha1.SSHD, svc *corev1.Service) error {
-	return c.removeDNSForService(kclient, svc, instance.Spec.DNSName)
+	FQDN, zones, err := getServiceDNS(kclient, instance.Spec.DNSName)
+	if err != nil {
+		return err
+	}
+	return c.removeDNSForService(FQDN, zones)
 }
 
 // setDefaultAPIPrivate sets the default api (api.<cluster-domain>) to private
@@ -110,11 +723,15 @@ func (c *Client) setDefaultAPIPublic(ctx context.Context, kclient client.Client,
 	//GCP ForwardingRule and TargetPool share the same name
 	extNLBName := infrastructureName + "-api"
 	staticIPName := infrastructureName + "-cluster-public-ip"
+	portRange, err := apiPortRange(kclient)
+	if err != nil {
+		return err
+	}
 	for _, lb := range response.Items {
 		// This list of forwardingrules (LBs) includes any service LBs
 		// for application routers so check the port range to identify
 		// the external API LB.
-		if lb.LoadBalancingScheme == "EXTERNAL" && lb.PortRange == "6443-6443" && lb.Name == extNLBName {
+		if lb.LoadBalancingScheme == "EXTERNAL" && lb.PortRange == portRange && lb.Name == extNLBName {
 			// If there is already an external LB serving over the API port, there is nothing to do.
 			return nil
 		}
@@ -123,7 +740,7 @@ func (c *Client) setDefaultAPIPublic(ctx context.Context, kclient client.Client,
 	if err != nil {
 		return err
 	}
-	err = c.createNetworkLoadBalancer(extNLBName, "EXTERNAL", extNLBName, region, staticIPAddress)
+	err = c.createNetworkLoadBalancer(extNLBName, "EXTERNAL", extNLBName, region, staticIPAddress, portRange)
 	if err != nil {
 		return err
 	}
@@ -140,132 +757,356 @@ func (c *Client) setDefaultAPIPublic(ctx context.Context, kclient client.Client,
 	return nil
 }
 
-func (c *Client) ensureDNSForService(kclient client.Client, svc *corev1.Service, dnsName string) error {
-	// google.golang.org/api/dns/v1.Service is a struct, not an interface, which
-	// will make this all but impossible to write unit tests for
-
+// ensureDNSForService makes the A record FQDN resolve to the load balancer IP
+// of svc in each of the managed zones, with the given TTL
+func (c *Client) ensureDNSForService(svc *corev1.Service, FQDN string, zones []string, ttl int64) error {
 	svcIPs, err := getIPAddressesFromService(svc)
 	if err != nil {
 		return err
 	}
+	for _, zone := range zones {
+		err = c.upsertARecord(zone, FQDN, svcIPs, ttl)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
 
+// removeDNSForService removes the A record FQDN from each of the managed zones
+func (c *Client) removeDNSForService(FQDN string, zones []string) error {
+	for _, zone := range zones {
+		err := c.deleteARecord(zone, FQDN)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// getServiceDNS returns the FQDN of a Service published in the cluster's
+// zones as <dnsName>.<cluster base domain>, and the names of those zones
+func getServiceDNS(kclient client.Client, dnsName string) (string, []string, error) {
 	baseDomain, err := baseutils.GetClusterBaseDomain(kclient)
 	if err != nil {
-		return err
+		return "", nil, err
 	}
-	FQDN := dnsName + "." + baseDomain + "."
-
-	// The resource record set to add.
-	// Kind and SignatureRrdatas are set as
-	// they are to satisfy reflect.DeepEqual.
-	newRRSet := &gdnsv1.ResourceRecordSet{
-		Kind:             "dns#resourceRecordSet",
-		Name:             FQDN,
-		Rrdatas:          svcIPs,
-		SignatureRrdatas: []string{},
-		Type:             "A",
-		Ttl:              30,
+	publicZone, privateZone, err := getManagedZones(kclient)
+	if err != nil {
+		return "", nil, err
+	}
+	zones := []string{}
+	for _, zone := range []string{publicZone, privateZone} {
+		if zone != "" {
+			zones = append(zones, zone)
+		}
 	}
+	return dnsName + "." + baseDomain + ".", zones, nil
+}
 
-	clusterDNS, err := getClusterDNS(kclient)
+// getAdminAPIDNS returns the FQDN of the "admin API" Service and the names of
+// the managed zones it's published in. An APIScheme with its own base domain
+// or managed zone is only published in that zone, which must exist
+func (c *Client) getAdminAPIDNS(kclient client.Client, instance *cloudingressv1alpha1.APIScheme) (string, []string, error) {
+	ingress := instance.Spec.ManagementAPIServerIngress
+	if ingress.BaseDomain == "" && ingress.HostedZoneID == "" {
+		return getServiceDNS(kclient, ingress.DNSName)
+	}
+	baseDomain, err := baseutils.GetAdminAPIBaseDomain(kclient, instance)
 	if err != nil {
-		return err
+		return "", nil, err
 	}
+	FQDN := ingress.DNSName + "." + baseDomain + "."
+	zone, err := c.getCustomManagedZone(ingress.HostedZoneID, baseDomain, FQDN)
+	if err != nil {
+		return "", nil, err
+	}
+	return FQDN, []string{zone}, nil
+}
 
-	var zones []configv1.DNSZone
-	if clusterDNS.Spec.PublicZone != nil {
-		zones = append(zones, *clusterDNS.Spec.PublicZone)
+// getCustomManagedZone returns the name of the managed zone given by an
+// APIScheme for FQDN: zone, once checked to exist and to be able to hold FQDN,
+// or else the public zone for baseDomain
+func (c *Client) getCustomManagedZone(zone, baseDomain, FQDN string) (string, error) {
+	if zone == "" {
+		managedZones, err := c.dns().ZoneForDomain(baseDomain)
+		if err != nil {
+			return "", err
+		}
+		for _, managedZone := range managedZones {
+			if !managedZone.Private {
+				return managedZone.ID, nil
+			}
+		}
+		return "", cioerrors.NewDNSZoneNotFoundError("Cloud DNS public managed zone for " + baseDomain)
 	}
-	if clusterDNS.Spec.PrivateZone != nil {
-		zones = append(zones, *clusterDNS.Spec.PrivateZone)
+	managedZone, err := c.dnsService.ManagedZones.Get(c.projectID, zone).Do()
+	if err != nil {
+		dnsError, ok := err.(*googleapi.Error)
+		if ok && dnsError.Code == http.StatusNotFound {
+			return "", cioerrors.NewDNSZoneNotFoundError("Cloud DNS managed zone " + zone)
+		}
+		return "", err
+	}
+	if !baseutils.IsInDNSZone(FQDN, managedZone.DnsName) {
+		return "", fmt.Errorf("%s can't be published in the Cloud DNS managed zone %s for %s", FQDN, zone, managedZone.DnsName)
 	}
+	return managedZone.Name, nil
+}
 
-	for _, zone := range zones {
-		dnsChange := &gdnsv1.Change{
-			Additions: []*gdnsv1.ResourceRecordSet{newRRSet},
+// ensureApplicationIngressDNS points the wildcard A record of each
+// ApplicationIngress at the forwarding rule IP of its router Service, as
+// ensureRouterServiceDNS does when the routerservice controller sees the
+// Service's load balancer change. Router Services without a load balancer yet
+// are skipped, as their load balancer change will trigger another reconcile
+func (c *Client) ensureApplicationIngressDNS(ctx context.Context, kclient client.Client, instance *cloudingressv1alpha1.PublishingStrategy) error {
+	for _, status := range instance.Status.ApplicationIngress {
+		var appIngress *cloudingressv1alpha1.ApplicationIngress
+		for i := range instance.Spec.ApplicationIngress {
+			if instance.Spec.ApplicationIngress[i].DNSName == status.DNSName {
+				appIngress = &instance.Spec.ApplicationIngress[i]
+			}
 		}
+		if appIngress == nil || status.IngressControllerName == "" {
+			continue
+		}
+		svc := &corev1.Service{}
+		err := kclient.Get(ctx, types.NamespacedName{Namespace: routerServiceNamespace, Name: routerServicePrefix + status.IngressControllerName}, svc)
+		if err != nil {
+			if k8serr.IsNotFound(err) {
+				log.Info("Router Service doesn't exist yet, not updating DNS", "DNSName", appIngress.DNSName)
+				continue
+			}
+			return err
+		}
+		err = c.ensureRouterServiceDNS(ctx, kclient, appIngress, svc)
+		if err != nil {
+			if _, ok := err.(*cioerrors.LoadBalancerNotReadyError); ok {
+				log.Info("Router load balancer isn't ready yet, not updating DNS", "DNSName", appIngress.DNSName)
+				continue
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+// ensureRouterServiceDNS points the wildcard A record of appIngress,
+// *.<DNSName>, at the forwarding rule IP of its router Service svc. It's
+// upserted in the private zone, and in the public zone if appIngress is
+// external. Otherwise, any record the external load balancer it replaced left
+// in the public zone is removed
+func (c *Client) ensureRouterServiceDNS(ctx context.Context, kclient client.Client, appIngress *cloudingressv1alpha1.ApplicationIngress, svc *corev1.Service) error {
+	baseDomain, err := baseutils.GetClusterBaseDomain(kclient)
+	if err != nil {
+		return err
+	}
+	if !strings.HasSuffix(appIngress.DNSName, baseDomain) {
+		return nil
+	}
+	ingressIPs, err := getIPAddressesFromService(svc)
+	if err != nil {
+		return err
+	}
+	publicZone, privateZone, err := getManagedZones(kclient)
+	if err != nil {
+		return err
+	}
+	FQDN := "*." + appIngress.DNSName + "."
+	ttl := recordTTL(appIngress.DNSTTLSeconds)
 
-		// Look for an existing resource record set in the zone.
-		listCall := c.dnsService.ResourceRecordSets.List(c.projectID, zone.ID)
-		response, err := listCall.Name(FQDN).Do()
+	if privateZone != "" {
+		err = c.upsertARecord(privateZone, FQDN, ingressIPs, ttl)
 		if err != nil {
 			return err
 		}
+	}
+	if publicZone == "" {
+		return nil
+	}
+	if appIngress.Listening == cloudingressv1alpha1.External {
+		return c.upsertARecord(publicZone, FQDN, ingressIPs, ttl)
+	}
+	return c.deleteARecord(publicZone, FQDN)
+}
 
-		// There will be at most one result but loop anyway.
-		// An empty slice will proceed directly to Create.
-		for _, rrset := range response.Rrsets {
-			if reflect.DeepEqual(newRRSet, rrset) {
-				dnsChange.Additions = []*gdnsv1.ResourceRecordSet{}
-			} else {
-				dnsChange.Deletions = append(dnsChange.Deletions, rrset)
+// ensureApplicationIngressLoadBalancers keeps the cloud resources around the
+// router load balancer of each ApplicationIngress in line with its scope. The
+// IP address of an external load balancer is reserved, so that it survives
+// the router Service being recreated and the DNS records pointing at it stay
+// right. Once the ApplicationIngress is internal, the reservation is released
+// and the external firewall rule is removed if the switch left it behind
+func (c *Client) ensureApplicationIngressLoadBalancers(ctx context.Context, kclient client.Client, instance *cloudingressv1alpha1.PublishingStrategy) error {
+	region, err := getClusterRegion(kclient)
+	if err != nil {
+		return err
+	}
+	infrastructureName, err := baseutils.GetClusterName(kclient)
+	if err != nil {
+		return err
+	}
+	for _, status := range instance.Status.ApplicationIngress {
+		var appIngress *cloudingressv1alpha1.ApplicationIngress
+		for i := range instance.Spec.ApplicationIngress {
+			if instance.Spec.ApplicationIngress[i].DNSName == status.DNSName {
+				appIngress = &instance.Spec.ApplicationIngress[i]
 			}
 		}
-
-		if len(dnsChange.Additions) > 0 {
-			log.Info("Submitting DNS changes:", "Zone", zone.ID,
-				"Additions", dnsChange.Additions, "Deletions", dnsChange.Deletions)
-			changesCall := c.dnsService.Changes.Create(c.projectID, zone.ID, dnsChange)
-			_, err = changesCall.Do()
-			if err != nil {
-				return err
+		if appIngress == nil {
+			continue
+		}
+		addressName := infrastructureName + "-" + routerServicePrefix + status.IngressControllerName + "-ip"
+		if appIngress.Listening == cloudingressv1alpha1.External {
+			if status.LoadBalancer == "" {
+				// The router Service is being (re)created
+				continue
 			}
+			err = c.reserveExternalIP(addressName, status.LoadBalancer, region)
+		} else {
+			err = c.releaseRouterExternalIP(kclient, status.IngressControllerName, addressName, region)
+		}
+		if err != nil {
+			return err
 		}
 	}
+	return nil
+}
 
+// reserveExternalIP promotes the external IP address of a load balancer to a
+// static address called name, unless it already is one
+func (c *Client) reserveExternalIP(name string, ip string, region string) error {
+	address, err := c.computeService.Addresses.Get(c.projectID, region, name).Do()
+	if err == nil {
+		if address.Address != ip {
+			return fmt.Errorf("Static IP %s is reserved for %s, but the router load balancer uses %s", name, address.Address, ip)
+		}
+		return nil
+	}
+	if gcpError, ok := err.(*googleapi.Error); !ok || gcpError.Code != http.StatusNotFound {
+		return err
+	}
+	operation, err := c.computeService.Addresses.Insert(c.projectID, region, &compute.Address{
+		Name:        name,
+		Address:     ip,
+		AddressType: "EXTERNAL",
+	}).Do()
+	if err != nil {
+		return fmt.Errorf("Request to reserve static IP %s failed: %v", ip, err)
+	}
+	_, err = c.computeService.RegionOperations.Wait(c.projectID, region, operation.Name).Do()
+	if err != nil {
+		return err
+	}
+	log.Info("Reserved the IP of the router load balancer", "Name", name, "IP Address", ip)
 	return nil
 }
 
-func (c *Client) removeDNSForService(kclient client.Client, svc *corev1.Service, dnsName string) error {
-	// google.golang.org/api/dns/v1.Service is a struct, not an interface, which
-	// will make this all but impossible to write unit tests for
+// releaseRouterExternalIP releases the static address reserved for the
+// router load balancer of an IngressController that became internal, and
+// removes the firewall rule of its external load balancer
+func (c *Client) releaseRouterExternalIP(kclient client.Client, ingressControllerName string, addressName string, region string) error {
+	_, err := c.computeService.Addresses.Delete(c.projectID, region, addressName).Do()
+	if err == nil {
+		log.Info("Released the static IP of the router load balancer, which is internal now", "Name", addressName)
+	} else if gcpError, ok := err.(*googleapi.Error); !ok || gcpError.Code != http.StatusNotFound {
+		return fmt.Errorf("Failed to release External IP %v: %v", addressName, err)
+	}
 
-	baseDomain, err := baseutils.GetClusterBaseDomain(kclient)
+	svc := &corev1.Service{}
+	err = kclient.Get(context.TODO(), types.NamespacedName{Namespace: routerServiceNamespace, Name: routerServicePrefix + ingressControllerName}, svc)
 	if err != nil {
-		return err
+		return client.IgnoreNotFound(err)
+	}
+	firewallName := "k8s-fw-" + getServiceLoadBalancerName(svc)
+	_, err = c.computeService.Firewalls.Delete(c.projectID, firewallName).Do()
+	if err == nil {
+		log.Info("Removed the firewall rule of the external router load balancer", "Name", firewallName)
+		return nil
 	}
-	FQDN := dnsName + "." + baseDomain + "."
+	if gcpError, ok := err.(*googleapi.Error); ok && gcpError.Code == http.StatusNotFound {
+		return nil
+	}
+	return err
+}
 
+// getManagedZones returns the names of the cluster's public and private Cloud
+// DNS managed zones. publicZone is empty when the cluster has no public zone
+func getManagedZones(kclient client.Client) (publicZone string, privateZone string, err error) {
 	clusterDNS, err := getClusterDNS(kclient)
 	if err != nil {
-		return err
+		return "", "", err
 	}
-
-	var zones []configv1.DNSZone
 	if clusterDNS.Spec.PublicZone != nil {
-		zones = append(zones, *clusterDNS.Spec.PublicZone)
+		publicZone = clusterDNS.Spec.PublicZone.ID
 	}
 	if clusterDNS.Spec.PrivateZone != nil {
-		zones = append(zones, *clusterDNS.Spec.PrivateZone)
+		privateZone = clusterDNS.Spec.PrivateZone.ID
 	}
+	return publicZone, privateZone, nil
+}
 
-	for _, zone := range zones {
-		dnsChange := &gdnsv1.Change{}
+// getARecord returns the A record named FQDN in the managed zone, or nil if
+// there's none
+func (c *Client) getARecord(zone string, FQDN string) (*dnsclient.Record, error) {
+	return c.dns().GetRecord(zone, FQDN, "A")
+}
 
-		// Look for an existing resource record set in the zone.
-		listCall := c.dnsService.ResourceRecordSets.List(c.projectID, zone.ID)
-		response, err := listCall.Name(FQDN).Do()
-		if err != nil {
-			return err
-		}
+// upsertARecord makes the A record named FQDN in the managed zone resolve to
+// ips with the given TTL, replacing the record if it resolves to anything
+// else or has another TTL
+func (c *Client) upsertARecord(zone string, FQDN string, ips []string, ttl int64) error {
+	log.Info("Ensuring DNS record", "Zone", zone, "Name", FQDN, "IPs", ips, "TTL", ttl)
+	return c.dns().EnsureRecord(zone, dnsclient.Record{Name: FQDN, Type: "A", TTL: ttl, Values: ips})
+}
 
-		// There will be at most one result but append anyway.
-		dnsChange.Deletions = append(dnsChange.Deletions, response.Rrsets...)
+// recordTTL returns the TTL a custom resource sets for its records, or
+// defaultRecordTTL if it sets none
+func recordTTL(ttl int64) int64 {
+	if ttl > 0 {
+		return ttl
+	}
+	return defaultRecordTTL
+}
 
-		if len(dnsChange.Deletions) > 0 {
-			log.Info("Submitting DNS changes:", "Zone", zone.ID, "Deletions", dnsChange.Deletions)
-			call := c.dnsService.Changes.Create(c.projectID, zone.ID, dnsChange)
-			_, err = call.Do()
-			if err != nil {
-				dnsError, ok := err.(*googleapi.Error)
-				if !ok || dnsError.Code != http.StatusNotFound {
-					return err
-				}
-			}
+// deleteARecord removes the A record named FQDN from the managed zone, if it
+// exists
+func (c *Client) deleteARecord(zone string, FQDN string) error {
+	log.Info("Deleting DNS record", "Zone", zone, "Name", FQDN)
+	return c.dns().DeleteRecord(zone, dnsclient.Record{Name: FQDN, Type: "A"})
+}
+
+// dns returns the client the DNS records are managed with, Cloud DNS
+func (c *Client) dns() dnsclient.DNSClient {
+	return dnsclient.NewCloudDNS(c.dnsService, c.projectID)
+}
+
+// sameIPs reports whether a and b hold the same IP addresses, in any order
+func sameIPs(a []string, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	seen := make(map[string]int)
+	for _, ip := range a {
+		seen[ip]++
+	}
+	for _, ip := range b {
+		if seen[ip] == 0 {
+			return false
 		}
+		seen[ip]--
 	}
+	return true
+}
 
-	return nil
+// getServiceLoadBalancerName returns the name the cloud provider gives the
+// forwarding rule, target pool and firewall rule (with a k8s-fw- prefix) of a
+// LoadBalancer Service. It's the Service's UID, truncated to 32 characters
+func getServiceLoadBalancerName(svc *corev1.Service) string {
+	lbName := strings.ReplaceAll("a"+string(svc.ObjectMeta.UID), "-", "")
+	if len(lbName) > 32 {
+		lbName = lbName[0:32]
+	}
+	return lbName
 }
 
 func getIPAddressesFromService(svc *corev1.Service) ([]string, error) {
@@ -303,12 +1144,16 @@ func (c *Client) removeLoadBalancerFromMasterNodes(ctx context.Context, kclient
 	}
 	extNLBName := infrastructureName + "-api"
 	intLBName := infrastructureName + "-api-internal"
+	portRange, err := apiPortRange(kclient)
+	if err != nil {
+		return "", err
+	}
 	var intIPAddress, lbName string
 	for _, lb := range response.Items {
 		// This list of forwardingrules (LBs) includes any service LBs
 		// for application routers so check the port range and name to identify
 		// the external API LB.
-		if lb.LoadBalancingScheme == "EXTERNAL" && lb.PortRange == "6443-6443" && lb.Name == extNLBName {
+		if lb.LoadBalancingScheme == "EXTERNAL" && lb.PortRange == portRange && lb.Name == extNLBName {
 			//delete the LB and remove it from the masters
 			lbName = lb.Name
 			_, err := c.computeService.ForwardingRules.Delete(c.projectID, region, lbName).Do()
@@ -466,7 +1311,17 @@ func (c *Client) releaseExternalIP(region string, addressName string) error {
 	return nil
 }
 
-func (c *Client) createNetworkLoadBalancer(name string, scheme string, targetPool string, region string, ip string) error {
+// apiPortRange returns the port range of the forwarding rule serving the
+// cluster's API, its port as given in the API server URL
+func apiPortRange(kclient client.Client) (string, error) {
+	port, err := baseutils.GetClusterAPIPort(kclient)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%d-%d", port, port), nil
+}
+
+func (c *Client) createNetworkLoadBalancer(name string, scheme string, targetPool string, region string, ip string, portRange string) error {
 	//Confirm the target pool is present and get its selflink URL
 	tpResp, err := c.computeService.TargetPools.Get(c.projectID, region, targetPool).Do()
 	if err != nil {
@@ -479,7 +1334,7 @@ func (c *Client) createNetworkLoadBalancer(name string, scheme string, targetPoo
 		LoadBalancingScheme: scheme,
 		NetworkTier:         "PREMIUM",
 		Target:              tpURL,
-		PortRange:           "6443-6443",
+		PortRange:           portRange,
 		IPProtocol:          "TCP",
 	}
 	_, err = c.computeService.ForwardingRules.Insert(c.projectID, region, i).Do()
@@ -491,36 +1346,27 @@ func (c *Client) createNetworkLoadBalancer(name string, scheme string, targetPoo
 }
 
 func (c *Client) updateAPIARecord(kclient client.Client, recordName string, newIP string) (oldIP string, err error) {
-	clusterDNS, err := getClusterDNS(kclient)
+	publicZone, _, err := getManagedZones(kclient)
 	if err != nil {
 		return "", err
 	}
-	pubZoneRecords, err := c.dnsService.ResourceRecordSets.List(c.projectID, clusterDNS.Spec.PublicZone.ID).Do()
-	if err != nil {
-		return "", fmt.Errorf("Failed to retrieve list of ResourceRecordSets from public zone %v : %v", clusterDNS.Spec.PublicZone.ID, err)
+	if publicZone == "" {
+		return "", fmt.Errorf("Cluster has no public DNS zone to publish the API in")
 	}
-	apiRRSets := []*gdnsv1.ResourceRecordSet{}
-	for _, rrset := range pubZoneRecords.Rrsets {
-		if rrset.Name == recordName {
-			apiRRSets = append(apiRRSets, rrset)
-		}
+	apiRRSet, err := c.getARecord(publicZone, recordName)
+	if err != nil {
+		return "", fmt.Errorf("Failed to retrieve the API A record from public zone %v : %v", publicZone, err)
 	}
-	if len(apiRRSets) != 1 {
-		return "", fmt.Errorf("Expected to find 1 A record for API, found %d", len(apiRRSets))
+	if apiRRSet == nil || len(apiRRSet.Values) == 0 {
+		return "", fmt.Errorf("Expected to find 1 A record for API, found 0")
 	}
-	oldIP = apiRRSets[0].Rrdatas[0]
+	oldIP = apiRRSet.Values[0]
 	if oldIP == newIP {
 		// A record is already pointing to the correct IP, nothing to do
 		log.Info("Default API A record is already pointing to the correct IP. No update necessary.", "IP address", newIP)
 		return oldIP, nil
 	}
-	dnsChange := &gdnsv1.Change{}
-	dnsChange.Deletions = append(dnsChange.Deletions, apiRRSets[0])
-	updatedRRSet := *apiRRSets[0]
-	updatedRRSet.Rrdatas = []string{newIP}
-	dnsChange.Additions = append(dnsChange.Additions, &updatedRRSet)
-	changesCall := c.dnsService.Changes.Create(c.projectID, clusterDNS.Spec.PublicZone.ID, dnsChange)
-	_, err = changesCall.Do()
+	err = c.upsertARecord(publicZone, recordName, []string{newIP}, apiRRSet.TTL)
 	if err != nil {
 		return "", err
 	}
@@ -553,3 +1399,83 @@ func getClusterDNS(kclient client.Client) (*configv1.DNS, error) {
 
 	return dns, nil
 }
+
+// requiredPermissions are the IAM permissions the operator uses. Its
+// credentials request grants them through predefined roles, but the project
+// may have taken some away, eg with a deny policy
+var requiredPermissions = []string{
+	"compute.addresses.create",
+	"compute.addresses.delete",
+	"compute.addresses.get",
+	"compute.firewalls.create",
+	"compute.firewalls.delete",
+	"compute.firewalls.get",
+	"compute.firewalls.update",
+	"compute.forwardingRules.create",
+	"compute.forwardingRules.delete",
+	"compute.forwardingRules.get",
+	"compute.forwardingRules.list",
+	"compute.instanceGroups.list",
+	"compute.instances.get",
+	"compute.regionBackendServices.get",
+	"compute.targetPools.addInstance",
+	"compute.targetPools.get",
+	"compute.targetPools.removeInstance",
+	"dns.changes.create",
+	"dns.managedZones.get",
+	"dns.managedZones.list",
+	"dns.resourceRecordSets.list",
+}
+
+// checkPermissions asks GCP which of requiredPermissions the operator's
+// service account has on the project, and returns those it doesn't
+func (c *Client) checkPermissions(ctx context.Context, kclient client.Client) ([]string, error) {
+	response, err := c.resourceManagerService.Projects.TestIamPermissions(c.projectID, &cloudresourcemanager.TestIamPermissionsRequest{
+		Permissions: requiredPermissions,
+	}).Context(ctx).Do()
+	if err != nil {
+		return nil, err
+	}
+	return missingPermissions(requiredPermissions, response.Permissions), nil
+}
+
+// checkCredentials asks GCP whether the operator's service account has the
+// first of requiredPermissions on the project. The answer doesn't matter, as
+// any valid credentials may ask
+func (c *Client) checkCredentials(ctx context.Context, kclient client.Client) error {
+	_, err := c.resourceManagerService.Projects.TestIamPermissions(c.projectID, &cloudresourcemanager.TestIamPermissionsRequest{
+		Permissions: requiredPermissions[:1],
+	}).Context(ctx).Do()
+	return err
+}
+
+// getLoadBalancerQuota returns the project's quota of forwarding rules, and
+// how many there are. Each of the operator's load balancers is a forwarding
+// rule, whatever its scheme
+func (c *Client) getLoadBalancerQuota(ctx context.Context, kclient client.Client, network bool) (int, int, error) {
+	project, err := c.computeService.Projects.Get(c.projectID).Context(ctx).Do()
+	if err != nil {
+		return 0, 0, err
+	}
+	for _, quota := range project.Quotas {
+		if quota.Metric == "FORWARDING_RULES" {
+			return int(quota.Usage), int(quota.Limit), nil
+		}
+	}
+	return 0, 0, fmt.Errorf("project %s has no forwarding rules quota", c.projectID)
+}
+
+// missingPermissions returns the permissions of required that aren't granted
+func missingPermissions(required, granted []string) []string {
+	grantedSet := make(map[string]bool, len(granted))
+	for _, permission := range granted {
+		grantedSet[permission] = true
+	}
+	missing := []string{}
+	for _, permission := range required {
+		if !grantedSet[permission] {
+			missing = append(missing, permission)
+		}
+	}
+	return missing
+}
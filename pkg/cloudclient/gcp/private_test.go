@@ -2,9 +2,14 @@ package gcp
 
 import (
 	"context"
+	"errors"
+	"net/http"
 	"reflect"
 	"testing"
 
+	"google.golang.org/api/compute/v1"
+	"google.golang.org/api/googleapi"
+
 	"github.com/openshift/cloud-ingress-operator/pkg/testutils"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -140,3 +145,243 @@ func TestGCPProviderDecodeEncode(t *testing.T) {
 		t.Fatalf("Failed to encode ProviderSpec for machine %s: %v", machine.GetName(), err)
 	}
 }
+
+func TestSameIPs(t *testing.T) {
+	tests := []struct {
+		name     string
+		a        []string
+		b        []string
+		expected bool
+	}{
+		{
+			name:     "same order",
+			a:        []string{"127.0.0.1", "10.0.0.1"},
+			b:        []string{"127.0.0.1", "10.0.0.1"},
+			expected: true,
+		},
+		{
+			name:     "different order",
+			a:        []string{"127.0.0.1", "10.0.0.1"},
+			b:        []string{"10.0.0.1", "127.0.0.1"},
+			expected: true,
+		},
+		{
+			name:     "different IP",
+			a:        []string{"127.0.0.1"},
+			b:        []string{"10.0.0.1"},
+			expected: false,
+		},
+		{
+			name:     "duplicate IP",
+			a:        []string{"127.0.0.1", "127.0.0.1"},
+			b:        []string{"127.0.0.1", "10.0.0.1"},
+			expected: false,
+		},
+		{
+			name:     "fewer IPs",
+			a:        []string{"127.0.0.1", "10.0.0.1"},
+			b:        []string{"127.0.0.1"},
+			expected: false,
+		},
+	}
+
+	for _, test := range tests {
+		actual := sameIPs(test.a, test.b)
+		if actual != test.expected {
+			t.Errorf("%s: got %v, expected %v", test.name, actual, test.expected)
+		}
+	}
+}
+
+func TestRecordTTL(t *testing.T) {
+	tests := []struct {
+		Name     string
+		TTL      int64
+		Expected int64
+	}{
+		{Name: "Unset", TTL: 0, Expected: defaultRecordTTL},
+		{Name: "Set", TTL: 300, Expected: 300},
+	}
+	for _, test := range tests {
+		if ttl := recordTTL(test.TTL); ttl != test.Expected {
+			t.Fatalf("Test [%v] FAILED. Expected %v. Got %v", test.Name, test.Expected, ttl)
+		}
+	}
+}
+
+func TestWorstHealthState(t *testing.T) {
+	tests := []struct {
+		name     string
+		statuses []*compute.HealthStatus
+		expected string
+	}{
+		{
+			name:     "not checked yet",
+			expected: "UNKNOWN",
+		},
+		{
+			name:     "healthy",
+			statuses: []*compute.HealthStatus{{HealthState: "HEALTHY"}, {HealthState: "HEALTHY"}},
+			expected: "HEALTHY",
+		},
+		{
+			name:     "unhealthy on one address",
+			statuses: []*compute.HealthStatus{{HealthState: "HEALTHY"}, {HealthState: "UNHEALTHY"}},
+			expected: "UNHEALTHY",
+		},
+	}
+
+	for _, test := range tests {
+		actual := worstHealthState(test.statuses)
+		if actual != test.expected {
+			t.Errorf("%s: got %v, expected %v", test.name, actual, test.expected)
+		}
+	}
+}
+
+func TestFirewallMatches(t *testing.T) {
+	desired := adminAPIFirewall("test-cluster", "projects/project/global/networks/test-cluster-network", []string{"10.0.0.0/8", "192.168.0.0/16"}, 6443)
+	tests := []struct {
+		name     string
+		modify   func(*compute.Firewall)
+		expected bool
+	}{
+		{
+			name:     "same rule",
+			modify:   func(f *compute.Firewall) {},
+			expected: true,
+		},
+		{
+			name: "source ranges in another order",
+			modify: func(f *compute.Firewall) {
+				f.SourceRanges = []string{"192.168.0.0/16", "10.0.0.0/8"}
+			},
+			expected: true,
+		},
+		{
+			name: "extra source range",
+			modify: func(f *compute.Firewall) {
+				f.SourceRanges = append(f.SourceRanges, "0.0.0.0/0")
+			},
+		},
+		{
+			name: "another port",
+			modify: func(f *compute.Firewall) {
+				f.Allowed = []*compute.FirewallAllowed{{IPProtocol: "tcp", Ports: []string{"443"}}}
+			},
+		},
+		{
+			name: "disabled",
+			modify: func(f *compute.Firewall) {
+				f.Disabled = true
+			},
+		},
+		{
+			name: "targets every instance",
+			modify: func(f *compute.Firewall) {
+				f.TargetTags = nil
+			},
+		},
+	}
+
+	for _, test := range tests {
+		current := adminAPIFirewall("test-cluster", "https://www.googleapis.com/compute/v1/projects/project/global/networks/test-cluster-network", []string{"10.0.0.0/8", "192.168.0.0/16"}, 6443)
+		test.modify(current)
+		actual := firewallMatches(current, desired)
+		if actual != test.expected {
+			t.Errorf("%s: got %v, expected %v", test.name, actual, test.expected)
+		}
+	}
+}
+
+func TestInstanceZone(t *testing.T) {
+	actual := instanceZone("https://www.googleapis.com/compute/v1/projects/project/zones/us-east1-b/instances/master-0")
+	if actual != "us-east1-b" {
+		t.Errorf("got %v, expected us-east1-b", actual)
+	}
+}
+
+func TestForwardingRuleBackend(t *testing.T) {
+	tests := []struct {
+		name           string
+		forwardingRule *compute.ForwardingRule
+		expected       loadBalancerBackend
+	}{
+		{
+			name:           "target pool",
+			forwardingRule: &compute.ForwardingRule{Target: "https://www.googleapis.com/compute/v1/projects/project/regions/us-east1/targetPools/a0123"},
+			expected:       loadBalancerBackend{region: "us-east1", targetPool: "a0123"},
+		},
+		{
+			name:           "backend service",
+			forwardingRule: &compute.ForwardingRule{BackendService: "https://www.googleapis.com/compute/v1/projects/project/regions/us-east1/backendServices/a0123"},
+			expected:       loadBalancerBackend{region: "us-east1", backendService: "a0123"},
+		},
+	}
+	for _, test := range tests {
+		actual := forwardingRuleBackend("us-east1", test.forwardingRule)
+		if actual != test.expected {
+			t.Errorf("%s: got %+v, expected %+v", test.name, actual, test.expected)
+		}
+	}
+}
+
+func TestInstanceGroupZone(t *testing.T) {
+	actual := instanceZone("https://www.googleapis.com/compute/v1/projects/project/zones/us-east1-c/instanceGroups/k8s-ig--0123")
+	if actual != "us-east1-c" {
+		t.Errorf("got %v, expected us-east1-c", actual)
+	}
+}
+
+func TestClassifyError(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected error
+	}{
+		{
+			name:     "not found",
+			err:      &googleapi.Error{Code: http.StatusNotFound},
+			expected: cioerrors.ErrNotFound,
+		},
+		{
+			name:     "too many requests",
+			err:      &googleapi.Error{Code: http.StatusTooManyRequests},
+			expected: cioerrors.ErrThrottled,
+		},
+		{
+			name:     "rate limit reported as forbidden",
+			err:      &googleapi.Error{Code: http.StatusForbidden, Errors: []googleapi.ErrorItem{{Reason: "rateLimitExceeded"}}},
+			expected: cioerrors.ErrThrottled,
+		},
+		{
+			name:     "forbidden",
+			err:      &googleapi.Error{Code: http.StatusForbidden, Errors: []googleapi.ErrorItem{{Reason: "forbidden"}}},
+			expected: cioerrors.ErrPermissionDenied,
+		},
+		{
+			name:     "in use",
+			err:      &googleapi.Error{Code: http.StatusBadRequest, Errors: []googleapi.ErrorItem{{Reason: "resourceInUseByAnotherResource"}}},
+			expected: cioerrors.ErrDependencyViolation,
+		},
+		{
+			name:     "server error",
+			err:      &googleapi.Error{Code: http.StatusServiceUnavailable},
+			expected: cioerrors.ErrTransient,
+		},
+		{
+			name:     "not a GCP error",
+			err:      cioerrors.NewLoadBalancerNotReadyError(),
+			expected: nil,
+		},
+	}
+	kinds := []error{cioerrors.ErrNotFound, cioerrors.ErrThrottled, cioerrors.ErrPermissionDenied, cioerrors.ErrDependencyViolation, cioerrors.ErrTransient}
+	for _, test := range tests {
+		actual := classifyError(test.err)
+		for _, kind := range kinds {
+			if errors.Is(actual, kind) != (kind == test.expected) {
+				t.Errorf("%s: expected kind %v, got %v", test.name, test.expected, actual)
+			}
+		}
+	}
+}
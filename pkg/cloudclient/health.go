@@ -0,0 +1,102 @@
+package cloudclient
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	baseutils "github.com/openshift/cloud-ingress-operator/pkg/utils"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var (
+	// HealthCheckInterval is how long the result of a check of the cloud
+	// credentials is reused, so frequent probes don't call the cloud API each
+	// time
+	HealthCheckInterval = time.Minute
+	// UnhealthyAfter is how long the cloud credentials may fail their checks
+	// before the operator reports itself not alive, and is restarted. Until
+	// then, it's only reported not ready
+	UnhealthyAfter = 15 * time.Minute
+)
+
+// HealthChecker checks the operator can do useful work: that the cluster's
+// cloud client can be made, and its credentials are valid and reach the cloud
+// API. It's safe for concurrent use by the health probes
+type HealthChecker struct {
+	kclient client.Client
+
+	mu          sync.Mutex
+	checkedAt   time.Time
+	lastErr     error
+	lastSuccess time.Time
+}
+
+// NewHealthChecker returns a HealthChecker using the cloud client the
+// controllers share
+func NewHealthChecker(kclient client.Client) *HealthChecker {
+	return &HealthChecker{
+		kclient:     kclient,
+		lastSuccess: time.Now(),
+	}
+}
+
+// Ready is a healthz.Checker failing while the cloud credentials do
+func (h *HealthChecker) Ready(_ *http.Request) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.check()
+}
+
+// Live is a healthz.Checker failing once the cloud credentials have failed for
+// UnhealthyAfter. A short cloud API outage shouldn't restart the operator
+func (h *HealthChecker) Live(_ *http.Request) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	err := h.check()
+	if err != nil && time.Since(h.lastSuccess) >= UnhealthyAfter {
+		return fmt.Errorf("no successful cloud credentials check since %s: %v", h.lastSuccess.Format(time.RFC3339), err)
+	}
+	return nil
+}
+
+// check returns the result of the last check, checking again once it's older
+// than HealthCheckInterval. h.mu must be held
+func (h *HealthChecker) check() error {
+	if !h.checkedAt.IsZero() && time.Since(h.checkedAt) < HealthCheckInterval {
+		return h.lastErr
+	}
+	h.checkedAt = time.Now()
+	h.lastErr = h.checkCredentials()
+	if h.lastErr == nil {
+		h.lastSuccess = h.checkedAt
+	}
+	return h.lastErr
+}
+
+// checkCredentials checks the credentials of the cloud client the controllers
+// share. A cluster on a cloud without a CloudClient has nothing to check
+func (h *HealthChecker) checkCredentials() (err error) {
+	platform, err := baseutils.GetPlatformType(h.kclient)
+	if err != nil {
+		return err
+	}
+	if _, ok := controllerMapping[*platform]; !ok {
+		return nil
+	}
+	configVersion, err := baseutils.GetPlatformConfigVersion(h.kclient)
+	if err != nil {
+		return err
+	}
+	// The cloud clients panic when they can't be made, eg without their
+	// credentials Secret
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("couldn't make the cloud client: %v", r)
+		}
+	}()
+	cloud := GetSharedClientFor(h.kclient, *platform, configVersion)
+	return cloud.CheckCredentials(context.TODO(), h.kclient)
+}
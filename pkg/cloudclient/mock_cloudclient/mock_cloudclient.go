@@ -50,6 +50,34 @@ func (mr *MockCloudClientMockRecorder) EnsureAdminAPIDNS(arg0, arg1, arg2, arg3
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnsureAdminAPIDNS", reflect.TypeOf((*MockCloudClient)(nil).EnsureAdminAPIDNS), arg0, arg1, arg2, arg3)
 }
 
+// EnsureAdminAPIWeightedDNS mocks base method
+func (m *MockCloudClient) EnsureAdminAPIWeightedDNS(arg0 context.Context, arg1 client.Client, arg2 *v1alpha1.APIScheme, arg3 *v1.Service, arg4 *v1.Service, arg5 int32) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnsureAdminAPIWeightedDNS", arg0, arg1, arg2, arg3, arg4, arg5)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// EnsureAdminAPIWeightedDNS indicates an expected call of EnsureAdminAPIWeightedDNS
+func (mr *MockCloudClientMockRecorder) EnsureAdminAPIWeightedDNS(arg0, arg1, arg2, arg3, arg4, arg5 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnsureAdminAPIWeightedDNS", reflect.TypeOf((*MockCloudClient)(nil).EnsureAdminAPIWeightedDNS), arg0, arg1, arg2, arg3, arg4, arg5)
+}
+
+// EnsureAdminAPIFailoverDNS mocks base method
+func (m *MockCloudClient) EnsureAdminAPIFailoverDNS(arg0 context.Context, arg1 client.Client, arg2 *v1alpha1.APIScheme, arg3 *v1.Service, arg4 *v1.Service) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnsureAdminAPIFailoverDNS", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// EnsureAdminAPIFailoverDNS indicates an expected call of EnsureAdminAPIFailoverDNS
+func (mr *MockCloudClientMockRecorder) EnsureAdminAPIFailoverDNS(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnsureAdminAPIFailoverDNS", reflect.TypeOf((*MockCloudClient)(nil).EnsureAdminAPIFailoverDNS), arg0, arg1, arg2, arg3, arg4)
+}
+
 // DeleteAdminAPIDNS mocks base method
 func (m *MockCloudClient) DeleteAdminAPIDNS(arg0 context.Context, arg1 client.Client, arg2 *v1alpha1.APIScheme, arg3 *v1.Service) error {
 	m.ctrl.T.Helper()
@@ -64,6 +92,311 @@ func (mr *MockCloudClientMockRecorder) DeleteAdminAPIDNS(arg0, arg1, arg2, arg3
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAdminAPIDNS", reflect.TypeOf((*MockCloudClient)(nil).DeleteAdminAPIDNS), arg0, arg1, arg2, arg3)
 }
 
+// EnsureAdminAPIStaticIPs mocks base method
+func (m *MockCloudClient) EnsureAdminAPIStaticIPs(arg0 context.Context, arg1 client.Client, arg2 *v1alpha1.APIScheme, arg3 *v1.Service) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnsureAdminAPIStaticIPs", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EnsureAdminAPIStaticIPs indicates an expected call of EnsureAdminAPIStaticIPs
+func (mr *MockCloudClientMockRecorder) EnsureAdminAPIStaticIPs(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnsureAdminAPIStaticIPs", reflect.TypeOf((*MockCloudClient)(nil).EnsureAdminAPIStaticIPs), arg0, arg1, arg2, arg3)
+}
+
+// ReleaseAdminAPIStaticIPs mocks base method
+func (m *MockCloudClient) ReleaseAdminAPIStaticIPs(arg0 context.Context, arg1 client.Client, arg2 *v1alpha1.APIScheme, arg3 []string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReleaseAdminAPIStaticIPs", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReleaseAdminAPIStaticIPs indicates an expected call of ReleaseAdminAPIStaticIPs
+func (mr *MockCloudClientMockRecorder) ReleaseAdminAPIStaticIPs(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReleaseAdminAPIStaticIPs", reflect.TypeOf((*MockCloudClient)(nil).ReleaseAdminAPIStaticIPs), arg0, arg1, arg2, arg3)
+}
+
+// EnsureAdminAPISecurityGroup mocks base method
+func (m *MockCloudClient) EnsureAdminAPISecurityGroup(arg0 context.Context, arg1 client.Client, arg2 *v1alpha1.APIScheme, arg3 *v1.Service) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnsureAdminAPISecurityGroup", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EnsureAdminAPISecurityGroup indicates an expected call of EnsureAdminAPISecurityGroup
+func (mr *MockCloudClientMockRecorder) EnsureAdminAPISecurityGroup(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnsureAdminAPISecurityGroup", reflect.TypeOf((*MockCloudClient)(nil).EnsureAdminAPISecurityGroup), arg0, arg1, arg2, arg3)
+}
+
+// DeleteAdminAPISecurityGroup mocks base method
+func (m *MockCloudClient) DeleteAdminAPISecurityGroup(arg0 context.Context, arg1 client.Client, arg2 *v1alpha1.APIScheme) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAdminAPISecurityGroup", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteAdminAPISecurityGroup indicates an expected call of DeleteAdminAPISecurityGroup
+func (mr *MockCloudClientMockRecorder) DeleteAdminAPISecurityGroup(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAdminAPISecurityGroup", reflect.TypeOf((*MockCloudClient)(nil).DeleteAdminAPISecurityGroup), arg0, arg1, arg2)
+}
+
+// DeleteAdminAPILoadBalancer mocks base method
+func (m *MockCloudClient) DeleteAdminAPILoadBalancer(arg0 context.Context, arg1 client.Client, arg2 *v1alpha1.APIScheme, arg3 *v1.Service) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAdminAPILoadBalancer", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteAdminAPILoadBalancer indicates an expected call of DeleteAdminAPILoadBalancer
+func (mr *MockCloudClientMockRecorder) DeleteAdminAPILoadBalancer(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAdminAPILoadBalancer", reflect.TypeOf((*MockCloudClient)(nil).DeleteAdminAPILoadBalancer), arg0, arg1, arg2, arg3)
+}
+
+// EnsureAdminAPIIPAddressType mocks base method
+func (m *MockCloudClient) EnsureAdminAPIIPAddressType(arg0 context.Context, arg1 client.Client, arg2 *v1alpha1.APIScheme, arg3 *v1.Service) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnsureAdminAPIIPAddressType", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// EnsureAdminAPIIPAddressType indicates an expected call of EnsureAdminAPIIPAddressType
+func (mr *MockCloudClientMockRecorder) EnsureAdminAPIIPAddressType(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnsureAdminAPIIPAddressType", reflect.TypeOf((*MockCloudClient)(nil).EnsureAdminAPIIPAddressType), arg0, arg1, arg2, arg3)
+}
+
+// EnsureAdminAPIAccessLogs mocks base method
+func (m *MockCloudClient) EnsureAdminAPIAccessLogs(arg0 context.Context, arg1 client.Client, arg2 *v1alpha1.APIScheme, arg3 *v1.Service) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnsureAdminAPIAccessLogs", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// EnsureAdminAPIAccessLogs indicates an expected call of EnsureAdminAPIAccessLogs
+func (mr *MockCloudClientMockRecorder) EnsureAdminAPIAccessLogs(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnsureAdminAPIAccessLogs", reflect.TypeOf((*MockCloudClient)(nil).EnsureAdminAPIAccessLogs), arg0, arg1, arg2, arg3)
+}
+
+// EnsureAdminAPIAlarms mocks base method
+func (m *MockCloudClient) EnsureAdminAPIAlarms(arg0 context.Context, arg1 client.Client, arg2 *v1alpha1.APIScheme, arg3 *v1.Service) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnsureAdminAPIAlarms", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// EnsureAdminAPIAlarms indicates an expected call of EnsureAdminAPIAlarms
+func (mr *MockCloudClientMockRecorder) EnsureAdminAPIAlarms(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnsureAdminAPIAlarms", reflect.TypeOf((*MockCloudClient)(nil).EnsureAdminAPIAlarms), arg0, arg1, arg2, arg3)
+}
+
+// EnsureAdminAPITLSPolicy mocks base method
+func (m *MockCloudClient) EnsureAdminAPITLSPolicy(arg0 context.Context, arg1 client.Client, arg2 *v1alpha1.APIScheme, arg3 *v1.Service) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnsureAdminAPITLSPolicy", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// EnsureAdminAPITLSPolicy indicates an expected call of EnsureAdminAPITLSPolicy
+func (mr *MockCloudClientMockRecorder) EnsureAdminAPITLSPolicy(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnsureAdminAPITLSPolicy", reflect.TypeOf((*MockCloudClient)(nil).EnsureAdminAPITLSPolicy), arg0, arg1, arg2, arg3)
+}
+
+// EnsureAdminAPIStickiness mocks base method
+func (m *MockCloudClient) EnsureAdminAPIStickiness(arg0 context.Context, arg1 client.Client, arg2 *v1alpha1.APIScheme, arg3 *v1.Service) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnsureAdminAPIStickiness", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// EnsureAdminAPIStickiness indicates an expected call of EnsureAdminAPIStickiness
+func (mr *MockCloudClientMockRecorder) EnsureAdminAPIStickiness(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnsureAdminAPIStickiness", reflect.TypeOf((*MockCloudClient)(nil).EnsureAdminAPIStickiness), arg0, arg1, arg2, arg3)
+}
+
+// EnsureAdminAPICertificate mocks base method
+func (m *MockCloudClient) EnsureAdminAPICertificate(arg0 context.Context, arg1 client.Client, arg2 *v1alpha1.APIScheme, arg3 *v1.Service) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnsureAdminAPICertificate", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// EnsureAdminAPICertificate indicates an expected call of EnsureAdminAPICertificate
+func (mr *MockCloudClientMockRecorder) EnsureAdminAPICertificate(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnsureAdminAPICertificate", reflect.TypeOf((*MockCloudClient)(nil).EnsureAdminAPICertificate), arg0, arg1, arg2, arg3)
+}
+
+// EnsureAdminAPIMasterMachines mocks base method
+func (m *MockCloudClient) EnsureAdminAPIMasterMachines(arg0 context.Context, arg1 client.Client, arg2 *v1alpha1.APIScheme, arg3 *v1.Service) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnsureAdminAPIMasterMachines", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// EnsureAdminAPIMasterMachines indicates an expected call of EnsureAdminAPIMasterMachines
+func (mr *MockCloudClientMockRecorder) EnsureAdminAPIMasterMachines(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnsureAdminAPIMasterMachines", reflect.TypeOf((*MockCloudClient)(nil).EnsureAdminAPIMasterMachines), arg0, arg1, arg2, arg3)
+}
+
+// DeleteAdminAPIAlarms mocks base method
+func (m *MockCloudClient) DeleteAdminAPIAlarms(arg0 context.Context, arg1 client.Client, arg2 *v1alpha1.APIScheme) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAdminAPIAlarms", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteAdminAPIAlarms indicates an expected call of DeleteAdminAPIAlarms
+func (mr *MockCloudClientMockRecorder) DeleteAdminAPIAlarms(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAdminAPIAlarms", reflect.TypeOf((*MockCloudClient)(nil).DeleteAdminAPIAlarms), arg0, arg1, arg2)
+}
+
+// CheckAdminAPIDrift mocks base method
+func (m *MockCloudClient) CheckAdminAPIDrift(arg0 context.Context, arg1 client.Client, arg2 *v1alpha1.APIScheme, arg3 *v1.Service) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckAdminAPIDrift", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CheckAdminAPIDrift indicates an expected call of CheckAdminAPIDrift
+func (mr *MockCloudClientMockRecorder) CheckAdminAPIDrift(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckAdminAPIDrift", reflect.TypeOf((*MockCloudClient)(nil).CheckAdminAPIDrift), arg0, arg1, arg2, arg3)
+}
+
+// RepairAdminAPIDrift mocks base method
+func (m *MockCloudClient) RepairAdminAPIDrift(arg0 context.Context, arg1 client.Client, arg2 *v1alpha1.APIScheme, arg3 *v1.Service) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RepairAdminAPIDrift", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RepairAdminAPIDrift indicates an expected call of RepairAdminAPIDrift
+func (mr *MockCloudClientMockRecorder) RepairAdminAPIDrift(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RepairAdminAPIDrift", reflect.TypeOf((*MockCloudClient)(nil).RepairAdminAPIDrift), arg0, arg1, arg2, arg3)
+}
+
+// GetAdminAPIRegisteredInstances mocks base method
+func (m *MockCloudClient) GetAdminAPIRegisteredInstances(arg0 context.Context, arg1 client.Client, arg2 *v1alpha1.APIScheme, arg3 *v1.Service) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAdminAPIRegisteredInstances", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAdminAPIRegisteredInstances indicates an expected call of GetAdminAPIRegisteredInstances
+func (mr *MockCloudClientMockRecorder) GetAdminAPIRegisteredInstances(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAdminAPIRegisteredInstances", reflect.TypeOf((*MockCloudClient)(nil).GetAdminAPIRegisteredInstances), arg0, arg1, arg2, arg3)
+}
+
+// GetAdminAPIHealthyInstances mocks base method
+func (m *MockCloudClient) GetAdminAPIHealthyInstances(arg0 context.Context, arg1 client.Client, arg2 *v1alpha1.APIScheme, arg3 *v1.Service) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAdminAPIHealthyInstances", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAdminAPIHealthyInstances indicates an expected call of GetAdminAPIHealthyInstances
+func (mr *MockCloudClientMockRecorder) GetAdminAPIHealthyInstances(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAdminAPIHealthyInstances", reflect.TypeOf((*MockCloudClient)(nil).GetAdminAPIHealthyInstances), arg0, arg1, arg2, arg3)
+}
+
+// GetAdminAPIInstanceHealth mocks base method
+func (m *MockCloudClient) GetAdminAPIInstanceHealth(arg0 context.Context, arg1 client.Client, arg2 *v1alpha1.APIScheme, arg3 *v1.Service) ([]v1alpha1.RegisteredInstance, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAdminAPIInstanceHealth", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]v1alpha1.RegisteredInstance)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAdminAPIInstanceHealth indicates an expected call of GetAdminAPIInstanceHealth
+func (mr *MockCloudClientMockRecorder) GetAdminAPIInstanceHealth(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAdminAPIInstanceHealth", reflect.TypeOf((*MockCloudClient)(nil).GetAdminAPIInstanceHealth), arg0, arg1, arg2, arg3)
+}
+
+// AdminAPILoadBalancerExists mocks base method
+func (m *MockCloudClient) AdminAPILoadBalancerExists(arg0 context.Context, arg1 client.Client, arg2 *v1alpha1.APIScheme, arg3 *v1.Service) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AdminAPILoadBalancerExists", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AdminAPILoadBalancerExists indicates an expected call of AdminAPILoadBalancerExists
+func (mr *MockCloudClientMockRecorder) AdminAPILoadBalancerExists(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdminAPILoadBalancerExists", reflect.TypeOf((*MockCloudClient)(nil).AdminAPILoadBalancerExists), arg0, arg1, arg2, arg3)
+}
+
+// GetAdminAPILoadBalancerAttributes mocks base method
+func (m *MockCloudClient) GetAdminAPILoadBalancerAttributes(arg0 context.Context, arg1 client.Client, arg2 *v1alpha1.APIScheme, arg3 *v1.Service) (*v1alpha1.LoadBalancerAttributes, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAdminAPILoadBalancerAttributes", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*v1alpha1.LoadBalancerAttributes)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAdminAPILoadBalancerAttributes indicates an expected call of GetAdminAPILoadBalancerAttributes
+func (mr *MockCloudClientMockRecorder) GetAdminAPILoadBalancerAttributes(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAdminAPILoadBalancerAttributes", reflect.TypeOf((*MockCloudClient)(nil).GetAdminAPILoadBalancerAttributes), arg0, arg1, arg2, arg3)
+}
+
+// GetAdminAPINameServers mocks base method
+func (m *MockCloudClient) GetAdminAPINameServers(arg0 context.Context, arg1 client.Client, arg2 *v1alpha1.APIScheme) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAdminAPINameServers", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAdminAPINameServers indicates an expected call of GetAdminAPINameServers
+func (mr *MockCloudClientMockRecorder) GetAdminAPINameServers(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAdminAPINameServers", reflect.TypeOf((*MockCloudClient)(nil).GetAdminAPINameServers), arg0, arg1, arg2)
+}
+
 // EnsureSSHDNS mocks base method
 func (m *MockCloudClient) EnsureSSHDNS(arg0 context.Context, arg1 client.Client, arg2 *v1alpha1.SSHD, arg3 *v1.Service) error {
 	m.ctrl.T.Helper()
@@ -119,3 +452,104 @@ func (mr *MockCloudClientMockRecorder) SetDefaultAPIPublic(arg0, arg1, arg2 inte
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetDefaultAPIPublic", reflect.TypeOf((*MockCloudClient)(nil).SetDefaultAPIPublic), arg0, arg1, arg2)
 }
+
+// EnsureApplicationIngressDNS mocks base method
+func (m *MockCloudClient) EnsureApplicationIngressDNS(arg0 context.Context, arg1 client.Client, arg2 *v1alpha1.PublishingStrategy) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnsureApplicationIngressDNS", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// EnsureApplicationIngressDNS indicates an expected call of EnsureApplicationIngressDNS
+func (mr *MockCloudClientMockRecorder) EnsureApplicationIngressDNS(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnsureApplicationIngressDNS", reflect.TypeOf((*MockCloudClient)(nil).EnsureApplicationIngressDNS), arg0, arg1, arg2)
+}
+
+// EnsureRouterServiceDNS mocks base method
+func (m *MockCloudClient) EnsureRouterServiceDNS(arg0 context.Context, arg1 client.Client, arg2 *v1alpha1.ApplicationIngress, arg3 *v1.Service) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnsureRouterServiceDNS", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// EnsureRouterServiceDNS indicates an expected call of EnsureRouterServiceDNS
+func (mr *MockCloudClientMockRecorder) EnsureRouterServiceDNS(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnsureRouterServiceDNS", reflect.TypeOf((*MockCloudClient)(nil).EnsureRouterServiceDNS), arg0, arg1, arg2, arg3)
+}
+
+// EnsureApplicationIngressLoadBalancers mocks base method
+func (m *MockCloudClient) EnsureApplicationIngressLoadBalancers(arg0 context.Context, arg1 client.Client, arg2 *v1alpha1.PublishingStrategy) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnsureApplicationIngressLoadBalancers", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// EnsureApplicationIngressLoadBalancers indicates an expected call of EnsureApplicationIngressLoadBalancers
+func (mr *MockCloudClientMockRecorder) EnsureApplicationIngressLoadBalancers(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnsureApplicationIngressLoadBalancers", reflect.TypeOf((*MockCloudClient)(nil).EnsureApplicationIngressLoadBalancers), arg0, arg1, arg2)
+}
+
+// CheckPermissions mocks base method
+func (m *MockCloudClient) CheckPermissions(arg0 context.Context, arg1 client.Client) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckPermissions", arg0, arg1)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CheckPermissions indicates an expected call of CheckPermissions
+func (mr *MockCloudClientMockRecorder) CheckPermissions(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckPermissions", reflect.TypeOf((*MockCloudClient)(nil).CheckPermissions), arg0, arg1)
+}
+
+// CheckCredentials mocks base method
+func (m *MockCloudClient) CheckCredentials(arg0 context.Context, arg1 client.Client) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckCredentials", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CheckCredentials indicates an expected call of CheckCredentials
+func (mr *MockCloudClientMockRecorder) CheckCredentials(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckCredentials", reflect.TypeOf((*MockCloudClient)(nil).CheckCredentials), arg0, arg1)
+}
+
+// GetLoadBalancerQuota mocks base method
+func (m *MockCloudClient) GetLoadBalancerQuota(arg0 context.Context, arg1 client.Client, arg2 bool) (int, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLoadBalancerQuota", arg0, arg1, arg2)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetLoadBalancerQuota indicates an expected call of GetLoadBalancerQuota
+func (mr *MockCloudClientMockRecorder) GetLoadBalancerQuota(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLoadBalancerQuota", reflect.TypeOf((*MockCloudClient)(nil).GetLoadBalancerQuota), arg0, arg1, arg2)
+}
+
+// ValidateAdminAPISubnets mocks base method
+func (m *MockCloudClient) ValidateAdminAPISubnets(arg0 context.Context, arg1 client.Client, arg2 []string, arg3 bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ValidateAdminAPISubnets", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ValidateAdminAPISubnets indicates an expected call of ValidateAdminAPISubnets
+func (mr *MockCloudClientMockRecorder) ValidateAdminAPISubnets(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateAdminAPISubnets", reflect.TypeOf((*MockCloudClient)(nil).ValidateAdminAPISubnets), arg0, arg1, arg2, arg3)
+}
@@ -3,13 +3,23 @@ package apischeme
 import (
 	"context"
 	"fmt"
+	"net"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
 	"time"
 
+	configv1 "github.com/openshift/api/config/v1"
+	"github.com/openshift/cloud-ingress-operator/config"
 	cloudingressv1alpha1 "github.com/openshift/cloud-ingress-operator/pkg/apis/cloudingress/v1alpha1"
 	"github.com/openshift/cloud-ingress-operator/pkg/cloudclient"
 	utils "github.com/openshift/cloud-ingress-operator/pkg/controller/utils"
 	cioerrors "github.com/openshift/cloud-ingress-operator/pkg/errors"
+	"github.com/openshift/cloud-ingress-operator/pkg/localmetrics"
 	baseutils "github.com/openshift/cloud-ingress-operator/pkg/utils"
+	machineapi "github.com/openshift/machine-api-operator/pkg/apis/machine/v1beta1"
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -18,13 +28,16 @@ import (
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 	"sigs.k8s.io/controller-runtime/pkg/source"
 )
@@ -33,12 +46,109 @@ const (
 	reconcileFinalizerDNS = "dns.cloudingress.managed.openshift.io"
 	elbAnnotationKey      = "service.beta.kubernetes.io/aws-load-balancer-connection-idle-timeout"
 	elbAnnotationValue    = "1800"
+	// nlbTypeAnnotationKey asks the AWS cloud provider for a network load
+	// balancer, which is needed for dualstack
+	nlbTypeAnnotationKey       = "service.beta.kubernetes.io/aws-load-balancer-type"
+	ipAddressTypeAnnotationKey = "service.beta.kubernetes.io/aws-load-balancer-ip-address-type"
+	// healthCheckAnnotationPrefix is followed by the name of the health check
+	// parameter, eg interval
+	healthCheckAnnotationPrefix = "service.beta.kubernetes.io/aws-load-balancer-healthcheck-"
+	// defaultHealthCheckPath is what HTTP and HTTPS health checks request if
+	// the CloudIngressConfig doesn't say
+	defaultHealthCheckPath = "/readyz"
+	// healthCheckProbeTimeout bounds each request of a health check protocol
+	// probe
+	healthCheckProbeTimeout = 5 * time.Second
+	// subnetsAnnotationKey lists the subnets the AWS cloud provider should
+	// put the load balancer in, instead of discovering them by tag
+	subnetsAnnotationKey = "service.beta.kubernetes.io/aws-load-balancer-subnets"
+	// targetNodeLabelsAnnotationKey limits the nodes the AWS cloud provider
+	// registers with the load balancer to those with its labels
+	targetNodeLabelsAnnotationKey = "service.beta.kubernetes.io/aws-load-balancer-target-node-labels"
+	// eipAllocationsAnnotationKey lists the Elastic IP allocations the AWS
+	// cloud provider attaches to a network load balancer
+	eipAllocationsAnnotationKey = "service.beta.kubernetes.io/aws-load-balancer-eip-allocations"
+	// awsInternalAnnotationKey and gcpLoadBalancerTypeAnnotationKey make the
+	// cloud provider create an internal load balancer
+	awsInternalAnnotationKey         = "service.beta.kubernetes.io/aws-load-balancer-internal"
+	gcpLoadBalancerTypeAnnotationKey = "cloud.google.com/load-balancer-type"
+	// gcpBackendServiceAnnotationKey makes the GCP cloud provider create an
+	// external passthrough load balancer with a backend service rather than
+	// a target pool
+	gcpBackendServiceAnnotationKey = "cloud.google.com/l4-rbs"
+	// externalDNSHostnameAnnotationKey is the hostname external-dns
+	// publishes the records of a Service's load balancer under
+	externalDNSHostnameAnnotationKey = "external-dns.alpha.kubernetes.io/hostname"
+	// apiSchemeNameLabel and apiSchemeNamespaceLabel name the APIScheme an
+	// admin API Service belongs to
+	apiSchemeNameLabel      = "apischeme_cr"
+	apiSchemeNamespaceLabel = "apischeme_cr_namespace"
+	// defaultAdminAPIDNSName is the name of the admin API Service an
+	// APIScheme without a DNSName adopts
+	defaultAdminAPIDNSName = "rh-api"
+	// lbRecoveryAnnotationKey is when the operator last asked the cloud
+	// provider to recreate the load balancer of an admin API Service, after
+	// it was deleted outside the cluster. It's removed once it's back
+	lbRecoveryAnnotationKey = "cloudingress.managed.openshift.io/lb-recovery"
+
+	// Kinds of the cloud resources recorded in the APIScheme status
+	resourceKindService       = "service"
+	resourceKindSecurityGroup = "security-group"
+	resourceKindElasticIP     = "elastic-ip"
 )
 
 var (
 	log = logf.Log.WithName("controller_apischeme")
 	// for testing to set it to something else
 	cloudClient cloudclient.CloudClient
+	// cloudClientConfigVersion is the platform config version cloudClient was
+	// made for. It's empty for a cloudClient set by the tests, which is kept
+	cloudClientConfigVersion string
+	// cloudClientMu guards cloudClient and cloudClientConfigVersion across
+	// concurrent reconciles
+	cloudClientMu sync.Mutex
+	// missingPermissions are the cloud permissions the last preflight found
+	// permissionsCheckedFor lacks, as of permissionsCheckedAt, unless
+	// permissionsUnchecked as the check failed. cloudClientMu guards them too
+	missingPermissions    []string
+	permissionsCheckedFor cloudclient.CloudClient
+	permissionsCheckedAt  time.Time
+	permissionsUnchecked  bool
+	// MaxConcurrentReconciles is how many APISchemes are reconciled at once
+	MaxConcurrentReconciles = 1
+	// RequeueIntervals are how soon an APIScheme is reconciled again. Its
+	// DriftScan is how often the cloud resources behind the admin API are
+	// re-verified and repaired, and its Success is soon enough to revoke the
+	// next CIDR block to expire on time
+	RequeueIntervals = utils.RequeueIntervals{
+		Success:   60 * time.Second,
+		Error:     10 * time.Second,
+		DriftScan: config.DefaultResyncPeriod,
+	}
+	// RollbackTimeout is how long a new admin API endpoint may fail to become
+	// ready before the cloud resources created for it are rolled back
+	RollbackTimeout = 30 * time.Minute
+	// HealthyInstancesPollInterval is how often the admin API load balancer is
+	// checked again while no instance behind it passes its health checks. A
+	// new endpoint that never gets one is rolled back after RollbackTimeout
+	HealthyInstancesPollInterval = 10 * time.Second
+	// PreconditionRecheckInterval is how often a failed precondition, fixed
+	// outside the operator, is checked again
+	PreconditionRecheckInterval = 5 * time.Minute
+	// LoadBalancerRecoveryInterval is how long the cloud provider is given to
+	// recreate a deleted admin API load balancer before it's asked again
+	LoadBalancerRecoveryInterval = 5 * time.Minute
+	// DNSZoneGracePeriod is how long after the cluster is created the DNS
+	// zone of the admin API record may be missing, before the APIScheme fails
+	DNSZoneGracePeriod = time.Hour
+	// MaxDNSZoneRetryDelay caps how long to wait for a missing DNS zone
+	// before checking again
+	MaxDNSZoneRetryDelay = 5 * time.Minute
+	// QuotaNearExhaustionRatio is the share of the cloud's load balancer
+	// quota in use from which the QuotaNearExhaustion condition is set
+	QuotaNearExhaustionRatio = 0.9
+	// for testing to probe something else than the API server
+	probeHealthCheckProtocol = baseutils.ProbeHealthCheckProtocol
 )
 
 /**
@@ -54,13 +164,19 @@ func Add(mgr manager.Manager) error {
 
 // newReconciler returns a new reconcile.Reconciler
 func newReconciler(mgr manager.Manager) reconcile.Reconciler {
-	return &ReconcileAPIScheme{client: mgr.GetClient(), scheme: mgr.GetScheme()}
+	return &ReconcileAPIScheme{
+		client:             baseutils.NewTimedClient(mgr.GetClient()),
+		scheme:             mgr.GetScheme(),
+		lastDriftCheck:     make(map[types.NamespacedName]time.Time),
+		lastMasterMachines: make(map[types.NamespacedName]string),
+		recorder:           mgr.GetEventRecorderFor("cloud-ingress-operator"),
+	}
 }
 
 // add adds a new Controller to mgr with r as the reconcile.Reconciler
 func add(mgr manager.Manager, r reconcile.Reconciler) error {
 	// Create a new controller
-	c, err := controller.New("apischeme-controller", mgr, controller.Options{Reconciler: r})
+	c, err := controller.New("apischeme-controller", mgr, controller.Options{Reconciler: r, MaxConcurrentReconciles: MaxConcurrentReconciles})
 	if err != nil {
 		return err
 	}
@@ -71,9 +187,114 @@ func add(mgr manager.Manager, r reconcile.Reconciler) error {
 		return err
 	}
 
+	// Watch for APISchemes being deleted or toggled, so that another one takes
+	// over the admin API load balancer once the one driving it no longer does
+	p := predicate.Funcs{
+		CreateFunc: func(e event.CreateEvent) bool {
+			return false
+		},
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			oldInstance, okOld := e.ObjectOld.(*cloudingressv1alpha1.APIScheme)
+			newInstance, okNew := e.ObjectNew.(*cloudingressv1alpha1.APIScheme)
+			return okOld && okNew && (oldInstance.Spec.ManagementAPIServerIngress.Enabled != newInstance.Spec.ManagementAPIServerIngress.Enabled ||
+				oldInstance.DeletionTimestamp.IsZero() != newInstance.DeletionTimestamp.IsZero())
+		},
+		DeleteFunc: func(e event.DeleteEvent) bool {
+			return true
+		},
+		GenericFunc: func(e event.GenericEvent) bool {
+			return false
+		},
+	}
+	err = c.Watch(&source.Kind{Type: &cloudingressv1alpha1.APIScheme{}}, handler.EnqueueRequestsFromMapFunc(allAPISchemes(mgr.GetClient())), p)
+	if err != nil {
+		return err
+	}
+
+	// Watch the master Machines, so the admin API load balancer follows a
+	// master being replaced instead of waiting for the next drift check. A new
+	// master matters once it's a node, an old one once it's being deleted
+	p = predicate.Funcs{
+		CreateFunc: func(e event.CreateEvent) bool {
+			return baseutils.IsMasterMachine(e.Object)
+		},
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			oldMachine, okOld := e.ObjectOld.(*machineapi.Machine)
+			newMachine, okNew := e.ObjectNew.(*machineapi.Machine)
+			return okOld && okNew && baseutils.IsMasterMachine(newMachine) &&
+				masterMachinesState([]machineapi.Machine{*oldMachine}) != masterMachinesState([]machineapi.Machine{*newMachine})
+		},
+		DeleteFunc: func(e event.DeleteEvent) bool {
+			return baseutils.IsMasterMachine(e.Object)
+		},
+		GenericFunc: func(e event.GenericEvent) bool {
+			return false
+		},
+	}
+	err = c.Watch(&source.Kind{Type: &machineapi.Machine{}}, handler.EnqueueRequestsFromMapFunc(allAPISchemes(mgr.GetClient())), p)
+	if err != nil {
+		return err
+	}
+
+	// Watch the cluster's Infrastructure and DNS configs, so the cloud client
+	// is made again as soon as the region or zones it was made for change
+	for _, obj := range []client.Object{&configv1.Infrastructure{}, &configv1.DNS{}} {
+		err = c.Watch(&source.Kind{Type: obj}, handler.EnqueueRequestsFromMapFunc(allAPISchemes(mgr.GetClient())), predicate.ResourceVersionChangedPredicate{})
+		if err != nil {
+			return err
+		}
+	}
+
+	// Watch ConfigMaps, so the allowed CIDR blocks another controller lists in
+	// one are applied as soon as they change
+	err = c.Watch(&source.Kind{Type: &corev1.ConfigMap{}}, handler.EnqueueRequestsFromMapFunc(apiSchemesAllowingCIDRBlocksOf(mgr.GetClient())), predicate.ResourceVersionChangedPredicate{})
+	if err != nil {
+		return err
+	}
+
 	return nil
 }
 
+// apiSchemesAllowingCIDRBlocksOf returns a handler.MapFunc asking to reconcile
+// the APISchemes whose AllowedCIDRBlocksConfigMap is the ConfigMap
+func apiSchemesAllowingCIDRBlocksOf(kclient client.Client) handler.MapFunc {
+	return func(obj client.Object) []reconcile.Request {
+		apiSchemeList := &cloudingressv1alpha1.APISchemeList{}
+		if err := kclient.List(context.TODO(), apiSchemeList, client.InNamespace(obj.GetNamespace())); err != nil {
+			log.Error(err, "Cannot get list of APISchemes")
+			return []reconcile.Request{}
+		}
+		requests := []reconcile.Request{}
+		for _, apiScheme := range apiSchemeList.Items {
+			if apiScheme.Spec.ManagementAPIServerIngress.AllowedCIDRBlocksConfigMap != obj.GetName() {
+				continue
+			}
+			requests = append(requests, reconcile.Request{
+				NamespacedName: types.NamespacedName{Name: apiScheme.Name, Namespace: apiScheme.Namespace},
+			})
+		}
+		return requests
+	}
+}
+
+// allAPISchemes returns a handler.MapFunc asking to reconcile every APIScheme
+func allAPISchemes(kclient client.Client) handler.MapFunc {
+	return func(_ client.Object) []reconcile.Request {
+		apiSchemeList := &cloudingressv1alpha1.APISchemeList{}
+		if err := kclient.List(context.TODO(), apiSchemeList); err != nil {
+			log.Error(err, "Cannot get list of APISchemes")
+			return []reconcile.Request{}
+		}
+		requests := []reconcile.Request{}
+		for _, apiScheme := range apiSchemeList.Items {
+			requests = append(requests, reconcile.Request{
+				NamespacedName: types.NamespacedName{Name: apiScheme.Name, Namespace: apiScheme.Namespace},
+			})
+		}
+		return requests
+	}
+}
+
 // blank assignment to verify that ReconcileAPIScheme implements reconcile.Reconciler
 var _ reconcile.Reconciler = &ReconcileAPIScheme{}
 
@@ -83,6 +304,20 @@ type ReconcileAPIScheme struct {
 	// that reads objects from the cache and writes to the apiserver
 	client client.Client
 	scheme *runtime.Scheme
+	// lastDriftCheck is when each APIScheme's cloud resources were last
+	// re-verified
+	lastDriftCheck map[types.NamespacedName]time.Time
+	// lastMasterMachines is the masterMachinesState of each APIScheme's last
+	// drift check
+	lastMasterMachines map[types.NamespacedName]string
+	// lastReconciledGeneration is the generation of each APIScheme's last
+	// successful reconcile
+	lastReconciledGeneration map[types.NamespacedName]int64
+	// driftMu guards lastDriftCheck, lastMasterMachines and
+	// lastReconciledGeneration across concurrent reconciles
+	driftMu sync.Mutex
+	// recorder emits the Events of the APISchemes, if set
+	recorder record.EventRecorder
 }
 
 // LoadBalancer contains the relevant information to create a Load Balancer
@@ -95,15 +330,17 @@ type LoadBalancer struct {
 // Reconcile will ensure that the rh-api management api endpoint is created and ready.
 // Rough Steps:
 // 1. Create Service
-// 2. Add DNS CNAME from rh-api to the ELB created by AWS provider
+// 2. Add DNS alias records from rh-api to the ELB created by AWS provider
 // 3. Ready for work (Ready)
 func (r *ReconcileAPIScheme) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	ctx, timings := baseutils.WithReconcileTimings(ctx)
+	defer utils.ReportReconcileTimings("apischeme", timings)
 	reqLogger := log.WithValues("Request.Namespace", request.Namespace, "Request.Name", request.Name)
 	reqLogger.Info("Reconciling APIScheme")
 
 	// Fetch the APIScheme instance
 	instance := &cloudingressv1alpha1.APIScheme{}
-	err := r.client.Get(context.TODO(), request.NamespacedName, instance)
+	err := r.client.Get(ctx, request.NamespacedName, instance)
 	if err != nil {
 		if errors.IsNotFound(err) {
 			// Request object not found, could have been deleted after reconcile request.
@@ -117,19 +354,55 @@ func (r *ReconcileAPIScheme) Reconcile(ctx context.Context, request reconcile.Re
 		return reconcile.Result{}, err
 	}
 
+	result, err := r.reconcileAPIScheme(ctx, request, instance)
+	r.recordFailures(instance, err)
+	return result, err
+}
+
+// reconcileAPIScheme reconciles instance, the APIScheme of request
+func (r *ReconcileAPIScheme) reconcileAPIScheme(ctx context.Context, request reconcile.Request, instance *cloudingressv1alpha1.APIScheme) (reconcile.Result, error) {
+	reqLogger := log.WithValues("Request.Namespace", request.Namespace, "Request.Name", request.Name)
+
 	// If the management API isn't enabled, we have nothing to do!
 	if !instance.Spec.ManagementAPIServerIngress.Enabled {
 		reqLogger.Info("Not enabled", "instance", instance)
 		return reconcile.Result{}, nil
 	}
 
-	if cloudClient == nil {
-		cloudPlatform, err := baseutils.GetPlatformType(r.client)
-		if err != nil {
-			r.SetAPISchemeStatus(instance, "Couldn't reconcile", "Couldn't create a Cloud Client", cloudingressv1alpha1.ConditionError)
-			return reconcile.Result{}, err
-		}
-		cloudClient = cloudclient.GetClientFor(r.client, *cloudPlatform)
+	// A hosted control plane has no masters in the cluster to put behind the
+	// admin API load balancer
+	hosted, err := baseutils.IsHostedControlPlane(r.client)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	if hosted {
+		reqLogger.Info("Unsupported, the control plane is hosted outside the cluster")
+		return r.reportHostedControlPlane(instance)
+	}
+
+	// Only one APIScheme drives the admin API load balancer, the others would
+	// fight over it
+	driving, err := r.getDrivingAPIScheme()
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	if driving != nil && (driving.Namespace != instance.Namespace || driving.Name != instance.Name) {
+		reqLogger.Info("Rejected, another APIScheme drives the admin API", "driving", driving.Namespace+"/"+driving.Name)
+		return r.reject(instance, driving)
+	}
+
+	// A new region or zone in the cluster's Infrastructure or DNS config
+	// needs a new cloud client
+	cloudClient, err := r.refreshCloudClient()
+	if err != nil {
+		r.SetAPISchemeStatus(instance, "Couldn't reconcile", "Couldn't create a Cloud Client", cloudingressv1alpha1.ConditionError)
+		return reconcile.Result{}, err
+	}
+
+	// The admin API of an existing cluster is taken as it is, rather than
+	// made to match a spec written without looking at it
+	if instance.Annotations[cloudingressv1alpha1.AdoptAnnotation] == "true" {
+		return r.adopt(ctx, instance)
 	}
 
 	serviceNamespacedName := types.NamespacedName{
@@ -137,57 +410,93 @@ func (r *ReconcileAPIScheme) Reconcile(ctx context.Context, request reconcile.Re
 		Namespace: "openshift-kube-apiserver",
 	}
 
+	observing, err := baseutils.IsObserving(r.client)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	if observing {
+		// In observer mode the cloud resources belong to something else, so
+		// the APIScheme is only ever planned, and a deletion waits too
+		reqLogger.Info("Observing, only reporting drift")
+		return r.reportPlan(ctx, instance, serviceNamespacedName)
+	}
+	paused, err := baseutils.IsPaused(r.client, instance)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	if paused {
+		// Not even a deletion is carried out, so the finalizer holds the
+		// APIScheme until it's unpaused
+		reqLogger.Info("Paused, only checking for drift")
+		return r.reportPaused(ctx, instance, serviceNamespacedName)
+	}
+	if instance.Spec.ManagementState == cloudingressv1alpha1.ManagementStatePlan {
+		// Like a paused APIScheme, a deletion waits for Managed
+		reqLogger.Info("Planning, not applying any change")
+		return r.reportPlan(ctx, instance, serviceNamespacedName)
+	}
+
+	// Missing permissions would otherwise fail some cloud change half way
+	// through, with an error naming none of them
+	missing, err := r.preflight(ctx, cloudClient)
+	if err != nil {
+		// Not every account lets the permissions be checked, so carry on
+		reqLogger.Info("Couldn't check the cloud permissions", "reason", err.Error())
+	} else if len(missing) > 0 {
+		reqLogger.Info("Preflight failed, the cloud credentials lack permissions", "missing", missing)
+		return r.reportPreflightFailed(instance, missing)
+	}
+
+	// While the cloud API throttles the operator, spec changes and deletions
+	// go first, the periodic reconciles wait for the backoff to end
+	if until, throttled := utils.CloudThrottle.BackingOff(time.Now()); throttled && instance.DeletionTimestamp.IsZero() && r.reconciledGeneration(request.NamespacedName) == instance.Generation {
+		reqLogger.Info("Throttled, putting the reconcile off until " + until.UTC().Format(time.RFC3339))
+		return reconcile.Result{RequeueAfter: time.Until(until)}, nil
+	}
+
 	// Check for a deletion timestamp.
 	if instance.DeletionTimestamp.IsZero() {
 		// Request object is alive, so ensure it has the DNS finalizer.
 		if !controllerutil.ContainsFinalizer(instance, reconcileFinalizerDNS) {
 			controllerutil.AddFinalizer(instance, reconcileFinalizerDNS)
-			if err = r.client.Update(context.TODO(), instance); err != nil {
+			if err = r.client.Update(ctx, instance); err != nil {
 				return reconcile.Result{}, err
 			}
 		}
 	} else {
 		// Request object is being deleted.
 		if controllerutil.ContainsFinalizer(instance, reconcileFinalizerDNS) {
-			found := &corev1.Service{}
-			if err = r.client.Get(context.TODO(), serviceNamespacedName, found); err != nil {
-				if errors.IsNotFound(err) {
-					// Service was not found!
-					//
-					// Skip the DeleteAdminAPIDNS call and remove the
-					// finalizer anyway so the CR deletion can proceed.
-					// This could leave DNS entries behind!
-					//
-					// TODO As a future enhancement, the CloudClient
-					//      provider should handle this scenario and
-					//      look up the necessary information itself
-					//      to proceed with the DNS deletion.
-					found = nil
-				} else {
-					reqLogger.Error(err, "Couldn't get the Service")
-					return reconcile.Result{}, err
-				}
+			err = Destroy(ctx, r.client, cloudClient, instance)
+			switch err := err.(type) {
+			case nil:
+				// all good
+			case *cioerrors.LoadBalancerNotReadyError:
+				// couldn't find the load balancer - it's likely still queued for creation
+				r.SetAPISchemeStatus(instance, "Couldn't reconcile", "Load balancer isn't ready", cloudingressv1alpha1.ConditionError)
+				return RequeueIntervals.ErrorResult(), nil
+			case *cioerrors.ElasticIPInUseError:
+				// AWS is still deleting the load balancer
+				reqLogger.Info("Waiting for the load balancer to release the Elastic IPs")
+				return RequeueIntervals.ErrorResult(), nil
+			case *cioerrors.SecurityGroupInUseError:
+				// AWS is still deleting the load balancer
+				reqLogger.Info("Waiting for the load balancer to release the security group")
+				return RequeueIntervals.ErrorResult(), nil
+			default:
+				r.setCloudErrorStatus(instance, "Couldn't reconcile", "Failed to delete the admin API endpoint", err)
+				return utils.CloudErrorResult(err)
 			}
 
-			if found != nil {
-				err = cloudClient.DeleteAdminAPIDNS(context.TODO(), r.client, instance, found)
-				switch err := err.(type) {
-				case nil:
-					// all good
-				case *cioerrors.LoadBalancerNotReadyError:
-					// couldn't find the load balancer - it's likely still queued for creation
-					r.SetAPISchemeStatus(instance, "Couldn't reconcile", "Load balancer isn't ready", cloudingressv1alpha1.ConditionError)
-					return reconcile.Result{Requeue: true, RequeueAfter: 10 * time.Second}, nil
-				default:
-					reqLogger.Error(err, "Failed to delete the DNS record")
-					r.SetAPISchemeStatus(instance, "Couldn't reconcile", "Failed to delete the DNS record", cloudingressv1alpha1.ConditionError)
-					return reconcile.Result{}, err
-				}
+			// Nothing is left for a recreated APIScheme to re-adopt
+			instance.Status.CreatedResources = nil
+			if err = saveResourceManifest(ctx, r.client, instance); err != nil {
+				reqLogger.Error(err, "Couldn't update the resource manifest")
+				return reconcile.Result{}, err
 			}
 
 			// Remove the DNS finalizer and update the request object.
 			controllerutil.RemoveFinalizer(instance, reconcileFinalizerDNS)
-			if err = r.client.Update(context.TODO(), instance); err != nil {
+			if err = r.client.Update(ctx, instance); err != nil {
 				return reconcile.Result{}, err
 			}
 
@@ -200,46 +509,226 @@ func (r *ReconcileAPIScheme) Reconcile(ctx context.Context, request reconcile.Re
 		return reconcile.Result{}, nil
 	}
 
+	// The cloud resources created for an APIScheme are recorded outside of it
+	// too, so an etcd restore or recreating it doesn't lose track of them
+	restored, err := restoreCreatedResources(ctx, r.client, instance)
+	if err != nil {
+		reqLogger.Error(err, "Couldn't read the resource manifest")
+		return reconcile.Result{}, err
+	}
+	if restored {
+		reqLogger.Info("Restored the cloud resources recorded in the resource manifest", "resources", instance.Status.CreatedResources)
+		if err = r.client.Status().Update(ctx, instance); err != nil {
+			reqLogger.Error(err, "Error updating cr status")
+			return reconcile.Result{}, err
+		}
+	}
+
+	// A rolled back endpoint is only tried again once its spec changes
+	if instance.Status.RolledBackGeneration != 0 && instance.Status.RolledBackGeneration == instance.Generation {
+		return r.rollBack(ctx, instance, serviceNamespacedName)
+	}
+
+	ingressConfig, err := baseutils.GetCloudIngressConfig(r.client)
+	if err != nil {
+		reqLogger.Error(err, "Couldn't get the CloudIngressConfig")
+		return reconcile.Result{}, err
+	}
+
+	if updateTemporaryPublicAccess(instance, time.Now()) {
+		if period := instance.Status.TemporaryPublicAccess; period != nil {
+			reqLogger.Info("Making the admin API public until " + period.ExpiresAt.UTC().Format(time.RFC3339))
+		}
+		err = r.client.Status().Update(ctx, instance)
+		if err != nil {
+			reqLogger.Error(err, "Error updating cr status")
+			return reconcile.Result{}, err
+		}
+	}
+
+	// Elastic IPs can only be attached to an internet-facing load balancer
+	if instance.Spec.ManagementAPIServerIngress.StaticIP != nil && instance.Spec.ManagementAPIServerIngress.Scheme == cloudingressv1alpha1.LoadBalancerSchemeInternal {
+		r.SetAPISchemeStatus(instance, "Invalid spec", "Static IPs need an internet-facing load balancer", cloudingressv1alpha1.ConditionError)
+		return reconcile.Result{}, nil
+	}
+
+	// Weighted and failover records can't be left to external-dns
+	if instance.Spec.ManagementAPIServerIngress.NLBMigration != nil && instance.Spec.ManagementAPIServerIngress.DNSManagement == cloudingressv1alpha1.DNSManagementExternalDNS {
+		r.SetAPISchemeStatus(instance, "Invalid spec", "An NLB migration needs the operator to manage the DNS records", cloudingressv1alpha1.ConditionError)
+		return reconcile.Result{}, nil
+	}
+
+	// Fields the cloud can't honor are reported, and left alone
+	platform, err := baseutils.GetPlatformType(r.client)
+	if err != nil {
+		reqLogger.Error(err, "Couldn't get the platform type")
+		return reconcile.Result{}, err
+	}
+	capabilities := cloudclient.GetCapabilitiesFor(*platform)
+	setUnsupportedFieldsCondition(instance, *platform, unsupportedFields(capabilities, instance))
+
+	// The admin API is made public and internal again at the boundaries of
+	// the maintenance windows
+	inWindow, nextWindowChange, err := baseutils.GetPublicAccessWindow(instance, time.Now())
+	if err != nil {
+		r.SetAPISchemeStatus(instance, "Invalid spec", err.Error(), cloudingressv1alpha1.ConditionError)
+		return reconcile.Result{}, nil
+	}
+	if instance.Spec.ManagementAPIServerIngress.Scheme != cloudingressv1alpha1.LoadBalancerSchemeInternal {
+		// Already public
+		nextWindowChange = nil
+	} else if inWindow {
+		reqLogger.Info("Maintenance window open, making the admin API public until " + nextWindowChange.UTC().Format(time.RFC3339))
+	}
+
+	// Allowed CIDR blocks drop out of the Service once they expire. Those of
+	// the ConfigMap and the baseline are merged in
+	allowedCIDRBlocks, nextExpiry, err := baseutils.GetEffectiveAllowedCIDRBlocks(r.client, instance, time.Now())
+	if err != nil {
+		r.SetAPISchemeStatus(instance, "Invalid allowed CIDR blocks", err.Error(), cloudingressv1alpha1.ConditionError)
+		return reconcile.Result{}, nil
+	}
+	instance.Status.EffectiveAllowedCIDRBlocks = allowedCIDRBlocks
+
+	servicePorts, err := adminAPIServicePorts(r.client, instance)
+	if err != nil {
+		reqLogger.Error(err, "Couldn't get the API server ports")
+		r.SetAPISchemeStatus(instance, "Couldn't reconcile", "Couldn't get the API server ports: "+err.Error(), cloudingressv1alpha1.ConditionError)
+		return reconcile.Result{}, err
+	}
+
 	// Does the Service exist already?
 	found := &corev1.Service{}
-	err = r.client.Get(context.TODO(), serviceNamespacedName, found)
+	err = r.client.Get(ctx, serviceNamespacedName, found)
 	if err != nil {
 		if errors.IsNotFound(err) {
 			// need to create it
-			dep := r.newServiceFor(instance)
+			dep := r.newServiceFor(instance, ingressConfig, allowedCIDRBlocks, servicePorts)
+			if err = r.checkLoadBalancerQuota(ctx, cloudClient, instance, dep.Annotations[nlbTypeAnnotationKey] == "nlb"); err != nil {
+				if quotaErr, ok := err.(*cioerrors.ActionRequiredError); ok {
+					reqLogger.Info("No room for the admin API load balancer", "problem", quotaErr.Error())
+					r.setActionRequiredStatus(instance, quotaErr.Reason, quotaErr.Error(), quotaErr.Remediation)
+					return reconcile.Result{RequeueAfter: PreconditionRecheckInterval}, nil
+				}
+				reqLogger.Error(err, "Couldn't check the load balancer quota")
+				r.setCloudErrorStatus(instance, "Couldn't reconcile", "Couldn't check the load balancer quota", err)
+				return utils.CloudErrorResult(err)
+			}
+			if err = cloudClient.ValidateAdminAPISubnets(ctx, r.client, instance.Spec.ManagementAPIServerIngress.SubnetIDs, hasInternalLoadBalancer(dep)); err != nil {
+				return r.subnetsErrorResult(instance, err)
+			}
+			if instance.Spec.ManagementAPIServerIngress.StaticIP != nil && capabilities.SupportsStaticIP {
+				_, err = r.ensureStaticIPs(ctx, cloudClient, instance, dep)
+				if err != nil {
+					reqLogger.Error(err, "Couldn't ensure static IPs for the Service")
+					r.setCloudErrorStatus(instance, "Couldn't reconcile", "Couldn't ensure static IPs", err)
+					return utils.CloudErrorResult(err)
+				}
+				// Only the addresses the operator allocated itself are its own
+				if len(instance.Spec.ManagementAPIServerIngress.StaticIP.AllocationIDs) == 0 && dep.Annotations[eipAllocationsAnnotationKey] != "" {
+					for _, allocationID := range strings.Split(dep.Annotations[eipAllocationsAnnotationKey], ",") {
+						recordCreatedResource(instance, resourceKindElasticIP, allocationID)
+					}
+				}
+			}
+			if capabilities.SupportsSGManagement {
+				var groupID string
+				groupID, err = cloudClient.EnsureAdminAPISecurityGroup(ctx, r.client, instance, dep)
+				if err != nil {
+					reqLogger.Error(err, "Couldn't ensure the security group for the Service")
+					return r.securityGroupErrorResult(instance, err)
+				}
+				if groupID != "" {
+					recordCreatedResource(instance, resourceKindSecurityGroup, groupID)
+				}
+			}
 			reqLogger.Info("Service not found. Creating", "service", dep)
-			err = r.client.Create(context.TODO(), dep)
+			err = utils.Apply(ctx, r.client, r.scheme, instance, dep)
 			if err != nil {
 				reqLogger.Error(err, "Failure to create new Service")
 				return reconcile.Result{}, err
 			}
+			recordCreatedResource(instance, resourceKindService, serviceNamespacedName.String())
+			if err = r.client.Status().Update(ctx, instance); err != nil {
+				reqLogger.Error(err, "Error updating cr status")
+			}
+			if err = saveResourceManifest(ctx, r.client, instance); err != nil {
+				reqLogger.Error(err, "Couldn't update the resource manifest")
+			}
 			// Reconcile again to get the new Service and give AWS time to create the ELB
 			reqLogger.Info("Service was just created, so let's try to requeue to set it up")
-			return reconcile.Result{Requeue: true, RequeueAfter: 10 * time.Second}, nil
+			return RequeueIntervals.ErrorResult(), nil
 		} else if err != nil {
 			reqLogger.Error(err, "Couldn't get the Service")
 			return reconcile.Result{}, err
 		}
 	}
+
+	// With APISchemes in several namespaces, two could ask for the same
+	// endpoint. The first to create its Service keeps it
+	if ownedByAnotherAPIScheme(instance, found) {
+		reqLogger.Info(fmt.Sprintf("%s/service/%s belongs to another APIScheme", found.GetNamespace(), found.GetName()))
+		r.SetAPISchemeStatus(instance, "Conflict", fmt.Sprintf("%s/service/%s belongs to the APIScheme %s/%s", found.GetNamespace(), found.GetName(), found.Labels[apiSchemeNamespaceLabel], found.Labels[apiSchemeNameLabel]), cloudingressv1alpha1.ConditionError)
+		return reconcile.Result{}, nil
+	}
+	if found.Labels[apiSchemeNamespaceLabel] == "" {
+		// Services created before APISchemes could be in other namespaces
+		metav1.SetMetaDataLabel(&found.ObjectMeta, apiSchemeNameLabel, instance.GetName())
+		metav1.SetMetaDataLabel(&found.ObjectMeta, apiSchemeNamespaceLabel, instance.GetNamespace())
+		err = r.client.Update(ctx, found)
+		if err != nil {
+			reqLogger.Error(err, "Error updating service labels")
+			return reconcile.Result{}, err
+		}
+	}
+
+	if rollbackDue(instance, found, time.Now()) {
+		// Only what the operator recorded creating is its own to delete
+		if !hasCreatedResource(instance, resourceKindService, serviceNamespacedName.String()) {
+			reqLogger.Info(fmt.Sprintf("The admin API endpoint isn't ready %s after %s/service/%s was created, but the operator didn't create it. Leaving it alone", RollbackTimeout, found.GetNamespace(), found.GetName()))
+			if err = r.reportNotRolledBack(ctx, instance, found); err != nil {
+				reqLogger.Error(err, "Error updating cr status")
+				return reconcile.Result{}, err
+			}
+		} else {
+			reqLogger.Info(fmt.Sprintf("The admin API endpoint isn't ready %s after %s/service/%s was created. Rolling back...", RollbackTimeout, found.GetNamespace(), found.GetName()))
+			return r.rollBack(ctx, instance, serviceNamespacedName)
+		}
+	}
+
 	// Reconcile the access list in the Service
-	if !sliceEquals(found.Spec.LoadBalancerSourceRanges, instance.Spec.ManagementAPIServerIngress.AllowedCIDRBlocks) {
-		reqLogger.Info(fmt.Sprintf("Mismatch svc %s != %s\n", found.Spec.LoadBalancerSourceRanges, instance.Spec.ManagementAPIServerIngress.AllowedCIDRBlocks))
+	if !sliceEquals(found.Spec.LoadBalancerSourceRanges, allowedCIDRBlocks) {
+		reqLogger.Info(fmt.Sprintf("Mismatch svc %s != %s\n", found.Spec.LoadBalancerSourceRanges, allowedCIDRBlocks))
 		reqLogger.Info(fmt.Sprintf("Mismatch between %s/service/%s LoadBalancerSourceRanges and AllowedCIDRBlocks. Updating...", found.GetNamespace(), found.GetName()))
-		found.Spec.LoadBalancerSourceRanges = instance.Spec.ManagementAPIServerIngress.AllowedCIDRBlocks
-		err = r.client.Update(context.TODO(), found)
+		found.Spec.LoadBalancerSourceRanges = allowedCIDRBlocks
+		err = r.client.Update(ctx, found)
 		if err != nil {
 			reqLogger.Error(err, fmt.Sprintf("Failed to update the %s/service/%s LoadBalancerSourceRanges", found.GetNamespace(), found.GetName()))
 			return reconcile.Result{}, err
 		}
 		// let's re-queue just in case
 		reqLogger.Info("Requeuing after svc update")
-		return reconcile.Result{Requeue: true, RequeueAfter: 10 * time.Second}, nil
+		return RequeueIntervals.ErrorResult(), nil
+	}
+
+	// Follow the API server onto another port, and the listeners of the spec.
+	// The node port of a listener kept is kept
+	if len(found.Spec.Ports) > 0 && !servicePortsMatch(found.Spec.Ports, servicePorts) {
+		reqLogger.Info(fmt.Sprintf("%s/service/%s has ports %s, the API is on %s. Updating...",
+			found.GetNamespace(), found.GetName(), describeServicePorts(found.Spec.Ports), describeServicePorts(servicePorts)))
+		found.Spec.Ports = updatedServicePorts(found.Spec.Ports, servicePorts)
+		err = r.client.Update(ctx, found)
+		if err != nil {
+			reqLogger.Error(err, fmt.Sprintf("Failed to update the %s/service/%s port", found.GetNamespace(), found.GetName()))
+			return reconcile.Result{}, err
+		}
+		return RequeueIntervals.ErrorResult(), nil
 	}
 
 	if !metav1.HasAnnotation(found.ObjectMeta, elbAnnotationKey) ||
 		found.Annotations[elbAnnotationKey] != elbAnnotationValue {
 		metav1.SetMetaDataAnnotation(&found.ObjectMeta, elbAnnotationKey, elbAnnotationValue)
-		err = r.client.Update(context.TODO(), found)
+		err = r.client.Update(ctx, found)
 		if err != nil {
 			reqLogger.Error(err, "Error updating service annotation")
 			return reconcile.Result{}, err
@@ -247,66 +736,617 @@ func (r *ReconcileAPIScheme) Reconcile(ctx context.Context, request reconcile.Re
 		reqLogger.Info(fmt.Sprintf("Updated %s svc idle timeout to %s", found.Name, elbAnnotationValue))
 	}
 
-	err = cloudClient.EnsureAdminAPIDNS(context.TODO(), r.client, instance, found)
+	healthCheck := healthCheckAnnotations(resolveHealthCheck(ingressConfig.Spec.HealthCheck, found))
+	if !annotationsMatch(found.ObjectMeta, healthCheck) {
+		for key, value := range healthCheck {
+			metav1.SetMetaDataAnnotation(&found.ObjectMeta, key, value)
+		}
+		err = r.client.Update(ctx, found)
+		if err != nil {
+			reqLogger.Error(err, "Error updating service annotation")
+			return reconcile.Result{}, err
+		}
+		reqLogger.Info(fmt.Sprintf("Updated %s svc health check", found.Name))
+	}
+
+	if subnets := strings.Join(instance.Spec.ManagementAPIServerIngress.SubnetIDs, ","); subnets != "" && found.Annotations[subnetsAnnotationKey] != subnets {
+		if err = cloudClient.ValidateAdminAPISubnets(ctx, r.client, instance.Spec.ManagementAPIServerIngress.SubnetIDs, hasInternalLoadBalancer(found)); err != nil {
+			return r.subnetsErrorResult(instance, err)
+		}
+		metav1.SetMetaDataAnnotation(&found.ObjectMeta, subnetsAnnotationKey, subnets)
+		err = r.client.Update(ctx, found)
+		if err != nil {
+			reqLogger.Error(err, "Error updating service annotation")
+			return reconcile.Result{}, err
+		}
+		reqLogger.Info(fmt.Sprintf("Updated %s svc subnets to %s", found.Name, subnets))
+	}
+
+	// Without a selector, the cloud provider registers every node again, and
+	// the masters get the load balancer back
+	if targetNodeLabels := baseutils.FormatNodeLabels(instance.Spec.ManagementAPIServerIngress.BackendNodeSelector); found.Annotations[targetNodeLabelsAnnotationKey] != targetNodeLabels {
+		if targetNodeLabels == "" {
+			delete(found.Annotations, targetNodeLabelsAnnotationKey)
+		} else {
+			metav1.SetMetaDataAnnotation(&found.ObjectMeta, targetNodeLabelsAnnotationKey, targetNodeLabels)
+		}
+		err = r.client.Update(ctx, found)
+		if err != nil {
+			reqLogger.Error(err, "Error updating service annotation")
+			return reconcile.Result{}, err
+		}
+		reqLogger.Info(fmt.Sprintf("Updated %s svc backend nodes to %q", found.Name, targetNodeLabels))
+	}
+
+	// A classic ELB can't be dualstack, so it has to be replaced by a network
+	// load balancer. The IP address type of the latter can be changed in place
+	if wantsNetworkLoadBalancer(instance, ingressConfig) && found.Annotations[nlbTypeAnnotationKey] != "nlb" {
+		reqLogger.Info(fmt.Sprintf("%s/service/%s needs a network load balancer. Recreating...", found.GetNamespace(), found.GetName()))
+		err = r.client.Delete(ctx, found)
+		if err != nil {
+			reqLogger.Error(err, "Error deleting service to replace its load balancer")
+			return reconcile.Result{}, err
+		}
+		return RequeueIntervals.ErrorResult(), nil
+	}
+	// Neither cloud can change the scheme of a load balancer in place
+	if wantsInternalLoadBalancer(instance) != hasInternalLoadBalancer(found) {
+		reqLogger.Info(fmt.Sprintf("%s/service/%s needs a load balancer with the %s scheme. Recreating...", found.GetNamespace(), found.GetName(), loadBalancerScheme(instance)))
+		err = r.client.Delete(ctx, found)
+		if err != nil {
+			reqLogger.Error(err, "Error deleting service to replace its load balancer")
+			return reconcile.Result{}, err
+		}
+		return RequeueIntervals.ErrorResult(), nil
+	}
+	// Nor can GCP switch a load balancer between a target pool and a backend
+	// service
+	if wantsBackendService(instance) != hasBackendService(found) {
+		reqLogger.Info(fmt.Sprintf("%s/service/%s needs a load balancer with a %s backend. Recreating...", found.GetNamespace(), found.GetName(), instance.Spec.ManagementAPIServerIngress.Backend))
+		err = r.client.Delete(ctx, found)
+		if err != nil {
+			reqLogger.Error(err, "Error deleting service to replace its load balancer")
+			return reconcile.Result{}, err
+		}
+		return RequeueIntervals.ErrorResult(), nil
+	}
+	if ipAddressType, ok := desiredIPAddressType(instance, found); ok && found.Annotations[ipAddressTypeAnnotationKey] != ipAddressType {
+		metav1.SetMetaDataAnnotation(&found.ObjectMeta, ipAddressTypeAnnotationKey, ipAddressType)
+		err = r.client.Update(ctx, found)
+		if err != nil {
+			reqLogger.Error(err, "Error updating service annotation")
+			return reconcile.Result{}, err
+		}
+		reqLogger.Info(fmt.Sprintf("Updated %s svc IP address type to %s", found.Name, ipAddressType))
+	}
+
+	// Static IPs can only be attached when the cloud load balancer is created,
+	// so a Service whose static IP configuration drifted is recreated
+	instance.Status.LoadBalancerIPs = nil
+	if instance.Spec.ManagementAPIServerIngress.StaticIP != nil && capabilities.SupportsStaticIP {
+		desired := found.DeepCopy()
+		ips, err := cloudClient.EnsureAdminAPIStaticIPs(ctx, r.client, instance, desired)
+		if err != nil {
+			reqLogger.Error(err, "Couldn't ensure static IPs for the Service")
+			r.setCloudErrorStatus(instance, "Couldn't reconcile", "Couldn't ensure static IPs", err)
+			return utils.CloudErrorResult(err)
+		}
+		if staticIPsChanged(found, desired) {
+			reqLogger.Info(fmt.Sprintf("Static IPs for %s/service/%s changed. Recreating...", found.GetNamespace(), found.GetName()))
+			err = r.client.Delete(ctx, found)
+			if err != nil {
+				reqLogger.Error(err, "Error deleting service to attach static IPs")
+				return reconcile.Result{}, err
+			}
+			return RequeueIntervals.ErrorResult(), nil
+		}
+		instance.Status.LoadBalancerIPs = ips
+	} else if found.Annotations[eipAllocationsAnnotationKey] != "" && capabilities.SupportsStaticIP {
+		reqLogger.Info(fmt.Sprintf("Static IPs for %s/service/%s were removed. Recreating...", found.GetNamespace(), found.GetName()))
+		err = r.client.Delete(ctx, found)
+		if err != nil {
+			reqLogger.Error(err, "Error deleting service to detach static IPs")
+			return reconcile.Result{}, err
+		}
+		return RequeueIntervals.ErrorResult(), nil
+	}
+	// Elastic IPs the operator allocated are released once the APIScheme no
+	// longer asks for them and the load balancer they were attached to is gone
+	if staticIP := instance.Spec.ManagementAPIServerIngress.StaticIP; staticIP == nil || len(staticIP.AllocationIDs) > 0 {
+		if err = r.releaseStaticIPs(ctx, cloudClient, instance); err != nil {
+			reqLogger.Error(err, "Couldn't release the Elastic IPs the APIScheme no longer uses")
+			r.setCloudErrorStatus(instance, "Couldn't reconcile", "Couldn't release static IPs", err)
+			return utils.CloudErrorResult(err)
+		}
+	}
+
+	// Unlike static IPs, the security group of a classic ELB can be swapped in
+	// place. Its rules are re-applied on every reconcile
+	if capabilities.SupportsSGManagement {
+		desired := found.DeepCopy()
+		_, err = cloudClient.EnsureAdminAPISecurityGroup(ctx, r.client, instance, desired)
+		if err != nil {
+			reqLogger.Error(err, "Couldn't ensure the security group for the Service")
+			return r.securityGroupErrorResult(instance, err)
+		}
+		if !reflect.DeepEqual(desired.Annotations, found.Annotations) {
+			err = r.client.Update(ctx, desired)
+			if err != nil {
+				reqLogger.Error(err, "Error updating service annotation")
+				return reconcile.Result{}, err
+			}
+			reqLogger.Info(fmt.Sprintf("Updated %s svc security groups", found.Name))
+			return RequeueIntervals.ErrorResult(), nil
+		}
+	}
+
+	err = r.repairDriftIfDue(ctx, request.NamespacedName, instance, found, ingressConfig.Spec.LoadBalancerPolicy)
+	// Before the DNS records, which follow the IP address type
+	if err == nil {
+		err = cloudClient.EnsureAdminAPIIPAddressType(ctx, r.client, instance, found)
+	}
+	if err == nil && capabilities.SupportsAccessLogs {
+		err = cloudClient.EnsureAdminAPIAccessLogs(ctx, r.client, instance, found)
+	}
+	if err == nil && capabilities.SupportsAlarms {
+		err = cloudClient.EnsureAdminAPIAlarms(ctx, r.client, instance, found)
+	}
+	if err == nil && capabilities.SupportsTLSPolicy {
+		err = cloudClient.EnsureAdminAPITLSPolicy(ctx, r.client, instance, found)
+	}
+	if err == nil && capabilities.SupportsCertificate {
+		err = cloudClient.EnsureAdminAPICertificate(ctx, r.client, instance, found)
+	}
+	if err == nil && capabilities.SupportsStickiness {
+		err = cloudClient.EnsureAdminAPIStickiness(ctx, r.client, instance, found)
+	}
+	// The endpoint isn't published or Ready until the load balancer has an
+	// instance to send traffic to
+	if err == nil {
+		var healthy []string
+		healthy, err = cloudClient.GetAdminAPIHealthyInstances(ctx, r.client, instance, found)
+		if err == nil && len(healthy) == 0 {
+			reqLogger.Info("No instance behind the admin API load balancer is healthy yet")
+			r.SetAPISchemeStatus(instance, "Couldn't reconcile", "No instance behind the load balancer passes its health checks", cloudingressv1alpha1.ConditionError)
+			return reconcile.Result{Requeue: true, RequeueAfter: HealthyInstancesPollInterval}, nil
+		}
+	}
+	if err == nil {
+		err = r.ensureAdminAPIDNS(ctx, cloudClient, instance, found, ingressConfig, allowedCIDRBlocks, servicePorts)
+	}
+	if err == nil {
+		err = r.setLoadBalancerStatus(ctx, instance, found)
+	}
+	// Only once the load balancer exists, for new masters to find it
+	if err == nil {
+		err = cloudClient.EnsureAdminAPIMasterMachines(ctx, r.client, instance, found)
+	}
+	if err == nil && ingressConfig.Spec.DNSVerification != nil {
+		err = r.verifyDNS(ctx, instance, found, ingressConfig.Spec.DNSVerification, ingressConfig.Spec.Disconnected)
+	}
 	// Check for error types that this operator knows about
 	switch err := err.(type) {
 	case nil:
 		// no problems
+		instance.Status.Conditions = utils.SetAPISchemeCondition(
+			instance.Status.Conditions,
+			cloudingressv1alpha1.ConditionCleanupPending,
+			corev1.ConditionFalse,
+			"Success",
+			"Admin API Endpoint created",
+			utils.UpdateConditionNever)
+		instance.Status.Conditions = utils.SetAPISchemeCondition(
+			instance.Status.Conditions,
+			cloudingressv1alpha1.ConditionPaused,
+			corev1.ConditionFalse,
+			"Resumed",
+			"Cloud changes are made again",
+			utils.UpdateConditionNever)
+		instance.Status.Conditions = utils.SetAPISchemeCondition(
+			instance.Status.Conditions,
+			cloudingressv1alpha1.ConditionPlanned,
+			corev1.ConditionFalse,
+			"Managed",
+			"Changes are applied",
+			utils.UpdateConditionNever)
+		instance.Status.Conditions = utils.SetAPISchemeCondition(
+			instance.Status.Conditions,
+			cloudingressv1alpha1.ConditionPreconditionFailed,
+			corev1.ConditionFalse,
+			"PreconditionsMet",
+			"The cloud resources the admin API depends on allow its changes",
+			utils.UpdateConditionNever)
+		instance.Status.Conditions = utils.SetAPISchemeCondition(
+			instance.Status.Conditions,
+			cloudingressv1alpha1.ConditionPreflightFailed,
+			corev1.ConditionFalse,
+			"PermissionsGranted",
+			"The cloud credentials have every permission the operator needs",
+			utils.UpdateConditionNever)
+		instance.Status.Conditions = utils.SetAPISchemeCondition(
+			instance.Status.Conditions,
+			cloudingressv1alpha1.ConditionRejected,
+			corev1.ConditionFalse,
+			"Driving",
+			"This APIScheme drives the admin API load balancer",
+			utils.UpdateConditionNever)
+		instance.Status.Conditions = utils.SetAPISchemeCondition(
+			instance.Status.Conditions,
+			cloudingressv1alpha1.ConditionThrottled,
+			corev1.ConditionFalse,
+			"NotThrottled",
+			"The cloud API accepts the operator's calls",
+			utils.UpdateConditionNever)
+		instance.Status.Conditions = utils.SetAPISchemeCondition(
+			instance.Status.Conditions,
+			cloudingressv1alpha1.ConditionWaitingForDNSZone,
+			corev1.ConditionFalse,
+			"DNSZoneFound",
+			"The DNS zone of the admin API record exists",
+			utils.UpdateConditionNever)
+		instance.Status.Conditions = utils.SetAPISchemeCondition(
+			instance.Status.Conditions,
+			cloudingressv1alpha1.ConditionActionRequired,
+			corev1.ConditionFalse,
+			"NoActionRequired",
+			"Nothing has to be fixed outside the operator",
+			utils.UpdateConditionNever)
+		instance.Status.Conditions = utils.SetAPISchemeCondition(
+			instance.Status.Conditions,
+			cloudingressv1alpha1.ConditionSecurityGroupRulesReverted,
+			corev1.ConditionFalse,
+			"Applied",
+			"The admin API security group has the rules of every allowed CIDR block",
+			utils.UpdateConditionNever)
+		instance.Status.Drifted = nil
+		instance.Status.Plan = nil
+		utils.ReportObservedDrift("apischeme", instance.Namespace+"/"+instance.Name, nil)
+		if err := r.completeLoadBalancerRecovery(ctx, instance, found); err != nil {
+			reqLogger.Error(err, "Error updating service annotation")
+			return reconcile.Result{}, err
+		}
 		r.SetAPISchemeStatus(instance, "Success", "Admin API Endpoint created", cloudingressv1alpha1.ConditionReady)
-		return reconcile.Result{RequeueAfter: 60 * time.Second}, nil
+		r.setReconciledGeneration(request.NamespacedName, instance.Generation)
+		// Come back in time to revoke the next CIDR block to expire, or to
+		// make the admin API internal again, or public for the next
+		// maintenance window
+		requeueAfter := RequeueIntervals.SuccessResult().RequeueAfter
+		if nextExpiry != nil && (requeueAfter == 0 || time.Until(*nextExpiry) < requeueAfter) {
+			requeueAfter = time.Until(*nextExpiry)
+		}
+		if temporarilyPublic(instance, time.Now()) && (requeueAfter == 0 || time.Until(instance.Status.TemporaryPublicAccess.ExpiresAt.Time) < requeueAfter) {
+			requeueAfter = time.Until(instance.Status.TemporaryPublicAccess.ExpiresAt.Time)
+		}
+		if nextWindowChange != nil && (requeueAfter == 0 || time.Until(*nextWindowChange) < requeueAfter) {
+			requeueAfter = time.Until(*nextWindowChange)
+		}
+		return reconcile.Result{RequeueAfter: requeueAfter}, nil
 	case *cioerrors.DnsUpdateError:
 		// couldn't update DNS
-		r.SetAPISchemeStatus(instance, "Couldn't reconcile", "Couldn't ensure the admin API endpoint: "+err.Error(), cloudingressv1alpha1.ConditionError)
+		r.setCloudErrorStatus(instance, "Couldn't reconcile", "Couldn't ensure the admin API endpoint", err)
 		return reconcile.Result{}, err
 	case *cioerrors.LoadBalancerNotReadyError:
+		recovering, recoverErr := r.recoverDeletedLoadBalancer(ctx, cloudClient, instance, found)
+		if recoverErr != nil {
+			reqLogger.Error(recoverErr, "Couldn't recover the load balancer of the Service")
+			return utils.CloudErrorResult(recoverErr)
+		}
+		if recovering {
+			r.SetAPISchemeStatus(instance, "Couldn't reconcile", "Load balancer was deleted, waiting for the cloud provider to recreate it", cloudingressv1alpha1.ConditionError)
+			return RequeueIntervals.ErrorResult(), nil
+		}
 		r.SetAPISchemeStatus(instance, "Couldn't reconcile", "Load balancer isn't ready", cloudingressv1alpha1.ConditionError)
-		return reconcile.Result{Requeue: true, RequeueAfter: 10 * time.Second}, nil
+		return RequeueIntervals.ErrorResult(), nil
+	case *cioerrors.DNSZoneNotFoundError:
+		return r.waitForDNSZone(instance, err)
+	case *cioerrors.PreconditionFailedError:
+		// Retrying won't help until it's fixed outside the operator
+		reqLogger.Info("Precondition failed", "reason", err.Error())
+		instance.Status.Conditions = utils.SetAPISchemeCondition(
+			instance.Status.Conditions,
+			cloudingressv1alpha1.ConditionPreconditionFailed,
+			corev1.ConditionTrue,
+			"PreconditionFailed",
+			err.Error(),
+			utils.UpdateConditionNever)
+		r.SetAPISchemeStatus(instance, "PreconditionFailed", err.Error(), cloudingressv1alpha1.ConditionError)
+		return reconcile.Result{RequeueAfter: PreconditionRecheckInterval}, nil
+	case *cioerrors.ActionRequiredError:
+		// Retrying won't help until someone acts on it
+		reqLogger.Info("Action required", "reason", err.Reason, "problem", err.Error())
+		r.setActionRequiredStatus(instance, err.Reason, err.Error(), err.Remediation)
+		return reconcile.Result{RequeueAfter: PreconditionRecheckInterval}, nil
 	default:
 		// not one of ours
-		log.Error(err, "Error ensuring Admin API", "instance", instance, "Service", found)
-		return reconcile.Result{}, err
+		result, resultErr := utils.CloudErrorResult(err)
+		switch {
+		case cioerrors.IsThrottled(err):
+			reqLogger.Info("Cloud API call throttled, backing off", "reason", err.Error(), "retryAfter", result.RequeueAfter.String())
+			r.setThrottledStatus(instance, result.RequeueAfter)
+		case cioerrors.IsTransient(err):
+			reqLogger.Info("Cloud API call failed, retrying", "reason", err.Error())
+		case cioerrors.IsPermissionDenied(err):
+			r.setCloudErrorStatus(instance, "Missing permissions", "The cloud credentials don't allow ensuring the admin API endpoint", err)
+		default:
+			log.Error(err, "Error ensuring Admin API", "instance", instance, "Service", found)
+			r.setCloudErrorStatus(instance, "Couldn't reconcile", "Couldn't ensure the admin API endpoint", err)
+		}
+		return result, resultErr
 	}
 }
 
-func (r *ReconcileAPIScheme) newServiceFor(instance *cloudingressv1alpha1.APIScheme) *corev1.Service {
-	labels := map[string]string{
-		"app":          "cloud-ingress-operator-" + instance.Spec.ManagementAPIServerIngress.DNSName,
-		"apischeme_cr": instance.GetName(),
+// recoverDeletedLoadBalancer asks the cloud provider to create the load
+// balancer of svc again if it was provisioned, as the Service's status says,
+// but no longer exists, eg it was deleted by hand. The cloud provider
+// recreates it with the same name, registering the instances and applying
+// the security groups of the Service, and the rest of the reconcile points
+// the DNS records at it and puts back the settings the operator makes
+// itself. It's asked again every LoadBalancerRecoveryInterval until then.
+// Returns whether the load balancer is being recovered
+func (r *ReconcileAPIScheme) recoverDeletedLoadBalancer(ctx context.Context, cloudClient cloudclient.CloudClient, instance *cloudingressv1alpha1.APIScheme, svc *corev1.Service) (bool, error) {
+	// A load balancer that was never provisioned is still being created
+	if len(svc.Status.LoadBalancer.Ingress) == 0 {
+		return false, nil
 	}
-	selector := map[string]string{
-		"apiserver": "true",
-		"app":       "openshift-kube-apiserver",
+	exists, err := cloudClient.AdminAPILoadBalancerExists(ctx, r.client, instance, svc)
+	if err != nil || exists {
+		return false, err
 	}
-	annotations := map[string]string{
-		elbAnnotationKey: elbAnnotationValue,
+	if requested, err := time.Parse(time.RFC3339, svc.Annotations[lbRecoveryAnnotationKey]); err == nil && time.Since(requested) < LoadBalancerRecoveryInterval {
+		return true, nil
 	}
-	// Note: This owner reference should nbnot be expected to work
-	//ref := metav1.NewControllerRef(instance, instance.GetObjectKind().GroupVersionKind())
-	return &corev1.Service{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:        instance.Spec.ManagementAPIServerIngress.DNSName,
-			Namespace:   "openshift-kube-apiserver",
-			Labels:      labels,
-			Annotations: annotations,
-			//OwnerReferences: []metav1.OwnerReference{*ref},
-		},
-		Spec: corev1.ServiceSpec{
-			Ports: []corev1.ServicePort{
-				{
-					Protocol:   "TCP",
-					Port:       6443,
-					TargetPort: intstr.FromInt(6443),
-				},
-			},
-			Selector:                 selector,
-			Type:                     corev1.ServiceTypeLoadBalancer,
-			LoadBalancerSourceRanges: instance.Spec.ManagementAPIServerIngress.AllowedCIDRBlocks,
-		},
+	log.Info("The load balancer of the Service was deleted, asking the cloud provider to recreate it", "Request.Name", instance.Name, "service", svc.Name)
+	metav1.SetMetaDataAnnotation(&svc.ObjectMeta, lbRecoveryAnnotationKey, time.Now().UTC().Format(time.RFC3339))
+	if err = baseutils.RequestLoadBalancerResync(r.client, svc); err != nil {
+		return false, err
+	}
+	if r.recorder != nil {
+		r.recorder.Event(instance, corev1.EventTypeWarning, "LoadBalancerDeleted", fmt.Sprintf("The load balancer of %s/service/%s was deleted, recreating it", svc.GetNamespace(), svc.GetName()))
 	}
+	return true, nil
 }
 
-// SetAPISchemeStatus will set the status on the APISscheme object with a human message, as in an error situation
-func (r *ReconcileAPIScheme) SetAPISchemeStatus(crObject *cloudingressv1alpha1.APIScheme, reason, message string, ctype cloudingressv1alpha1.APISchemeConditionType) {
+// completeLoadBalancerRecovery records that the load balancer of svc, which
+// recoverDeletedLoadBalancer had asked the cloud provider to recreate, is
+// back and serving the admin API again
+func (r *ReconcileAPIScheme) completeLoadBalancerRecovery(ctx context.Context, instance *cloudingressv1alpha1.APIScheme, svc *corev1.Service) error {
+	if !metav1.HasAnnotation(svc.ObjectMeta, lbRecoveryAnnotationKey) {
+		return nil
+	}
+	delete(svc.Annotations, lbRecoveryAnnotationKey)
+	if err := r.client.Update(ctx, svc); err != nil {
+		return err
+	}
+	log.Info("Recovered the deleted load balancer of the Service", "Request.Name", instance.Name, "service", svc.Name)
+	if r.recorder != nil {
+		r.recorder.Event(instance, corev1.EventTypeNormal, "Recovered", fmt.Sprintf("Recreated the deleted load balancer of %s/service/%s", svc.GetNamespace(), svc.GetName()))
+	}
+	return nil
+}
+
+// ownedByAnotherAPIScheme checks if svc was created for an APIScheme other
+// than instance. A Service without the namespace label predates APISchemes in
+// other namespaces, so it's instance's if the name matches
+func ownedByAnotherAPIScheme(instance *cloudingressv1alpha1.APIScheme, svc *corev1.Service) bool {
+	if name, ok := svc.Labels[apiSchemeNameLabel]; ok && name != instance.GetName() {
+		return true
+	}
+	namespace, ok := svc.Labels[apiSchemeNamespaceLabel]
+	return ok && namespace != instance.GetNamespace()
+}
+
+// migrationServiceName returns the name of the Service whose network load
+// balancer shares the admin API DNS name during a migration
+func migrationServiceName(instance *cloudingressv1alpha1.APIScheme) string {
+	return instance.Spec.ManagementAPIServerIngress.DNSName + "-nlb"
+}
+
+// ensureAdminAPIDNS points the admin API DNS name at the load balancer of
+// found. While the APIScheme asks for a migration to a network load balancer,
+// a second Service with one is kept, and once it is healthy the name is
+// weighted between the two. Removing the migration from the spec points the
+// name back at found alone, then deletes the second Service
+func (r *ReconcileAPIScheme) ensureAdminAPIDNS(ctx context.Context, cloudClient cloudclient.CloudClient, instance *cloudingressv1alpha1.APIScheme, found *corev1.Service, ingressConfig *cloudingressv1alpha1.CloudIngressConfig, allowedCIDRBlocks []string, servicePorts []corev1.ServicePort) error {
+	reqLogger := log.WithValues("APIScheme", instance.GetName())
+	if instance.Spec.ManagementAPIServerIngress.DNSManagement == cloudingressv1alpha1.DNSManagementExternalDNS {
+		return r.ensureExternalDNSHostname(ctx, cloudClient, instance, found)
+	}
+	if _, ok := found.Annotations[externalDNSHostnameAnnotationKey]; ok {
+		// external-dns deletes the records it published once the annotation
+		// is gone, and drift repair publishes them again if it does so after
+		// the operator
+		reqLogger.Info(fmt.Sprintf("Taking the DNS records of %s/service/%s back from external-dns", found.GetNamespace(), found.GetName()))
+		delete(found.Annotations, externalDNSHostnameAnnotationKey)
+		if err := r.client.Update(ctx, found); err != nil {
+			return err
+		}
+	}
+	migration := instance.Spec.ManagementAPIServerIngress.NLBMigration
+	if migration != nil {
+		// Only AWS has classic load balancers to migrate away from
+		platform, err := baseutils.GetPlatformType(r.client)
+		if err != nil {
+			return err
+		}
+		if *platform != configv1.AWSPlatformType {
+			migration = nil
+		}
+	}
+
+	migrationSvc := &corev1.Service{}
+	err := r.client.Get(ctx, types.NamespacedName{Name: migrationServiceName(instance), Namespace: found.GetNamespace()}, migrationSvc)
+	if err != nil && !errors.IsNotFound(err) {
+		return err
+	}
+	migrationExists := err == nil
+
+	if migration == nil {
+		instance.Status.NLBMigration = nil
+		err = cloudClient.EnsureAdminAPIDNS(ctx, r.client, instance, found)
+		if err != nil || !migrationExists {
+			return err
+		}
+		// The simple records replaced the weighted ones, so the migration
+		// load balancer no longer receives any traffic
+		reqLogger.Info(fmt.Sprintf("The NLB migration is over. Deleting %s/service/%s...", migrationSvc.GetNamespace(), migrationSvc.GetName()))
+		if err = cloudClient.DeleteAdminAPIDNS(ctx, r.client, instance, migrationSvc); err != nil {
+			return err
+		}
+		if err = cloudClient.DeleteAdminAPILoadBalancer(ctx, r.client, instance, migrationSvc); err != nil {
+			return err
+		}
+		if err = r.client.Delete(ctx, migrationSvc); err != nil && !errors.IsNotFound(err) {
+			return err
+		}
+		return nil
+	}
+
+	desired := r.newServiceFor(instance, ingressConfig, allowedCIDRBlocks, servicePorts)
+	desired.Name = migrationServiceName(instance)
+	desired.Annotations[nlbTypeAnnotationKey] = "nlb"
+	if !migrationExists {
+		if err = r.checkLoadBalancerQuota(ctx, cloudClient, instance, true); err != nil {
+			return err
+		}
+		if err = cloudClient.ValidateAdminAPISubnets(ctx, r.client, instance.Spec.ManagementAPIServerIngress.SubnetIDs, hasInternalLoadBalancer(desired)); err != nil {
+			return err
+		}
+		reqLogger.Info(fmt.Sprintf("Creating %s/service/%s for the NLB migration", desired.GetNamespace(), desired.GetName()))
+		if err = utils.Apply(ctx, r.client, r.scheme, instance, desired); err != nil {
+			return err
+		}
+		migrationSvc = desired
+	} else if !sliceEquals(migrationSvc.Spec.LoadBalancerSourceRanges, allowedCIDRBlocks) || !annotationsMatch(migrationSvc.ObjectMeta, desired.Annotations) {
+		migrationSvc.Spec.LoadBalancerSourceRanges = allowedCIDRBlocks
+		for key, value := range desired.Annotations {
+			metav1.SetMetaDataAnnotation(&migrationSvc.ObjectMeta, key, value)
+		}
+		if err = r.client.Update(ctx, migrationSvc); err != nil {
+			return err
+		}
+	}
+
+	// Until the network load balancer has a healthy target, all the traffic
+	// stays on found
+	status := &cloudingressv1alpha1.NLBMigrationStatus{ServiceName: migrationSvc.GetName()}
+	instance.Status.NLBMigration = status
+	healthy, err := cloudClient.GetAdminAPIHealthyInstances(ctx, r.client, instance, migrationSvc)
+	if _, notReady := err.(*cioerrors.LoadBalancerNotReadyError); notReady || (err == nil && len(healthy) == 0) {
+		reqLogger.Info("The NLB migration load balancer isn't ready yet")
+		return cloudClient.EnsureAdminAPIDNS(ctx, r.client, instance, found)
+	}
+	if err != nil {
+		return err
+	}
+	if err = cloudClient.EnsureAdminAPIIPAddressType(ctx, r.client, instance, migrationSvc); err != nil {
+		return err
+	}
+	if migration.Routing == cloudingressv1alpha1.NLBRoutingFailover {
+		err = cloudClient.EnsureAdminAPIFailoverDNS(ctx, r.client, instance, found, migrationSvc)
+		if err != nil {
+			return err
+		}
+		status.Ready = true
+		return nil
+	}
+	err = cloudClient.EnsureAdminAPIWeightedDNS(ctx, r.client, instance, found, migrationSvc, migration.Weight)
+	if err != nil {
+		return err
+	}
+	status.Ready = true
+	status.Weight = migration.Weight
+	return nil
+}
+
+// ensureExternalDNSHostname annotates found with the admin API hostname, for
+// external-dns to publish its records instead of the operator. The records
+// the operator published before are deleted first, as external-dns doesn't
+// take over records it didn't create
+func (r *ReconcileAPIScheme) ensureExternalDNSHostname(ctx context.Context, cloudClient cloudclient.CloudClient, instance *cloudingressv1alpha1.APIScheme, found *corev1.Service) error {
+	baseDomain, err := baseutils.GetAdminAPIBaseDomain(r.client, instance)
+	if err != nil {
+		return err
+	}
+	hostname := instance.Spec.ManagementAPIServerIngress.DNSName + "." + baseDomain
+	current, annotated := found.Annotations[externalDNSHostnameAnnotationKey]
+	if current == hostname {
+		return nil
+	}
+	if !annotated {
+		log.Info(fmt.Sprintf("Handing the DNS records of %s/service/%s over to external-dns", found.GetNamespace(), found.GetName()))
+		if err = cloudClient.DeleteAdminAPIDNS(ctx, r.client, instance, found); err != nil {
+			return err
+		}
+	}
+	metav1.SetMetaDataAnnotation(&found.ObjectMeta, externalDNSHostnameAnnotationKey, hostname)
+	return r.client.Update(ctx, found)
+}
+
+func (r *ReconcileAPIScheme) newServiceFor(instance *cloudingressv1alpha1.APIScheme, ingressConfig *cloudingressv1alpha1.CloudIngressConfig, allowedCIDRBlocks []string, servicePorts []corev1.ServicePort) *corev1.Service {
+	labels := map[string]string{
+		"app":                   "cloud-ingress-operator-" + instance.Spec.ManagementAPIServerIngress.DNSName,
+		apiSchemeNameLabel:      instance.GetName(),
+		apiSchemeNamespaceLabel: instance.GetNamespace(),
+	}
+	selector := map[string]string{
+		"apiserver": "true",
+		"app":       "openshift-kube-apiserver",
+	}
+	annotations := map[string]string{
+		elbAnnotationKey: elbAnnotationValue,
+	}
+	if wantsNetworkLoadBalancer(instance, ingressConfig) {
+		annotations[nlbTypeAnnotationKey] = "nlb"
+	}
+	if instance.Spec.ManagementAPIServerIngress.IPAddressType == cloudingressv1alpha1.IPAddressTypeDualStack {
+		annotations[ipAddressTypeAnnotationKey] = string(cloudingressv1alpha1.IPAddressTypeDualStack)
+	}
+	// Each cloud provider ignores the other's annotation
+	if wantsInternalLoadBalancer(instance) {
+		annotations[awsInternalAnnotationKey] = "true"
+		annotations[gcpLoadBalancerTypeAnnotationKey] = "Internal"
+	}
+	if wantsBackendService(instance) {
+		annotations[gcpBackendServiceAnnotationKey] = "enabled"
+	}
+	for key, value := range healthCheckAnnotations(ingressConfig.Spec.HealthCheck) {
+		annotations[key] = value
+	}
+	if len(instance.Spec.ManagementAPIServerIngress.SubnetIDs) > 0 {
+		annotations[subnetsAnnotationKey] = strings.Join(instance.Spec.ManagementAPIServerIngress.SubnetIDs, ",")
+	}
+	if len(instance.Spec.ManagementAPIServerIngress.BackendNodeSelector) > 0 {
+		annotations[targetNodeLabelsAnnotationKey] = baseutils.FormatNodeLabels(instance.Spec.ManagementAPIServerIngress.BackendNodeSelector)
+	}
+	return &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        instance.Spec.ManagementAPIServerIngress.DNSName,
+			Namespace:   "openshift-kube-apiserver",
+			Labels:      labels,
+			Annotations: annotations,
+		},
+		Spec: corev1.ServiceSpec{
+			Ports:                    servicePorts,
+			Selector:                 selector,
+			Type:                     corev1.ServiceTypeLoadBalancer,
+			LoadBalancerSourceRanges: allowedCIDRBlocks,
+		},
+	}
+}
+
+// setCloudErrorStatus sets the Error condition of crObject to message followed
+// by err. A failure of a cloud API request is also emitted as a Warning Event,
+// both quoting the provider's error code and request ID
+func (r *ReconcileAPIScheme) setCloudErrorStatus(crObject *cloudingressv1alpha1.APIScheme, reason, message string, err error) {
+	message += ": " + cioerrors.Describe(err)
+	r.SetAPISchemeStatus(crObject, reason, message, cloudingressv1alpha1.ConditionError)
+	if _, _, ok := cioerrors.RequestID(err); ok && r.recorder != nil {
+		r.recorder.Event(crObject, corev1.EventTypeWarning, "CloudAPIFailure", message)
+	}
+}
+
+// SetAPISchemeStatus will set the status on the APISscheme object with a human message, as in an error situation
+func (r *ReconcileAPIScheme) SetAPISchemeStatus(crObject *cloudingressv1alpha1.APIScheme, reason, message string, ctype cloudingressv1alpha1.APISchemeConditionType) {
 	crObject.Status.Conditions = utils.SetAPISchemeCondition(
 		crObject.Status.Conditions,
 		ctype,
@@ -315,6 +1355,9 @@ func (r *ReconcileAPIScheme) SetAPISchemeStatus(crObject *cloudingressv1alpha1.A
 		message,
 		utils.UpdateConditionNever)
 	crObject.Status.State = ctype
+	if ctype != cloudingressv1alpha1.ConditionReady {
+		crObject.Status.APIEndpointVisibility = cloudingressv1alpha1.APIEndpointTransitioning
+	}
 
 	err := r.client.Status().Update(context.TODO(), crObject)
 	// TODO: Should we return an error here if this update fails?
@@ -323,6 +1366,1624 @@ func (r *ReconcileAPIScheme) SetAPISchemeStatus(crObject *cloudingressv1alpha1.A
 	}
 }
 
+// setThrottledStatus marks instance Throttled, with when its reconcile is
+// retried after backoff
+func (r *ReconcileAPIScheme) setThrottledStatus(instance *cloudingressv1alpha1.APIScheme, backoff time.Duration) {
+	instance.Status.Conditions = utils.SetAPISchemeCondition(
+		instance.Status.Conditions,
+		cloudingressv1alpha1.ConditionThrottled,
+		corev1.ConditionTrue,
+		"RateLimited",
+		fmt.Sprintf("The cloud API throttles the operator, retrying in %s at %s", backoff, time.Now().Add(backoff).UTC().Format(time.RFC3339)),
+		utils.UpdateConditionIfReasonOrMessageChange)
+	err := r.client.Status().Update(context.TODO(), instance)
+	if err != nil {
+		log.Error(err, "Error updating cr status")
+	}
+}
+
+// waitForDNSZone reconciles instance, whose admin API record can't be
+// published as its DNS zone, described by zoneErr, doesn't exist. During the
+// DNSZoneGracePeriod of a new cluster the zone is assumed to be on its way:
+// instance is only marked WaitingForDNSZone, and checked again after as long
+// as it has waited so far, up to MaxDNSZoneRetryDelay. After it, the APIScheme
+// fails, and is eventually Degraded
+func (r *ReconcileAPIScheme) waitForDNSZone(instance *cloudingressv1alpha1.APIScheme, zoneErr error) (reconcile.Result, error) {
+	infra, err := baseutils.GetInfrastructureObject(r.client)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	now := time.Now()
+	if now.Sub(infra.CreationTimestamp.Time) >= DNSZoneGracePeriod {
+		instance.Status.Conditions = utils.SetAPISchemeCondition(
+			instance.Status.Conditions,
+			cloudingressv1alpha1.ConditionWaitingForDNSZone,
+			corev1.ConditionFalse,
+			"GracePeriodOver",
+			"The cluster is too old for the DNS zone to still be on its way",
+			utils.UpdateConditionNever)
+		r.setActionRequiredStatus(instance, cloudingressv1alpha1.ActionRequiredDNSZoneMissing, zoneErr.Error(),
+			"create the DNS zone, or fix the base domain of the cluster's DNS config")
+		return reconcile.Result{}, zoneErr
+	}
+	log.Info("Waiting for the DNS zone of the admin API record", "instance", instance.Namespace+"/"+instance.Name, "reason", zoneErr.Error())
+	waitingSince := now
+	if waiting := utils.FindAPISchemeCondition(instance.Status.Conditions, cloudingressv1alpha1.ConditionWaitingForDNSZone); waiting != nil && waiting.Status == corev1.ConditionTrue {
+		waitingSince = waiting.LastTransitionTime.Time
+	}
+	r.SetAPISchemeStatus(instance, "DNSZoneNotFound", zoneErr.Error(), cloudingressv1alpha1.ConditionWaitingForDNSZone)
+	return reconcile.Result{RequeueAfter: dnsZoneRetryDelay(now.Sub(waitingSince))}, nil
+}
+
+// setActionRequiredStatus sets the ActionRequired condition of instance, with
+// the machine-readable reason and the remediation appended to the problem,
+// and its Error state
+func (r *ReconcileAPIScheme) setActionRequiredStatus(instance *cloudingressv1alpha1.APIScheme, reason, problem, remediation string) {
+	message := fmt.Sprintf("%s. To fix it: %s", problem, remediation)
+	instance.Status.Conditions = utils.SetAPISchemeCondition(
+		instance.Status.Conditions,
+		cloudingressv1alpha1.ConditionActionRequired,
+		corev1.ConditionTrue,
+		reason,
+		message,
+		utils.UpdateConditionIfReasonOrMessageChange)
+	r.SetAPISchemeStatus(instance, reason, message, cloudingressv1alpha1.ConditionError)
+}
+
+// checkLoadBalancerQuota checks the cloud's quota leaves room for the load
+// balancer of a Service about to be created, a network one or not. The usage
+// is reported in the QuotaNearExhaustion condition and metric, and an
+// ActionRequiredError returned if the quota is used up, rather than the cloud
+// provider failing to create it later. A quota that can't be read doesn't
+// stop the Service being created
+func (r *ReconcileAPIScheme) checkLoadBalancerQuota(ctx context.Context, cloudClient cloudclient.CloudClient, instance *cloudingressv1alpha1.APIScheme, network bool) error {
+	reqLogger := log.WithValues("Request.Namespace", instance.Namespace, "Request.Name", instance.Name)
+	used, limit, err := cloudClient.GetLoadBalancerQuota(ctx, r.client, network)
+	if err != nil {
+		reqLogger.Error(err, "Couldn't read the load balancer quota. Creating the load balancer anyway")
+		return nil
+	}
+	if limit <= 0 {
+		return nil
+	}
+	kind := "classic"
+	if network {
+		kind = "network"
+	}
+	localmetrics.MetricLoadBalancerQuotaUsage.WithLabelValues(kind).Set(float64(used) / float64(limit))
+	message := fmt.Sprintf("%d of the %d load balancers the quota allows are in use", used, limit)
+	if used >= limit {
+		return cioerrors.NewActionRequiredError(cloudingressv1alpha1.ActionRequiredLoadBalancerQuota,
+			fmt.Sprintf("The admin API load balancer can't be created: %s", message),
+			"raise the cloud's load balancer quota, or delete unused load balancers")
+	}
+	if float64(used)/float64(limit) >= QuotaNearExhaustionRatio {
+		reqLogger.Info("The load balancer quota is nearly used up", "used", used, "limit", limit)
+		instance.Status.Conditions = utils.SetAPISchemeCondition(
+			instance.Status.Conditions,
+			cloudingressv1alpha1.ConditionQuotaNearExhaustion,
+			corev1.ConditionTrue,
+			"QuotaNearExhaustion",
+			message,
+			utils.UpdateConditionIfReasonOrMessageChange)
+		return nil
+	}
+	instance.Status.Conditions = utils.SetAPISchemeCondition(
+		instance.Status.Conditions,
+		cloudingressv1alpha1.ConditionQuotaNearExhaustion,
+		corev1.ConditionFalse,
+		"QuotaAvailable",
+		message,
+		utils.UpdateConditionIfReasonOrMessageChange)
+	return nil
+}
+
+// subnetsErrorResult reports err, from validating the subnets listed for the
+// admin API load balancer, in the status of instance, and returns the result
+// to reconcile it with. Subnets that don't suit the load balancer's scheme
+// wait for the spec or the subnets to be fixed
+func (r *ReconcileAPIScheme) subnetsErrorResult(instance *cloudingressv1alpha1.APIScheme, err error) (reconcile.Result, error) {
+	if err, ok := err.(*cioerrors.ActionRequiredError); ok {
+		log.Info("The subnets don't suit the admin API load balancer", "Request.Namespace", instance.Namespace, "Request.Name", instance.Name, "problem", err.Error())
+		r.setActionRequiredStatus(instance, err.Reason, err.Error(), err.Remediation)
+		return reconcile.Result{RequeueAfter: PreconditionRecheckInterval}, nil
+	}
+	r.setCloudErrorStatus(instance, "Couldn't reconcile", "Couldn't validate the subnets", err)
+	return utils.CloudErrorResult(err)
+}
+
+// securityGroupErrorResult reports err, from ensuring the admin API security
+// group, in the status of instance, and returns the result to reconcile it
+// with
+func (r *ReconcileAPIScheme) securityGroupErrorResult(instance *cloudingressv1alpha1.APIScheme, err error) (reconcile.Result, error) {
+	switch err := err.(type) {
+	case *cioerrors.ActionRequiredError:
+		r.setActionRequiredStatus(instance, err.Reason, err.Error(), err.Remediation)
+		return reconcile.Result{RequeueAfter: PreconditionRecheckInterval}, nil
+	case *cioerrors.SecurityGroupRulesRevertedError:
+		reason := "Reverted"
+		if !err.Reverted {
+			reason = "PartiallyApplied"
+		}
+		instance.Status.Conditions = utils.SetAPISchemeCondition(
+			instance.Status.Conditions,
+			cloudingressv1alpha1.ConditionSecurityGroupRulesReverted,
+			corev1.ConditionTrue,
+			reason,
+			err.Error(),
+			utils.UpdateConditionIfReasonOrMessageChange)
+		r.SetAPISchemeStatus(instance, "Couldn't reconcile", err.Error(), cloudingressv1alpha1.ConditionError)
+		return utils.CloudErrorResult(err.Cause)
+	}
+	r.setCloudErrorStatus(instance, "Couldn't reconcile", "Couldn't ensure the security group", err)
+	return utils.CloudErrorResult(err)
+}
+
+// dnsZoneRetryDelay returns how long to wait for a missing DNS zone that has
+// been waited for for waited: as long again, from RequeueIntervals.Error up
+// to MaxDNSZoneRetryDelay
+func dnsZoneRetryDelay(waited time.Duration) time.Duration {
+	if waited < RequeueIntervals.Error {
+		return RequeueIntervals.Error
+	}
+	if waited > MaxDNSZoneRetryDelay {
+		return MaxDNSZoneRetryDelay
+	}
+	return waited
+}
+
+// reconciledGeneration returns the generation of the last successful
+// reconcile of the APIScheme name, or 0 if there was none
+func (r *ReconcileAPIScheme) reconciledGeneration(name types.NamespacedName) int64 {
+	r.driftMu.Lock()
+	defer r.driftMu.Unlock()
+	return r.lastReconciledGeneration[name]
+}
+
+// setReconciledGeneration records generation as that of the last successful
+// reconcile of the APIScheme name
+func (r *ReconcileAPIScheme) setReconciledGeneration(name types.NamespacedName, generation int64) {
+	r.driftMu.Lock()
+	defer r.driftMu.Unlock()
+	if r.lastReconciledGeneration == nil {
+		r.lastReconciledGeneration = make(map[types.NamespacedName]int64)
+	}
+	r.lastReconciledGeneration[name] = generation
+}
+
+// recordFailures updates the failure streak of instance with the outcome of a
+// reconcile, which failed if it returned reconcileErr or left instance in the
+// Error state. Once the streak reaches utils.DegradedAfterFailures, instance
+// is marked Degraded with the errors of the streak
+func (r *ReconcileAPIScheme) recordFailures(instance *cloudingressv1alpha1.APIScheme, reconcileErr error) {
+	// Neither is reconciled any further
+	if !instance.Spec.ManagementAPIServerIngress.Enabled || !instance.DeletionTimestamp.IsZero() {
+		return
+	}
+	message := ""
+	if reconcileErr != nil {
+		message = cioerrors.Describe(reconcileErr)
+	} else if instance.Status.State == cloudingressv1alpha1.ConditionError {
+		message = "reconcile failed"
+		if condition := utils.FindAPISchemeCondition(instance.Status.Conditions, cloudingressv1alpha1.ConditionError); condition != nil {
+			message = condition.Message
+		}
+	}
+	failures := utils.UpdateReconcileFailures(instance.Status.Failures, message, metav1.Now())
+	utils.ReportReconcileFailures("apischeme", instance.Namespace+"/"+instance.Name, failures)
+	degraded := utils.FindAPISchemeCondition(instance.Status.Conditions, cloudingressv1alpha1.ConditionDegraded)
+	if failures == nil && instance.Status.Failures == nil && (degraded == nil || degraded.Status != corev1.ConditionTrue) {
+		return
+	}
+
+	instance.Status.Failures = failures
+	if failures != nil && failures.Degraded {
+		instance.Status.Conditions = utils.SetAPISchemeCondition(
+			instance.Status.Conditions,
+			cloudingressv1alpha1.ConditionDegraded,
+			corev1.ConditionTrue,
+			"ReconcileFailing",
+			utils.DegradedMessage(failures),
+			utils.UpdateConditionIfReasonOrMessageChange)
+	} else {
+		instance.Status.Conditions = utils.SetAPISchemeCondition(
+			instance.Status.Conditions,
+			cloudingressv1alpha1.ConditionDegraded,
+			corev1.ConditionFalse,
+			"AsExpected",
+			"",
+			utils.UpdateConditionNever)
+	}
+	err := r.client.Status().Update(context.TODO(), instance)
+	if err != nil && !errors.IsNotFound(err) {
+		log.Error(err, "Error updating cr status")
+	}
+}
+
+// reportPaused reconciles a paused APIScheme. No cloud change is made, but the
+// admin API cloud resources are still checked for drift, which is recorded in
+// the status of instance instead of being repaired
+func (r *ReconcileAPIScheme) reportPaused(ctx context.Context, instance *cloudingressv1alpha1.APIScheme, serviceNamespacedName types.NamespacedName) (reconcile.Result, error) {
+	message := "Cloud changes are paused"
+	instance.Status.Drifted = nil
+	found := &corev1.Service{}
+	err := r.client.Get(ctx, serviceNamespacedName, found)
+	switch {
+	case errors.IsNotFound(err):
+		message += ", the admin API Service doesn't exist"
+	case err != nil:
+		return reconcile.Result{}, err
+	default:
+		drifted, err := getCloudClient().CheckAdminAPIDrift(ctx, r.client, instance, found)
+		if err != nil {
+			// eg the load balancer isn't ready yet
+			message += ", couldn't check for drift: " + cioerrors.Describe(err)
+		} else if len(drifted) > 0 {
+			instance.Status.Drifted = drifted
+			message += ", drifted: " + strings.Join(drifted, ", ")
+		}
+	}
+	utils.ReportObservedDrift("apischeme", instance.Namespace+"/"+instance.Name, instance.Status.Drifted)
+	instance.Status.Conditions = utils.SetAPISchemeCondition(
+		instance.Status.Conditions,
+		cloudingressv1alpha1.ConditionPaused,
+		corev1.ConditionTrue,
+		"Paused",
+		message,
+		utils.UpdateConditionIfReasonOrMessageChange)
+	instance.Status.State = cloudingressv1alpha1.ConditionPaused
+	err = r.client.Status().Update(ctx, instance)
+	if err != nil {
+		log.Error(err, "Error updating cr status")
+		return reconcile.Result{}, err
+	}
+	return reconcile.Result{RequeueAfter: RequeueIntervals.DriftScan}, nil
+}
+
+// getDrivingAPIScheme returns the APIScheme driving the admin API load
+// balancer, or nil if there's none
+func (r *ReconcileAPIScheme) getDrivingAPIScheme() (*cloudingressv1alpha1.APIScheme, error) {
+	apiSchemeList := &cloudingressv1alpha1.APISchemeList{}
+	err := r.client.List(context.TODO(), apiSchemeList)
+	if err != nil {
+		return nil, err
+	}
+	return drivingAPIScheme(apiSchemeList.Items), nil
+}
+
+// drivingAPIScheme returns which of apiSchemes drives the admin API load
+// balancer: the oldest enabled one, by namespace and name when they were
+// created at the same time, so that the choice never flips between
+// reconciles. One being deleted keeps driving it until its cloud resources
+// are cleaned up. Returns nil if none is enabled
+func drivingAPIScheme(apiSchemes []cloudingressv1alpha1.APIScheme) *cloudingressv1alpha1.APIScheme {
+	var driving *cloudingressv1alpha1.APIScheme
+	for i := range apiSchemes {
+		candidate := &apiSchemes[i]
+		if !candidate.Spec.ManagementAPIServerIngress.Enabled {
+			continue
+		}
+		if driving == nil || candidate.CreationTimestamp.Before(&driving.CreationTimestamp) ||
+			candidate.CreationTimestamp.Equal(&driving.CreationTimestamp) &&
+				candidate.Namespace+"/"+candidate.Name < driving.Namespace+"/"+driving.Name {
+			driving = candidate
+		}
+	}
+	return driving
+}
+
+// reject reconciles an APIScheme that doesn't drive the admin API load
+// balancer because driving does. It's marked Rejected, and makes no cloud
+// change. Deleting it only removes its finalizer, which it may have from
+// before driving was created, as the cloud resources are driving's
+func (r *ReconcileAPIScheme) reject(instance *cloudingressv1alpha1.APIScheme, driving *cloudingressv1alpha1.APIScheme) (reconcile.Result, error) {
+	if !instance.DeletionTimestamp.IsZero() {
+		if controllerutil.ContainsFinalizer(instance, reconcileFinalizerDNS) {
+			controllerutil.RemoveFinalizer(instance, reconcileFinalizerDNS)
+			if err := r.client.Update(context.TODO(), instance); err != nil {
+				return reconcile.Result{}, err
+			}
+		}
+		return reconcile.Result{}, nil
+	}
+	instance.Status.Conditions = utils.SetAPISchemeCondition(
+		instance.Status.Conditions,
+		cloudingressv1alpha1.ConditionRejected,
+		corev1.ConditionTrue,
+		"AnotherAPISchemeDrives",
+		fmt.Sprintf("The APIScheme %s/%s drives the admin API load balancer, only one APIScheme may per cluster", driving.Namespace, driving.Name),
+		utils.UpdateConditionIfReasonOrMessageChange)
+	instance.Status.State = cloudingressv1alpha1.ConditionRejected
+	err := r.client.Status().Update(context.TODO(), instance)
+	if err != nil {
+		log.Error(err, "Error updating cr status")
+		return reconcile.Result{}, err
+	}
+	return reconcile.Result{}, nil
+}
+
+// reportHostedControlPlane reconciles an APIScheme of a cluster whose control
+// plane is hosted outside of it. It's marked Unsupported, and makes no cloud
+// change. Deleting it only removes its finalizer, which it may have from
+// before the cluster's topology was known
+func (r *ReconcileAPIScheme) reportHostedControlPlane(instance *cloudingressv1alpha1.APIScheme) (reconcile.Result, error) {
+	if !instance.DeletionTimestamp.IsZero() {
+		if controllerutil.ContainsFinalizer(instance, reconcileFinalizerDNS) {
+			controllerutil.RemoveFinalizer(instance, reconcileFinalizerDNS)
+			if err := r.client.Update(context.TODO(), instance); err != nil {
+				return reconcile.Result{}, err
+			}
+		}
+		return reconcile.Result{}, nil
+	}
+	instance.Status.Conditions = utils.SetAPISchemeCondition(
+		instance.Status.Conditions,
+		cloudingressv1alpha1.ConditionUnsupported,
+		corev1.ConditionTrue,
+		"HostedControlPlane",
+		"The cluster's control plane is hosted outside of it, and so is its admin API endpoint",
+		utils.UpdateConditionIfReasonOrMessageChange)
+	instance.Status.State = cloudingressv1alpha1.ConditionUnsupported
+	err := r.client.Status().Update(context.TODO(), instance)
+	if err != nil {
+		log.Error(err, "Error updating cr status")
+		return reconcile.Result{}, err
+	}
+	return reconcile.Result{}, nil
+}
+
+// unsupportedFields returns the fields of the spec of instance that ask for
+// what the cloud of capabilities can't do
+func unsupportedFields(capabilities cloudclient.Capabilities, instance *cloudingressv1alpha1.APIScheme) []string {
+	spec := instance.Spec.ManagementAPIServerIngress
+	fields := []string{}
+	if spec.StaticIP != nil && !capabilities.SupportsStaticIP {
+		fields = append(fields, "staticIP")
+	}
+	if spec.AccessLogs != nil && !capabilities.SupportsAccessLogs {
+		fields = append(fields, "accessLogs")
+	}
+	if spec.Alarms != nil && !capabilities.SupportsAlarms {
+		fields = append(fields, "alarms")
+	}
+	if spec.TLSSecurityPolicy != "" && !capabilities.SupportsTLSPolicy {
+		fields = append(fields, "tlsSecurityPolicy")
+	}
+	if spec.CertificateARN != "" && !capabilities.SupportsCertificate {
+		fields = append(fields, "certificateARN")
+	}
+	if spec.Stickiness != nil && !capabilities.SupportsStickiness {
+		fields = append(fields, "stickiness")
+	}
+	if len(spec.BackendNodeSelector) > 0 && !capabilities.SupportsBackendNodeSelector {
+		fields = append(fields, "backendNodeSelector")
+	}
+	if spec.Backend != "" && !capabilities.SupportsBackendSelection {
+		fields = append(fields, "backend")
+	}
+	if spec.DNSTTLSeconds != 0 && !capabilities.SupportsDNSTTL {
+		fields = append(fields, "dnsTTLSeconds")
+	}
+	return fields
+}
+
+// setUnsupportedFieldsCondition sets the Unsupported condition of instance
+// while its spec has fields the cloud of platform can't honor, which are
+// ignored. It's saved with the next status update
+func setUnsupportedFieldsCondition(instance *cloudingressv1alpha1.APIScheme, platform configv1.PlatformType, fields []string) {
+	status := corev1.ConditionFalse
+	reason := "Supported"
+	message := fmt.Sprintf("%s supports every field of the spec", platform)
+	if len(fields) > 0 {
+		status = corev1.ConditionTrue
+		reason = "UnsupportedFields"
+		message = fmt.Sprintf("%s doesn't support %s, they're ignored", platform, strings.Join(fields, ", "))
+	}
+	instance.Status.Conditions = utils.SetAPISchemeCondition(
+		instance.Status.Conditions,
+		cloudingressv1alpha1.ConditionUnsupported,
+		status,
+		reason,
+		message,
+		utils.UpdateConditionIfReasonOrMessageChange)
+}
+
+// reportPreflightFailed reconciles an APIScheme while the cloud credentials
+// lack the permissions missing. It's marked PreflightFailed, and makes no
+// cloud change until they're granted
+func (r *ReconcileAPIScheme) reportPreflightFailed(instance *cloudingressv1alpha1.APIScheme, missing []string) (reconcile.Result, error) {
+	message := fmt.Sprintf("The cloud credentials lack the permissions %s", strings.Join(missing, ", "))
+	instance.Status.Conditions = utils.SetAPISchemeCondition(
+		instance.Status.Conditions,
+		cloudingressv1alpha1.ConditionPreflightFailed,
+		corev1.ConditionTrue,
+		"MissingPermissions",
+		message,
+		utils.UpdateConditionIfReasonOrMessageChange)
+	r.SetAPISchemeStatus(instance, "PreflightFailed", message, cloudingressv1alpha1.ConditionError)
+	return reconcile.Result{RequeueAfter: PreconditionRecheckInterval}, nil
+}
+
+// reportPlan reconciles an APIScheme in the Plan management state, or any
+// APIScheme in observer mode. The changes Managed would make are recorded in
+// the status of instance, and none is made
+func (r *ReconcileAPIScheme) reportPlan(ctx context.Context, instance *cloudingressv1alpha1.APIScheme, serviceNamespacedName types.NamespacedName) (reconcile.Result, error) {
+	drifted, plan, checkErr, err := r.plan(ctx, getCloudClient(), instance, serviceNamespacedName)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	instance.Status.Drifted = drifted
+	instance.Status.Plan = plan
+	utils.ReportObservedDrift("apischeme", instance.Namespace+"/"+instance.Name, drifted)
+	message := fmt.Sprintf("%d changes planned", len(instance.Status.Plan))
+	if checkErr != nil {
+		message = "Couldn't check the cloud resources for drift: " + cioerrors.Describe(checkErr)
+	}
+	instance.Status.Conditions = utils.SetAPISchemeCondition(
+		instance.Status.Conditions,
+		cloudingressv1alpha1.ConditionPlanned,
+		corev1.ConditionTrue,
+		"Planned",
+		message,
+		utils.UpdateConditionIfReasonOrMessageChange)
+	instance.Status.State = cloudingressv1alpha1.ConditionPlanned
+	err = r.client.Status().Update(ctx, instance)
+	if err != nil {
+		log.Error(err, "Error updating cr status")
+		return reconcile.Result{}, err
+	}
+	return reconcile.Result{RequeueAfter: RequeueIntervals.DriftScan}, nil
+}
+
+// plan returns the cloud resources behind the admin API of instance found
+// drifted, and the changes reconciling it in the Managed state would make, see
+// planChanges. checkErr is why the cloud resources couldn't be checked for
+// drift, eg as the load balancer isn't ready yet, in which case the plan leaves
+// them out
+func (r *ReconcileAPIScheme) plan(ctx context.Context, cli cloudclient.CloudClient, instance *cloudingressv1alpha1.APIScheme, serviceNamespacedName types.NamespacedName) (drifted []string, plan []string, checkErr error, err error) {
+	ingressConfig, err := baseutils.GetCloudIngressConfig(r.client)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	found := &corev1.Service{}
+	err = r.client.Get(ctx, serviceNamespacedName, found)
+	switch {
+	case errors.IsNotFound(err):
+		found = nil
+	case err != nil:
+		return nil, nil, nil, err
+	default:
+		drifted, checkErr = cli.CheckAdminAPIDrift(ctx, r.client, instance, found)
+	}
+	allowedCIDRBlocks, _, err := baseutils.GetEffectiveAllowedCIDRBlocks(r.client, instance, time.Now())
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	servicePorts, err := adminAPIServicePorts(r.client, instance)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	desired := r.newServiceFor(instance, ingressConfig, allowedCIDRBlocks, servicePorts)
+	return drifted, planChanges(instance, desired, found, drifted), checkErr, nil
+}
+
+// Verify returns the changes reconciling instance would make, as its Plan
+// management state would record them, without making any or changing its
+// status. It fails if its cloud resources can't be checked for drift, as they
+// can't be verified then. A disabled APIScheme has no changes
+func Verify(ctx context.Context, kclient client.Client, cli cloudclient.CloudClient, instance *cloudingressv1alpha1.APIScheme) ([]string, error) {
+	if !instance.Spec.ManagementAPIServerIngress.Enabled {
+		return []string{}, nil
+	}
+	r := &ReconcileAPIScheme{client: kclient}
+	serviceNamespacedName := types.NamespacedName{
+		Name:      instance.Spec.ManagementAPIServerIngress.DNSName,
+		Namespace: "openshift-kube-apiserver",
+	}
+	_, plan, checkErr, err := r.plan(ctx, cli, instance, serviceNamespacedName)
+	if err != nil {
+		return nil, err
+	}
+	if checkErr != nil {
+		return plan, fmt.Errorf("couldn't check the cloud resources for drift: %s", cioerrors.Describe(checkErr))
+	}
+	return plan, nil
+}
+
+// planChanges lists the changes reconciling instance would make, given the
+// admin API Service found, or nil if it doesn't exist, the Service desired
+// for instance, and the cloud resources found drifted. Changes that can only
+// be worked out by making them, like the rules of the security group, aren't
+// listed
+func planChanges(instance *cloudingressv1alpha1.APIScheme, desired, found *corev1.Service, drifted []string) []string {
+	ingress := instance.Spec.ManagementAPIServerIngress
+	service := desired.Namespace + "/service/" + desired.Name
+	plan := []string{}
+	if found == nil {
+		if ingress.StaticIP != nil && len(ingress.StaticIP.AllocationIDs) == 0 {
+			plan = append(plan, "allocate static IPs")
+		}
+		plan = append(plan,
+			"create security group",
+			"create "+service+" and its load balancer",
+			"create DNS record "+ingress.DNSName)
+		return plan
+	}
+	if !sliceEquals(found.Spec.LoadBalancerSourceRanges, desired.Spec.LoadBalancerSourceRanges) {
+		plan = append(plan, fmt.Sprintf("set the allowed CIDR blocks of %s to %s", service, strings.Join(desired.Spec.LoadBalancerSourceRanges, ", ")))
+	}
+	if len(found.Spec.Ports) > 0 && len(desired.Spec.Ports) > 0 && !servicePortsMatch(found.Spec.Ports, desired.Spec.Ports) {
+		plan = append(plan, fmt.Sprintf("set the ports of %s to %s", service, describeServicePorts(desired.Spec.Ports)))
+	}
+	if desired.Annotations[nlbTypeAnnotationKey] == "nlb" && found.Annotations[nlbTypeAnnotationKey] != "nlb" {
+		plan = append(plan, "recreate "+service+" with a network load balancer")
+	}
+	if hasInternalLoadBalancer(desired) != hasInternalLoadBalancer(found) {
+		plan = append(plan, fmt.Sprintf("recreate %s with a load balancer with the %s scheme", service, loadBalancerScheme(instance)))
+	}
+	recreated := map[string]bool{
+		nlbTypeAnnotationKey:             true,
+		awsInternalAnnotationKey:         true,
+		gcpLoadBalancerTypeAnnotationKey: true,
+	}
+	keys := make([]string, 0, len(desired.Annotations))
+	for key := range desired.Annotations {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		if !recreated[key] && found.Annotations[key] != desired.Annotations[key] {
+			plan = append(plan, fmt.Sprintf("set annotation %s=%s on %s", key, desired.Annotations[key], service))
+		}
+	}
+	for _, component := range drifted {
+		plan = append(plan, "repair drifted "+component)
+	}
+	return plan
+}
+
+// adopt rewrites the spec of instance to match its admin API Service, as found
+// in the cluster, and removes the AdoptAnnotation. Without a Service, the
+// spec is left as it is. Nothing is changed in the cloud, the next reconcile
+// finds the Service already matching. The adopted endpoint was serving
+// already, so it's marked Ready, and repaired rather than rolled back from then
+// on
+func (r *ReconcileAPIScheme) adopt(ctx context.Context, instance *cloudingressv1alpha1.APIScheme) (reconcile.Result, error) {
+	reqLogger := log.WithValues("Request.Namespace", instance.Namespace, "Request.Name", instance.Name)
+	dnsName := instance.Spec.ManagementAPIServerIngress.DNSName
+	if dnsName == "" {
+		dnsName = defaultAdminAPIDNSName
+	}
+	svc := &corev1.Service{}
+	err := r.client.Get(ctx, types.NamespacedName{Name: dnsName, Namespace: "openshift-kube-apiserver"}, svc)
+	if err != nil && !errors.IsNotFound(err) {
+		return reconcile.Result{}, err
+	}
+	found := err == nil
+	if found && ownedByAnotherAPIScheme(instance, svc) {
+		// The other APIScheme's spec already matches it
+		found = false
+	}
+	if found {
+		reqLogger.Info(fmt.Sprintf("Adopting %s/service/%s", svc.GetNamespace(), svc.GetName()))
+		adoptSpec(instance, svc)
+	} else {
+		reqLogger.Info(fmt.Sprintf("Nothing to adopt, openshift-kube-apiserver/service/%s doesn't exist", dnsName))
+	}
+	delete(instance.Annotations, cloudingressv1alpha1.AdoptAnnotation)
+	if err = r.client.Update(ctx, instance); err != nil {
+		return reconcile.Result{}, err
+	}
+	if !found {
+		return reconcile.Result{Requeue: true}, nil
+	}
+
+	if err = r.setLoadBalancerStatus(ctx, instance, svc); err != nil {
+		reqLogger.Info("Couldn't get the load balancer of the adopted Service", "reason", err.Error())
+	}
+	instance.Status.Conditions = utils.SetAPISchemeCondition(
+		instance.Status.Conditions,
+		cloudingressv1alpha1.ConditionReady,
+		corev1.ConditionTrue,
+		"Adopted",
+		fmt.Sprintf("Adopted %s/service/%s", svc.GetNamespace(), svc.GetName()),
+		utils.UpdateConditionNever)
+	instance.Status.State = cloudingressv1alpha1.ConditionReady
+	if r.recorder != nil {
+		r.recorder.Event(instance, corev1.EventTypeNormal, "Adopted", fmt.Sprintf("Adopted %s/service/%s", svc.GetNamespace(), svc.GetName()))
+	}
+	if err = r.client.Status().Update(ctx, instance); err != nil {
+		reqLogger.Error(err, "Error updating cr status")
+		return reconcile.Result{}, err
+	}
+	return reconcile.Result{Requeue: true}, nil
+}
+
+// adoptSpec sets the ManagementAPIServerIngress of instance to what svc, an
+// existing admin API Service, was created with: its name, source ranges,
+// listeners, scheme, IP address type, subnets and Elastic IPs. The cloud
+// provider derives the security group rules of the load balancer from the
+// source ranges. Everything else in the spec is kept
+func adoptSpec(instance *cloudingressv1alpha1.APIScheme, svc *corev1.Service) {
+	ingress := &instance.Spec.ManagementAPIServerIngress
+	ingress.Enabled = true
+	ingress.DNSName = svc.GetName()
+	ingress.AllowedCIDRBlocks = append([]string{}, svc.Spec.LoadBalancerSourceRanges...)
+	// Only the ports of listeners in a spec are named
+	ingress.Listeners = nil
+	for _, port := range svc.Spec.Ports {
+		if strings.HasPrefix(port.Name, "api-") {
+			ingress.Listeners = append(ingress.Listeners, cloudingressv1alpha1.APIListener{Port: port.Port, TargetPort: int32(port.TargetPort.IntValue())})
+		}
+	}
+	ingress.Scheme = cloudingressv1alpha1.LoadBalancerSchemeInternetFacing
+	if hasInternalLoadBalancer(svc) {
+		ingress.Scheme = cloudingressv1alpha1.LoadBalancerSchemeInternal
+	}
+	ingress.IPAddressType = cloudingressv1alpha1.IPAddressTypeIPv4
+	if svc.Annotations[ipAddressTypeAnnotationKey] == string(cloudingressv1alpha1.IPAddressTypeDualStack) {
+		ingress.IPAddressType = cloudingressv1alpha1.IPAddressTypeDualStack
+	}
+	ingress.SubnetIDs = nil
+	if subnets := svc.Annotations[subnetsAnnotationKey]; subnets != "" {
+		ingress.SubnetIDs = strings.Split(subnets, ",")
+	}
+	ingress.BackendNodeSelector = nil
+	if labels := svc.Annotations[targetNodeLabelsAnnotationKey]; labels != "" {
+		ingress.BackendNodeSelector = baseutils.ParseNodeLabels(labels)
+	}
+	// Given as pre-allocated, the addresses are never released by the
+	// operator
+	ingress.StaticIP = nil
+	if allocations := svc.Annotations[eipAllocationsAnnotationKey]; allocations != "" {
+		ingress.StaticIP = &cloudingressv1alpha1.StaticIP{AllocationIDs: strings.Split(allocations, ",")}
+	}
+}
+
+// setLoadBalancerStatus records in the status of instance where the admin API
+// endpoint can be reached from, and the load balancer of svc serving it
+func (r *ReconcileAPIScheme) setLoadBalancerStatus(ctx context.Context, instance *cloudingressv1alpha1.APIScheme, svc *corev1.Service) error {
+	registered, err := getCloudClient().GetAdminAPIInstanceHealth(ctx, r.client, instance, svc)
+	if err != nil {
+		return err
+	}
+	// The cloud providers list them in no particular order
+	sort.Slice(registered, func(i, j int) bool {
+		return registered[i].InstanceID < registered[j].InstanceID
+	})
+	instance.Status.RegisteredInstanceCount = int32(len(registered))
+	instance.Status.RegisteredInstances = registered
+	instance.Status.CloudLoadBalancerDNSName = ""
+	if ingress := svc.Status.LoadBalancer.Ingress; len(ingress) > 0 {
+		instance.Status.CloudLoadBalancerDNSName = ingress[0].Hostname
+		if ingress[0].Hostname == "" {
+			instance.Status.CloudLoadBalancerDNSName = ingress[0].IP
+		}
+	}
+	instance.Status.APIEndpointVisibility = endpointVisibility(svc)
+	return nil
+}
+
+// verifyDNS resolves the admin API record of instance against the authoritative
+// nameservers of its zones and the resolvers of verification, and records in
+// the DNSVerified condition of instance whether they all resolve it to the
+// load balancer of svc. A record that hasn't propagated doesn't fail the
+// reconcile, it's checked again on the next one. A disconnected cluster can't
+// reach the public nameservers, so only the resolvers are asked
+func (r *ReconcileAPIScheme) verifyDNS(ctx context.Context, instance *cloudingressv1alpha1.APIScheme, svc *corev1.Service, verification *cloudingressv1alpha1.DNSVerification, disconnected bool) error {
+	nameServers := []string{}
+	var err error
+	if !disconnected {
+		nameServers, err = getCloudClient().GetAdminAPINameServers(ctx, r.client, instance)
+		if err != nil {
+			return err
+		}
+	}
+	baseDomain, err := baseutils.GetAdminAPIBaseDomain(r.client, instance)
+	if err != nil {
+		return err
+	}
+	recordName := instance.Spec.ManagementAPIServerIngress.DNSName + "." + baseDomain
+	// Records aliasing a load balancer hostname follow its addresses, only
+	// those pointing at an IP address can be checked against it
+	expected := []string{}
+	for _, ingress := range svc.Status.LoadBalancer.Ingress {
+		if ingress.IP != "" {
+			expected = append(expected, ingress.IP)
+		}
+	}
+	servers := append(nameServers, verification.Resolvers...)
+	failures := baseutils.UnpropagatedDNSServers(ctx, servers, recordName, expected)
+	localmetrics.MetricDNSNotPropagated.WithLabelValues("apischeme", instance.Namespace+"/"+instance.Name).Set(float64(len(failures)))
+
+	status, reason, message := corev1.ConditionTrue, "Propagated", fmt.Sprintf("%s resolves on %d DNS servers", recordName, len(servers))
+	switch {
+	case len(servers) == 0:
+		// Only published in private zones, and no resolver to ask
+		status, reason, message = corev1.ConditionUnknown, "NoDNSServers", "No public nameserver or resolver to resolve "+recordName+" against"
+	case len(failures) > 0:
+		log.Info("The admin API record hasn't propagated", "record", recordName, "failures", failures)
+		status, reason, message = corev1.ConditionFalse, "NotPropagated", strings.Join(failures, "; ")
+	}
+	if utils.FindAPISchemeCondition(instance.Status.Conditions, cloudingressv1alpha1.ConditionDNSVerified) == nil {
+		// SetAPISchemeCondition only adds conditions that are True, and a
+		// record that never propagated must show too
+		now := metav1.Now()
+		instance.Status.Conditions = append(instance.Status.Conditions, cloudingressv1alpha1.APISchemeCondition{
+			Type:               cloudingressv1alpha1.ConditionDNSVerified,
+			Status:             status,
+			Reason:             reason,
+			Message:            message,
+			LastTransitionTime: now,
+			LastProbeTime:      now,
+		})
+		return nil
+	}
+	instance.Status.Conditions = utils.SetAPISchemeCondition(
+		instance.Status.Conditions,
+		cloudingressv1alpha1.ConditionDNSVerified,
+		status,
+		reason,
+		message,
+		utils.UpdateConditionIfReasonOrMessageChange)
+	return nil
+}
+
+// endpointVisibility returns where the load balancer of svc can be reached
+// from. It's Transitioning until the cloud provider has created it
+func endpointVisibility(svc *corev1.Service) cloudingressv1alpha1.APIEndpointVisibility {
+	if len(svc.Status.LoadBalancer.Ingress) == 0 {
+		return cloudingressv1alpha1.APIEndpointTransitioning
+	}
+	if hasInternalLoadBalancer(svc) {
+		return cloudingressv1alpha1.APIEndpointPrivate
+	}
+	return cloudingressv1alpha1.APIEndpointPublic
+}
+
+// hasInternalLoadBalancer checks if svc asks either cloud provider for an
+// internal load balancer
+func hasInternalLoadBalancer(svc *corev1.Service) bool {
+	if internal, ok := svc.Annotations[awsInternalAnnotationKey]; ok && internal != "false" {
+		return true
+	}
+	return strings.EqualFold(svc.Annotations[gcpLoadBalancerTypeAnnotationKey], "Internal")
+}
+
+// wantsBackendService checks if the external admin API load balancer of
+// instance should forward to a backend service. Internal ones always do,
+// without asking
+func wantsBackendService(instance *cloudingressv1alpha1.APIScheme) bool {
+	return instance.Spec.ManagementAPIServerIngress.Backend == cloudingressv1alpha1.LoadBalancerBackendBackendService && !wantsInternalLoadBalancer(instance)
+}
+
+// hasBackendService checks if svc asks the GCP cloud provider for an external
+// load balancer with a backend service
+func hasBackendService(svc *corev1.Service) bool {
+	return svc.Annotations[gcpBackendServiceAnnotationKey] == "enabled"
+}
+
+// loadBalancerScheme returns the scheme of the admin API load balancer of
+// instance, which defaults to internet-facing. An internal one is
+// internet-facing during a period of temporary public access, or a
+// maintenance window of its public access schedule
+func loadBalancerScheme(instance *cloudingressv1alpha1.APIScheme) cloudingressv1alpha1.LoadBalancerScheme {
+	if instance.Spec.ManagementAPIServerIngress.Scheme == "" || temporarilyPublic(instance, time.Now()) {
+		return cloudingressv1alpha1.LoadBalancerSchemeInternetFacing
+	}
+	// An invalid schedule is reported by the reconcile, and opens no window
+	if open, _, _ := baseutils.GetPublicAccessWindow(instance, time.Now()); open {
+		return cloudingressv1alpha1.LoadBalancerSchemeInternetFacing
+	}
+	return instance.Spec.ManagementAPIServerIngress.Scheme
+}
+
+// updateTemporaryPublicAccess records in the status of instance the period of
+// temporary public access its spec asks for, starting at now if it's new or
+// its duration changed, and forgets it once the spec no longer asks for it.
+// Returns whether the status changed
+func updateTemporaryPublicAccess(instance *cloudingressv1alpha1.APIScheme, now time.Time) bool {
+	requested := instance.Spec.ManagementAPIServerIngress.TemporaryPublicAccess
+	current := instance.Status.TemporaryPublicAccess
+	switch {
+	case requested == nil:
+		instance.Status.TemporaryPublicAccess = nil
+		return current != nil
+	case current != nil && current.Duration == requested.Duration:
+		return false
+	}
+	instance.Status.TemporaryPublicAccess = &cloudingressv1alpha1.TemporaryPublicAccessStatus{
+		Duration:  requested.Duration,
+		ExpiresAt: metav1.NewTime(now.Add(requested.Duration.Duration)),
+	}
+	return true
+}
+
+// temporarilyPublic checks if the period of temporary public access of
+// instance recorded by updateTemporaryPublicAccess hasn't expired at now
+func temporarilyPublic(instance *cloudingressv1alpha1.APIScheme, now time.Time) bool {
+	requested := instance.Spec.ManagementAPIServerIngress.TemporaryPublicAccess
+	current := instance.Status.TemporaryPublicAccess
+	return requested != nil && current != nil && current.Duration == requested.Duration && now.Before(current.ExpiresAt.Time)
+}
+
+// wantsInternalLoadBalancer checks if the admin API load balancer of instance
+// should be internal
+func wantsInternalLoadBalancer(instance *cloudingressv1alpha1.APIScheme) bool {
+	return loadBalancerScheme(instance) == cloudingressv1alpha1.LoadBalancerSchemeInternal
+}
+
+// refreshCloudClient returns the cloud client, making a new one first if the
+// cluster's platform config changed since it was made. Concurrent reconciles
+// share it
+func (r *ReconcileAPIScheme) refreshCloudClient() (cloudclient.CloudClient, error) {
+	cloudClientMu.Lock()
+	defer cloudClientMu.Unlock()
+	if cloudClient != nil && cloudClientConfigVersion == "" {
+		return cloudClient, nil
+	}
+	configVersion, err := baseutils.GetPlatformConfigVersion(r.client)
+	if err != nil {
+		return nil, err
+	}
+	if cloudClient == nil || configVersion != cloudClientConfigVersion {
+		cloudPlatform, err := baseutils.GetPlatformType(r.client)
+		if err != nil {
+			return nil, err
+		}
+		if cloudClient != nil {
+			log.Info("The cluster's platform config changed, making a new cloud client")
+		}
+		cloudClient = cloudclient.GetSharedClientFor(r.client, *cloudPlatform, configVersion)
+		cloudClientConfigVersion = configVersion
+	}
+	return cloudClient, nil
+}
+
+// preflight returns the cloud permissions cli lacks. They're checked once per
+// cloud client, and again every PreconditionRecheckInterval while some are
+// missing or couldn't be checked. A cloud client set by the tests isn't
+// checked
+func (r *ReconcileAPIScheme) preflight(ctx context.Context, cli cloudclient.CloudClient) ([]string, error) {
+	cloudClientMu.Lock()
+	defer cloudClientMu.Unlock()
+	if cloudClientConfigVersion == "" {
+		return nil, nil
+	}
+	recheck := (len(missingPermissions) > 0 || permissionsUnchecked) && time.Since(permissionsCheckedAt) >= PreconditionRecheckInterval
+	if permissionsCheckedFor == cli && !recheck {
+		return missingPermissions, nil
+	}
+	permissionsCheckedFor = cli
+	permissionsCheckedAt = time.Now()
+	missing, err := cli.CheckPermissions(ctx, r.client)
+	permissionsUnchecked = err != nil
+	if err != nil {
+		missingPermissions = nil
+		return nil, err
+	}
+	missingPermissions = missing
+	localmetrics.MetricMissingPermissions.Set(float64(len(missing)))
+	return missing, nil
+}
+
+// getCloudClient returns the cloud client refreshCloudClient last returned
+func getCloudClient() cloudclient.CloudClient {
+	cloudClientMu.Lock()
+	defer cloudClientMu.Unlock()
+	return cloudClient
+}
+
+// repairDriftIfDue re-verifies the cloud resources behind the admin API, at
+// most once every RequeueIntervals.DriftScan or whenever the master Machines
+// changed, and repairs whatever drifted. The load balancer attributes are
+// audited against policy at the same time
+func (r *ReconcileAPIScheme) repairDriftIfDue(ctx context.Context, name types.NamespacedName, instance *cloudingressv1alpha1.APIScheme, svc *corev1.Service, policy *cloudingressv1alpha1.LoadBalancerAttributes) error {
+	masterMachines, err := baseutils.GetMasterMachines(r.client)
+	if err != nil {
+		return err
+	}
+	mastersState := masterMachinesState(masterMachines.Items)
+	r.driftMu.Lock()
+	if r.lastDriftCheck == nil {
+		r.lastDriftCheck = make(map[types.NamespacedName]time.Time)
+	}
+	if r.lastMasterMachines == nil {
+		r.lastMasterMachines = make(map[types.NamespacedName]string)
+	}
+	lastDriftCheck := r.lastDriftCheck[name]
+	lastMastersState, checked := r.lastMasterMachines[name]
+	r.driftMu.Unlock()
+	if mastersState == lastMastersState {
+		// Scans are put off while the cloud API throttles the operator, only
+		// a change of masters can't wait
+		if _, throttled := utils.CloudThrottle.BackingOff(time.Now()); throttled || time.Since(lastDriftCheck) < RequeueIntervals.DriftScan {
+			return nil
+		}
+	}
+	if checked && mastersState != lastMastersState {
+		log.Info("Master Machines changed, checking the admin API load balancer instances", "Request.Name", name.Name)
+	}
+	drifted, err := getCloudClient().RepairAdminAPIDrift(ctx, r.client, instance, svc)
+	for _, component := range drifted {
+		localmetrics.MetricDriftDetected.WithLabelValues(component).Inc()
+	}
+	if err != nil {
+		return err
+	}
+	if len(drifted) > 0 {
+		log.Info("Repaired drift in the admin API cloud resources", "Request.Name", name.Name, "drifted", drifted)
+	}
+	// The audit only reports, it's no reason to fail the reconcile
+	if err := r.auditLoadBalancerCompliance(ctx, instance, svc, policy); err != nil {
+		log.Error(err, "Couldn't audit the admin API load balancer attributes", "Request.Name", name.Name)
+	}
+	r.driftMu.Lock()
+	r.lastDriftCheck[name] = time.Now()
+	r.lastMasterMachines[name] = mastersState
+	r.driftMu.Unlock()
+	return nil
+}
+
+// loadBalancerAttributeNames are the attributes of LoadBalancerAttributes, as
+// labelled in the compliance metric
+var loadBalancerAttributeNames = []string{"crossZone", "connectionDraining", "idleTimeout", "accessLogs"}
+
+// auditLoadBalancerCompliance exports whether each attribute of the admin API
+// load balancer that policy sets complies with it. The metrics of the
+// attributes it doesn't audit are removed
+func (r *ReconcileAPIScheme) auditLoadBalancerCompliance(ctx context.Context, instance *cloudingressv1alpha1.APIScheme, svc *corev1.Service, policy *cloudingressv1alpha1.LoadBalancerAttributes) error {
+	compliance := map[string]bool{}
+	if policy != nil {
+		attributes, err := getCloudClient().GetAdminAPILoadBalancerAttributes(ctx, r.client, instance, svc)
+		if err != nil {
+			return err
+		}
+		compliance = loadBalancerCompliance(policy, attributes)
+	}
+	name := instance.Namespace + "/" + instance.Name
+	noncompliant := []string{}
+	for _, attribute := range loadBalancerAttributeNames {
+		compliant, audited := compliance[attribute]
+		if !audited {
+			localmetrics.MetricLoadBalancerCompliant.DeleteLabelValues("apischeme", name, attribute)
+			continue
+		}
+		value := 1.0
+		if !compliant {
+			value = 0
+			noncompliant = append(noncompliant, attribute)
+		}
+		localmetrics.MetricLoadBalancerCompliant.WithLabelValues("apischeme", name, attribute).Set(value)
+	}
+	if len(noncompliant) > 0 {
+		log.Info("The admin API load balancer doesn't comply with the load balancer policy", "Request.Name", instance.Name, "attributes", noncompliant)
+	}
+	return nil
+}
+
+// loadBalancerCompliance returns whether each attribute that both policy and
+// attributes set match, keyed by their name in loadBalancerAttributeNames
+func loadBalancerCompliance(policy, attributes *cloudingressv1alpha1.LoadBalancerAttributes) map[string]bool {
+	compliance := map[string]bool{}
+	if policy == nil || attributes == nil {
+		return compliance
+	}
+	if policy.CrossZone != nil && attributes.CrossZone != nil {
+		compliance["crossZone"] = *policy.CrossZone == *attributes.CrossZone
+	}
+	if policy.ConnectionDraining != nil && attributes.ConnectionDraining != nil {
+		compliance["connectionDraining"] = *policy.ConnectionDraining == *attributes.ConnectionDraining
+	}
+	if policy.IdleTimeoutSeconds != nil && attributes.IdleTimeoutSeconds != nil {
+		compliance["idleTimeout"] = *policy.IdleTimeoutSeconds == *attributes.IdleTimeoutSeconds
+	}
+	if policy.AccessLogs != nil && attributes.AccessLogs != nil {
+		compliance["accessLogs"] = *policy.AccessLogs == *attributes.AccessLogs
+	}
+	return compliance
+}
+
+// masterMachinesState sums up which master Machines exist, the nodes they
+// became and which of them are being deleted. The instances behind the admin
+// API load balancer should change when it does
+func masterMachinesState(machines []machineapi.Machine) string {
+	states := make([]string, 0, len(machines))
+	for _, machine := range machines {
+		state := machine.Name
+		if machine.Status.NodeRef != nil {
+			state += "/" + machine.Status.NodeRef.Name
+		}
+		if machine.DeletionTimestamp != nil {
+			state += "/deleting"
+		}
+		states = append(states, state)
+	}
+	sort.Strings(states)
+	return strings.Join(states, ",")
+}
+
+// rollbackDue checks if the admin API endpoint still isn't ready RollbackTimeout
+// after its Service was created. An endpoint that was ready once is repaired
+// instead of rolled back. Whether the operator created the Service, and so may
+// roll it back, is up to the caller
+func rollbackDue(instance *cloudingressv1alpha1.APIScheme, svc *corev1.Service, now time.Time) bool {
+	if utils.FindAPISchemeCondition(instance.Status.Conditions, cloudingressv1alpha1.ConditionReady) != nil {
+		return false
+	}
+	if svc.CreationTimestamp.IsZero() {
+		return false
+	}
+	return now.Sub(svc.CreationTimestamp.Time) > RollbackTimeout
+}
+
+// Destroy deletes the admin API endpoint of instance and everything behind it,
+// in dependency order: the DNS records, which are found through the load
+// balancer, then the load balancer, the Service, the alarms, the Elastic IPs
+// the operator allocated and last the security group. The Elastic IPs and the
+// security group can only go once no load balancer uses them. It's what the
+// finalizer runs, and what the cleanup command runs when the operator can't.
+// Returns LoadBalancerNotReadyError if the load balancer is still being
+// created, and ElasticIPInUseError or SecurityGroupInUseError while AWS is
+// still deleting it; calling it again picks up where it left off
+func Destroy(ctx context.Context, kclient client.Client, cli cloudclient.CloudClient, instance *cloudingressv1alpha1.APIScheme) error {
+	// The load balancer of an NLB migration goes first, so the admin API
+	// record is never left pointing at it alone
+	migrationSvc := &corev1.Service{}
+	err := kclient.Get(ctx, types.NamespacedName{Name: migrationServiceName(instance), Namespace: "openshift-kube-apiserver"}, migrationSvc)
+	switch {
+	case errors.IsNotFound(err):
+		// no migration
+	case err != nil:
+		log.Error(err, "Couldn't get the NLB migration Service")
+		return err
+	default:
+		if err = cli.DeleteAdminAPIDNS(ctx, kclient, instance, migrationSvc); err != nil {
+			log.Error(err, "Failed to delete the NLB migration DNS records")
+			return err
+		}
+		if err = cli.DeleteAdminAPILoadBalancer(ctx, kclient, instance, migrationSvc); err != nil {
+			log.Error(err, "Failed to delete the NLB migration load balancer")
+			return err
+		}
+		if err = kclient.Delete(ctx, migrationSvc); err != nil && !errors.IsNotFound(err) {
+			log.Error(err, "Failed to delete the NLB migration Service")
+			return err
+		}
+	}
+
+	svc := &corev1.Service{}
+	err = kclient.Get(ctx, types.NamespacedName{Name: instance.Spec.ManagementAPIServerIngress.DNSName, Namespace: "openshift-kube-apiserver"}, svc)
+	switch {
+	case err == nil && ownedByAnotherAPIScheme(instance, svc):
+		// The endpoint is another APIScheme's to delete
+		log.Info("Leaving the Service of another APIScheme", "Service", svc.GetName())
+	case errors.IsNotFound(err):
+		// Without the Service, the load balancer and the DNS records of the
+		// endpoint can't be found. This could leave them behind!
+		//
+		// TODO As a future enhancement, the CloudClient
+		//      provider should handle this scenario and
+		//      look up the necessary information itself
+		//      to proceed with the DNS deletion.
+	case err != nil:
+		log.Error(err, "Couldn't get the Service")
+		return err
+	default:
+		if err = cli.DeleteAdminAPIDNS(ctx, kclient, instance, svc); err != nil {
+			log.Error(err, "Failed to delete the DNS record")
+			return err
+		}
+		if err = cli.DeleteAdminAPILoadBalancer(ctx, kclient, instance, svc); err != nil {
+			log.Error(err, "Failed to delete the load balancer")
+			return err
+		}
+		if err = kclient.Delete(ctx, svc); err != nil && !errors.IsNotFound(err) {
+			log.Error(err, "Failed to delete the Service")
+			return err
+		}
+	}
+
+	if err = cli.DeleteAdminAPIAlarms(ctx, kclient, instance); err != nil {
+		log.Error(err, "Failed to delete the alarms")
+		return err
+	}
+	// The Elastic IPs listed in the APIScheme are the user's, and never released
+	if allocationIDs := createdResourceIDs(instance, resourceKindElasticIP); len(allocationIDs) > 0 {
+		released, err := cli.ReleaseAdminAPIStaticIPs(ctx, kclient, instance, allocationIDs)
+		for _, allocationID := range released {
+			instance.Status.CreatedResources = forgetCreatedResource(instance.Status.CreatedResources, resourceKindElasticIP, allocationID)
+		}
+		if err != nil {
+			log.Error(err, "Failed to release the Elastic IPs")
+			return err
+		}
+		if inUse := createdResourceIDs(instance, resourceKindElasticIP); len(inUse) > 0 {
+			return cioerrors.NewElasticIPInUseError(inUse)
+		}
+	}
+	if err = cli.DeleteAdminAPISecurityGroup(ctx, kclient, instance); err != nil {
+		if _, inUse := err.(*cioerrors.SecurityGroupInUseError); !inUse {
+			log.Error(err, "Failed to delete the security group")
+		}
+		return err
+	}
+	return nil
+}
+
+// rollBack deletes the cloud resources created for an admin API endpoint that
+// never became ready, so a failed reconcile doesn't leave a half-configured
+// load balancer behind. It's repeated until everything it can delete is gone,
+// including the Elastic IPs the operator allocated once the load balancer lets
+// go of them. Anything that couldn't be deleted is listed in the
+// CleanupPending condition
+func (r *ReconcileAPIScheme) rollBack(ctx context.Context, instance *cloudingressv1alpha1.APIScheme, serviceNamespacedName types.NamespacedName) (reconcile.Result, error) {
+	cloudClient := getCloudClient()
+	instance.Status.RolledBackGeneration = instance.Generation
+	requeue := false
+
+	svc := &corev1.Service{}
+	err := r.client.Get(ctx, serviceNamespacedName, svc)
+	switch {
+	case err == nil:
+		// There's no DNS record if the load balancer never got ready
+		err = cloudClient.DeleteAdminAPIDNS(ctx, r.client, instance, svc)
+		if _, notReady := err.(*cioerrors.LoadBalancerNotReadyError); err != nil && !notReady {
+			log.Error(err, "Failed to delete the DNS record")
+			return utils.CloudErrorResult(err)
+		}
+		if err = cloudClient.DeleteAdminAPILoadBalancer(ctx, r.client, instance, svc); err != nil {
+			log.Error(err, "Failed to delete the load balancer")
+			return utils.CloudErrorResult(err)
+		}
+		if err = r.client.Delete(ctx, svc); err != nil && !errors.IsNotFound(err) {
+			log.Error(err, "Failed to delete the Service")
+			return reconcile.Result{}, err
+		}
+		// the security group waits for AWS to finish deleting the load balancer
+		requeue = true
+	case errors.IsNotFound(err):
+		instance.Status.CreatedResources = forgetCreatedResources(instance.Status.CreatedResources, resourceKindService)
+	default:
+		log.Error(err, "Couldn't get the Service")
+		return reconcile.Result{}, err
+	}
+
+	if err = cloudClient.DeleteAdminAPIAlarms(ctx, r.client, instance); err != nil {
+		log.Error(err, "Failed to delete the alarms")
+		return utils.CloudErrorResult(err)
+	}
+
+	if err = r.releaseStaticIPs(ctx, cloudClient, instance); err != nil {
+		log.Error(err, "Failed to release the Elastic IPs")
+		return utils.CloudErrorResult(err)
+	}
+	if len(createdResourceIDs(instance, resourceKindElasticIP)) > 0 {
+		// the load balancer isn't gone yet
+		requeue = true
+	}
+
+	err = cloudClient.DeleteAdminAPISecurityGroup(ctx, r.client, instance)
+	switch err.(type) {
+	case nil:
+		instance.Status.CreatedResources = forgetCreatedResources(instance.Status.CreatedResources, resourceKindSecurityGroup)
+	case *cioerrors.SecurityGroupInUseError:
+		// the load balancer isn't gone yet
+		requeue = true
+	default:
+		log.Error(err, "Failed to delete the security group")
+		return utils.CloudErrorResult(err)
+	}
+
+	status := corev1.ConditionFalse
+	message := "Nothing was left behind"
+	if len(instance.Status.CreatedResources) > 0 {
+		status = corev1.ConditionTrue
+		message = "Left behind: " + strings.Join(instance.Status.CreatedResources, ", ")
+	}
+	instance.Status.Conditions = utils.SetAPISchemeCondition(
+		instance.Status.Conditions,
+		cloudingressv1alpha1.ConditionCleanupPending,
+		status,
+		"RolledBack",
+		message,
+		utils.UpdateConditionIfReasonOrMessageChange)
+	r.SetAPISchemeStatus(instance, "Rolled back", fmt.Sprintf("Admin API endpoint wasn't ready after %s. Change the APIScheme to try again", RollbackTimeout), cloudingressv1alpha1.ConditionError)
+	if err = saveResourceManifest(ctx, r.client, instance); err != nil {
+		log.Error(err, "Couldn't update the resource manifest")
+		return reconcile.Result{}, err
+	}
+
+	if requeue {
+		return RequeueIntervals.ErrorResult(), nil
+	}
+	return reconcile.Result{}, nil
+}
+
+// staticIPsChanged checks if desired, found with its static IPs ensured, has
+// other static IPs than found. The Elastic IP allocations are compared as a
+// set, the load balancer gets the same addresses whatever their order
+func staticIPsChanged(found, desired *corev1.Service) bool {
+	if desired.Spec.LoadBalancerIP != found.Spec.LoadBalancerIP {
+		return true
+	}
+	annotations := desired.Annotations
+	foundAllocations, foundOK := found.Annotations[eipAllocationsAnnotationKey]
+	desiredAllocations, desiredOK := desired.Annotations[eipAllocationsAnnotationKey]
+	if foundOK && desiredOK && foundAllocations != desiredAllocations {
+		foundIDs, desiredIDs := strings.Split(foundAllocations, ","), strings.Split(desiredAllocations, ",")
+		sort.Strings(foundIDs)
+		sort.Strings(desiredIDs)
+		if reflect.DeepEqual(foundIDs, desiredIDs) {
+			annotations = make(map[string]string, len(desired.Annotations))
+			for key, value := range desired.Annotations {
+				annotations[key] = value
+			}
+			annotations[eipAllocationsAnnotationKey] = foundAllocations
+		}
+	}
+	return !reflect.DeepEqual(annotations, found.Annotations)
+}
+
+// reportNotRolledBack lists svc, which is due a rollback but wasn't recorded as
+// created by the operator, in the CleanupPending condition of instance. svc and
+// the cloud resources behind it are left for whoever created them to clean up
+func (r *ReconcileAPIScheme) reportNotRolledBack(ctx context.Context, instance *cloudingressv1alpha1.APIScheme, svc *corev1.Service) error {
+	message := fmt.Sprintf("Not rolled back, the operator didn't create %s/service/%s", svc.GetNamespace(), svc.GetName())
+	condition := utils.FindAPISchemeCondition(instance.Status.Conditions, cloudingressv1alpha1.ConditionCleanupPending)
+	if condition != nil && condition.Status == corev1.ConditionTrue && condition.Message == message {
+		return nil
+	}
+	instance.Status.Conditions = utils.SetAPISchemeCondition(
+		instance.Status.Conditions,
+		cloudingressv1alpha1.ConditionCleanupPending,
+		corev1.ConditionTrue,
+		"NotRolledBack",
+		message,
+		utils.UpdateConditionIfReasonOrMessageChange)
+	return r.client.Status().Update(ctx, instance)
+}
+
+// ensureStaticIPs ensures the static IPs of the admin API endpoint for svc.
+// Elastic IPs recorded as allocated for an earlier attempt are reused as if the
+// APIScheme listed them, unless they're gone, rather than allocating more
+func (r *ReconcileAPIScheme) ensureStaticIPs(ctx context.Context, cloudClient cloudclient.CloudClient, instance *cloudingressv1alpha1.APIScheme, svc *corev1.Service) ([]string, error) {
+	allocationIDs := createdResourceIDs(instance, resourceKindElasticIP)
+	if len(instance.Spec.ManagementAPIServerIngress.StaticIP.AllocationIDs) > 0 || len(allocationIDs) == 0 {
+		return cloudClient.EnsureAdminAPIStaticIPs(ctx, r.client, instance, svc)
+	}
+	recorded := instance.DeepCopy()
+	recorded.Spec.ManagementAPIServerIngress.StaticIP.AllocationIDs = allocationIDs
+	ips, err := cloudClient.EnsureAdminAPIStaticIPs(ctx, r.client, recorded, svc)
+	if err == nil {
+		return ips, nil
+	}
+	log.Info("Couldn't reuse the recorded Elastic IPs, ensuring new ones", "allocationIDs", allocationIDs, "reason", err.Error())
+	instance.Status.CreatedResources = forgetCreatedResources(instance.Status.CreatedResources, resourceKindElasticIP)
+	return cloudClient.EnsureAdminAPIStaticIPs(ctx, r.client, instance, svc)
+}
+
+// releaseStaticIPs releases the Elastic IPs recorded as allocated by the
+// operator for instance, and forgets those released. Addresses still attached
+// to a load balancer are kept for a later reconcile
+func (r *ReconcileAPIScheme) releaseStaticIPs(ctx context.Context, cloudClient cloudclient.CloudClient, instance *cloudingressv1alpha1.APIScheme) error {
+	allocationIDs := createdResourceIDs(instance, resourceKindElasticIP)
+	if len(allocationIDs) == 0 {
+		return nil
+	}
+	released, err := cloudClient.ReleaseAdminAPIStaticIPs(ctx, r.client, instance, allocationIDs)
+	for _, allocationID := range released {
+		instance.Status.CreatedResources = forgetCreatedResource(instance.Status.CreatedResources, resourceKindElasticIP, allocationID)
+	}
+	if len(released) > 0 {
+		if saveErr := saveResourceManifest(ctx, r.client, instance); saveErr != nil && err == nil {
+			err = saveErr
+		}
+	}
+	return err
+}
+
+// recordCreatedResource adds a cloud resource to the ones recorded in the
+// status of instance, unless it's there already
+func recordCreatedResource(instance *cloudingressv1alpha1.APIScheme, kind, id string) {
+	resource := kind + "/" + id
+	for _, created := range instance.Status.CreatedResources {
+		if created == resource {
+			return
+		}
+	}
+	instance.Status.CreatedResources = append(instance.Status.CreatedResources, resource)
+}
+
+// forgetCreatedResources returns resources without the ones of kind
+func forgetCreatedResources(resources []string, kind string) []string {
+	remaining := []string{}
+	for _, resource := range resources {
+		if !strings.HasPrefix(resource, kind+"/") {
+			remaining = append(remaining, resource)
+		}
+	}
+	return remaining
+}
+
+// forgetCreatedResource returns resources without the resource of kind and id
+func forgetCreatedResource(resources []string, kind, id string) []string {
+	remaining := []string{}
+	for _, resource := range resources {
+		if resource != kind+"/"+id {
+			remaining = append(remaining, resource)
+		}
+	}
+	return remaining
+}
+
+// createdResourceIDs returns the IDs of the resources of kind recorded in the
+// status of instance
+func createdResourceIDs(instance *cloudingressv1alpha1.APIScheme, kind string) []string {
+	ids := []string{}
+	for _, resource := range instance.Status.CreatedResources {
+		if strings.HasPrefix(resource, kind+"/") {
+			ids = append(ids, strings.TrimPrefix(resource, kind+"/"))
+		}
+	}
+	return ids
+}
+
+// hasCreatedResource checks if the resource of kind and id is recorded in the
+// status of instance
+func hasCreatedResource(instance *cloudingressv1alpha1.APIScheme, kind, id string) bool {
+	for _, created := range createdResourceIDs(instance, kind) {
+		if created == id {
+			return true
+		}
+	}
+	return false
+}
+
+// resourceManifestKey is the key of the resource manifest holding the cloud
+// resources created for instance
+func resourceManifestKey(instance *cloudingressv1alpha1.APIScheme) string {
+	return instance.GetNamespace() + "." + instance.GetName()
+}
+
+// getResourceManifest returns the cloud resources recorded for instance in the
+// resource manifest, as <kind>/<id>
+func getResourceManifest(ctx context.Context, kclient client.Client, instance *cloudingressv1alpha1.APIScheme) ([]string, error) {
+	manifest := &corev1.ConfigMap{}
+	err := kclient.Get(ctx, types.NamespacedName{Name: config.ResourceManifestConfigMapName, Namespace: config.OperatorNamespace}, manifest)
+	if errors.IsNotFound(err) {
+		return []string{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if manifest.Data[resourceManifestKey(instance)] == "" {
+		return []string{}, nil
+	}
+	return strings.Split(manifest.Data[resourceManifestKey(instance)], "\n"), nil
+}
+
+// saveResourceManifest records the cloud resources in the status of instance
+// in the resource manifest, one per line. Without any, instance is dropped
+// from it
+func saveResourceManifest(ctx context.Context, kclient client.Client, instance *cloudingressv1alpha1.APIScheme) error {
+	manifest := &corev1.ConfigMap{}
+	err := kclient.Get(ctx, types.NamespacedName{Name: config.ResourceManifestConfigMapName, Namespace: config.OperatorNamespace}, manifest)
+	notFound := errors.IsNotFound(err)
+	if err != nil && !notFound {
+		return err
+	}
+	key := resourceManifestKey(instance)
+	resources := strings.Join(instance.Status.CreatedResources, "\n")
+	if manifest.Data[key] == resources {
+		return nil
+	}
+	if resources == "" {
+		delete(manifest.Data, key)
+	} else {
+		if manifest.Data == nil {
+			manifest.Data = map[string]string{}
+		}
+		manifest.Data[key] = resources
+	}
+	if notFound {
+		manifest.ObjectMeta = metav1.ObjectMeta{
+			Name:      config.ResourceManifestConfigMapName,
+			Namespace: config.OperatorNamespace,
+		}
+		return kclient.Create(ctx, manifest)
+	}
+	return kclient.Update(ctx, manifest)
+}
+
+// restoreCreatedResources fills in the cloud resources recorded in the status
+// of instance from the resource manifest when an etcd restore or recreating
+// the APIScheme lost them, so the next attempt re-adopts them rather than
+// provisioning duplicates, and a rollback still cleans them up. It reports
+// whether there was anything to restore
+func restoreCreatedResources(ctx context.Context, kclient client.Client, instance *cloudingressv1alpha1.APIScheme) (bool, error) {
+	if len(instance.Status.CreatedResources) > 0 {
+		return false, nil
+	}
+	resources, err := getResourceManifest(ctx, kclient, instance)
+	if err != nil || len(resources) == 0 {
+		return false, err
+	}
+	instance.Status.CreatedResources = resources
+	return true, nil
+}
+
+// desiredIPAddressType returns the IP address type annotation value svc should
+// have. Services that were never dualstack don't need the annotation at all,
+// which is reported by returning false
+func desiredIPAddressType(instance *cloudingressv1alpha1.APIScheme, svc *corev1.Service) (string, bool) {
+	if instance.Spec.ManagementAPIServerIngress.IPAddressType == cloudingressv1alpha1.IPAddressTypeDualStack {
+		return string(cloudingressv1alpha1.IPAddressTypeDualStack), true
+	}
+	if metav1.HasAnnotation(svc.ObjectMeta, ipAddressTypeAnnotationKey) {
+		return string(cloudingressv1alpha1.IPAddressTypeIPv4), true
+	}
+	return "", false
+}
+
+// wantsNetworkLoadBalancer checks if the admin API must be served by a network
+// load balancer, because it's dualstack or the feature gate asks for one
+func wantsNetworkLoadBalancer(instance *cloudingressv1alpha1.APIScheme, ingressConfig *cloudingressv1alpha1.CloudIngressConfig) bool {
+	return instance.Spec.ManagementAPIServerIngress.IPAddressType == cloudingressv1alpha1.IPAddressTypeDualStack ||
+		ingressConfig.Spec.FeatureGates.NetworkLoadBalancer
+}
+
+// adminAPIServicePorts returns the ports of the admin API Service of
+// instance: one per listener of its spec, or by default the port the
+// cluster's API is served on from outside. They forward to the ones the API
+// server pods listen on, unless a listener says otherwise
+func adminAPIServicePorts(kclient client.Client, instance *cloudingressv1alpha1.APIScheme) ([]corev1.ServicePort, error) {
+	targetPort, err := baseutils.GetAPIServerPort(kclient)
+	if err != nil {
+		return nil, err
+	}
+	listeners := instance.Spec.ManagementAPIServerIngress.Listeners
+	if len(listeners) == 0 {
+		port, err := baseutils.GetClusterAPIPort(kclient)
+		if err != nil {
+			return nil, err
+		}
+		return []corev1.ServicePort{{
+			Protocol:   "TCP",
+			Port:       int32(port),
+			TargetPort: intstr.FromInt(int(targetPort)),
+		}}, nil
+	}
+	ports := []corev1.ServicePort{}
+	for _, listener := range listeners {
+		port := corev1.ServicePort{
+			// A Service with several ports needs them named
+			Name:       fmt.Sprintf("api-%d", listener.Port),
+			Protocol:   "TCP",
+			Port:       listener.Port,
+			TargetPort: intstr.FromInt(int(targetPort)),
+		}
+		if listener.TargetPort != 0 {
+			port.TargetPort = intstr.FromInt(int(listener.TargetPort))
+		}
+		ports = append(ports, port)
+	}
+	return ports, nil
+}
+
+// servicePortsMatch checks if the Service ports found listen and forward
+// where desired do, in the same order. Node ports are left to Kubernetes
+func servicePortsMatch(found, desired []corev1.ServicePort) bool {
+	if len(found) != len(desired) {
+		return false
+	}
+	for i := range found {
+		if found[i].Port != desired[i].Port || found[i].TargetPort != desired[i].TargetPort {
+			return false
+		}
+	}
+	return true
+}
+
+// updatedServicePorts returns the desired ports of a Service whose ports are
+// found. A port keeps the node port of the one found listening on it, or
+// else of the one found in its place, if that one goes, so that a port moved
+// keeps its listener's instance port
+func updatedServicePorts(found, desired []corev1.ServicePort) []corev1.ServicePort {
+	kept := make(map[int32]bool)
+	for _, port := range desired {
+		kept[port.Port] = true
+	}
+	updated := []corev1.ServicePort{}
+	for i, port := range desired {
+		for _, foundPort := range found {
+			if foundPort.Port == port.Port {
+				port.NodePort = foundPort.NodePort
+			}
+		}
+		if port.NodePort == 0 && i < len(found) && !kept[found[i].Port] {
+			port.NodePort = found[i].NodePort
+		}
+		updated = append(updated, port)
+	}
+	return updated
+}
+
+// describeServicePorts lists ports as <port>-><target port>, eg 443->6443
+func describeServicePorts(ports []corev1.ServicePort) string {
+	described := []string{}
+	for _, port := range ports {
+		described = append(described, fmt.Sprintf("%d->%s", port.Port, port.TargetPort.String()))
+	}
+	return strings.Join(described, ", ")
+}
+
+// healthCheckAnnotations returns the Service annotations overriding the cloud
+// provider's health check defaults. Unset parameters have no annotation
+func healthCheckAnnotations(healthCheck *cloudingressv1alpha1.HealthCheck) map[string]string {
+	annotations := map[string]string{}
+	if healthCheck == nil {
+		return annotations
+	}
+	parameters := map[string]int32{
+		"interval":            healthCheck.IntervalSeconds,
+		"timeout":             healthCheck.TimeoutSeconds,
+		"healthy-threshold":   healthCheck.HealthyThreshold,
+		"unhealthy-threshold": healthCheck.UnhealthyThreshold,
+	}
+	for name, value := range parameters {
+		if value > 0 {
+			annotations[healthCheckAnnotationPrefix+name] = strconv.Itoa(int(value))
+		}
+	}
+	if healthCheck.Protocol != "" {
+		annotations[healthCheckAnnotationPrefix+"protocol"] = string(healthCheck.Protocol)
+	}
+	// Only HTTP and HTTPS health checks request a path
+	if healthCheck.Path != "" && (healthCheck.Protocol == cloudingressv1alpha1.HealthCheckProtocolHTTP || healthCheck.Protocol == cloudingressv1alpha1.HealthCheckProtocolHTTPS) {
+		annotations[healthCheckAnnotationPrefix+"path"] = healthCheck.Path
+	}
+	return annotations
+}
+
+// resolveHealthCheck returns healthCheck with its protocol and path filled in.
+// Without a protocol, the one the admin API Service svc already has is kept,
+// so the health check is never changed by a probe of a briefly unhealthy API
+// server. Only a Service without one has the API server behind it probed
+// through its cluster IP
+func resolveHealthCheck(healthCheck *cloudingressv1alpha1.HealthCheck, svc *corev1.Service) *cloudingressv1alpha1.HealthCheck {
+	resolved := &cloudingressv1alpha1.HealthCheck{}
+	if healthCheck != nil {
+		resolved = healthCheck.DeepCopy()
+	}
+	if resolved.Path == "" {
+		resolved.Path = defaultHealthCheckPath
+	}
+	if resolved.Protocol != "" {
+		return resolved
+	}
+	if current := svc.Annotations[healthCheckAnnotationPrefix+"protocol"]; current != "" {
+		resolved.Protocol = cloudingressv1alpha1.HealthCheckProtocol(current)
+		return resolved
+	}
+	if svc.Spec.ClusterIP == "" || svc.Spec.ClusterIP == corev1.ClusterIPNone || len(svc.Spec.Ports) == 0 {
+		return resolved
+	}
+	address := net.JoinHostPort(svc.Spec.ClusterIP, strconv.Itoa(int(svc.Spec.Ports[0].Port)))
+	resolved.Protocol = probeHealthCheckProtocol(address, resolved.Path, healthCheckProbeTimeout)
+	return resolved
+}
+
+// annotationsMatch checks if every one of annotations is set on meta
+func annotationsMatch(meta metav1.ObjectMeta, annotations map[string]string) bool {
+	for key, value := range annotations {
+		if meta.Annotations[key] != value {
+			return false
+		}
+	}
+	return true
+}
+
 func sliceEquals(left, right []string) bool {
 	if len(left) != len(right) {
 		return false
@@ -1,13 +1,30 @@
 package apischeme
 
 import (
+	"context"
 	"fmt"
+	"reflect"
+	"sync"
 	"testing"
+	"time"
 
+	"github.com/golang/mock/gomock"
+	"github.com/openshift/cloud-ingress-operator/config"
+	cloudingressv1alpha1 "github.com/openshift/cloud-ingress-operator/pkg/apis/cloudingress/v1alpha1"
+	"github.com/openshift/cloud-ingress-operator/pkg/cloudclient"
+	mockcc "github.com/openshift/cloud-ingress-operator/pkg/cloudclient/mock_cloudclient"
+	utils "github.com/openshift/cloud-ingress-operator/pkg/controller/utils"
+	cioerrors "github.com/openshift/cloud-ingress-operator/pkg/errors"
 	"github.com/openshift/cloud-ingress-operator/pkg/testutils"
 
 	baseutils "github.com/openshift/cloud-ingress-operator/pkg/utils"
+	machineapi "github.com/openshift/machine-api-operator/pkg/apis/machine/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
 
 func TestClusterBaseDomain(t *testing.T) {
@@ -29,3 +46,1465 @@ func TestClusterBaseDomain(t *testing.T) {
 		t.Fatalf("Base domain mismatch. Expected %s, got %s", "unit.test", base)
 	}
 }
+
+func TestHealthCheckAnnotations(t *testing.T) {
+	tests := []struct {
+		Name        string
+		HealthCheck *cloudingressv1alpha1.HealthCheck
+		Expected    map[string]string
+	}{
+		{
+			Name:     "No health check settings",
+			Expected: map[string]string{},
+		},
+		{
+			Name: "Only set parameters are annotated",
+			HealthCheck: &cloudingressv1alpha1.HealthCheck{
+				IntervalSeconds:    10,
+				UnhealthyThreshold: 2,
+			},
+			Expected: map[string]string{
+				"service.beta.kubernetes.io/aws-load-balancer-healthcheck-interval":            "10",
+				"service.beta.kubernetes.io/aws-load-balancer-healthcheck-unhealthy-threshold": "2",
+			},
+		},
+		{
+			Name: "HTTPS requests a path",
+			HealthCheck: &cloudingressv1alpha1.HealthCheck{
+				Protocol: cloudingressv1alpha1.HealthCheckProtocolHTTPS,
+				Path:     "/readyz",
+			},
+			Expected: map[string]string{
+				"service.beta.kubernetes.io/aws-load-balancer-healthcheck-protocol": "HTTPS",
+				"service.beta.kubernetes.io/aws-load-balancer-healthcheck-path":     "/readyz",
+			},
+		},
+		{
+			Name: "SSL doesn't",
+			HealthCheck: &cloudingressv1alpha1.HealthCheck{
+				Protocol: cloudingressv1alpha1.HealthCheckProtocolSSL,
+				Path:     "/readyz",
+			},
+			Expected: map[string]string{
+				"service.beta.kubernetes.io/aws-load-balancer-healthcheck-protocol": "SSL",
+			},
+		},
+	}
+	for _, test := range tests {
+		actual := healthCheckAnnotations(test.HealthCheck)
+		if !reflect.DeepEqual(actual, test.Expected) {
+			t.Fatalf("Test [%v] FAILED. Expected %v. Got %v", test.Name, test.Expected, actual)
+		}
+	}
+}
+
+func TestResolveHealthCheck(t *testing.T) {
+	probed := cloudingressv1alpha1.HealthCheckProtocolHTTPS
+	probedAddress := ""
+	probeHealthCheckProtocol = func(address, path string, timeout time.Duration) cloudingressv1alpha1.HealthCheckProtocol {
+		probedAddress = address
+		return probed
+	}
+	defer func() { probeHealthCheckProtocol = baseutils.ProbeHealthCheckProtocol }()
+	svc := &corev1.Service{
+		Spec: corev1.ServiceSpec{
+			ClusterIP: "172.30.0.10",
+			Ports:     []corev1.ServicePort{{Port: 6443}},
+		},
+	}
+
+	resolved := resolveHealthCheck(nil, svc)
+	if resolved.Protocol != cloudingressv1alpha1.HealthCheckProtocolHTTPS || resolved.Path != "/readyz" || probedAddress != "172.30.0.10:6443" {
+		t.Fatalf("Expected the probed HTTPS health check of /readyz, got %+v after probing %s", resolved, probedAddress)
+	}
+
+	// The protocol the Service has is kept without probing again
+	probedAddress = ""
+	probed = cloudingressv1alpha1.HealthCheckProtocolTCP
+	svc.Annotations = map[string]string{
+		"service.beta.kubernetes.io/aws-load-balancer-healthcheck-protocol": "HTTPS",
+	}
+	resolved = resolveHealthCheck(nil, svc)
+	if resolved.Protocol != cloudingressv1alpha1.HealthCheckProtocolHTTPS || probedAddress != "" {
+		t.Fatalf("Expected the HTTPS health check to be kept, got %+v after probing %q", resolved, probedAddress)
+	}
+
+	// A configured protocol isn't probed
+	probedAddress = ""
+	configured := &cloudingressv1alpha1.HealthCheck{Protocol: cloudingressv1alpha1.HealthCheckProtocolHTTP, Path: "/healthz"}
+	resolved = resolveHealthCheck(configured, svc)
+	if resolved.Protocol != cloudingressv1alpha1.HealthCheckProtocolHTTP || resolved.Path != "/healthz" || probedAddress != "" {
+		t.Fatalf("Expected the configured health check, got %+v after probing %q", resolved, probedAddress)
+	}
+}
+
+func TestRollbackDue(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		Name       string
+		Conditions []cloudingressv1alpha1.APISchemeCondition
+		Created    time.Time
+		Expected   bool
+	}{
+		{
+			Name:     "Service not created yet",
+			Expected: false,
+		},
+		{
+			Name:     "Service created recently",
+			Created:  now.Add(-RollbackTimeout / 2),
+			Expected: false,
+		},
+		{
+			Name:     "Never ready after the timeout",
+			Created:  now.Add(-2 * RollbackTimeout),
+			Expected: true,
+		},
+		{
+			Name: "Ready once",
+			Conditions: []cloudingressv1alpha1.APISchemeCondition{
+				{Type: cloudingressv1alpha1.ConditionReady, Status: corev1.ConditionTrue},
+			},
+			Created:  now.Add(-2 * RollbackTimeout),
+			Expected: false,
+		},
+	}
+	for _, test := range tests {
+		instance := &cloudingressv1alpha1.APIScheme{
+			Status: cloudingressv1alpha1.APISchemeStatus{Conditions: test.Conditions},
+		}
+		svc := &corev1.Service{}
+		if !test.Created.IsZero() {
+			svc.CreationTimestamp = metav1.NewTime(test.Created)
+		}
+		actual := rollbackDue(instance, svc, now)
+		if actual != test.Expected {
+			t.Fatalf("Test [%v] FAILED. Expected %v. Got %v", test.Name, test.Expected, actual)
+		}
+	}
+}
+
+func TestRollBackOnlyCreatedService(t *testing.T) {
+	tests := []struct {
+		Name     string
+		Recorded bool
+	}{
+		{
+			Name:     "Created by the operator",
+			Recorded: true,
+		},
+		{
+			Name:     "Created by something else",
+			Recorded: false,
+		},
+	}
+	for _, test := range tests {
+		ctrl := gomock.NewController(t)
+		aObj := testutils.CreateAPISchemeObject("rh-api", true, []string{"0.0.0.0/0"})
+		if test.Recorded {
+			recordCreatedResource(aObj, resourceKindService, "openshift-kube-apiserver/rh-api")
+		}
+		infraObj := testutils.CreateInfraObject("basename", testutils.DefaultAPIEndpoint, testutils.DefaultAPIEndpoint, testutils.DefaultRegionName)
+		// Never ready, and not updated without calling the cloud
+		svc := &corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              "rh-api",
+				Namespace:         "openshift-kube-apiserver",
+				CreationTimestamp: metav1.NewTime(time.Now().Add(-2 * RollbackTimeout)),
+			},
+			Spec: corev1.ServiceSpec{
+				Type:                     corev1.ServiceTypeLoadBalancer,
+				Ports:                    []corev1.ServicePort{{Port: 6443, TargetPort: intstr.FromInt(6444)}},
+				LoadBalancerSourceRanges: []string{"10.0.0.0/16"},
+			},
+		}
+		mocks := testutils.NewTestMock(t, []runtime.Object{aObj, infraObj, svc})
+		r := &ReconcileAPIScheme{client: mocks.FakeKubeClient}
+		cloud := mockcc.NewMockCloudClient(ctrl)
+		if test.Recorded {
+			cloud.EXPECT().DeleteAdminAPIDNS(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+			cloud.EXPECT().DeleteAdminAPILoadBalancer(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+			cloud.EXPECT().DeleteAdminAPIAlarms(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+			cloud.EXPECT().DeleteAdminAPISecurityGroup(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+		}
+		previous := cloudClient
+		cloudClient = cloud
+
+		request := reconcile.Request{NamespacedName: types.NamespacedName{Namespace: aObj.Namespace, Name: aObj.Name}}
+		_, err := r.Reconcile(context.TODO(), request)
+		cloudClient = previous
+		ctrl.Finish()
+		if err != nil {
+			t.Fatalf("Test [%v] FAILED. Unexpected error: %v", test.Name, err)
+		}
+
+		instance := &cloudingressv1alpha1.APIScheme{}
+		if err = mocks.FakeKubeClient.Get(context.TODO(), request.NamespacedName, instance); err != nil {
+			t.Fatalf("Test [%v] FAILED. Couldn't get the APIScheme: %v", test.Name, err)
+		}
+		err = mocks.FakeKubeClient.Get(context.TODO(), types.NamespacedName{Namespace: svc.Namespace, Name: svc.Name}, &corev1.Service{})
+		kept := err == nil
+		if kept == test.Recorded {
+			t.Fatalf("Test [%v] FAILED. Expected the Service to be kept %v. Got %v", test.Name, !test.Recorded, kept)
+		}
+		// The fake client leaves the generation at 0, so it's the condition
+		// rollBack sets that shows it ran
+		rolledBack := utils.FindAPISchemeCondition(instance.Status.Conditions, cloudingressv1alpha1.ConditionError) != nil
+		if rolledBack != test.Recorded {
+			t.Fatalf("Test [%v] FAILED. Expected rolled back %v. Got %v", test.Name, test.Recorded, rolledBack)
+		}
+		condition := utils.FindAPISchemeCondition(instance.Status.Conditions, cloudingressv1alpha1.ConditionCleanupPending)
+		if !test.Recorded && (condition == nil || condition.Status != corev1.ConditionTrue) {
+			t.Fatalf("Test [%v] FAILED. Expected the Service to be listed in the CleanupPending condition. Got %+v", test.Name, condition)
+		}
+	}
+}
+
+func TestCreatedResources(t *testing.T) {
+	instance := &cloudingressv1alpha1.APIScheme{}
+	recordCreatedResource(instance, resourceKindElasticIP, "eipalloc-1")
+	recordCreatedResource(instance, resourceKindSecurityGroup, "sg-1")
+	recordCreatedResource(instance, resourceKindSecurityGroup, "sg-1")
+	recordCreatedResource(instance, resourceKindService, "openshift-kube-apiserver/rh-api")
+	expected := []string{"elastic-ip/eipalloc-1", "security-group/sg-1", "service/openshift-kube-apiserver/rh-api"}
+	if !reflect.DeepEqual(instance.Status.CreatedResources, expected) {
+		t.Fatalf("Expected %v. Got %v", expected, instance.Status.CreatedResources)
+	}
+
+	remaining := forgetCreatedResources(instance.Status.CreatedResources, resourceKindSecurityGroup)
+	expected = []string{"elastic-ip/eipalloc-1", "service/openshift-kube-apiserver/rh-api"}
+	if !reflect.DeepEqual(remaining, expected) {
+		t.Fatalf("Expected %v. Got %v", expected, remaining)
+	}
+}
+
+func TestResourceManifest(t *testing.T) {
+	mocks := testutils.NewTestMock(t, []runtime.Object{})
+	aObj := testutils.CreateAPISchemeObject("rh-api", true, []string{"0.0.0.0/0"})
+	recordCreatedResource(aObj, resourceKindElasticIP, "eipalloc-1")
+	recordCreatedResource(aObj, resourceKindSecurityGroup, "sg-1")
+	if err := saveResourceManifest(context.TODO(), mocks.FakeKubeClient, aObj); err != nil {
+		t.Fatalf("Couldn't save the resource manifest: %v", err)
+	}
+
+	// The APIScheme is recreated without a status
+	recreated := testutils.CreateAPISchemeObject("rh-api", true, []string{"0.0.0.0/0"})
+	restored, err := restoreCreatedResources(context.TODO(), mocks.FakeKubeClient, recreated)
+	if err != nil || !restored {
+		t.Fatalf("Expected the created resources to be restored. Got %v, %v", restored, err)
+	}
+	expected := []string{"elastic-ip/eipalloc-1", "security-group/sg-1"}
+	if !reflect.DeepEqual(recreated.Status.CreatedResources, expected) {
+		t.Fatalf("Expected %v. Got %v", expected, recreated.Status.CreatedResources)
+	}
+	restored, err = restoreCreatedResources(context.TODO(), mocks.FakeKubeClient, recreated)
+	if err != nil || restored {
+		t.Fatalf("Expected nothing left to restore. Got %v, %v", restored, err)
+	}
+
+	// Another APIScheme has a manifest of its own
+	other := testutils.CreateAPISchemeObject("other-api", true, []string{"0.0.0.0/0"})
+	other.Name = "other-api"
+	restored, err = restoreCreatedResources(context.TODO(), mocks.FakeKubeClient, other)
+	if err != nil || restored {
+		t.Fatalf("Expected nothing to restore for another APIScheme. Got %v, %v", restored, err)
+	}
+
+	recreated.Status.CreatedResources = nil
+	if err = saveResourceManifest(context.TODO(), mocks.FakeKubeClient, recreated); err != nil {
+		t.Fatalf("Couldn't save the resource manifest: %v", err)
+	}
+	manifest := &corev1.ConfigMap{}
+	err = mocks.FakeKubeClient.Get(context.TODO(), types.NamespacedName{Name: config.ResourceManifestConfigMapName, Namespace: config.OperatorNamespace}, manifest)
+	if err != nil {
+		t.Fatalf("Couldn't get the resource manifest: %v", err)
+	}
+	if _, ok := manifest.Data[resourceManifestKey(recreated)]; ok {
+		t.Fatalf("Expected the APIScheme to be dropped from the manifest. Got %v", manifest.Data)
+	}
+}
+
+func TestStaticIPsChanged(t *testing.T) {
+	service := func(allocations string) *corev1.Service {
+		return &corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{
+				Annotations: map[string]string{
+					nlbTypeAnnotationKey:        "nlb",
+					eipAllocationsAnnotationKey: allocations,
+				},
+			},
+		}
+	}
+	tests := []struct {
+		Name     string
+		Found    string
+		Desired  string
+		Expected bool
+	}{
+		{
+			Name:     "Same allocations",
+			Found:    "eipalloc-a,eipalloc-b",
+			Desired:  "eipalloc-a,eipalloc-b",
+			Expected: false,
+		},
+		{
+			Name:     "Same allocations in reverse order",
+			Found:    "eipalloc-b,eipalloc-a",
+			Desired:  "eipalloc-a,eipalloc-b",
+			Expected: false,
+		},
+		{
+			Name:     "Other allocations",
+			Found:    "eipalloc-a,eipalloc-b",
+			Desired:  "eipalloc-a,eipalloc-c",
+			Expected: true,
+		},
+		{
+			Name:     "More allocations",
+			Found:    "eipalloc-a",
+			Desired:  "eipalloc-a,eipalloc-b",
+			Expected: true,
+		},
+	}
+	for _, test := range tests {
+		actual := staticIPsChanged(service(test.Found), service(test.Desired))
+		if actual != test.Expected {
+			t.Fatalf("Test [%v] FAILED. Expected %v. Got %v", test.Name, test.Expected, actual)
+		}
+	}
+}
+
+func TestEnsureStaticIPs(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mocks := testutils.NewTestMock(t, []runtime.Object{})
+	r := &ReconcileAPIScheme{client: mocks.FakeKubeClient}
+	cloud := mockcc.NewMockCloudClient(ctrl)
+
+	tests := []struct {
+		Name              string
+		SpecIDs           []string
+		RecordedIDs       []string
+		RecordedErr       error
+		ExpectedIDs       [][]string
+		ExpectedResources []string
+	}{
+		{
+			Name:        "Nothing recorded",
+			ExpectedIDs: [][]string{nil},
+		},
+		{
+			Name:              "Recorded Elastic IPs are reused",
+			RecordedIDs:       []string{"eipalloc-1", "eipalloc-2"},
+			ExpectedIDs:       [][]string{{"eipalloc-1", "eipalloc-2"}},
+			ExpectedResources: []string{"elastic-ip/eipalloc-1", "elastic-ip/eipalloc-2"},
+		},
+		{
+			Name:              "Recorded Elastic IPs are gone",
+			RecordedIDs:       []string{"eipalloc-1"},
+			RecordedErr:       fmt.Errorf("InvalidAllocationID.NotFound"),
+			ExpectedIDs:       [][]string{{"eipalloc-1"}, nil},
+			ExpectedResources: []string{},
+		},
+		{
+			Name:              "The APIScheme's own Elastic IPs win",
+			SpecIDs:           []string{"eipalloc-3"},
+			RecordedIDs:       []string{"eipalloc-1"},
+			ExpectedIDs:       [][]string{{"eipalloc-3"}},
+			ExpectedResources: []string{"elastic-ip/eipalloc-1"},
+		},
+	}
+	for _, test := range tests {
+		aObj := testutils.CreateAPISchemeObject("rh-api", true, []string{"0.0.0.0/0"})
+		aObj.Spec.ManagementAPIServerIngress.StaticIP = &cloudingressv1alpha1.StaticIP{AllocationIDs: test.SpecIDs}
+		for _, id := range test.RecordedIDs {
+			recordCreatedResource(aObj, resourceKindElasticIP, id)
+		}
+		svc := &corev1.Service{}
+		calls := [][]string{}
+		cloud.EXPECT().EnsureAdminAPIStaticIPs(context.TODO(), mocks.FakeKubeClient, gomock.Any(), svc).DoAndReturn(
+			func(_ context.Context, _ interface{}, instance *cloudingressv1alpha1.APIScheme, _ *corev1.Service) ([]string, error) {
+				calls = append(calls, instance.Spec.ManagementAPIServerIngress.StaticIP.AllocationIDs)
+				if len(calls) == 1 && test.RecordedErr != nil {
+					return nil, test.RecordedErr
+				}
+				return []string{"192.0.2.1"}, nil
+			}).Times(len(test.ExpectedIDs))
+		if _, err := r.ensureStaticIPs(context.TODO(), cloud, aObj, svc); err != nil {
+			t.Fatalf("Test [%v] FAILED. Unexpected error %v", test.Name, err)
+		}
+		if !reflect.DeepEqual(calls, test.ExpectedIDs) {
+			t.Fatalf("Test [%v] FAILED. Expected %v. Got %v", test.Name, test.ExpectedIDs, calls)
+		}
+		if test.ExpectedResources != nil && !reflect.DeepEqual(aObj.Status.CreatedResources, test.ExpectedResources) {
+			t.Fatalf("Test [%v] FAILED. Expected %v. Got %v", test.Name, test.ExpectedResources, aObj.Status.CreatedResources)
+		}
+	}
+}
+
+func TestDestroyReleasesStaticIPs(t *testing.T) {
+	tests := []struct {
+		Name              string
+		RecordedIDs       []string
+		ReleasedIDs       []string
+		ExpectedInUse     bool
+		ExpectedResources []string
+	}{
+		{
+			Name:              "No Elastic IPs allocated",
+			ExpectedResources: []string{"security-group/sg-1"},
+		},
+		{
+			Name:              "Allocated Elastic IPs are released",
+			RecordedIDs:       []string{"eipalloc-1", "eipalloc-2"},
+			ReleasedIDs:       []string{"eipalloc-1", "eipalloc-2"},
+			ExpectedResources: []string{"security-group/sg-1"},
+		},
+		{
+			Name:              "Elastic IPs the load balancer still holds are waited for",
+			RecordedIDs:       []string{"eipalloc-1", "eipalloc-2"},
+			ReleasedIDs:       []string{"eipalloc-1"},
+			ExpectedInUse:     true,
+			ExpectedResources: []string{"elastic-ip/eipalloc-2", "security-group/sg-1"},
+		},
+	}
+	for _, test := range tests {
+		ctrl := gomock.NewController(t)
+		aObj := testutils.CreateAPISchemeObject("rh-api", true, []string{"0.0.0.0/0"})
+		for _, id := range test.RecordedIDs {
+			recordCreatedResource(aObj, resourceKindElasticIP, id)
+		}
+		recordCreatedResource(aObj, resourceKindSecurityGroup, "sg-1")
+		svc := &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "rh-api", Namespace: "openshift-kube-apiserver"}}
+		mocks := testutils.NewTestMock(t, []runtime.Object{aObj, svc})
+		cloud := mockcc.NewMockCloudClient(ctrl)
+
+		cloud.EXPECT().DeleteAdminAPIDNS(gomock.Any(), gomock.Any(), aObj, gomock.Any()).Return(nil).Times(1)
+		cloud.EXPECT().DeleteAdminAPILoadBalancer(gomock.Any(), gomock.Any(), aObj, gomock.Any()).Return(nil).Times(1)
+		cloud.EXPECT().DeleteAdminAPIAlarms(gomock.Any(), gomock.Any(), aObj).Return(nil).Times(1)
+		if len(test.RecordedIDs) > 0 {
+			cloud.EXPECT().ReleaseAdminAPIStaticIPs(gomock.Any(), gomock.Any(), aObj, test.RecordedIDs).Return(test.ReleasedIDs, nil).Times(1)
+		}
+		if !test.ExpectedInUse {
+			cloud.EXPECT().DeleteAdminAPISecurityGroup(gomock.Any(), gomock.Any(), aObj).Return(nil).Times(1)
+		}
+
+		err := Destroy(context.TODO(), mocks.FakeKubeClient, cloud, aObj)
+		if test.ExpectedInUse {
+			if _, inUse := err.(*cioerrors.ElasticIPInUseError); !inUse {
+				t.Fatalf("Test [%v] FAILED. Expected ElasticIPInUseError. Got %v", test.Name, err)
+			}
+		} else if err != nil {
+			t.Fatalf("Test [%v] FAILED. Unexpected error %v", test.Name, err)
+		}
+		if !reflect.DeepEqual(aObj.Status.CreatedResources, test.ExpectedResources) {
+			t.Fatalf("Test [%v] FAILED. Expected %v. Got %v", test.Name, test.ExpectedResources, aObj.Status.CreatedResources)
+		}
+		ctrl.Finish()
+	}
+}
+
+func TestEndpointVisibility(t *testing.T) {
+	ingress := []corev1.LoadBalancerIngress{{Hostname: "a0123456789.us-east-1.elb.amazonaws.com"}}
+	tests := []struct {
+		Name        string
+		Annotations map[string]string
+		Ingress     []corev1.LoadBalancerIngress
+		Expected    cloudingressv1alpha1.APIEndpointVisibility
+	}{
+		{
+			Name:     "Load balancer not created yet",
+			Expected: cloudingressv1alpha1.APIEndpointTransitioning,
+		},
+		{
+			Name:     "Internet-facing load balancer",
+			Ingress:  ingress,
+			Expected: cloudingressv1alpha1.APIEndpointPublic,
+		},
+		{
+			Name:        "AWS internal load balancer",
+			Annotations: map[string]string{"service.beta.kubernetes.io/aws-load-balancer-internal": "true"},
+			Ingress:     ingress,
+			Expected:    cloudingressv1alpha1.APIEndpointPrivate,
+		},
+		{
+			Name:        "GCP internal load balancer",
+			Annotations: map[string]string{"cloud.google.com/load-balancer-type": "Internal"},
+			Ingress:     []corev1.LoadBalancerIngress{{IP: "10.0.0.5"}},
+			Expected:    cloudingressv1alpha1.APIEndpointPrivate,
+		},
+	}
+	for _, test := range tests {
+		svc := &corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Annotations: test.Annotations},
+			Status: corev1.ServiceStatus{
+				LoadBalancer: corev1.LoadBalancerStatus{Ingress: test.Ingress},
+			},
+		}
+		actual := endpointVisibility(svc)
+		if actual != test.Expected {
+			t.Fatalf("Test [%v] FAILED. Expected %v. Got %v", test.Name, test.Expected, actual)
+		}
+	}
+}
+
+func TestMasterMachinesState(t *testing.T) {
+	_, machines := testutils.CreateMachineObjectList([]string{"master-1", "master-0"}, "basename", "master", testutils.DefaultRegionName, testutils.DefaultAzName)
+	before := masterMachinesState(machines)
+	if before != masterMachinesState([]machineapi.Machine{machines[1], machines[0]}) {
+		t.Fatalf("Expected the state not to depend on the order of the Machines")
+	}
+
+	// master-0 is being replaced by master-2, which becomes a node
+	now := metav1.Now()
+	machines[1].DeletionTimestamp = &now
+	deleting := masterMachinesState(machines)
+	if deleting == before {
+		t.Fatalf("Expected a master being deleted to change the state")
+	}
+	replacement := testutils.CreateMachineObj("master-2", "basename", "master", testutils.DefaultRegionName, testutils.DefaultAzName)
+	machines = append(machines, replacement)
+	created := masterMachinesState(machines)
+	machines[2].Status.NodeRef = &corev1.ObjectReference{Kind: "Node", Name: "ip-10-0-0-2.ec2.internal"}
+	if masterMachinesState(machines) == created {
+		t.Fatalf("Expected a new master becoming a node to change the state")
+	}
+
+	if !baseutils.IsMasterMachine(&machines[2]) {
+		t.Fatalf("Expected %s to be a master Machine", machines[2].Name)
+	}
+	worker := testutils.CreateMachineObj("worker-0", "basename", "worker", testutils.DefaultRegionName, testutils.DefaultAzName)
+	if baseutils.IsMasterMachine(&worker) {
+		t.Fatalf("Expected %s not to be a master Machine", worker.Name)
+	}
+}
+
+func TestPlanChanges(t *testing.T) {
+	instance := &cloudingressv1alpha1.APIScheme{
+		Spec: cloudingressv1alpha1.APISchemeSpec{
+			ManagementAPIServerIngress: cloudingressv1alpha1.ManagementAPIServerIngress{
+				Enabled:           true,
+				DNSName:           "rh-api",
+				AllowedCIDRBlocks: []string{"10.0.0.0/8"},
+			},
+			ManagementState: cloudingressv1alpha1.ManagementStatePlan,
+		},
+	}
+	desired := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "rh-api",
+			Namespace:   "openshift-kube-apiserver",
+			Annotations: map[string]string{elbAnnotationKey: elbAnnotationValue},
+		},
+		Spec: corev1.ServiceSpec{
+			LoadBalancerSourceRanges: []string{"10.0.0.0/8"},
+			Ports:                    []corev1.ServicePort{{Port: 443, TargetPort: intstr.FromInt(6443)}},
+		},
+	}
+	movedPort := desired.DeepCopy()
+	movedPort.Spec.Ports[0].Port = 6443
+	tests := []struct {
+		Name     string
+		Found    *corev1.Service
+		Drifted  []string
+		Expected []string
+	}{
+		{
+			Name:  "Service doesn't exist",
+			Found: nil,
+			Expected: []string{
+				"create security group",
+				"create openshift-kube-apiserver/service/rh-api and its load balancer",
+				"create DNS record rh-api",
+			},
+		},
+		{
+			Name:     "Nothing to change",
+			Found:    desired,
+			Expected: []string{},
+		},
+		{
+			Name:     "API served on another port",
+			Found:    movedPort,
+			Expected: []string{"set the port of openshift-kube-apiserver/service/rh-api to 443, forwarding to 6443"},
+		},
+		{
+			Name: "Changed CIDR blocks and annotation, drifted listeners",
+			Found: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{Name: "rh-api", Namespace: "openshift-kube-apiserver"},
+				Spec:       corev1.ServiceSpec{LoadBalancerSourceRanges: []string{"0.0.0.0/0"}},
+			},
+			Drifted: []string{"listeners"},
+			Expected: []string{
+				"set the allowed CIDR blocks of openshift-kube-apiserver/service/rh-api to 10.0.0.0/8",
+				"set annotation " + elbAnnotationKey + "=" + elbAnnotationValue + " on openshift-kube-apiserver/service/rh-api",
+				"repair drifted listeners",
+			},
+		},
+	}
+	for _, test := range tests {
+		actual := planChanges(instance, desired, test.Found, test.Drifted)
+		if !reflect.DeepEqual(actual, test.Expected) {
+			t.Fatalf("Test [%v] FAILED. Expected %v. Got %v", test.Name, test.Expected, actual)
+		}
+	}
+}
+
+func TestWantsInternalLoadBalancer(t *testing.T) {
+	tests := []struct {
+		Name     string
+		Scheme   cloudingressv1alpha1.LoadBalancerScheme
+		Expected bool
+	}{
+		{
+			Name:     "Defaults to internet-facing",
+			Expected: false,
+		},
+		{
+			Name:     "Internet-facing",
+			Scheme:   cloudingressv1alpha1.LoadBalancerSchemeInternetFacing,
+			Expected: false,
+		},
+		{
+			Name:     "Internal",
+			Scheme:   cloudingressv1alpha1.LoadBalancerSchemeInternal,
+			Expected: true,
+		},
+	}
+	for _, test := range tests {
+		instance := &cloudingressv1alpha1.APIScheme{
+			Spec: cloudingressv1alpha1.APISchemeSpec{
+				ManagementAPIServerIngress: cloudingressv1alpha1.ManagementAPIServerIngress{Scheme: test.Scheme},
+			},
+		}
+		actual := wantsInternalLoadBalancer(instance)
+		if actual != test.Expected {
+			t.Fatalf("Test [%v] FAILED. Expected %v. Got %v", test.Name, test.Expected, actual)
+		}
+		svc := (&ReconcileAPIScheme{}).newServiceFor(instance, &cloudingressv1alpha1.CloudIngressConfig{}, instance.Spec.ManagementAPIServerIngress.AllowedCIDRBlocks, []corev1.ServicePort{{Port: 6443}})
+		if hasInternalLoadBalancer(svc) != test.Expected {
+			t.Fatalf("Test [%v] FAILED. Expected the Service to ask for an internal load balancer: %v. Got %v", test.Name, test.Expected, svc.Annotations)
+		}
+	}
+}
+
+func TestAdoptSpec(t *testing.T) {
+	tests := []struct {
+		Name string
+		Spec cloudingressv1alpha1.ManagementAPIServerIngress
+	}{
+		{
+			Name: "Internet-facing",
+			Spec: cloudingressv1alpha1.ManagementAPIServerIngress{
+				DNSName:           "rh-api",
+				AllowedCIDRBlocks: []string{"10.0.0.0/16", "209.132.0.0/16"},
+			},
+		},
+		{
+			Name: "Internal in given subnets",
+			Spec: cloudingressv1alpha1.ManagementAPIServerIngress{
+				DNSName:           "rh-api",
+				AllowedCIDRBlocks: []string{"10.0.0.0/16"},
+				Scheme:            cloudingressv1alpha1.LoadBalancerSchemeInternal,
+				SubnetIDs:         []string{"subnet-a", "subnet-b"},
+			},
+		},
+		{
+			Name: "Listeners",
+			Spec: cloudingressv1alpha1.ManagementAPIServerIngress{
+				DNSName:           "rh-api",
+				AllowedCIDRBlocks: []string{"10.0.0.0/16"},
+				Listeners:         []cloudingressv1alpha1.APIListener{{Port: 443, TargetPort: 6443}, {Port: 6443, TargetPort: 6443}},
+			},
+		},
+		{
+			Name: "Dual-stack",
+			Spec: cloudingressv1alpha1.ManagementAPIServerIngress{
+				DNSName:           "admin-api",
+				AllowedCIDRBlocks: []string{"10.0.0.0/16"},
+				IPAddressType:     cloudingressv1alpha1.IPAddressTypeDualStack,
+			},
+		},
+		{
+			Name: "Dedicated backend nodes",
+			Spec: cloudingressv1alpha1.ManagementAPIServerIngress{
+				DNSName:             "rh-api",
+				AllowedCIDRBlocks:   []string{"10.0.0.0/16"},
+				BackendNodeSelector: map[string]string{"node-role.kubernetes.io/infra": "", "api-frontend": "true"},
+			},
+		},
+	}
+	for _, test := range tests {
+		r := &ReconcileAPIScheme{}
+		existing := &cloudingressv1alpha1.APIScheme{
+			Spec: cloudingressv1alpha1.APISchemeSpec{ManagementAPIServerIngress: test.Spec},
+		}
+		ports := []corev1.ServicePort{{Port: 6443}}
+		if len(test.Spec.Listeners) > 0 {
+			ports = []corev1.ServicePort{}
+			for _, listener := range test.Spec.Listeners {
+				ports = append(ports, corev1.ServicePort{Name: fmt.Sprintf("api-%d", listener.Port), Port: listener.Port, TargetPort: intstr.FromInt(int(listener.TargetPort))})
+			}
+		}
+		svc := r.newServiceFor(existing, &cloudingressv1alpha1.CloudIngressConfig{}, test.Spec.AllowedCIDRBlocks, ports)
+
+		// Written without looking at the cluster
+		instance := &cloudingressv1alpha1.APIScheme{
+			Spec: cloudingressv1alpha1.APISchemeSpec{
+				ManagementAPIServerIngress: cloudingressv1alpha1.ManagementAPIServerIngress{
+					DNSName:           "rh-api",
+					AllowedCIDRBlocks: []string{"0.0.0.0/0"},
+				},
+			},
+		}
+		adoptSpec(instance, svc)
+		if !instance.Spec.ManagementAPIServerIngress.Enabled {
+			t.Fatalf("Test [%v] FAILED. Expected the adopted APIScheme to be enabled", test.Name)
+		}
+		if !reflect.DeepEqual(instance.Spec.ManagementAPIServerIngress.Listeners, test.Spec.Listeners) {
+			t.Fatalf("Test [%v] FAILED. Expected listeners %v. Got %v", test.Name, test.Spec.Listeners, instance.Spec.ManagementAPIServerIngress.Listeners)
+		}
+		desired := r.newServiceFor(instance, &cloudingressv1alpha1.CloudIngressConfig{}, instance.Spec.ManagementAPIServerIngress.AllowedCIDRBlocks, ports)
+		if !reflect.DeepEqual(desired.ObjectMeta, svc.ObjectMeta) || !reflect.DeepEqual(desired.Spec, svc.Spec) {
+			t.Fatalf("Test [%v] FAILED. Expected %+v. Got %+v", test.Name, svc, desired)
+		}
+	}
+}
+
+func TestAdoptIsNotRolledBack(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	aObj := testutils.CreateAPISchemeObject("rh-api", true, []string{"0.0.0.0/0"})
+	aObj.Annotations = map[string]string{cloudingressv1alpha1.AdoptAnnotation: "true"}
+	infraObj := testutils.CreateInfraObject("basename", testutils.DefaultAPIEndpoint, testutils.DefaultAPIEndpoint, testutils.DefaultRegionName)
+	// Serving for long past the rollback timeout, on a port the next
+	// reconcile updates without calling the cloud
+	svc := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              "rh-api",
+			Namespace:         "openshift-kube-apiserver",
+			CreationTimestamp: metav1.NewTime(time.Now().Add(-2 * RollbackTimeout)),
+		},
+		Spec: corev1.ServiceSpec{
+			Type:                     corev1.ServiceTypeLoadBalancer,
+			Ports:                    []corev1.ServicePort{{Port: 6443, TargetPort: intstr.FromInt(6444)}},
+			LoadBalancerSourceRanges: []string{"10.0.0.0/16"},
+		},
+	}
+	mocks := testutils.NewTestMock(t, []runtime.Object{aObj, infraObj, svc})
+	r := &ReconcileAPIScheme{client: mocks.FakeKubeClient}
+	// A rollback would delete the cloud resources, which the mock fails on
+	cloud := mockcc.NewMockCloudClient(ctrl)
+	cloud.EXPECT().GetAdminAPIInstanceHealth(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return([]cloudingressv1alpha1.RegisteredInstance{}, nil)
+	previous := cloudClient
+	cloudClient = cloud
+	defer func() { cloudClient = previous }()
+
+	request := reconcile.Request{NamespacedName: types.NamespacedName{Namespace: aObj.Namespace, Name: aObj.Name}}
+	for i := 0; i < 2; i++ {
+		if _, err := r.Reconcile(context.TODO(), request); err != nil {
+			t.Fatalf("Reconcile %d: unexpected error: %v", i+1, err)
+		}
+	}
+
+	instance := &cloudingressv1alpha1.APIScheme{}
+	if err := mocks.FakeKubeClient.Get(context.TODO(), request.NamespacedName, instance); err != nil {
+		t.Fatalf("Couldn't get the APIScheme: %v", err)
+	}
+	if condition := utils.FindAPISchemeCondition(instance.Status.Conditions, cloudingressv1alpha1.ConditionReady); condition == nil || condition.Status != corev1.ConditionTrue {
+		t.Fatalf("Expected the adopted APIScheme to be Ready. Got %+v", condition)
+	}
+	if instance.Status.RolledBackGeneration != 0 {
+		t.Fatalf("Expected the adopted APIScheme not to be rolled back. Got generation %d rolled back", instance.Status.RolledBackGeneration)
+	}
+	if err := mocks.FakeKubeClient.Get(context.TODO(), types.NamespacedName{Namespace: svc.Namespace, Name: svc.Name}, &corev1.Service{}); err != nil {
+		t.Fatalf("Expected the adopted Service to be kept. Got %v", err)
+	}
+}
+
+func TestUpdatedServicePorts(t *testing.T) {
+	port := func(name string, port, targetPort, nodePort int32) corev1.ServicePort {
+		return corev1.ServicePort{Name: name, Port: port, TargetPort: intstr.FromInt(int(targetPort)), NodePort: nodePort}
+	}
+	tests := []struct {
+		Name     string
+		Found    []corev1.ServicePort
+		Desired  []corev1.ServicePort
+		Expected []corev1.ServicePort
+	}{
+		{
+			Name:     "API server moved",
+			Found:    []corev1.ServicePort{port("", 6443, 6443, 30123)},
+			Desired:  []corev1.ServicePort{port("", 6443, 8443, 0)},
+			Expected: []corev1.ServicePort{port("", 6443, 8443, 30123)},
+		},
+		{
+			Name:     "Listener moved to 443",
+			Found:    []corev1.ServicePort{port("", 6443, 6443, 30123)},
+			Desired:  []corev1.ServicePort{port("api-443", 443, 6443, 0)},
+			Expected: []corev1.ServicePort{port("api-443", 443, 6443, 30123)},
+		},
+		{
+			Name:     "Listener added",
+			Found:    []corev1.ServicePort{port("", 6443, 6443, 30123)},
+			Desired:  []corev1.ServicePort{port("api-443", 443, 6443, 0), port("api-6443", 6443, 6443, 0)},
+			Expected: []corev1.ServicePort{port("api-443", 443, 6443, 0), port("api-6443", 6443, 6443, 30123)},
+		},
+	}
+	for _, test := range tests {
+		if servicePortsMatch(test.Found, test.Desired) {
+			t.Fatalf("Test [%v] FAILED. Expected the ports not to match", test.Name)
+		}
+		actual := updatedServicePorts(test.Found, test.Desired)
+		if !reflect.DeepEqual(actual, test.Expected) {
+			t.Fatalf("Test [%v] FAILED. Expected %v. Got %v", test.Name, test.Expected, actual)
+		}
+	}
+}
+
+func TestOwnedByAnotherAPIScheme(t *testing.T) {
+	instance := &cloudingressv1alpha1.APIScheme{
+		ObjectMeta: metav1.ObjectMeta{Name: "rh-api", Namespace: "fleet-a"},
+	}
+	tests := []struct {
+		Name     string
+		Labels   map[string]string
+		Expected bool
+	}{
+		{
+			Name:     "Created for the APIScheme",
+			Labels:   (&ReconcileAPIScheme{}).newServiceFor(instance, &cloudingressv1alpha1.CloudIngressConfig{}, nil, []corev1.ServicePort{{Port: 6443}}).Labels,
+			Expected: false,
+		},
+		{
+			Name:     "Created before the namespace label",
+			Labels:   map[string]string{apiSchemeNameLabel: "rh-api"},
+			Expected: false,
+		},
+		{
+			Name:     "Not labelled",
+			Expected: false,
+		},
+		{
+			Name:     "Another name",
+			Labels:   map[string]string{apiSchemeNameLabel: "other"},
+			Expected: true,
+		},
+		{
+			Name:     "Another namespace",
+			Labels:   map[string]string{apiSchemeNameLabel: "rh-api", apiSchemeNamespaceLabel: "fleet-b"},
+			Expected: true,
+		},
+	}
+	for _, test := range tests {
+		svc := &corev1.Service{ObjectMeta: metav1.ObjectMeta{Labels: test.Labels}}
+		actual := ownedByAnotherAPIScheme(instance, svc)
+		if actual != test.Expected {
+			t.Fatalf("Test [%v] FAILED. Expected %v. Got %v", test.Name, test.Expected, actual)
+		}
+	}
+}
+
+func TestDrivingAPIScheme(t *testing.T) {
+	older := metav1.NewTime(time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC))
+	newer := metav1.NewTime(older.Add(time.Hour))
+	apiScheme := func(namespace string, created metav1.Time, enabled bool) cloudingressv1alpha1.APIScheme {
+		return cloudingressv1alpha1.APIScheme{
+			ObjectMeta: metav1.ObjectMeta{Name: "rh-api", Namespace: namespace, CreationTimestamp: created},
+			Spec: cloudingressv1alpha1.APISchemeSpec{
+				ManagementAPIServerIngress: cloudingressv1alpha1.ManagementAPIServerIngress{Enabled: enabled},
+			},
+		}
+	}
+	tests := []struct {
+		Name       string
+		APISchemes []cloudingressv1alpha1.APIScheme
+		Expected   string
+	}{
+		{
+			Name:     "None",
+			Expected: "",
+		},
+		{
+			Name:       "Only one",
+			APISchemes: []cloudingressv1alpha1.APIScheme{apiScheme("fleet-a", newer, true)},
+			Expected:   "fleet-a",
+		},
+		{
+			Name:       "Oldest",
+			APISchemes: []cloudingressv1alpha1.APIScheme{apiScheme("fleet-a", newer, true), apiScheme("fleet-b", older, true)},
+			Expected:   "fleet-b",
+		},
+		{
+			Name:       "Oldest disabled",
+			APISchemes: []cloudingressv1alpha1.APIScheme{apiScheme("fleet-a", newer, true), apiScheme("fleet-b", older, false)},
+			Expected:   "fleet-a",
+		},
+		{
+			Name:       "Created at once",
+			APISchemes: []cloudingressv1alpha1.APIScheme{apiScheme("fleet-b", older, true), apiScheme("fleet-a", older, true)},
+			Expected:   "fleet-a",
+		},
+		{
+			Name:       "All disabled",
+			APISchemes: []cloudingressv1alpha1.APIScheme{apiScheme("fleet-a", older, false)},
+			Expected:   "",
+		},
+	}
+	for _, test := range tests {
+		actual := ""
+		if driving := drivingAPIScheme(test.APISchemes); driving != nil {
+			actual = driving.Namespace
+		}
+		if actual != test.Expected {
+			t.Fatalf("Test [%v] FAILED. Expected %v. Got %v", test.Name, test.Expected, actual)
+		}
+	}
+}
+
+func TestTemporaryPublicAccess(t *testing.T) {
+	now := time.Date(2026, 10, 15, 12, 0, 0, 0, time.UTC)
+	instance := &cloudingressv1alpha1.APIScheme{
+		Spec: cloudingressv1alpha1.APISchemeSpec{
+			ManagementAPIServerIngress: cloudingressv1alpha1.ManagementAPIServerIngress{
+				Scheme:                cloudingressv1alpha1.LoadBalancerSchemeInternal,
+				TemporaryPublicAccess: &cloudingressv1alpha1.TemporaryPublicAccess{Duration: metav1.Duration{Duration: 2 * time.Hour}},
+			},
+		},
+	}
+
+	if !updateTemporaryPublicAccess(instance, now) {
+		t.Fatal("Expected a new period to be recorded")
+	}
+	if !instance.Status.TemporaryPublicAccess.ExpiresAt.Time.Equal(now.Add(2 * time.Hour)) {
+		t.Fatalf("Expected the period to expire in 2h. Got %v", instance.Status.TemporaryPublicAccess.ExpiresAt)
+	}
+	if updateTemporaryPublicAccess(instance, now.Add(time.Hour)) {
+		t.Fatal("Expected the period to be kept")
+	}
+	if !temporarilyPublic(instance, now.Add(time.Hour)) {
+		t.Fatal("Expected the admin API to be public during the period")
+	}
+	if temporarilyPublic(instance, now.Add(3*time.Hour)) {
+		t.Fatal("Expected the admin API to be internal again once the period expired")
+	}
+
+	// A new duration starts a new period
+	instance.Spec.ManagementAPIServerIngress.TemporaryPublicAccess.Duration.Duration = time.Hour
+	if !updateTemporaryPublicAccess(instance, now.Add(3*time.Hour)) || !temporarilyPublic(instance, now.Add(3*time.Hour)) {
+		t.Fatalf("Expected a new period. Got %+v", instance.Status.TemporaryPublicAccess)
+	}
+
+	instance.Spec.ManagementAPIServerIngress.TemporaryPublicAccess = nil
+	if !updateTemporaryPublicAccess(instance, now) || instance.Status.TemporaryPublicAccess != nil {
+		t.Fatalf("Expected the period to be forgotten. Got %+v", instance.Status.TemporaryPublicAccess)
+	}
+}
+
+func TestRecordFailures(t *testing.T) {
+	aObj := testutils.CreateAPISchemeObject("rh-api", true, []string{"0.0.0.0/0"})
+	mocks := testutils.NewTestMock(t, []runtime.Object{aObj})
+	r := &ReconcileAPIScheme{client: mocks.FakeKubeClient}
+
+	for i := 1; i <= utils.DegradedAfterFailures; i++ {
+		r.recordFailures(aObj, fmt.Errorf("error %d", i%2))
+		if aObj.Status.Failures == nil || aObj.Status.Failures.Count != int32(i) {
+			t.Fatalf("Expected %d failures. Got %+v", i, aObj.Status.Failures)
+		}
+		degraded := utils.FindAPISchemeCondition(aObj.Status.Conditions, cloudingressv1alpha1.ConditionDegraded)
+		if (degraded != nil) != (i == utils.DegradedAfterFailures) {
+			t.Fatalf("Unexpected Degraded condition after %d failures: %+v", i, degraded)
+		}
+	}
+	if !reflect.DeepEqual(aObj.Status.Failures.Errors, []string{"error 0", "error 1"}) {
+		t.Fatalf("Expected the distinct errors, most recent last. Got %v", aObj.Status.Failures.Errors)
+	}
+
+	r.recordFailures(aObj, nil)
+	if aObj.Status.Failures != nil {
+		t.Fatalf("Expected the streak to end. Got %+v", aObj.Status.Failures)
+	}
+	degraded := utils.FindAPISchemeCondition(aObj.Status.Conditions, cloudingressv1alpha1.ConditionDegraded)
+	if degraded == nil || degraded.Status != corev1.ConditionFalse {
+		t.Fatalf("Expected the Degraded condition to be False. Got %+v", degraded)
+	}
+}
+
+func TestVerifyDNS(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	aObj := testutils.CreateAPISchemeObject("rh-api", true, []string{"0.0.0.0/0"})
+	infraObj := testutils.CreateInfraObject("basename", testutils.DefaultAPIEndpoint, testutils.DefaultAPIEndpoint, testutils.DefaultRegionName)
+	mocks := testutils.NewTestMock(t, []runtime.Object{aObj, infraObj})
+	r := &ReconcileAPIScheme{client: mocks.FakeKubeClient}
+	cloud := mockcc.NewMockCloudClient(ctrl)
+	previous := cloudClient
+	cloudClient = cloud
+	defer func() { cloudClient = previous }()
+	svc := &corev1.Service{}
+
+	tests := []struct {
+		Name         string
+		Resolvers    []string
+		Disconnected bool
+		Expected     corev1.ConditionStatus
+	}{
+		{
+			Name:     "Only private zones",
+			Expected: corev1.ConditionUnknown,
+		},
+		{
+			Name:      "Unreachable resolver",
+			Resolvers: []string{"127.0.0.1:1"},
+			Expected:  corev1.ConditionFalse,
+		},
+		{
+			// The public nameservers aren't even looked up
+			Name:         "Disconnected",
+			Resolvers:    []string{"127.0.0.1:1"},
+			Disconnected: true,
+			Expected:     corev1.ConditionFalse,
+		},
+	}
+	for _, test := range tests {
+		if !test.Disconnected {
+			cloud.EXPECT().GetAdminAPINameServers(context.TODO(), mocks.FakeKubeClient, aObj).Return([]string{}, nil)
+		}
+		err := r.verifyDNS(context.TODO(), aObj, svc, &cloudingressv1alpha1.DNSVerification{Resolvers: test.Resolvers}, test.Disconnected)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", test.Name, err)
+		}
+		condition := utils.FindAPISchemeCondition(aObj.Status.Conditions, cloudingressv1alpha1.ConditionDNSVerified)
+		if condition == nil || condition.Status != test.Expected {
+			t.Errorf("%s: expected the DNSVerified condition to be %s. Got %+v", test.Name, test.Expected, condition)
+		}
+	}
+}
+
+func TestDNSZoneRetryDelay(t *testing.T) {
+	tests := []struct {
+		Name     string
+		Waited   time.Duration
+		Expected time.Duration
+	}{
+		{
+			Name:     "Just started waiting",
+			Expected: RequeueIntervals.Error,
+		},
+		{
+			Name:     "Waited a while",
+			Waited:   2 * time.Minute,
+			Expected: 2 * time.Minute,
+		},
+		{
+			Name:     "Waited long",
+			Waited:   40 * time.Minute,
+			Expected: MaxDNSZoneRetryDelay,
+		},
+	}
+	for _, test := range tests {
+		actual := dnsZoneRetryDelay(test.Waited)
+		if actual != test.Expected {
+			t.Fatalf("Test [%v] FAILED. Expected %v. Got %v", test.Name, test.Expected, actual)
+		}
+	}
+}
+
+func TestWaitForDNSZone(t *testing.T) {
+	zoneErr := cioerrors.NewDNSZoneNotFoundError("Route53 Zone for unit.test.")
+	tests := []struct {
+		Name          string
+		ClusterAge    time.Duration
+		ExpectedState cloudingressv1alpha1.APISchemeConditionType
+		ErrorExpected bool
+	}{
+		{
+			Name:          "New cluster",
+			ClusterAge:    10 * time.Minute,
+			ExpectedState: cloudingressv1alpha1.ConditionWaitingForDNSZone,
+		},
+		{
+			Name:          "Grace period over",
+			ClusterAge:    DNSZoneGracePeriod + time.Minute,
+			ExpectedState: cloudingressv1alpha1.ConditionError,
+			ErrorExpected: true,
+		},
+	}
+	for _, test := range tests {
+		aObj := testutils.CreateAPISchemeObject("rh-api", true, []string{"0.0.0.0/0"})
+		infraObj := testutils.CreateInfraObject("basename", testutils.DefaultAPIEndpoint, testutils.DefaultAPIEndpoint, testutils.DefaultRegionName)
+		infraObj.CreationTimestamp = metav1.NewTime(time.Now().Add(-test.ClusterAge))
+		mocks := testutils.NewTestMock(t, []runtime.Object{aObj, infraObj})
+		r := &ReconcileAPIScheme{client: mocks.FakeKubeClient}
+
+		result, err := r.waitForDNSZone(aObj, zoneErr)
+		if (err != nil) != test.ErrorExpected {
+			t.Fatalf("Test [%v] FAILED. Expected error %v. Got %v", test.Name, test.ErrorExpected, err)
+		}
+		if aObj.Status.State != test.ExpectedState {
+			t.Fatalf("Test [%v] FAILED. Expected state %v. Got %v", test.Name, test.ExpectedState, aObj.Status.State)
+		}
+		actionRequired := utils.FindAPISchemeCondition(aObj.Status.Conditions, cloudingressv1alpha1.ConditionActionRequired)
+		if test.ErrorExpected && (actionRequired == nil || actionRequired.Reason != cloudingressv1alpha1.ActionRequiredDNSZoneMissing) {
+			t.Fatalf("Test [%v] FAILED. Expected the ActionRequired condition with reason %v. Got %v", test.Name, cloudingressv1alpha1.ActionRequiredDNSZoneMissing, actionRequired)
+		}
+		if !test.ErrorExpected && result.RequeueAfter != RequeueIntervals.Error {
+			t.Fatalf("Test [%v] FAILED. Expected a requeue after %v. Got %v", test.Name, RequeueIntervals.Error, result)
+		}
+	}
+}
+
+func TestUnsupportedFields(t *testing.T) {
+	tests := []struct {
+		Name         string
+		Capabilities cloudclient.Capabilities
+		Spec         cloudingressv1alpha1.ManagementAPIServerIngress
+		Expected     []string
+	}{
+		{
+			Name:         "Everything supported",
+			Capabilities: cloudclient.Capabilities{SupportsStaticIP: true, SupportsAccessLogs: true, SupportsAlarms: true, SupportsTLSPolicy: true},
+			Spec: cloudingressv1alpha1.ManagementAPIServerIngress{
+				StaticIP:          &cloudingressv1alpha1.StaticIP{},
+				AccessLogs:        &cloudingressv1alpha1.AccessLogs{S3BucketName: "logs"},
+				TLSSecurityPolicy: "ELBSecurityPolicy-TLS-1-2-2017-01",
+			},
+			Expected: []string{},
+		},
+		{
+			Name:         "Unsupported fields left unset",
+			Capabilities: cloudclient.Capabilities{SupportsStaticIP: true},
+			Spec:         cloudingressv1alpha1.ManagementAPIServerIngress{StaticIP: &cloudingressv1alpha1.StaticIP{}},
+			Expected:     []string{},
+		},
+		{
+			Name:         "Unsupported fields set",
+			Capabilities: cloudclient.Capabilities{SupportsStaticIP: true},
+			Spec: cloudingressv1alpha1.ManagementAPIServerIngress{
+				AccessLogs:          &cloudingressv1alpha1.AccessLogs{S3BucketName: "logs"},
+				Alarms:              &cloudingressv1alpha1.Alarms{},
+				TLSSecurityPolicy:   "ELBSecurityPolicy-TLS-1-2-2017-01",
+				CertificateARN:      "arn:aws:acm:us-east-1:123456789012:certificate/rh-api",
+				Stickiness:          &cloudingressv1alpha1.Stickiness{SourceIP: true},
+				BackendNodeSelector: map[string]string{"node-role.kubernetes.io/infra": ""},
+				DNSTTLSeconds:       300,
+			},
+			Expected: []string{"accessLogs", "alarms", "tlsSecurityPolicy", "certificateARN", "stickiness", "backendNodeSelector", "dnsTTLSeconds"},
+		},
+	}
+	for _, test := range tests {
+		aObj := testutils.CreateAPISchemeObject("rh-api", true, []string{"0.0.0.0/0"})
+		aObj.Spec.ManagementAPIServerIngress = test.Spec
+		if fields := unsupportedFields(test.Capabilities, aObj); !reflect.DeepEqual(fields, test.Expected) {
+			t.Fatalf("Test [%v] FAILED. Expected %v. Got %v", test.Name, test.Expected, fields)
+		}
+	}
+}
+
+func TestRepairDriftIfDueConcurrently(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	infraObj := testutils.CreateInfraObject("basename", testutils.DefaultAPIEndpoint, testutils.DefaultAPIEndpoint, testutils.DefaultRegionName)
+	mocks := testutils.NewTestMock(t, []runtime.Object{infraObj})
+	r := &ReconcileAPIScheme{client: mocks.FakeKubeClient}
+	cloud := mockcc.NewMockCloudClient(ctrl)
+	previous := cloudClient
+	cloudClient = cloud
+	defer func() { cloudClient = previous }()
+
+	const workers = 5
+	// Each APIScheme is checked once, however many reconciles run at once
+	cloud.EXPECT().RepairAdminAPIDrift(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return([]string{}, nil).Times(workers)
+	for round := 0; round < 2; round++ {
+		var wg sync.WaitGroup
+		errs := make(chan error, workers)
+		for i := 0; i < workers; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				name := types.NamespacedName{Namespace: "openshift-cloud-ingress-operator", Name: fmt.Sprintf("rh-api-%d", i)}
+				aObj := testutils.CreateAPISchemeObject(name.Name, true, []string{"0.0.0.0/0"})
+				errs <- r.repairDriftIfDue(context.TODO(), name, aObj, &corev1.Service{}, nil)
+			}(i)
+		}
+		wg.Wait()
+		close(errs)
+		for err := range errs {
+			if err != nil {
+				t.Fatalf("Round %d: unexpected error: %v", round, err)
+			}
+		}
+	}
+}
+
+func TestLoadBalancerCompliance(t *testing.T) {
+	enabled, disabled := true, false
+	idleTimeout, otherIdleTimeout := int64(1800), int64(60)
+	tests := []struct {
+		Name       string
+		Policy     *cloudingressv1alpha1.LoadBalancerAttributes
+		Attributes *cloudingressv1alpha1.LoadBalancerAttributes
+		Expected   map[string]bool
+	}{
+		{
+			Name:       "Nothing is audited without a policy",
+			Attributes: &cloudingressv1alpha1.LoadBalancerAttributes{CrossZone: &enabled},
+			Expected:   map[string]bool{},
+		},
+		{
+			Name:       "Attributes the policy sets are compared",
+			Policy:     &cloudingressv1alpha1.LoadBalancerAttributes{CrossZone: &enabled, IdleTimeoutSeconds: &idleTimeout},
+			Attributes: &cloudingressv1alpha1.LoadBalancerAttributes{CrossZone: &enabled, ConnectionDraining: &disabled, IdleTimeoutSeconds: &otherIdleTimeout, AccessLogs: &disabled},
+			Expected:   map[string]bool{"crossZone": true, "idleTimeout": false},
+		},
+		{
+			Name:       "Attributes the load balancer doesn't have are skipped",
+			Policy:     &cloudingressv1alpha1.LoadBalancerAttributes{ConnectionDraining: &enabled, AccessLogs: &enabled},
+			Attributes: &cloudingressv1alpha1.LoadBalancerAttributes{CrossZone: &disabled, AccessLogs: &disabled},
+			Expected:   map[string]bool{"accessLogs": false},
+		},
+	}
+	for _, test := range tests {
+		compliance := loadBalancerCompliance(test.Policy, test.Attributes)
+		if !reflect.DeepEqual(compliance, test.Expected) {
+			t.Fatalf("Test [%v] FAILED. Expected %v. Got %v", test.Name, test.Expected, compliance)
+		}
+	}
+}
+
+func TestVerify(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	infraObj := testutils.CreateInfraObject("basename", testutils.DefaultAPIEndpoint, testutils.DefaultAPIEndpoint, testutils.DefaultRegionName)
+	svc := &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "rh-api", Namespace: "openshift-kube-apiserver"}}
+	tests := []struct {
+		Name            string
+		Enabled         bool
+		Service         bool
+		DriftErr        error
+		ExpectedChanges int
+		ErrorExpected   bool
+	}{
+		{
+			Name: "Disabled",
+		},
+		{
+			Name:            "No Service",
+			Enabled:         true,
+			ExpectedChanges: 3,
+		},
+		{
+			Name:          "Drift check failed",
+			Enabled:       true,
+			Service:       true,
+			DriftErr:      fmt.Errorf("load balancer not ready"),
+			ErrorExpected: true,
+		},
+	}
+	for _, test := range tests {
+		objs := []runtime.Object{infraObj.DeepCopy()}
+		if test.Service {
+			objs = append(objs, svc.DeepCopy())
+		}
+		mocks := testutils.NewTestMock(t, objs)
+		cloud := mockcc.NewMockCloudClient(ctrl)
+		if test.Service {
+			cloud.EXPECT().CheckAdminAPIDrift(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, test.DriftErr)
+		}
+		aObj := testutils.CreateAPISchemeObject("rh-api", test.Enabled, []string{"0.0.0.0/0"})
+
+		changes, err := Verify(context.TODO(), mocks.FakeKubeClient, cloud, aObj)
+		if (err != nil) != test.ErrorExpected {
+			t.Fatalf("Test [%v] FAILED. Expected an error: %v. Got %v", test.Name, test.ErrorExpected, err)
+		}
+		if !test.ErrorExpected && len(changes) != test.ExpectedChanges {
+			t.Fatalf("Test [%v] FAILED. Expected %v changes. Got %v", test.Name, test.ExpectedChanges, changes)
+		}
+	}
+}
+
+func TestCheckLoadBalancerQuota(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mocks := testutils.NewTestMock(t, []runtime.Object{})
+	r := &ReconcileAPIScheme{client: mocks.FakeKubeClient}
+	cloud := mockcc.NewMockCloudClient(ctrl)
+
+	tests := []struct {
+		Name              string
+		Used              int
+		Limit             int
+		QueryErr          error
+		ExpectedCondition corev1.ConditionStatus
+		ErrorExpected     bool
+	}{
+		{
+			Name:              "Room left",
+			Used:              5,
+			Limit:             20,
+			ExpectedCondition: corev1.ConditionFalse,
+		},
+		{
+			Name:              "Nearly used up",
+			Used:              18,
+			Limit:             20,
+			ExpectedCondition: corev1.ConditionTrue,
+		},
+		{
+			Name:          "Used up",
+			Used:          20,
+			Limit:         20,
+			ErrorExpected: true,
+		},
+		{
+			Name:     "Quota can't be read",
+			QueryErr: fmt.Errorf("AccessDenied"),
+		},
+	}
+	for _, test := range tests {
+		aObj := testutils.CreateAPISchemeObject("rh-api", true, []string{"0.0.0.0/0"})
+		cloud.EXPECT().GetLoadBalancerQuota(context.TODO(), mocks.FakeKubeClient, true).Return(test.Used, test.Limit, test.QueryErr)
+		err := r.checkLoadBalancerQuota(context.TODO(), cloud, aObj, true)
+		if test.ErrorExpected {
+			if _, ok := err.(*cioerrors.ActionRequiredError); !ok {
+				t.Fatalf("Test [%v] FAILED. Expected an ActionRequiredError. Got %v", test.Name, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("Test [%v] FAILED. Unexpected error %v", test.Name, err)
+		}
+		condition := utils.FindAPISchemeCondition(aObj.Status.Conditions, cloudingressv1alpha1.ConditionQuotaNearExhaustion)
+		if test.ExpectedCondition == "" {
+			if condition != nil {
+				t.Fatalf("Test [%v] FAILED. Expected no QuotaNearExhaustion condition. Got %+v", test.Name, condition)
+			}
+			continue
+		}
+		if condition == nil || condition.Status != test.ExpectedCondition {
+			t.Fatalf("Test [%v] FAILED. Expected %v. Got %+v", test.Name, test.ExpectedCondition, condition)
+		}
+	}
+}
+
+func TestEnsureExternalDNSHostname(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	aObj := testutils.CreateAPISchemeObject("rh-api", true, []string{"0.0.0.0/0"})
+	aObj.Spec.ManagementAPIServerIngress.DNSManagement = cloudingressv1alpha1.DNSManagementExternalDNS
+	infraObj := testutils.CreateInfraObject("basename", testutils.DefaultAPIEndpoint, testutils.DefaultAPIEndpoint, testutils.DefaultRegionName)
+	svc := &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "rh-api", Namespace: "openshift-kube-apiserver"}}
+	mocks := testutils.NewTestMock(t, []runtime.Object{aObj, infraObj, svc})
+	r := &ReconcileAPIScheme{client: mocks.FakeKubeClient}
+	cloud := mockcc.NewMockCloudClient(ctrl)
+
+	// The records the operator published are only deleted on the handover
+	cloud.EXPECT().DeleteAdminAPIDNS(context.TODO(), mocks.FakeKubeClient, aObj, gomock.Any()).Return(nil).Times(1)
+	for round := 0; round < 2; round++ {
+		found := &corev1.Service{}
+		err := mocks.FakeKubeClient.Get(context.TODO(), types.NamespacedName{Name: "rh-api", Namespace: "openshift-kube-apiserver"}, found)
+		if err != nil {
+			t.Fatalf("Round %d: unexpected error %v", round, err)
+		}
+		if err = r.ensureExternalDNSHostname(context.TODO(), cloud, aObj, found); err != nil {
+			t.Fatalf("Round %d: unexpected error %v", round, err)
+		}
+		if found.Annotations[externalDNSHostnameAnnotationKey] != "rh-api.unit.test" {
+			t.Fatalf("Round %d: expected the hostname annotation rh-api.unit.test. Got %q", round, found.Annotations[externalDNSHostnameAnnotationKey])
+		}
+	}
+}
+
+func TestRecoverDeletedLoadBalancer(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	aObj := testutils.CreateAPISchemeObject("rh-api", true, []string{"0.0.0.0/0"})
+	ingress := []corev1.LoadBalancerIngress{{Hostname: "a0123456789.us-east-1.elb.amazonaws.com"}}
+	recent := time.Now().UTC().Format(time.RFC3339)
+	stale := time.Now().Add(-2 * LoadBalancerRecoveryInterval).UTC().Format(time.RFC3339)
+	tests := []struct {
+		Name              string
+		Ingress           []corev1.LoadBalancerIngress
+		Exists            bool
+		Requested         string
+		ExpectedCheck     bool
+		ExpectedRecovery  bool
+		ExpectedRequested bool
+	}{
+		{
+			Name:    "A load balancer never provisioned is still being created",
+			Ingress: nil,
+		},
+		{
+			Name:          "A load balancer that exists isn't ready yet",
+			Ingress:       ingress,
+			Exists:        true,
+			ExpectedCheck: true,
+		},
+		{
+			Name:              "A deleted load balancer is recreated",
+			Ingress:           ingress,
+			ExpectedCheck:     true,
+			ExpectedRecovery:  true,
+			ExpectedRequested: true,
+		},
+		{
+			Name:             "A load balancer being recreated is waited for",
+			Ingress:          ingress,
+			Requested:        recent,
+			ExpectedCheck:    true,
+			ExpectedRecovery: true,
+		},
+		{
+			Name:              "A load balancer still missing is asked for again",
+			Ingress:           ingress,
+			Requested:         stale,
+			ExpectedCheck:     true,
+			ExpectedRecovery:  true,
+			ExpectedRequested: true,
+		},
+	}
+	for _, test := range tests {
+		svc := &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "rh-api", Namespace: "openshift-kube-apiserver"}}
+		svc.Status.LoadBalancer.Ingress = test.Ingress
+		if test.Requested != "" {
+			svc.Annotations = map[string]string{lbRecoveryAnnotationKey: test.Requested}
+		}
+		mocks := testutils.NewTestMock(t, []runtime.Object{aObj, svc})
+		r := &ReconcileAPIScheme{client: mocks.FakeKubeClient}
+		cloud := mockcc.NewMockCloudClient(ctrl)
+		found := &corev1.Service{}
+		if err := mocks.FakeKubeClient.Get(context.TODO(), types.NamespacedName{Name: "rh-api", Namespace: "openshift-kube-apiserver"}, found); err != nil {
+			t.Fatalf("Test [%v] unexpected error %v", test.Name, err)
+		}
+		if test.ExpectedCheck {
+			cloud.EXPECT().AdminAPILoadBalancerExists(gomock.Any(), gomock.Any(), aObj, gomock.Any()).Return(test.Exists, nil).Times(1)
+		}
+		recovering, err := r.recoverDeletedLoadBalancer(context.TODO(), cloud, aObj, found)
+		if err != nil {
+			t.Fatalf("Test [%v] unexpected error %v", test.Name, err)
+		}
+		if recovering != test.ExpectedRecovery {
+			t.Fatalf("Test [%v] FAILED. Expected recovering %v. Got %v", test.Name, test.ExpectedRecovery, recovering)
+		}
+		requested := found.Annotations[lbRecoveryAnnotationKey] != test.Requested
+		if requested != test.ExpectedRequested {
+			t.Fatalf("Test [%v] FAILED. Expected to ask for the load balancer again %v. Got %v", test.Name, test.ExpectedRequested, requested)
+		}
+	}
+
+	// Once it's back, the recovery is recorded as complete
+	svc := &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "rh-api", Namespace: "openshift-kube-apiserver", Annotations: map[string]string{lbRecoveryAnnotationKey: recent}}}
+	mocks := testutils.NewTestMock(t, []runtime.Object{aObj, svc})
+	r := &ReconcileAPIScheme{client: mocks.FakeKubeClient}
+	found := &corev1.Service{}
+	if err := mocks.FakeKubeClient.Get(context.TODO(), types.NamespacedName{Name: "rh-api", Namespace: "openshift-kube-apiserver"}, found); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if err := r.completeLoadBalancerRecovery(context.TODO(), aObj, found); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if metav1.HasAnnotation(found.ObjectMeta, lbRecoveryAnnotationKey) {
+		t.Fatalf("Expected the recovery annotation to be removed. Got %v", found.Annotations)
+	}
+}
@@ -0,0 +1,384 @@
+package clusteroperator
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	configv1 "github.com/openshift/api/config/v1"
+	"github.com/openshift/cloud-ingress-operator/config"
+	cloudingressv1alpha1 "github.com/openshift/cloud-ingress-operator/pkg/apis/cloudingress/v1alpha1"
+	utils "github.com/openshift/cloud-ingress-operator/pkg/controller/utils"
+	"github.com/openshift/cloud-ingress-operator/version"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+var log = logf.Log.WithName("controller_clusteroperator")
+
+// Reasons of the ClusterOperator conditions
+const (
+	reasonAsExpected          = "AsExpected"
+	reasonAdminAPIUnavailable = "AdminAPIUnavailable"
+	reasonTransitioning       = "Transitioning"
+	reasonReconcileFailing    = "ReconcileFailing"
+)
+
+// Add creates a new ClusterOperator Controller and adds it to the Manager. The
+// Manager will set fields on the Controller and Start it when the Manager is
+// Started.
+func Add(mgr manager.Manager) error {
+	return add(mgr, newReconciler(mgr))
+}
+
+// newReconciler returns a new reconcile.Reconciler
+func newReconciler(mgr manager.Manager) reconcile.Reconciler {
+	return &ReconcileClusterOperator{client: mgr.GetClient(), scheme: mgr.GetScheme()}
+}
+
+// add adds a new Controller to mgr with r as the reconcile.Reconciler. The
+// operator's ClusterOperator is the only object reconciled, whenever any of
+// the custom resources it sums up changes
+func add(mgr manager.Manager, r reconcile.Reconciler) error {
+	c, err := controller.New("clusteroperator-controller", mgr, controller.Options{Reconciler: r, MaxConcurrentReconciles: 1})
+	if err != nil {
+		return err
+	}
+
+	for _, obj := range []client.Object{&cloudingressv1alpha1.APIScheme{}, &cloudingressv1alpha1.PublishingStrategy{}} {
+		err = c.Watch(&source.Kind{Type: obj}, handler.EnqueueRequestsFromMapFunc(operatorRequest), predicate.ResourceVersionChangedPredicate{})
+		if err != nil {
+			return err
+		}
+	}
+
+	// Watch the ClusterOperator itself, so it's put back if it's deleted or
+	// changed by something else
+	p := predicate.NewPredicateFuncs(func(obj client.Object) bool {
+		return obj.GetName() == config.OperatorName
+	})
+	return c.Watch(&source.Kind{Type: &configv1.ClusterOperator{}}, handler.EnqueueRequestsFromMapFunc(operatorRequest), p)
+}
+
+// operatorRequest is a handler.MapFunc asking to reconcile the operator's
+// ClusterOperator
+func operatorRequest(_ client.Object) []reconcile.Request {
+	return []reconcile.Request{{NamespacedName: types.NamespacedName{Name: config.OperatorName}}}
+}
+
+// blank assignment to verify that ReconcileClusterOperator implements reconcile.Reconciler
+var _ reconcile.Reconciler = &ReconcileClusterOperator{}
+
+// ReconcileClusterOperator publishes the state of every endpoint the operator
+// manages in the status of its ClusterOperator, so cluster admins and OCM
+// find it in one place
+type ReconcileClusterOperator struct {
+	// This client, initialized using mgr.Client() above, is a split client
+	// that reads objects from the cache and writes to the apiserver
+	client client.Client
+	scheme *runtime.Scheme
+}
+
+// summary is the state of the endpoints, in the status.extension of the
+// ClusterOperator
+type summary struct {
+	// AdminAPI are the admin API endpoints, one per APIScheme
+	AdminAPI []adminAPISummary `json:"adminAPI"`
+	// Ingress are the default API and the application ingresses, one per
+	// PublishingStrategy
+	Ingress []ingressSummary `json:"ingress"`
+}
+
+// adminAPISummary is the state of the admin API endpoint of an APIScheme
+type adminAPISummary struct {
+	APIScheme    string `json:"apiScheme"`
+	Enabled      bool   `json:"enabled"`
+	Visibility   string `json:"visibility,omitempty"`
+	State        string `json:"state,omitempty"`
+	LoadBalancer string `json:"loadBalancer,omitempty"`
+	// DNS is the health of the admin API records: Verified, Unverified,
+	// WaitingForZone, Drifted, ExternalDNS or Managed
+	DNS      string `json:"dns"`
+	Degraded bool   `json:"degraded,omitempty"`
+}
+
+// ingressSummary is the state of the ingresses of a PublishingStrategy
+type ingressSummary struct {
+	PublishingStrategy string `json:"publishingStrategy"`
+	// DefaultAPI is the visibility of the default API, internal or external
+	DefaultAPI string `json:"defaultAPI"`
+	// DefaultIngress is the application ingress the cluster installed with
+	DefaultIngress *applicationIngressSummary `json:"defaultIngress,omitempty"`
+	// SecondaryIngresses are the other application ingresses
+	SecondaryIngresses []applicationIngressSummary `json:"secondaryIngresses,omitempty"`
+	Degraded           bool                        `json:"degraded,omitempty"`
+}
+
+// applicationIngressSummary is the state of an ApplicationIngress
+type applicationIngressSummary struct {
+	DNSName           string `json:"dnsName"`
+	Visibility        string `json:"visibility"`
+	IngressController string `json:"ingressController,omitempty"`
+	LoadBalancer      string `json:"loadBalancer,omitempty"`
+}
+
+// Reconcile creates the operator's ClusterOperator if it's missing, and sets
+// its status from the APISchemes and PublishingStrategies
+func (r *ReconcileClusterOperator) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	apiSchemes := &cloudingressv1alpha1.APISchemeList{}
+	if err := r.client.List(ctx, apiSchemes); err != nil {
+		return reconcile.Result{}, err
+	}
+	publishingStrategies := &cloudingressv1alpha1.PublishingStrategyList{}
+	if err := r.client.List(ctx, publishingStrategies); err != nil {
+		return reconcile.Result{}, err
+	}
+
+	co := &configv1.ClusterOperator{}
+	err := r.client.Get(ctx, types.NamespacedName{Name: config.OperatorName}, co)
+	if errors.IsNotFound(err) {
+		co = &configv1.ClusterOperator{ObjectMeta: metav1.ObjectMeta{Name: config.OperatorName}}
+		if err = r.client.Create(ctx, co); err != nil {
+			return reconcile.Result{}, err
+		}
+		log.Info("Created the ClusterOperator", "name", config.OperatorName)
+	} else if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	status, err := clusterOperatorStatus(co.Status, apiSchemes.Items, publishingStrategies.Items, metav1.Now())
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	if statusEqual(co.Status, status) {
+		return reconcile.Result{}, nil
+	}
+	co.Status = status
+	if err = r.client.Status().Update(ctx, co); err != nil {
+		return reconcile.Result{}, err
+	}
+	log.Info("Updated the ClusterOperator status", "name", config.OperatorName)
+	return reconcile.Result{}, nil
+}
+
+// clusterOperatorStatus returns the status of the ClusterOperator summing up
+// apiSchemes and publishingStrategies. The transition times of the conditions
+// of current that keep their status are kept
+func clusterOperatorStatus(current configv1.ClusterOperatorStatus, apiSchemes []cloudingressv1alpha1.APIScheme, publishingStrategies []cloudingressv1alpha1.PublishingStrategy, now metav1.Time) (configv1.ClusterOperatorStatus, error) {
+	s := summary{AdminAPI: []adminAPISummary{}, Ingress: []ingressSummary{}}
+	relatedObjects := []configv1.ObjectReference{{Resource: "namespaces", Name: config.OperatorNamespace}}
+	unavailable, progressing, degraded := []string{}, []string{}, []string{}
+
+	for _, instance := range apiSchemes {
+		name := instance.Namespace + "/" + instance.Name
+		relatedObjects = append(relatedObjects, configv1.ObjectReference{
+			Group:     cloudingressv1alpha1.SchemeGroupVersion.Group,
+			Resource:  "apischemes",
+			Namespace: instance.Namespace,
+			Name:      instance.Name,
+		})
+		adminAPI := adminAPISummarize(instance)
+		s.AdminAPI = append(s.AdminAPI, adminAPI)
+		if !adminAPI.Enabled || conditionTrue(instance.Status.Conditions, cloudingressv1alpha1.ConditionRejected) ||
+			conditionTrue(instance.Status.Conditions, cloudingressv1alpha1.ConditionUnsupported) {
+			continue
+		}
+		if adminAPI.LoadBalancer == "" {
+			unavailable = append(unavailable, fmt.Sprintf("APIScheme %s has no load balancer", name))
+		}
+		if adminAPI.Visibility == string(cloudingressv1alpha1.APIEndpointTransitioning) {
+			progressing = append(progressing, fmt.Sprintf("APIScheme %s is transitioning", name))
+		}
+		if instance.Status.NLBMigration != nil {
+			progressing = append(progressing, fmt.Sprintf("APIScheme %s is migrating to a network load balancer", name))
+		}
+		if adminAPI.Degraded {
+			degraded = append(degraded, fmt.Sprintf("APIScheme %s is degraded", name))
+		}
+	}
+
+	for _, instance := range publishingStrategies {
+		name := instance.Namespace + "/" + instance.Name
+		relatedObjects = append(relatedObjects, configv1.ObjectReference{
+			Group:     cloudingressv1alpha1.SchemeGroupVersion.Group,
+			Resource:  "publishingstrategies",
+			Namespace: instance.Namespace,
+			Name:      instance.Name,
+		})
+		ingress := ingressSummarize(instance)
+		s.Ingress = append(s.Ingress, ingress)
+		for _, applicationIngress := range append(ingressList(ingress.DefaultIngress), ingress.SecondaryIngresses...) {
+			if applicationIngress.LoadBalancer == "" {
+				progressing = append(progressing, fmt.Sprintf("application ingress %s of PublishingStrategy %s has no load balancer yet", applicationIngress.DNSName, name))
+			}
+		}
+		if instance.Status.DefaultAPIServerIngress != nil {
+			progressing = append(progressing, fmt.Sprintf("PublishingStrategy %s is draining the external API load balancer", name))
+		}
+		if ingress.Degraded {
+			degraded = append(degraded, fmt.Sprintf("PublishingStrategy %s is degraded", name))
+		}
+	}
+
+	extension, err := json.Marshal(s)
+	if err != nil {
+		return configv1.ClusterOperatorStatus{}, err
+	}
+	return configv1.ClusterOperatorStatus{
+		Conditions: []configv1.ClusterOperatorStatusCondition{
+			clusterOperatorCondition(current.Conditions, configv1.OperatorAvailable, len(unavailable) == 0, reasonAdminAPIUnavailable, unavailable, now),
+			clusterOperatorCondition(current.Conditions, configv1.OperatorProgressing, len(progressing) != 0, reasonTransitioning, progressing, now),
+			clusterOperatorCondition(current.Conditions, configv1.OperatorDegraded, len(degraded) != 0, reasonReconcileFailing, degraded, now),
+		},
+		Versions:       []configv1.OperandVersion{{Name: "operator", Version: version.Version}},
+		RelatedObjects: relatedObjects,
+		Extension:      runtime.RawExtension{Raw: extension},
+	}, nil
+}
+
+// clusterOperatorCondition returns the condition conditionType, True if
+// isTrue. If there are messages, the problems that set it, they're its message
+// with reason
+func clusterOperatorCondition(current []configv1.ClusterOperatorStatusCondition, conditionType configv1.ClusterStatusConditionType, isTrue bool, reason string, messages []string, now metav1.Time) configv1.ClusterOperatorStatusCondition {
+	condition := configv1.ClusterOperatorStatusCondition{
+		Type:               conditionType,
+		Status:             configv1.ConditionFalse,
+		LastTransitionTime: now,
+		Reason:             reasonAsExpected,
+	}
+	if isTrue {
+		condition.Status = configv1.ConditionTrue
+	}
+	if len(messages) != 0 {
+		condition.Reason = reason
+		condition.Message = strings.Join(messages, "; ")
+	}
+	for _, c := range current {
+		if c.Type == conditionType && c.Status == condition.Status {
+			condition.LastTransitionTime = c.LastTransitionTime
+		}
+	}
+	return condition
+}
+
+// adminAPISummarize returns the state of the admin API endpoint of instance
+func adminAPISummarize(instance cloudingressv1alpha1.APIScheme) adminAPISummary {
+	adminAPI := adminAPISummary{
+		APIScheme:    instance.Namespace + "/" + instance.Name,
+		Enabled:      instance.Spec.ManagementAPIServerIngress.Enabled,
+		Visibility:   string(instance.Status.APIEndpointVisibility),
+		State:        string(instance.Status.State),
+		LoadBalancer: instance.Status.CloudLoadBalancerDNSName,
+		Degraded: (instance.Status.Failures != nil && instance.Status.Failures.Degraded) ||
+			conditionTrue(instance.Status.Conditions, cloudingressv1alpha1.ConditionActionRequired),
+	}
+	if adminAPI.LoadBalancer == "" && len(instance.Status.LoadBalancerIPs) != 0 {
+		adminAPI.LoadBalancer = instance.Status.LoadBalancerIPs[0]
+	}
+	switch {
+	case instance.Spec.ManagementAPIServerIngress.DNSManagement == cloudingressv1alpha1.DNSManagementExternalDNS:
+		adminAPI.DNS = "ExternalDNS"
+	case conditionTrue(instance.Status.Conditions, cloudingressv1alpha1.ConditionWaitingForDNSZone):
+		adminAPI.DNS = "WaitingForZone"
+	case contains(instance.Status.Drifted, "dns"):
+		adminAPI.DNS = "Drifted"
+	case utils.FindAPISchemeCondition(instance.Status.Conditions, cloudingressv1alpha1.ConditionDNSVerified) != nil:
+		adminAPI.DNS = "Unverified"
+		if conditionTrue(instance.Status.Conditions, cloudingressv1alpha1.ConditionDNSVerified) {
+			adminAPI.DNS = "Verified"
+		}
+	default:
+		adminAPI.DNS = "Managed"
+	}
+	return adminAPI
+}
+
+// ingressSummarize returns the state of the ingresses of instance
+func ingressSummarize(instance cloudingressv1alpha1.PublishingStrategy) ingressSummary {
+	ingress := ingressSummary{
+		PublishingStrategy: instance.Namespace + "/" + instance.Name,
+		DefaultAPI:         string(instance.Spec.DefaultAPIServerIngress.Listening),
+		Degraded:           instance.Status.Failures != nil && instance.Status.Failures.Degraded,
+	}
+	if ingress.DefaultAPI == "" {
+		ingress.DefaultAPI = string(cloudingressv1alpha1.External)
+	}
+	for _, applicationIngress := range instance.Spec.ApplicationIngress {
+		s := applicationIngressSummary{
+			DNSName:    applicationIngress.DNSName,
+			Visibility: string(applicationIngress.Listening),
+		}
+		for _, status := range instance.Status.ApplicationIngress {
+			if status.DNSName == applicationIngress.DNSName {
+				s.IngressController = status.IngressControllerName
+				s.LoadBalancer = status.LoadBalancer
+			}
+		}
+		if applicationIngress.Default {
+			s := s
+			ingress.DefaultIngress = &s
+			continue
+		}
+		ingress.SecondaryIngresses = append(ingress.SecondaryIngresses, s)
+	}
+	sort.Slice(ingress.SecondaryIngresses, func(i, j int) bool {
+		return ingress.SecondaryIngresses[i].DNSName < ingress.SecondaryIngresses[j].DNSName
+	})
+	return ingress
+}
+
+// statusEqual returns whether the ClusterOperator statuses a and b are the
+// same. Their extensions are compared decoded, as the API server may not keep
+// the JSON as it was written
+func statusEqual(a, b configv1.ClusterOperatorStatus) bool {
+	if !reflect.DeepEqual(a.Conditions, b.Conditions) || !reflect.DeepEqual(a.Versions, b.Versions) ||
+		!reflect.DeepEqual(a.RelatedObjects, b.RelatedObjects) {
+		return false
+	}
+	var summaryA, summaryB summary
+	if json.Unmarshal(a.Extension.Raw, &summaryA) != nil || json.Unmarshal(b.Extension.Raw, &summaryB) != nil {
+		return false
+	}
+	return reflect.DeepEqual(summaryA, summaryB)
+}
+
+// ingressList returns the application ingress i in a list, or an empty list
+// if it's nil
+func ingressList(i *applicationIngressSummary) []applicationIngressSummary {
+	if i == nil {
+		return []applicationIngressSummary{}
+	}
+	return []applicationIngressSummary{*i}
+}
+
+// conditionTrue returns whether the APIScheme condition conditionType is set
+// and True
+func conditionTrue(conditions []cloudingressv1alpha1.APISchemeCondition, conditionType cloudingressv1alpha1.APISchemeConditionType) bool {
+	condition := utils.FindAPISchemeCondition(conditions, conditionType)
+	return condition != nil && condition.Status == corev1.ConditionTrue
+}
+
+// contains returns whether list contains s
+func contains(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,230 @@
+package clusteroperator
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	configv1 "github.com/openshift/api/config/v1"
+	"github.com/openshift/cloud-ingress-operator/config"
+	cloudingressv1alpha1 "github.com/openshift/cloud-ingress-operator/pkg/apis/cloudingress/v1alpha1"
+	"github.com/openshift/cloud-ingress-operator/pkg/testutils"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func readyAPIScheme() cloudingressv1alpha1.APIScheme {
+	return cloudingressv1alpha1.APIScheme{
+		ObjectMeta: metav1.ObjectMeta{Name: "rh-api", Namespace: "openshift-cloud-ingress-operator"},
+		Spec: cloudingressv1alpha1.APISchemeSpec{
+			ManagementAPIServerIngress: cloudingressv1alpha1.ManagementAPIServerIngress{Enabled: true},
+		},
+		Status: cloudingressv1alpha1.APISchemeStatus{
+			CloudLoadBalancerDNSName: "rh-api.elb.amazonaws.com",
+			APIEndpointVisibility:    cloudingressv1alpha1.APIEndpointPublic,
+			State:                    cloudingressv1alpha1.ConditionReady,
+		},
+	}
+}
+
+func publishingStrategy() cloudingressv1alpha1.PublishingStrategy {
+	return cloudingressv1alpha1.PublishingStrategy{
+		ObjectMeta: metav1.ObjectMeta{Name: "publishingstrategy", Namespace: "openshift-cloud-ingress-operator"},
+		Spec: cloudingressv1alpha1.PublishingStrategySpec{
+			DefaultAPIServerIngress: cloudingressv1alpha1.DefaultAPIServerIngress{Listening: cloudingressv1alpha1.Internal},
+			ApplicationIngress: []cloudingressv1alpha1.ApplicationIngress{
+				{Listening: cloudingressv1alpha1.External, Default: true, DNSName: "apps.unit.test"},
+				{Listening: cloudingressv1alpha1.Internal, DNSName: "apps2.unit.test"},
+			},
+		},
+		Status: cloudingressv1alpha1.PublishingStrategyStatus{
+			ApplicationIngress: []cloudingressv1alpha1.ApplicationIngressStatus{
+				{DNSName: "apps.unit.test", IngressControllerName: "default", LoadBalancer: "default.elb.amazonaws.com"},
+				{DNSName: "apps2.unit.test", IngressControllerName: "apps2", LoadBalancer: "apps2.elb.amazonaws.com"},
+			},
+		},
+	}
+}
+
+func TestClusterOperatorStatus(t *testing.T) {
+	noLoadBalancer := readyAPIScheme()
+	noLoadBalancer.Status.CloudLoadBalancerDNSName = ""
+	rejected := readyAPIScheme()
+	rejected.Status.CloudLoadBalancerDNSName = ""
+	rejected.Status.Conditions = []cloudingressv1alpha1.APISchemeCondition{{Type: cloudingressv1alpha1.ConditionRejected, Status: corev1.ConditionTrue}}
+	transitioning := readyAPIScheme()
+	transitioning.Status.APIEndpointVisibility = cloudingressv1alpha1.APIEndpointTransitioning
+	actionRequired := readyAPIScheme()
+	actionRequired.Status.Conditions = []cloudingressv1alpha1.APISchemeCondition{{Type: cloudingressv1alpha1.ConditionActionRequired, Status: corev1.ConditionTrue}}
+	pendingIngress := publishingStrategy()
+	pendingIngress.Status.ApplicationIngress = pendingIngress.Status.ApplicationIngress[:1]
+	failingIngress := publishingStrategy()
+	failingIngress.Status.Failures = &cloudingressv1alpha1.ReconcileFailures{Count: 5, Degraded: true}
+
+	tests := []struct {
+		Name                 string
+		APISchemes           []cloudingressv1alpha1.APIScheme
+		PublishingStrategies []cloudingressv1alpha1.PublishingStrategy
+		Expected             map[configv1.ClusterStatusConditionType]configv1.ConditionStatus
+	}{
+		{
+			Name:                 "Everything ready",
+			APISchemes:           []cloudingressv1alpha1.APIScheme{readyAPIScheme()},
+			PublishingStrategies: []cloudingressv1alpha1.PublishingStrategy{publishingStrategy()},
+			Expected:             map[configv1.ClusterStatusConditionType]configv1.ConditionStatus{configv1.OperatorAvailable: configv1.ConditionTrue, configv1.OperatorProgressing: configv1.ConditionFalse, configv1.OperatorDegraded: configv1.ConditionFalse},
+		},
+		{
+			Name:       "Admin API without a load balancer",
+			APISchemes: []cloudingressv1alpha1.APIScheme{noLoadBalancer},
+			Expected:   map[configv1.ClusterStatusConditionType]configv1.ConditionStatus{configv1.OperatorAvailable: configv1.ConditionFalse, configv1.OperatorProgressing: configv1.ConditionFalse, configv1.OperatorDegraded: configv1.ConditionFalse},
+		},
+		{
+			Name:       "Rejected APIScheme",
+			APISchemes: []cloudingressv1alpha1.APIScheme{readyAPIScheme(), rejected},
+			Expected:   map[configv1.ClusterStatusConditionType]configv1.ConditionStatus{configv1.OperatorAvailable: configv1.ConditionTrue, configv1.OperatorProgressing: configv1.ConditionFalse, configv1.OperatorDegraded: configv1.ConditionFalse},
+		},
+		{
+			Name:       "Admin API transitioning",
+			APISchemes: []cloudingressv1alpha1.APIScheme{transitioning},
+			Expected:   map[configv1.ClusterStatusConditionType]configv1.ConditionStatus{configv1.OperatorAvailable: configv1.ConditionTrue, configv1.OperatorProgressing: configv1.ConditionTrue, configv1.OperatorDegraded: configv1.ConditionFalse},
+		},
+		{
+			Name:       "Action required",
+			APISchemes: []cloudingressv1alpha1.APIScheme{actionRequired},
+			Expected:   map[configv1.ClusterStatusConditionType]configv1.ConditionStatus{configv1.OperatorAvailable: configv1.ConditionTrue, configv1.OperatorProgressing: configv1.ConditionFalse, configv1.OperatorDegraded: configv1.ConditionTrue},
+		},
+		{
+			Name:                 "Application ingress without a load balancer",
+			PublishingStrategies: []cloudingressv1alpha1.PublishingStrategy{pendingIngress},
+			Expected:             map[configv1.ClusterStatusConditionType]configv1.ConditionStatus{configv1.OperatorAvailable: configv1.ConditionTrue, configv1.OperatorProgressing: configv1.ConditionTrue, configv1.OperatorDegraded: configv1.ConditionFalse},
+		},
+		{
+			Name:                 "PublishingStrategy failing",
+			PublishingStrategies: []cloudingressv1alpha1.PublishingStrategy{failingIngress},
+			Expected:             map[configv1.ClusterStatusConditionType]configv1.ConditionStatus{configv1.OperatorAvailable: configv1.ConditionTrue, configv1.OperatorProgressing: configv1.ConditionFalse, configv1.OperatorDegraded: configv1.ConditionTrue},
+		},
+	}
+	for _, test := range tests {
+		status, err := clusterOperatorStatus(configv1.ClusterOperatorStatus{}, test.APISchemes, test.PublishingStrategies, metav1.Now())
+		if err != nil {
+			t.Fatalf("Test [%v] FAILED. Unexpected error %v", test.Name, err)
+		}
+		for _, condition := range status.Conditions {
+			if condition.Status != test.Expected[condition.Type] {
+				t.Fatalf("Test [%v] FAILED. Expected %v to be %v. Got %v (%v)", test.Name, condition.Type, test.Expected[condition.Type], condition.Status, condition.Message)
+			}
+		}
+	}
+}
+
+func TestClusterOperatorStatusKeepsTransitionTimes(t *testing.T) {
+	before := metav1.NewTime(time.Now().Add(-time.Hour).Truncate(time.Second))
+	first, err := clusterOperatorStatus(configv1.ClusterOperatorStatus{}, []cloudingressv1alpha1.APIScheme{readyAPIScheme()}, nil, before)
+	if err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	transitioning := readyAPIScheme()
+	transitioning.Status.APIEndpointVisibility = cloudingressv1alpha1.APIEndpointTransitioning
+	now := metav1.Now()
+	second, err := clusterOperatorStatus(first, []cloudingressv1alpha1.APIScheme{transitioning}, nil, now)
+	if err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	for _, condition := range second.Conditions {
+		expected := before
+		if condition.Type == configv1.OperatorProgressing {
+			expected = now
+		}
+		if !condition.LastTransitionTime.Equal(&expected) {
+			t.Fatalf("Expected %v to have transitioned at %v. Got %v", condition.Type, expected, condition.LastTransitionTime)
+		}
+	}
+}
+
+func TestAdminAPISummarizeDNS(t *testing.T) {
+	tests := []struct {
+		Name       string
+		Conditions []cloudingressv1alpha1.APISchemeCondition
+		Drifted    []string
+		External   bool
+		Expected   string
+	}{
+		{
+			Name:     "Managed",
+			Expected: "Managed",
+		},
+		{
+			Name:       "Verified",
+			Conditions: []cloudingressv1alpha1.APISchemeCondition{{Type: cloudingressv1alpha1.ConditionDNSVerified, Status: corev1.ConditionTrue}},
+			Expected:   "Verified",
+		},
+		{
+			Name:       "Unverified",
+			Conditions: []cloudingressv1alpha1.APISchemeCondition{{Type: cloudingressv1alpha1.ConditionDNSVerified, Status: corev1.ConditionFalse}},
+			Expected:   "Unverified",
+		},
+		{
+			Name:       "Waiting for the zone",
+			Conditions: []cloudingressv1alpha1.APISchemeCondition{{Type: cloudingressv1alpha1.ConditionWaitingForDNSZone, Status: corev1.ConditionTrue}},
+			Expected:   "WaitingForZone",
+		},
+		{
+			Name:     "Drifted",
+			Drifted:  []string{"listeners", "dns"},
+			Expected: "Drifted",
+		},
+		{
+			Name:     "External DNS",
+			External: true,
+			Expected: "ExternalDNS",
+		},
+	}
+	for _, test := range tests {
+		instance := readyAPIScheme()
+		instance.Status.Conditions = test.Conditions
+		instance.Status.Drifted = test.Drifted
+		if test.External {
+			instance.Spec.ManagementAPIServerIngress.DNSManagement = cloudingressv1alpha1.DNSManagementExternalDNS
+		}
+		actual := adminAPISummarize(instance).DNS
+		if actual != test.Expected {
+			t.Fatalf("Test [%v] FAILED. Expected %v. Got %v", test.Name, test.Expected, actual)
+		}
+	}
+}
+
+func TestReconcileClusterOperator(t *testing.T) {
+	instance := readyAPIScheme()
+	strategy := publishingStrategy()
+	mocks := testutils.NewTestMock(t, []runtime.Object{&instance, &strategy})
+	r := &ReconcileClusterOperator{client: mocks.FakeKubeClient, scheme: mocks.Scheme}
+
+	_, err := r.Reconcile(context.TODO(), reconcile.Request{NamespacedName: types.NamespacedName{Name: config.OperatorName}})
+	if err != nil {
+		t.Fatalf("Couldn't reconcile: %v", err)
+	}
+
+	co := &configv1.ClusterOperator{}
+	err = mocks.FakeKubeClient.Get(context.TODO(), types.NamespacedName{Name: config.OperatorName}, co)
+	if err != nil {
+		t.Fatalf("Couldn't get the ClusterOperator: %v", err)
+	}
+	if len(co.Status.Conditions) != 3 || len(co.Status.RelatedObjects) != 3 {
+		t.Fatalf("Expected 3 conditions and 3 related objects. Got %v and %v", co.Status.Conditions, co.Status.RelatedObjects)
+	}
+	s := summary{}
+	if err = json.Unmarshal(co.Status.Extension.Raw, &s); err != nil {
+		t.Fatalf("Couldn't decode the summary: %v", err)
+	}
+	if len(s.AdminAPI) != 1 || s.AdminAPI[0].Visibility != "Public" {
+		t.Fatalf("Expected a public admin API. Got %v", s.AdminAPI)
+	}
+	if len(s.Ingress) != 1 || s.Ingress[0].DefaultAPI != "internal" || s.Ingress[0].DefaultIngress == nil ||
+		len(s.Ingress[0].SecondaryIngresses) != 1 || s.Ingress[0].SecondaryIngresses[0].IngressController != "apps2" {
+		t.Fatalf("Expected an internal default API, a default and a secondary ingress. Got %v", s.Ingress)
+	}
+}
@@ -5,11 +5,14 @@ import (
 	"fmt"
 	"reflect"
 	"strings"
+	"time"
 
 	operatorv1 "github.com/openshift/api/operator/v1"
 	"github.com/openshift/cloud-ingress-operator/pkg/apis/cloudingress/v1alpha1"
 	cloudingressv1alpha1 "github.com/openshift/cloud-ingress-operator/pkg/apis/cloudingress/v1alpha1"
 	"github.com/openshift/cloud-ingress-operator/pkg/cloudclient"
+	utils "github.com/openshift/cloud-ingress-operator/pkg/controller/utils"
+	cioerrors "github.com/openshift/cloud-ingress-operator/pkg/errors"
 	baseutils "github.com/openshift/cloud-ingress-operator/pkg/utils"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -19,9 +22,11 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 	"sigs.k8s.io/controller-runtime/pkg/source"
 )
@@ -29,10 +34,21 @@ import (
 const (
 	ingressControllerNamespace = "openshift-ingress-operator"
 	infraNodeLabelKey          = "node-role.kubernetes.io/infra"
+	routerServiceNamespace     = "openshift-ingress"
+	routerServicePrefix        = "router-"
 )
 
 var log = logf.Log.WithName("controller_publishingstrategy")
 
+// MaxConcurrentReconciles is how many PublishingStrategies are reconciled at
+// once
+var MaxConcurrentReconciles = 1
+
+// RequeueIntervals are how soon a PublishingStrategy is reconciled again. Its
+// Error is how often draining and health checks of the API load balancers are
+// checked again
+var RequeueIntervals = utils.RequeueIntervals{Error: 30 * time.Second}
+
 type patchField string
 
 var IngressControllerSelector patchField = "IngressControllerSelector"
@@ -47,13 +63,13 @@ func Add(mgr manager.Manager) error {
 
 // newReconciler returns a new reconcile.Reconciler
 func newReconciler(mgr manager.Manager) reconcile.Reconciler {
-	return &ReconcilePublishingStrategy{client: mgr.GetClient(), scheme: mgr.GetScheme()}
+	return &ReconcilePublishingStrategy{client: baseutils.NewTimedClient(mgr.GetClient()), scheme: mgr.GetScheme()}
 }
 
 // add adds a new Controller to mgr with r as the reconcile.Reconciler
 func add(mgr manager.Manager, r reconcile.Reconciler) error {
 	// Create a new controller
-	c, err := controller.New("publishingstrategy-controller", mgr, controller.Options{Reconciler: r})
+	c, err := controller.New("publishingstrategy-controller", mgr, controller.Options{Reconciler: r, MaxConcurrentReconciles: MaxConcurrentReconciles})
 	if err != nil {
 		return err
 	}
@@ -64,9 +80,53 @@ func add(mgr manager.Manager, r reconcile.Reconciler) error {
 		return err
 	}
 
+	// Watch the router Services, whose load balancers are reported in the
+	// status, for load balancer changes
+	p := predicate.Funcs{
+		CreateFunc: func(e event.CreateEvent) bool {
+			return isRouterService(e.Object)
+		},
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			oldSvc, okOld := e.ObjectOld.(*corev1.Service)
+			newSvc, okNew := e.ObjectNew.(*corev1.Service)
+			return okOld && okNew && isRouterService(newSvc) &&
+				!reflect.DeepEqual(oldSvc.Status.LoadBalancer, newSvc.Status.LoadBalancer)
+		},
+		DeleteFunc: func(e event.DeleteEvent) bool {
+			return isRouterService(e.Object)
+		},
+		GenericFunc: func(e event.GenericEvent) bool {
+			return false
+		},
+	}
+	kclient := mgr.GetClient()
+	err = c.Watch(&source.Kind{Type: &corev1.Service{}}, handler.EnqueueRequestsFromMapFunc(func(_ client.Object) []reconcile.Request {
+		publishingStrategyList := &cloudingressv1alpha1.PublishingStrategyList{}
+		if err := kclient.List(context.TODO(), publishingStrategyList); err != nil {
+			log.Error(err, "Cannot get list of PublishingStrategies")
+			return []reconcile.Request{}
+		}
+		requests := []reconcile.Request{}
+		for _, publishingStrategy := range publishingStrategyList.Items {
+			requests = append(requests, reconcile.Request{
+				NamespacedName: types.NamespacedName{Name: publishingStrategy.Name, Namespace: publishingStrategy.Namespace},
+			})
+		}
+		return requests
+	}), p)
+	if err != nil {
+		return err
+	}
+
 	return nil
 }
 
+// isRouterService checks if obj is one of the ingress operator's router
+// Services
+func isRouterService(obj client.Object) bool {
+	return obj.GetNamespace() == routerServiceNamespace && strings.HasPrefix(obj.GetName(), routerServicePrefix)
+}
+
 // blank assignment to verify that ReconcilePublishingStrategy implements reconcile.Reconciler
 var _ reconcile.Reconciler = &ReconcilePublishingStrategy{}
 
@@ -86,12 +146,14 @@ type ReconcilePublishingStrategy struct {
 // The Controller will requeue the Request to be processed again if the returned error is non-nil or
 // Result.Requeue is true, otherwise upon completion it will remove the work from the queue.
 func (r *ReconcilePublishingStrategy) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	ctx, timings := baseutils.WithReconcileTimings(ctx)
+	defer utils.ReportReconcileTimings("publishingstrategy", timings)
 	reqLogger := log.WithValues("Request.Namespace", request.Namespace, "Request.Name", request.Name)
 	reqLogger.Info("Reconciling PublishingStrategy")
 
 	// Fetch the PublishingStrategy instance
 	instance := &cloudingressv1alpha1.PublishingStrategy{}
-	err := r.client.Get(context.TODO(), request.NamespacedName, instance)
+	err := r.client.Get(ctx, request.NamespacedName, instance)
 	if err != nil {
 		if k8serr.IsNotFound(err) {
 			// Request object not found, could have been deleted after reconcile request.
@@ -103,12 +165,39 @@ func (r *ReconcilePublishingStrategy) Reconcile(ctx context.Context, request rec
 		return reconcile.Result{}, err
 	}
 
+	result, err := r.reconcilePublishingStrategy(ctx, request, instance)
+	r.recordFailures(instance, err)
+	return result, err
+}
+
+// reconcilePublishingStrategy reconciles instance, the PublishingStrategy of
+// request
+func (r *ReconcilePublishingStrategy) reconcilePublishingStrategy(ctx context.Context, request reconcile.Request, instance *cloudingressv1alpha1.PublishingStrategy) (reconcile.Result, error) {
+	reqLogger := log.WithValues("Request.Namespace", request.Namespace, "Request.Name", request.Name)
+
+	observing, err := baseutils.IsObserving(r.client)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	if observing {
+		reqLogger.Info("Observing, only reporting drift")
+		return reconcile.Result{}, r.reportObserved(instance)
+	}
+	paused, err := baseutils.IsPaused(r.client, instance)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	if paused {
+		reqLogger.Info("Paused, not reconciling")
+		return reconcile.Result{}, nil
+	}
+
 	// Get all IngressControllers on cluster with an annotation that indicates cloud-ingress-operator owns it
 	ingressControllerList := &operatorv1.IngressControllerList{}
 	listOptions := []client.ListOption{
 		client.InNamespace("openshift-ingress-operator"),
 	}
-	err = r.client.List(context.TODO(), ingressControllerList, listOptions...)
+	err = r.client.List(ctx, ingressControllerList, listOptions...)
 	if err != nil {
 		log.Error(err, "Cannot get list of ingresscontroller")
 		return reconcile.Result{}, err
@@ -156,18 +245,23 @@ func (r *ReconcilePublishingStrategy) Reconcile(ctx context.Context, request rec
 	*/
 	for _, ingressDefinition := range instance.Spec.ApplicationIngress {
 
-		// Set the IngressController CRs name based on the DNSName
-		ingressName := getIngressName(ingressDefinition.DNSName)
+		// Set the IngressController CRs name based on the DNSName. The default
+		// IngressController is named "default", which is expected by cluster-ingress-operator
+		ingressName, err := utils.IngressControllerName(ingressDefinition)
+		if err != nil {
+			return reconcile.Result{}, err
+		}
 
 		if ingressDefinition.Default {
-			// The default IngressController should be named "default" which is expected by cluster-ingress-operator
-			ingressName = "default"
-
 			// Safety check, to ensure that the default ingress controller DNS name matches the cluster's base domain
 			// This protects against malformed publishing strategies
 			if !strings.HasSuffix(ingressDefinition.DNSName, clusterBaseDomain) {
 				return reconcile.Result{}, fmt.Errorf("default ingress DNS doesn't match cluster's base domain: got %v, expected to end in %v", ingressDefinition.DNSName, clusterBaseDomain)
 			}
+		} else if !strings.HasSuffix(ingressDefinition.DNSName, clusterBaseDomain) {
+			// The ingress operator only publishes DNS records in the cluster's
+			// zones, so anything else has to be pointed at the load balancer by hand
+			reqLogger.Info(fmt.Sprintf("ApplicationIngress %s isn't under the cluster's base domain %s, its DNS records won't be managed", ingressDefinition.DNSName, clusterBaseDomain))
 		}
 
 		reqLogger.Info(fmt.Sprintf("Checking ApplicationIngress for %s IngressController CR", ingressName))
@@ -175,24 +269,27 @@ func (r *ReconcilePublishingStrategy) Reconcile(ctx context.Context, request rec
 		/* Each ApplicationIngress refers to an IngressController CR. Here, the namespaced name
 		is built based on that reference so that an attempt can be made to GET the IngressController
 		This verifies that the IngressController exists and uses that for other checks, or triggers a creation
-		if it doesn't. getIngressName is a function which returns the name of an IngressController CR given its
-		DNS uri. It's used here to properly get the name to build a namespaced name.
+		if it doesn't. utils.IngressControllerName returns the name of an IngressController CR given its
+		ApplicationIngress. It's used here to properly get the name to build a namespaced name.
 		*/
 		namespacedName := types.NamespacedName{Name: ingressName, Namespace: ingressControllerNamespace}
 
 		// Generate the desired IngressController spec based on the ApplicationIngress definition.
 		// This generated spec will be compared against the actual spec as desrcibed above
-		desiredIngressController := generateIngressController(ingressDefinition)
+		desiredIngressController, err := generateIngressController(ingressDefinition)
+		if err != nil {
+			return reconcile.Result{}, err
+		}
 
 		// Attempt to find the IngressController referenced by the ApplicationIngress
 		// by doing a GET of the namespaced name object build above against the k8s api.
 		ingressController := &operatorv1.IngressController{}
-		err = r.client.Get(context.TODO(), namespacedName, ingressController)
+		err = r.client.Get(ctx, namespacedName, ingressController)
 		if err != nil {
 			// Attempt to create the CR if not found
 			if k8serr.IsNotFound(err) {
 				reqLogger.Info(fmt.Sprintf("ApplicationIngress %s not found, attempting to create", ingressName))
-				err = r.client.Create(context.TODO(), desiredIngressController)
+				err = utils.Apply(ctx, r.client, r.scheme, instance, desiredIngressController)
 				if err != nil {
 					return reconcile.Result{}, err
 				}
@@ -228,7 +325,7 @@ func (r *ReconcilePublishingStrategy) Reconcile(ctx context.Context, request rec
 					// the actual default IngressController must be deleted
 					reqLogger.Info("Static Spec and Status do not match for default IngressController, deleting")
 					// TODO: Should we return an error here if this delete fails?
-					if err := r.client.Delete(context.TODO(), ingressController); err != nil {
+					if err := r.client.Delete(ctx, ingressController); err != nil {
 						reqLogger.Error(err, "Error deleting IngressController")
 					}
 					return reconcile.Result{Requeue: true}, nil
@@ -239,7 +336,7 @@ func (r *ReconcilePublishingStrategy) Reconcile(ctx context.Context, request rec
 				// the IngressController must be deleted
 				reqLogger.Info(fmt.Sprintf("Static Spec does not match for for IngressController %s, deleting", ingressName))
 				// TODO: Should we return an error here if this delete fails?
-				if err := r.client.Delete(context.TODO(), ingressController); err != nil {
+				if err := r.client.Delete(ctx, ingressController); err != nil {
 					reqLogger.Error(err, "Error deleting IngressController")
 				}
 				return reconcile.Result{Requeue: true}, nil
@@ -263,7 +360,7 @@ func (r *ReconcilePublishingStrategy) Reconcile(ctx context.Context, request rec
 					ingressController.Spec.RouteSelector = desiredIngressController.Spec.RouteSelector
 					// Perform the patch on the existing IngressController using the base to patch against and the
 					// changes added to bring the exsting CR to the desired state
-					err = r.client.Patch(context.TODO(), ingressController, baseToPatch)
+					err = r.client.Patch(ctx, ingressController, baseToPatch)
 					if err != nil {
 						return reconcile.Result{}, err
 					}
@@ -286,7 +383,7 @@ func (r *ReconcilePublishingStrategy) Reconcile(ctx context.Context, request rec
 
 				// Perform the patch on the existing IngressController using the base to patch against and the
 				// changes added to bring the exsting CR to the desired state
-				err = r.client.Patch(context.TODO(), ingressController, baseToPatch)
+				err = r.client.Patch(ctx, ingressController, baseToPatch)
 				if err != nil {
 					return reconcile.Result{}, err
 				}
@@ -300,57 +397,257 @@ func (r *ReconcilePublishingStrategy) Reconcile(ctx context.Context, request rec
 		if !inPublishingStrategy {
 			// Delete requires an object referece, so we must get it first
 			ingressToDelete := &operatorv1.IngressController{}
-			err = r.client.Get(context.TODO(), types.NamespacedName{Name: ingress, Namespace: ingressControllerNamespace}, ingressToDelete)
+			err = r.client.Get(ctx, types.NamespacedName{Name: ingress, Namespace: ingressControllerNamespace}, ingressToDelete)
 			if err != nil {
 				return reconcile.Result{}, err
 			}
-			err = r.client.Delete(context.TODO(), ingressToDelete)
+			err = r.client.Delete(ctx, ingressToDelete)
 			if err != nil {
 				return reconcile.Result{}, err
 			}
 		}
 	}
 
+	utils.ReportObservedDrift("publishingstrategy", instance.Namespace+"/"+instance.Name, nil)
+	instance.Status.Drifted = nil
+	err = r.setApplicationIngressStatus(instance)
+	if err != nil {
+		log.Error(err, "Failed to update the PublishingStrategy status")
+		return reconcile.Result{}, err
+	}
+
 	cloudPlatform, err := baseutils.GetPlatformType(r.client)
 	if err != nil {
 		log.Error(err, "Failed to create a Cloud Client")
 		return reconcile.Result{}, err
 	}
-	cloudClient := cloudclient.GetClientFor(r.client, *cloudPlatform)
+	configVersion, err := baseutils.GetPlatformConfigVersion(r.client)
+	if err != nil {
+		log.Error(err, "Failed to create a Cloud Client")
+		return reconcile.Result{}, err
+	}
+	cloudClient := cloudclient.GetSharedClientFor(r.client, *cloudPlatform, configVersion)
+
+	err = cloudClient.EnsureApplicationIngressLoadBalancers(ctx, r.client, instance)
+	if err != nil {
+		log.Error(err, "Error updating the ApplicationIngress load balancers")
+		return utils.CloudErrorResult(err)
+	}
+
+	if !cloudclient.GetCapabilitiesFor(*cloudPlatform).SupportsDNSTTL {
+		for _, ingressDefinition := range instance.Spec.ApplicationIngress {
+			if ingressDefinition.DNSTTLSeconds != 0 {
+				log.Info(fmt.Sprintf("%s doesn't support dnsTTLSeconds, the records of ApplicationIngress %s keep the TTL of its load balancer", *cloudPlatform, ingressDefinition.DNSName))
+			}
+		}
+	}
+	err = cloudClient.EnsureApplicationIngressDNS(ctx, r.client, instance)
+	if err != nil {
+		log.Error(err, "Error updating the ApplicationIngress DNS records")
+		return utils.CloudErrorResult(err)
+	}
+
+	// The default API of a hosted control plane is published with it, and
+	// there are no masters in the cluster to move between load balancers
+	hosted, err := baseutils.IsHostedControlPlane(r.client)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	if hosted {
+		log.Info("The control plane is hosted outside the cluster, leaving the default API alone")
+		return RequeueIntervals.SuccessResult(), nil
+	}
 
 	if instance.Spec.DefaultAPIServerIngress.Listening == cloudingressv1alpha1.Internal {
-		err := cloudClient.SetDefaultAPIPrivate(context.TODO(), r.client, instance)
-		if err != nil {
+		if len(instance.Spec.DefaultAPIServerIngress.KeepPublicPorts) > 0 && !cloudclient.GetCapabilitiesFor(*cloudPlatform).SupportsListenerToggle {
+			log.Info(fmt.Sprintf("%s doesn't support keepPublicPorts, every public listener of the external API load balancer is removed", *cloudPlatform))
+		}
+		drainingStatus := instance.Status.DefaultAPIServerIngress.DeepCopy()
+		err := cloudClient.SetDefaultAPIPrivate(ctx, r.client, instance)
+		if !reflect.DeepEqual(drainingStatus, instance.Status.DefaultAPIServerIngress) {
+			if statusErr := r.client.Status().Update(ctx, instance); statusErr != nil {
+				log.Error(statusErr, "Error updating the PublishingStrategy status")
+				return reconcile.Result{}, statusErr
+			}
+		}
+		switch err.(type) {
+		case nil:
+			// all good
+		case *cioerrors.LoadBalancerDrainingError:
+			log.Info("Waiting for the external API load balancer to drain", "reason", err.Error())
+			return RequeueIntervals.ErrorResult(), nil
+		case *cioerrors.LoadBalancerNotReadyError:
+			log.Info("Waiting for the targets of the internal API load balancer to become healthy")
+			return RequeueIntervals.ErrorResult(), nil
+		default:
 			log.Error(err, fmt.Sprintf("Error updating api.%s alias to internal NLB", clusterBaseDomain))
-			return reconcile.Result{}, err
+			return utils.CloudErrorResult(err)
 		}
 		log.Info(fmt.Sprintf("Update api.%s alias to internal NLB successful", clusterBaseDomain))
-		return reconcile.Result{}, nil
+		return RequeueIntervals.SuccessResult(), nil
 	}
 
 	// if CR is wanted the default server API to be internet-facing, we
 	// create the external NLB for port 6443/TCP and add api.<cluster-name> DNS record to point to external NLB
 	if instance.Spec.DefaultAPIServerIngress.Listening == cloudingressv1alpha1.External {
-		err := cloudClient.SetDefaultAPIPublic(context.TODO(), r.client, instance)
-		if err != nil {
+		// Going back to external stops any connection draining
+		if instance.Status.DefaultAPIServerIngress != nil {
+			instance.Status.DefaultAPIServerIngress = nil
+			if err := r.client.Status().Update(ctx, instance); err != nil {
+				log.Error(err, "Error updating the PublishingStrategy status")
+				return reconcile.Result{}, err
+			}
+		}
+		err := cloudClient.SetDefaultAPIPublic(ctx, r.client, instance)
+		switch err.(type) {
+		case nil:
+			// all good
+		case *cioerrors.LoadBalancerNotReadyError:
+			log.Info("Waiting for the targets of the external API load balancer to become healthy")
+			return RequeueIntervals.ErrorResult(), nil
+		default:
 			log.Error(err, fmt.Sprintf("Error updating api.%s alias to external NLB", clusterBaseDomain))
-			return reconcile.Result{}, err
+			return utils.CloudErrorResult(err)
 		}
 		log.Info(fmt.Sprintf("Update api.%s alias to external NLB successful", clusterBaseDomain))
-		return reconcile.Result{}, nil
+		return RequeueIntervals.SuccessResult(), nil
 	}
-	return reconcile.Result{}, nil
+	return RequeueIntervals.SuccessResult(), nil
 }
 
-// getIngressName takes the domain name and returns the name of the IngressController CR
-func getIngressName(dnsName string) string {
-	firstPeriodIndex := strings.Index(dnsName, ".")
-	newIngressName := dnsName[:firstPeriodIndex]
-	return newIngressName
+// recordFailures updates the failure streak of instance with the outcome of a
+// reconcile, which failed if it returned reconcileErr. The streak is marked
+// degraded once it reaches utils.DegradedAfterFailures
+func (r *ReconcilePublishingStrategy) recordFailures(instance *cloudingressv1alpha1.PublishingStrategy, reconcileErr error) {
+	message := ""
+	if reconcileErr != nil {
+		message = reconcileErr.Error()
+	}
+	failures := utils.UpdateReconcileFailures(instance.Status.Failures, message, metav1.Now())
+	utils.ReportReconcileFailures("publishingstrategy", instance.Namespace+"/"+instance.Name, failures)
+	if failures == nil && instance.Status.Failures == nil {
+		return
+	}
+	instance.Status.Failures = failures
+	if failures != nil && failures.Degraded {
+		log.Info("Degraded", "Request.Namespace", instance.Namespace, "Request.Name", instance.Name, "message", utils.DegradedMessage(failures))
+	}
+	err := r.client.Status().Update(context.TODO(), instance)
+	if err != nil && !k8serr.IsNotFound(err) {
+		log.Error(err, "Error updating cr status")
+	}
+}
+
+// reportObserved reconciles instance in observer mode. The IngressControllers
+// that differ from what its ApplicationIngresses make them are recorded in its
+// status instead of being changed
+func (r *ReconcilePublishingStrategy) reportObserved(instance *cloudingressv1alpha1.PublishingStrategy) error {
+	drifted, err := Verify(r.client, instance)
+	if err != nil {
+		return err
+	}
+	utils.ReportObservedDrift("publishingstrategy", instance.Namespace+"/"+instance.Name, drifted)
+	if !reflect.DeepEqual(drifted, instance.Status.Drifted) {
+		instance.Status.Drifted = drifted
+		if err := r.client.Status().Update(context.TODO(), instance); err != nil {
+			return err
+		}
+	}
+	return r.setApplicationIngressStatus(instance)
+}
+
+// Verify returns the IngressControllers reconciling instance would create,
+// recreate, patch or delete, with why, without changing any
+func Verify(kclient client.Client, instance *cloudingressv1alpha1.PublishingStrategy) ([]string, error) {
+	ingressControllerList := &operatorv1.IngressControllerList{}
+	err := kclient.List(context.TODO(), ingressControllerList, client.InNamespace(ingressControllerNamespace))
+	if err != nil {
+		return nil, err
+	}
+	return driftedIngressControllers(instance, ingressControllerList.Items), nil
+}
+
+// driftedIngressControllers lists the IngressControllers of existing that
+// reconciling instance would create, recreate, patch or delete, with why
+func driftedIngressControllers(instance *cloudingressv1alpha1.PublishingStrategy, existing []operatorv1.IngressController) []string {
+	var drifted []string
+	wanted := make(map[string]bool, len(instance.Spec.ApplicationIngress))
+	for _, ingressDefinition := range instance.Spec.ApplicationIngress {
+		desired, err := generateIngressController(ingressDefinition)
+		if err != nil {
+			drifted = append(drifted, err.Error())
+			continue
+		}
+		wanted[desired.Name] = true
+		var ingressController *operatorv1.IngressController
+		for i := range existing {
+			if existing[i].Name == desired.Name {
+				ingressController = &existing[i]
+			}
+		}
+		if ingressController == nil {
+			drifted = append(drifted, desired.Name+" is missing")
+			continue
+		}
+		// The default IngressController may only have its settings in the status
+		if !validateStaticSpec(*ingressController, desired.Spec) &&
+			!(ingressDefinition.Default && validateStaticStatus(*ingressController, desired.Spec)) {
+			drifted = append(drifted, desired.Name+" needs recreating")
+			continue
+		}
+		if valid, field := validatePatchableSpec(*ingressController, desired.Spec); !valid {
+			if ingressDefinition.Default && field == IngressControllerSelector {
+				if valid, _ := validatePatchableStatus(*ingressController, desired.Spec); valid {
+					continue
+				}
+			}
+			drifted = append(drifted, fmt.Sprintf("%s has a different %s", desired.Name, field))
+		}
+	}
+	owned := getIngressWithCloudIngressOpreatorOwnerAnnotation(operatorv1.IngressControllerList{Items: existing})
+	for _, ingressController := range owned.Items {
+		if !wanted[ingressController.Name] {
+			drifted = append(drifted, ingressController.Name+" isn't in the PublishingStrategy")
+		}
+	}
+	return drifted
+}
+
+// setApplicationIngressStatus records which IngressController publishes each
+// ApplicationIngress, and the cloud load balancer of its router Service
+func (r *ReconcilePublishingStrategy) setApplicationIngressStatus(instance *cloudingressv1alpha1.PublishingStrategy) error {
+	statuses := []cloudingressv1alpha1.ApplicationIngressStatus{}
+	for _, ingressDefinition := range instance.Spec.ApplicationIngress {
+		ingressName, err := utils.IngressControllerName(ingressDefinition)
+		if err != nil {
+			return err
+		}
+		status := cloudingressv1alpha1.ApplicationIngressStatus{
+			DNSName:               ingressDefinition.DNSName,
+			IngressControllerName: ingressName,
+		}
+		svc := &corev1.Service{}
+		err = r.client.Get(context.TODO(), types.NamespacedName{Name: routerServicePrefix + ingressName, Namespace: routerServiceNamespace}, svc)
+		if err != nil && !k8serr.IsNotFound(err) {
+			return err
+		}
+		if len(svc.Status.LoadBalancer.Ingress) > 0 {
+			status.LoadBalancer = svc.Status.LoadBalancer.Ingress[0].Hostname
+			if status.LoadBalancer == "" {
+				status.LoadBalancer = svc.Status.LoadBalancer.Ingress[0].IP
+			}
+		}
+		statuses = append(statuses, status)
+	}
+	if reflect.DeepEqual(statuses, instance.Status.ApplicationIngress) {
+		return nil
+	}
+	instance.Status.ApplicationIngress = statuses
+	return r.client.Status().Update(context.TODO(), instance)
 }
 
 // Generates an IngressController CR object based on the configuration of an ApplicationIngress instance
-func generateIngressController(appIngress v1alpha1.ApplicationIngress) *operatorv1.IngressController {
+func generateIngressController(appIngress v1alpha1.ApplicationIngress) (*operatorv1.IngressController, error) {
 	// Translate the ApplicationIngress listening string into the matching type for the IngressController
 	loadBalancerScope := operatorv1.LoadBalancerScope("")
 	switch appIngress.Listening {
@@ -362,9 +659,9 @@ func generateIngressController(appIngress v1alpha1.ApplicationIngress) *operator
 		loadBalancerScope = operatorv1.ExternalLoadBalancer
 	}
 
-	ingressName := getIngressName(appIngress.DNSName)
-	if appIngress.Default {
-		ingressName = "default"
+	ingressName, err := utils.IngressControllerName(appIngress)
+	if err != nil {
+		return nil, err
 	}
 
 	// Builds the IngressController CR object based on the ApplicationIngress
@@ -1,24 +1,68 @@
 package publishingstrategy
 
 import (
+	"context"
+	"reflect"
 	"testing"
 
 	operatorv1 "github.com/openshift/api/operator/v1"
 	cloudingressv1alpha1 "github.com/openshift/cloud-ingress-operator/pkg/apis/cloudingress/v1alpha1"
+	utils "github.com/openshift/cloud-ingress-operator/pkg/controller/utils"
+	"github.com/openshift/cloud-ingress-operator/pkg/testutils"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 )
 
-func TestGetIngressName(t *testing.T) {
-
-	domainName := "apps2.test.domain_name.org"
-
-	expected := "apps2"
-	result := getIngressName(domainName)
+func TestIngressControllerName(t *testing.T) {
+	tests := []struct {
+		Name          string
+		AppIngress    cloudingressv1alpha1.ApplicationIngress
+		Expected      string
+		ExpectedError bool
+	}{
+		{
+			Name:       "First label of the DNS name",
+			AppIngress: cloudingressv1alpha1.ApplicationIngress{DNSName: "apps2.test.domain_name.org"},
+			Expected:   "apps2",
+		},
+		{
+			Name:       "Default IngressController",
+			AppIngress: cloudingressv1alpha1.ApplicationIngress{DNSName: "apps.test.domain_name.org", Default: true},
+			Expected:   "default",
+		},
+		{
+			Name:          "DNS name without a dot",
+			AppIngress:    cloudingressv1alpha1.ApplicationIngress{DNSName: "apps2"},
+			ExpectedError: true,
+		},
+		{
+			Name:          "DNS name starting with a dot",
+			AppIngress:    cloudingressv1alpha1.ApplicationIngress{DNSName: ".test.domain_name.org"},
+			ExpectedError: true,
+		},
+	}
+	for _, test := range tests {
+		result, err := utils.IngressControllerName(test.AppIngress)
+		if (err != nil) != test.ExpectedError {
+			t.Fatalf("Test [%v] FAILED. Expected error %v. Got %v", test.Name, test.ExpectedError, err)
+		}
+		if result != test.Expected {
+			t.Fatalf("Test [%v] FAILED. Expected %v. Got %v", test.Name, test.Expected, result)
+		}
+	}
+}
 
-	if expected != result {
-		t.Errorf("got %s \n, expected %s \n", result, expected)
+// mustGenerateIngressController generates the IngressController of appIngress,
+// failing the test if it can't
+func mustGenerateIngressController(t *testing.T, appIngress cloudingressv1alpha1.ApplicationIngress) *operatorv1.IngressController {
+	t.Helper()
+	ingressController, err := generateIngressController(appIngress)
+	if err != nil {
+		t.Fatalf("Couldn't generate the IngressController: %v", err)
 	}
+	return ingressController
 }
 
 func TestGenerateIngressController(t *testing.T) {
@@ -53,7 +97,7 @@ func TestGenerateIngressController(t *testing.T) {
 		Certificate: corev1.SecretReference{Name: "example-cert-nondefault", Namespace: "openshift-ingress-operator"},
 	}
 
-	result := generateIngressController(applicationIngress)
+	result := mustGenerateIngressController(t, applicationIngress)
 
 	// since these are pointers to different struct the pointer addresses are not the same, therefore reflect.DeepEqual won't work
 	// compare parts that we can
@@ -72,7 +116,7 @@ func TestValidateStaticStatus(t *testing.T) {
 		Certificate: corev1.SecretReference{Name: "example-cert-nondefault", Namespace: "openshift-ingress-operator"},
 	}
 	// Generate desired IngressContoller
-	desiredIngressController := generateIngressController(applicationIngress)
+	desiredIngressController := mustGenerateIngressController(t, applicationIngress)
 
 	var replicas int32 = 2
 	// Build "actual" IngressController that should fail
@@ -138,7 +182,7 @@ func TestValidateStaticSpec(t *testing.T) {
 		Certificate: corev1.SecretReference{Name: "example-cert-nondefault", Namespace: "openshift-ingress-operator"},
 	}
 	// Generate desired IngressContoller
-	desiredIngressController := generateIngressController(applicationIngress)
+	desiredIngressController := mustGenerateIngressController(t, applicationIngress)
 
 	// Build "actual" IngressController that should fail
 	actualIngressController1 := &operatorv1.IngressController{
@@ -213,7 +257,7 @@ func TestValidatePatchableSpec(t *testing.T) {
 		},
 	}
 	// Generate desired IngressContoller
-	desiredIngressController := generateIngressController(applicationIngress)
+	desiredIngressController := mustGenerateIngressController(t, applicationIngress)
 
 	// Build "actual" IngressController that should fail
 	actualIngressController1 := &operatorv1.IngressController{
@@ -313,7 +357,7 @@ func TestValidatePatchableStatus(t *testing.T) {
 		},
 	}
 	// Generate desired IngressContoller
-	desiredIngressController := generateIngressController(applicationIngress)
+	desiredIngressController := mustGenerateIngressController(t, applicationIngress)
 
 	// Build "actual" IngressController that should fail
 	actualIngressController1 := &operatorv1.IngressController{
@@ -375,3 +419,110 @@ func TestValidatePatchableStatus(t *testing.T) {
 		t.Errorf("Expected IngressController and desired config to be the same %+v\n %+v\n", actualIngressController2.Status.Selector, desiredIngressController.Spec.RouteSelector.MatchLabels)
 	}
 }
+
+func TestSetApplicationIngressStatus(t *testing.T) {
+	publishingStrategy := &cloudingressv1alpha1.PublishingStrategy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "publishingstrategy",
+			Namespace: "openshift-cloud-ingress-operator",
+		},
+		Spec: cloudingressv1alpha1.PublishingStrategySpec{
+			ApplicationIngress: []cloudingressv1alpha1.ApplicationIngress{
+				{
+					Listening: cloudingressv1alpha1.External,
+					Default:   true,
+					DNSName:   "apps.unit.test",
+				},
+				{
+					Listening: cloudingressv1alpha1.Internal,
+					Default:   false,
+					DNSName:   "apps2.unit.test",
+				},
+			},
+		},
+	}
+	routerDefaultSvc := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "router-default",
+			Namespace: "openshift-ingress",
+		},
+		Status: corev1.ServiceStatus{
+			LoadBalancer: corev1.LoadBalancerStatus{
+				Ingress: []corev1.LoadBalancerIngress{
+					{Hostname: "abcdefgh.us-east-1.elb.amazonaws.com"},
+				},
+			},
+		},
+	}
+	mocks := testutils.NewTestMock(t, []runtime.Object{publishingStrategy, routerDefaultSvc})
+	r := &ReconcilePublishingStrategy{client: mocks.FakeKubeClient, scheme: mocks.Scheme}
+
+	err := r.setApplicationIngressStatus(publishingStrategy)
+	if err != nil {
+		t.Fatalf("Couldn't set the ApplicationIngress status: %v", err)
+	}
+
+	expected := []cloudingressv1alpha1.ApplicationIngressStatus{
+		{
+			DNSName:               "apps.unit.test",
+			IngressControllerName: "default",
+			LoadBalancer:          "abcdefgh.us-east-1.elb.amazonaws.com",
+		},
+		{
+			// no router Service yet
+			DNSName:               "apps2.unit.test",
+			IngressControllerName: "apps2",
+		},
+	}
+	actual := &cloudingressv1alpha1.PublishingStrategy{}
+	err = r.client.Get(context.TODO(), types.NamespacedName{Name: publishingStrategy.Name, Namespace: publishingStrategy.Namespace}, actual)
+	if err != nil {
+		t.Fatalf("Couldn't get the PublishingStrategy: %v", err)
+	}
+	if !reflect.DeepEqual(actual.Status.ApplicationIngress, expected) {
+		t.Errorf("got %+v \n, expected %+v \n", actual.Status.ApplicationIngress, expected)
+	}
+}
+
+func TestDriftedIngressControllers(t *testing.T) {
+	publishingStrategy := &cloudingressv1alpha1.PublishingStrategy{
+		Spec: cloudingressv1alpha1.PublishingStrategySpec{
+			ApplicationIngress: []cloudingressv1alpha1.ApplicationIngress{
+				{
+					Listening: cloudingressv1alpha1.External,
+					Default:   true,
+					DNSName:   "apps.unit.test",
+				},
+				{
+					Listening:   cloudingressv1alpha1.Internal,
+					Default:     false,
+					DNSName:     "apps2.unit.test",
+					Certificate: corev1.SecretReference{Name: "apps2-cert", Namespace: "openshift-ingress"},
+				},
+			},
+		},
+	}
+	apps2 := *mustGenerateIngressController(t, publishingStrategy.Spec.ApplicationIngress[1])
+	apps3 := operatorv1.IngressController{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "apps3",
+			Namespace:   "openshift-ingress-operator",
+			Annotations: map[string]string{"Owner": "cloud-ingress-operator"},
+		},
+	}
+
+	if drifted := driftedIngressControllers(publishingStrategy, []operatorv1.IngressController{*mustGenerateIngressController(t, publishingStrategy.Spec.ApplicationIngress[0]), apps2}); drifted != nil {
+		t.Errorf("Expected no drift, got %v", drifted)
+	}
+
+	apps2.Spec.DefaultCertificate = &corev1.LocalObjectReference{Name: "another-cert"}
+	expected := []string{
+		"default is missing",
+		"apps2 has a different IngressControllerCertificate",
+		"apps3 isn't in the PublishingStrategy",
+	}
+	drifted := driftedIngressControllers(publishingStrategy, []operatorv1.IngressController{apps2, apps3})
+	if !reflect.DeepEqual(drifted, expected) {
+		t.Errorf("got %v, expected %v", drifted, expected)
+	}
+}
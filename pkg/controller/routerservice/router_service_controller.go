@@ -2,11 +2,21 @@ package routerservice
 
 import (
 	"context"
+	"sync"
+	"time"
 
+	configv1 "github.com/openshift/api/config/v1"
+	operatorv1 "github.com/openshift/api/operator/v1"
+	cloudingressv1alpha1 "github.com/openshift/cloud-ingress-operator/pkg/apis/cloudingress/v1alpha1"
+	"github.com/openshift/cloud-ingress-operator/pkg/cloudclient"
+	utils "github.com/openshift/cloud-ingress-operator/pkg/controller/utils"
+	cioerrors "github.com/openshift/cloud-ingress-operator/pkg/errors"
+	baseutils "github.com/openshift/cloud-ingress-operator/pkg/utils"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/event"
@@ -20,10 +30,32 @@ import (
 
 var log = logf.Log.WithName("controller_router_service")
 
+// MaxConcurrentReconciles is how many router Services are reconciled at once
+var MaxConcurrentReconciles = 1
+
+// RequeueIntervals are how soon a router Service is reconciled again. Its
+// Error is how often its IngressController and load balancer are checked
+// again while they're not ready
+var RequeueIntervals = utils.RequeueIntervals{Error: 30 * time.Second}
+
 const (
 	RouterServiceNamespace = "openshift-ingress"
 	ELBAnnotationKey       = "service.beta.kubernetes.io/aws-load-balancer-connection-idle-timeout"
 	ELBAnnotationValue     = "1800"
+	// ELBInternalAnnotationKey makes the AWS load balancer internal. Its
+	// presence, not its value, decides the scheme
+	ELBInternalAnnotationKey   = "service.beta.kubernetes.io/aws-load-balancer-internal"
+	ELBInternalAnnotationValue = "0.0.0.0/0"
+	ProxyProtocolAnnotationKey = "service.beta.kubernetes.io/aws-load-balancer-proxy-protocol"
+	ProxyProtocolAnnotation    = "*"
+	// GCPInternalAnnotationKey set to GCPInternalAnnotationValue makes the GCP
+	// load balancer internal. The ingress operator sets it for internal
+	// IngressControllers
+	GCPInternalAnnotationKey   = "cloud.google.com/load-balancer-type"
+	GCPInternalAnnotationValue = "Internal"
+
+	ingressControllerNamespace = "openshift-ingress-operator"
+	routerServicePrefix        = "router-"
 )
 
 // Add creates a new Controller and adds it to the Manager. The Manager will set fields on the Controller
@@ -34,13 +66,13 @@ func Add(mgr manager.Manager) error {
 
 // newReconciler returns a new reconcile.Reconciler
 func newReconciler(mgr manager.Manager) reconcile.Reconciler {
-	return &ReconcileRouterService{client: mgr.GetClient(), scheme: mgr.GetScheme()}
+	return &ReconcileRouterService{client: baseutils.NewTimedClient(mgr.GetClient()), scheme: mgr.GetScheme()}
 }
 
 // add adds a new Controller to mgr with r as the reconcile.Reconciler
 func add(mgr manager.Manager, r reconcile.Reconciler) error {
 	// Create a new controller
-	c, err := controller.New("router-service-controller", mgr, controller.Options{Reconciler: r})
+	c, err := controller.New("router-service-controller", mgr, controller.Options{Reconciler: r, MaxConcurrentReconciles: MaxConcurrentReconciles})
 	if err != nil {
 		return err
 	}
@@ -63,9 +95,40 @@ func add(mgr manager.Manager, r reconcile.Reconciler) error {
 		return err
 	}
 
+	// Watch for changes to PublishingStrategy, which decides the scheme of
+	// each router Service's load balancer
+	err = c.Watch(&source.Kind{Type: &cloudingressv1alpha1.PublishingStrategy{}}, handler.EnqueueRequestsFromMapFunc(routerServicesFor))
+	if err != nil {
+		return err
+	}
+
 	return nil
 }
 
+// routerServicesFor maps a PublishingStrategy to the router Services of its
+// ApplicationIngresses
+func routerServicesFor(obj client.Object) []reconcile.Request {
+	publishingStrategy, ok := obj.(*cloudingressv1alpha1.PublishingStrategy)
+	if !ok {
+		return []reconcile.Request{}
+	}
+	requests := []reconcile.Request{}
+	for _, appIngress := range publishingStrategy.Spec.ApplicationIngress {
+		// The publishingstrategy controller reports a malformed DNS name
+		ingressName, err := utils.IngressControllerName(appIngress)
+		if err != nil {
+			continue
+		}
+		requests = append(requests, reconcile.Request{
+			NamespacedName: types.NamespacedName{
+				Name:      routerServicePrefix + ingressName,
+				Namespace: RouterServiceNamespace,
+			},
+		})
+	}
+	return requests
+}
+
 // blank assignment to verify that ReconcileRouterService implements reconcile.Reconciler
 var _ reconcile.Reconciler = &ReconcileRouterService{}
 
@@ -75,6 +138,37 @@ type ReconcileRouterService struct {
 	// that reads objects from the cache and writes to the apiserver
 	client client.Client
 	scheme *runtime.Scheme
+
+	cloudClient cloudclient.CloudClient
+	// cloudClientConfigVersion is the platform config version cloudClient was
+	// made for. It's empty for a cloudClient set by the tests, which is kept
+	cloudClientConfigVersion string
+	// cloudClientMu guards cloudClient and cloudClientConfigVersion across
+	// concurrent reconciles
+	cloudClientMu sync.Mutex
+}
+
+// refreshCloudClient returns the cloud client, making a new one first if the
+// cluster's platform config changed since it was made
+func (r *ReconcileRouterService) refreshCloudClient() (cloudclient.CloudClient, error) {
+	r.cloudClientMu.Lock()
+	defer r.cloudClientMu.Unlock()
+	if r.cloudClient != nil && r.cloudClientConfigVersion == "" {
+		return r.cloudClient, nil
+	}
+	configVersion, err := baseutils.GetPlatformConfigVersion(r.client)
+	if err != nil {
+		return nil, err
+	}
+	if r.cloudClient == nil || configVersion != r.cloudClientConfigVersion {
+		platform, err := baseutils.GetPlatformType(r.client)
+		if err != nil {
+			return nil, err
+		}
+		r.cloudClient = cloudclient.GetSharedClientFor(r.client, *platform, configVersion)
+		r.cloudClientConfigVersion = configVersion
+	}
+	return r.cloudClient, nil
 }
 
 // Reconcile reads that state of the cluster for a RouterService object and makes changes based on the state read
@@ -83,11 +177,13 @@ type ReconcileRouterService struct {
 // The Controller will requeue the Request to be processed again if the returned error is non-nil or
 // Result.Requeue is true, otherwise upon completion it will remove the work from the queue.
 func (r *ReconcileRouterService) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	ctx, timings := baseutils.WithReconcileTimings(ctx)
+	defer utils.ReportReconcileTimings("routerservice", timings)
 	reqLogger := log.WithValues("Request.Namespace", request.Namespace, "Request.Name", request.Name)
 
 	// Fetch the Service
 	svc := &corev1.Service{}
-	err := r.client.Get(context.TODO(), request.NamespacedName, svc)
+	err := r.client.Get(ctx, request.NamespacedName, svc)
 	if err != nil {
 		if errors.IsNotFound(err) {
 			// Request object not found, could have been deleted after reconcile request.
@@ -99,21 +195,171 @@ func (r *ReconcileRouterService) Reconcile(ctx context.Context, request reconcil
 		return reconcile.Result{}, err
 	}
 
+	paused, err := baseutils.IsPaused(r.client, svc)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	if paused {
+		reqLogger.Info("Paused, not reconciling")
+		return reconcile.Result{}, nil
+	}
+
 	// Only check LoadBalancer service types for annotations
-	if svc.Spec.Type == corev1.ServiceTypeLoadBalancer {
-		if !metav1.HasAnnotation(svc.ObjectMeta, ELBAnnotationKey) ||
-			svc.ObjectMeta.Annotations[ELBAnnotationKey] != ELBAnnotationValue {
-			reqLogger.Info("Updating annotation for " + svc.Name)
-			metav1.SetMetaDataAnnotation(&svc.ObjectMeta, ELBAnnotationKey, ELBAnnotationValue)
-			err = r.client.Update(context.TODO(), svc)
-			if err != nil {
-				reqLogger.Error(err, "Error updating service annotation")
+	if svc.Spec.Type != corev1.ServiceTypeLoadBalancer {
+		return reconcile.Result{}, nil
+	}
+
+	if !metav1.HasAnnotation(svc.ObjectMeta, ELBAnnotationKey) ||
+		svc.ObjectMeta.Annotations[ELBAnnotationKey] != ELBAnnotationValue {
+		reqLogger.Info("Updating annotation for " + svc.Name)
+		metav1.SetMetaDataAnnotation(&svc.ObjectMeta, ELBAnnotationKey, ELBAnnotationValue)
+		err = r.client.Update(ctx, svc)
+		if err != nil {
+			reqLogger.Error(err, "Error updating service annotation")
+			return reconcile.Result{}, err
+		}
+	} else {
+		reqLogger.Info("skipping service " + svc.Name + " w/ proper annotations")
+	}
+
+	// The remaining annotations are only enforced for router Services of an
+	// ApplicationIngress, and only on AWS and GCP
+	appIngress, err := r.getApplicationIngressFor(svc)
+	if err != nil {
+		reqLogger.Error(err, "Error getting the PublishingStrategy")
+		return reconcile.Result{}, err
+	}
+	if appIngress == nil {
+		return reconcile.Result{}, nil
+	}
+	cloudPlatform, err := baseutils.GetPlatformType(r.client)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	if *cloudPlatform != configv1.AWSPlatformType && *cloudPlatform != configv1.GCPPlatformType {
+		return reconcile.Result{}, nil
+	}
+
+	// AWS can't change the scheme of an existing load balancer, so a router
+	// Service with the wrong scheme is deleted for the ingress operator to
+	// recreate. GCP could, but recreating the Service also gives the load
+	// balancer an address of the right kind. That's only useful once the
+	// IngressController has the right scope, or it would be recreated with the
+	// wrong scheme again
+	internal := appIngress.Listening == cloudingressv1alpha1.Internal
+	if isInternalLoadBalancer(svc, *cloudPlatform) != internal {
+		ingressName, err := utils.IngressControllerName(*appIngress)
+		if err != nil {
+			return reconcile.Result{}, err
+		}
+		scopeMatches, err := r.ingressControllerScopeMatches(ingressName, internal)
+		if err != nil {
+			return reconcile.Result{}, err
+		}
+		if !scopeMatches {
+			reqLogger.Info("Load balancer scheme of " + svc.Name + " doesn't match the PublishingStrategy, waiting for its IngressController")
+			return RequeueIntervals.ErrorResult(), nil
+		}
+		reqLogger.Info("Load balancer scheme of " + svc.Name + " doesn't match the PublishingStrategy, deleting it to be recreated")
+		err = r.client.Delete(ctx, svc)
+		if err != nil {
+			reqLogger.Error(err, "Error deleting service")
+			return reconcile.Result{}, err
+		}
+		return reconcile.Result{}, nil
+	}
+
+	// The scheme is right, so the wildcard DNS records of the ApplicationIngress
+	// follow the load balancer the Service has now. A recreated Service is
+	// reconciled again once the cloud provider gives it its new load balancer
+	if len(svc.Status.LoadBalancer.Ingress) > 0 {
+		cloudClient, err := r.refreshCloudClient()
+		if err != nil {
+			return reconcile.Result{}, err
+		}
+		err = cloudClient.EnsureRouterServiceDNS(ctx, r.client, appIngress, svc)
+		if err != nil {
+			switch err.(type) {
+			case *cioerrors.LoadBalancerNotReadyError:
+				reqLogger.Info("Load balancer of " + svc.Name + " isn't ready yet, requeueing")
+				return RequeueIntervals.ErrorResult(), nil
+			default:
+				reqLogger.Error(err, "Error ensuring the DNS records of "+appIngress.DNSName)
 				return reconcile.Result{}, err
 			}
-		} else {
-			reqLogger.Info("skipping service " + svc.Name + " w/ proper annotations")
 		}
 	}
 
-	return reconcile.Result{}, nil
+	if *cloudPlatform != configv1.AWSPlatformType {
+		return RequeueIntervals.SuccessResult(), nil
+	}
+	if internal && svc.Annotations[ELBInternalAnnotationKey] != ELBInternalAnnotationValue ||
+		svc.Annotations[ProxyProtocolAnnotationKey] != ProxyProtocolAnnotation {
+		reqLogger.Info("Updating load balancer annotations for " + svc.Name)
+		if internal {
+			metav1.SetMetaDataAnnotation(&svc.ObjectMeta, ELBInternalAnnotationKey, ELBInternalAnnotationValue)
+		}
+		metav1.SetMetaDataAnnotation(&svc.ObjectMeta, ProxyProtocolAnnotationKey, ProxyProtocolAnnotation)
+		err = r.client.Update(ctx, svc)
+		if err != nil {
+			reqLogger.Error(err, "Error updating service annotation")
+			return reconcile.Result{}, err
+		}
+	}
+
+	return RequeueIntervals.SuccessResult(), nil
+}
+
+// isInternalLoadBalancer checks if the cloud provider gives svc an internal
+// load balancer on platform
+func isInternalLoadBalancer(svc *corev1.Service, platform configv1.PlatformType) bool {
+	if platform == configv1.GCPPlatformType {
+		return svc.Annotations[GCPInternalAnnotationKey] == GCPInternalAnnotationValue
+	}
+	return metav1.HasAnnotation(svc.ObjectMeta, ELBInternalAnnotationKey)
+}
+
+// getApplicationIngressFor returns the ApplicationIngress svc is the router
+// Service for, or nil if there is none
+func (r *ReconcileRouterService) getApplicationIngressFor(svc *corev1.Service) (*cloudingressv1alpha1.ApplicationIngress, error) {
+	publishingStrategyList := &cloudingressv1alpha1.PublishingStrategyList{}
+	err := r.client.List(context.TODO(), publishingStrategyList)
+	if err != nil {
+		return nil, err
+	}
+	for _, publishingStrategy := range publishingStrategyList.Items {
+		for _, appIngress := range publishingStrategy.Spec.ApplicationIngress {
+			if ingressName, err := utils.IngressControllerName(appIngress); err == nil && routerServicePrefix+ingressName == svc.Name {
+				return appIngress.DeepCopy(), nil
+			}
+		}
+	}
+	return nil, nil
+}
+
+// ingressControllerScopeMatches checks if the named IngressController already
+// publishes through a load balancer of the wanted scope. The default
+// IngressController may only have it in its status
+func (r *ReconcileRouterService) ingressControllerScopeMatches(name string, internal bool) (bool, error) {
+	ingressController := &operatorv1.IngressController{}
+	err := r.client.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: ingressControllerNamespace}, ingressController)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	scope := operatorv1.ExternalLoadBalancer
+	if internal {
+		scope = operatorv1.InternalLoadBalancer
+	}
+	for _, eps := range []*operatorv1.EndpointPublishingStrategy{
+		ingressController.Spec.EndpointPublishingStrategy,
+		ingressController.Status.EndpointPublishingStrategy,
+	} {
+		if eps != nil && eps.LoadBalancer != nil {
+			return eps.LoadBalancer.Scope == scope, nil
+		}
+	}
+	return false, nil
 }
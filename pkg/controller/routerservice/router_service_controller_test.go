@@ -3,9 +3,18 @@ package routerservice
 import (
 	"context"
 	"testing"
+	"time"
 
+	"github.com/golang/mock/gomock"
+	operatorv1 "github.com/openshift/api/operator/v1"
+	cloudingressv1alpha1 "github.com/openshift/cloud-ingress-operator/pkg/apis/cloudingress/v1alpha1"
+	mockcc "github.com/openshift/cloud-ingress-operator/pkg/cloudclient/mock_cloudclient"
+	cioerrors "github.com/openshift/cloud-ingress-operator/pkg/errors"
+	"github.com/openshift/cloud-ingress-operator/pkg/testutils"
 	corev1 "k8s.io/api/core/v1"
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes/scheme"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
@@ -38,6 +47,9 @@ func TestRouterServiceController(t *testing.T) {
 
 	// Register operator types with the runtime scheme.
 	s := scheme.Scheme
+	if err := cloudingressv1alpha1.SchemeBuilder.AddToScheme(s); err != nil {
+		t.Fatalf("Couldn't add cloudingressv1alpha1 scheme: (%v)", err)
+	}
 
 	// Create a fake client to mock API calls.
 	cl := fake.
@@ -91,3 +103,192 @@ func TestRouterServiceController(t *testing.T) {
 		t.Error("service does not have expected annotation")
 	}
 }
+
+// TestRouterServiceSchemeChange checks that a router Service whose load
+// balancer scheme doesn't match the PublishingStrategy is deleted once its
+// IngressController has the right scope, and left alone before that.
+func TestRouterServiceSchemeChange(t *testing.T) {
+	tests := []struct {
+		Name            string
+		GCP             bool
+		Annotations     map[string]string
+		Scope           operatorv1.LoadBalancerScope
+		ExpectedDeleted bool
+	}{
+		{
+			Name:            "IngressController already internal",
+			Scope:           operatorv1.InternalLoadBalancer,
+			ExpectedDeleted: true,
+		},
+		{
+			Name:            "IngressController still external",
+			Scope:           operatorv1.ExternalLoadBalancer,
+			ExpectedDeleted: false,
+		},
+		{
+			Name:            "GCP IngressController already internal",
+			GCP:             true,
+			Scope:           operatorv1.InternalLoadBalancer,
+			ExpectedDeleted: true,
+		},
+		{
+			Name:            "GCP load balancer already internal",
+			GCP:             true,
+			Annotations:     map[string]string{GCPInternalAnnotationKey: GCPInternalAnnotationValue},
+			Scope:           operatorv1.InternalLoadBalancer,
+			ExpectedDeleted: false,
+		},
+	}
+	for _, test := range tests {
+		annotations := map[string]string{
+			ELBAnnotationKey: ELBAnnotationValue,
+		}
+		for key, value := range test.Annotations {
+			annotations[key] = value
+		}
+		routerDefaultSvc := &corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "router-default",
+				Namespace:   RouterServiceNamespace,
+				Annotations: annotations,
+			},
+			Spec: corev1.ServiceSpec{
+				Type: corev1.ServiceTypeLoadBalancer,
+			},
+		}
+		publishingStrategy := &cloudingressv1alpha1.PublishingStrategy{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "publishingstrategy",
+				Namespace: "openshift-cloud-ingress-operator",
+			},
+			Spec: cloudingressv1alpha1.PublishingStrategySpec{
+				ApplicationIngress: []cloudingressv1alpha1.ApplicationIngress{
+					{
+						Listening: cloudingressv1alpha1.Internal,
+						Default:   true,
+						DNSName:   "apps." + testutils.DefaultClusterDomain,
+					},
+				},
+			},
+		}
+		ingressController := &operatorv1.IngressController{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "default",
+				Namespace: ingressControllerNamespace,
+			},
+			Status: operatorv1.IngressControllerStatus{
+				EndpointPublishingStrategy: &operatorv1.EndpointPublishingStrategy{
+					Type:         operatorv1.LoadBalancerServiceStrategyType,
+					LoadBalancer: &operatorv1.LoadBalancerStrategy{Scope: test.Scope},
+				},
+			},
+		}
+		infraObj := testutils.CreateInfraObject("basename", testutils.DefaultAPIEndpoint, testutils.DefaultAPIEndpoint, testutils.DefaultRegionName)
+		if test.GCP {
+			infraObj = testutils.CreateGCPInfraObject("basename", testutils.DefaultAPIEndpoint, testutils.DefaultAPIEndpoint, testutils.DefaultRegionName)
+		}
+		if err := operatorv1.AddToScheme(scheme.Scheme); err != nil {
+			t.Fatalf("Couldn't add operatorv1 scheme: (%v)", err)
+		}
+		mocks := testutils.NewTestMock(t, []runtime.Object{routerDefaultSvc, publishingStrategy, ingressController, infraObj})
+		r := &ReconcileRouterService{client: mocks.FakeKubeClient, scheme: mocks.Scheme}
+
+		req := reconcile.Request{
+			NamespacedName: types.NamespacedName{
+				Name:      routerDefaultSvc.Name,
+				Namespace: routerDefaultSvc.Namespace,
+			},
+		}
+		_, err := r.Reconcile(context.TODO(), req)
+		if err != nil {
+			t.Fatalf("Test [%v] reconcile: (%v)", test.Name, err)
+		}
+
+		err = r.client.Get(context.TODO(), req.NamespacedName, &corev1.Service{})
+		deleted := k8serr.IsNotFound(err)
+		if err != nil && !deleted {
+			t.Fatalf("Test [%v] get service: (%v)", test.Name, err)
+		}
+		if deleted != test.ExpectedDeleted {
+			t.Fatalf("Test [%v] FAILED. Expected service deleted: %t. Got %t", test.Name, test.ExpectedDeleted, deleted)
+		}
+	}
+}
+
+// TestRouterServiceDNS checks that the wildcard DNS records of an
+// ApplicationIngress are pointed at the load balancer its router Service has,
+// once the scheme is right, and that a load balancer not ready yet is retried
+func TestRouterServiceDNS(t *testing.T) {
+	tests := []struct {
+		Name                 string
+		DNSError             error
+		ExpectedRequeueAfter time.Duration
+	}{
+		{
+			Name:                 "DNS records ensured",
+			DNSError:             nil,
+			ExpectedRequeueAfter: 0,
+		},
+		{
+			Name:                 "Load balancer not ready",
+			DNSError:             cioerrors.NewLoadBalancerNotReadyError(),
+			ExpectedRequeueAfter: 30 * time.Second,
+		},
+	}
+	for _, test := range tests {
+		ctrl := gomock.NewController(t)
+		routerDefaultSvc := &corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "router-default",
+				Namespace: RouterServiceNamespace,
+				Annotations: map[string]string{
+					ELBAnnotationKey:           ELBAnnotationValue,
+					ELBInternalAnnotationKey:   ELBInternalAnnotationValue,
+					ProxyProtocolAnnotationKey: ProxyProtocolAnnotation,
+				},
+			},
+			Spec: corev1.ServiceSpec{
+				Type: corev1.ServiceTypeLoadBalancer,
+			},
+			Status: corev1.ServiceStatus{
+				LoadBalancer: corev1.LoadBalancerStatus{
+					Ingress: []corev1.LoadBalancerIngress{{Hostname: "internal-lb.elb.amazonaws.com"}},
+				},
+			},
+		}
+		publishingStrategy := &cloudingressv1alpha1.PublishingStrategy{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "publishingstrategy",
+				Namespace: "openshift-cloud-ingress-operator",
+			},
+			Spec: cloudingressv1alpha1.PublishingStrategySpec{
+				ApplicationIngress: []cloudingressv1alpha1.ApplicationIngress{
+					{
+						Listening: cloudingressv1alpha1.Internal,
+						Default:   true,
+						DNSName:   "apps." + testutils.DefaultClusterDomain,
+					},
+				},
+			},
+		}
+		infraObj := testutils.CreateInfraObject("basename", testutils.DefaultAPIEndpoint, testutils.DefaultAPIEndpoint, testutils.DefaultRegionName)
+		mocks := testutils.NewTestMock(t, []runtime.Object{routerDefaultSvc, publishingStrategy, infraObj})
+		cloud := mockcc.NewMockCloudClient(ctrl)
+		cloud.EXPECT().EnsureRouterServiceDNS(gomock.Any(), mocks.FakeKubeClient, &publishingStrategy.Spec.ApplicationIngress[0], gomock.Any()).Return(test.DNSError)
+		r := &ReconcileRouterService{client: mocks.FakeKubeClient, scheme: mocks.Scheme, cloudClient: cloud}
+
+		res, err := r.Reconcile(context.TODO(), reconcile.Request{
+			NamespacedName: types.NamespacedName{
+				Name:      routerDefaultSvc.Name,
+				Namespace: routerDefaultSvc.Namespace,
+			},
+		})
+		if err != nil {
+			t.Fatalf("Test [%v] reconcile: (%v)", test.Name, err)
+		}
+		if res.RequeueAfter != test.ExpectedRequeueAfter {
+			t.Fatalf("Test [%v] FAILED. Expected requeue after %v. Got %v", test.Name, test.ExpectedRequeueAfter, res.RequeueAfter)
+		}
+		ctrl.Finish()
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"reflect"
 	"sort"
 	"strings"
+	"sync"
 	"time"
 
 	// For host key generation
@@ -14,8 +15,10 @@ import (
 	"crypto/x509"
 	"encoding/pem"
 
+	configv1 "github.com/openshift/api/config/v1"
 	cloudingressv1alpha1 "github.com/openshift/cloud-ingress-operator/pkg/apis/cloudingress/v1alpha1"
 	"github.com/openshift/cloud-ingress-operator/pkg/cloudclient"
+	utils "github.com/openshift/cloud-ingress-operator/pkg/controller/utils"
 	cioerrors "github.com/openshift/cloud-ingress-operator/pkg/errors"
 	baseutils "github.com/openshift/cloud-ingress-operator/pkg/utils"
 
@@ -34,12 +37,20 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 	"sigs.k8s.io/controller-runtime/pkg/source"
 )
 
 var log = logf.Log.WithName("controller_sshd")
 
+// MaxConcurrentReconciles is how many SSHDs are reconciled at once
+var MaxConcurrentReconciles = 1
+
+// RequeueIntervals are how soon an SSHD is reconciled again. Its Error is how
+// often its load balancer is checked again while it's not ready
+var RequeueIntervals = utils.RequeueIntervals{Error: 10 * time.Second}
+
 // Add creates a new SSHD Controller and adds it to the Manager. The Manager will set fields on the Controller
 // and Start it when the Manager is Started.
 func Add(mgr manager.Manager) error {
@@ -48,13 +59,13 @@ func Add(mgr manager.Manager) error {
 
 // newReconciler returns a new reconcile.Reconciler
 func newReconciler(mgr manager.Manager) reconcile.Reconciler {
-	return &ReconcileSSHD{client: mgr.GetClient(), scheme: mgr.GetScheme()}
+	return &ReconcileSSHD{client: baseutils.NewTimedClient(mgr.GetClient()), scheme: mgr.GetScheme()}
 }
 
 // add adds a new Controller to mgr with r as the reconcile.Reconciler
 func add(mgr manager.Manager, r reconcile.Reconciler) error {
 	// Create a new controller
-	c, err := controller.New("sshd-controller", mgr, controller.Options{Reconciler: r})
+	c, err := controller.New("sshd-controller", mgr, controller.Options{Reconciler: r, MaxConcurrentReconciles: MaxConcurrentReconciles})
 	if err != nil {
 		return err
 	}
@@ -90,6 +101,29 @@ func add(mgr manager.Manager, r reconcile.Reconciler) error {
 		return err
 	}
 
+	// Watch for changes to the cluster's Infrastructure and DNS configs, which
+	// need a new cloud client
+	kclient := mgr.GetClient()
+	for _, obj := range []client.Object{&configv1.Infrastructure{}, &configv1.DNS{}} {
+		err = c.Watch(&source.Kind{Type: obj}, handler.EnqueueRequestsFromMapFunc(func(_ client.Object) []reconcile.Request {
+			sshdList := &cloudingressv1alpha1.SSHDList{}
+			if err := kclient.List(context.TODO(), sshdList); err != nil {
+				log.Error(err, "Cannot get list of SSHDs")
+				return []reconcile.Request{}
+			}
+			requests := []reconcile.Request{}
+			for _, sshd := range sshdList.Items {
+				requests = append(requests, reconcile.Request{
+					NamespacedName: types.NamespacedName{Name: sshd.Name, Namespace: sshd.Namespace},
+				})
+			}
+			return requests
+		}), predicate.ResourceVersionChangedPredicate{})
+		if err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -104,6 +138,35 @@ type ReconcileSSHD struct {
 	scheme *runtime.Scheme
 
 	cloudClient cloudclient.CloudClient
+	// cloudClientConfigVersion is the platform config version cloudClient was
+	// made for. It's empty for a cloudClient set by the tests, which is kept
+	cloudClientConfigVersion string
+	// cloudClientMu guards cloudClient and cloudClientConfigVersion across
+	// concurrent reconciles
+	cloudClientMu sync.Mutex
+}
+
+// refreshCloudClient returns the cloud client, making a new one first if the
+// cluster's platform config changed since it was made
+func (r *ReconcileSSHD) refreshCloudClient() (cloudclient.CloudClient, error) {
+	r.cloudClientMu.Lock()
+	defer r.cloudClientMu.Unlock()
+	if r.cloudClient != nil && r.cloudClientConfigVersion == "" {
+		return r.cloudClient, nil
+	}
+	configVersion, err := baseutils.GetPlatformConfigVersion(r.client)
+	if err != nil {
+		return nil, err
+	}
+	if r.cloudClient == nil || configVersion != r.cloudClientConfigVersion {
+		platform, err := baseutils.GetPlatformType(r.client)
+		if err != nil {
+			return nil, err
+		}
+		r.cloudClient = cloudclient.GetSharedClientFor(r.client, *platform, configVersion)
+		r.cloudClientConfigVersion = configVersion
+	}
+	return r.cloudClient, nil
 }
 
 const (
@@ -121,12 +184,14 @@ const (
 // The Controller will requeue the Request to be processed again if the returned error is non-nil or
 // Result.Requeue is true, otherwise upon completion it will remove the work from the queue.
 func (r *ReconcileSSHD) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	ctx, timings := baseutils.WithReconcileTimings(ctx)
+	defer utils.ReportReconcileTimings("sshd", timings)
 	reqLogger := log.WithValues("Request.Namespace", request.Namespace, "Request.Name", request.Name)
 	reqLogger.Info("Reconciling SSHD")
 
 	// Fetch the SSHD instance
 	instance := &cloudingressv1alpha1.SSHD{}
-	err := r.client.Get(context.TODO(), request.NamespacedName, instance)
+	err := r.client.Get(ctx, request.NamespacedName, instance)
 	if err != nil {
 		if errors.IsNotFound(err) {
 			// Request object not found, could have been deleted after reconcile request.
@@ -138,15 +203,21 @@ func (r *ReconcileSSHD) Reconcile(ctx context.Context, request reconcile.Request
 		return reconcile.Result{}, err
 	}
 
-	// Ensure we have a cloudClient instance.
-	if r.cloudClient == nil {
-		platform, err := baseutils.GetPlatformType(r.client)
-		if err != nil {
-			r.SetSSHDStatusError(instance, "Failed to get cluster's platform", err)
-			return reconcile.Result{}, err
-		}
+	paused, err := baseutils.IsPaused(r.client, instance)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	if paused {
+		reqLogger.Info("Paused, not reconciling")
+		return reconcile.Result{}, nil
+	}
 
-		r.cloudClient = cloudclient.GetClientFor(r.client, *platform)
+	// Ensure we have a cloudClient instance, made for the cluster's current
+	// Infrastructure and DNS configs.
+	cloudClient, err := r.refreshCloudClient()
+	if err != nil {
+		r.SetSSHDStatusError(instance, "Failed to get cluster's platform", err)
+		return reconcile.Result{}, err
 	}
 
 	// Check for a deletion timestamp.
@@ -154,7 +225,7 @@ func (r *ReconcileSSHD) Reconcile(ctx context.Context, request reconcile.Request
 		// Request object is alive, so ensure it has the DNS finalizer.
 		if !controllerutil.ContainsFinalizer(instance, reconcileSSHDFinalizerDNS) {
 			controllerutil.AddFinalizer(instance, reconcileSSHDFinalizerDNS)
-			if err = r.client.Update(context.TODO(), instance); err != nil {
+			if err = r.client.Update(ctx, instance); err != nil {
 				return reconcile.Result{}, err
 			}
 		}
@@ -165,26 +236,26 @@ func (r *ReconcileSSHD) Reconcile(ctx context.Context, request reconcile.Request
 
 			// fetch the sshd service
 			svc := &corev1.Service{}
-			err := r.client.Get(context.TODO(), types.NamespacedName{Namespace: instance.Namespace, Name: instance.Name}, svc)
+			err := r.client.Get(ctx, types.NamespacedName{Namespace: instance.Namespace, Name: instance.Name}, svc)
 			if err != nil {
 				return reconcile.Result{}, err
 			}
 
-			err = r.cloudClient.DeleteSSHDNS(context.TODO(), r.client, instance, svc)
+			err = cloudClient.DeleteSSHDNS(ctx, r.client, instance, svc)
 			switch err := err.(type) {
 			case nil:
 				// all good
 			case *cioerrors.LoadBalancerNotReadyError:
 				r.SetSSHDStatus(instance, "Couldn't reconcile", "Load balancer isn't ready.")
-				return reconcile.Result{Requeue: true, RequeueAfter: 10 * time.Second}, nil
+				return RequeueIntervals.ErrorResult(), nil
 			default:
 				r.SetSSHDStatusError(instance, "Failed to delete the DNS record", err)
-				return reconcile.Result{}, err
+				return utils.CloudErrorResult(err)
 			}
 
 			// Remove the DNS finalizer and update the request object.
 			controllerutil.RemoveFinalizer(instance, reconcileSSHDFinalizerDNS)
-			if err = r.client.Update(context.TODO(), instance); err != nil {
+			if err = r.client.Update(ctx, instance); err != nil {
 				return reconcile.Result{}, err
 			}
 		}
@@ -213,7 +284,7 @@ func (r *ReconcileSSHD) Reconcile(ctx context.Context, request reconcile.Request
 	if err != nil {
 		return reconcile.Result{}, err
 	}
-	if err = r.client.List(context.TODO(), configMapList,
+	if err = r.client.List(ctx, configMapList,
 		client.InNamespace(instance.Namespace),
 		&client.MatchingLabelsSelector{Selector: selector}); err != nil {
 		r.SetSSHDStatusError(instance, "Failed to list config maps with SSH keys", err)
@@ -230,7 +301,7 @@ func (r *ReconcileSSHD) Reconcile(ctx context.Context, request reconcile.Request
 		Namespace: instance.Namespace,
 		Name:      instance.Name + "-host-keys",
 	}
-	if err = r.client.Get(context.TODO(), secretName, hostKeysSecret); err != nil {
+	if err = r.client.Get(ctx, secretName, hostKeysSecret); err != nil {
 		if errors.IsNotFound(err) {
 			// Create a new "host-keys" Secret.
 			r.SetSSHDStatusPending(instance, "Generating host keys")
@@ -243,7 +314,7 @@ func (r *ReconcileSSHD) Reconcile(ctx context.Context, request reconcile.Request
 				r.SetSSHDStatusError(instance, "Failed to set secret controller reference", err)
 				return reconcile.Result{}, err
 			}
-			if err = r.client.Create(context.TODO(), secret); err != nil {
+			if err = r.client.Create(ctx, secret); err != nil {
 				if errors.IsAlreadyExists(err) {
 					return reconcile.Result{Requeue: true}, nil
 				}
@@ -261,18 +332,11 @@ func (r *ReconcileSSHD) Reconcile(ctx context.Context, request reconcile.Request
 	foundDeployment := &appsv1.Deployment{}
 	deployment := newSSHDDeployment(instance, configMapList, hostKeysSecret)
 	deploymentName := client.ObjectKeyFromObject(deployment)
-	if err = r.client.Get(context.TODO(), deploymentName, foundDeployment); err != nil {
+	if err = r.client.Get(ctx, deploymentName, foundDeployment); err != nil {
 		if errors.IsNotFound(err) {
 			// Create a new Deployment.
 			r.SetSSHDStatusPending(instance, "Creating deployment")
-			if err := controllerutil.SetControllerReference(instance, deployment, r.scheme); err != nil {
-				r.SetSSHDStatusError(instance, "Failed to set deployment controller reference", err)
-				return reconcile.Result{}, err
-			}
-			if err = r.client.Create(context.TODO(), deployment); err != nil {
-				if errors.IsAlreadyExists(err) {
-					return reconcile.Result{Requeue: true}, nil
-				}
+			if err = utils.Apply(ctx, r.client, r.scheme, instance, deployment); err != nil {
 				r.SetSSHDStatusError(instance, "Failed to create deployment", err)
 				return reconcile.Result{}, err
 			}
@@ -285,7 +349,7 @@ func (r *ReconcileSSHD) Reconcile(ctx context.Context, request reconcile.Request
 			// Specs aren't equal, update and fix.
 			r.SetSSHDStatusPending(instance, "Updating deployment", "from", foundDeployment.Spec, "to", deployment.Spec)
 			foundDeployment.Spec = *deployment.Spec.DeepCopy()
-			if err = r.client.Update(context.TODO(), foundDeployment); err != nil {
+			if err = r.client.Update(ctx, foundDeployment); err != nil {
 				r.SetSSHDStatusError(instance, "Failed to update deployment", err)
 				return reconcile.Result{}, err
 			}
@@ -296,24 +360,17 @@ func (r *ReconcileSSHD) Reconcile(ctx context.Context, request reconcile.Request
 	foundService := &corev1.Service{}
 	service := newSSHDService(instance)
 	serviceName := client.ObjectKeyFromObject(service)
-	if err = r.client.Get(context.TODO(), serviceName, foundService); err != nil {
+	if err = r.client.Get(ctx, serviceName, foundService); err != nil {
 		if errors.IsNotFound(err) {
 			// Create a new Service.
 			r.SetSSHDStatusPending(instance, "Creating service")
-			if err = controllerutil.SetControllerReference(instance, service, r.scheme); err != nil {
-				r.SetSSHDStatusError(instance, "Failed to set service controller reference", err)
-				return reconcile.Result{}, err
-			}
-			if err = r.client.Create(context.TODO(), service); err != nil {
-				if errors.IsAlreadyExists(err) {
-					return reconcile.Result{Requeue: true}, nil
-				}
+			if err = utils.Apply(ctx, r.client, r.scheme, instance, service); err != nil {
 				r.SetSSHDStatusError(instance, "Failed to create service", err)
 				return reconcile.Result{}, err
 			}
 			// Reconcile again to get the new Service and give AWS time to create the ELB.
 			reqLogger.Info("Service was just created, so let's try to requeue to set it up")
-			return reconcile.Result{Requeue: true, RequeueAfter: 10 * time.Second}, nil
+			return RequeueIntervals.ErrorResult(), nil
 		} else {
 			return reconcile.Result{}, err
 		}
@@ -340,31 +397,31 @@ func (r *ReconcileSSHD) Reconcile(ctx context.Context, request reconcile.Request
 		}
 
 		if serviceNeedsUpdate {
-			if err = r.client.Update(context.TODO(), foundService); err != nil {
+			if err = r.client.Update(ctx, foundService); err != nil {
 				r.SetSSHDStatusError(instance, "Failed to update service", err)
 				return reconcile.Result{}, err
 			}
 			// Requeue to give AWS time to apply the changes.
 			reqLogger.Info("Requeuing after service update")
-			return reconcile.Result{Requeue: true, RequeueAfter: 10 * time.Second}, nil
+			return RequeueIntervals.ErrorResult(), nil
 		}
 	}
 
-	err = r.cloudClient.EnsureSSHDNS(context.TODO(), r.client, instance, foundService)
+	err = cloudClient.EnsureSSHDNS(ctx, r.client, instance, foundService)
 	switch err := err.(type) {
 	case nil:
 		// all good
 	case *cioerrors.LoadBalancerNotReadyError:
 		r.SetSSHDStatus(instance, "Couldn't reconcile", "Load balancer isn't ready yet.")
-		return reconcile.Result{Requeue: true, RequeueAfter: 10 * time.Second}, nil
+		return RequeueIntervals.ErrorResult(), nil
 	default:
 		r.SetSSHDStatusError(instance, "Failed to ensure the DNS record", err)
-		return reconcile.Result{}, err
+		return utils.CloudErrorResult(err)
 	}
 
 	r.SetSSHDStatus(instance, "SSHD is ready", cloudingressv1alpha1.SSHDStateReady)
 
-	return reconcile.Result{}, nil
+	return RequeueIntervals.SuccessResult(), nil
 }
 
 func getMatchLabels(cr *cloudingressv1alpha1.SSHD) map[string]string {
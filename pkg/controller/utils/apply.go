@@ -0,0 +1,49 @@
+package utils
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+// FieldManager is the field manager of the fields the operator sets on the
+// objects it applies
+const FieldManager = "cloud-ingress-operator"
+
+// SetOwner makes owner the controller of object, so that object is garbage
+// collected once owner is deleted. Kubernetes only allows an owner in the
+// same namespace, or a cluster scoped one, so an object in another namespace,
+// eg the admin API Services in openshift-kube-apiserver, is left without an
+// owner, and its controller deletes it along with the owner itself
+func SetOwner(owner, object client.Object, scheme *runtime.Scheme) error {
+	if owner.GetNamespace() != "" && owner.GetNamespace() != object.GetNamespace() {
+		return nil
+	}
+	return controllerutil.SetControllerReference(owner, object, scheme)
+}
+
+// Apply creates object, owned by owner as SetOwner allows, with the operator
+// as its field manager. An object that already exists is server-side applied
+// instead, taking over the fields of object from any other field manager
+func Apply(ctx context.Context, kclient client.Client, scheme *runtime.Scheme, owner, object client.Object) error {
+	if err := SetOwner(owner, object, scheme); err != nil {
+		return err
+	}
+	err := kclient.Create(ctx, object, client.FieldOwner(FieldManager))
+	if !errors.IsAlreadyExists(err) {
+		return err
+	}
+	// An apply patch needs the kind of object, which typed objects usually
+	// leave out
+	gvk, err := apiutil.GVKForObject(object, scheme)
+	if err != nil {
+		return err
+	}
+	object.GetObjectKind().SetGroupVersionKind(gvk)
+	object.SetResourceVersion("")
+	return kclient.Patch(ctx, object, client.Apply, client.FieldOwner(FieldManager), client.ForceOwnership)
+}
@@ -0,0 +1,40 @@
+package utils
+
+import (
+	"time"
+
+	cioerrors "github.com/openshift/cloud-ingress-operator/pkg/errors"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	// ThrottledRetryDelay leaves the cloud API rate limits time to recover,
+	// the first time they're hit
+	ThrottledRetryDelay = 30 * time.Second
+	// DependencyViolationRetryDelay leaves a cloud resource time to be
+	// released by another, eg a security group by a load balancer being
+	// deleted
+	DependencyViolationRetryDelay = 30 * time.Second
+	// PermissionDeniedRetryDelay is long, as missing permissions only come
+	// back once someone fixes the operator's credentials
+	PermissionDeniedRetryDelay = 5 * time.Minute
+)
+
+// CloudErrorResult returns what a reconcile failing with the cloud client
+// error err returns. Throttled errors are requeued after the backoff of
+// CloudThrottle, and dependency violation and permission denied errors after
+// a fixed delay, all without the error, which would only add the controller's
+// backoff to it; permission denied should also be reported in the status.
+// Transient errors are returned to be retried with the controller's backoff,
+// and so are not found and unclassified errors, which fail the reconcile
+func CloudErrorResult(err error) (reconcile.Result, error) {
+	switch {
+	case cioerrors.IsThrottled(err):
+		return reconcile.Result{RequeueAfter: CloudThrottle.Throttled(time.Now())}, nil
+	case cioerrors.IsDependencyViolation(err):
+		return reconcile.Result{RequeueAfter: DependencyViolationRetryDelay}, nil
+	case cioerrors.IsPermissionDenied(err):
+		return reconcile.Result{RequeueAfter: PermissionDeniedRetryDelay}, nil
+	}
+	return reconcile.Result{}, err
+}
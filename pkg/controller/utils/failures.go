@@ -0,0 +1,83 @@
+package utils
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	cloudingressv1alpha1 "github.com/openshift/cloud-ingress-operator/pkg/apis/cloudingress/v1alpha1"
+	"github.com/openshift/cloud-ingress-operator/pkg/localmetrics"
+	baseutils "github.com/openshift/cloud-ingress-operator/pkg/utils"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// DegradedAfterFailures is how many consecutive reconciles of a resource
+	// may fail before it's reported as degraded. Shorter streaks are usually
+	// the cloud or the API server recovering by themselves
+	DegradedAfterFailures = 5
+	// maxFailureErrors is how many of the distinct errors of a streak are kept
+	maxFailureErrors = 5
+)
+
+// UpdateReconcileFailures returns the failure streak following failures once a
+// reconcile failed with message, or nil if message is empty because the
+// reconcile succeeded
+func UpdateReconcileFailures(failures *cloudingressv1alpha1.ReconcileFailures, message string, now metav1.Time) *cloudingressv1alpha1.ReconcileFailures {
+	if message == "" {
+		return nil
+	}
+	updated := &cloudingressv1alpha1.ReconcileFailures{Since: now}
+	if failures != nil {
+		updated = failures.DeepCopy()
+	}
+	updated.Count++
+	errs := []string{}
+	for _, err := range updated.Errors {
+		if err != message {
+			errs = append(errs, err)
+		}
+	}
+	errs = append(errs, message)
+	if len(errs) > maxFailureErrors {
+		errs = errs[len(errs)-maxFailureErrors:]
+	}
+	updated.Errors = errs
+	updated.Degraded = updated.Count >= DegradedAfterFailures
+	return updated
+}
+
+// DegradedMessage sums up the errors of a failure streak
+func DegradedMessage(failures *cloudingressv1alpha1.ReconcileFailures) string {
+	return fmt.Sprintf("%d consecutive reconciles failed since %s: %s",
+		failures.Count, failures.Since.UTC().Format("2006-01-02T15:04:05Z"), strings.Join(failures.Errors, "; "))
+}
+
+// ReportReconcileFailures sets the metrics of the failure streak of the
+// resource name, reconciled by controller
+func ReportReconcileFailures(controller, name string, failures *cloudingressv1alpha1.ReconcileFailures) {
+	count, degraded := 0.0, 0.0
+	if failures != nil {
+		count = float64(failures.Count)
+		if failures.Degraded {
+			degraded = 1
+		}
+	}
+	localmetrics.MetricReconcileFailures.WithLabelValues(controller, name).Set(count)
+	localmetrics.MetricDegraded.WithLabelValues(controller, name).Set(degraded)
+}
+
+// ReportObservedDrift exports the number of drifted components of the resource
+// name that were left as they are, which is none when the controller repairs
+// them
+func ReportObservedDrift(controller, name string, drifted []string) {
+	localmetrics.MetricDriftObserved.WithLabelValues(controller, name).Set(float64(len(drifted)))
+}
+
+// ReportReconcileTimings exports how long a reconcile of controller spent in
+// each phase
+func ReportReconcileTimings(controller string, timings *baseutils.ReconcileTimings) {
+	for phase, d := range timings.Breakdown(time.Now()) {
+		localmetrics.MetricReconcilePhaseDuration.WithLabelValues(controller, phase).Observe(d.Seconds())
+	}
+}
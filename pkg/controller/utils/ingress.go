@@ -0,0 +1,23 @@
+package utils
+
+import (
+	"fmt"
+	"strings"
+
+	cloudingressv1alpha1 "github.com/openshift/cloud-ingress-operator/pkg/apis/cloudingress/v1alpha1"
+)
+
+// IngressControllerName returns the name of the IngressController an
+// ApplicationIngress is published through: default for the default one, and
+// otherwise the first label of its DNSName, eg apps2 for apps2.<base domain>.
+// A DNSName without a dot has no such label, which is an error
+func IngressControllerName(appIngress cloudingressv1alpha1.ApplicationIngress) (string, error) {
+	if appIngress.Default {
+		return "default", nil
+	}
+	firstPeriodIndex := strings.Index(appIngress.DNSName, ".")
+	if firstPeriodIndex <= 0 {
+		return "", fmt.Errorf("ApplicationIngress DNS name %q has no label before a dot to name its IngressController", appIngress.DNSName)
+	}
+	return appIngress.DNSName[:firstPeriodIndex], nil
+}
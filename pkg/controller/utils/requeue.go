@@ -0,0 +1,57 @@
+package utils
+
+import (
+	"time"
+
+	cloudingressv1alpha1 "github.com/openshift/cloud-ingress-operator/pkg/apis/cloudingress/v1alpha1"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// RequeueIntervals are how soon a controller reconciles an object again
+type RequeueIntervals struct {
+	// Success requeues a reconcile that succeeded. Zero leaves it to the
+	// watches and the resync period
+	Success time.Duration
+	// Error requeues a reconcile left waiting on the cloud provider
+	Error time.Duration
+	// DriftScan is how often the cloud resources behind an object are
+	// re-verified. Zero leaves it to the resync period
+	DriftScan time.Duration
+}
+
+// Override returns the intervals with those set in overrides instead
+func (i RequeueIntervals) Override(overrides *cloudingressv1alpha1.ControllerRequeueIntervals) RequeueIntervals {
+	if overrides == nil {
+		return i
+	}
+	if overrides.Success != nil {
+		i.Success = overrides.Success.Duration
+	}
+	if overrides.Error != nil {
+		i.Error = overrides.Error.Duration
+	}
+	if overrides.DriftScan != nil {
+		i.DriftScan = overrides.DriftScan.Duration
+	}
+	return i
+}
+
+// SuccessResult returns what a reconcile that succeeded and re-verified every
+// cloud resource returns: a requeue after the shorter of Success and
+// DriftScan, or none if both are zero
+func (i RequeueIntervals) SuccessResult() reconcile.Result {
+	after := i.Success
+	if after <= 0 || (i.DriftScan > 0 && i.DriftScan < after) {
+		after = i.DriftScan
+	}
+	if after <= 0 {
+		return reconcile.Result{}
+	}
+	return reconcile.Result{RequeueAfter: after}
+}
+
+// ErrorResult returns what a reconcile left waiting on the cloud provider
+// returns
+func (i RequeueIntervals) ErrorResult() reconcile.Result {
+	return reconcile.Result{Requeue: true, RequeueAfter: i.Error}
+}
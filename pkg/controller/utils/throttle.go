@@ -0,0 +1,52 @@
+package utils
+
+import (
+	"sync"
+	"time"
+)
+
+// MaxThrottledRetryDelay caps how long the controllers back off a throttling
+// cloud API
+const MaxThrottledRetryDelay = 5 * time.Minute
+
+// Throttle tracks how long the controllers back off a cloud API that throttles
+// them. Each throttled reconcile doubles the backoff, from ThrottledRetryDelay
+// up to MaxThrottledRetryDelay. Once a backoff has been over for as long
+// again, the next throttle starts from ThrottledRetryDelay
+type Throttle struct {
+	mu      sync.Mutex
+	backoff time.Duration
+	until   time.Time
+}
+
+// CloudThrottle is the Throttle of the cloud API. The controllers share it,
+// as they share the cloud API rate limits
+var CloudThrottle = &Throttle{}
+
+// Throttled records a throttled reconcile at now, and returns how long to
+// back off for
+func (t *Throttle) Throttled(now time.Time) time.Duration {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	switch {
+	case t.backoff == 0 || now.After(t.until.Add(t.backoff)):
+		t.backoff = ThrottledRetryDelay
+	case now.Before(t.until):
+		// Another reconcile was throttled during the same backoff
+		return t.until.Sub(now)
+	default:
+		t.backoff *= 2
+		if t.backoff > MaxThrottledRetryDelay {
+			t.backoff = MaxThrottledRetryDelay
+		}
+	}
+	t.until = now.Add(t.backoff)
+	return t.backoff
+}
+
+// BackingOff returns when the backoff ends, and whether it's still on at now
+func (t *Throttle) BackingOff(now time.Time) (time.Time, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.until, now.Before(t.until)
+}
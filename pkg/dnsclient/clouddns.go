@@ -0,0 +1,117 @@
+package dnsclient
+
+import (
+	"fmt"
+	"net/http"
+
+	gdnsv1 "google.golang.org/api/dns/v1"
+	"google.golang.org/api/googleapi"
+)
+
+// CloudDNS implements DNSClient with Google Cloud DNS. It has no alias
+// records
+type CloudDNS struct {
+	service   *gdnsv1.Service
+	projectID string
+}
+
+// NewCloudDNS returns a DNSClient managing the records of the project
+// projectID with service
+func NewCloudDNS(service *gdnsv1.Service, projectID string) *CloudDNS {
+	return &CloudDNS{service: service, projectID: projectID}
+}
+
+// ZoneForDomain implements DNSClient
+func (d *CloudDNS) ZoneForDomain(domain string) ([]Zone, error) {
+	response, err := d.service.ManagedZones.List(d.projectID).DnsName(FQDN(domain)).Do()
+	if err != nil {
+		return nil, err
+	}
+	zones := []Zone{}
+	for _, managedZone := range response.ManagedZones {
+		zones = append(zones, Zone{
+			ID:      managedZone.Name,
+			Name:    managedZone.DnsName,
+			Private: managedZone.Visibility == "private",
+		})
+	}
+	return zones, nil
+}
+
+// GetRecord implements DNSClient
+func (d *CloudDNS) GetRecord(zoneID, name, recordType string) (*Record, error) {
+	// google.golang.org/api/dns/v1.Service is a struct, not an interface, which
+	// will make this all but impossible to write unit tests for
+	response, err := d.service.ResourceRecordSets.List(d.projectID, zoneID).Name(FQDN(name)).Type(recordType).Do()
+	if err != nil {
+		return nil, err
+	}
+	// There will be at most one result
+	if len(response.Rrsets) == 0 {
+		return nil, nil
+	}
+	return fromResourceRecordSet(response.Rrsets[0]), nil
+}
+
+// EnsureRecord implements DNSClient
+func (d *CloudDNS) EnsureRecord(zoneID string, record Record) error {
+	if record.Alias != nil {
+		return fmt.Errorf("Cloud DNS has no alias records, %s can't point at %s", record.Name, record.Alias.DNSName)
+	}
+	current, err := d.GetRecord(zoneID, record.Name, record.Type)
+	if err != nil {
+		return err
+	}
+	dnsChange := &gdnsv1.Change{
+		Additions: []*gdnsv1.ResourceRecordSet{toResourceRecordSet(record)},
+	}
+	if current != nil {
+		if sameTarget(current, &record) && current.TTL == record.TTL {
+			return nil
+		}
+		dnsChange.Deletions = []*gdnsv1.ResourceRecordSet{toResourceRecordSet(*current)}
+	}
+	_, err = d.service.Changes.Create(d.projectID, zoneID, dnsChange).Do()
+	return err
+}
+
+// DeleteRecord implements DNSClient
+func (d *CloudDNS) DeleteRecord(zoneID string, record Record) error {
+	current, err := d.GetRecord(zoneID, record.Name, record.Type)
+	if err != nil || current == nil {
+		return err
+	}
+	if len(record.Values) > 0 && !sameTarget(current, &record) {
+		return nil
+	}
+	// Cloud DNS only deletes a record given exactly as it is
+	dnsChange := &gdnsv1.Change{
+		Deletions: []*gdnsv1.ResourceRecordSet{toResourceRecordSet(*current)},
+	}
+	_, err = d.service.Changes.Create(d.projectID, zoneID, dnsChange).Do()
+	if gcpError, ok := err.(*googleapi.Error); ok && gcpError.Code == http.StatusNotFound {
+		return nil
+	}
+	return err
+}
+
+// fromResourceRecordSet returns the Record of the Cloud DNS record set
+func fromResourceRecordSet(set *gdnsv1.ResourceRecordSet) *Record {
+	return &Record{
+		Name:   set.Name,
+		Type:   set.Type,
+		TTL:    set.Ttl,
+		Values: set.Rrdatas,
+	}
+}
+
+// toResourceRecordSet returns the Cloud DNS record set of record
+func toResourceRecordSet(record Record) *gdnsv1.ResourceRecordSet {
+	return &gdnsv1.ResourceRecordSet{
+		Kind:    "dns#resourceRecordSet",
+		Name:    FQDN(record.Name),
+		Type:    record.Type,
+		Ttl:     record.TTL,
+		Rrdatas: record.Values,
+	}
+}
@@ -0,0 +1,113 @@
+// Package dnsclient publishes DNS records with a DNS provider, so the cloud
+// clients manage records the same way whichever provider hosts the zones.
+// Each provider implements DNSClient, eg Route53 and Cloud DNS
+package dnsclient
+
+import (
+	"strings"
+)
+
+// DNSClient defines the interface for a provider agnostic implementation of
+// DNS record management
+type DNSClient interface {
+	// ZoneForDomain returns the zones named domain, public or private. It's
+	// empty if the provider has none
+	ZoneForDomain(domain string) ([]Zone, error)
+
+	// GetRecord returns the record of the given name and type in the zone,
+	// or nil if there's none
+	GetRecord(zoneID, name, recordType string) (*Record, error)
+
+	// EnsureRecord creates record in the zone, or replaces the record of the
+	// same name and type if it differs
+	EnsureRecord(zoneID string, record Record) error
+
+	// DeleteRecord deletes the record of the same name and type as record from
+	// the zone, if it exists. If record has values or an alias, the record is
+	// only deleted if it still has them, so one that was since pointed
+	// elsewhere is left alone
+	DeleteRecord(zoneID string, record Record) error
+}
+
+// Zone is a DNS zone of a provider
+type Zone struct {
+	// ID identifies the zone with the provider, eg a Route53 hosted zone ID
+	// or a Cloud DNS managed zone name
+	ID string
+	// Name is the fully qualified domain name of the zone
+	Name string
+	// Private zones are only resolved from inside the cluster's network
+	Private bool
+}
+
+// Record is a DNS record set
+type Record struct {
+	// Name is the fully qualified domain name of the record
+	Name string
+	// Type is the record type, eg A, AAAA or CNAME
+	Type string
+	// TTL is how long the record may be cached, in seconds. Alias records
+	// have none
+	TTL int64
+	// Values are what the record resolves to, eg IP addresses
+	Values []string
+	// Alias points the record at a load balancer instead of Values, for the
+	// providers that support it
+	Alias *Alias
+}
+
+// Alias is the load balancer an alias record resolves to
+type Alias struct {
+	// DNSName is the hostname of the load balancer
+	DNSName string
+	// HostedZoneID is the ID of the load balancer's own hosted zone
+	HostedZoneID string
+	// EvaluateTargetHealth stops resolving to an unhealthy load balancer
+	EvaluateTargetHealth bool
+}
+
+// FQDN returns name fully qualified, with a trailing dot
+func FQDN(name string) string {
+	if strings.HasSuffix(name, ".") {
+		return name
+	}
+	return name + "."
+}
+
+// sameName checks if the domain names a and b are the same, fully qualified
+// or not, in any case
+func sameName(a, b string) bool {
+	return strings.EqualFold(FQDN(a), FQDN(b))
+}
+
+// sameValues checks if a and b hold the same values, in any order
+func sameValues(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	seen := make(map[string]int, len(a))
+	for _, value := range a {
+		seen[value]++
+	}
+	for _, value := range b {
+		if seen[value] == 0 {
+			return false
+		}
+		seen[value]--
+	}
+	return true
+}
+
+// sameTarget checks if the records current and desired resolve to the same
+// values or load balancer. Their TTLs aren't compared
+func sameTarget(current, desired *Record) bool {
+	if (current.Alias == nil) != (desired.Alias == nil) {
+		return false
+	}
+	if desired.Alias != nil {
+		return sameName(current.Alias.DNSName, desired.Alias.DNSName) &&
+			current.Alias.HostedZoneID == desired.Alias.HostedZoneID &&
+			current.Alias.EvaluateTargetHealth == desired.Alias.EvaluateTargetHealth
+	}
+	return sameValues(current.Values, desired.Values)
+}
@@ -0,0 +1,138 @@
+package dnsclient
+
+import (
+	"path"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/route53"
+	"github.com/aws/aws-sdk-go/service/route53/route53iface"
+)
+
+// Route53 implements DNSClient with AWS Route53
+type Route53 struct {
+	client route53iface.Route53API
+}
+
+// NewRoute53 returns a DNSClient managing records with client
+func NewRoute53(client route53iface.Route53API) *Route53 {
+	return &Route53{client: client}
+}
+
+// ZoneForDomain implements DNSClient
+func (r *Route53) ZoneForDomain(domain string) ([]Zone, error) {
+	output, err := r.client.ListHostedZonesByName(&route53.ListHostedZonesByNameInput{
+		DNSName: aws.String(FQDN(domain)),
+	})
+	if err != nil {
+		return nil, err
+	}
+	zones := []Zone{}
+	for _, zone := range output.HostedZones {
+		// The zones are listed from domain on, in order
+		if !sameName(aws.StringValue(zone.Name), domain) {
+			continue
+		}
+		zones = append(zones, Zone{
+			ID:      path.Base(aws.StringValue(zone.Id)),
+			Name:    aws.StringValue(zone.Name),
+			Private: zone.Config != nil && aws.BoolValue(zone.Config.PrivateZone),
+		})
+	}
+	return zones, nil
+}
+
+// GetRecord implements DNSClient. Weighted records, which have a set
+// identifier, aren't returned
+func (r *Route53) GetRecord(zoneID, name, recordType string) (*Record, error) {
+	output, err := r.client.ListResourceRecordSets(&route53.ListResourceRecordSetsInput{
+		HostedZoneId:    aws.String(zoneID),
+		StartRecordName: aws.String(FQDN(name)),
+		StartRecordType: aws.String(recordType),
+		MaxItems:        aws.String("100"),
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, set := range output.ResourceRecordSets {
+		// Route53 lists the * of wildcard records in octal
+		setName := strings.Replace(aws.StringValue(set.Name), `\052`, "*", 1)
+		if !sameName(setName, name) || aws.StringValue(set.Type) != recordType || set.SetIdentifier != nil {
+			continue
+		}
+		record := &Record{
+			Name: setName,
+			Type: recordType,
+			TTL:  aws.Int64Value(set.TTL),
+		}
+		for _, value := range set.ResourceRecords {
+			record.Values = append(record.Values, aws.StringValue(value.Value))
+		}
+		if set.AliasTarget != nil {
+			record.Alias = &Alias{
+				DNSName:              aws.StringValue(set.AliasTarget.DNSName),
+				HostedZoneID:         aws.StringValue(set.AliasTarget.HostedZoneId),
+				EvaluateTargetHealth: aws.BoolValue(set.AliasTarget.EvaluateTargetHealth),
+			}
+		}
+		return record, nil
+	}
+	return nil, nil
+}
+
+// EnsureRecord implements DNSClient
+func (r *Route53) EnsureRecord(zoneID string, record Record) error {
+	current, err := r.GetRecord(zoneID, record.Name, record.Type)
+	if err != nil {
+		return err
+	}
+	if current != nil && sameTarget(current, &record) && (record.Alias != nil || current.TTL == record.TTL) {
+		return nil
+	}
+	return r.change(zoneID, route53.ChangeActionUpsert, record)
+}
+
+// DeleteRecord implements DNSClient
+func (r *Route53) DeleteRecord(zoneID string, record Record) error {
+	current, err := r.GetRecord(zoneID, record.Name, record.Type)
+	if err != nil || current == nil {
+		return err
+	}
+	if (record.Alias != nil || len(record.Values) > 0) && !sameTarget(current, &record) {
+		return nil
+	}
+	// Route53 only deletes a record given exactly as it is
+	return r.change(zoneID, route53.ChangeActionDelete, *current)
+}
+
+// change makes a single change of action to record in the zone
+func (r *Route53) change(zoneID, action string, record Record) error {
+	set := &route53.ResourceRecordSet{
+		Name: aws.String(FQDN(record.Name)),
+		Type: aws.String(record.Type),
+	}
+	if record.Alias != nil {
+		set.AliasTarget = &route53.AliasTarget{
+			DNSName:              aws.String(FQDN(record.Alias.DNSName)),
+			HostedZoneId:         aws.String(record.Alias.HostedZoneID),
+			EvaluateTargetHealth: aws.Bool(record.Alias.EvaluateTargetHealth),
+		}
+	} else {
+		set.TTL = aws.Int64(record.TTL)
+		for _, value := range record.Values {
+			set.ResourceRecords = append(set.ResourceRecords, &route53.ResourceRecord{Value: aws.String(value)})
+		}
+	}
+	_, err := r.client.ChangeResourceRecordSets(&route53.ChangeResourceRecordSetsInput{
+		HostedZoneId: aws.String(zoneID),
+		ChangeBatch: &route53.ChangeBatch{
+			Changes: []*route53.Change{
+				{
+					Action:            aws.String(action),
+					ResourceRecordSet: set,
+				},
+			},
+		},
+	})
+	return err
+}
@@ -0,0 +1,170 @@
+package dnsclient
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/route53"
+	"github.com/aws/aws-sdk-go/service/route53/route53iface"
+)
+
+type mockRoute53 struct {
+	route53iface.Route53API
+	HostedZones []*route53.HostedZone
+	Records     []*route53.ResourceRecordSet
+	Changes     []*route53.Change
+}
+
+func (m *mockRoute53) ListHostedZonesByName(_ *route53.ListHostedZonesByNameInput) (*route53.ListHostedZonesByNameOutput, error) {
+	return &route53.ListHostedZonesByNameOutput{HostedZones: m.HostedZones}, nil
+}
+
+func (m *mockRoute53) ListResourceRecordSets(_ *route53.ListResourceRecordSetsInput) (*route53.ListResourceRecordSetsOutput, error) {
+	return &route53.ListResourceRecordSetsOutput{ResourceRecordSets: m.Records}, nil
+}
+
+func (m *mockRoute53) ChangeResourceRecordSets(input *route53.ChangeResourceRecordSetsInput) (*route53.ChangeResourceRecordSetsOutput, error) {
+	m.Changes = append(m.Changes, input.ChangeBatch.Changes...)
+	return &route53.ChangeResourceRecordSetsOutput{}, nil
+}
+
+func aliasRecordSet(name, dnsName string, setIdentifier *string) *route53.ResourceRecordSet {
+	return &route53.ResourceRecordSet{
+		Name:          aws.String(name),
+		Type:          aws.String("A"),
+		SetIdentifier: setIdentifier,
+		AliasTarget: &route53.AliasTarget{
+			DNSName:              aws.String(dnsName),
+			HostedZoneId:         aws.String("Z35SXDOTRQ7X7K"),
+			EvaluateTargetHealth: aws.Bool(false),
+		},
+	}
+}
+
+func TestRoute53ZoneForDomain(t *testing.T) {
+	zone := func(id, name string, private bool) *route53.HostedZone {
+		return &route53.HostedZone{
+			Id:     aws.String("/hostedzone/" + id),
+			Name:   aws.String(name),
+			Config: &route53.HostedZoneConfig{PrivateZone: aws.Bool(private)},
+		}
+	}
+	mock := &mockRoute53{HostedZones: []*route53.HostedZone{
+		zone("ZPUBLIC", "example.com.", false),
+		zone("ZPRIVATE", "example.com.", true),
+		zone("ZSUB", "sub.example.com.", false),
+	}}
+	zones, err := NewRoute53(mock).ZoneForDomain("example.com")
+	if err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	expected := []Zone{
+		{ID: "ZPUBLIC", Name: "example.com."},
+		{ID: "ZPRIVATE", Name: "example.com.", Private: true},
+	}
+	if !reflect.DeepEqual(zones, expected) {
+		t.Fatalf("Expected %+v, got %+v", expected, zones)
+	}
+}
+
+func TestRoute53EnsureRecord(t *testing.T) {
+	desired := Record{
+		Name:  "rh-api.example.com",
+		Type:  "A",
+		Alias: &Alias{DNSName: "new.us-east-1.elb.amazonaws.com", HostedZoneID: "Z35SXDOTRQ7X7K"},
+	}
+	tests := []struct {
+		Name            string
+		Records         []*route53.ResourceRecordSet
+		ExpectedChanges int
+	}{
+		{
+			Name:            "No record",
+			ExpectedChanges: 1,
+		},
+		{
+			Name:    "Matching record",
+			Records: []*route53.ResourceRecordSet{aliasRecordSet("rh-api.example.com.", "new.us-east-1.elb.amazonaws.com.", nil)},
+		},
+		{
+			Name:            "Record pointing elsewhere",
+			Records:         []*route53.ResourceRecordSet{aliasRecordSet("rh-api.example.com.", "old.us-east-1.elb.amazonaws.com.", nil)},
+			ExpectedChanges: 1,
+		},
+		{
+			Name:            "Only a weighted record",
+			Records:         []*route53.ResourceRecordSet{aliasRecordSet("rh-api.example.com.", "new.us-east-1.elb.amazonaws.com.", aws.String("migration"))},
+			ExpectedChanges: 1,
+		},
+	}
+	for _, test := range tests {
+		mock := &mockRoute53{Records: test.Records}
+		err := NewRoute53(mock).EnsureRecord("ZPUBLIC", desired)
+		if err != nil {
+			t.Fatalf("Test [%v] unexpected error %v", test.Name, err)
+		}
+		if len(mock.Changes) != test.ExpectedChanges {
+			t.Fatalf("Test [%v] FAILED. Expected %v changes. Got %v", test.Name, test.ExpectedChanges, mock.Changes)
+		}
+		for _, change := range mock.Changes {
+			if aws.StringValue(change.Action) != route53.ChangeActionUpsert {
+				t.Fatalf("Test [%v] FAILED. Expected an UPSERT. Got %v", test.Name, change)
+			}
+		}
+	}
+}
+
+func TestRoute53DeleteRecord(t *testing.T) {
+	tests := []struct {
+		Name            string
+		Records         []*route53.ResourceRecordSet
+		Record          Record
+		ExpectedChanges int
+	}{
+		{
+			Name:   "No record",
+			Record: Record{Name: "rh-api.example.com", Type: "A"},
+		},
+		{
+			Name:            "Any record",
+			Records:         []*route53.ResourceRecordSet{aliasRecordSet("rh-api.example.com.", "old.us-east-1.elb.amazonaws.com.", nil)},
+			Record:          Record{Name: "rh-api.example.com", Type: "A"},
+			ExpectedChanges: 1,
+		},
+		{
+			Name:    "Record pointing elsewhere",
+			Records: []*route53.ResourceRecordSet{aliasRecordSet("rh-api.example.com.", "other.us-east-1.elb.amazonaws.com.", nil)},
+			Record: Record{
+				Name:  "rh-api.example.com",
+				Type:  "A",
+				Alias: &Alias{DNSName: "old.us-east-1.elb.amazonaws.com", HostedZoneID: "Z35SXDOTRQ7X7K"},
+			},
+		},
+		{
+			Name:    "Wildcard record",
+			Records: []*route53.ResourceRecordSet{aliasRecordSet(`\052.apps.example.com.`, "old.us-east-1.elb.amazonaws.com.", nil)},
+			Record: Record{
+				Name:  "*.apps.example.com",
+				Type:  "A",
+				Alias: &Alias{DNSName: "old.us-east-1.elb.amazonaws.com", HostedZoneID: "Z35SXDOTRQ7X7K"},
+			},
+			ExpectedChanges: 1,
+		},
+	}
+	for _, test := range tests {
+		mock := &mockRoute53{Records: test.Records}
+		err := NewRoute53(mock).DeleteRecord("ZPUBLIC", test.Record)
+		if err != nil {
+			t.Fatalf("Test [%v] unexpected error %v", test.Name, err)
+		}
+		if len(mock.Changes) != test.ExpectedChanges {
+			t.Fatalf("Test [%v] FAILED. Expected %v changes. Got %v", test.Name, test.ExpectedChanges, mock.Changes)
+		}
+		for _, change := range mock.Changes {
+			if aws.StringValue(change.Action) != route53.ChangeActionDelete {
+				t.Fatalf("Test [%v] FAILED. Expected a DELETE. Got %v", test.Name, change)
+			}
+		}
+	}
+}
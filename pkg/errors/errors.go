@@ -1,9 +1,99 @@
 package errors
 
 import (
+	"errors"
 	"fmt"
+	"strings"
 )
 
+// The kinds of cloud API failures. Each cloud client classifies the errors of
+// its provider's SDK into one of them, so that controllers can react the same
+// way on every cloud. Test for them with errors.Is or the Is* functions
+var (
+	// ErrNotFound is a cloud resource that doesn't exist
+	ErrNotFound = errors.New("cloud resource not found")
+	// ErrThrottled is a call refused because of the cloud API rate limits
+	ErrThrottled = errors.New("cloud API call throttled")
+	// ErrPermissionDenied is a call the operator's credentials don't allow
+	ErrPermissionDenied = errors.New("cloud API call not permitted")
+	// ErrDependencyViolation is a cloud resource that can't be changed or
+	// deleted because another one still depends on it
+	ErrDependencyViolation = errors.New("cloud resource still in use")
+	// ErrTransient is a failure of the cloud API itself or of the connection
+	// to it, which should go away when retried
+	ErrTransient = errors.New("transient cloud API failure")
+)
+
+// CloudError is a cloud API failure classified as one of the Err* kinds. Its
+// message is that of the provider's error, which it unwraps to
+type CloudError struct {
+	kind error
+	err  error
+}
+
+func (e *CloudError) Error() string { return e.err.Error() }
+
+// Unwrap returns the provider's error
+func (e *CloudError) Unwrap() error { return e.err }
+
+// Is makes errors.Is match the kind of the failure
+func (e *CloudError) Is(target error) bool { return target == e.kind }
+
+// NewCloudError classifies the provider's error err as kind, one of the Err*
+// kinds
+func NewCloudError(kind, err error) error {
+	return &CloudError{
+		kind: kind,
+		err:  err,
+	}
+}
+
+// IsNotFound checks if err is an ErrNotFound
+func IsNotFound(err error) bool { return errors.Is(err, ErrNotFound) }
+
+// IsThrottled checks if err is an ErrThrottled
+func IsThrottled(err error) bool { return errors.Is(err, ErrThrottled) }
+
+// IsPermissionDenied checks if err is an ErrPermissionDenied
+func IsPermissionDenied(err error) bool { return errors.Is(err, ErrPermissionDenied) }
+
+// IsDependencyViolation checks if err is an ErrDependencyViolation
+func IsDependencyViolation(err error) bool { return errors.Is(err, ErrDependencyViolation) }
+
+// IsTransient checks if err is an ErrTransient
+func IsTransient(err error) bool { return errors.Is(err, ErrTransient) }
+
+// requestFailure is a provider's error that identifies the failed API request,
+// such as an AWS SDK awserr.RequestFailure
+type requestFailure interface {
+	error
+	Code() string
+	RequestID() string
+}
+
+// RequestID returns the provider's error code and the ID of the failed request
+// behind err, if the provider's error carries them
+func RequestID(err error) (code, requestID string, ok bool) {
+	var failure requestFailure
+	if !errors.As(err, &failure) || failure.RequestID() == "" {
+		return "", "", false
+	}
+	return failure.Code(), failure.RequestID(), true
+}
+
+// Describe returns the first line of the message of err, followed by the
+// provider's error code and request ID when it has them, so that a support
+// case can be filed with the cloud provider from a status condition or an
+// Event alone
+func Describe(err error) string {
+	message := strings.SplitN(err.Error(), "\n", 2)[0]
+	code, requestID, ok := RequestID(err)
+	if !ok {
+		return message
+	}
+	return fmt.Sprintf("%s (error code: %s, request ID: %s)", message, code, requestID)
+}
+
 type LoadBalancerNotReadyError struct {
 	e string
 }
@@ -27,3 +117,114 @@ func NewDNSUpdateError(reason string) error {
 		e: fmt.Sprintf("DNS Update Error %s", reason),
 	}
 }
+
+type SecurityGroupInUseError struct {
+	e string
+}
+
+func (e *SecurityGroupInUseError) Error() string { return e.e }
+
+func NewSecurityGroupInUseError(groupID string) error {
+	return &SecurityGroupInUseError{
+		e: fmt.Sprintf("Security group %s is still in use", groupID),
+	}
+}
+
+type ElasticIPInUseError struct {
+	e string
+}
+
+func (e *ElasticIPInUseError) Error() string { return e.e }
+
+func NewElasticIPInUseError(allocationIDs []string) error {
+	return &ElasticIPInUseError{
+		e: fmt.Sprintf("Elastic IPs %s are still in use", strings.Join(allocationIDs, ", ")),
+	}
+}
+
+type LoadBalancerDrainingError struct {
+	e string
+}
+
+func (e *LoadBalancerDrainingError) Error() string { return e.e }
+
+func NewLoadBalancerDrainingError(name string, activeConnections int64) error {
+	return &LoadBalancerDrainingError{
+		e: fmt.Sprintf("Load balancer %s still has %d active connections", name, activeConnections),
+	}
+}
+
+// PreconditionFailedError is a change the operator won't make because the
+// cloud resources it depends on wouldn't let it work, eg an access log bucket
+// the load balancer can't write to. It's fixed outside the operator
+type PreconditionFailedError struct {
+	e string
+}
+
+func (e *PreconditionFailedError) Error() string { return e.e }
+
+func NewPreconditionFailedError(reason string) error {
+	return &PreconditionFailedError{
+		e: fmt.Sprintf("Precondition failed: %s", reason),
+	}
+}
+
+// SecurityGroupRulesRevertedError is a change of the ingress rules of a
+// security group that failed part way, and was undone so the group doesn't
+// keep only part of it. Cause is what failed. If undoing it failed too,
+// Reverted is false, and the group may be left with part of the change
+type SecurityGroupRulesRevertedError struct {
+	e        string
+	Cause    error
+	Reverted bool
+}
+
+func (e *SecurityGroupRulesRevertedError) Error() string { return e.e }
+
+func NewSecurityGroupRulesRevertedError(groupID string, cause, revertErr error) error {
+	if revertErr != nil {
+		return &SecurityGroupRulesRevertedError{
+			e:     fmt.Sprintf("Security group %s has only part of its rule changes, applying them failed: %v, and reverting them failed: %v", groupID, cause, revertErr),
+			Cause: cause,
+		}
+	}
+	return &SecurityGroupRulesRevertedError{
+		e:        fmt.Sprintf("Security group %s rule changes were reverted after part of them failed: %v", groupID, cause),
+		Cause:    cause,
+		Reverted: true,
+	}
+}
+
+// DNSZoneNotFoundError is a DNS zone a record goes in that doesn't exist, eg
+// the base domain zone during a fresh install, until the installer creates it
+type DNSZoneNotFoundError struct {
+	e string
+}
+
+func (e *DNSZoneNotFoundError) Error() string { return e.e }
+
+func NewDNSZoneNotFoundError(zone string) error {
+	return &DNSZoneNotFoundError{
+		e: fmt.Sprintf("%s not found", zone),
+	}
+}
+
+// ActionRequiredError is a failure retrying won't fix, that someone has to act
+// on outside the operator, eg a security group at its rule limit. Reason is a
+// machine-readable code for alert routing, one of the ActionRequired* reasons
+// of the APIScheme API, and Remediation says what to do about it
+type ActionRequiredError struct {
+	e           string
+	Reason      string
+	Remediation string
+}
+
+func (e *ActionRequiredError) Error() string { return e.e }
+
+func NewActionRequiredError(reason, problem, remediation string) error {
+	return &ActionRequiredError{
+		e:           fmt.Sprintf("Action required: %s", problem),
+		Reason:      reason,
+		Remediation: remediation,
+	}
+}
@@ -10,7 +10,62 @@ var (
 		Help: "Report if default ingress is on cluster",
 	})
 
+	MetricDriftDetected = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "cloud_ingress_operator_drift_detected_total",
+		Help: "Report how many times cloud resources were found changed outside the operator and repaired",
+	}, []string{"component"})
+
+	MetricDriftObserved = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "cloud_ingress_operator_drift_observed",
+		Help: "Report how many components of a resource were found changed outside the operator and left as they are",
+	}, []string{"controller", "name"})
+
+	MetricReconcileFailures = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "cloud_ingress_operator_reconcile_failures",
+		Help: "Report how many consecutive reconciles of a resource failed",
+	}, []string{"controller", "name"})
+
+	MetricDegraded = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "cloud_ingress_operator_degraded",
+		Help: "Report if a resource's reconciles failed too many times in a row",
+	}, []string{"controller", "name"})
+
+	MetricDNSNotPropagated = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "cloud_ingress_operator_dns_not_propagated",
+		Help: "Report how many DNS servers don't resolve a resource's records as expected",
+	}, []string{"controller", "name"})
+
+	MetricMissingPermissions = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "cloud_ingress_operator_missing_permissions",
+		Help: "Report how many of the cloud permissions the operator needs its credentials lack",
+	})
+
+	MetricLoadBalancerQuotaUsage = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "cloud_ingress_operator_load_balancer_quota_usage_ratio",
+		Help: "Report the share of the cloud's load balancer quota in use when the operator last created a load balancer",
+	}, []string{"type"})
+
+	MetricLoadBalancerCompliant = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "cloud_ingress_operator_load_balancer_compliant",
+		Help: "Report if an attribute of a resource's load balancer matches the load balancer policy of the CloudIngressConfig",
+	}, []string{"controller", "name", "attribute"})
+
+	MetricReconcilePhaseDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "cloud_ingress_operator_reconcile_phase_duration_seconds",
+		Help:    "Report how long each reconcile spent calling the cloud, calling the API server, waiting in loops and on the rest",
+		Buckets: []float64{0.01, 0.05, 0.1, 0.5, 1, 5, 10, 30, 60, 120, 300},
+	}, []string{"controller", "phase"})
+
 	MetricsList = []prometheus.Collector{
 		MetricDefaultIngressController,
+		MetricDriftDetected,
+		MetricDriftObserved,
+		MetricReconcileFailures,
+		MetricDegraded,
+		MetricDNSNotPropagated,
+		MetricMissingPermissions,
+		MetricLoadBalancerQuotaUsage,
+		MetricLoadBalancerCompliant,
+		MetricReconcilePhaseDuration,
 	}
 )
@@ -0,0 +1,92 @@
+package migrations
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/openshift/cloud-ingress-operator/config"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+var log = logf.Log.WithName("migrations")
+
+// versionKey is the key of the state ConfigMap holding the version of the
+// last migration applied
+const versionKey = "version"
+
+// Migration is a change to the operator's custom resources, or to what it
+// keeps about them, that an upgrade applies once, eg renaming a field or
+// moving objects to a new storage version. A migration may be applied again,
+// by a replica that stopped half way or by two replicas at once, so it must
+// leave objects it already migrated alone
+type Migration struct {
+	// Version orders the migrations. It's recorded once the migration is
+	// applied, so it must never change
+	Version int
+	// Description says what the migration does, for the logs
+	Description string
+	// Migrate applies the migration
+	Migrate func(context.Context, client.Client) error
+}
+
+// Migrations are applied in order when the operator starts, before any
+// controller reconciles. New ones go at the end, with the next version
+var Migrations = []Migration{
+	{
+		Version:     1,
+		Description: "Store every custom resource at the storage version of its CRD",
+		Migrate:     migrateStorageVersions,
+	},
+}
+
+// Run applies the migrations with a version past the one recorded in the
+// state ConfigMap, recording each version as it's applied. It stops at the
+// first one that fails, to be retried on the next start
+func Run(ctx context.Context, kclient client.Client, migrations []Migration) error {
+	state := &corev1.ConfigMap{}
+	err := kclient.Get(ctx, types.NamespacedName{Name: config.MigrationsConfigMapName, Namespace: config.OperatorNamespace}, state)
+	if errors.IsNotFound(err) {
+		state = &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      config.MigrationsConfigMapName,
+				Namespace: config.OperatorNamespace,
+			},
+			Data: map[string]string{versionKey: "0"},
+		}
+		err = kclient.Create(ctx, state)
+	}
+	if err != nil {
+		return err
+	}
+	applied, err := strconv.Atoi(state.Data[versionKey])
+	if err != nil {
+		return fmt.Errorf("the %s ConfigMap has an invalid version %q", config.MigrationsConfigMapName, state.Data[versionKey])
+	}
+
+	for _, migration := range migrations {
+		if migration.Version <= applied {
+			continue
+		}
+		log.Info("Applying migration", "version", migration.Version, "description", migration.Description)
+		if err := migration.Migrate(ctx, kclient); err != nil {
+			return fmt.Errorf("migration %d failed: %v", migration.Version, err)
+		}
+		if state.Data == nil {
+			state.Data = map[string]string{}
+		}
+		state.Data[versionKey] = strconv.Itoa(migration.Version)
+		// Another replica recording the same version conflicts, and this one
+		// applies the migration again on its next start
+		if err := kclient.Update(ctx, state); err != nil {
+			return err
+		}
+		applied = migration.Version
+	}
+	return nil
+}
@@ -0,0 +1,93 @@
+package migrations
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/openshift/cloud-ingress-operator/config"
+	"github.com/openshift/cloud-ingress-operator/pkg/testutils"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestRun(t *testing.T) {
+	tests := []struct {
+		Name            string
+		Applied         string
+		FailVersion     int
+		ExpectedApplied []int
+		ExpectedVersion string
+		ErrorExpected   bool
+	}{
+		{
+			Name:            "Fresh install",
+			ExpectedApplied: []int{1, 2, 3},
+			ExpectedVersion: "3",
+		},
+		{
+			Name:            "Upgrade",
+			Applied:         "1",
+			ExpectedApplied: []int{2, 3},
+			ExpectedVersion: "3",
+		},
+		{
+			Name:            "Up to date",
+			Applied:         "3",
+			ExpectedApplied: []int{},
+			ExpectedVersion: "3",
+		},
+		{
+			Name:            "Failed migration",
+			FailVersion:     2,
+			ExpectedApplied: []int{1, 2},
+			ExpectedVersion: "1",
+			ErrorExpected:   true,
+		},
+	}
+	for _, test := range tests {
+		objs := []runtime.Object{}
+		if test.Applied != "" {
+			objs = append(objs, &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: config.MigrationsConfigMapName, Namespace: config.OperatorNamespace},
+				Data:       map[string]string{versionKey: test.Applied},
+			})
+		}
+		mocks := testutils.NewTestMock(t, objs)
+		applied := []int{}
+		migrations := []Migration{}
+		for version := 1; version <= 3; version++ {
+			version := version
+			migrations = append(migrations, Migration{
+				Version: version,
+				Migrate: func(context.Context, client.Client) error {
+					applied = append(applied, version)
+					if version == test.FailVersion {
+						return fmt.Errorf("failed")
+					}
+					return nil
+				},
+			})
+		}
+
+		err := Run(context.TODO(), mocks.FakeKubeClient, migrations)
+		if (err != nil) != test.ErrorExpected {
+			t.Fatalf("Test [%v] FAILED. Expected an error: %v. Got %v", test.Name, test.ErrorExpected, err)
+		}
+		if !reflect.DeepEqual(applied, test.ExpectedApplied) {
+			t.Fatalf("Test [%v] FAILED. Expected %v. Got %v", test.Name, test.ExpectedApplied, applied)
+		}
+		state := &corev1.ConfigMap{}
+		err = mocks.FakeKubeClient.Get(context.TODO(), types.NamespacedName{Name: config.MigrationsConfigMapName, Namespace: config.OperatorNamespace}, state)
+		if err != nil {
+			t.Fatalf("Test [%v] FAILED. Unexpected error %v", test.Name, err)
+		}
+		if state.Data[versionKey] != test.ExpectedVersion {
+			t.Fatalf("Test [%v] FAILED. Expected version %v. Got %v", test.Name, test.ExpectedVersion, state.Data[versionKey])
+		}
+	}
+}
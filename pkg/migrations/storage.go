@@ -0,0 +1,98 @@
+package migrations
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// customResourceDefinitions are the names of the CRDs of the operator's
+// custom resources
+var customResourceDefinitions = []string{
+	"apischemes.cloudingress.managed.openshift.io",
+	"cloudingressconfigs.cloudingress.managed.openshift.io",
+	"publishingstrategies.cloudingress.managed.openshift.io",
+	"sshds.cloudingress.managed.openshift.io",
+}
+
+// crdGVK is the kind of a CRD. It's read unstructured, as the operator doesn't
+// otherwise need the apiextensions types
+var crdGVK = schema.GroupVersionKind{Group: "apiextensions.k8s.io", Version: "v1", Kind: "CustomResourceDefinition"}
+
+// migrateStorageVersions rewrites the objects of every CRD of the operator at
+// its storage version
+func migrateStorageVersions(ctx context.Context, kclient client.Client) error {
+	for _, crdName := range customResourceDefinitions {
+		if err := migrateStorageVersion(ctx, kclient, crdName); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// migrateStorageVersion writes every object of the CRD crdName back
+// unchanged, so the API server stores it at the CRD's storage version, then
+// leaves that version alone in the CRD's status.storedVersions. A version of
+// the API can only be removed from the CRD once no object is stored at it
+func migrateStorageVersion(ctx context.Context, kclient client.Client, crdName string) error {
+	crd := &unstructured.Unstructured{}
+	crd.SetGroupVersionKind(crdGVK)
+	if err := kclient.Get(ctx, types.NamespacedName{Name: crdName}, crd); err != nil {
+		return err
+	}
+	storageVersion, err := crdStorageVersion(crd)
+	if err != nil {
+		return err
+	}
+	group, _, _ := unstructured.NestedString(crd.Object, "spec", "group")
+	kind, _, _ := unstructured.NestedString(crd.Object, "spec", "names", "kind")
+
+	list := &unstructured.UnstructuredList{}
+	list.SetGroupVersionKind(schema.GroupVersionKind{Group: group, Version: storageVersion, Kind: kind + "List"})
+	if err := kclient.List(ctx, list); err != nil {
+		return err
+	}
+	for i := range list.Items {
+		err := kclient.Update(ctx, &list.Items[i])
+		// An object deleted, or changed, since it was listed doesn't need
+		// rewriting anymore
+		if errors.IsNotFound(err) || errors.IsConflict(err) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+	}
+	log.Info("Stored every object at the storage version", "crd", crdName, "version", storageVersion, "objects", len(list.Items))
+
+	storedVersions, _, _ := unstructured.NestedStringSlice(crd.Object, "status", "storedVersions")
+	if len(storedVersions) == 1 && storedVersions[0] == storageVersion {
+		return nil
+	}
+	if err := unstructured.SetNestedStringSlice(crd.Object, []string{storageVersion}, "status", "storedVersions"); err != nil {
+		return err
+	}
+	return kclient.Status().Update(ctx, crd)
+}
+
+// crdStorageVersion returns the version of the API the objects of crd are
+// stored at
+func crdStorageVersion(crd *unstructured.Unstructured) (string, error) {
+	versions, _, _ := unstructured.NestedSlice(crd.Object, "spec", "versions")
+	for _, version := range versions {
+		fields, ok := version.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if storage, _ := fields["storage"].(bool); storage {
+			name, _ := fields["name"].(string)
+			return name, nil
+		}
+	}
+	return "", fmt.Errorf("CRD %s has no storage version", crd.GetName())
+}
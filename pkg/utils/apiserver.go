@@ -0,0 +1,61 @@
+package utils
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"strconv"
+
+	operatorv1 "github.com/openshift/api/operator/v1"
+	"github.com/openshift/cloud-ingress-operator/config"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// kubeAPIServerObservedConfig is the part of the KubeAPIServer's observed
+// config the operator reads
+type kubeAPIServerObservedConfig struct {
+	ServingInfo struct {
+		BindAddress string `json:"bindAddress"`
+	} `json:"servingInfo"`
+}
+
+// GetAPIServerPort returns the port the API server pods listen on, from the
+// serving address in the cluster's KubeAPIServer config. It's the default
+// admin API port if the cluster has no KubeAPIServer config, or one without a
+// serving address
+func GetAPIServerPort(kclient client.Client) (int64, error) {
+	kas := &operatorv1.KubeAPIServer{}
+	err := kclient.Get(context.TODO(), types.NamespacedName{Name: "cluster"}, kas)
+	if errors.IsNotFound(err) || meta.IsNoMatchError(err) || runtime.IsNotRegisteredError(err) {
+		return config.AdminAPIListenerPort, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	return observedAPIServerPort(kas.Spec.ObservedConfig)
+}
+
+// observedAPIServerPort returns the port of the serving address in the
+// observed config of a KubeAPIServer
+func observedAPIServerPort(observedConfig runtime.RawExtension) (int64, error) {
+	if len(observedConfig.Raw) == 0 {
+		return config.AdminAPIListenerPort, nil
+	}
+	observed := &kubeAPIServerObservedConfig{}
+	err := json.Unmarshal(observedConfig.Raw, observed)
+	if err != nil {
+		return 0, err
+	}
+	if observed.ServingInfo.BindAddress == "" {
+		return config.AdminAPIListenerPort, nil
+	}
+	_, port, err := net.SplitHostPort(observed.ServingInfo.BindAddress)
+	if err != nil {
+		return 0, err
+	}
+	return strconv.ParseInt(port, 10, 32)
+}
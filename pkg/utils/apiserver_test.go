@@ -0,0 +1,35 @@
+package utils
+
+import (
+	"testing"
+
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestObservedAPIServerPort(t *testing.T) {
+	tests := []struct {
+		Name     string
+		Observed string
+		Expected int64
+	}{
+		{Name: "No observed config", Expected: 6443},
+		{Name: "No serving address", Observed: `{"apiServerArguments": {}}`, Expected: 6443},
+		{Name: "Default port", Observed: `{"servingInfo": {"bindAddress": "0.0.0.0:6443"}}`, Expected: 6443},
+		{Name: "Custom port", Observed: `{"servingInfo": {"bindAddress": "0.0.0.0:443"}}`, Expected: 443},
+		{Name: "IPv6 address", Observed: `{"servingInfo": {"bindAddress": "[::]:8443"}}`, Expected: 8443},
+	}
+	for _, test := range tests {
+		actual, err := observedAPIServerPort(runtime.RawExtension{Raw: []byte(test.Observed)})
+		if err != nil {
+			t.Fatalf("Test [%v] unexpected error %v", test.Name, err)
+		}
+		if actual != test.Expected {
+			t.Fatalf("Test [%v] FAILED. Expected %d. Got %d", test.Name, test.Expected, actual)
+		}
+	}
+
+	_, err := observedAPIServerPort(runtime.RawExtension{Raw: []byte(`{"servingInfo": {"bindAddress": "0.0.0.0"}}`)})
+	if err == nil {
+		t.Fatalf("Expected an error for a serving address without a port")
+	}
+}
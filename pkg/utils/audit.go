@@ -0,0 +1,57 @@
+package utils
+
+import (
+	"reflect"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// auditLog is the audit trail of the cloud changes: one structured log line
+// per cloud API call that changes something, with the log's timestamp
+var auditLog = logf.Log.WithName("audit")
+
+// AuditRecord is a cloud API call that changes something, made or refused
+type AuditRecord struct {
+	// Cloud is the cloud provider, aws or gcp
+	Cloud string
+	// Service is the cloud service called, eg ec2 or dns.googleapis.com
+	Service string
+	// Operation is the API operation, eg CreateSecurityGroup or
+	// POST /dns/v1/projects/p/managedZones/z/changes
+	Operation string
+	// Params are the parameters of the call
+	Params interface{}
+	// Caller is the custom resource the call was made for, see AuditCaller.
+	// Empty if it isn't known
+	Caller string
+	// Err is why the call failed, nil if it succeeded
+	Err error
+}
+
+// Audit adds record to the audit trail
+func Audit(record AuditRecord) {
+	keysAndValues := []interface{}{
+		"cloud", record.Cloud,
+		"service", record.Service,
+		"operation", record.Operation,
+		"params", record.Params,
+		"caller", record.Caller,
+	}
+	if record.Err != nil {
+		keysAndValues = append(keysAndValues, "result", "failed", "error", record.Err.Error())
+	} else {
+		keysAndValues = append(keysAndValues, "result", "succeeded")
+	}
+	auditLog.Info("Cloud change", keysAndValues...)
+}
+
+// AuditCaller names obj in the audit trail, as its kind, namespace and name,
+// eg APIScheme openshift-cloud-ingress-operator/rh-api
+func AuditCaller(obj metav1.Object) string {
+	if obj == nil || reflect.ValueOf(obj).IsNil() {
+		return ""
+	}
+	kind := reflect.Indirect(reflect.ValueOf(obj)).Type().Name()
+	return kind + " " + obj.GetNamespace() + "/" + obj.GetName()
+}
@@ -0,0 +1,40 @@
+package utils
+
+import (
+	"testing"
+
+	cloudingressv1alpha1 "github.com/openshift/cloud-ingress-operator/pkg/apis/cloudingress/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestAuditCaller(t *testing.T) {
+	var noAPIScheme *cloudingressv1alpha1.APIScheme
+	tests := []struct {
+		Name     string
+		Object   metav1.Object
+		Expected string
+	}{
+		{
+			Name:     "APIScheme",
+			Object:   &cloudingressv1alpha1.APIScheme{ObjectMeta: metav1.ObjectMeta{Name: "rh-api", Namespace: "openshift-cloud-ingress-operator"}},
+			Expected: "APIScheme openshift-cloud-ingress-operator/rh-api",
+		},
+		{
+			Name:     "Router Service",
+			Object:   &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "router-default", Namespace: "openshift-ingress"}},
+			Expected: "Service openshift-ingress/router-default",
+		},
+		{
+			Name:     "Nil",
+			Object:   noAPIScheme,
+			Expected: "",
+		},
+	}
+	for _, test := range tests {
+		actual := AuditCaller(test.Object)
+		if actual != test.Expected {
+			t.Fatalf("Test [%v] FAILED. Expected %v. Got %v", test.Name, test.Expected, actual)
+		}
+	}
+}
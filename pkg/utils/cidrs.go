@@ -0,0 +1,165 @@
+package utils
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+
+	cloudingressv1alpha1 "github.com/openshift/cloud-ingress-operator/pkg/apis/cloudingress/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// CIDRBlockMetadata describes why a CIDR block is allowed in, see
+// cloudingressv1alpha1.AllowedCIDRBlocksMetadataAnnotation
+type CIDRBlockMetadata struct {
+	// Ticket is the ticket the CIDR block was allowed for
+	Ticket string `json:"ticket,omitempty"`
+	// Requester is who asked for the CIDR block
+	Requester string `json:"requester,omitempty"`
+	// Expires is when the CIDR block stops being allowed
+	Expires *metav1.Time `json:"expires,omitempty"`
+}
+
+// Description returns the description of the security group rule allowing
+// the CIDR block in
+func (m CIDRBlockMetadata) Description() string {
+	fields := []string{}
+	if m.Ticket != "" {
+		fields = append(fields, "ticket="+m.Ticket)
+	}
+	if m.Requester != "" {
+		fields = append(fields, "requester="+m.Requester)
+	}
+	if m.Expires != nil {
+		fields = append(fields, "expires="+m.Expires.UTC().Format(time.RFC3339))
+	}
+	return strings.Join(fields, " ")
+}
+
+// GetCIDRBlocksMetadata returns the metadata of the AllowedCIDRBlocks of
+// instance, by CIDR block
+func GetCIDRBlocksMetadata(instance *cloudingressv1alpha1.APIScheme) (map[string]CIDRBlockMetadata, error) {
+	metadata := map[string]CIDRBlockMetadata{}
+	value, ok := instance.GetAnnotations()[cloudingressv1alpha1.AllowedCIDRBlocksMetadataAnnotation]
+	if !ok {
+		return metadata, nil
+	}
+	if err := json.Unmarshal([]byte(value), &metadata); err != nil {
+		return nil, fmt.Errorf("Couldn't parse the %s annotation: %s", cloudingressv1alpha1.AllowedCIDRBlocksMetadataAnnotation, err)
+	}
+	return metadata, nil
+}
+
+// GetAllowedCIDRBlocks returns the AllowedCIDRBlocks of instance that haven't
+// expired at now, and when the next of those expires, if any does. No CIDR
+// block at all allows everyone in, so it's an error for all of them to expire
+func GetAllowedCIDRBlocks(instance *cloudingressv1alpha1.APIScheme, now time.Time) ([]string, *time.Time, error) {
+	allowed, nextExpiry, err := unexpiredCIDRBlocks(instance, now)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(instance.Spec.ManagementAPIServerIngress.AllowedCIDRBlocks) > 0 && len(allowed) == 0 {
+		return nil, nil, fmt.Errorf("Every allowed CIDR block expired, which would allow everyone in")
+	}
+	return allowed, nextExpiry, nil
+}
+
+// GetEffectiveAllowedCIDRBlocks returns the CIDR blocks allowed to access the
+// management API of instance at now, merged from its sources: its
+// AllowedCIDRBlocks that haven't expired, those of its
+// AllowedCIDRBlocksConfigMap, then the CloudIngressConfig's
+// BaselineAllowedCIDRBlocks. A CIDR block listed twice is kept once, where
+// it's first listed. Every one of them must be valid, as applying the others
+// would lock out whoever the invalid one was meant for. It also returns when
+// the next of them expires, if any does
+func GetEffectiveAllowedCIDRBlocks(kclient client.Client, instance *cloudingressv1alpha1.APIScheme, now time.Time) ([]string, *time.Time, error) {
+	cidrs, nextExpiry, err := unexpiredCIDRBlocks(instance, now)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err = validateCIDRBlocks(cidrs, "The APIScheme"); err != nil {
+		return nil, nil, err
+	}
+	if name := instance.Spec.ManagementAPIServerIngress.AllowedCIDRBlocksConfigMap; name != "" {
+		value, err := getConfigMapKey(kclient, types.NamespacedName{Namespace: instance.Namespace, Name: name}, cloudingressv1alpha1.AllowedCIDRBlocksConfigMapKey)
+		if err != nil {
+			return nil, nil, err
+		}
+		listed := strings.FieldsFunc(value, func(r rune) bool { return r == ',' || unicode.IsSpace(r) })
+		if err = validateCIDRBlocks(listed, "The ConfigMap "+instance.Namespace+"/"+name); err != nil {
+			return nil, nil, err
+		}
+		cidrs = append(cidrs, listed...)
+	}
+	ingressConfig, err := GetCloudIngressConfig(kclient)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err = validateCIDRBlocks(ingressConfig.Spec.BaselineAllowedCIDRBlocks, "The CloudIngressConfig baseline"); err != nil {
+		return nil, nil, err
+	}
+	cidrs = append(cidrs, ingressConfig.Spec.BaselineAllowedCIDRBlocks...)
+
+	// Only the APIScheme's own CIDR blocks expire
+	if len(instance.Spec.ManagementAPIServerIngress.AllowedCIDRBlocks) > 0 && len(cidrs) == 0 {
+		return nil, nil, fmt.Errorf("Every allowed CIDR block expired, which would allow everyone in")
+	}
+	allowed := []string{}
+	seen := make(map[string]bool)
+	for _, cidr := range cidrs {
+		// Tell 10.0.0.1/8 and 10.0.0.0/8 apart no more than the cloud does
+		_, network, _ := net.ParseCIDR(cidr)
+		if seen[network.String()] {
+			continue
+		}
+		seen[network.String()] = true
+		allowed = append(allowed, cidr)
+	}
+	return allowed, nextExpiry, nil
+}
+
+// unexpiredCIDRBlocks returns the AllowedCIDRBlocks of instance that haven't
+// expired at now, and when the next of those expires, if any does
+func unexpiredCIDRBlocks(instance *cloudingressv1alpha1.APIScheme, now time.Time) ([]string, *time.Time, error) {
+	cidrs := instance.Spec.ManagementAPIServerIngress.AllowedCIDRBlocks
+	metadata, err := GetCIDRBlocksMetadata(instance)
+	if err != nil {
+		return nil, nil, err
+	}
+	allowed := []string{}
+	expiries := []time.Time{}
+	for _, cidr := range cidrs {
+		expires := metadata[cidr].Expires
+		if expires == nil {
+			allowed = append(allowed, cidr)
+			continue
+		}
+		if !expires.Time.After(now) {
+			continue
+		}
+		allowed = append(allowed, cidr)
+		expiries = append(expiries, expires.Time.UTC())
+	}
+	if len(expiries) == 0 {
+		return allowed, nil, nil
+	}
+	sort.Slice(expiries, func(i, j int) bool { return expiries[i].Before(expiries[j]) })
+	return allowed, &expiries[0], nil
+}
+
+// validateCIDRBlocks checks every one of cidrs is a CIDR block, naming source
+// in the error otherwise
+func validateCIDRBlocks(cidrs []string, source string) error {
+	for _, cidr := range cidrs {
+		if _, _, err := net.ParseCIDR(cidr); err != nil {
+			return fmt.Errorf("%s allows an invalid CIDR block %q", source, cidr)
+		}
+	}
+	return nil
+}
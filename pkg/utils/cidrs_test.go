@@ -0,0 +1,179 @@
+package utils
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	cloudingressv1alpha1 "github.com/openshift/cloud-ingress-operator/pkg/apis/cloudingress/v1alpha1"
+	"github.com/openshift/cloud-ingress-operator/pkg/testutils"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestGetAllowedCIDRBlocks(t *testing.T) {
+	now := time.Date(2026, 10, 15, 12, 0, 0, 0, time.UTC)
+	nextExpiry := time.Date(2026, 10, 15, 13, 0, 0, 0, time.UTC)
+	tests := []struct {
+		Name               string
+		CIDRs              []string
+		Metadata           string
+		Expected           []string
+		ExpectedNextExpiry *time.Time
+		ErrorExpected      bool
+	}{
+		{
+			Name:     "No metadata",
+			CIDRs:    []string{"10.0.0.0/8"},
+			Expected: []string{"10.0.0.0/8"},
+		},
+		{
+			Name:     "No CIDR blocks",
+			Expected: []string{},
+		},
+		{
+			Name:               "Expired CIDR block",
+			CIDRs:              []string{"10.0.0.0/8", "192.168.0.0/24", "172.16.0.0/12"},
+			Metadata:           `{"192.168.0.0/24": {"ticket": "OHSS-1", "expires": "2026-10-15T11:00:00Z"}, "172.16.0.0/12": {"expires": "2026-10-15T13:00:00Z"}}`,
+			Expected:           []string{"10.0.0.0/8", "172.16.0.0/12"},
+			ExpectedNextExpiry: &nextExpiry,
+		},
+		{
+			Name:          "Every CIDR block expired",
+			CIDRs:         []string{"192.168.0.0/24"},
+			Metadata:      `{"192.168.0.0/24": {"expires": "2026-10-15T11:00:00Z"}}`,
+			ErrorExpected: true,
+		},
+		{
+			Name:          "Invalid metadata",
+			CIDRs:         []string{"10.0.0.0/8"},
+			Metadata:      `["10.0.0.0/8"]`,
+			ErrorExpected: true,
+		},
+	}
+	for _, test := range tests {
+		instance := testutils.CreateAPISchemeObject("rh-api", true, test.CIDRs)
+		if test.Metadata != "" {
+			instance.SetAnnotations(map[string]string{cloudingressv1alpha1.AllowedCIDRBlocksMetadataAnnotation: test.Metadata})
+		}
+		allowed, next, err := GetAllowedCIDRBlocks(instance, now)
+		if err == nil && test.ErrorExpected || err != nil && !test.ErrorExpected {
+			t.Fatalf("Test [%v] FAILED. Expect error? %t: Return %v", test.Name, test.ErrorExpected, err)
+		}
+		if test.ErrorExpected {
+			continue
+		}
+		if !reflect.DeepEqual(allowed, test.Expected) {
+			t.Fatalf("Test [%v] FAILED. Expected %v. Got %v", test.Name, test.Expected, allowed)
+		}
+		if !reflect.DeepEqual(next, test.ExpectedNextExpiry) {
+			t.Fatalf("Test [%v] FAILED. Expected the next expiry %v. Got %v", test.Name, test.ExpectedNextExpiry, next)
+		}
+	}
+}
+
+func TestGetEffectiveAllowedCIDRBlocks(t *testing.T) {
+	now := time.Date(2026, 10, 15, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		Name          string
+		CIDRs         []string
+		Metadata      string
+		ConfigMap     string
+		Listed        string
+		Baseline      []string
+		Expected      []string
+		ErrorExpected bool
+	}{
+		{
+			Name:     "Only the APIScheme",
+			CIDRs:    []string{"10.0.0.0/8"},
+			Expected: []string{"10.0.0.0/8"},
+		},
+		{
+			Name:      "Merged and de-duplicated",
+			CIDRs:     []string{"10.0.0.0/8", "192.168.0.0/24"},
+			ConfigMap: "allowed-cidrs",
+			Listed:    "172.16.0.0/12\n10.0.0.1/8, 203.0.113.0/24\n",
+			Baseline:  []string{"192.168.0.0/24", "198.51.100.0/24"},
+			Expected:  []string{"10.0.0.0/8", "192.168.0.0/24", "172.16.0.0/12", "203.0.113.0/24", "198.51.100.0/24"},
+		},
+		{
+			Name:      "Missing ConfigMap",
+			CIDRs:     []string{"10.0.0.0/8"},
+			ConfigMap: "missing",
+			Expected:  []string{"10.0.0.0/8"},
+		},
+		{
+			Name:          "Invalid CIDR block in the ConfigMap",
+			CIDRs:         []string{"10.0.0.0/8"},
+			ConfigMap:     "allowed-cidrs",
+			Listed:        "172.16.0.0/12\nbastion",
+			ErrorExpected: true,
+		},
+		{
+			Name:          "Invalid baseline CIDR block",
+			CIDRs:         []string{"10.0.0.0/8"},
+			Baseline:      []string{"198.51.100.0/33"},
+			ErrorExpected: true,
+		},
+		{
+			Name:     "Expired CIDR blocks replaced by the baseline",
+			CIDRs:    []string{"192.168.0.0/24"},
+			Metadata: `{"192.168.0.0/24": {"expires": "2026-10-15T11:00:00Z"}}`,
+			Baseline: []string{"198.51.100.0/24"},
+			Expected: []string{"198.51.100.0/24"},
+		},
+		{
+			Name:          "Every CIDR block expired",
+			CIDRs:         []string{"192.168.0.0/24"},
+			Metadata:      `{"192.168.0.0/24": {"expires": "2026-10-15T11:00:00Z"}}`,
+			ErrorExpected: true,
+		},
+	}
+	for _, test := range tests {
+		instance := testutils.CreateAPISchemeObject("rh-api", true, test.CIDRs)
+		instance.Spec.ManagementAPIServerIngress.AllowedCIDRBlocksConfigMap = test.ConfigMap
+		if test.Metadata != "" {
+			instance.SetAnnotations(map[string]string{cloudingressv1alpha1.AllowedCIDRBlocksMetadataAnnotation: test.Metadata})
+		}
+		ingressConfig := &cloudingressv1alpha1.CloudIngressConfig{
+			ObjectMeta: metav1.ObjectMeta{Name: "cluster"},
+			Spec:       cloudingressv1alpha1.CloudIngressConfigSpec{BaselineAllowedCIDRBlocks: test.Baseline},
+		}
+		cm := &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "allowed-cidrs", Namespace: instance.Namespace},
+			Data:       map[string]string{cloudingressv1alpha1.AllowedCIDRBlocksConfigMapKey: test.Listed},
+		}
+		mocks := testutils.NewTestMock(t, []runtime.Object{instance, ingressConfig, cm})
+		allowed, _, err := GetEffectiveAllowedCIDRBlocks(mocks.FakeKubeClient, instance, now)
+		if err == nil && test.ErrorExpected || err != nil && !test.ErrorExpected {
+			t.Fatalf("Test [%v] FAILED. Expect error? %t: Return %v", test.Name, test.ErrorExpected, err)
+		}
+		if test.ErrorExpected {
+			continue
+		}
+		if !reflect.DeepEqual(allowed, test.Expected) {
+			t.Fatalf("Test [%v] FAILED. Expected %v. Got %v", test.Name, test.Expected, allowed)
+		}
+	}
+}
+
+func TestCIDRBlockDescription(t *testing.T) {
+	metadata, err := GetCIDRBlocksMetadata(&cloudingressv1alpha1.APIScheme{})
+	if err != nil || len(metadata) != 0 {
+		t.Fatalf("Expected no metadata without the annotation. Got %v, %v", metadata, err)
+	}
+	instance := testutils.CreateAPISchemeObject("rh-api", true, []string{"192.168.0.0/24"})
+	instance.SetAnnotations(map[string]string{
+		cloudingressv1alpha1.AllowedCIDRBlocksMetadataAnnotation: `{"192.168.0.0/24": {"ticket": "OHSS-1", "requester": "sre", "expires": "2026-10-15T11:00:00+02:00"}}`,
+	})
+	metadata, err = GetCIDRBlocksMetadata(instance)
+	if err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	expected := "ticket=OHSS-1 requester=sre expires=2026-10-15T09:00:00Z"
+	if description := metadata["192.168.0.0/24"].Description(); description != expected {
+		t.Fatalf("Expected %q. Got %q", expected, description)
+	}
+}
@@ -0,0 +1,92 @@
+package utils
+
+import (
+	"context"
+	"sync"
+
+	"github.com/openshift/cloud-ingress-operator/config"
+	cloudingressv1alpha1 "github.com/openshift/cloud-ingress-operator/pkg/apis/cloudingress/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/flowcontrol"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var (
+	cloudAPIRateLimiter     flowcontrol.RateLimiter
+	cloudAPIRateLimiterOnce sync.Once
+)
+
+// GetCloudIngressConfig returns the operator-wide settings. A missing
+// CloudIngressConfig is the same as an empty one, which keeps every default.
+// Like the Infrastructure object, it's read as unstructured so that the
+// cluster-scoped object bypasses the namespaced cache
+func GetCloudIngressConfig(kclient client.Client) (*cloudingressv1alpha1.CloudIngressConfig, error) {
+	u := &unstructured.Unstructured{}
+	u.SetGroupVersionKind(cloudingressv1alpha1.SchemeGroupVersion.WithKind("CloudIngressConfig"))
+	ns := types.NamespacedName{
+		Namespace: "",
+		Name:      config.CloudIngressConfigName,
+	}
+	err := kclient.Get(context.TODO(), ns, u)
+	if err != nil {
+		// The CRD may not be installed yet either
+		if errors.IsNotFound(err) || meta.IsNoMatchError(err) {
+			return &cloudingressv1alpha1.CloudIngressConfig{}, nil
+		}
+		return nil, err
+	}
+
+	ingressConfig := &cloudingressv1alpha1.CloudIngressConfig{}
+	err = runtime.DefaultUnstructuredConverter.FromUnstructured(u.UnstructuredContent(), ingressConfig)
+	if err != nil {
+		return nil, err
+	}
+	return ingressConfig, nil
+}
+
+// IsPaused checks if the operator should make no cloud change for obj, because
+// obj has the PausedAnnotation, or the CloudIngressConfig pauses every object
+// or puts the operator in observer mode
+func IsPaused(kclient client.Client, obj metav1.Object) (bool, error) {
+	if obj.GetAnnotations()[cloudingressv1alpha1.PausedAnnotation] == "true" {
+		return true, nil
+	}
+	ingressConfig, err := GetCloudIngressConfig(kclient)
+	if err != nil {
+		return false, err
+	}
+	return ingressConfig.Spec.Paused || ingressConfig.Spec.Observer, nil
+}
+
+// IsObserving checks if the CloudIngressConfig puts the operator in observer
+// mode, where drift is only reported
+func IsObserving(kclient client.Client) (bool, error) {
+	ingressConfig, err := GetCloudIngressConfig(kclient)
+	if err != nil {
+		return false, err
+	}
+	return ingressConfig.Spec.Observer, nil
+}
+
+// GetCloudAPIRateLimiter returns the limiter shared by every cloud API call the
+// operator makes. It's built from the rate limit it's first called with, so
+// later changes take effect when the operator restarts. Returns nil if there
+// is no rate limit
+func GetCloudAPIRateLimiter(rateLimit *cloudingressv1alpha1.RateLimit) flowcontrol.RateLimiter {
+	cloudAPIRateLimiterOnce.Do(func() {
+		if rateLimit == nil || rateLimit.QPS <= 0 {
+			return
+		}
+		burst := int(rateLimit.Burst)
+		if burst < 1 {
+			burst = 1
+		}
+		cloudAPIRateLimiter = flowcontrol.NewTokenBucketRateLimiter(float32(rateLimit.QPS), burst)
+	})
+	return cloudAPIRateLimiter
+}
@@ -0,0 +1,113 @@
+package utils
+
+import (
+	"testing"
+
+	cloudingressv1alpha1 "github.com/openshift/cloud-ingress-operator/pkg/apis/cloudingress/v1alpha1"
+	"github.com/openshift/cloud-ingress-operator/pkg/testutils"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestGetCloudIngressConfig(t *testing.T) {
+	ingressConfig := &cloudingressv1alpha1.CloudIngressConfig{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "cluster",
+		},
+		Spec: cloudingressv1alpha1.CloudIngressConfigSpec{
+			AWSRegion: "us-west-2",
+			DryRun:    true,
+		},
+	}
+	mocks := testutils.NewTestMock(t, []runtime.Object{ingressConfig})
+
+	actual, err := GetCloudIngressConfig(mocks.FakeKubeClient)
+	if err != nil {
+		t.Fatalf("Couldn't get the CloudIngressConfig: %v", err)
+	}
+	if actual.Spec.AWSRegion != "us-west-2" || !actual.Spec.DryRun {
+		t.Fatalf("Expected the stored settings, got %+v", actual.Spec)
+	}
+}
+
+func TestIsPaused(t *testing.T) {
+	ingressConfig := &cloudingressv1alpha1.CloudIngressConfig{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "cluster",
+		},
+	}
+	annotated := &cloudingressv1alpha1.APIScheme{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "rh-api",
+			Annotations: map[string]string{cloudingressv1alpha1.PausedAnnotation: "true"},
+		},
+	}
+	notAnnotated := &cloudingressv1alpha1.APIScheme{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "rh-api",
+			Annotations: map[string]string{cloudingressv1alpha1.PausedAnnotation: "false"},
+		},
+	}
+	mocks := testutils.NewTestMock(t, []runtime.Object{ingressConfig})
+
+	paused, err := IsPaused(mocks.FakeKubeClient, annotated)
+	if err != nil || !paused {
+		t.Fatalf("Expected the annotation to pause, got %v, %v", paused, err)
+	}
+	paused, err = IsPaused(mocks.FakeKubeClient, notAnnotated)
+	if err != nil || paused {
+		t.Fatalf("Expected no pause, got %v, %v", paused, err)
+	}
+
+	ingressConfig.Spec.Paused = true
+	mocks = testutils.NewTestMock(t, []runtime.Object{ingressConfig})
+	paused, err = IsPaused(mocks.FakeKubeClient, notAnnotated)
+	if err != nil || !paused {
+		t.Fatalf("Expected the CloudIngressConfig to pause, got %v, %v", paused, err)
+	}
+}
+
+func TestIsObserving(t *testing.T) {
+	ingressConfig := &cloudingressv1alpha1.CloudIngressConfig{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "cluster",
+		},
+		Spec: cloudingressv1alpha1.CloudIngressConfigSpec{
+			Observer: true,
+		},
+	}
+	notAnnotated := &cloudingressv1alpha1.APIScheme{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "rh-api",
+		},
+	}
+	mocks := testutils.NewTestMock(t, []runtime.Object{ingressConfig})
+
+	observing, err := IsObserving(mocks.FakeKubeClient)
+	if err != nil || !observing {
+		t.Fatalf("Expected observer mode, got %v, %v", observing, err)
+	}
+	// Observing makes no cloud change either
+	paused, err := IsPaused(mocks.FakeKubeClient, notAnnotated)
+	if err != nil || !paused {
+		t.Fatalf("Expected observer mode to pause, got %v, %v", paused, err)
+	}
+
+	mocks = testutils.NewTestMock(t, []runtime.Object{})
+	observing, err = IsObserving(mocks.FakeKubeClient)
+	if err != nil || observing {
+		t.Fatalf("Expected no observer mode without a CloudIngressConfig, got %v, %v", observing, err)
+	}
+}
+
+func TestGetCloudIngressConfigMissing(t *testing.T) {
+	mocks := testutils.NewTestMock(t, []runtime.Object{})
+
+	actual, err := GetCloudIngressConfig(mocks.FakeKubeClient)
+	if err != nil {
+		t.Fatalf("A missing CloudIngressConfig shouldn't be an error: %v", err)
+	}
+	if actual.Spec.DryRun || actual.Spec.RateLimit != nil || actual.Spec.FeatureGates.NetworkLoadBalancer {
+		t.Fatalf("Expected the defaults, got %+v", actual.Spec)
+	}
+}
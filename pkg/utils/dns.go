@@ -0,0 +1,74 @@
+package utils
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+)
+
+// dnsLookupTimeout bounds each query of a DNS propagation check
+const dnsLookupTimeout = 5 * time.Second
+
+// LookupHostAt returns the addresses name resolves to according to the DNS
+// server at server, as host or host:port, port 53 if it has none. Unlike the
+// system resolver, it doesn't fall back to other servers
+func LookupHostAt(ctx context.Context, server, name string) ([]string, error) {
+	address := dnsServerAddress(server)
+	resolver := &net.Resolver{
+		PreferGo: true,
+		Dial: func(ctx context.Context, network, _ string) (net.Conn, error) {
+			dialer := net.Dialer{}
+			return dialer.DialContext(ctx, network, address)
+		},
+	}
+	ctx, cancel := context.WithTimeout(ctx, dnsLookupTimeout)
+	defer cancel()
+	// Fully qualified so no search domain is appended
+	return resolver.LookupHost(ctx, strings.TrimSuffix(name, ".")+".")
+}
+
+// UnpropagatedDNSServers resolves name against each of servers, and returns why
+// for each of those that can't resolve it or, if expected isn't empty, doesn't
+// answer with every address of expected
+func UnpropagatedDNSServers(ctx context.Context, servers []string, name string, expected []string) []string {
+	failures := []string{}
+	for _, server := range servers {
+		addresses, err := LookupHostAt(ctx, server, name)
+		switch {
+		case err != nil:
+			failures = append(failures, fmt.Sprintf("%s: %v", server, err))
+		case len(addresses) == 0:
+			failures = append(failures, fmt.Sprintf("%s: no address for %s", server, name))
+		default:
+			if missing := missingAddresses(addresses, expected); len(missing) > 0 {
+				failures = append(failures, fmt.Sprintf("%s: %s doesn't resolve to %s", server, name, strings.Join(missing, ", ")))
+			}
+		}
+	}
+	return failures
+}
+
+// dnsServerAddress returns the host:port of the DNS server server
+func dnsServerAddress(server string) string {
+	if _, _, err := net.SplitHostPort(server); err == nil {
+		return server
+	}
+	return net.JoinHostPort(strings.Trim(server, "[]"), "53")
+}
+
+// missingAddresses returns the addresses of expected that aren't in addresses
+func missingAddresses(addresses, expected []string) []string {
+	found := map[string]bool{}
+	for _, address := range addresses {
+		found[address] = true
+	}
+	missing := []string{}
+	for _, address := range expected {
+		if !found[address] {
+			missing = append(missing, address)
+		}
+	}
+	return missing
+}
@@ -0,0 +1,60 @@
+package utils
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDNSServerAddress(t *testing.T) {
+	tests := []struct {
+		Server   string
+		Expected string
+	}{
+		{Server: "8.8.8.8", Expected: "8.8.8.8:53"},
+		{Server: "8.8.8.8:5353", Expected: "8.8.8.8:5353"},
+		{Server: "ns-1.awsdns-01.com", Expected: "ns-1.awsdns-01.com:53"},
+		{Server: "ns-cloud-a1.googledomains.com.", Expected: "ns-cloud-a1.googledomains.com.:53"},
+		{Server: "2001:4860:4860::8888", Expected: "[2001:4860:4860::8888]:53"},
+		{Server: "[2001:4860:4860::8888]", Expected: "[2001:4860:4860::8888]:53"},
+		{Server: "[2001:4860:4860::8888]:5353", Expected: "[2001:4860:4860::8888]:5353"},
+	}
+	for _, test := range tests {
+		actual := dnsServerAddress(test.Server)
+		if actual != test.Expected {
+			t.Errorf("%s: expected %s, got %s", test.Server, test.Expected, actual)
+		}
+	}
+}
+
+func TestMissingAddresses(t *testing.T) {
+	tests := []struct {
+		Name      string
+		Addresses []string
+		Expected  []string
+		Missing   []string
+	}{
+		{
+			Name:      "Nothing expected",
+			Addresses: []string{"192.0.2.1"},
+			Missing:   []string{},
+		},
+		{
+			Name:      "All there",
+			Addresses: []string{"192.0.2.1", "192.0.2.2"},
+			Expected:  []string{"192.0.2.2"},
+			Missing:   []string{},
+		},
+		{
+			Name:      "Old address",
+			Addresses: []string{"192.0.2.1"},
+			Expected:  []string{"192.0.2.2"},
+			Missing:   []string{"192.0.2.2"},
+		},
+	}
+	for _, test := range tests {
+		actual := missingAddresses(test.Addresses, test.Expected)
+		if !reflect.DeepEqual(actual, test.Missing) {
+			t.Errorf("%s: expected %v, got %v", test.Name, test.Missing, actual)
+		}
+	}
+}
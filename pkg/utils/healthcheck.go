@@ -0,0 +1,53 @@
+package utils
+
+import (
+	"crypto/tls"
+	"net"
+	"net/http"
+	"time"
+
+	cloudingressv1alpha1 "github.com/openshift/cloud-ingress-operator/pkg/apis/cloudingress/v1alpha1"
+)
+
+// ProbeHealthCheckProtocol works out the most thorough health check the
+// backend at address (host:port) passes: HTTPS if path answers 200 over TLS,
+// SSL if it only completes a TLS handshake, HTTP if path answers 200 in plain
+// text, and TCP otherwise. Certificates aren't verified, as load balancer
+// health checks don't either
+func ProbeHealthCheckProtocol(address, path string, timeout time.Duration) cloudingressv1alpha1.HealthCheckProtocol {
+	tlsConfig := &tls.Config{InsecureSkipVerify: true}
+	conn, err := tls.DialWithDialer(&net.Dialer{Timeout: timeout}, "tcp", address, tlsConfig)
+	if err == nil {
+		conn.Close()
+		if probeHTTP("https://"+address+path, tlsConfig, timeout) {
+			return cloudingressv1alpha1.HealthCheckProtocolHTTPS
+		}
+		return cloudingressv1alpha1.HealthCheckProtocolSSL
+	}
+	if probeHTTP("http://"+address+path, nil, timeout) {
+		return cloudingressv1alpha1.HealthCheckProtocolHTTP
+	}
+	return cloudingressv1alpha1.HealthCheckProtocolTCP
+}
+
+// probeHTTP checks if a GET of url answers 200, like a health check
+func probeHTTP(url string, tlsConfig *tls.Config, timeout time.Duration) bool {
+	httpClient := &http.Client{
+		Timeout: timeout,
+		Transport: &http.Transport{
+			// Straight to the backend, like the load balancer
+			Proxy:           nil,
+			TLSClientConfig: tlsConfig,
+		},
+		// A redirect fails a health check
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+	resp, err := httpClient.Get(url)
+	if err != nil {
+		return false
+	}
+	resp.Body.Close()
+	return resp.StatusCode == http.StatusOK
+}
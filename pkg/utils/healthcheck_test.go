@@ -0,0 +1,63 @@
+package utils
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	cloudingressv1alpha1 "github.com/openshift/cloud-ingress-operator/pkg/apis/cloudingress/v1alpha1"
+)
+
+func TestProbeHealthCheckProtocol(t *testing.T) {
+	readyz := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/readyz" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+	tlsServer := httptest.NewTLSServer(readyz)
+	defer tlsServer.Close()
+	plainServer := httptest.NewServer(readyz)
+	defer plainServer.Close()
+
+	tests := []struct {
+		Name     string
+		Address  string
+		Path     string
+		Expected cloudingressv1alpha1.HealthCheckProtocol
+	}{
+		{
+			Name:     "TLS, ready",
+			Address:  strings.TrimPrefix(tlsServer.URL, "https://"),
+			Path:     "/readyz",
+			Expected: cloudingressv1alpha1.HealthCheckProtocolHTTPS,
+		},
+		{
+			Name:     "TLS, path refused",
+			Address:  strings.TrimPrefix(tlsServer.URL, "https://"),
+			Path:     "/livez",
+			Expected: cloudingressv1alpha1.HealthCheckProtocolSSL,
+		},
+		{
+			Name:     "Plain text, ready",
+			Address:  strings.TrimPrefix(plainServer.URL, "http://"),
+			Path:     "/readyz",
+			Expected: cloudingressv1alpha1.HealthCheckProtocolHTTP,
+		},
+		{
+			Name:     "Plain text, path refused",
+			Address:  strings.TrimPrefix(plainServer.URL, "http://"),
+			Path:     "/livez",
+			Expected: cloudingressv1alpha1.HealthCheckProtocolTCP,
+		},
+	}
+	for _, test := range tests {
+		actual := ProbeHealthCheckProtocol(test.Address, test.Path, 2*time.Second)
+		if actual != test.Expected {
+			t.Errorf("Test [%v] FAILED. Expected %s, got %s", test.Name, test.Expected, actual)
+		}
+	}
+}
@@ -6,8 +6,12 @@ import (
 	"context"
 	"fmt"
 	"net/url"
+	"strconv"
+	"strings"
 
 	configv1 "github.com/openshift/api/config/v1"
+	cloudingressv1alpha1 "github.com/openshift/cloud-ingress-operator/pkg/apis/cloudingress/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -17,6 +21,24 @@ import (
 
 // GetInfrastructureObject returns the canonical Infrastructure object
 func GetInfrastructureObject(kclient client.Client) (*configv1.Infrastructure, error) {
+	u, err := getUnstructuredInfrastructure(kclient)
+	if err != nil {
+		return nil, err
+	}
+
+	uContent := u.UnstructuredContent()
+	var infra *configv1.Infrastructure
+	err = runtime.DefaultUnstructuredConverter.FromUnstructured(uContent, &infra)
+	if err != nil {
+		return nil, err
+	}
+
+	return infra, nil
+}
+
+// getUnstructuredInfrastructure returns the canonical Infrastructure object as
+// is, with the fields newer than the vendored API
+func getUnstructuredInfrastructure(kclient client.Client) (*unstructured.Unstructured, error) {
 	u := &unstructured.Unstructured{}
 	u.SetGroupVersionKind(schema.GroupVersionKind{
 		Group:   "",
@@ -31,15 +53,77 @@ func GetInfrastructureObject(kclient client.Client) (*configv1.Infrastructure, e
 	if err != nil {
 		return nil, err
 	}
+	return u, nil
+}
 
-	uContent := u.UnstructuredContent()
-	var infra *configv1.Infrastructure
-	err = runtime.DefaultUnstructuredConverter.FromUnstructured(uContent, &infra)
+// GetAWSServiceEndpoints returns the custom AWS service endpoints the cluster
+// was installed with, eg VPC endpoints in a cluster without internet egress,
+// from status.platformStatus.aws.serviceEndpoints of the Infrastructure
+// object. That field is newer than the vendored API, so it's read as is
+func GetAWSServiceEndpoints(kclient client.Client) ([]cloudingressv1alpha1.AWSServiceEndpoint, error) {
+	u, err := getUnstructuredInfrastructure(kclient)
 	if err != nil {
 		return nil, err
 	}
+	return awsServiceEndpoints(u)
+}
 
-	return infra, nil
+// awsServiceEndpoints returns the custom AWS service endpoints of the
+// Infrastructure object u
+func awsServiceEndpoints(u *unstructured.Unstructured) ([]cloudingressv1alpha1.AWSServiceEndpoint, error) {
+	items, found, err := unstructured.NestedSlice(u.Object, "status", "platformStatus", "aws", "serviceEndpoints")
+	if err != nil || !found {
+		return []cloudingressv1alpha1.AWSServiceEndpoint{}, err
+	}
+	serviceEndpoints := make([]cloudingressv1alpha1.AWSServiceEndpoint, 0, len(items))
+	for _, item := range items {
+		fields, ok := item.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("Invalid AWS service endpoint in the Infrastructure object: %v", item)
+		}
+		name, _, _ := unstructured.NestedString(fields, "name")
+		endpointURL, _, _ := unstructured.NestedString(fields, "url")
+		if name == "" || endpointURL == "" {
+			return nil, fmt.Errorf("Invalid AWS service endpoint in the Infrastructure object: %v", item)
+		}
+		serviceEndpoints = append(serviceEndpoints, cloudingressv1alpha1.AWSServiceEndpoint{Name: name, URL: endpointURL})
+	}
+	return serviceEndpoints, nil
+}
+
+// ExternalTopologyMode is the control plane topology of a cluster whose
+// control plane is hosted outside of it, eg by HyperShift
+const ExternalTopologyMode = "External"
+
+// GetControlPlaneTopology returns where the cluster's control plane runs,
+// from status.controlPlaneTopology of the Infrastructure object:
+// HighlyAvailable, SingleReplica, or External for a hosted control plane.
+// That field is newer than the vendored API, so it's read as is. Clusters
+// older than it have an empty topology and their own masters
+func GetControlPlaneTopology(kclient client.Client) (string, error) {
+	u, err := getUnstructuredInfrastructure(kclient)
+	if err != nil {
+		return "", err
+	}
+	return controlPlaneTopology(u), nil
+}
+
+// controlPlaneTopology returns the control plane topology of the
+// Infrastructure object u
+func controlPlaneTopology(u *unstructured.Unstructured) string {
+	topology, _, _ := unstructured.NestedString(u.Object, "status", "controlPlaneTopology")
+	return topology
+}
+
+// IsHostedControlPlane checks if the cluster's control plane is hosted
+// outside of it. Such a cluster has no master Machines, and its API
+// endpoints are managed with the hosted control plane
+func IsHostedControlPlane(kclient client.Client) (bool, error) {
+	topology, err := GetControlPlaneTopology(kclient)
+	if err != nil {
+		return false, err
+	}
+	return topology == ExternalTopologyMode, nil
 }
 
 // GetClusterBaseDomain returns the installed clsuter's base domain name
@@ -56,6 +140,57 @@ func GetClusterBaseDomain(kclient client.Client) (string, error) {
 	return serverURL.Hostname()[4:], nil
 }
 
+// GetClusterAPIPort returns the port the cluster's API is served on from
+// outside the cluster, from its API server URL
+func GetClusterAPIPort(kclient client.Client) (int64, error) {
+	infra, err := GetInfrastructureObject(kclient)
+	if err != nil {
+		return 0, err
+	}
+	return urlPort(infra.Status.APIServerURL)
+}
+
+// GetClusterInternalAPIPort returns the port the cluster's API is served on
+// from inside the cluster, from its internal API server URL
+func GetClusterInternalAPIPort(kclient client.Client) (int64, error) {
+	infra, err := GetInfrastructureObject(kclient)
+	if err != nil {
+		return 0, err
+	}
+	return urlPort(infra.Status.APIServerInternalURL)
+}
+
+// urlPort returns the port of the HTTPS URL rawURL, which is 443 if it
+// doesn't have one
+func urlPort(rawURL string) (int64, error) {
+	serverURL, err := url.Parse(rawURL)
+	if err != nil {
+		return 0, fmt.Errorf("Couldn't parse the API server URL from %s: %s", rawURL, err)
+	}
+	if serverURL.Port() == "" {
+		return 443, nil
+	}
+	return strconv.ParseInt(serverURL.Port(), 10, 32)
+}
+
+// GetAdminAPIBaseDomain returns the domain the admin API of instance is
+// published under: the APIScheme's own base domain, or the cluster's
+func GetAdminAPIBaseDomain(kclient client.Client, instance *cloudingressv1alpha1.APIScheme) (string, error) {
+	if instance.Spec.ManagementAPIServerIngress.BaseDomain != "" {
+		return strings.TrimSuffix(instance.Spec.ManagementAPIServerIngress.BaseDomain, "."), nil
+	}
+	return GetClusterBaseDomain(kclient)
+}
+
+// IsInDNSZone checks if the domain name can be published in the DNS zone named
+// zone, ie if it's the zone's apex or one of its subdomains. Either may be
+// fully qualified
+func IsInDNSZone(name, zone string) bool {
+	name = strings.ToLower(strings.TrimSuffix(name, "."))
+	zone = strings.ToLower(strings.TrimSuffix(zone, "."))
+	return name == zone || strings.HasSuffix(name, "."+zone)
+}
+
 // GetClusterName returns the installed cluster's name (max 27 characters)
 func GetClusterName(kclient client.Client) (string, error) {
 	infra, err := GetInfrastructureObject(kclient)
@@ -73,3 +208,20 @@ func GetPlatformType(kclient client.Client) (*configv1.PlatformType, error) {
 	}
 	return &infra.Status.PlatformStatus.Type, nil
 }
+
+// GetPlatformConfigVersion returns the resource versions of the cluster's
+// Infrastructure and DNS configs, which the cloud clients are configured from.
+// A cloud client made for another version may have a stale region or zone. A
+// cluster without a DNS config only has the Infrastructure's version
+func GetPlatformConfigVersion(kclient client.Client) (string, error) {
+	infra, err := GetInfrastructureObject(kclient)
+	if err != nil {
+		return "", err
+	}
+	dns := &configv1.DNS{}
+	err = kclient.Get(context.TODO(), types.NamespacedName{Name: "cluster"}, dns)
+	if err != nil && !errors.IsNotFound(err) {
+		return "", err
+	}
+	return infra.ResourceVersion + "/" + dns.ResourceVersion, nil
+}
@@ -1,10 +1,14 @@
 package utils
 
 import (
+	"context"
 	"fmt"
+	"reflect"
 	"testing"
 
+	cloudingressv1alpha1 "github.com/openshift/cloud-ingress-operator/pkg/apis/cloudingress/v1alpha1"
 	"github.com/openshift/cloud-ingress-operator/pkg/testutils"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
@@ -22,6 +26,49 @@ func TestClusterBaseDomain(t *testing.T) {
 	}
 }
 
+func TestGetAdminAPIBaseDomain(t *testing.T) {
+	infraObj := testutils.CreateInfraObject("basename", testutils.DefaultAPIEndpoint, testutils.DefaultAPIEndpoint, testutils.DefaultRegionName)
+	mocks := testutils.NewTestMock(t, []runtime.Object{infraObj})
+	apischeme := testutils.CreateAPISchemeObject("rh-api", true, []string{"0.0.0.0/0"})
+
+	base, err := GetAdminAPIBaseDomain(mocks.FakeKubeClient, apischeme)
+	if err != nil {
+		t.Fatalf("Could not get the admin API base domain name: %v", err)
+	}
+	if base != "unit.test" {
+		t.Fatalf("Expected the cluster's base domain %s, got %s", "unit.test", base)
+	}
+
+	apischeme.Spec.ManagementAPIServerIngress.BaseDomain = "vanity.example.com."
+	base, err = GetAdminAPIBaseDomain(mocks.FakeKubeClient, apischeme)
+	if err != nil {
+		t.Fatalf("Could not get the admin API base domain name: %v", err)
+	}
+	if base != "vanity.example.com" {
+		t.Fatalf("Expected the APIScheme's base domain %s, got %s", "vanity.example.com", base)
+	}
+}
+
+func TestIsInDNSZone(t *testing.T) {
+	tests := []struct {
+		Name     string
+		Zone     string
+		Expected bool
+	}{
+		{Name: "rh-api.vanity.example.com", Zone: "example.com.", Expected: true},
+		{Name: "rh-api.vanity.example.com.", Zone: "rh-api.vanity.example.com", Expected: true},
+		{Name: "rh-api.Vanity.Example.com", Zone: "vanity.example.com", Expected: true},
+		{Name: "rh-api.notexample.com", Zone: "example.com", Expected: false},
+		{Name: "example.com", Zone: "rh-api.example.com", Expected: false},
+	}
+	for _, test := range tests {
+		actual := IsInDNSZone(test.Name, test.Zone)
+		if actual != test.Expected {
+			t.Fatalf("Expected IsInDNSZone(%q, %q) to be %t, got %t", test.Name, test.Zone, test.Expected, actual)
+		}
+	}
+}
+
 func TestGetClusterName(t *testing.T) {
 	clustername := "cluster-test-name"
 	infraObj := testutils.CreateInfraObject(clustername, testutils.DefaultAPIEndpoint, testutils.DefaultAPIEndpoint, testutils.DefaultRegionName)
@@ -37,6 +84,123 @@ func TestGetClusterName(t *testing.T) {
 	}
 }
 
+func TestGetClusterAPIPort(t *testing.T) {
+	infraObj := testutils.CreateInfraObject("basename", "https://api-int.unit.test:6443", "https://api.unit.test", testutils.DefaultRegionName)
+	mocks := testutils.NewTestMock(t, []runtime.Object{infraObj})
+
+	port, err := GetClusterAPIPort(mocks.FakeKubeClient)
+	if err != nil {
+		t.Fatalf("Couldn't get the cluster's API port: %v", err)
+	}
+	if port != 443 {
+		t.Fatalf("Expected the HTTPS port 443 for a URL without one, got %d", port)
+	}
+	port, err = GetClusterInternalAPIPort(mocks.FakeKubeClient)
+	if err != nil {
+		t.Fatalf("Couldn't get the cluster's internal API port: %v", err)
+	}
+	if port != 6443 {
+		t.Fatalf("Expected the internal API port 6443, got %d", port)
+	}
+}
+
+func TestAWSServiceEndpoints(t *testing.T) {
+	infraObj := testutils.CreateInfraObject("basename", testutils.DefaultAPIEndpoint, testutils.DefaultAPIEndpoint, testutils.DefaultRegionName)
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(infraObj)
+	if err != nil {
+		t.Fatalf("Couldn't convert the Infrastructure object: %v", err)
+	}
+	u := &unstructured.Unstructured{Object: content}
+
+	actual, err := awsServiceEndpoints(u)
+	if err != nil || len(actual) != 0 {
+		t.Fatalf("Expected no endpoint without serviceEndpoints. Got %v, %v", actual, err)
+	}
+
+	err = unstructured.SetNestedSlice(u.Object, []interface{}{
+		map[string]interface{}{"name": "ec2", "url": "https://vpce-0a1b2c3d.ec2.us-east-1.vpce.amazonaws.com"},
+	}, "status", "platformStatus", "aws", "serviceEndpoints")
+	if err != nil {
+		t.Fatalf("Couldn't set the service endpoints: %v", err)
+	}
+	actual, err = awsServiceEndpoints(u)
+	if err != nil {
+		t.Fatalf("Couldn't get the service endpoints: %v", err)
+	}
+	expected := []cloudingressv1alpha1.AWSServiceEndpoint{
+		{Name: "ec2", URL: "https://vpce-0a1b2c3d.ec2.us-east-1.vpce.amazonaws.com"},
+	}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("Expected %v, got %v", expected, actual)
+	}
+
+	err = unstructured.SetNestedSlice(u.Object, []interface{}{
+		map[string]interface{}{"name": "ec2"},
+	}, "status", "platformStatus", "aws", "serviceEndpoints")
+	if err != nil {
+		t.Fatalf("Couldn't set the service endpoints: %v", err)
+	}
+	_, err = awsServiceEndpoints(u)
+	if err == nil {
+		t.Fatal("Expected an error for an endpoint without URL")
+	}
+}
+
+func TestControlPlaneTopology(t *testing.T) {
+	infraObj := testutils.CreateInfraObject("basename", testutils.DefaultAPIEndpoint, testutils.DefaultAPIEndpoint, testutils.DefaultRegionName)
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(infraObj)
+	if err != nil {
+		t.Fatalf("Couldn't convert the Infrastructure object: %v", err)
+	}
+	u := &unstructured.Unstructured{Object: content}
+
+	if actual := controlPlaneTopology(u); actual != "" {
+		t.Fatalf("Expected no topology without controlPlaneTopology. Got %v", actual)
+	}
+
+	err = unstructured.SetNestedField(u.Object, ExternalTopologyMode, "status", "controlPlaneTopology")
+	if err != nil {
+		t.Fatalf("Couldn't set the control plane topology: %v", err)
+	}
+	if actual := controlPlaneTopology(u); actual != ExternalTopologyMode {
+		t.Fatalf("Expected %v, got %v", ExternalTopologyMode, actual)
+	}
+}
+
+func TestGetPlatformConfigVersion(t *testing.T) {
+	infraObj := testutils.CreateInfraObject("basename", testutils.DefaultAPIEndpoint, testutils.DefaultAPIEndpoint, testutils.DefaultRegionName)
+	mocks := testutils.NewTestMock(t, []runtime.Object{infraObj})
+
+	before, err := GetPlatformConfigVersion(mocks.FakeKubeClient)
+	if err != nil {
+		t.Fatalf("Couldn't get the platform config version: %v", err)
+	}
+	again, err := GetPlatformConfigVersion(mocks.FakeKubeClient)
+	if err != nil {
+		t.Fatalf("Couldn't get the platform config version: %v", err)
+	}
+	if again != before {
+		t.Fatalf("Expected the version %s to stay the same, got %s", before, again)
+	}
+
+	infra, err := GetInfrastructureObject(mocks.FakeKubeClient)
+	if err != nil {
+		t.Fatalf("Couldn't get the Infrastructure: %v", err)
+	}
+	infra.Status.PlatformStatus.AWS.Region = "us-west-2"
+	err = mocks.FakeKubeClient.Update(context.TODO(), infra)
+	if err != nil {
+		t.Fatalf("Couldn't update the Infrastructure: %v", err)
+	}
+	after, err := GetPlatformConfigVersion(mocks.FakeKubeClient)
+	if err != nil {
+		t.Fatalf("Couldn't get the platform config version: %v", err)
+	}
+	if after == before {
+		t.Fatalf("Expected the version to change from %s once the Infrastructure changed", before)
+	}
+}
+
 // None of these should ever occur, but if they did, it'd be nice to know they return an error
 func TestNoInfraObj(t *testing.T) {
 	masterNames := make([]string, 3)
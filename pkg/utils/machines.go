@@ -11,6 +11,12 @@ import (
 
 const masterMachineLabel string = "machine.openshift.io/cluster-api-machine-role"
 
+// IsMasterMachine checks if obj is a master Machine
+func IsMasterMachine(obj client.Object) bool {
+	_, ok := obj.(*machineapi.Machine)
+	return ok && obj.GetLabels()[masterMachineLabel] == "master"
+}
+
 // GetMasterMachines returns a MachineList object whose .Items can be iterated
 // over to perform actions on/with information from each master machine object.
 func GetMasterMachines(kclient client.Client) (*machineapi.MachineList, error) {
@@ -0,0 +1,237 @@
+package utils
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"net/url"
+	"reflect"
+	"sync"
+	"time"
+
+	configv1 "github.com/openshift/api/config/v1"
+	"github.com/openshift/cloud-ingress-operator/config"
+	"golang.org/x/net/http/httpproxy"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// proxyRecheckInterval is how often the egress proxy configuration is read
+	// again
+	proxyRecheckInterval = time.Minute
+	// trustedCABundleKey is where the Cluster Network Operator injects the
+	// trusted CA bundle in ConfigMaps labelled for it. The ConfigMap of the
+	// CloudIngressConfig holds its CA bundle there too
+	trustedCABundleKey = "ca-bundle.crt"
+	// cloudProviderCABundleKey is where the cloud provider config of the
+	// cluster holds the CA bundle of its cloud API endpoints
+	cloudProviderCABundleKey = "ca-bundle.pem"
+	// cloudProviderConfigNamespace holds the cloud provider config of the
+	// cluster
+	cloudProviderConfigNamespace = "openshift-config"
+)
+
+// ProxyConfig is the egress proxy configuration of the cloud API calls
+type ProxyConfig struct {
+	HTTPProxy  string
+	HTTPSProxy string
+	NoProxy    string
+	// CABundle holds PEM certificates trusted on top of the system ones, eg
+	// the proxy's own, or those of the endpoints of an air-gapped region
+	CABundle string
+}
+
+// GetProxyConfig returns the egress proxy configuration. It's the status of the
+// cluster-wide Proxy object or, if that sets no proxy, the HTTP_PROXY,
+// HTTPS_PROXY and NO_PROXY environment variables. The trusted CA bundle is the
+// one injected in the operator's ConfigMap, followed by those of
+// getCloudAPICABundles. Like the Infrastructure object, everything is read as
+// unstructured to bypass the cache
+func GetProxyConfig(kclient client.Client) (*ProxyConfig, error) {
+	proxyConfig := &ProxyConfig{}
+
+	u := &unstructured.Unstructured{}
+	u.SetGroupVersionKind(configv1.GroupVersion.WithKind("Proxy"))
+	err := kclient.Get(context.TODO(), types.NamespacedName{Name: "cluster"}, u)
+	switch {
+	case err == nil:
+		proxy := &configv1.Proxy{}
+		err = runtime.DefaultUnstructuredConverter.FromUnstructured(u.UnstructuredContent(), proxy)
+		if err != nil {
+			return nil, err
+		}
+		proxyConfig.HTTPProxy = proxy.Status.HTTPProxy
+		proxyConfig.HTTPSProxy = proxy.Status.HTTPSProxy
+		proxyConfig.NoProxy = proxy.Status.NoProxy
+	case errors.IsNotFound(err) || meta.IsNoMatchError(err):
+		// no cluster-wide proxy
+	default:
+		return nil, err
+	}
+	if proxyConfig.HTTPProxy == "" && proxyConfig.HTTPSProxy == "" {
+		env := httpproxy.FromEnvironment()
+		proxyConfig.HTTPProxy = env.HTTPProxy
+		proxyConfig.HTTPSProxy = env.HTTPSProxy
+		proxyConfig.NoProxy = env.NoProxy
+	}
+
+	caBundle, err := getConfigMapKey(kclient, types.NamespacedName{Namespace: config.OperatorNamespace, Name: config.TrustedCABundleConfigMapName}, trustedCABundleKey)
+	if err != nil {
+		return nil, err
+	}
+	caBundles, err := getCloudAPICABundles(kclient)
+	if err != nil {
+		return nil, err
+	}
+	for _, bundle := range append([]string{caBundle}, caBundles...) {
+		if bundle == "" {
+			continue
+		}
+		if proxyConfig.CABundle != "" {
+			proxyConfig.CABundle += "\n"
+		}
+		proxyConfig.CABundle += bundle
+	}
+	return proxyConfig, nil
+}
+
+// getCloudAPICABundles returns the CA bundles trusted for the cloud API calls
+// besides the egress proxy's: the one the cluster was installed with, in the
+// ConfigMap of spec.cloudConfig of the Infrastructure object, eg for the
+// endpoints of C2S, and the one of the CloudIngressConfig
+func getCloudAPICABundles(kclient client.Client) ([]string, error) {
+	caBundles := []string{}
+	u, err := getUnstructuredInfrastructure(kclient)
+	if err != nil && !errors.IsNotFound(err) {
+		return nil, err
+	}
+	if err == nil {
+		name, _, _ := unstructured.NestedString(u.Object, "spec", "cloudConfig", "name")
+		if name != "" {
+			caBundle, err := getConfigMapKey(kclient, types.NamespacedName{Namespace: cloudProviderConfigNamespace, Name: name}, cloudProviderCABundleKey)
+			if err != nil {
+				return nil, err
+			}
+			caBundles = append(caBundles, caBundle)
+		}
+	}
+
+	ingressConfig, err := GetCloudIngressConfig(kclient)
+	if err != nil {
+		return nil, err
+	}
+	if name := ingressConfig.Spec.CloudAPICABundleConfigMapName; name != "" {
+		caBundle, err := getConfigMapKey(kclient, types.NamespacedName{Namespace: config.OperatorNamespace, Name: name}, trustedCABundleKey)
+		if err != nil {
+			return nil, err
+		}
+		if caBundle == "" {
+			return nil, fmt.Errorf("The ConfigMap %s/%s has no %s", config.OperatorNamespace, name, trustedCABundleKey)
+		}
+		caBundles = append(caBundles, caBundle)
+	}
+	return caBundles, nil
+}
+
+// getConfigMapKey returns the value of key in the ConfigMap ns, read as
+// unstructured. It's empty if either is missing
+func getConfigMapKey(kclient client.Client, ns types.NamespacedName, key string) (string, error) {
+	u := &unstructured.Unstructured{}
+	u.SetGroupVersionKind(corev1.SchemeGroupVersion.WithKind("ConfigMap"))
+	err := kclient.Get(context.TODO(), ns, u)
+	if errors.IsNotFound(err) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	value, _, err := unstructured.NestedString(u.Object, "data", key)
+	return value, err
+}
+
+// CloudAPITransport is the http.RoundTripper of the cloud API calls. It goes
+// through the egress proxy and trusts its CA bundle, and follows changes to
+// either without the operator restarting
+type CloudAPITransport struct {
+	kclient client.Client
+
+	mu        sync.Mutex
+	lastCheck time.Time
+	current   *ProxyConfig
+	transport *http.Transport
+}
+
+// NewCloudAPITransport returns a CloudAPITransport reading the egress proxy
+// configuration with kclient
+func NewCloudAPITransport(kclient client.Client) *CloudAPITransport {
+	return &CloudAPITransport{kclient: kclient}
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *CloudAPITransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	transport, err := t.getTransport()
+	if err != nil {
+		return nil, err
+	}
+	return transport.RoundTrip(req)
+}
+
+// getTransport returns the transport for the current egress proxy
+// configuration, which is read again at most once every proxyRecheckInterval.
+// If it can't be read, the last transport is kept
+func (t *CloudAPITransport) getTransport() (*http.Transport, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.transport != nil && time.Since(t.lastCheck) < proxyRecheckInterval {
+		return t.transport, nil
+	}
+	proxyConfig, err := GetProxyConfig(t.kclient)
+	if err == nil && (t.transport == nil || !reflect.DeepEqual(proxyConfig, t.current)) {
+		var transport *http.Transport
+		transport, err = newProxyTransport(proxyConfig)
+		if err == nil {
+			if t.transport != nil {
+				t.transport.CloseIdleConnections()
+			}
+			t.current, t.transport = proxyConfig, transport
+		}
+	}
+	if err != nil && t.transport == nil {
+		return nil, err
+	}
+	t.lastCheck = time.Now()
+	return t.transport, nil
+}
+
+// newProxyTransport returns a copy of http.DefaultTransport that uses the proxy
+// and trusts the CA bundle of proxyConfig
+func newProxyTransport(proxyConfig *ProxyConfig) (*http.Transport, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	proxyFunc := (&httpproxy.Config{
+		HTTPProxy:  proxyConfig.HTTPProxy,
+		HTTPSProxy: proxyConfig.HTTPSProxy,
+		NoProxy:    proxyConfig.NoProxy,
+	}).ProxyFunc()
+	transport.Proxy = func(req *http.Request) (*url.URL, error) {
+		return proxyFunc(req.URL)
+	}
+	if proxyConfig.CABundle != "" {
+		roots, err := x509.SystemCertPool()
+		if err != nil {
+			roots = x509.NewCertPool()
+		}
+		if !roots.AppendCertsFromPEM([]byte(proxyConfig.CABundle)) {
+			return nil, fmt.Errorf("The CA bundles trusted for the cloud API calls have no certificate")
+		}
+		transport.TLSClientConfig = &tls.Config{RootCAs: roots}
+	}
+	return transport, nil
+}
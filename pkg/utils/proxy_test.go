@@ -0,0 +1,156 @@
+package utils
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+
+	configv1 "github.com/openshift/api/config/v1"
+	"github.com/openshift/cloud-ingress-operator/config"
+	cloudingressv1alpha1 "github.com/openshift/cloud-ingress-operator/pkg/apis/cloudingress/v1alpha1"
+	"github.com/openshift/cloud-ingress-operator/pkg/testutils"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestGetProxyConfig(t *testing.T) {
+	proxy := &configv1.Proxy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "cluster",
+		},
+		Status: configv1.ProxyStatus{
+			HTTPSProxy: "http://proxy.example.com:3128",
+			NoProxy:    ".cluster.local",
+		},
+	}
+	caBundle := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      config.TrustedCABundleConfigMapName,
+			Namespace: config.OperatorNamespace,
+		},
+		Data: map[string]string{
+			"ca-bundle.crt": "-----BEGIN CERTIFICATE-----",
+		},
+	}
+	mocks := testutils.NewTestMock(t, []runtime.Object{proxy, caBundle})
+
+	actual, err := GetProxyConfig(mocks.FakeKubeClient)
+	if err != nil {
+		t.Fatalf("Couldn't get the proxy configuration: %v", err)
+	}
+	expected := ProxyConfig{
+		HTTPSProxy: "http://proxy.example.com:3128",
+		NoProxy:    ".cluster.local",
+		CABundle:   "-----BEGIN CERTIFICATE-----",
+	}
+	if *actual != expected {
+		t.Fatalf("Expected %+v, got %+v", expected, *actual)
+	}
+}
+
+func TestGetCloudAPICABundles(t *testing.T) {
+	infra := testutils.CreateInfraObject("basename", testutils.DefaultAPIEndpoint, testutils.DefaultAPIEndpoint, testutils.DefaultRegionName)
+	infra.Spec.CloudConfig.Name = "cloud-provider-config"
+	cloudProviderConfig := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "cloud-provider-config",
+			Namespace: "openshift-config",
+		},
+		Data: map[string]string{
+			"config":        "[Global]",
+			"ca-bundle.pem": "installed",
+		},
+	}
+	ingressConfig := &cloudingressv1alpha1.CloudIngressConfig{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: config.CloudIngressConfigName,
+		},
+		Spec: cloudingressv1alpha1.CloudIngressConfigSpec{
+			CloudAPICABundleConfigMapName: "cloud-api-ca",
+		},
+	}
+	customCABundle := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "cloud-api-ca",
+			Namespace: config.OperatorNamespace,
+		},
+		Data: map[string]string{
+			"ca-bundle.crt": "custom",
+		},
+	}
+	tests := []struct {
+		Name        string
+		Objects     []runtime.Object
+		Expected    []string
+		ExpectError bool
+	}{
+		{
+			Name:     "None",
+			Objects:  []runtime.Object{testutils.CreateInfraObject("basename", testutils.DefaultAPIEndpoint, testutils.DefaultAPIEndpoint, testutils.DefaultRegionName)},
+			Expected: []string{},
+		},
+		{
+			Name:     "Installed and custom",
+			Objects:  []runtime.Object{infra, cloudProviderConfig, ingressConfig, customCABundle},
+			Expected: []string{"installed", "custom"},
+		},
+		{
+			Name:        "Missing custom ConfigMap",
+			Objects:     []runtime.Object{infra, cloudProviderConfig, ingressConfig},
+			ExpectError: true,
+		},
+	}
+	for _, test := range tests {
+		mocks := testutils.NewTestMock(t, test.Objects)
+		actual, err := getCloudAPICABundles(mocks.FakeKubeClient)
+		if (err != nil) != test.ExpectError {
+			t.Fatalf("Test [%v] FAILED. Expected error %v. Got %v", test.Name, test.ExpectError, err)
+		}
+		if !test.ExpectError && !reflect.DeepEqual(actual, test.Expected) {
+			t.Fatalf("Test [%v] FAILED. Expected %v. Got %v", test.Name, test.Expected, actual)
+		}
+	}
+}
+
+func TestNewProxyTransport(t *testing.T) {
+	transport, err := newProxyTransport(&ProxyConfig{
+		HTTPSProxy: "http://proxy.example.com:3128",
+		NoProxy:    ".amazonaws.com.cn",
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	tests := []struct {
+		URL      string
+		Expected string
+	}{
+		{
+			URL:      "https://ec2.us-east-1.amazonaws.com/",
+			Expected: "http://proxy.example.com:3128",
+		},
+		{
+			URL:      "https://ec2.cn-north-1.amazonaws.com.cn/",
+			Expected: "",
+		},
+	}
+	for _, test := range tests {
+		req, _ := http.NewRequest("GET", test.URL, nil)
+		proxyURL, err := transport.Proxy(req)
+		if err != nil {
+			t.Fatalf("Unexpected error %v", err)
+		}
+		actual := ""
+		if proxyURL != nil {
+			actual = proxyURL.String()
+		}
+		if actual != test.Expected {
+			t.Fatalf("Expected %s to go through %q, got %q", test.URL, test.Expected, actual)
+		}
+	}
+
+	_, err = newProxyTransport(&ProxyConfig{CABundle: "not a certificate"})
+	if err == nil {
+		t.Fatalf("Expected an invalid CA bundle to be refused")
+	}
+}
@@ -0,0 +1,165 @@
+package utils
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	cloudingressv1alpha1 "github.com/openshift/cloud-ingress-operator/pkg/apis/cloudingress/v1alpha1"
+)
+
+// CronSchedule is a parsed cron expression of five fields: minute, hour, day
+// of month, month and day of week. Each field is *, a value, a range a-b, or
+// a list of those, each optionally with a /step. Days of week are 0-7, 0 and
+// 7 being Sunday
+type CronSchedule struct {
+	minutes, hours, days, months, weekdays map[int]bool
+	// anyDay and anyWeekday are set for a * day of month or day of week. When
+	// neither is, a day matching either field matches, as in crontab
+	anyDay, anyWeekday bool
+	location           *time.Location
+}
+
+// ParseCronSchedule parses the cron expression spec, whose times are in the
+// IANA time zone timeZone, eg Europe/Berlin. An empty time zone is UTC
+func ParseCronSchedule(spec, timeZone string) (*CronSchedule, error) {
+	location, err := time.LoadLocation(timeZone)
+	if err != nil {
+		return nil, fmt.Errorf("Unknown time zone %q: %s", timeZone, err)
+	}
+	fields := strings.Fields(spec)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("Cron expression %q needs 5 fields, it has %d", spec, len(fields))
+	}
+	s := &CronSchedule{
+		anyDay:     fields[2] == "*",
+		anyWeekday: fields[4] == "*",
+		location:   location,
+	}
+	for i, field := range []struct {
+		values   *map[int]bool
+		min, max int
+	}{
+		{&s.minutes, 0, 59},
+		{&s.hours, 0, 23},
+		{&s.days, 1, 31},
+		{&s.months, 1, 12},
+		{&s.weekdays, 0, 7},
+	} {
+		*field.values, err = parseCronField(fields[i], field.min, field.max)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid cron expression %q: %s", spec, err)
+		}
+	}
+	if s.weekdays[7] {
+		s.weekdays[0] = true
+	}
+	return s, nil
+}
+
+// parseCronField returns the values of field between min and max
+func parseCronField(field string, min, max int) (map[int]bool, error) {
+	values := map[int]bool{}
+	for _, part := range strings.Split(field, ",") {
+		step := 1
+		if i := strings.Index(part, "/"); i >= 0 {
+			var err error
+			step, err = strconv.Atoi(part[i+1:])
+			if err != nil || step < 1 {
+				return nil, fmt.Errorf("invalid step in %q", part)
+			}
+			part = part[:i]
+		}
+		from, to := min, max
+		if part != "*" {
+			bounds := strings.SplitN(part, "-", 2)
+			var err error
+			from, err = strconv.Atoi(bounds[0])
+			if err != nil {
+				return nil, fmt.Errorf("invalid value in %q", part)
+			}
+			to = from
+			if len(bounds) == 2 {
+				to, err = strconv.Atoi(bounds[1])
+				if err != nil {
+					return nil, fmt.Errorf("invalid value in %q", part)
+				}
+			}
+			if from < min || to > max || from > to {
+				return nil, fmt.Errorf("%q isn't within %d-%d", part, min, max)
+			}
+		}
+		for value := from; value <= to; value += step {
+			values[value] = true
+		}
+	}
+	return values, nil
+}
+
+// Next returns the first time after t the schedule fires, or the zero time
+// if it never does, eg on February 30th
+func (s *CronSchedule) Next(t time.Time) time.Time {
+	t = t.In(s.location).Truncate(time.Minute).Add(time.Minute)
+	// Any schedule that fires at all does within 4 years, leap days included
+	limit := t.AddDate(4, 0, 1)
+	for t.Before(limit) {
+		year, month, day := t.Date()
+		switch {
+		case !s.months[int(month)]:
+			t = time.Date(year, month+1, 1, 0, 0, 0, 0, s.location)
+		case !s.dayMatches(t):
+			t = time.Date(year, month, day+1, 0, 0, 0, 0, s.location)
+		case !s.hours[t.Hour()]:
+			t = time.Date(year, month, day, t.Hour()+1, 0, 0, 0, s.location)
+		case !s.minutes[t.Minute()]:
+			t = t.Add(time.Minute)
+		default:
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+// dayMatches checks if the day of t matches the day of month and day of week
+// fields
+func (s *CronSchedule) dayMatches(t time.Time) bool {
+	day, weekday := s.days[t.Day()], s.weekdays[int(t.Weekday())]
+	switch {
+	case s.anyDay && s.anyWeekday:
+		return true
+	case s.anyDay:
+		return weekday
+	case s.anyWeekday:
+		return day
+	}
+	return day || weekday
+}
+
+// GetPublicAccessWindow returns whether a maintenance window of the
+// PublicAccessSchedule of instance is open at now, and when it closes, or
+// when the next one opens if none is. It's never open without a schedule
+func GetPublicAccessWindow(instance *cloudingressv1alpha1.APIScheme, now time.Time) (bool, *time.Time, error) {
+	schedule := instance.Spec.ManagementAPIServerIngress.PublicAccessSchedule
+	if schedule == nil {
+		return false, nil, nil
+	}
+	if schedule.Duration.Duration <= 0 {
+		return false, nil, fmt.Errorf("The public access schedule needs a positive duration")
+	}
+	cron, err := ParseCronSchedule(schedule.Start, schedule.TimeZone)
+	if err != nil {
+		return false, nil, err
+	}
+	// A window open at now opened less than a duration ago
+	start := cron.Next(now.Add(-schedule.Duration.Duration))
+	if start.IsZero() {
+		return false, nil, fmt.Errorf("The public access schedule %q never opens a window", schedule.Start)
+	}
+	if !start.After(now) {
+		// A later window overlapping this one is open when it closes
+		end := start.Add(schedule.Duration.Duration)
+		return true, &end, nil
+	}
+	return false, &start, nil
+}
@@ -0,0 +1,162 @@
+package utils
+
+import (
+	"testing"
+	"time"
+
+	cloudingressv1alpha1 "github.com/openshift/cloud-ingress-operator/pkg/apis/cloudingress/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestCronScheduleNext(t *testing.T) {
+	// A Thursday
+	now := time.Date(2026, 10, 15, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		Name          string
+		Spec          string
+		TimeZone      string
+		Expected      time.Time
+		ErrorExpected bool
+	}{
+		{
+			Name:     "Every minute",
+			Spec:     "* * * * *",
+			Expected: time.Date(2026, 10, 15, 12, 1, 0, 0, time.UTC),
+		},
+		{
+			Name:     "Saturdays at 22:00",
+			Spec:     "0 22 * * 6",
+			Expected: time.Date(2026, 10, 17, 22, 0, 0, 0, time.UTC),
+		},
+		{
+			Name:     "Sunday as 7",
+			Spec:     "30 1 * * 7",
+			Expected: time.Date(2026, 10, 18, 1, 30, 0, 0, time.UTC),
+		},
+		{
+			Name:     "Steps and lists",
+			Spec:     "*/20 9,13 * * 1-5",
+			Expected: time.Date(2026, 10, 15, 13, 0, 0, 0, time.UTC),
+		},
+		{
+			Name:     "Day of month or day of week",
+			Spec:     "0 0 1 * 5",
+			Expected: time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			Name:     "Next year",
+			Spec:     "0 0 1 1 *",
+			Expected: time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			Name:     "Time zone",
+			Spec:     "0 22 * * *",
+			TimeZone: "Europe/Berlin",
+			Expected: time.Date(2026, 10, 15, 20, 0, 0, 0, time.UTC),
+		},
+		{
+			Name: "Never",
+			Spec: "0 0 30 2 *",
+		},
+		{
+			Name:          "Too few fields",
+			Spec:          "0 22 * *",
+			ErrorExpected: true,
+		},
+		{
+			Name:          "Out of range",
+			Spec:          "0 24 * * *",
+			ErrorExpected: true,
+		},
+		{
+			Name:          "Unknown time zone",
+			Spec:          "0 22 * * *",
+			TimeZone:      "Mars/Olympus_Mons",
+			ErrorExpected: true,
+		},
+	}
+	for _, test := range tests {
+		schedule, err := ParseCronSchedule(test.Spec, test.TimeZone)
+		if (err != nil) != test.ErrorExpected {
+			t.Fatalf("Test [%v] FAILED. Expected error %v. Got %v", test.Name, test.ErrorExpected, err)
+		}
+		if err != nil {
+			continue
+		}
+		if actual := schedule.Next(now); !actual.Equal(test.Expected) {
+			t.Fatalf("Test [%v] FAILED. Expected %v. Got %v", test.Name, test.Expected, actual)
+		}
+	}
+}
+
+func TestGetPublicAccessWindow(t *testing.T) {
+	saturday := time.Date(2026, 10, 17, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		Name               string
+		Schedule           *cloudingressv1alpha1.PublicAccessSchedule
+		Now                time.Time
+		ExpectedOpen       bool
+		ExpectedNextChange *time.Time
+		ErrorExpected      bool
+	}{
+		{
+			Name: "No schedule",
+			Now:  saturday,
+		},
+		{
+			Name:               "Before the window",
+			Schedule:           &cloudingressv1alpha1.PublicAccessSchedule{Start: "0 22 * * 6", Duration: metav1.Duration{Duration: 4 * time.Hour}},
+			Now:                saturday.Add(21 * time.Hour),
+			ExpectedNextChange: timePtr(saturday.Add(22 * time.Hour)),
+		},
+		{
+			Name:               "In the window",
+			Schedule:           &cloudingressv1alpha1.PublicAccessSchedule{Start: "0 22 * * 6", Duration: metav1.Duration{Duration: 4 * time.Hour}},
+			Now:                saturday.Add(23 * time.Hour),
+			ExpectedOpen:       true,
+			ExpectedNextChange: timePtr(saturday.Add(26 * time.Hour)),
+		},
+		{
+			Name:               "At the end of the window",
+			Schedule:           &cloudingressv1alpha1.PublicAccessSchedule{Start: "0 22 * * 6", Duration: metav1.Duration{Duration: 4 * time.Hour}},
+			Now:                saturday.Add(26 * time.Hour),
+			ExpectedNextChange: timePtr(saturday.Add(7*24*time.Hour + 22*time.Hour)),
+		},
+		{
+			Name:          "No duration",
+			Schedule:      &cloudingressv1alpha1.PublicAccessSchedule{Start: "0 22 * * 6"},
+			Now:           saturday,
+			ErrorExpected: true,
+		},
+		{
+			Name:          "Never opens",
+			Schedule:      &cloudingressv1alpha1.PublicAccessSchedule{Start: "0 0 31 4 *", Duration: metav1.Duration{Duration: time.Hour}},
+			Now:           saturday,
+			ErrorExpected: true,
+		},
+	}
+	for _, test := range tests {
+		instance := &cloudingressv1alpha1.APIScheme{
+			Spec: cloudingressv1alpha1.APISchemeSpec{
+				ManagementAPIServerIngress: cloudingressv1alpha1.ManagementAPIServerIngress{
+					Scheme:               cloudingressv1alpha1.LoadBalancerSchemeInternal,
+					PublicAccessSchedule: test.Schedule,
+				},
+			},
+		}
+		open, nextChange, err := GetPublicAccessWindow(instance, test.Now)
+		if (err != nil) != test.ErrorExpected {
+			t.Fatalf("Test [%v] FAILED. Expected error %v. Got %v", test.Name, test.ErrorExpected, err)
+		}
+		if open != test.ExpectedOpen {
+			t.Fatalf("Test [%v] FAILED. Expected open %v. Got %v", test.Name, test.ExpectedOpen, open)
+		}
+		if (nextChange == nil) != (test.ExpectedNextChange == nil) || (nextChange != nil && !nextChange.Equal(*test.ExpectedNextChange)) {
+			t.Fatalf("Test [%v] FAILED. Expected next change %v. Got %v", test.Name, test.ExpectedNextChange, nextChange)
+		}
+	}
+}
+
+func timePtr(t time.Time) *time.Time {
+	return &t
+}
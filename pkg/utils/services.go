@@ -0,0 +1,134 @@
+package utils
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// lbResyncAnnotationKey is bumped on a Service to make the cloud provider
+	// re-ensure its load balancer. Any annotation change does that, this one
+	// just records when the operator asked for it
+	lbResyncAnnotationKey string = "cloudingress.managed.openshift.io/lb-resync"
+
+	masterNodeRoleLabel          string = "node-role.kubernetes.io/master"
+	excludeFromExternalLBsLabel  string = "node.kubernetes.io/exclude-from-external-load-balancers"
+	legacyExcludeFromExternalLBs string = "alpha.service-controller.kubernetes.io/exclude-balancer"
+)
+
+// RequestLoadBalancerResync asks the cloud provider's service controller to
+// reconcile the load balancer of svc again, which repairs listeners,
+// registered instances, health checks and security groups
+func RequestLoadBalancerResync(kclient client.Client, svc *corev1.Service) error {
+	metav1.SetMetaDataAnnotation(&svc.ObjectMeta, lbResyncAnnotationKey, time.Now().UTC().Format(time.RFC3339))
+	return kclient.Update(context.TODO(), svc)
+}
+
+// GetLoadBalancerNodes returns the Nodes the cloud provider's service
+// controller registers with Service load balancers: Ready Nodes that are
+// neither masters nor excluded from external load balancers, and have the
+// labels of selector, if any
+func GetLoadBalancerNodes(kclient client.Client, selector map[string]string) ([]corev1.Node, error) {
+	nodeList := &corev1.NodeList{}
+	err := kclient.List(context.TODO(), nodeList, client.MatchingLabels(selector))
+	if err != nil {
+		return nil, err
+	}
+	nodes := []corev1.Node{}
+	for _, node := range nodeList.Items {
+		if _, ok := node.Labels[masterNodeRoleLabel]; ok {
+			continue
+		}
+		if _, ok := node.Labels[excludeFromExternalLBsLabel]; ok {
+			continue
+		}
+		if _, ok := node.Labels[legacyExcludeFromExternalLBs]; ok {
+			continue
+		}
+		for _, condition := range node.Status.Conditions {
+			if condition.Type == corev1.NodeReady && condition.Status == corev1.ConditionTrue {
+				nodes = append(nodes, node)
+				break
+			}
+		}
+	}
+	return nodes, nil
+}
+
+// FormatNodeLabels returns selector as the key=value,... list of node labels
+// the AWS cloud provider reads from a Service annotation, in key order
+func FormatNodeLabels(selector map[string]string) string {
+	labels := make([]string, 0, len(selector))
+	for key, value := range selector {
+		labels = append(labels, key+"="+value)
+	}
+	sort.Strings(labels)
+	return strings.Join(labels, ",")
+}
+
+// ParseNodeLabels returns the node labels of a key=value,... list, the
+// reverse of FormatNodeLabels
+func ParseNodeLabels(labels string) map[string]string {
+	selector := map[string]string{}
+	for _, label := range strings.Split(labels, ",") {
+		if label = strings.TrimSpace(label); label == "" {
+			continue
+		}
+		parts := strings.SplitN(label, "=", 2)
+		if len(parts) == 1 {
+			parts = append(parts, "")
+		}
+		selector[parts[0]] = parts[1]
+	}
+	return selector
+}
+
+// SameMembers checks if the load balancer instances registered are the ones
+// desired, in any order. Instances registered twice count once
+func SameMembers(registered, desired []string) bool {
+	isRegistered := make(map[string]bool, len(registered))
+	for _, instance := range registered {
+		isRegistered[instance] = true
+	}
+	isDesired := make(map[string]bool, len(desired))
+	for _, instance := range desired {
+		if !isRegistered[instance] {
+			return false
+		}
+		isDesired[instance] = true
+	}
+	return len(isRegistered) == len(isDesired)
+}
+
+// MemberChanges returns the desired load balancer instances that aren't
+// registered, and the registered instances that aren't desired, each sorted.
+// Both are empty when SameMembers is true
+func MemberChanges(registered, desired []string) ([]string, []string) {
+	isRegistered := make(map[string]bool, len(registered))
+	for _, instance := range registered {
+		isRegistered[instance] = true
+	}
+	isDesired := make(map[string]bool, len(desired))
+	register := []string{}
+	for _, instance := range desired {
+		if !isRegistered[instance] && !isDesired[instance] {
+			register = append(register, instance)
+		}
+		isDesired[instance] = true
+	}
+	deregister := []string{}
+	for instance := range isRegistered {
+		if !isDesired[instance] {
+			deregister = append(deregister, instance)
+		}
+	}
+	sort.Strings(register)
+	sort.Strings(deregister)
+	return register, deregister
+}
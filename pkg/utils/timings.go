@@ -0,0 +1,180 @@
+package utils
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// The phases of a reconcile whose time is added up
+const (
+	// PhaseCloud is the time spent in cloud API calls, retries and rate
+	// limiting included
+	PhaseCloud = "cloud"
+	// PhaseKubernetes is the time spent in API server calls
+	PhaseKubernetes = "kubernetes"
+	// PhaseWait is the time spent sleeping in wait loops, eg between retries
+	// of DNS changes
+	PhaseWait = "wait"
+	// PhaseOther is the rest of the time of a reconcile
+	PhaseOther = "other"
+)
+
+// ReconcileTimings adds up the time a reconcile spends in each phase. A nil
+// ReconcileTimings adds up nothing, so code that may run outside a reconcile
+// doesn't need to check for it. It's safe for concurrent use
+type ReconcileTimings struct {
+	start  time.Time
+	mu     sync.Mutex
+	phases map[string]time.Duration
+}
+
+// reconcileTimingsKey is the context key of the ReconcileTimings of a reconcile
+type reconcileTimingsKey struct{}
+
+// WithReconcileTimings returns a copy of ctx carrying the timings of a
+// reconcile starting now, and those timings
+func WithReconcileTimings(ctx context.Context) (context.Context, *ReconcileTimings) {
+	timings := &ReconcileTimings{
+		start:  time.Now(),
+		phases: map[string]time.Duration{},
+	}
+	return context.WithValue(ctx, reconcileTimingsKey{}, timings), timings
+}
+
+// ReconcileTimingsFrom returns the timings ctx carries, nil if it carries none
+func ReconcileTimingsFrom(ctx context.Context) *ReconcileTimings {
+	if ctx == nil {
+		return nil
+	}
+	timings, _ := ctx.Value(reconcileTimingsKey{}).(*ReconcileTimings)
+	return timings
+}
+
+// Add adds d to the time spent in phase
+func (t *ReconcileTimings) Add(phase string, d time.Duration) {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.phases[phase] += d
+}
+
+// Start starts timing phase, and returns the func that stops it
+func (t *ReconcileTimings) Start(phase string) func() {
+	if t == nil {
+		return func() {}
+	}
+	start := time.Now()
+	return func() {
+		t.Add(phase, time.Since(start))
+	}
+}
+
+// Breakdown returns the time spent in each phase until now. The time not
+// spent in any of them is PhaseOther. Phases that overlap, like concurrent
+// calls, can add up to more than the time since the reconcile started, in
+// which case PhaseOther is zero
+func (t *ReconcileTimings) Breakdown(now time.Time) map[string]time.Duration {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	breakdown := map[string]time.Duration{
+		PhaseCloud:      0,
+		PhaseKubernetes: 0,
+		PhaseWait:       0,
+	}
+	other := now.Sub(t.start)
+	for phase, d := range t.phases {
+		breakdown[phase] += d
+		other -= d
+	}
+	if other < 0 {
+		other = 0
+	}
+	breakdown[PhaseOther] = other
+	return breakdown
+}
+
+// TimePhase starts timing phase of the reconcile of ctx, and returns the func
+// that stops it. Outside a reconcile it times nothing
+func TimePhase(ctx context.Context, phase string) func() {
+	return ReconcileTimingsFrom(ctx).Start(phase)
+}
+
+// NewTimedClient returns a client.Client adding the time of the calls of c to
+// the PhaseKubernetes of the reconcile of their context
+func NewTimedClient(c client.Client) client.Client {
+	return timedClient{Client: c}
+}
+
+// timedClient times the calls of the client.Client it embeds
+type timedClient struct {
+	client.Client
+}
+
+// Get implements client.Reader
+func (c timedClient) Get(ctx context.Context, key client.ObjectKey, obj client.Object) error {
+	defer TimePhase(ctx, PhaseKubernetes)()
+	return c.Client.Get(ctx, key, obj)
+}
+
+// List implements client.Reader
+func (c timedClient) List(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+	defer TimePhase(ctx, PhaseKubernetes)()
+	return c.Client.List(ctx, list, opts...)
+}
+
+// Create implements client.Writer
+func (c timedClient) Create(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
+	defer TimePhase(ctx, PhaseKubernetes)()
+	return c.Client.Create(ctx, obj, opts...)
+}
+
+// Delete implements client.Writer
+func (c timedClient) Delete(ctx context.Context, obj client.Object, opts ...client.DeleteOption) error {
+	defer TimePhase(ctx, PhaseKubernetes)()
+	return c.Client.Delete(ctx, obj, opts...)
+}
+
+// Update implements client.Writer
+func (c timedClient) Update(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+	defer TimePhase(ctx, PhaseKubernetes)()
+	return c.Client.Update(ctx, obj, opts...)
+}
+
+// Patch implements client.Writer
+func (c timedClient) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+	defer TimePhase(ctx, PhaseKubernetes)()
+	return c.Client.Patch(ctx, obj, patch, opts...)
+}
+
+// DeleteAllOf implements client.Writer
+func (c timedClient) DeleteAllOf(ctx context.Context, obj client.Object, opts ...client.DeleteAllOfOption) error {
+	defer TimePhase(ctx, PhaseKubernetes)()
+	return c.Client.DeleteAllOf(ctx, obj, opts...)
+}
+
+// Status implements client.StatusClient
+func (c timedClient) Status() client.StatusWriter {
+	return timedStatusWriter{StatusWriter: c.Client.Status()}
+}
+
+// timedStatusWriter times the calls of the client.StatusWriter it embeds
+type timedStatusWriter struct {
+	client.StatusWriter
+}
+
+// Update implements client.StatusWriter
+func (w timedStatusWriter) Update(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+	defer TimePhase(ctx, PhaseKubernetes)()
+	return w.StatusWriter.Update(ctx, obj, opts...)
+}
+
+// Patch implements client.StatusWriter
+func (w timedStatusWriter) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+	defer TimePhase(ctx, PhaseKubernetes)()
+	return w.StatusWriter.Patch(ctx, obj, patch, opts...)
+}
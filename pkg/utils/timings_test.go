@@ -0,0 +1,81 @@
+package utils
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestReconcileTimingsBreakdown(t *testing.T) {
+	start := time.Date(2021, 6, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		Name     string
+		Phases   map[string]time.Duration
+		Now      time.Time
+		Expected map[string]time.Duration
+	}{
+		{
+			Name:   "Nothing timed",
+			Phases: map[string]time.Duration{},
+			Now:    start.Add(10 * time.Second),
+			Expected: map[string]time.Duration{
+				PhaseCloud:      0,
+				PhaseKubernetes: 0,
+				PhaseWait:       0,
+				PhaseOther:      10 * time.Second,
+			},
+		},
+		{
+			Name: "Every phase",
+			Phases: map[string]time.Duration{
+				PhaseCloud:      6 * time.Second,
+				PhaseKubernetes: time.Second,
+				PhaseWait:       2 * time.Second,
+			},
+			Now: start.Add(10 * time.Second),
+			Expected: map[string]time.Duration{
+				PhaseCloud:      6 * time.Second,
+				PhaseKubernetes: time.Second,
+				PhaseWait:       2 * time.Second,
+				PhaseOther:      time.Second,
+			},
+		},
+		{
+			Name: "Overlapping calls",
+			Phases: map[string]time.Duration{
+				PhaseCloud: 15 * time.Second,
+			},
+			Now: start.Add(10 * time.Second),
+			Expected: map[string]time.Duration{
+				PhaseCloud:      15 * time.Second,
+				PhaseKubernetes: 0,
+				PhaseWait:       0,
+				PhaseOther:      0,
+			},
+		},
+	}
+	for _, test := range tests {
+		timings := &ReconcileTimings{start: start, phases: test.Phases}
+		actual := timings.Breakdown(test.Now)
+		if !reflect.DeepEqual(actual, test.Expected) {
+			t.Fatalf("Test [%v] FAILED. Expected %v. Got %v", test.Name, test.Expected, actual)
+		}
+	}
+}
+
+func TestTimePhase(t *testing.T) {
+	// Outside a reconcile nothing is timed
+	TimePhase(context.TODO(), PhaseCloud)()
+
+	ctx, timings := WithReconcileTimings(context.TODO())
+	if ReconcileTimingsFrom(ctx) != timings {
+		t.Fatalf("Test [Context] FAILED. Expected %v. Got %v", timings, ReconcileTimingsFrom(ctx))
+	}
+	stop := TimePhase(ctx, PhaseWait)
+	time.Sleep(10 * time.Millisecond)
+	stop()
+	if waited := timings.Breakdown(time.Now())[PhaseWait]; waited < 10*time.Millisecond {
+		t.Fatalf("Test [Wait] FAILED. Expected at least %v. Got %v", 10*time.Millisecond, waited)
+	}
+}
@@ -0,0 +1,122 @@
+// +build e2e
+
+package e2e
+
+import (
+	"context"
+	"testing"
+
+	configv1 "github.com/openshift/api/config/v1"
+	"github.com/openshift/cloud-ingress-operator/config"
+	cloudingressv1alpha1 "github.com/openshift/cloud-ingress-operator/pkg/apis/cloudingress/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+// TestAPISchemeLifecycle creates a public admin API endpoint, makes it
+// private, then deletes it, checking its load balancer and DNS records at
+// every step
+func TestAPISchemeLifecycle(t *testing.T) {
+	ctx := context.TODO()
+	name := types.NamespacedName{Name: config.AdminAPIName, Namespace: config.OperatorNamespace}
+	instance := &cloudingressv1alpha1.APIScheme{}
+	err := kclient.Get(ctx, name, instance)
+	switch {
+	case err == nil:
+		t.Skipf("The cluster already has the APIScheme %s, the test needs to create its own", name)
+	case !errors.IsNotFound(err):
+		t.Fatalf("Couldn't get the APIScheme %s: %v", name, err)
+	}
+
+	// create, public
+	instance = &cloudingressv1alpha1.APIScheme{
+		ObjectMeta: metav1.ObjectMeta{Name: name.Name, Namespace: name.Namespace},
+		Spec: cloudingressv1alpha1.APISchemeSpec{
+			ManagementAPIServerIngress: cloudingressv1alpha1.ManagementAPIServerIngress{
+				Enabled:           true,
+				DNSName:           config.AdminAPIName,
+				AllowedCIDRBlocks: []string{"0.0.0.0/0"},
+			},
+		},
+	}
+	err = kclient.Create(ctx, instance)
+	if err != nil {
+		t.Fatalf("Couldn't create the APIScheme: %v", err)
+	}
+	defer func() {
+		// Leave nothing behind if the test fails half-way
+		if err := kclient.Delete(ctx, instance); err != nil && !errors.IsNotFound(err) {
+			t.Errorf("Couldn't delete the APIScheme: %v", err)
+		}
+	}()
+	svc := waitForAPIScheme(t, instance, cloudingressv1alpha1.APIEndpointPublic)
+	assertLoadBalancerScheme(t, svc, "internet-facing")
+	assertAdminAPIServed(t, instance, svc)
+
+	// private
+	instance.Spec.ManagementAPIServerIngress.Scheme = cloudingressv1alpha1.LoadBalancerSchemeInternal
+	err = kclient.Update(ctx, instance)
+	if err != nil {
+		t.Fatalf("Couldn't make the APIScheme internal: %v", err)
+	}
+	svc = waitForAPIScheme(t, instance, cloudingressv1alpha1.APIEndpointPrivate)
+	assertLoadBalancerScheme(t, svc, "internal")
+	assertAdminAPIServed(t, instance, svc)
+
+	// delete
+	hostname := svc.Status.LoadBalancer.Ingress[0].Hostname
+	err = kclient.Delete(ctx, instance)
+	if err != nil {
+		t.Fatalf("Couldn't delete the APIScheme: %v", err)
+	}
+	err = wait.PollImmediate(pollInterval, pollTimeout, func() (bool, error) {
+		err := kclient.Get(ctx, name, &cloudingressv1alpha1.APIScheme{})
+		if errors.IsNotFound(err) {
+			return true, nil
+		}
+		return false, err
+	})
+	if err != nil {
+		t.Fatalf("The APIScheme wasn't deleted: %v", err)
+	}
+	err = kclient.Get(ctx, types.NamespacedName{Name: svc.Name, Namespace: svc.Namespace}, &corev1.Service{})
+	if !errors.IsNotFound(err) {
+		t.Fatalf("Expected %s/service/%s to be deleted, got %v", svc.Namespace, svc.Name, err)
+	}
+	if platform == configv1.AWSPlatformType {
+		if _, ok := awsLoadBalancerScheme(t, hostname); ok {
+			t.Fatalf("Expected the load balancer %s to be deleted", hostname)
+		}
+	}
+	if servers, err := cloud.GetAdminAPINameServers(ctx, kclient, instance); err == nil {
+		if failures := unresolvedAdminAPI(t, instance); len(failures) != len(servers) {
+			t.Fatalf("Expected the admin API record to be deleted, only %d of %d nameservers don't resolve it: %v", len(failures), len(servers), failures)
+		}
+	}
+}
+
+// waitForAPIScheme waits for instance to be ready with the given visibility,
+// and returns its Service once it has a load balancer
+func waitForAPIScheme(t *testing.T, instance *cloudingressv1alpha1.APIScheme, visibility cloudingressv1alpha1.APIEndpointVisibility) *corev1.Service {
+	ctx := context.TODO()
+	err := wait.PollImmediate(pollInterval, pollTimeout, func() (bool, error) {
+		err := kclient.Get(ctx, types.NamespacedName{Name: instance.Name, Namespace: instance.Namespace}, instance)
+		return err == nil && instance.Status.State == cloudingressv1alpha1.ConditionReady &&
+			instance.Status.APIEndpointVisibility == visibility, err
+	})
+	if err != nil {
+		t.Fatalf("The APIScheme isn't ready and %s, its status is %+v: %v", visibility, instance.Status, err)
+	}
+	svc := &corev1.Service{}
+	err = kclient.Get(ctx, types.NamespacedName{Name: instance.Spec.ManagementAPIServerIngress.DNSName, Namespace: "openshift-kube-apiserver"}, svc)
+	if err != nil {
+		t.Fatalf("Couldn't get the admin API Service: %v", err)
+	}
+	if len(svc.Status.LoadBalancer.Ingress) == 0 {
+		t.Fatalf("The admin API Service has no load balancer")
+	}
+	return svc
+}
@@ -0,0 +1,140 @@
+// +build e2e
+
+package e2e
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/elb"
+	"github.com/aws/aws-sdk-go/service/elbv2"
+	configv1 "github.com/openshift/api/config/v1"
+	"github.com/openshift/cloud-ingress-operator/config"
+	cloudingressv1alpha1 "github.com/openshift/cloud-ingress-operator/pkg/apis/cloudingress/v1alpha1"
+	baseutils "github.com/openshift/cloud-ingress-operator/pkg/utils"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// assertAdminAPIServed checks the load balancer of the admin API Service svc
+// sends traffic to healthy instances, hasn't drifted from what the operator
+// would make it, and that the public nameservers of the cluster answer for
+// the admin API record
+func assertAdminAPIServed(t *testing.T, instance *cloudingressv1alpha1.APIScheme, svc *corev1.Service) {
+	ctx := context.TODO()
+	healthy, err := cloud.GetAdminAPIHealthyInstances(ctx, kclient, instance, svc)
+	if err != nil {
+		t.Fatalf("Couldn't get the healthy instances of the admin API load balancer: %v", err)
+	}
+	if len(healthy) == 0 {
+		t.Fatalf("No instance behind the admin API load balancer is healthy")
+	}
+	drifted, err := cloud.CheckAdminAPIDrift(ctx, kclient, instance, svc)
+	if err != nil {
+		t.Fatalf("Couldn't check the admin API for drift: %v", err)
+	}
+	if len(drifted) > 0 {
+		t.Fatalf("The admin API has drifted: %v", drifted)
+	}
+	if failures := unresolvedAdminAPI(t, instance); len(failures) > 0 {
+		t.Fatalf("The admin API record doesn't resolve: %v", failures)
+	}
+}
+
+// unresolvedAdminAPI resolves the admin API record of instance against the
+// authoritative nameservers of its zones, and returns why for each of those
+// that can't
+func unresolvedAdminAPI(t *testing.T, instance *cloudingressv1alpha1.APIScheme) []string {
+	ctx := context.TODO()
+	servers, err := cloud.GetAdminAPINameServers(ctx, kclient, instance)
+	if err != nil {
+		t.Fatalf("Couldn't get the nameservers of the admin API zones: %v", err)
+	}
+	baseDomain, err := baseutils.GetAdminAPIBaseDomain(kclient, instance)
+	if err != nil {
+		t.Fatalf("Couldn't get the base domain of the admin API: %v", err)
+	}
+	return baseutils.UnpropagatedDNSServers(ctx, servers, instance.Spec.ManagementAPIServerIngress.DNSName+"."+baseDomain, nil)
+}
+
+// awsSession returns a session with the operator's AWS credentials, in the
+// cluster's region
+func awsSession(t *testing.T) *session.Session {
+	secret := &corev1.Secret{}
+	err := kclient.Get(context.TODO(), types.NamespacedName{Name: config.AWSSecretName, Namespace: config.OperatorNamespace}, secret)
+	if err != nil {
+		t.Fatalf("Couldn't get the operator's AWS credentials: %v", err)
+	}
+	infra, err := baseutils.GetInfrastructureObject(kclient)
+	if err != nil {
+		t.Fatalf("Couldn't get the cluster's Infrastructure: %v", err)
+	}
+	sess, err := session.NewSession(&aws.Config{
+		Region: aws.String(infra.Status.PlatformStatus.AWS.Region),
+		Credentials: credentials.NewStaticCredentials(
+			string(secret.Data["aws_access_key_id"]), string(secret.Data["aws_secret_access_key"]), ""),
+	})
+	if err != nil {
+		t.Fatalf("Couldn't create an AWS session: %v", err)
+	}
+	return sess
+}
+
+// awsLoadBalancerScheme returns the scheme, internal or internet-facing, of
+// the AWS load balancer, classic or network, with the DNS name dnsName.
+// Returns false if there's none
+func awsLoadBalancerScheme(t *testing.T, dnsName string) (string, bool) {
+	sess := awsSession(t)
+	scheme := ""
+	err := elb.New(sess).DescribeLoadBalancersPages(&elb.DescribeLoadBalancersInput{}, func(page *elb.DescribeLoadBalancersOutput, lastPage bool) bool {
+		for _, lb := range page.LoadBalancerDescriptions {
+			if strings.EqualFold(aws.StringValue(lb.DNSName), dnsName) {
+				scheme = aws.StringValue(lb.Scheme)
+				return false
+			}
+		}
+		return true
+	})
+	if err != nil {
+		t.Fatalf("Couldn't describe the classic load balancers: %v", err)
+	}
+	if scheme != "" {
+		return scheme, true
+	}
+	err = elbv2.New(sess).DescribeLoadBalancersPages(&elbv2.DescribeLoadBalancersInput{}, func(page *elbv2.DescribeLoadBalancersOutput, lastPage bool) bool {
+		for _, lb := range page.LoadBalancers {
+			if strings.EqualFold(aws.StringValue(lb.DNSName), dnsName) {
+				scheme = aws.StringValue(lb.Scheme)
+				return false
+			}
+		}
+		return true
+	})
+	if err != nil {
+		t.Fatalf("Couldn't describe the network load balancers: %v", err)
+	}
+	return scheme, scheme != ""
+}
+
+// assertLoadBalancerScheme checks the load balancer of svc has the scheme
+// expected, internal or internet-facing. Only AWS is checked
+func assertLoadBalancerScheme(t *testing.T, svc *corev1.Service, expected string) {
+	if platform != configv1.AWSPlatformType {
+		return
+	}
+	if len(svc.Status.LoadBalancer.Ingress) == 0 {
+		t.Fatalf("%s/service/%s has no load balancer", svc.Namespace, svc.Name)
+	}
+	hostname := svc.Status.LoadBalancer.Ingress[0].Hostname
+	scheme, ok := awsLoadBalancerScheme(t, hostname)
+	if !ok {
+		t.Fatalf("The load balancer %s of %s/service/%s doesn't exist", hostname, svc.Namespace, svc.Name)
+	}
+	if scheme != expected {
+		t.Fatalf("Expected the load balancer %s of %s/service/%s to be %s, it's %s", hostname, svc.Namespace, svc.Name, expected, scheme)
+	}
+}
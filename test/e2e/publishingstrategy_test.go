@@ -0,0 +1,175 @@
+// +build e2e
+
+package e2e
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/elbv2"
+	configv1 "github.com/openshift/api/config/v1"
+	operatorv1 "github.com/openshift/api/operator/v1"
+	"github.com/openshift/cloud-ingress-operator/config"
+	cloudingressv1alpha1 "github.com/openshift/cloud-ingress-operator/pkg/apis/cloudingress/v1alpha1"
+	baseutils "github.com/openshift/cloud-ingress-operator/pkg/utils"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// TestPublishingStrategyApplicationIngress makes the default application
+// ingress internal, then external again, checking the scheme of its router's
+// load balancer each time
+func TestPublishingStrategyApplicationIngress(t *testing.T) {
+	publishingStrategy := getPublishingStrategy(t)
+	index := -1
+	for i, ingress := range publishingStrategy.Spec.ApplicationIngress {
+		if ingress.Default {
+			index = i
+		}
+	}
+	if index < 0 {
+		t.Skipf("The PublishingStrategy %s has no default ApplicationIngress", publishingStrategy.Name)
+	}
+	original := publishingStrategy.Spec.ApplicationIngress[index].Listening
+	defer setApplicationIngressListening(t, publishingStrategy, index, original)
+
+	for _, listening := range []cloudingressv1alpha1.Listening{cloudingressv1alpha1.Internal, cloudingressv1alpha1.External} {
+		setApplicationIngressListening(t, publishingStrategy, index, listening)
+		waitForApplicationIngress(t, publishingStrategy, index, listening)
+	}
+}
+
+// TestPublishingStrategyDefaultAPI makes the default API internal, then
+// external again, checking its external load balancer is removed and
+// restored. Only run with E2E_DISRUPTIVE=true, as the tests may lose the
+// cluster while its default API is internal
+func TestPublishingStrategyDefaultAPI(t *testing.T) {
+	if !disruptive {
+		t.Skip("Making the default API internal can cut the tests off from the cluster, set E2E_DISRUPTIVE=true to run it")
+	}
+	publishingStrategy := getPublishingStrategy(t)
+	original := publishingStrategy.Spec.DefaultAPIServerIngress.Listening
+	defer setDefaultAPIListening(t, publishingStrategy, original)
+
+	for _, listening := range []cloudingressv1alpha1.Listening{cloudingressv1alpha1.Internal, cloudingressv1alpha1.External} {
+		setDefaultAPIListening(t, publishingStrategy, listening)
+		if platform != configv1.AWSPlatformType {
+			continue
+		}
+		clusterName, err := baseutils.GetClusterName(kclient)
+		if err != nil {
+			t.Fatalf("Couldn't get the cluster's name: %v", err)
+		}
+		elbName := clusterName + "-" + config.ExternalCloudAPILBNameSuffix
+		err = wait.PollImmediate(pollInterval, pollTimeout, func() (bool, error) {
+			return awsLoadBalancerNamedExists(t, elbName) == (listening == cloudingressv1alpha1.External), nil
+		})
+		if err != nil {
+			t.Fatalf("Expected the external API load balancer %s to exist: %v. %v", elbName, listening == cloudingressv1alpha1.External, err)
+		}
+	}
+}
+
+// getPublishingStrategy returns the cluster's PublishingStrategy, skipping the
+// test if there's none
+func getPublishingStrategy(t *testing.T) *cloudingressv1alpha1.PublishingStrategy {
+	list := &cloudingressv1alpha1.PublishingStrategyList{}
+	err := kclient.List(context.TODO(), list, client.InNamespace(config.OperatorNamespace))
+	if err != nil {
+		t.Fatalf("Couldn't list the PublishingStrategies: %v", err)
+	}
+	if len(list.Items) == 0 {
+		t.Skipf("The cluster has no PublishingStrategy in %s", config.OperatorNamespace)
+	}
+	return &list.Items[0]
+}
+
+// setApplicationIngressListening sets the listening scope of the
+// ApplicationIngress at index in publishingStrategy
+func setApplicationIngressListening(t *testing.T, publishingStrategy *cloudingressv1alpha1.PublishingStrategy, index int, listening cloudingressv1alpha1.Listening) {
+	updatePublishingStrategy(t, publishingStrategy, func() {
+		publishingStrategy.Spec.ApplicationIngress[index].Listening = listening
+	})
+}
+
+// setDefaultAPIListening sets the listening scope of the default API in
+// publishingStrategy
+func setDefaultAPIListening(t *testing.T, publishingStrategy *cloudingressv1alpha1.PublishingStrategy, listening cloudingressv1alpha1.Listening) {
+	updatePublishingStrategy(t, publishingStrategy, func() {
+		publishingStrategy.Spec.DefaultAPIServerIngress.Listening = listening
+	})
+}
+
+// updatePublishingStrategy applies change to the latest publishingStrategy
+func updatePublishingStrategy(t *testing.T, publishingStrategy *cloudingressv1alpha1.PublishingStrategy, change func()) {
+	ctx := context.TODO()
+	err := kclient.Get(ctx, types.NamespacedName{Name: publishingStrategy.Name, Namespace: publishingStrategy.Namespace}, publishingStrategy)
+	if err != nil {
+		t.Fatalf("Couldn't get the PublishingStrategy: %v", err)
+	}
+	change()
+	err = kclient.Update(ctx, publishingStrategy)
+	if err != nil {
+		t.Fatalf("Couldn't update the PublishingStrategy: %v", err)
+	}
+}
+
+// waitForApplicationIngress waits for the IngressController of the
+// ApplicationIngress at index in publishingStrategy to have the listening
+// scope, and on AWS for the load balancer of its router to have the matching
+// scheme
+func waitForApplicationIngress(t *testing.T, publishingStrategy *cloudingressv1alpha1.PublishingStrategy, index int, listening cloudingressv1alpha1.Listening) {
+	ctx := context.TODO()
+	scope, scheme := operatorv1.ExternalLoadBalancer, "internet-facing"
+	if listening == cloudingressv1alpha1.Internal {
+		scope, scheme = operatorv1.InternalLoadBalancer, "internal"
+	}
+	dnsName := publishingStrategy.Spec.ApplicationIngress[index].DNSName
+	err := wait.PollImmediate(pollInterval, pollTimeout, func() (bool, error) {
+		err := kclient.Get(ctx, types.NamespacedName{Name: publishingStrategy.Name, Namespace: publishingStrategy.Namespace}, publishingStrategy)
+		if err != nil {
+			return false, err
+		}
+		for _, status := range publishingStrategy.Status.ApplicationIngress {
+			if status.DNSName != dnsName || status.LoadBalancer == "" {
+				continue
+			}
+			ingressController := &operatorv1.IngressController{}
+			err := kclient.Get(ctx, types.NamespacedName{Name: status.IngressControllerName, Namespace: "openshift-ingress-operator"}, ingressController)
+			if err != nil {
+				return false, err
+			}
+			strategy := ingressController.Status.EndpointPublishingStrategy
+			if strategy == nil || strategy.LoadBalancer == nil || strategy.LoadBalancer.Scope != scope {
+				return false, nil
+			}
+			if platform != configv1.AWSPlatformType {
+				return true, nil
+			}
+			actual, ok := awsLoadBalancerScheme(t, status.LoadBalancer)
+			return ok && actual == scheme, nil
+		}
+		return false, nil
+	})
+	if err != nil {
+		t.Fatalf("The ApplicationIngress %s isn't %s, the PublishingStrategy status is %+v: %v", dnsName, listening, publishingStrategy.Status, err)
+	}
+}
+
+// awsLoadBalancerNamedExists checks if there's a network load balancer named
+// name
+func awsLoadBalancerNamedExists(t *testing.T, name string) bool {
+	output, err := elbv2.New(awsSession(t)).DescribeLoadBalancers(&elbv2.DescribeLoadBalancersInput{
+		Names: []*string{aws.String(name)},
+	})
+	if err != nil {
+		if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == elbv2.ErrCodeLoadBalancerNotFoundException {
+			return false
+		}
+		t.Fatalf("Couldn't describe the load balancer %s: %v", name, err)
+	}
+	return len(output.LoadBalancers) > 0
+}
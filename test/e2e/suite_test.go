@@ -0,0 +1,86 @@
+// +build e2e
+
+// Package e2e runs publish and unpublish scenarios against a real cluster the
+// operator is deployed on, and checks the cloud resources they lead to. See
+// hack/e2e-test.sh to run it
+package e2e
+
+import (
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	configv1 "github.com/openshift/api/config/v1"
+	operatorv1 "github.com/openshift/api/operator/v1"
+	"github.com/openshift/cloud-ingress-operator/config"
+	"github.com/openshift/cloud-ingress-operator/pkg/apis"
+	"github.com/openshift/cloud-ingress-operator/pkg/cloudclient"
+	baseutils "github.com/openshift/cloud-ingress-operator/pkg/utils"
+	machineapi "github.com/openshift/machine-api-operator/pkg/apis/machine/v1beta1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	ctrlconfig "sigs.k8s.io/controller-runtime/pkg/client/config"
+)
+
+const (
+	// pollInterval is how often the tests check for what the operator does
+	pollInterval = 5 * time.Second
+	// pollTimeout is how long the tests wait for what the operator does. Cloud
+	// load balancers and DNS records take minutes
+	pollTimeout = 15 * time.Minute
+)
+
+var (
+	// kclient talks to the cluster's API server
+	kclient client.Client
+	// cloud is a cloud client of the cluster's platform, with the operator's
+	// credentials, to check the cloud resources
+	cloud cloudclient.CloudClient
+	// platform is the cluster's cloud platform
+	platform configv1.PlatformType
+	// disruptive runs the scenarios that can cut the tests off from the
+	// cluster, like making the default API private
+	disruptive bool
+)
+
+func TestMain(m *testing.M) {
+	os.Exit(run(m))
+}
+
+// run connects to the cluster of KUBECONFIG, then runs the tests
+func run(m *testing.M) int {
+	if namespace := os.Getenv("OPERATOR_NAMESPACE"); namespace != "" {
+		config.OperatorNamespace = namespace
+	}
+	disruptive = os.Getenv("E2E_DISRUPTIVE") == "true"
+
+	cfg, err := ctrlconfig.GetConfig()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Couldn't get the cluster's config: %v\n", err)
+		return 1
+	}
+	s := scheme.Scheme
+	for _, addToScheme := range []func(*runtime.Scheme) error{apis.AddToScheme, configv1.AddToScheme, operatorv1.AddToScheme, machineapi.AddToScheme} {
+		if err := addToScheme(s); err != nil {
+			fmt.Fprintf(os.Stderr, "Couldn't register the API types: %v\n", err)
+			return 1
+		}
+	}
+	kclient, err = client.New(cfg, client.Options{Scheme: s})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Couldn't create the client: %v\n", err)
+		return 1
+	}
+
+	platformType, err := baseutils.GetPlatformType(kclient)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Couldn't get the cluster's platform: %v\n", err)
+		return 1
+	}
+	platform = *platformType
+	cloud = cloudclient.GetClientFor(kclient, platform)
+
+	return m.Run()
+}
@@ -0,0 +1,205 @@
+// +build integration
+
+package integration
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/elb"
+	"github.com/aws/aws-sdk-go/service/route53"
+	"github.com/openshift/cloud-ingress-operator/config"
+	cloudingressv1alpha1 "github.com/openshift/cloud-ingress-operator/pkg/apis/cloudingress/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+const (
+	// pollInterval is how often the tests check for what the controllers do
+	pollInterval = time.Second
+	// pollTimeout is how long the tests wait for what the controllers do
+	pollTimeout = 2 * time.Minute
+)
+
+// TestAPISchemeAWS creates an APIScheme and checks the admin API endpoint is
+// published: the Service, its security group and the DNS records of the
+// classic ELB
+func TestAPISchemeAWS(t *testing.T) {
+	ctx := context.TODO()
+	instance := &cloudingressv1alpha1.APIScheme{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      config.AdminAPIName,
+			Namespace: config.OperatorNamespace,
+		},
+		Spec: cloudingressv1alpha1.APISchemeSpec{
+			ManagementAPIServerIngress: cloudingressv1alpha1.ManagementAPIServerIngress{
+				Enabled:           true,
+				DNSName:           config.AdminAPIName,
+				AllowedCIDRBlocks: []string{"10.0.0.0/8"},
+			},
+		},
+	}
+	err := kclient.Create(ctx, instance)
+	if err != nil {
+		t.Fatalf("Couldn't create the APIScheme: %v", err)
+	}
+
+	svc := &corev1.Service{}
+	err = wait.PollImmediate(pollInterval, pollTimeout, func() (bool, error) {
+		err := kclient.Get(ctx, types.NamespacedName{Namespace: "openshift-kube-apiserver", Name: config.AdminAPIName}, svc)
+		if errors.IsNotFound(err) {
+			return false, nil
+		}
+		return err == nil, err
+	})
+	if err != nil {
+		t.Fatalf("The admin API Service wasn't created: %v", err)
+	}
+	groupID := svc.Annotations["service.beta.kubernetes.io/aws-load-balancer-security-groups"]
+	if groupID == "" {
+		t.Fatalf("The admin API Service has no security group: %v", svc.Annotations)
+	}
+	assertSecurityGroupAllows(t, groupID, "10.0.0.0/8")
+
+	err = createLoadBalancerForService(ctx, svc)
+	if err != nil {
+		t.Fatalf("Couldn't create the load balancer of the admin API Service: %v", err)
+	}
+
+	err = wait.PollImmediate(pollInterval, pollTimeout, func() (bool, error) {
+		err := kclient.Get(ctx, types.NamespacedName{Namespace: instance.Namespace, Name: instance.Name}, instance)
+		return err == nil && instance.Status.State == cloudingressv1alpha1.ConditionReady, err
+	})
+	if err != nil {
+		t.Fatalf("The APIScheme isn't ready, its status is %+v: %v", instance.Status, err)
+	}
+	if instance.Status.CloudLoadBalancerDNSName != svc.Status.LoadBalancer.Ingress[0].Hostname {
+		t.Fatalf("Expected the load balancer %s in the status, got %s", svc.Status.LoadBalancer.Ingress[0].Hostname, instance.Status.CloudLoadBalancerDNSName)
+	}
+	for _, zoneName := range []string{clusterBaseDomain, "example.com"} {
+		assertAliasRecord(t, zoneName, config.AdminAPIName+"."+clusterBaseDomain, svc.Status.LoadBalancer.Ingress[0].Hostname)
+	}
+}
+
+// createLoadBalancerForService stands in for the cloud provider, which doesn't
+// run with envtest: it creates the classic ELB of svc, named after its UID,
+// registers the cluster's instances with it and records it in the status of
+// svc
+func createLoadBalancerForService(ctx context.Context, svc *corev1.Service) error {
+	elbName := strings.ReplaceAll("a"+string(svc.UID), "-", "")
+	if len(elbName) > 32 {
+		elbName = elbName[0:32]
+	}
+	ec2Client := ec2.New(awsSession)
+	ownedFilter := []*ec2.Filter{
+		{
+			Name:   aws.String("tag:kubernetes.io/cluster/" + clusterName),
+			Values: []*string{aws.String("owned")},
+		},
+	}
+	subnets, err := ec2Client.DescribeSubnets(&ec2.DescribeSubnetsInput{Filters: ownedFilter})
+	if err != nil {
+		return err
+	}
+	reservations, err := ec2Client.DescribeInstances(&ec2.DescribeInstancesInput{Filters: ownedFilter})
+	if err != nil {
+		return err
+	}
+	instances := []*elb.Instance{}
+	for _, reservation := range reservations.Reservations {
+		for _, instance := range reservation.Instances {
+			instances = append(instances, &elb.Instance{InstanceId: instance.InstanceId})
+		}
+	}
+	subnetIDs := []*string{}
+	for _, subnet := range subnets.Subnets {
+		subnetIDs = append(subnetIDs, subnet.SubnetId)
+	}
+	listeners := []*elb.Listener{}
+	for _, port := range svc.Spec.Ports {
+		listeners = append(listeners, &elb.Listener{
+			InstancePort:     aws.Int64(int64(port.NodePort)),
+			InstanceProtocol: aws.String("TCP"),
+			LoadBalancerPort: aws.Int64(int64(port.Port)),
+			Protocol:         aws.String("TCP"),
+		})
+	}
+	elbClient := elb.New(awsSession)
+	output, err := elbClient.CreateLoadBalancer(&elb.CreateLoadBalancerInput{
+		Listeners:        listeners,
+		LoadBalancerName: aws.String(elbName),
+		SecurityGroups:   aws.StringSlice(strings.Split(svc.Annotations["service.beta.kubernetes.io/aws-load-balancer-security-groups"], ",")),
+		Subnets:          subnetIDs,
+	})
+	if err != nil {
+		return err
+	}
+	_, err = elbClient.RegisterInstancesWithLoadBalancer(&elb.RegisterInstancesWithLoadBalancerInput{
+		Instances:        instances,
+		LoadBalancerName: aws.String(elbName),
+	})
+	if err != nil {
+		return err
+	}
+	svc.Status.LoadBalancer.Ingress = []corev1.LoadBalancerIngress{
+		{Hostname: aws.StringValue(output.DNSName)},
+	}
+	return kclient.Status().Update(ctx, svc)
+}
+
+// assertSecurityGroupAllows checks the security group groupID lets cidr in on
+// the admin API port
+func assertSecurityGroupAllows(t *testing.T, groupID, cidr string) {
+	output, err := ec2.New(awsSession).DescribeSecurityGroups(&ec2.DescribeSecurityGroupsInput{
+		GroupIds: []*string{aws.String(groupID)},
+	})
+	if err != nil {
+		t.Fatalf("Couldn't describe the security group %s: %v", groupID, err)
+	}
+	for _, permission := range output.SecurityGroups[0].IpPermissions {
+		if aws.Int64Value(permission.FromPort) != config.AdminAPIListenerPort {
+			continue
+		}
+		for _, ipRange := range permission.IpRanges {
+			if aws.StringValue(ipRange.CidrIp) == cidr {
+				return
+			}
+		}
+	}
+	t.Fatalf("The security group %s doesn't allow %s: %+v", groupID, cidr, output.SecurityGroups[0].IpPermissions)
+}
+
+// assertAliasRecord checks the zone zoneName has an A record recordName
+// aliasing dnsName
+func assertAliasRecord(t *testing.T, zoneName, recordName, dnsName string) {
+	route53Client := route53.New(awsSession)
+	zones, err := route53Client.ListHostedZonesByName(&route53.ListHostedZonesByNameInput{
+		DNSName: aws.String(zoneName + "."),
+	})
+	if err != nil || len(zones.HostedZones) == 0 {
+		t.Fatalf("Couldn't find the zone %s: %v", zoneName, err)
+	}
+	records, err := route53Client.ListResourceRecordSets(&route53.ListResourceRecordSetsInput{
+		HostedZoneId:    zones.HostedZones[0].Id,
+		StartRecordName: aws.String(recordName + "."),
+		StartRecordType: aws.String(route53.RRTypeA),
+		MaxItems:        aws.String("1"),
+	})
+	if err != nil {
+		t.Fatalf("Couldn't list the records of the zone %s: %v", zoneName, err)
+	}
+	for _, record := range records.ResourceRecordSets {
+		if aws.StringValue(record.Name) == recordName+"." && record.AliasTarget != nil &&
+			strings.TrimSuffix(aws.StringValue(record.AliasTarget.DNSName), ".") == dnsName {
+			return
+		}
+	}
+	t.Fatalf("The zone %s has no A record %s for %s: %+v", zoneName, recordName, dnsName, records.ResourceRecordSets)
+}
@@ -0,0 +1,164 @@
+// +build integration
+
+package integration
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/route53"
+	configv1 "github.com/openshift/api/config/v1"
+	"github.com/openshift/cloud-ingress-operator/config"
+	cloudingressv1alpha1 "github.com/openshift/cloud-ingress-operator/pkg/apis/cloudingress/v1alpha1"
+	machineapi "github.com/openshift/machine-api-operator/pkg/apis/machine/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// clusterName is the infrastructure name of the test cluster
+	clusterName = "itest-x7k2p"
+	// clusterBaseDomain is the base domain of the test cluster. Its records go
+	// in a zone of that name, and in one for its parent domain
+	clusterBaseDomain = "itest.example.com"
+	// zone is the availability zone of the test cluster's master
+	zone = region + "a"
+)
+
+// createClusterObjects seeds localstack with the cloud resources of an AWS
+// cluster, and the API server with the objects describing them: the
+// Infrastructure, a master Machine, the operator's credentials and a
+// CloudIngressConfig pointing every AWS service at localstack
+func createClusterObjects(ctx context.Context) error {
+	instanceID, err := createAWSCluster()
+	if err != nil {
+		return err
+	}
+
+	for _, name := range []string{config.OperatorNamespace, "openshift-kube-apiserver", "openshift-machine-api"} {
+		err = kclient.Create(ctx, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name}})
+		if err != nil {
+			return err
+		}
+	}
+
+	endpoints := []cloudingressv1alpha1.AWSServiceEndpoint{}
+	for _, service := range []string{"ec2", "elasticloadbalancing", "monitoring", "route53"} {
+		endpoints = append(endpoints, cloudingressv1alpha1.AWSServiceEndpoint{Name: service, URL: localstackEndpoint})
+	}
+	objs := []client.Object{
+		&configv1.Infrastructure{
+			ObjectMeta: metav1.ObjectMeta{Name: "cluster"},
+			Status: configv1.InfrastructureStatus{
+				InfrastructureName:   clusterName,
+				APIServerURL:         "https://api." + clusterBaseDomain + ":6443",
+				APIServerInternalURL: "https://api-int." + clusterBaseDomain + ":6443",
+				Platform:             configv1.AWSPlatformType,
+				PlatformStatus: &configv1.PlatformStatus{
+					Type: configv1.AWSPlatformType,
+					AWS:  &configv1.AWSPlatformStatus{Region: region},
+				},
+			},
+		},
+		&machineapi.Machine{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      clusterName + "-master-0",
+				Namespace: "openshift-machine-api",
+				Labels:    map[string]string{"machine.openshift.io/cluster-api-machine-role": "master"},
+			},
+			Spec: machineapi.MachineSpec{
+				ProviderID: pointer.StringPtr(fmt.Sprintf("aws:///%s/%s", zone, instanceID)),
+			},
+		},
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      config.AWSSecretName,
+				Namespace: config.OperatorNamespace,
+			},
+			Data: map[string][]byte{
+				"aws_access_key_id":     []byte("test"),
+				"aws_secret_access_key": []byte("test"),
+			},
+		},
+		&cloudingressv1alpha1.CloudIngressConfig{
+			ObjectMeta: metav1.ObjectMeta{Name: config.CloudIngressConfigName},
+			Spec: cloudingressv1alpha1.CloudIngressConfigSpec{
+				AWSRegion:           region,
+				AWSServiceEndpoints: endpoints,
+			},
+		},
+	}
+	for _, obj := range objs {
+		err = kclient.Create(ctx, obj)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// createAWSCluster creates the cluster's VPC, with a public subnet and a master
+// instance, and its DNS zones. Returns the ID of the master instance
+func createAWSCluster() (string, error) {
+	ec2Client := ec2.New(awsSession)
+	ownedTag := &ec2.Tag{
+		Key:   aws.String("kubernetes.io/cluster/" + clusterName),
+		Value: aws.String("owned"),
+	}
+
+	vpc, err := ec2Client.CreateVpc(&ec2.CreateVpcInput{CidrBlock: aws.String("10.0.0.0/16")})
+	if err != nil {
+		return "", err
+	}
+	subnet, err := ec2Client.CreateSubnet(&ec2.CreateSubnetInput{
+		AvailabilityZone: aws.String(zone),
+		CidrBlock:        aws.String("10.0.0.0/20"),
+		VpcId:            vpc.Vpc.VpcId,
+	})
+	if err != nil {
+		return "", err
+	}
+	_, err = ec2Client.CreateTags(&ec2.CreateTagsInput{
+		Resources: []*string{subnet.Subnet.SubnetId},
+		Tags: []*ec2.Tag{
+			ownedTag,
+			{Key: aws.String("Name"), Value: aws.String(clusterName + "-public-" + zone)},
+			{Key: aws.String("kubernetes.io/role/elb"), Value: aws.String("1")},
+		},
+	})
+	if err != nil {
+		return "", err
+	}
+	reservation, err := ec2Client.RunInstances(&ec2.RunInstancesInput{
+		ImageId:      aws.String("ami-00000000"),
+		InstanceType: aws.String(ec2.InstanceTypeM5Xlarge),
+		MaxCount:     aws.Int64(1),
+		MinCount:     aws.Int64(1),
+		SubnetId:     subnet.Subnet.SubnetId,
+		TagSpecifications: []*ec2.TagSpecification{
+			{
+				ResourceType: aws.String(ec2.ResourceTypeInstance),
+				Tags:         []*ec2.Tag{ownedTag},
+			},
+		},
+	})
+	if err != nil {
+		return "", err
+	}
+
+	route53Client := route53.New(awsSession)
+	for _, zoneName := range []string{clusterBaseDomain, "example.com"} {
+		_, err = route53Client.CreateHostedZone(&route53.CreateHostedZoneInput{
+			CallerReference: aws.String(clusterName + "-" + zoneName),
+			Name:            aws.String(zoneName),
+		})
+		if err != nil {
+			return "", err
+		}
+	}
+	return aws.StringValue(reservation.Instances[0].InstanceId), nil
+}
@@ -0,0 +1,119 @@
+// +build integration
+
+// Package integration runs the operator's controllers against a real API
+// server, from envtest, and a localstack container standing in for AWS. See
+// hack/integration-test.sh to run it
+package integration
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	configv1 "github.com/openshift/api/config/v1"
+	"github.com/openshift/cloud-ingress-operator/pkg/apis"
+	"github.com/openshift/cloud-ingress-operator/pkg/controller/apischeme"
+	machineapi "github.com/openshift/machine-api-operator/pkg/apis/machine/v1beta1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/envtest"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+)
+
+const (
+	// defaultLocalstackEndpoint is where localstack listens by default
+	defaultLocalstackEndpoint = "http://localhost:4566"
+	// region is the AWS region of the test cluster
+	region = "us-east-1"
+)
+
+var (
+	// kclient talks to the envtest API server, bypassing the manager's cache
+	kclient client.Client
+	// awsSession talks to localstack
+	awsSession *session.Session
+	// localstackEndpoint is the URL of every AWS service in localstack
+	localstackEndpoint string
+)
+
+func TestMain(m *testing.M) {
+	os.Exit(run(m))
+}
+
+// run starts the API server and the controllers, then runs the tests
+func run(m *testing.M) int {
+	localstackEndpoint = os.Getenv("LOCALSTACK_ENDPOINT")
+	if localstackEndpoint == "" {
+		localstackEndpoint = defaultLocalstackEndpoint
+	}
+	// localstack takes any credentials
+	awsSession = session.Must(session.NewSession(&aws.Config{
+		Region:      aws.String(region),
+		Endpoint:    aws.String(localstackEndpoint),
+		Credentials: credentials.NewStaticCredentials("test", "test", ""),
+	}))
+
+	testEnv := &envtest.Environment{
+		CRDDirectoryPaths: []string{
+			filepath.Join("..", "..", "deploy", "crds"),
+			filepath.Join("testdata", "crds"),
+		},
+		ErrorIfCRDPathMissing: true,
+	}
+	cfg, err := testEnv.Start()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Couldn't start the API server: %v\n", err)
+		return 1
+	}
+	defer func() {
+		if err := testEnv.Stop(); err != nil {
+			fmt.Fprintf(os.Stderr, "Couldn't stop the API server: %v\n", err)
+		}
+	}()
+
+	s := scheme.Scheme
+	for _, addToScheme := range []func(*runtime.Scheme) error{apis.AddToScheme, configv1.AddToScheme, machineapi.AddToScheme} {
+		if err := addToScheme(s); err != nil {
+			fmt.Fprintf(os.Stderr, "Couldn't register the API types: %v\n", err)
+			return 1
+		}
+	}
+	kclient, err = client.New(cfg, client.Options{Scheme: s})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Couldn't create the client: %v\n", err)
+		return 1
+	}
+	// The cluster objects the controllers read when they start go in first
+	if err := createClusterObjects(context.TODO()); err != nil {
+		fmt.Fprintf(os.Stderr, "Couldn't create the cluster objects: %v\n", err)
+		return 1
+	}
+
+	mgr, err := manager.New(cfg, manager.Options{
+		Scheme:             s,
+		MetricsBindAddress: "0",
+	})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Couldn't create the manager: %v\n", err)
+		return 1
+	}
+	if err := apischeme.Add(mgr); err != nil {
+		fmt.Fprintf(os.Stderr, "Couldn't add the APIScheme controller: %v\n", err)
+		return 1
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		if err := mgr.Start(ctx); err != nil {
+			fmt.Fprintf(os.Stderr, "Manager exited non-zero: %v\n", err)
+		}
+	}()
+
+	return m.Run()
+}